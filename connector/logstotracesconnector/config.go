@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logstotracesconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+// Config defines the configuration options for the logs to traces connector.
+type Config struct {
+	// StartConditions is a list of OTTL conditions. A log record matching any of them opens a span.
+	StartConditions []string `mapstructure:"start_conditions"`
+	// EndConditions is a list of OTTL conditions. A log record matching any of them closes the
+	// pending span with the same correlation key.
+	EndConditions []string `mapstructure:"end_conditions"`
+	// CorrelationKey is an OTTL value expression evaluated on start and end events. Events that
+	// resolve to the same key are paired into one span.
+	CorrelationKey string `mapstructure:"correlation_key"`
+	// ParentKey is an optional OTTL value expression evaluated on start events. When it resolves to
+	// the correlation key of a pending span, the new span becomes a child of that span.
+	ParentKey string `mapstructure:"parent_key"`
+	// Span defines how the span is built from the matching start and end events.
+	Span SpanConfig `mapstructure:"span"`
+	// Timeout is the maximum time a start event waits for its end event. Unmatched starts are
+	// emitted as error spans once the timeout expires. Default 5m.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxPending is the maximum number of start events waiting for their end event. When the limit is
+	// reached the oldest pending start is emitted as an error span. Default 10000.
+	MaxPending int `mapstructure:"max_pending"`
+	// ErrorMode determines how errors returned from OTTL evaluation are handled.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// SpanConfig defines how span fields are derived from the start and end events.
+type SpanConfig struct {
+	// Name is an OTTL value expression evaluated on the start event.
+	Name string `mapstructure:"name"`
+	// Attributes are evaluated on both events, values from the end event take precedence.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+	// ErrorConditions is a list of OTTL conditions evaluated on the end event. The span status is
+	// set to Error if any of them match, and to Ok otherwise.
+	ErrorConditions []string `mapstructure:"error_conditions"`
+	// StatusMessage is an optional OTTL value expression evaluated on the end event of failed spans.
+	StatusMessage string `mapstructure:"status_message"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AttributeConfig sets a span attribute from an OTTL value expression.
+type AttributeConfig struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ confmap.Validator = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid.
+func (c *Config) Validate() (combinedErrors error) {
+	if len(c.StartConditions) == 0 {
+		combinedErrors = errors.Join(combinedErrors, errors.New("start_conditions must not be empty"))
+	}
+	if len(c.EndConditions) == 0 {
+		combinedErrors = errors.Join(combinedErrors, errors.New("end_conditions must not be empty"))
+	}
+	if c.CorrelationKey == "" {
+		combinedErrors = errors.Join(combinedErrors, errors.New("correlation_key must be specified"))
+	}
+	if c.Span.Name == "" {
+		combinedErrors = errors.Join(combinedErrors, errors.New("span.name must be specified"))
+	}
+	if c.Timeout <= 0 {
+		combinedErrors = errors.Join(combinedErrors, errors.New("timeout must be positive"))
+	}
+	if c.MaxPending <= 0 {
+		combinedErrors = errors.Join(combinedErrors, errors.New("max_pending must be positive"))
+	}
+
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	for _, field := range []struct {
+		name       string
+		conditions []string
+	}{
+		{"start_conditions", c.StartConditions},
+		{"end_conditions", c.EndConditions},
+		{"span.error_conditions", c.Span.ErrorConditions},
+	} {
+		if len(field.conditions) == 0 {
+			continue
+		}
+		if _, err := filterottl.NewBoolExprForLogWithPathContextNames(field.conditions, filterottl.StandardLogFuncs(), c.ErrorMode, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("%s: %w", field.name, err))
+		}
+	}
+
+	parser, err := newParser(set)
+	if err != nil {
+		return errors.Join(combinedErrors, err)
+	}
+	expressions := [][2]string{
+		{"correlation_key", c.CorrelationKey},
+		{"parent_key", c.ParentKey},
+		{"span.name", c.Span.Name},
+		{"span.status_message", c.Span.StatusMessage},
+	}
+	for i, attr := range c.Span.Attributes {
+		if attr.Key == "" {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("span.attributes[%d]: key must be specified", i))
+		}
+		if attr.Value == "" {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("span.attributes[%d]: value must be specified", i))
+		}
+		expressions = append(expressions, [2]string{fmt.Sprintf("span.attributes[%d]", i), attr.Value})
+	}
+	for _, expr := range expressions {
+		if expr[1] == "" {
+			continue
+		}
+		if _, err := parser.ParseValueExpression(expr[1]); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("%s: %w", expr[0], err))
+		}
+	}
+	return combinedErrors
+}
+
+func newParser(set component.TelemetrySettings) (ottl.Parser[*ottllog.TransformContext], error) {
+	return ottllog.NewParser(filterottl.StandardLogFuncs(), set, ottllog.EnablePathContextNames())
+}
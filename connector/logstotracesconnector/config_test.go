@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logstotracesconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		errContains []string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			errContains: []string{
+				"start_conditions must not be empty",
+				"end_conditions must not be empty",
+				"correlation_key must be specified",
+				"span.name must be specified",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				StartConditions: []string{`IsMatch(log.body, "^job .* started")`},
+				EndConditions:   []string{`IsMatch(log.body, "^job .* finished")`},
+				CorrelationKey:  `log.attributes["job.id"]`,
+				ParentKey:       `log.attributes["parent.job.id"]`,
+				Span: SpanConfig{
+					Name: `log.attributes["job.name"]`,
+					Attributes: []AttributeConfig{
+						{Key: "job.id", Value: `log.attributes["job.id"]`},
+						{Key: "job.exit_code", Value: `log.attributes["exit_code"]`},
+					},
+					ErrorConditions: []string{`log.attributes["exit_code"] != 0`},
+					StatusMessage:   `log.attributes["error"]`,
+				},
+				Timeout:    time.Hour,
+				MaxPending: 500,
+				ErrorMode:  ottl.IgnoreError,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "missing_keys"),
+			errContains: []string{
+				"start_conditions must not be empty",
+				"end_conditions must not be empty",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_ottl"),
+			errContains: []string{
+				"start_conditions:",
+				"correlation_key:",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_limits"),
+			errContains: []string{
+				"timeout must be positive",
+				"max_pending must be positive",
+				"span.attributes[0]: key must be specified",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = cfg.(*Config).Validate()
+			if len(tt.errContains) > 0 {
+				for _, msg := range tt.errContains {
+					assert.ErrorContains(t, err, msg)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logstotracesconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector"
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+const (
+	timeoutStatusMessage  = "end event not received before timeout"
+	evictedStatusMessage  = "evicted: too many pending spans"
+	replacedStatusMessage = "replaced by a new start event with the same correlation key"
+
+	maxExpirationInterval = time.Second
+)
+
+type attributeExpr struct {
+	key   string
+	value *ottl.ValueExpression[*ottllog.TransformContext]
+}
+
+type logsToTracesConnector struct {
+	config         *Config
+	logger         *zap.Logger
+	tracesConsumer consumer.Traces
+
+	startCondition  *ottl.ConditionSequence[*ottllog.TransformContext]
+	endCondition    *ottl.ConditionSequence[*ottllog.TransformContext]
+	errorCondition  *ottl.ConditionSequence[*ottllog.TransformContext]
+	correlationKey  *ottl.ValueExpression[*ottllog.TransformContext]
+	parentKey       *ottl.ValueExpression[*ottllog.TransformContext]
+	spanName        *ottl.ValueExpression[*ottllog.TransformContext]
+	statusMessage   *ottl.ValueExpression[*ottllog.TransformContext]
+	attributeValues []attributeExpr
+
+	mu      sync.Mutex
+	pending *pendingStore
+	now     func() time.Time
+
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+	wg           sync.WaitGroup
+}
+
+func (c *logsToTracesConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logsToTracesConnector) Start(context.Context, component.Host) error {
+	interval := min(c.config.Timeout, maxExpirationInterval)
+	c.wg.Add(1)
+	go c.expirationLoop(interval)
+	return nil
+}
+
+func (c *logsToTracesConnector) Shutdown(ctx context.Context) error {
+	c.shutdownOnce.Do(func() { close(c.shutdownCh) })
+	c.wg.Wait()
+
+	// Flush the remaining pending spans so that no start event is silently lost.
+	c.mu.Lock()
+	remaining := c.pending.drain()
+	c.mu.Unlock()
+	if len(remaining) == 0 {
+		return nil
+	}
+	td := ptrace.NewTraces()
+	now := pcommon.NewTimestampFromTime(c.now())
+	for _, ps := range remaining {
+		appendFailedSpan(td, ps, now, timeoutStatusMessage)
+	}
+	return c.tracesConsumer.ConsumeTraces(ctx, td)
+}
+
+func (c *logsToTracesConnector) expirationLoop(interval time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.expire(context.Background()); err != nil {
+				c.logger.Error("failed to export expired spans", zap.Error(err))
+			}
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// expire emits all pending spans whose end event was not received in time as error spans.
+func (c *logsToTracesConnector) expire(ctx context.Context) error {
+	now := c.now()
+	c.mu.Lock()
+	expired := c.pending.expire(now)
+	c.mu.Unlock()
+	if len(expired) == 0 {
+		return nil
+	}
+
+	td := ptrace.NewTraces()
+	for _, ps := range expired {
+		appendFailedSpan(td, ps, pcommon.NewTimestampFromTime(now), timeoutStatusMessage)
+	}
+	return c.tracesConsumer.ConsumeTraces(ctx, td)
+}
+
+// ConsumeLogs emits the spans completed by the log records. The records which fail to be evaluated are
+// reported with a permanent error, after the spans completed by the other records have been emitted,
+// as the pending spans have already been updated by the batch. When the spans fail to be emitted, the
+// error of the next consumer is returned instead, and the failed records are logged.
+func (c *logsToTracesConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var errs error
+	td := ptrace.NewTraces()
+
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLog := ld.ResourceLogs().At(i)
+		for j := 0; j < resourceLog.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLog.ScopeLogs().At(j)
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logRecord := scopeLogs.LogRecords().At(k)
+				tCtx := ottllog.NewTransformContextPtr(resourceLog, scopeLogs, logRecord)
+				errs = errors.Join(errs, c.consumeLogRecord(ctx, tCtx, td))
+				tCtx.Close()
+			}
+		}
+	}
+	if td.SpanCount() > 0 {
+		if err := c.tracesConsumer.ConsumeTraces(ctx, td); err != nil {
+			// The error of the next consumer is returned as is, so that it can be retried.
+			if errs != nil {
+				c.logger.Warn("Failed to evaluate log records", zap.Error(errs))
+			}
+			return err
+		}
+	}
+	if errs != nil {
+		return consumererror.NewPermanent(errs)
+	}
+	return nil
+}
+
+func (c *logsToTracesConnector) consumeLogRecord(ctx context.Context, tCtx *ottllog.TransformContext, td ptrace.Traces) error {
+	isStart, err := c.startCondition.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	if isStart {
+		return c.handleStart(ctx, tCtx, td)
+	}
+
+	isEnd, err := c.endCondition.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	if isEnd {
+		return c.handleEnd(ctx, tCtx, td)
+	}
+	return nil
+}
+
+func (c *logsToTracesConnector) handleStart(ctx context.Context, tCtx *ottllog.TransformContext, td ptrace.Traces) error {
+	key, ok, err := c.evalString(ctx, c.correlationKey, tCtx)
+	if err != nil || !ok {
+		return err
+	}
+	name, _, err := c.evalString(ctx, c.spanName, tCtx)
+	if err != nil {
+		return err
+	}
+	var parentKey string
+	if c.parentKey != nil {
+		if parentKey, _, err = c.evalString(ctx, c.parentKey, tCtx); err != nil {
+			return err
+		}
+	}
+
+	logRecord := tCtx.GetLogRecord()
+	ps := &pendingSpan{
+		key:        key,
+		name:       name,
+		spanID:     newSpanID(),
+		start:      recordTimestamp(logRecord),
+		resource:   pcommon.NewResource(),
+		attributes: pcommon.NewMap(),
+		expiresAt:  c.now().Add(c.config.Timeout),
+	}
+	tCtx.GetResource().CopyTo(ps.resource)
+	if err = c.setAttributes(ctx, tCtx, ps.attributes); err != nil {
+		return err
+	}
+
+	// A start event emitted within an existing trace makes the new span a child of the log's span.
+	if !logRecord.TraceID().IsEmpty() {
+		ps.traceID = logRecord.TraceID()
+		ps.parentSpanID = logRecord.SpanID()
+	} else {
+		ps.traceID = newTraceID()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if parentKey != "" {
+		if parent, found := c.pending.get(parentKey); found {
+			ps.traceID = parent.traceID
+			ps.parentSpanID = parent.spanID
+		}
+	}
+	replaced, evicted := c.pending.put(ps)
+	now := pcommon.NewTimestampFromTime(c.now())
+	if replaced != nil {
+		appendFailedSpan(td, replaced, now, replacedStatusMessage)
+	}
+	if evicted != nil {
+		c.logger.Debug("Evicting pending span, max_pending reached", zap.String("correlation_key", evicted.key))
+		appendFailedSpan(td, evicted, now, evictedStatusMessage)
+	}
+	return nil
+}
+
+func (c *logsToTracesConnector) handleEnd(ctx context.Context, tCtx *ottllog.TransformContext, td ptrace.Traces) error {
+	key, ok, err := c.evalString(ctx, c.correlationKey, tCtx)
+	if err != nil || !ok {
+		return err
+	}
+
+	c.mu.Lock()
+	ps, found := c.pending.remove(key)
+	c.mu.Unlock()
+	if !found {
+		c.logger.Debug("Dropping end event without a pending start event", zap.String("correlation_key", key))
+		return nil
+	}
+
+	// Attributes from the end event take precedence over those captured from the start event.
+	// The span is removed from the pending spans, so it is emitted even if the end event fails to be evaluated.
+	attrErr := c.setAttributes(ctx, tCtx, ps.attributes)
+	span := appendSpan(td, ps, recordTimestamp(tCtx.GetLogRecord()))
+	return errors.Join(attrErr, c.setStatus(ctx, tCtx, span))
+}
+
+// setStatus sets the status of a span ended by the end event of tCtx.
+func (c *logsToTracesConnector) setStatus(ctx context.Context, tCtx *ottllog.TransformContext, span ptrace.Span) error {
+	if c.errorCondition == nil {
+		span.Status().SetCode(ptrace.StatusCodeOk)
+		return nil
+	}
+	isError, err := c.errorCondition.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	if !isError {
+		span.Status().SetCode(ptrace.StatusCodeOk)
+		return nil
+	}
+	span.Status().SetCode(ptrace.StatusCodeError)
+	if c.statusMessage != nil {
+		msg, _, err := c.evalString(ctx, c.statusMessage, tCtx)
+		if err != nil {
+			return err
+		}
+		span.Status().SetMessage(msg)
+	}
+	return nil
+}
+
+func (c *logsToTracesConnector) setAttributes(ctx context.Context, tCtx *ottllog.TransformContext, attrs pcommon.Map) error {
+	for _, attr := range c.attributeValues {
+		val, err := attr.value.Eval(ctx, tCtx)
+		if err != nil {
+			if c.config.ErrorMode == ottl.PropagateError {
+				return err
+			}
+			c.logger.Debug("Failed to evaluate span attribute", zap.String("key", attr.key), zap.Error(err))
+			continue
+		}
+		if val == nil {
+			continue
+		}
+		if err = putValue(attrs, attr.key, val); err != nil {
+			return fmt.Errorf("failed to set span attribute %q: %w", attr.key, err)
+		}
+	}
+	return nil
+}
+
+// evalString evaluates expr and converts the result to a string. It reports false if the expression
+// resolved to nil or to an empty string.
+func (c *logsToTracesConnector) evalString(ctx context.Context, expr *ottl.ValueExpression[*ottllog.TransformContext], tCtx *ottllog.TransformContext) (string, bool, error) {
+	val, err := expr.Eval(ctx, tCtx)
+	if err != nil {
+		if c.config.ErrorMode == ottl.PropagateError {
+			return "", false, err
+		}
+		c.logger.Debug("Failed to evaluate expression", zap.Stringer("expression", expr), zap.Error(err))
+		return "", false, nil
+	}
+	if val == nil {
+		return "", false, nil
+	}
+	if str, ok := val.(string); ok {
+		return str, str != "", nil
+	}
+	m := pcommon.NewMap()
+	if err = putValue(m, "", val); err != nil {
+		return "", false, err
+	}
+	v, _ := m.Get("")
+	s := v.AsString()
+	return s, s != "", nil
+}
+
+func putValue(m pcommon.Map, key string, val any) error {
+	switch typed := val.(type) {
+	case pcommon.Value:
+		typed.CopyTo(m.PutEmpty(key))
+	case pcommon.Map:
+		typed.CopyTo(m.PutEmptyMap(key))
+	case pcommon.Slice:
+		typed.CopyTo(m.PutEmptySlice(key))
+	case pcommon.ByteSlice:
+		typed.CopyTo(m.PutEmptyBytes(key))
+	default:
+		return m.PutEmpty(key).FromRaw(typed)
+	}
+	return nil
+}
+
+// appendSpan appends a span built from ps and ending at end to td.
+func appendSpan(td ptrace.Traces, ps *pendingSpan, end pcommon.Timestamp) ptrace.Span {
+	rs := td.ResourceSpans().AppendEmpty()
+	ps.resource.CopyTo(rs.Resource())
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(metadata.ScopeName)
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(ps.traceID)
+	span.SetSpanID(ps.spanID)
+	span.SetParentSpanID(ps.parentSpanID)
+	span.SetName(ps.name)
+	span.SetKind(ptrace.SpanKindInternal)
+	span.SetStartTimestamp(ps.start)
+	if end < ps.start {
+		end = ps.start
+	}
+	span.SetEndTimestamp(end)
+	ps.attributes.CopyTo(span.Attributes())
+	return span
+}
+
+// appendFailedSpan appends an error span for a start event that never got a matching end event.
+func appendFailedSpan(td ptrace.Traces, ps *pendingSpan, end pcommon.Timestamp, msg string) {
+	span := appendSpan(td, ps, end)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage(msg)
+}
+
+func recordTimestamp(lr plog.LogRecord) pcommon.Timestamp {
+	if lr.Timestamp() != 0 {
+		return lr.Timestamp()
+	}
+	return lr.ObservedTimestamp()
+}
+
+func newTraceID() pcommon.TraceID {
+	var tid pcommon.TraceID
+	_, _ = rand.Read(tid[:])
+	return tid
+}
+
+func newSpanID() pcommon.SpanID {
+	var sid pcommon.SpanID
+	_, _ = rand.Read(sid[:])
+	return sid
+}
@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logstotracesconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector/internal/metadata"
+)
+
+var baseTime = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+type testEvent struct {
+	body   string
+	offset time.Duration
+	attrs  map[string]any
+}
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StartConditions = []string{`IsMatch(log.body, "started")`}
+	cfg.EndConditions = []string{`IsMatch(log.body, "finished")`}
+	cfg.CorrelationKey = `log.attributes["job.id"]`
+	cfg.ParentKey = `log.attributes["parent.id"]`
+	cfg.Span = SpanConfig{
+		Name: `log.attributes["job.name"]`,
+		Attributes: []AttributeConfig{
+			{Key: "job.id", Value: `log.attributes["job.id"]`},
+			{Key: "exit_code", Value: `log.attributes["exit_code"]`},
+		},
+		ErrorConditions: []string{`log.attributes["exit_code"] != nil and log.attributes["exit_code"] != 0`},
+		StatusMessage:   `Concat(["exit code", log.attributes["exit_code"]], " ")`,
+	}
+	return cfg
+}
+
+func newTestConnector(t *testing.T, cfg *Config) (*logsToTracesConnector, *consumertest.TracesSink) {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	sink := &consumertest.TracesSink{}
+	conn, err := NewFactory().CreateLogsToTraces(t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	return conn.(*logsToTracesConnector), sink
+}
+
+func newLogs(events ...testEvent) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "batch")
+	sl := rl.ScopeLogs().AppendEmpty()
+	for _, e := range events {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStr(e.body)
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(baseTime.Add(e.offset)))
+		_ = lr.Attributes().FromRaw(e.attrs)
+	}
+	return ld
+}
+
+func allSpans(sink *consumertest.TracesSink) []ptrace.Span {
+	var spans []ptrace.Span
+	for _, td := range sink.AllTraces() {
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			for j := 0; j < rs.ScopeSpans().Len(); j++ {
+				ss := rs.ScopeSpans().At(j)
+				for k := 0; k < ss.Spans().Len(); k++ {
+					spans = append(spans, ss.Spans().At(k))
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func TestConsumeLogsPairsStartAndEnd(t *testing.T) {
+	conn, sink := newTestConnector(t, testConfig())
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job nightly started", attrs: map[string]any{"job.id": "42", "job.name": "nightly"}},
+		testEvent{body: "unrelated line", offset: time.Second},
+	)))
+	assert.Empty(t, sink.AllTraces())
+	assert.Equal(t, 1, conn.pending.len())
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job nightly finished", offset: 90 * time.Second, attrs: map[string]any{"job.id": "42", "exit_code": int64(0)}},
+	)))
+	assert.Equal(t, 0, conn.pending.len())
+
+	require.Len(t, sink.AllTraces(), 1)
+	rs := sink.AllTraces()[0].ResourceSpans().At(0)
+	serviceName, ok := rs.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "batch", serviceName.Str())
+	assert.Equal(t, metadata.ScopeName, rs.ScopeSpans().At(0).Scope().Name())
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "nightly", span.Name())
+	assert.False(t, span.TraceID().IsEmpty())
+	assert.False(t, span.SpanID().IsEmpty())
+	assert.True(t, span.ParentSpanID().IsEmpty())
+	assert.Equal(t, pcommon.NewTimestampFromTime(baseTime), span.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(baseTime.Add(90*time.Second)), span.EndTimestamp())
+	assert.Equal(t, ptrace.StatusCodeOk, span.Status().Code())
+	assert.Equal(t, map[string]any{"job.id": "42", "exit_code": int64(0)}, span.Attributes().AsRaw())
+}
+
+func TestConsumeLogsErrorStatus(t *testing.T) {
+	conn, sink := newTestConnector(t, testConfig())
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job import started", attrs: map[string]any{"job.id": "7", "job.name": "import"}},
+		testEvent{body: "job import finished", offset: time.Minute, attrs: map[string]any{"job.id": "7", "exit_code": int64(3)}},
+	)))
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 1)
+	assert.Equal(t, ptrace.StatusCodeError, spans[0].Status().Code())
+	assert.Equal(t, "exit code 3", spans[0].Status().Message())
+}
+
+func TestConsumeLogsNestedSpans(t *testing.T) {
+	conn, sink := newTestConnector(t, testConfig())
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job etl started", attrs: map[string]any{"job.id": "parent", "job.name": "etl"}},
+		testEvent{body: "job extract started", offset: time.Second, attrs: map[string]any{"job.id": "child", "job.name": "extract", "parent.id": "parent"}},
+		testEvent{body: "job extract finished", offset: 2 * time.Second, attrs: map[string]any{"job.id": "child"}},
+		testEvent{body: "job etl finished", offset: 3 * time.Second, attrs: map[string]any{"job.id": "parent"}},
+	)))
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 2)
+	child, parent := spans[0], spans[1]
+	assert.Equal(t, "extract", child.Name())
+	assert.Equal(t, "etl", parent.Name())
+	assert.Equal(t, parent.TraceID(), child.TraceID())
+	assert.Equal(t, parent.SpanID(), child.ParentSpanID())
+	assert.True(t, parent.ParentSpanID().IsEmpty())
+}
+
+func TestConsumeLogsJoinsLogTraceContext(t *testing.T) {
+	conn, sink := newTestConnector(t, testConfig())
+
+	ld := newLogs(
+		testEvent{body: "job sync started", attrs: map[string]any{"job.id": "1", "job.name": "sync"}},
+		testEvent{body: "job sync finished", offset: time.Second, attrs: map[string]any{"job.id": "1"}},
+	)
+	traceID := pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	spanID := pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+	start := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	start.SetTraceID(traceID)
+	start.SetSpanID(spanID)
+	require.NoError(t, conn.ConsumeLogs(t.Context(), ld))
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 1)
+	assert.Equal(t, traceID, spans[0].TraceID())
+	assert.Equal(t, spanID, spans[0].ParentSpanID())
+}
+
+func TestConsumeLogsUnmatchedEnd(t *testing.T) {
+	conn, sink := newTestConnector(t, testConfig())
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job report finished", attrs: map[string]any{"job.id": "unknown"}},
+		testEvent{body: "job report started"},
+	)))
+	assert.Empty(t, sink.AllTraces())
+	assert.Equal(t, 0, conn.pending.len())
+}
+
+func TestExpireUnmatchedStarts(t *testing.T) {
+	cfg := testConfig()
+	cfg.Timeout = time.Minute
+	conn, sink := newTestConnector(t, cfg)
+	now := baseTime
+	conn.now = func() time.Time { return now }
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job a started", attrs: map[string]any{"job.id": "a", "job.name": "a"}},
+	)))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job b started", offset: 30 * time.Second, attrs: map[string]any{"job.id": "b", "job.name": "b"}},
+	)))
+
+	now = now.Add(45 * time.Second)
+	require.NoError(t, conn.expire(t.Context()))
+	assert.Equal(t, 1, conn.pending.len())
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "a", spans[0].Name())
+	assert.Equal(t, ptrace.StatusCodeError, spans[0].Status().Code())
+	assert.Equal(t, timeoutStatusMessage, spans[0].Status().Message())
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), spans[0].EndTimestamp())
+}
+
+func TestMaxPendingEvictsOldest(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxPending = 2
+	conn, sink := newTestConnector(t, cfg)
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job a started", attrs: map[string]any{"job.id": "a", "job.name": "a"}},
+		testEvent{body: "job b started", attrs: map[string]any{"job.id": "b", "job.name": "b"}},
+		testEvent{body: "job c started", attrs: map[string]any{"job.id": "c", "job.name": "c"}},
+		testEvent{body: "job b started", attrs: map[string]any{"job.id": "b", "job.name": "b2"}},
+	)))
+	assert.Equal(t, 2, conn.pending.len())
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 2)
+	assert.Equal(t, "a", spans[0].Name())
+	assert.Equal(t, evictedStatusMessage, spans[0].Status().Message())
+	assert.Equal(t, "b", spans[1].Name())
+	assert.Equal(t, replacedStatusMessage, spans[1].Status().Message())
+}
+
+func TestShutdownFlushesPendingSpans(t *testing.T) {
+	conn, sink := newTestConnector(t, testConfig())
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job a started", attrs: map[string]any{"job.id": "a", "job.name": "a"}},
+	)))
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	spans := allSpans(sink)
+	require.Len(t, spans, 1)
+	assert.Equal(t, ptrace.StatusCodeError, spans[0].Status().Code())
+}
+
+func TestConsumeLogsPartialFailure(t *testing.T) {
+	cfg := testConfig()
+	cfg.Span.Attributes = append(cfg.Span.Attributes, AttributeConfig{Key: "payload", Value: `ParseJSON(log.attributes["payload"])`})
+	conn, sink := newTestConnector(t, cfg)
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job b started", attrs: map[string]any{"job.id": "b", "job.name": "b", "payload": "{}"}},
+	)))
+	err := conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job a started", attrs: map[string]any{"job.id": "a", "job.name": "a", "payload": "{}"}},
+		testEvent{body: "job c started", attrs: map[string]any{"job.id": "c", "job.name": "c", "payload": "not json"}},
+		testEvent{body: "job a finished", offset: time.Second, attrs: map[string]any{"job.id": "a", "payload": "{}"}},
+		testEvent{body: "job b finished", offset: time.Second, attrs: map[string]any{"job.id": "b", "payload": "not json"}},
+	))
+
+	// The failed records are reported with a permanent error, once the completed spans are emitted.
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	spans := allSpans(sink)
+	require.Len(t, spans, 2)
+	assert.Equal(t, "a", spans[0].Name())
+	assert.Equal(t, ptrace.StatusCodeOk, spans[0].Status().Code())
+	// The span ended by the failed end event keeps the attributes of its start event.
+	assert.Equal(t, "b", spans[1].Name())
+	assert.Equal(t, ptrace.StatusCodeOk, spans[1].Status().Code())
+	assert.Equal(t, map[string]any{"job.id": "b", "payload": map[string]any{}}, spans[1].Attributes().AsRaw())
+	// The failed start event is not pending.
+	assert.Equal(t, 0, conn.pending.len())
+}
+
+func TestConsumeLogsPartialFailureRetryable(t *testing.T) {
+	cfg := testConfig()
+	cfg.Span.Attributes = append(cfg.Span.Attributes, AttributeConfig{Key: "payload", Value: `ParseJSON(log.attributes["payload"])`})
+	conn, _ := newTestConnector(t, cfg)
+	downstreamErr := errors.New("connection refused")
+	conn.tracesConsumer = consumertest.NewErr(downstreamErr)
+
+	err := conn.ConsumeLogs(t.Context(), newLogs(
+		testEvent{body: "job a started", attrs: map[string]any{"job.id": "a", "job.name": "a", "payload": "{}"}},
+		testEvent{body: "job c started", attrs: map[string]any{"job.id": "c", "job.name": "c", "payload": "not json"}},
+		testEvent{body: "job a finished", offset: time.Second, attrs: map[string]any{"job.id": "a", "payload": "{}"}},
+	))
+
+	// The error of the next consumer stays retryable, despite the failed records.
+	require.ErrorIs(t, err, downstreamErr)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestShutdownTwice(t *testing.T) {
+	conn, _ := newTestConnector(t, testConfig())
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, conn.Shutdown(t.Context()))
+	require.NoError(t, conn.Shutdown(t.Context()))
+}
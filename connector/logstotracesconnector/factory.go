@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+package logstotracesconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	defaultTimeout    = 5 * time.Minute
+	defaultMaxPending = 10000
+)
+
+// NewFactory creates a factory for the logs to traces connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithLogsToTraces(createLogsToTracesConnector, metadata.LogsToTracesStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Timeout:    defaultTimeout,
+		MaxPending: defaultMaxPending,
+		ErrorMode:  ottl.PropagateError,
+	}
+}
+
+func createLogsToTracesConnector(_ context.Context, set connector.Settings, cfg component.Config, nextConsumer consumer.Traces) (connector.Logs, error) {
+	c := cfg.(*Config)
+
+	conn := &logsToTracesConnector{
+		config:         c,
+		logger:         set.Logger,
+		tracesConsumer: nextConsumer,
+		pending:        newPendingStore(c.MaxPending),
+		now:            time.Now,
+		shutdownCh:     make(chan struct{}),
+	}
+
+	var err error
+	if conn.startCondition, err = filterottl.NewBoolExprForLogWithPathContextNames(c.StartConditions, filterottl.StandardLogFuncs(), c.ErrorMode, set.TelemetrySettings); err != nil {
+		return nil, fmt.Errorf("start_conditions: %w", err)
+	}
+	if conn.endCondition, err = filterottl.NewBoolExprForLogWithPathContextNames(c.EndConditions, filterottl.StandardLogFuncs(), c.ErrorMode, set.TelemetrySettings); err != nil {
+		return nil, fmt.Errorf("end_conditions: %w", err)
+	}
+	if len(c.Span.ErrorConditions) > 0 {
+		if conn.errorCondition, err = filterottl.NewBoolExprForLogWithPathContextNames(c.Span.ErrorConditions, filterottl.StandardLogFuncs(), c.ErrorMode, set.TelemetrySettings); err != nil {
+			return nil, fmt.Errorf("span.error_conditions: %w", err)
+		}
+	}
+
+	parser, err := newParser(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	if conn.correlationKey, err = parser.ParseValueExpression(c.CorrelationKey); err != nil {
+		return nil, fmt.Errorf("correlation_key: %w", err)
+	}
+	if c.ParentKey != "" {
+		if conn.parentKey, err = parser.ParseValueExpression(c.ParentKey); err != nil {
+			return nil, fmt.Errorf("parent_key: %w", err)
+		}
+	}
+	if conn.spanName, err = parser.ParseValueExpression(c.Span.Name); err != nil {
+		return nil, fmt.Errorf("span.name: %w", err)
+	}
+	if c.Span.StatusMessage != "" {
+		if conn.statusMessage, err = parser.ParseValueExpression(c.Span.StatusMessage); err != nil {
+			return nil, fmt.Errorf("span.status_message: %w", err)
+		}
+	}
+	for i, attr := range c.Span.Attributes {
+		value, err := parser.ParseValueExpression(attr.Value)
+		if err != nil {
+			return nil, fmt.Errorf("span.attributes[%d]: %w", i, err)
+		}
+		conn.attributeValues = append(conn.attributeValues, attributeExpr{key: attr.Key, value: value})
+	}
+	return conn, nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logstotracesconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logstotracesconnector"
+
+import (
+	"container/list"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// pendingSpan holds the state captured from a start event until the matching end event arrives.
+type pendingSpan struct {
+	key          string
+	name         string
+	traceID      pcommon.TraceID
+	spanID       pcommon.SpanID
+	parentSpanID pcommon.SpanID
+	start        pcommon.Timestamp
+	resource     pcommon.Resource
+	attributes   pcommon.Map
+	expiresAt    time.Time
+}
+
+// pendingStore keeps pending spans ordered by arrival so that the oldest entries can be expired or
+// evicted first. It is not safe for concurrent use.
+type pendingStore struct {
+	l        *list.List
+	m        map[string]*list.Element
+	maxItems int
+}
+
+func newPendingStore(maxItems int) *pendingStore {
+	return &pendingStore{
+		l:        list.New(),
+		m:        make(map[string]*list.Element),
+		maxItems: maxItems,
+	}
+}
+
+func (s *pendingStore) len() int {
+	return s.l.Len()
+}
+
+// get returns the pending span stored for key, if any.
+func (s *pendingStore) get(key string) (*pendingSpan, bool) {
+	ele, ok := s.m[key]
+	if !ok {
+		return nil, false
+	}
+	return ele.Value.(*pendingSpan), true
+}
+
+// put stores ps and returns the entries that had to be removed to make room for it: a previous
+// span with the same key and, if the store is full, the oldest pending span.
+func (s *pendingStore) put(ps *pendingSpan) (replaced, evicted *pendingSpan) {
+	if ele, ok := s.m[ps.key]; ok {
+		replaced = ele.Value.(*pendingSpan)
+		s.l.Remove(ele)
+		delete(s.m, ps.key)
+	}
+	if s.l.Len() >= s.maxItems {
+		evicted = s.removeElement(s.l.Front())
+	}
+	s.m[ps.key] = s.l.PushBack(ps)
+	return replaced, evicted
+}
+
+// remove deletes and returns the pending span stored for key, if any.
+func (s *pendingStore) remove(key string) (*pendingSpan, bool) {
+	ele, ok := s.m[key]
+	if !ok {
+		return nil, false
+	}
+	return s.removeElement(ele), true
+}
+
+// expire removes and returns all pending spans that expired before now.
+func (s *pendingStore) expire(now time.Time) []*pendingSpan {
+	var expired []*pendingSpan
+	for head := s.l.Front(); head != nil; head = s.l.Front() {
+		if head.Value.(*pendingSpan).expiresAt.After(now) {
+			break
+		}
+		expired = append(expired, s.removeElement(head))
+	}
+	return expired
+}
+
+// drain removes and returns all pending spans.
+func (s *pendingStore) drain() []*pendingSpan {
+	drained := make([]*pendingSpan, 0, s.l.Len())
+	for head := s.l.Front(); head != nil; head = s.l.Front() {
+		drained = append(drained, s.removeElement(head))
+	}
+	return drained
+}
+
+func (s *pendingStore) removeElement(ele *list.Element) *pendingSpan {
+	ps := ele.Value.(*pendingSpan)
+	s.l.Remove(ele)
+	delete(s.m, ps.key)
+	return ps
+}
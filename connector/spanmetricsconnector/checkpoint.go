@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+)
+
+const (
+	checkpointStorageKey = "spanmetrics_state"
+
+	// checkpointLastSeenAttribute holds the time a resource was last seen in the scope of the checkpoint.
+	checkpointLastSeenAttribute = "spanmetrics.checkpoint.last_seen"
+)
+
+// getStorageClient resolves a storage.Client for the connector.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindConnector, componentID, "")
+}
+
+// buildCheckpoint serializes the metric state of all resources. The checkpoint is a pmetric.Metrics
+// holding one resource per cached resource and one metric per aggregated signal, see metrics.SumMetrics.Checkpoint
+// for the layout of the data points.
+//
+// Must be called holding lock.
+func (p *connectorImp) buildCheckpoint() ([]byte, error) {
+	m := pmetric.NewMetrics()
+	p.resourceMetrics.ForEach(func(_ resourceKey, rawMetrics *resourceMetrics) {
+		rm := m.ResourceMetrics().AppendEmpty()
+		rawMetrics.attributes.CopyTo(rm.Resource().Attributes())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("spanmetricsconnector")
+		sm.Scope().Attributes().PutInt(checkpointLastSeenAttribute, rawMetrics.lastSeen.UnixNano())
+
+		metric := sm.Metrics().AppendEmpty()
+		metric.SetName(metricNameCalls)
+		rawMetrics.sums.Checkpoint(metric)
+
+		if !p.config.Histogram.Disable {
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(metricNameDuration)
+			rawMetrics.histograms.Checkpoint(metric)
+		}
+
+		if p.events.Enabled {
+			metric = sm.Metrics().AppendEmpty()
+			metric.SetName(metricNameEvents)
+			rawMetrics.events.Checkpoint(metric)
+		}
+	})
+
+	marshaler := pmetric.ProtoMarshaler{}
+	return marshaler.MarshalMetrics(m)
+}
+
+// restoreCheckpoint loads the metric state written by buildCheckpoint. Resources and series that
+// expired while the collector was down, according to metrics_expiration and series_expiration, are skipped.
+//
+// Must be called holding lock.
+func (p *connectorImp) restoreCheckpoint(data []byte) error {
+	unmarshaler := pmetric.ProtoUnmarshaler{}
+	m, err := unmarshaler.UnmarshalMetrics(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+
+	now := p.clock.Now()
+	for i := 0; i < m.ResourceMetrics().Len(); i++ {
+		rm := m.ResourceMetrics().At(i)
+		if rm.ScopeMetrics().Len() == 0 {
+			continue
+		}
+		sm := rm.ScopeMetrics().At(0)
+
+		var lastSeen pcommon.Timestamp
+		if v, ok := sm.Scope().Attributes().Get(checkpointLastSeenAttribute); ok && v.Int() > 0 {
+			lastSeen = pcommon.Timestamp(v.Int())
+		}
+		if p.config.MetricsExpiration > 0 && now.Sub(lastSeen.AsTime()) >= p.config.MetricsExpiration {
+			continue
+		}
+
+		attributes := pcommon.NewMap()
+		rm.Resource().Attributes().CopyTo(attributes)
+		rawMetrics := &resourceMetrics{
+			histograms: initHistogramMetrics(p.config),
+			sums:       metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
+			events:     metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
+			attributes: attributes,
+		}
+		if lastSeen != 0 {
+			rawMetrics.lastSeen = lastSeen.AsTime()
+		}
+
+		for j := 0; j < sm.Metrics().Len(); j++ {
+			metric := sm.Metrics().At(j)
+			var restoreErr error
+			switch metric.Name() {
+			case metricNameCalls:
+				restoreErr = rawMetrics.sums.Restore(metric, p.config.SeriesExpiration, now)
+			case metricNameDuration:
+				if !p.config.Histogram.Disable {
+					restoreErr = rawMetrics.histograms.Restore(metric, p.config.SeriesExpiration, now)
+				}
+			case metricNameEvents:
+				if p.events.Enabled {
+					restoreErr = rawMetrics.events.Restore(metric, p.config.SeriesExpiration, now)
+				}
+			}
+			if restoreErr != nil {
+				p.logger.Warn("Discarding checkpointed metric state", zap.String("metric", metric.Name()), zap.Error(restoreErr))
+			}
+		}
+
+		p.resourceMetrics.Add(p.createResourceKey(attributes), rawMetrics)
+	}
+	return nil
+}
+
+// loadCheckpoint restores the metric state from storage, if a checkpoint exists.
+func (p *connectorImp) loadCheckpoint(ctx context.Context) error {
+	data, err := p.storageClient.Get(ctx, checkpointStorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint from storage: %w", err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.restoreCheckpoint(data); err != nil {
+		return err
+	}
+	p.logger.Info("Restored metric state from checkpoint", zap.Int("resources", p.resourceMetrics.Len()))
+	return nil
+}
+
+// saveCheckpoint writes the current metric state to storage if checkpoint_interval has elapsed
+// since the last checkpoint, or unconditionally if force is set.
+func (p *connectorImp) saveCheckpoint(ctx context.Context, force bool) error {
+	p.lock.Lock()
+	now := p.clock.Now()
+	if !force && now.Sub(p.lastCheckpoint) < p.config.CheckpointInterval {
+		p.lock.Unlock()
+		return nil
+	}
+	data, err := p.buildCheckpoint()
+	p.lastCheckpoint = now
+	p.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to build checkpoint: %w", err)
+	}
+
+	if err := p.storageClient.Set(ctx, checkpointStorageKey, data); err != nil {
+		return fmt.Errorf("failed to write checkpoint to storage: %w", err)
+	}
+	p.logger.Debug("Saved metric state checkpoint", zap.Int("bytes", len(data)))
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package spanmetricsconnector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newCheckpointConnector(t *testing.T, histogramConfig func() HistogramConfig, clock clockwork.Clock) *connectorImp {
+	t.Helper()
+	p, err := newConnectorImp(stringp("defaultNullValue"), histogramConfig, disabledExemplarsConfig, enabledEventsConfig, cumulative, 0, []string{}, 1000, clock, false)
+	require.NoError(t, err)
+	storageID := storagetest.NewStorageID("test")
+	p.config.Storage = &storageID
+	p.id = component.MustNewID("spanmetrics")
+	return p
+}
+
+// summarize returns the data point count and the sum of values of each metric, ignoring ordering.
+func summarize(md pmetric.Metrics) map[string][2]float64 {
+	summary := map[string][2]float64{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				s := summary[m.Name()]
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					for l := 0; l < m.Sum().DataPoints().Len(); l++ {
+						s[0]++
+						s[1] += float64(m.Sum().DataPoints().At(l).IntValue())
+					}
+				case pmetric.MetricTypeHistogram:
+					for l := 0; l < m.Histogram().DataPoints().Len(); l++ {
+						s[0]++
+						s[1] += m.Histogram().DataPoints().At(l).Sum()
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for l := 0; l < m.ExponentialHistogram().DataPoints().Len(); l++ {
+						s[0]++
+						s[1] += m.ExponentialHistogram().DataPoints().At(l).Sum()
+					}
+				}
+				summary[m.Name()] = s
+			}
+		}
+	}
+	return summary
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		histogramConfig func() HistogramConfig
+	}{
+		{name: "explicit", histogramConfig: explicitHistogramsConfig},
+		{name: "exponential", histogramConfig: exponentialHistogramsConfig},
+		{name: "disabled", histogramConfig: disabledHistogramsConfig},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+			clock := clockwork.NewFakeClock()
+
+			p1 := newCheckpointConnector(t, tc.histogramConfig, clock)
+			require.NoError(t, p1.Start(t.Context(), host))
+			require.NoError(t, p1.ConsumeTraces(t.Context(), buildSampleTrace()))
+			// The first export of a cumulative sum is reported as zero.
+			p1.buildMetrics()
+			require.NoError(t, p1.ConsumeTraces(t.Context(), buildSampleTrace()))
+			expected := p1.buildMetrics()
+			require.NoError(t, p1.Shutdown(t.Context()))
+
+			clock.Advance(time.Minute)
+			p2 := newCheckpointConnector(t, tc.histogramConfig, clock)
+			require.NoError(t, p2.Start(t.Context(), host))
+			defer func() { require.NoError(t, p2.Shutdown(t.Context())) }()
+			restored := p2.buildMetrics()
+
+			assert.Equal(t, expected.DataPointCount(), restored.DataPointCount())
+			assert.Equal(t, summarize(expected), summarize(restored))
+			startTimestamps := func(md pmetric.Metrics) []string {
+				var ts []string
+				ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				for i := 0; i < ms.Len(); i++ {
+					if ms.At(i).Type() == pmetric.MetricTypeSum {
+						for j := 0; j < ms.At(i).Sum().DataPoints().Len(); j++ {
+							ts = append(ts, ms.At(i).Sum().DataPoints().At(j).StartTimestamp().String())
+						}
+					}
+				}
+				return ts
+			}
+			assert.ElementsMatch(t, startTimestamps(expected), startTimestamps(restored))
+
+			// Restored series keep aggregating new spans.
+			require.NoError(t, p2.ConsumeTraces(t.Context(), buildSampleTrace()))
+			afterRestore := summarize(p2.buildMetrics())
+			assert.Equal(t, expected.DataPointCount(), p2.buildMetrics().DataPointCount())
+			assert.Greater(t, afterRestore[metricNameCalls][1], summarize(expected)[metricNameCalls][1])
+		})
+	}
+}
+
+func TestCheckpointRestoreSkipsExpiredResources(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	clock := clockwork.NewFakeClock()
+
+	p1 := newCheckpointConnector(t, explicitHistogramsConfig, clock)
+	p1.config.MetricsExpiration = time.Hour
+	require.NoError(t, p1.Start(t.Context(), host))
+	require.NoError(t, p1.ConsumeTraces(t.Context(), buildSampleTrace()))
+	require.NoError(t, p1.Shutdown(t.Context()))
+
+	clock.Advance(30 * time.Minute)
+	p2 := newCheckpointConnector(t, explicitHistogramsConfig, clock)
+	p2.config.MetricsExpiration = time.Hour
+	require.NoError(t, p2.Start(t.Context(), host))
+	assert.Positive(t, p2.resourceMetrics.Len())
+	require.NoError(t, p2.Shutdown(t.Context()))
+
+	clock.Advance(time.Hour)
+	p3 := newCheckpointConnector(t, explicitHistogramsConfig, clock)
+	p3.config.MetricsExpiration = time.Hour
+	require.NoError(t, p3.Start(t.Context(), host))
+	assert.Equal(t, 0, p3.resourceMetrics.Len())
+	require.NoError(t, p3.Shutdown(t.Context()))
+}
+
+func TestCheckpointDiscardsMismatchedHistogram(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	clock := clockwork.NewFakeClock()
+
+	p1 := newCheckpointConnector(t, explicitHistogramsConfig, clock)
+	require.NoError(t, p1.Start(t.Context(), host))
+	require.NoError(t, p1.ConsumeTraces(t.Context(), buildSampleTrace()))
+	require.NoError(t, p1.Shutdown(t.Context()))
+
+	p2 := newCheckpointConnector(t, exponentialHistogramsConfig, clock)
+	require.NoError(t, p2.Start(t.Context(), host))
+	restored := summarize(p2.buildMetrics())
+	require.NoError(t, p2.Shutdown(t.Context()))
+
+	assert.NotZero(t, restored[metricNameCalls][0])
+	assert.Zero(t, restored[metricNameDuration][0])
+}
+
+func TestCheckpointStorageNotFound(t *testing.T) {
+	p := newCheckpointConnector(t, explicitHistogramsConfig, clockwork.NewFakeClock())
+	require.ErrorContains(t, p.Start(t.Context(), storagetest.NewStorageHost()), "storage extension")
+}
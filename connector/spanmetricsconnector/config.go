@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gobwas/glob"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -111,6 +112,15 @@ type Config struct {
 	// EnableMetricsSamplingMethod adds the sampling.method attribute ("extrapolated" or "counted") to metrics.
 	// When false (default), the attribute is not added.
 	EnableMetricsSamplingMethod bool `mapstructure:"enable_metrics_sampling_method"`
+
+	// Storage is the ID of a storage extension used to checkpoint the cumulative metric state, so that
+	// counters and histograms keep their values and start timestamps across restarts.
+	// Only supported with cumulative aggregation temporality.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// CheckpointInterval is the minimum time between two checkpoints. Checkpoints are written after a
+	// metrics flush, so the default value (0) writes a checkpoint on every flush. Requires storage to be set.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
 }
 
 type HistogramConfig struct {
@@ -200,6 +210,18 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid max_per_data_point: %v, the value should be positive", c.Exemplars.MaxPerDataPoint)
 	}
 
+	if c.CheckpointInterval < 0 {
+		return fmt.Errorf("invalid checkpoint_interval: %v, the duration should be positive", c.CheckpointInterval)
+	}
+
+	if c.Storage == nil && c.CheckpointInterval > 0 {
+		return errors.New("checkpoint_interval requires storage to be set")
+	}
+
+	if c.Storage != nil && c.GetAggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		return errors.New("storage is only supported with cumulative aggregation temporality")
+	}
+
 	return nil
 }
 
@@ -23,6 +23,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 )
 
+var fileStorageID = component.MustNewID("file_storage")
+
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
@@ -131,6 +133,22 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "invalid_series_expiration"),
 			errorMessage: "the duration should be positive",
 		},
+		{
+			name: "checkpoint",
+			id:   component.NewIDWithName(metadata.Type, "checkpoint"),
+			expected: &Config{
+				AggregationTemporality:   "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Histogram:                HistogramConfig{Disable: false, Unit: defaultUnit},
+				Exemplars: ExemplarsConfig{
+					MaxPerDataPoint: defaultMaxPerDatapoint,
+				},
+				Namespace:          DefaultNamespace,
+				Storage:            &fileStorageID,
+				CheckpointInterval: 5 * time.Minute,
+			},
+		},
 		{
 			name: "exemplars_enabled",
 			id:   component.NewIDWithName(metadata.Type, "exemplars_enabled"),
@@ -463,6 +481,35 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectedErr: "invalid series_expiration: -1s, the duration should be positive",
 		},
+		{
+			name: "invalid checkpoint interval",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				Storage:                  &fileStorageID,
+				CheckpointInterval:       -1 * time.Second,
+			},
+			expectedErr: "invalid checkpoint_interval: -1s, the duration should be positive",
+		},
+		{
+			name: "checkpoint interval without storage",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				CheckpointInterval:       time.Minute,
+			},
+			expectedErr: "checkpoint_interval requires storage to be set",
+		},
+		{
+			name: "storage with delta temporality",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				AggregationTemporality:   delta,
+				Storage:                  &fileStorageID,
+			},
+			expectedErr: "storage is only supported with cumulative aggregation temporality",
+		},
 		{
 			name: "invalid delta timestamp cache size",
 			config: Config{
@@ -6,6 +6,7 @@ package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"slices"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -99,6 +101,11 @@ type connectorImp struct {
 	// Tracks the last TimestampUnixNano for delta metrics so that they represent an uninterrupted series. Unused for cumulative span metrics.
 	lastDeltaTimestamps *simplelru.LRU[metrics.Key, pcommon.Timestamp]
 	instanceID          string
+
+	// id and storageClient are used to checkpoint the cumulative metric state, if storage is configured.
+	id             component.ID
+	storageClient  storage.Client
+	lastCheckpoint time.Time
 }
 
 type resourceMetrics struct {
@@ -244,9 +251,21 @@ func durationsToUnits(vs []time.Duration, unitDivider int64) []float64 {
 }
 
 // Start implements the component.Component interface.
-func (p *connectorImp) Start(ctx context.Context, _ component.Host) error {
+func (p *connectorImp) Start(ctx context.Context, host component.Host) error {
 	p.logger.Info("Starting spanmetrics connector")
 
+	if p.config.Storage != nil {
+		client, err := getStorageClient(ctx, host, p.config.Storage, p.id)
+		if err != nil {
+			return fmt.Errorf("failed to get storage client: %w", err)
+		}
+		p.storageClient = client
+		if err := p.loadCheckpoint(ctx); err != nil {
+			p.logger.Warn("Failed to restore metric state, starting fresh", zap.Error(err))
+		}
+		p.lastCheckpoint = p.clock.Now()
+	}
+
 	p.started = true
 	go func() {
 		for {
@@ -263,7 +282,8 @@ func (p *connectorImp) Start(ctx context.Context, _ component.Host) error {
 }
 
 // Shutdown implements the component.Component interface.
-func (p *connectorImp) Shutdown(context.Context) error {
+func (p *connectorImp) Shutdown(ctx context.Context) error {
+	var err error
 	p.shutdownOnce.Do(func() {
 		p.logger.Info("Shutting down spanmetrics connector")
 		if p.started {
@@ -272,8 +292,14 @@ func (p *connectorImp) Shutdown(context.Context) error {
 			p.done <- struct{}{}
 			p.started = false
 		}
+		if p.storageClient != nil {
+			if saveErr := p.saveCheckpoint(ctx, true); saveErr != nil {
+				p.logger.Warn("Final checkpoint save failed", zap.Error(saveErr))
+			}
+			err = p.storageClient.Close(ctx)
+		}
 	})
-	return nil
+	return err
 }
 
 // Capabilities implements the consumer interface.
@@ -299,6 +325,12 @@ func (p *connectorImp) exportMetrics(ctx context.Context) {
 	// This component no longer needs to read the metrics once built, so it is safe to unlock.
 	p.lock.Unlock()
 
+	if p.storageClient != nil {
+		if err := p.saveCheckpoint(ctx, false); err != nil {
+			p.logger.Warn("Failed to checkpoint metric state", zap.Error(err))
+		}
+	}
+
 	if err := p.metricsConsumer.ConsumeMetrics(ctx, m); err != nil {
 		p.logger.Error("Failed ConsumeMetrics", zap.Error(err))
 		return
@@ -72,5 +72,6 @@ func createTracesToMetricsConnector(ctx context.Context, params connector.Settin
 		return nil, err
 	}
 	c.metricsConsumer = nextConsumer
+	c.id = params.ID
 	return c, nil
 }
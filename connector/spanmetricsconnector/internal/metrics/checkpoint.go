@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// checkpointKeyAttribute holds the series key in checkpointed data points. It is removed again on restore.
+const checkpointKeyAttribute = "spanmetrics.checkpoint.key"
+
+var (
+	errUnexpectedMetricType = errors.New("checkpointed metric type does not match the configured histogram type")
+	errBoundsMismatch       = errors.New("checkpointed histogram bounds do not match the configured buckets")
+)
+
+// The checkpoint of a series is stored as a data point with:
+//   - the series attributes, plus the series key under checkpointKeyAttribute,
+//   - the series start timestamp as StartTimestamp,
+//   - the time the series was last seen as Timestamp,
+//   - the NoRecordedValue flag if the series has not been exported yet.
+
+func checkpointSeries(attributes pcommon.Map, dst pcommon.Map, key Key) {
+	attributes.CopyTo(dst)
+	dst.PutEmptyBytes(checkpointKeyAttribute).FromRaw([]byte(key))
+}
+
+func restoreSeries(attributes pcommon.Map) (Key, pcommon.Map, bool) {
+	keyVal, ok := attributes.Get(checkpointKeyAttribute)
+	if !ok || keyVal.Type() != pcommon.ValueTypeBytes {
+		return "", pcommon.Map{}, false
+	}
+	key := Key(keyVal.Bytes().AsRaw())
+	restored := pcommon.NewMap()
+	attributes.CopyTo(restored)
+	restored.Remove(checkpointKeyAttribute)
+	return key, restored, true
+}
+
+func isExpired(lastSeen pcommon.Timestamp, expiration time.Duration, now time.Time) bool {
+	return expiration > 0 && now.Sub(lastSeen.AsTime()) >= expiration
+}
+
+// Checkpoint writes the state of all series into metric.
+func (m *SumMetrics) Checkpoint(metric pmetric.Metric) {
+	dps := metric.SetEmptySum().DataPoints()
+	dps.EnsureCapacity(len(m.metrics))
+	for k, s := range m.metrics {
+		dp := dps.AppendEmpty()
+		checkpointSeries(s.attributes, dp.Attributes(), k)
+		dp.SetStartTimestamp(s.startTimestamp)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(s.lastSeen))
+		dp.SetIntValue(int64(s.count))
+		dp.SetFlags(dp.Flags().WithNoRecordedValue(s.isFirst))
+	}
+}
+
+// Restore loads the series checkpointed in metric, skipping series that expired before now.
+func (m *SumMetrics) Restore(metric pmetric.Metric, expiration time.Duration, now time.Time) error {
+	if metric.Type() != pmetric.MetricTypeSum {
+		return errUnexpectedMetricType
+	}
+	dps := metric.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key, attributes, ok := restoreSeries(dp.Attributes())
+		if !ok || isExpired(dp.Timestamp(), expiration, now) {
+			continue
+		}
+		m.metrics[key] = &Sum{
+			attributes:       attributes,
+			count:            uint64(dp.IntValue()),
+			exemplars:        pmetric.NewExemplarSlice(),
+			maxExemplarCount: m.maxExemplarCount,
+			startTimestamp:   dp.StartTimestamp(),
+			isFirst:          dp.Flags().NoRecordedValue(),
+			lastSeen:         dp.Timestamp().AsTime(),
+		}
+	}
+	return nil
+}
+
+func (m *explicitHistogramMetrics) Checkpoint(metric pmetric.Metric) {
+	dps := metric.SetEmptyHistogram().DataPoints()
+	dps.EnsureCapacity(len(m.metrics))
+	for k, h := range m.metrics {
+		dp := dps.AppendEmpty()
+		checkpointSeries(h.attributes, dp.Attributes(), k)
+		dp.SetStartTimestamp(h.startTimestamp)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(h.lastSeen))
+		dp.ExplicitBounds().FromRaw(h.bounds)
+		dp.BucketCounts().FromRaw(h.bucketCounts)
+		dp.SetCount(h.count)
+		dp.SetSum(h.sum)
+	}
+}
+
+func (m *explicitHistogramMetrics) Restore(metric pmetric.Metric, expiration time.Duration, now time.Time) error {
+	if metric.Type() != pmetric.MetricTypeHistogram {
+		return errUnexpectedMetricType
+	}
+	dps := metric.Histogram().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if !slices.Equal(dp.ExplicitBounds().AsRaw(), m.bounds) || dp.BucketCounts().Len() != len(m.bounds)+1 {
+			return errBoundsMismatch
+		}
+	}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key, attributes, ok := restoreSeries(dp.Attributes())
+		if !ok || isExpired(dp.Timestamp(), expiration, now) {
+			continue
+		}
+		m.metrics[key] = &explicitHistogram{
+			attributes:       attributes,
+			exemplars:        pmetric.NewExemplarSlice(),
+			bucketCounts:     dp.BucketCounts().AsRaw(),
+			count:            dp.Count(),
+			sum:              dp.Sum(),
+			bounds:           m.bounds,
+			maxExemplarCount: m.maxExemplarCount,
+			startTimestamp:   dp.StartTimestamp(),
+			lastSeen:         dp.Timestamp().AsTime(),
+		}
+	}
+	return nil
+}
+
+func (m *exponentialHistogramMetrics) Checkpoint(metric pmetric.Metric) {
+	dps := metric.SetEmptyExponentialHistogram().DataPoints()
+	dps.EnsureCapacity(len(m.metrics))
+	for k, e := range m.metrics {
+		dp := dps.AppendEmpty()
+		checkpointSeries(e.attributes, dp.Attributes(), k)
+		dp.SetStartTimestamp(e.startTimestamp)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(e.lastSeen))
+		e.toDataPoint(dp, m.maxSize)
+	}
+}
+
+func (m *exponentialHistogramMetrics) Restore(metric pmetric.Metric, expiration time.Duration, now time.Time) error {
+	if metric.Type() != pmetric.MetricTypeExponentialHistogram {
+		return errUnexpectedMetricType
+	}
+	dps := metric.ExponentialHistogram().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key, attributes, ok := restoreSeries(dp.Attributes())
+		if !ok || isExpired(dp.Timestamp(), expiration, now) {
+			continue
+		}
+		histogram := new(structure.Histogram[float64])
+		histogram.Init(structure.NewConfig(structure.WithMaxSize(m.maxSize)))
+
+		restored := pmetric.NewExponentialHistogramDataPoint()
+		dp.CopyTo(restored)
+		restored.Attributes().Clear()
+		m.metrics[key] = &exponentialHistogram{
+			histogram:        histogram,
+			restored:         &restored,
+			attributes:       attributes,
+			exemplars:        pmetric.NewExemplarSlice(),
+			maxExemplarCount: m.maxExemplarCount,
+			startTimestamp:   dp.StartTimestamp(),
+			lastSeen:         dp.Timestamp().AsTime(),
+		}
+	}
+	return nil
+}
+
+// toDataPoint copies the histogram into dp, including the state restored from a checkpoint.
+func (e *exponentialHistogram) toDataPoint(dp pmetric.ExponentialHistogramDataPoint, maxSize int32) {
+	expoHistToExponentialDataPoint(e.histogram, dp)
+	if e.restored != nil {
+		mergeExponentialDataPoints(dp, *e.restored, maxSize)
+	}
+}
+
+// mergeExponentialDataPoints adds the counts of src into dst, downscaling both to a common scale
+// so that each half holds at most maxSize buckets.
+func mergeExponentialDataPoints(dst, src pmetric.ExponentialHistogramDataPoint, maxSize int32) {
+	if src.Count() == 0 {
+		return
+	}
+	if dst.Count() == 0 {
+		dst.SetScale(src.Scale())
+		src.Positive().CopyTo(dst.Positive())
+		src.Negative().CopyTo(dst.Negative())
+		dst.SetCount(src.Count())
+		dst.SetSum(src.Sum())
+		dst.SetZeroCount(src.ZeroCount())
+		if src.HasMin() {
+			dst.SetMin(src.Min())
+		}
+		if src.HasMax() {
+			dst.SetMax(src.Max())
+		}
+		return
+	}
+
+	scale := min(dst.Scale(), src.Scale())
+	for scale > -10 &&
+		(bucketRange(dst.Positive(), src.Positive(), dst.Scale()-scale, src.Scale()-scale) > int64(maxSize) ||
+			bucketRange(dst.Negative(), src.Negative(), dst.Scale()-scale, src.Scale()-scale) > int64(maxSize)) {
+		scale--
+	}
+	mergeBuckets(dst.Positive(), src.Positive(), dst.Scale()-scale, src.Scale()-scale)
+	mergeBuckets(dst.Negative(), src.Negative(), dst.Scale()-scale, src.Scale()-scale)
+
+	dst.SetScale(scale)
+	dst.SetCount(dst.Count() + src.Count())
+	dst.SetSum(dst.Sum() + src.Sum())
+	dst.SetZeroCount(dst.ZeroCount() + src.ZeroCount())
+	if src.HasMin() && (!dst.HasMin() || src.Min() < dst.Min()) {
+		dst.SetMin(src.Min())
+	}
+	if src.HasMax() && (!dst.HasMax() || src.Max() > dst.Max()) {
+		dst.SetMax(src.Max())
+	}
+}
+
+// bucketIndexRange returns the lowest and highest bucket index of b after downscaling by shift.
+func bucketIndexRange(b pmetric.ExponentialHistogramDataPointBuckets, shift int32) (low, high int32, ok bool) {
+	if b.BucketCounts().Len() == 0 {
+		return 0, 0, false
+	}
+	low = b.Offset() >> shift
+	high = (b.Offset() + int32(b.BucketCounts().Len()) - 1) >> shift
+	return low, high, true
+}
+
+func bucketRange(a, b pmetric.ExponentialHistogramDataPointBuckets, shiftA, shiftB int32) int64 {
+	lowA, highA, okA := bucketIndexRange(a, shiftA)
+	lowB, highB, okB := bucketIndexRange(b, shiftB)
+	switch {
+	case okA && okB:
+		return int64(max(highA, highB)) - int64(min(lowA, lowB)) + 1
+	case okA:
+		return int64(highA) - int64(lowA) + 1
+	case okB:
+		return int64(highB) - int64(lowB) + 1
+	default:
+		return 0
+	}
+}
+
+// mergeBuckets replaces dst with the sum of dst and src after downscaling them by shiftDst and shiftSrc.
+func mergeBuckets(dst, src pmetric.ExponentialHistogramDataPointBuckets, shiftDst, shiftSrc int32) {
+	lowDst, highDst, okDst := bucketIndexRange(dst, shiftDst)
+	lowSrc, highSrc, okSrc := bucketIndexRange(src, shiftSrc)
+	if !okDst && !okSrc {
+		return
+	}
+	low, high := lowDst, highDst
+	switch {
+	case !okDst:
+		low, high = lowSrc, highSrc
+	case okSrc:
+		low, high = min(lowDst, lowSrc), max(highDst, highSrc)
+	}
+
+	counts := make([]uint64, high-low+1)
+	for _, in := range []struct {
+		b     pmetric.ExponentialHistogramDataPointBuckets
+		shift int32
+	}{{dst, shiftDst}, {src, shiftSrc}} {
+		for i := 0; i < in.b.BucketCounts().Len(); i++ {
+			idx := (in.b.Offset() + int32(i)) >> in.shift
+			counts[idx-low] += in.b.BucketCounts().At(i)
+		}
+	}
+	dst.SetOffset(low)
+	dst.BucketCounts().FromRaw(counts)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightstep/go-expohisto/structure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func exponentialDataPoint(maxSize int32, values ...float64) pmetric.ExponentialHistogramDataPoint {
+	h := new(structure.Histogram[float64])
+	h.Init(structure.NewConfig(structure.WithMaxSize(maxSize)))
+	for _, v := range values {
+		h.Update(v)
+	}
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	expoHistToExponentialDataPoint(h, dp)
+	return dp
+}
+
+func TestMergeExponentialDataPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxSize int32
+		first   []float64
+		second  []float64
+	}{
+		{name: "empty source", maxSize: 160, first: []float64{1, 2, 3}},
+		{name: "empty destination", maxSize: 160, second: []float64{1, 2, 3}},
+		{name: "same range", maxSize: 160, first: []float64{1, 2, 3}, second: []float64{1.5, 2.5}},
+		{name: "disjoint ranges", maxSize: 160, first: []float64{0.001, 0.002}, second: []float64{1000, 2000}},
+		{name: "downscale to max size", maxSize: 4, first: []float64{1, 2, 4, 8}, second: []float64{16, 32, 64, 128}},
+		{name: "zero and negative", maxSize: 160, first: []float64{0, -1, 2}, second: []float64{0, -4, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := exponentialDataPoint(tt.maxSize, tt.first...)
+			mergeExponentialDataPoints(dst, exponentialDataPoint(tt.maxSize, tt.second...), tt.maxSize)
+			expected := exponentialDataPoint(tt.maxSize, append(append([]float64{}, tt.first...), tt.second...)...)
+
+			assert.Equal(t, expected.Count(), dst.Count())
+			assert.InDelta(t, expected.Sum(), dst.Sum(), 1e-9)
+			assert.Equal(t, expected.ZeroCount(), dst.ZeroCount())
+			assert.Equal(t, expected.Min(), dst.Min())
+			assert.Equal(t, expected.Max(), dst.Max())
+			assert.LessOrEqual(t, dst.Positive().BucketCounts().Len(), int(tt.maxSize))
+			assert.LessOrEqual(t, dst.Negative().BucketCounts().Len(), int(tt.maxSize))
+
+			// Downscale the expected histogram to the merged scale: the buckets must match.
+			shift := expected.Scale() - dst.Scale()
+			require.GreaterOrEqual(t, shift, int32(0))
+			for _, half := range []struct {
+				expected, actual pmetric.ExponentialHistogramDataPointBuckets
+			}{
+				{expected.Positive(), dst.Positive()},
+				{expected.Negative(), dst.Negative()},
+			} {
+				want := map[int32]uint64{}
+				for i := 0; i < half.expected.BucketCounts().Len(); i++ {
+					if c := half.expected.BucketCounts().At(i); c > 0 {
+						want[(half.expected.Offset()+int32(i))>>shift] += c
+					}
+				}
+				got := map[int32]uint64{}
+				for i := 0; i < half.actual.BucketCounts().Len(); i++ {
+					if c := half.actual.BucketCounts().At(i); c > 0 {
+						got[half.actual.Offset()+int32(i)] += c
+					}
+				}
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func TestSumMetricsCheckpointRestore(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sums := NewSumMetrics(5, 0)
+	attrs := func(v string) BuildAttributesFun {
+		return func() pcommon.Map {
+			m := pcommon.NewMap()
+			m.PutStr("span.name", v)
+			return m
+		}
+	}
+	fresh, _ := sums.GetOrCreate("fresh", attrs("fresh"), 10, now)
+	fresh.Add(3)
+	stale, _ := sums.GetOrCreate("stale", attrs("stale"), 20, now.Add(-time.Hour))
+	stale.Add(7)
+
+	metric := pmetric.NewMetric()
+	sums.Checkpoint(metric)
+	require.Equal(t, 2, metric.Sum().DataPoints().Len())
+
+	restored := NewSumMetrics(5, 0)
+	require.NoError(t, restored.Restore(metric, 30*time.Minute, now))
+	require.Len(t, restored.metrics, 1)
+	s := restored.metrics["fresh"]
+	require.NotNil(t, s)
+	assert.Equal(t, uint64(3), s.count)
+	assert.Equal(t, pcommon.Timestamp(10), s.startTimestamp)
+	assert.True(t, s.isFirst)
+	assert.Equal(t, map[string]any{"span.name": "fresh"}, s.attributes.AsRaw())
+
+	histogram := pmetric.NewMetric()
+	histogram.SetEmptyHistogram()
+	assert.ErrorIs(t, restored.Restore(histogram, 0, now), errUnexpectedMetricType)
+}
@@ -22,6 +22,8 @@ type HistogramMetrics interface {
 	BuildMetrics(pmetric.Metric, pcommon.Timestamp, func(Key, pcommon.Timestamp) pcommon.Timestamp, pmetric.AggregationTemporality)
 	ClearExemplars()
 	ExpireSeries(expiration time.Duration, now time.Time)
+	Checkpoint(pmetric.Metric)
+	Restore(metric pmetric.Metric, expiration time.Duration, now time.Time) error
 }
 
 type Histogram interface {
@@ -65,6 +67,8 @@ type exponentialHistogram struct {
 	exemplars  pmetric.ExemplarSlice
 
 	histogram *structure.Histogram[float64]
+	// restored holds the state loaded from a checkpoint, it is merged into the exported data points.
+	restored *pmetric.ExponentialHistogramDataPoint
 
 	maxExemplarCount int
 
@@ -234,7 +238,7 @@ func (m *exponentialHistogramMetrics) BuildMetrics(
 		startTimestamp := startTimeStampGenerator(k, e.startTimestamp)
 		dp.SetStartTimestamp(startTimestamp)
 		dp.SetTimestamp(timestamp)
-		expoHistToExponentialDataPoint(e.histogram, dp)
+		e.toDataPoint(dp, m.maxSize)
 		for i := 0; i < e.exemplars.Len(); i++ {
 			e.exemplars.At(i).SetTimestamp(timestamp)
 		}
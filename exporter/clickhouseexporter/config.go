@@ -77,6 +77,21 @@ type Config struct {
 	JSON bool `mapstructure:"json"`
 	// MetricsTables defines the table names for metric types.
 	MetricsTables MetricTablesConfig `mapstructure:"metrics_tables"`
+	// SchemaMigrations configures the migrations of tables created by earlier versions of the exporter.
+	SchemaMigrations SchemaMigrationsConfig `mapstructure:"schema_migrations"`
+}
+
+// SchemaMigrationsConfig defines how the exporter upgrades and validates the schema of existing tables on start.
+type SchemaMigrationsConfig struct {
+	// Enabled runs the pending migrations of the exporter's tables on start. Default is false.
+	Enabled bool `mapstructure:"enabled"`
+	// DryRun logs the DDL of the pending migrations instead of running it.
+	DryRun bool `mapstructure:"dry_run"`
+	// TableName is the table recording the applied migrations. default is `otel_schema_migrations`.
+	TableName string `mapstructure:"table_name"`
+	// Check fails the start of the exporter if a table is missing a column written by the exporter,
+	// or if the column has a different type.
+	Check bool `mapstructure:"check"`
 }
 
 type MetricTablesConfig struct {
@@ -99,19 +114,22 @@ type TableEngine struct {
 }
 
 const (
-	defaultDatabase           = "default"
-	defaultTableEngineName    = "MergeTree"
-	defaultMetricTableName    = "otel_metrics"
-	defaultGaugeSuffix        = "_gauge"
-	defaultSumSuffix          = "_sum"
-	defaultSummarySuffix      = "_summary"
-	defaultHistogramSuffix    = "_histogram"
-	defaultExpHistogramSuffix = "_exponential_histogram"
+	defaultDatabase            = "default"
+	defaultTableEngineName     = "MergeTree"
+	defaultMetricTableName     = "otel_metrics"
+	defaultGaugeSuffix         = "_gauge"
+	defaultSumSuffix           = "_sum"
+	defaultSummarySuffix       = "_summary"
+	defaultHistogramSuffix     = "_histogram"
+	defaultExpHistogramSuffix  = "_exponential_histogram"
+	defaultMigrationsTableName = "otel_schema_migrations"
 )
 
 var (
-	errConfigNoEndpoint      = errors.New("endpoint must be specified")
-	errConfigInvalidEndpoint = errors.New("endpoint must be url format")
+	errConfigNoEndpoint        = errors.New("endpoint must be specified")
+	errConfigInvalidEndpoint   = errors.New("endpoint must be url format")
+	errConfigDryRunDisabled    = errors.New("schema_migrations::dry_run requires schema_migrations::enabled")
+	errConfigNoMigrationsTable = errors.New("schema_migrations::table_name must be specified")
 )
 
 func createDefaultConfig() component.Config {
@@ -136,6 +154,9 @@ func createDefaultConfig() component.Config {
 			Histogram:            metrics.MetricTypeConfig{Name: defaultMetricTableName + defaultHistogramSuffix},
 			ExponentialHistogram: metrics.MetricTypeConfig{Name: defaultMetricTableName + defaultExpHistogramSuffix},
 		},
+		SchemaMigrations: SchemaMigrationsConfig{
+			TableName: defaultMigrationsTableName,
+		},
 	}
 }
 
@@ -152,6 +173,13 @@ func (cfg *Config) Validate() (err error) {
 
 	cfg.buildMetricTableNames()
 
+	if cfg.SchemaMigrations.DryRun && !cfg.SchemaMigrations.Enabled {
+		err = errors.Join(err, errConfigDryRunDisabled)
+	}
+	if cfg.SchemaMigrations.Enabled && cfg.SchemaMigrations.TableName == "" {
+		err = errors.Join(err, errConfigNoMigrationsTable)
+	}
+
 	// Validate DSN with clickhouse driver.
 	// Last chance to catch invalid config.
 	if _, e := clickhouse.ParseDSN(dsn); e != nil {
@@ -79,6 +79,11 @@ func TestLoadConfig(t *testing.T) {
 					Histogram:            metrics.MetricTypeConfig{Name: "otel_metrics_custom_histogram"},
 					ExponentialHistogram: metrics.MetricTypeConfig{Name: "otel_metrics_custom_exp_histogram"},
 				},
+				SchemaMigrations: SchemaMigrationsConfig{
+					Enabled:   true,
+					TableName: "otel_migrations",
+					Check:     true,
+				},
 				ConnectionParams: map[string]string{},
 				QueueSettings: configoptional.Some(func() exporterhelper.QueueBatchConfig {
 					queue := exporterhelper.NewDefaultQueueConfig()
@@ -621,6 +626,54 @@ func TestShouldCreateSchema(t *testing.T) {
 	}
 }
 
+func TestSchemaMigrationsConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     SchemaMigrationsConfig
+		wantErr error
+	}{
+		{
+			name: "default",
+			cfg:  createDefaultConfig().(*Config).SchemaMigrations,
+		},
+		{
+			name: "dry run",
+			cfg:  SchemaMigrationsConfig{Enabled: true, DryRun: true, TableName: defaultMigrationsTableName},
+		},
+		{
+			name:    "dry run without enabled",
+			cfg:     SchemaMigrationsConfig{DryRun: true, TableName: defaultMigrationsTableName},
+			wantErr: errConfigDryRunDisabled,
+		},
+		{
+			name:    "enabled without table name",
+			cfg:     SchemaMigrationsConfig{Enabled: true},
+			wantErr: errConfigNoMigrationsTable,
+		},
+		{
+			name: "check without migrations",
+			cfg:  SchemaMigrationsConfig{Check: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.SchemaMigrations = tt.cfg
+			})
+			err := cfg.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestTableEngineConfigParsing(t *testing.T) {
 	t.Parallel()
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
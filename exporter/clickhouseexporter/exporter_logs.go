@@ -60,6 +60,15 @@ func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
 		}
 	}
 
+	createSQL, err := renderCreateLogsTableSQL(e.cfg, false)
+	if err != nil {
+		return fmt.Errorf("render create logs table sql: %w", err)
+	}
+	table := tableSchema{name: e.cfg.LogsTableName, createSQL: createSQL, migrations: sqltemplates.LogsMigrations}
+	if err := prepareTableSchemas(ctx, e.cfg, e.db, e.logger, table); err != nil {
+		return err
+	}
+
 	err = e.detectSchemaFeatures(ctx)
 	if err != nil {
 		e.logger.Error("schema detection failed", zap.Error(err))
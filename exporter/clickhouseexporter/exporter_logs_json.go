@@ -66,6 +66,15 @@ func (e *logsJSONExporter) start(ctx context.Context, _ component.Host) error {
 		}
 	}
 
+	createSQL, err := renderCreateLogsJSONTableSQL(e.cfg)
+	if err != nil {
+		return fmt.Errorf("render create logs json table sql: %w", err)
+	}
+	table := tableSchema{name: e.cfg.LogsTableName, createSQL: createSQL, migrations: sqltemplates.LogsJSONMigrations}
+	if err := prepareTableSchemas(ctx, e.cfg, e.db, e.logger, table); err != nil {
+		return err
+	}
+
 	err = e.detectSchemaFeatures(ctx)
 	if err != nil {
 		e.logger.Error("schema detection failed", zap.Error(err))
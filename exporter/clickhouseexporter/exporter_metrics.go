@@ -47,21 +47,32 @@ func (e *metricsExporter) start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
+	database := e.cfg.database()
+	clusterStr := e.cfg.clusterString()
+	ttlExpr := internal.GenerateTTLExpr(e.cfg.TTL, "toDateTime(TimeUnix)")
 	if e.cfg.shouldCreateSchema() {
-		database := e.cfg.database()
-		clusterStr := e.cfg.clusterString()
 		if err := internal.CreateDatabase(ctx, e.db, database, clusterStr); err != nil {
 			return err
 		}
 
-		ttlExpr := internal.GenerateTTLExpr(e.cfg.TTL, "toDateTime(TimeUnix)")
 		err := metrics.NewMetricsTable(ctx, e.tablesConfig, database, clusterStr, e.cfg.tableEngineString(), ttlExpr, e.db)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	createSQL := metrics.CreateTableSQL(e.tablesConfig, database, clusterStr, e.cfg.tableEngineString(), ttlExpr)
+	tables := make([]tableSchema, 0, len(createSQL))
+	for _, metricType := range []pmetric.MetricType{
+		pmetric.MetricTypeGauge,
+		pmetric.MetricTypeSum,
+		pmetric.MetricTypeSummary,
+		pmetric.MetricTypeHistogram,
+		pmetric.MetricTypeExponentialHistogram,
+	} {
+		tables = append(tables, tableSchema{name: e.tablesConfig[metricType].Name, createSQL: createSQL[metricType]})
+	}
+	return prepareTableSchemas(ctx, e.cfg, e.db, e.logger, tables...)
 }
 
 func generateMetricTablesConfigMapper(cfg *Config) metrics.MetricTablesConfigMapper {
@@ -57,7 +57,8 @@ func (e *profilesExporter) start(ctx context.Context, _ component.Host) error {
 		}
 	}
 
-	return nil
+	table := tableSchema{name: e.cfg.ProfilesTableName, createSQL: renderCreateProfilesTableSQL(e.cfg)}
+	return prepareTableSchemas(ctx, e.cfg, e.db, e.logger, table)
 }
 
 func (e *profilesExporter) shutdown(_ context.Context) error {
@@ -56,7 +56,8 @@ func (e *tracesExporter) start(ctx context.Context, _ component.Host) error {
 		}
 	}
 
-	return nil
+	table := tableSchema{name: e.cfg.TracesTableName, createSQL: renderCreateTracesTableSQL(e.cfg)}
+	return prepareTableSchemas(ctx, e.cfg, e.db, e.logger, table)
 }
 
 func (e *tracesExporter) shutdown(_ context.Context) error {
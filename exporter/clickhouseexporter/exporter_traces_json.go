@@ -64,6 +64,11 @@ func (e *tracesJSONExporter) start(ctx context.Context, _ component.Host) error
 		}
 	}
 
+	table := tableSchema{name: e.cfg.TracesTableName, createSQL: renderCreateTracesJSONTableSQL(e.cfg), migrations: sqltemplates.TracesJSONMigrations}
+	if err := prepareTableSchemas(ctx, e.cfg, e.db, e.logger, table); err != nil {
+		return err
+	}
+
 	err = e.detectSchemaFeatures(ctx)
 	if err != nil {
 		e.logger.Error("schema detection failed", zap.Error(err))
@@ -101,3 +101,30 @@ func GetTableColumns(ctx context.Context, db driver.Conn, database, table string
 
 	return columnNames, nil
 }
+
+// GetTableColumnTypes returns the types of the columns of a table, by column name.
+func GetTableColumnTypes(ctx context.Context, db driver.Conn, database, table string) (map[string]string, error) {
+	descTable := fmt.Sprintf("DESC TABLE %q.%q", database, table)
+	rows, err := db.Query(ctx, descTable)
+	if err != nil {
+		return nil, fmt.Errorf("get table columns: %w", err)
+	}
+
+	columnTypes := map[string]string{}
+	for rows.Next() {
+		var columnName, columnType, skip string
+		scanErr := rows.Scan(&columnName, &columnType, &skip, &skip, &skip, &skip, &skip)
+		if scanErr != nil {
+			return nil, fmt.Errorf("scan table column: %w", scanErr)
+		}
+
+		columnTypes[columnName] = columnType
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("get table columns rows close: %w", err)
+	}
+
+	return columnTypes, nil
+}
@@ -63,8 +63,7 @@ func SetLogger(l *zap.Logger) {
 
 // NewMetricsTable create metric tables with an expiry time to storage metric telemetry data
 func NewMetricsTable(ctx context.Context, tablesConfig MetricTablesConfigMapper, database, cluster, engine, ttlExpr string, db driver.Conn) error {
-	for key, ddlTemplate := range supportedMetricTypes {
-		query := fmt.Sprintf(ddlTemplate, database, tablesConfig[key].Name, cluster, engine, ttlExpr)
+	for _, query := range CreateTableSQL(tablesConfig, database, cluster, engine, ttlExpr) {
 		if err := db.Exec(ctx, query); err != nil {
 			return fmt.Errorf("exec create metrics table sql: %w", err)
 		}
@@ -72,6 +71,15 @@ func NewMetricsTable(ctx context.Context, tablesConfig MetricTablesConfigMapper,
 	return nil
 }
 
+// CreateTableSQL returns the DDL creating the table of each metric type.
+func CreateTableSQL(tablesConfig MetricTablesConfigMapper, database, cluster, engine, ttlExpr string) map[pmetric.MetricType]string {
+	queries := make(map[pmetric.MetricType]string, len(supportedMetricTypes))
+	for key, ddlTemplate := range supportedMetricTypes {
+		queries[key] = fmt.Sprintf(ddlTemplate, database, tablesConfig[key].Name, cluster, engine, ttlExpr)
+	}
+	return queries
+}
+
 // NewMetricsModel create a model for contain different metric data
 func NewMetricsModel(tablesConfig MetricTablesConfigMapper, database string) map[pmetric.MetricType]MetricsModel {
 	return map[pmetric.MetricType]MetricsModel{
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Column is a column of a table definition.
+type Column struct {
+	Name string
+	Type string
+	// Computed is true for MATERIALIZED and ALIAS columns, which are not written by the exporter.
+	Computed bool
+	// Nested holds the sub-columns of a Nested column.
+	Nested []Column
+}
+
+// columnModifiers start the part of a column definition following the type.
+var columnModifiers = []string{"DEFAULT", "MATERIALIZED", "ALIAS", "EPHEMERAL", "COMMENT", "CODEC", "TTL", "STATISTICS", "SETTINGS"}
+
+var booleanType = regexp.MustCompile(`\bBoolean\b`)
+
+// ParseColumns returns the columns of a CREATE TABLE statement, skipping indexes, projections and constraints.
+func ParseColumns(ddl string) ([]Column, error) {
+	start := strings.IndexByte(ddl, '(')
+	if start < 0 {
+		return nil, errors.New("missing column definitions")
+	}
+	body, ok := enclosed(ddl[start:])
+	if !ok {
+		return nil, errors.New("unbalanced parentheses in column definitions")
+	}
+	return parseColumnList(body)
+}
+
+func parseColumnList(body string) ([]Column, error) {
+	var columns []Column
+	for _, def := range splitTopLevel(body) {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+		keyword, _, _ := strings.Cut(def, " ")
+		switch strings.ToUpper(keyword) {
+		case "INDEX", "PROJECTION", "CONSTRAINT":
+			continue
+		}
+		column, err := parseColumn(def)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func parseColumn(def string) (Column, error) {
+	var column Column
+	var rest string
+	if strings.HasPrefix(def, "`") {
+		end := strings.IndexByte(def[1:], '`')
+		if end < 0 {
+			return column, fmt.Errorf("unterminated column name in %q", def)
+		}
+		column.Name, rest = def[1:end+1], def[end+2:]
+	} else {
+		idx := strings.IndexFunc(def, unicode.IsSpace)
+		if idx < 0 {
+			return column, fmt.Errorf("missing type of column %q", def)
+		}
+		column.Name, rest = def[:idx], def[idx:]
+	}
+
+	typ, modifiers := splitType(strings.TrimSpace(rest))
+	for _, modifier := range []string{"MATERIALIZED", "ALIAS"} {
+		if strings.HasPrefix(modifiers, modifier) {
+			column.Computed = true
+		}
+	}
+	if nested, found := strings.CutPrefix(typ, "Nested"); found {
+		body, ok := enclosed(strings.TrimSpace(nested))
+		if !ok {
+			return column, fmt.Errorf("invalid Nested type of column %q", column.Name)
+		}
+		var err error
+		if column.Nested, err = parseColumnList(body); err != nil {
+			return column, fmt.Errorf("column %q: %w", column.Name, err)
+		}
+	}
+	column.Type = NormalizeType(typ)
+	return column, nil
+}
+
+// splitType splits a column definition without its name into the type and the following modifiers.
+func splitType(def string) (string, string) {
+	depth := 0
+	for i := 0; i < len(def); i++ {
+		switch def[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ' ', '\t', '\n':
+			if depth > 0 {
+				continue
+			}
+			rest := strings.TrimLeftFunc(def[i:], unicode.IsSpace)
+			for _, modifier := range columnModifiers {
+				if strings.HasPrefix(rest, modifier) {
+					return strings.TrimSpace(def[:i]), rest
+				}
+			}
+		}
+	}
+	return def, ""
+}
+
+// enclosed returns the text between the opening parenthesis at the start of s and its matching closing one.
+func enclosed(s string) (string, bool) {
+	if !strings.HasPrefix(s, "(") {
+		return "", false
+	}
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '`' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitTopLevel splits s at the commas outside parentheses and quotes.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, last := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '`' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
+}
+
+// NormalizeType returns a representation of a column type that is comparable with the types
+// returned by DESC TABLE.
+func NormalizeType(typ string) string {
+	typ = strings.Join(strings.Fields(typ), "")
+	return booleanType.ReplaceAllString(typ, "Bool")
+}
+
+// CompareColumns checks that the actual column types, as returned by GetTableColumnTypes, contain the
+// expected columns written by the exporter. Nested columns may be present either flattened into
+// arrays or as a single Nested column.
+func CompareColumns(expected []Column, actual map[string]string) error {
+	var errs error
+	check := func(name, expectedType string) {
+		actualType, found := actual[name]
+		switch {
+		case !found:
+			errs = errors.Join(errs, fmt.Errorf("missing column %s %s", name, expectedType))
+		case NormalizeType(actualType) != expectedType:
+			errs = errors.Join(errs, fmt.Errorf("column %s has type %s, expected %s", name, actualType, expectedType))
+		}
+	}
+	for _, column := range expected {
+		if column.Computed {
+			continue
+		}
+		if _, found := actual[column.Name]; len(column.Nested) == 0 || found {
+			check(column.Name, column.Type)
+			continue
+		}
+		for _, sub := range column.Nested {
+			check(column.Name+"."+sub.Name, "Array("+sub.Type+")")
+		}
+	}
+	return errs
+}
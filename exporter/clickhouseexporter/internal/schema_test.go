@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTableDDL = "CREATE TABLE IF NOT EXISTS `otel`.`test` ON CLUSTER my_cluster (\n" +
+	"    Timestamp DateTime64(9) CODEC(Delta(8), ZSTD(1)),\n" +
+	"    `Service Name` LowCardinality(String) COMMENT 'name, of the service' CODEC(ZSTD(1)),\n" +
+	"    Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),\n" +
+	"    Flag Boolean DEFAULT false,\n" +
+	"    `__materialized` LowCardinality(String) MATERIALIZED Attributes['name'] CODEC(ZSTD(1)),\n" +
+	"    Exemplars Nested (\n" +
+	"        Value Float64,\n" +
+	"        TraceId String\n" +
+	"    ) CODEC(ZSTD(1)),\n" +
+	"    INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,\n" +
+	"    PROJECTION by_service (SELECT * ORDER BY `Service Name`)\n" +
+	") ENGINE = MergeTree()\n" +
+	"ORDER BY (Timestamp)"
+
+func TestParseColumns(t *testing.T) {
+	columns, err := ParseColumns(testTableDDL)
+	require.NoError(t, err)
+	assert.Equal(t, []Column{
+		{Name: "Timestamp", Type: "DateTime64(9)"},
+		{Name: "Service Name", Type: "LowCardinality(String)"},
+		{Name: "Attributes", Type: "Map(LowCardinality(String),String)"},
+		{Name: "Flag", Type: "Bool"},
+		{Name: "__materialized", Type: "LowCardinality(String)", Computed: true},
+		{
+			Name: "Exemplars",
+			Type: "Nested(ValueFloat64,TraceIdString)",
+			Nested: []Column{
+				{Name: "Value", Type: "Float64"},
+				{Name: "TraceId", Type: "String"},
+			},
+		},
+	}, columns)
+}
+
+func TestParseColumnsInvalid(t *testing.T) {
+	_, err := ParseColumns("CREATE TABLE test")
+	assert.ErrorContains(t, err, "missing column definitions")
+
+	_, err = ParseColumns("CREATE TABLE test (Timestamp DateTime64(9)")
+	assert.ErrorContains(t, err, "unbalanced parentheses")
+
+	_, err = ParseColumns("CREATE TABLE test (Timestamp)")
+	assert.ErrorContains(t, err, `missing type of column "Timestamp"`)
+}
+
+func TestCompareColumns(t *testing.T) {
+	expected, err := ParseColumns(testTableDDL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		actual  map[string]string
+		wantErr []string
+	}{
+		{
+			name: "flattened nested columns",
+			actual: map[string]string{
+				"Timestamp":         "DateTime64(9)",
+				"Service Name":      "LowCardinality(String)",
+				"Attributes":        "Map(LowCardinality(String), String)",
+				"Flag":              "Bool",
+				"Exemplars.Value":   "Array(Float64)",
+				"Exemplars.TraceId": "Array(String)",
+				"Extra":             "String",
+			},
+		},
+		{
+			name: "nested column",
+			actual: map[string]string{
+				"Timestamp":    "DateTime64(9)",
+				"Service Name": "LowCardinality(String)",
+				"Attributes":   "Map(LowCardinality(String), String)",
+				"Flag":         "Bool",
+				"Exemplars":    "Nested(Value Float64, TraceId String)",
+			},
+		},
+		{
+			name: "missing and mismatched columns",
+			actual: map[string]string{
+				"Timestamp":         "DateTime64(3)",
+				"Attributes":        "Map(LowCardinality(String), String)",
+				"Flag":              "Bool",
+				"Exemplars.Value":   "Array(Float64)",
+				"Exemplars.TraceId": "Array(String)",
+			},
+			wantErr: []string{
+				"column Timestamp has type DateTime64(3), expected DateTime64(9)",
+				"missing column Service Name LowCardinality(String)",
+			},
+		},
+		{
+			name: "missing nested sub-column",
+			actual: map[string]string{
+				"Timestamp":       "DateTime64(9)",
+				"Service Name":    "LowCardinality(String)",
+				"Attributes":      "Map(LowCardinality(String), String)",
+				"Flag":            "Bool",
+				"Exemplars.Value": "Array(Float64)",
+			},
+			wantErr: []string{"missing column Exemplars.TraceId Array(String)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompareColumns(expected, tt.actual)
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, wantErr := range tt.wantErr {
+				assert.ErrorContains(t, err, wantErr)
+			}
+		})
+	}
+}
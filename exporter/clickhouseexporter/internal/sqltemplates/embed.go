@@ -3,8 +3,11 @@
 package sqltemplates // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/sqltemplates"
 
 import (
-	_ "embed"
+	"embed"
 	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -121,3 +124,66 @@ var MetricsSummaryCreateTable string
 
 //go:embed metrics_summary_insert.sql
 var MetricsSummaryInsert string
+
+// MIGRATIONS
+
+//go:embed migrations_table.sql
+var MigrationsCreateTable string
+
+//go:embed migrations_insert.sql
+var MigrationsInsert string
+
+// Parsed templates for the migrations table (text/template).
+var (
+	MigrationsCreateTableTmpl = newTemplate("migrations_table", MigrationsCreateTable)
+	MigrationsInsertTmpl      = newTemplate("migrations_insert", MigrationsInsert)
+)
+
+//go:embed migrations
+var migrationsFS embed.FS
+
+// Migration is a versioned change of a table created by an earlier version of the exporter.
+// Migrations are loaded from migrations/<table>/<version>_<description>.sql and rendered with
+// CreateTableData. Their statements must be idempotent, e.g. ADD COLUMN IF NOT EXISTS, since
+// tables created by the current version already contain the change.
+type Migration struct {
+	Version     uint32
+	Description string
+	Tmpl        *template.Template
+}
+
+// Migrations of each table, ordered by version.
+var (
+	LogsMigrations       = loadMigrations("logs")
+	LogsJSONMigrations   = loadMigrations("logs_json")
+	TracesJSONMigrations = loadMigrations("traces_json")
+)
+
+func loadMigrations(table string) []Migration {
+	dir := path.Join("migrations", table)
+	// ReadDir returns the entries sorted by file name, which orders them by version.
+	entries, err := fs.ReadDir(migrationsFS, dir)
+	if err != nil {
+		panic(err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".sql")
+		version, description, found := strings.Cut(name, "_")
+		parsedVersion, err := strconv.ParseUint(version, 10, 32)
+		if !found || err != nil || parsedVersion == 0 {
+			panic(fmt.Sprintf("invalid migration file name %q", entry.Name()))
+		}
+		text, err := fs.ReadFile(migrationsFS, path.Join(dir, entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		migrations = append(migrations, Migration{
+			Version:     uint32(parsedVersion),
+			Description: strings.ReplaceAll(description, "_", " "),
+			Tmpl:        newTemplate(table+"_"+name, string(text)),
+		})
+	}
+	return migrations
+}
@@ -0,0 +1,2 @@
+ALTER TABLE {{ident .Database}}.{{ident .TableName}} {{.ClusterString}}
+    ADD COLUMN IF NOT EXISTS `EventName` String COMMENT 'Event name for log records representing events' CODEC(ZSTD(1))
@@ -0,0 +1,7 @@
+ALTER TABLE {{ident .Database}}.{{ident .TableName}} {{.ClusterString}}
+    ADD COLUMN IF NOT EXISTS `ResourceAttributesKeys` Array(LowCardinality(String)) CODEC(ZSTD(1)) AFTER `ResourceAttributes`,
+    ADD COLUMN IF NOT EXISTS `ScopeAttributesKeys` Array(LowCardinality(String)) CODEC(ZSTD(1)) AFTER `ScopeAttributes`,
+    ADD COLUMN IF NOT EXISTS `LogAttributesKeys` Array(LowCardinality(String)) CODEC(ZSTD(1)) AFTER `LogAttributes`,
+    ADD INDEX IF NOT EXISTS idx_res_attr_keys ResourceAttributesKeys TYPE bloom_filter(0.01) GRANULARITY 1,
+    ADD INDEX IF NOT EXISTS idx_scope_attr_keys ScopeAttributesKeys TYPE bloom_filter(0.01) GRANULARITY 1,
+    ADD INDEX IF NOT EXISTS idx_log_attr_keys LogAttributesKeys TYPE bloom_filter(0.01) GRANULARITY 1
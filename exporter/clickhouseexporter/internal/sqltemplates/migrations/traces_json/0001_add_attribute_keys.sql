@@ -0,0 +1,5 @@
+ALTER TABLE {{ident .Database}}.{{ident .TableName}} {{.ClusterString}}
+    ADD COLUMN IF NOT EXISTS `ResourceAttributesKeys` Array(LowCardinality(String)) CODEC(ZSTD(1)) AFTER `ResourceAttributes`,
+    ADD COLUMN IF NOT EXISTS `SpanAttributesKeys` Array(LowCardinality(String)) CODEC(ZSTD(1)) AFTER `SpanAttributes`,
+    ADD INDEX IF NOT EXISTS idx_res_attr_keys ResourceAttributesKeys TYPE bloom_filter(0.01) GRANULARITY 1,
+    ADD INDEX IF NOT EXISTS idx_span_attr_keys SpanAttributesKeys TYPE bloom_filter(0.01) GRANULARITY 1
@@ -0,0 +1,11 @@
+INSERT INTO {{ident .Database}}.{{ident .TableName}} (
+    TableName,
+    Version,
+    Description,
+    CollectorVersion
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?
+)
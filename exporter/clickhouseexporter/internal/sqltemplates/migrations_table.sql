@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS {{ident .Database}}.{{ident .TableName}} {{.ClusterString}} (
+    `TableName` String COMMENT 'Name of the migrated table' CODEC(ZSTD(1)),
+    `Version` UInt32 COMMENT 'Version of the applied migration',
+    `Description` String COMMENT 'Description of the applied migration' CODEC(ZSTD(1)),
+    `CollectorVersion` LowCardinality(String) COMMENT 'Version of the collector that applied the migration' CODEC(ZSTD(1)),
+    `AppliedAt` DateTime64(3) DEFAULT now64(3) COMMENT 'Time the migration was applied' CODEC(Delta(8), ZSTD(1))
+) ENGINE = {{.Engine}}
+ORDER BY (TableName, Version)
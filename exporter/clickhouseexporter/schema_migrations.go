@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/sqltemplates"
+)
+
+// tableSchema describes a table written by the exporter.
+type tableSchema struct {
+	name string
+	// createSQL is the DDL the exporter creates the table with, it defines the expected columns.
+	createSQL string
+	// migrations upgrade tables created by earlier versions of the exporter, ordered by version.
+	migrations []sqltemplates.Migration
+}
+
+// prepareTableSchemas runs the pending migrations of the tables and validates their columns,
+// as configured by schema_migrations.
+func prepareTableSchemas(ctx context.Context, cfg *Config, db driver.Conn, logger *zap.Logger, tables ...tableSchema) error {
+	for _, table := range tables {
+		if cfg.SchemaMigrations.Enabled {
+			if err := migrateTable(ctx, cfg, db, logger, table); err != nil {
+				return fmt.Errorf("migrate table %s: %w", table.name, err)
+			}
+		}
+		// Columns added by a dry run are missing from the table, so the schema check is skipped.
+		if cfg.SchemaMigrations.Check && !cfg.SchemaMigrations.DryRun {
+			if err := checkTableSchema(ctx, cfg, db, table); err != nil {
+				return fmt.Errorf("check schema of table %s: %w", table.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func migrateTable(ctx context.Context, cfg *Config, db driver.Conn, logger *zap.Logger, table tableSchema) error {
+	if len(table.migrations) == 0 {
+		return nil
+	}
+
+	applied, err := appliedMigrations(ctx, cfg, db, table.name)
+	if err != nil {
+		return err
+	}
+
+	data := sqltemplates.CreateTableData{
+		Database:      cfg.database(),
+		TableName:     table.name,
+		ClusterString: cfg.clusterString(),
+		Engine:        cfg.tableEngineString(),
+	}
+	for _, migration := range table.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		sql, err := renderSQL(migration.Tmpl, data)
+		if err != nil {
+			return fmt.Errorf("render migration %d: %w", migration.Version, err)
+		}
+
+		fields := []zap.Field{
+			zap.String("table", table.name),
+			zap.Uint32("version", migration.Version),
+			zap.String("description", migration.Description),
+		}
+		if cfg.SchemaMigrations.DryRun {
+			logger.Info("Pending schema migration", append(fields, zap.String("sql", sql))...)
+			continue
+		}
+
+		if err := db.Exec(ctx, sql); err != nil {
+			return fmt.Errorf("exec migration %d: %w", migration.Version, err)
+		}
+		if err := recordMigration(ctx, cfg, db, table.name, migration); err != nil {
+			return err
+		}
+		logger.Info("Applied schema migration", fields...)
+	}
+
+	return nil
+}
+
+// appliedMigrations returns the versions of the migrations recorded for the table. Dry runs don't
+// create the migrations table, so a missing table is treated as no migrations being applied.
+func appliedMigrations(ctx context.Context, cfg *Config, db driver.Conn, table string) (map[uint32]bool, error) {
+	database := cfg.database()
+	migrationsTable := cfg.SchemaMigrations.TableName
+
+	if cfg.SchemaMigrations.DryRun {
+		var exists uint8
+		row := db.QueryRow(ctx, fmt.Sprintf("EXISTS TABLE %q.%q", database, migrationsTable))
+		if err := row.Scan(&exists); err != nil {
+			return nil, fmt.Errorf("check migrations table: %w", err)
+		}
+		if exists == 0 {
+			return map[uint32]bool{}, nil
+		}
+	} else {
+		sql, err := renderSQL(sqltemplates.MigrationsCreateTableTmpl, sqltemplates.CreateTableData{
+			Database:      database,
+			TableName:     migrationsTable,
+			ClusterString: cfg.clusterString(),
+			Engine:        cfg.tableEngineString(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("render create migrations table sql: %w", err)
+		}
+		if err := db.Exec(ctx, sql); err != nil {
+			return nil, fmt.Errorf("exec create migrations table sql: %w", err)
+		}
+	}
+
+	rows, err := db.Query(ctx, fmt.Sprintf("SELECT Version FROM %q.%q WHERE TableName = ?", database, migrationsTable), table)
+	if err != nil {
+		return nil, fmt.Errorf("get applied migrations: %w", err)
+	}
+
+	applied := map[uint32]bool{}
+	for rows.Next() {
+		var version uint32
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scan applied migration: %w", err)
+		}
+		applied[version] = true
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("get applied migrations rows close: %w", err)
+	}
+
+	return applied, nil
+}
+
+func recordMigration(ctx context.Context, cfg *Config, db driver.Conn, table string, migration sqltemplates.Migration) error {
+	sql, err := renderSQL(sqltemplates.MigrationsInsertTmpl, sqltemplates.InsertData{
+		Database:  cfg.database(),
+		TableName: cfg.SchemaMigrations.TableName,
+	})
+	if err != nil {
+		return fmt.Errorf("render migrations insert sql: %w", err)
+	}
+	if err := db.Exec(ctx, sql, table, migration.Version, migration.Description, cfg.collectorVersion); err != nil {
+		return fmt.Errorf("record migration %d: %w", migration.Version, err)
+	}
+	return nil
+}
+
+// checkTableSchema validates that the table contains the columns written by the exporter, with the
+// types of the table DDL.
+func checkTableSchema(ctx context.Context, cfg *Config, db driver.Conn, table tableSchema) error {
+	expected, err := internal.ParseColumns(table.createSQL)
+	if err != nil {
+		return fmt.Errorf("parse table DDL: %w", err)
+	}
+	actual, err := internal.GetTableColumnTypes(ctx, db, cfg.database(), table.name)
+	if err != nil {
+		return err
+	}
+	return internal.CompareColumns(expected, actual)
+}
+
+func renderSQL(tmpl *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
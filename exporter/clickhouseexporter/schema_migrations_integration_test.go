@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package clickhouseexporter
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+)
+
+func testSchemaMigrations(t *testing.T, endpoint string) {
+	withMigrations := func(fns ...func(*Config)) *Config {
+		return withTestExporterConfig(append([]func(*Config){func(cfg *Config) {
+			cfg.LogsTableName = "otel_logs_migrations"
+			cfg.SchemaMigrations = SchemaMigrationsConfig{
+				Enabled:   true,
+				TableName: "otel_schema_migrations_test",
+				Check:     true,
+			}
+		}}, fns...)...)(endpoint)
+	}
+	startLogsExporter := func(cfg *Config) (*logsExporter, error) {
+		exporter := newLogsExporter(zaptest.NewLogger(t), cfg)
+		t.Cleanup(func() { _ = exporter.shutdown(t.Context()) })
+		return exporter, exporter.start(t.Context(), nil)
+	}
+
+	cfg := withMigrations()
+	exporter, err := startLogsExporter(cfg)
+	require.NoError(t, err)
+	db := exporter.db
+	tableName := fmt.Sprintf("%q.%q", cfg.database(), cfg.LogsTableName)
+	migrationsTableName := fmt.Sprintf("%q.%q", cfg.database(), cfg.SchemaMigrations.TableName)
+
+	countMigrations := func() uint64 {
+		var count uint64
+		row := db.QueryRow(t.Context(), fmt.Sprintf("SELECT count() FROM %s WHERE TableName = ?", migrationsTableName), cfg.LogsTableName)
+		require.NoError(t, row.Scan(&count))
+		return count
+	}
+	hasEventName := func() bool {
+		columns, err := internal.GetTableColumns(t.Context(), db, cfg.database(), cfg.LogsTableName)
+		require.NoError(t, err)
+		return slices.Contains(columns, logsColumnEventName)
+	}
+
+	// Migrations of a table created by the current version are recorded as applied.
+	require.Equal(t, uint64(1), countMigrations())
+
+	// Simulate a table created before the EventName column was added.
+	require.NoError(t, db.Exec(t.Context(), fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", tableName, logsColumnEventName)))
+	require.NoError(t, db.Exec(t.Context(), fmt.Sprintf("TRUNCATE TABLE %s", migrationsTableName)))
+
+	t.Run("check fails on missing column", func(t *testing.T) {
+		_, err := startLogsExporter(withMigrations(func(cfg *Config) {
+			cfg.SchemaMigrations.Enabled = false
+		}))
+		require.ErrorContains(t, err, "missing column EventName String")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		_, err := startLogsExporter(withMigrations(func(cfg *Config) {
+			cfg.SchemaMigrations.DryRun = true
+		}))
+		require.NoError(t, err)
+		require.False(t, hasEventName())
+		require.Equal(t, uint64(0), countMigrations())
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		exporter, err := startLogsExporter(withMigrations())
+		require.NoError(t, err)
+		require.True(t, hasEventName())
+		require.True(t, exporter.schemaFeatures.EventName)
+		require.Equal(t, uint64(1), countMigrations())
+
+		// Migrating again is a no-op.
+		_, err = startLogsExporter(withMigrations())
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), countMigrations())
+	})
+}
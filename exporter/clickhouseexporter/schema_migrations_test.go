@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/sqltemplates"
+)
+
+func TestParseColumnsOfTableDDL(t *testing.T) {
+	cfg := withDefaultConfig(func(c *Config) {
+		c.Endpoint = defaultEndpoint
+		c.Database = "test_db"
+		c.TTL = 72 * time.Hour
+		c.ClusterName = "my_cluster"
+	})
+
+	logsSQL, err := renderCreateLogsTableSQL(cfg, true)
+	require.NoError(t, err)
+	logsJSONSQL, err := renderCreateLogsJSONTableSQL(cfg)
+	require.NoError(t, err)
+
+	tables := map[string]struct {
+		sql     string
+		columns []string
+	}{
+		"logs":        {logsSQL, []string{"Timestamp", "EventName", "LogAttributes"}},
+		"logs json":   {logsJSONSQL, []string{"Timestamp", "EventName", "LogAttributesKeys"}},
+		"traces":      {renderCreateTracesTableSQL(cfg), []string{"Timestamp", "TraceId", "Events", "Links"}},
+		"traces json": {renderCreateTracesJSONTableSQL(cfg), []string{"Timestamp", "TraceId", "SpanAttributesKeys"}},
+		"profiles":    {renderCreateProfilesTableSQL(cfg), []string{"Timestamp", "ProfileId"}},
+	}
+	tablesConfig := generateMetricTablesConfigMapper(cfg)
+	for metricType, sql := range metrics.CreateTableSQL(tablesConfig, cfg.database(), cfg.clusterString(), cfg.tableEngineString(), "") {
+		tables["metrics "+metricType.String()] = struct {
+			sql     string
+			columns []string
+		}{sql, []string{"TimeUnix", "MetricName", "Attributes"}}
+	}
+
+	for name, table := range tables {
+		t.Run(name, func(t *testing.T) {
+			columns, err := internal.ParseColumns(table.sql)
+			require.NoError(t, err)
+
+			types := map[string]string{}
+			for _, column := range columns {
+				assert.NotEmpty(t, column.Type, column.Name)
+				types[column.Name] = column.Type
+			}
+			for _, column := range table.columns {
+				assert.Contains(t, types, column)
+			}
+		})
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	cfg := withDefaultConfig(func(c *Config) {
+		c.Endpoint = defaultEndpoint
+		c.Database = "test_db"
+		c.ClusterName = "my_cluster"
+	})
+	data := sqltemplates.CreateTableData{
+		Database:      cfg.database(),
+		TableName:     "otel_logs",
+		ClusterString: cfg.clusterString(),
+		Engine:        cfg.tableEngineString(),
+	}
+
+	for name, migrations := range map[string][]sqltemplates.Migration{
+		"logs":        sqltemplates.LogsMigrations,
+		"logs json":   sqltemplates.LogsJSONMigrations,
+		"traces json": sqltemplates.TracesJSONMigrations,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NotEmpty(t, migrations)
+			for i, migration := range migrations {
+				assert.Equal(t, uint32(i+1), migration.Version, "migrations must be numbered consecutively")
+				assert.NotEmpty(t, migration.Description)
+
+				sql, err := renderSQL(migration.Tmpl, data)
+				require.NoError(t, err)
+				assert.Contains(t, sql, "ALTER TABLE `test_db`.`otel_logs` ON CLUSTER `my_cluster`")
+				assert.Contains(t, sql, "IF NOT EXISTS")
+			}
+		})
+	}
+
+	assert.Equal(t, "add event name", sqltemplates.LogsMigrations[0].Description)
+}
+
+func TestRenderMigrationsTableSQL(t *testing.T) {
+	cfg := withDefaultConfig(func(c *Config) {
+		c.Endpoint = defaultEndpoint
+		c.Database = "test_db"
+	})
+	data := sqltemplates.CreateTableData{
+		Database:  cfg.database(),
+		TableName: cfg.SchemaMigrations.TableName,
+		Engine:    cfg.tableEngineString(),
+	}
+
+	sql, err := renderSQL(sqltemplates.MigrationsCreateTableTmpl, data)
+	require.NoError(t, err)
+	assert.Contains(t, sql, "CREATE TABLE IF NOT EXISTS `test_db`.`otel_schema_migrations`")
+	assert.Contains(t, sql, "ENGINE = MergeTree()")
+
+	columns, err := internal.ParseColumns(sql)
+	require.NoError(t, err)
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	assert.Equal(t, []string{"TableName", "Version", "Description", "CollectorVersion", "AppliedAt"}, names)
+
+	insertSQL, err := renderSQL(sqltemplates.MigrationsInsertTmpl, sqltemplates.InsertData{
+		Database:  cfg.database(),
+		TableName: cfg.SchemaMigrations.TableName,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, insertSQL, "INSERT INTO `test_db`.`otel_schema_migrations`")
+}
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	conventions "go.opentelemetry.io/otel/semconv/v1.40.0"
@@ -43,8 +44,10 @@ type bulkIndexer interface {
 }
 
 type bulkIndexerSession interface {
-	// Add adds a document to the bulk indexing session.
-	Add(ctx context.Context, index, docID, pipeline string, document io.WriterTo, dynamicTemplates map[string]string, action string) error
+	// Add adds a document to the bulk indexing session. The source record
+	// of the document, if any, is written to the dead letter along with the
+	// document if it is rejected.
+	Add(ctx context.Context, index, docID, pipeline string, document io.WriterTo, dynamicTemplates map[string]string, action string, record sourceRecord) error
 
 	// End must be called on the session object once it is no longer
 	// needed, in order to release any associated resources.
@@ -79,8 +82,9 @@ func newBulkIndexer(
 	tb *metadata.TelemetryBuilder,
 	logger *zap.Logger,
 	getErrorHintFunc func(index, errorType string) string,
+	deadLetter deadLetter,
 ) bulkIndexer {
-	return newSyncBulkIndexer(client, config, requireDataStream, tb, logger, getErrorHintFunc)
+	return newSyncBulkIndexer(client, config, requireDataStream, tb, logger, getErrorHintFunc, deadLetter)
 }
 
 func bulkIndexerConfig(client elastictransport.Interface, config *Config, requireDataStream bool, logger *zap.Logger) docappender.BulkIndexerConfig {
@@ -102,7 +106,7 @@ func bulkIndexerConfig(client elastictransport.Interface, config *Config, requir
 		RetryOnDocumentStatus:   config.Retry.RetryOnDocumentStatus,
 		RequireDataStream:       requireDataStream,
 		CompressionLevel:        compressionLevel,
-		PopulateFailedDocsInput: config.TelemetrySettings.LogFailedDocsInput,
+		PopulateFailedDocsInput: config.TelemetrySettings.LogFailedDocsInput || config.DeadLetter.enabled(),
 		IncludeSourceOnError:    bulkIndexerIncludeSourceOnError(config.IncludeSourceOnError),
 		QueryParams:             getQueryParamsFromEndpoint(config, logger),
 		FilterPath:              config.BulkResponseFilterPath,
@@ -147,6 +151,7 @@ func newSyncBulkIndexer(
 	tb *metadata.TelemetryBuilder,
 	logger *zap.Logger,
 	getErrorHintFunc func(index, errorType string) string,
+	deadLetter deadLetter,
 ) *syncBulkIndexer {
 	var maxFlushBytes int64
 	if config.QueueBatchConfig.HasValue() && config.QueueBatchConfig.Get().Batch.HasValue() {
@@ -166,6 +171,7 @@ func newSyncBulkIndexer(
 		getErrorHintFunc:       getErrorHintFunc,
 		requireDataStream:      requireDataStream,
 		suppressConflictErrors: config.SuppressConflictErrors,
+		deadLetter:             deadLetter,
 	}
 }
 
@@ -180,6 +186,7 @@ type syncBulkIndexer struct {
 	getErrorHintFunc       func(index, errorType string) string
 	requireDataStream      bool
 	suppressConflictErrors bool
+	deadLetter             deadLetter
 }
 
 // StartSession creates a new docappender.BulkIndexer, and wraps
@@ -199,7 +206,11 @@ func (s *syncBulkIndexer) StartSession(ctx context.Context) bulkIndexerSession {
 	docsReceivedAttr := metric.WithAttributeSet(attribute.NewSet(
 		getAttributesFromMetadataKeys(ctx, s.metadataKeys)...,
 	))
-	return &syncBulkIndexerSession{s: s, bi: bi, docsReceivedAttr: docsReceivedAttr}
+	session := &syncBulkIndexerSession{s: s, bi: bi, docsReceivedAttr: docsReceivedAttr}
+	if s.deadLetter != nil {
+		session.records = make(sourceRecords)
+	}
+	return session
 }
 
 // Close is a no-op.
@@ -211,10 +222,19 @@ type syncBulkIndexerSession struct {
 	s                *syncBulkIndexer
 	bi               *docappender.BulkIndexer
 	docsReceivedAttr metric.MeasurementOption
+
+	// records holds the source records of the documents, if there is a
+	// dead letter.
+	records sourceRecords
 }
 
 // Add adds an item to the sync bulk indexer session.
-func (s *syncBulkIndexerSession) Add(ctx context.Context, index, docID, pipeline string, document io.WriterTo, dynamicTemplates map[string]string, action string) error {
+func (s *syncBulkIndexerSession) Add(ctx context.Context, index, docID, pipeline string, document io.WriterTo, dynamicTemplates map[string]string, action string, record sourceRecord) error {
+	var recording *recordingWriterTo
+	if s.records != nil && record != nil {
+		recording = &recordingWriterTo{WriterTo: document}
+		document = recording
+	}
 	doc := docappender.BulkIndexerItem{
 		Index:             index,
 		Body:              document,
@@ -228,6 +248,10 @@ func (s *syncBulkIndexerSession) Add(ctx context.Context, index, docID, pipeline
 	if err != nil {
 		return err
 	}
+	if recording != nil {
+		key := recording.document.String()
+		s.records[key] = append(s.records[key], record)
+	}
 	s.s.telemetryBuilder.ElasticsearchDocsReceived.Add(ctx, 1, s.docsReceivedAttr)
 	// sending_queue operates on flush sizes based on pdata model whereas bulk
 	// indexers operate on ndjson. Force a flush if the ndjson size is too large.
@@ -257,11 +281,14 @@ func (s *syncBulkIndexerSession) Flush(ctx context.Context) error {
 			s.s.failedDocsInputLogger,
 			s.s.getErrorHintFunc,
 			s.s.suppressConflictErrors,
+			s.s.deadLetter,
+			s.records,
 		); err != nil {
 			return err
 		}
 		if s.bi.Items() == 0 {
 			// No documents in buffer waiting for per-document retry, exit retry loop.
+			clear(s.records)
 			return nil
 		}
 		if retryBackoff == nil {
@@ -294,6 +321,8 @@ func flushBulkIndexer(
 	failedDocsInputLogger *zap.Logger,
 	getErrorHintFunc func(index, errorType string) string,
 	suppressConflictErrors bool,
+	deadLetter deadLetter,
+	records sourceRecords,
 ) error {
 	itemsCount := bi.Items()
 	if itemsCount == 0 {
@@ -377,6 +406,7 @@ func flushBulkIndexer(
 		tb.ElasticsearchBulkRequestsLatency.Record(ctx, latency, successAttrSet)
 	}
 
+	var deadLetterDocs []deadLetterDocument
 	for _, resp := range stat.FailedDocs {
 		// Collect telemetry
 		outcome := statusToOutcome(resp.Status)
@@ -398,6 +428,16 @@ func flushBulkIndexer(
 				// or globally suppressed by the user. Do not log them.
 				continue
 			}
+		} else if deadLetter != nil {
+			// Rejections of duplicates are not written to the dead letter,
+			// as the documents are already indexed.
+			doc := newDeadLetterDocument(resp, startTime)
+			record, err := records.take(doc.Document)
+			if err != nil {
+				logger.Error("failed to encode the source record of a rejected document", zap.Error(err))
+			}
+			doc.Record = record
+			deadLetterDocs = append(deadLetterDocs, doc)
 		}
 
 		// Log failed docs
@@ -420,6 +460,17 @@ func flushBulkIndexer(
 		}
 		failedDocsInputLogger.Debug("failed to index document; input may contain sensitive data", fields...)
 	}
+	if len(deadLetterDocs) > 0 {
+		if err := deadLetter.write(ctx, deadLetterDocs); err != nil {
+			logger.Error("failed to write rejected documents to dead letter",
+				zap.Int("documents", len(deadLetterDocs)), zap.Error(err),
+			)
+		} else {
+			tb.ElasticsearchDocsDeadLettered.Add(ctx, int64(len(deadLetterDocs)),
+				metric.WithAttributeSet(defaultAttrsSet),
+			)
+		}
+	}
 	if stat.Indexed > 0 {
 		tb.ElasticsearchDocsProcessed.Add(
 			ctx,
@@ -533,6 +584,9 @@ type bulkIndexers struct {
 	profilingStackFrames bulkIndexer // For profiling-stackframes
 	profilingExecutables bulkIndexer // For profiling-executables
 
+	// deadLetter receives the documents rejected by Elasticsearch, if configured.
+	deadLetter deadLetter
+
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -541,7 +595,9 @@ func (b *bulkIndexers) start(
 	cfg *Config,
 	set exporter.Settings,
 	host component.Host,
+	signal pipeline.Signal,
 	allowedMappingModes map[string]MappingMode,
+	reprocess func(context.Context, []string) error,
 ) error {
 	userAgent := fmt.Sprintf(
 		"%s/%s (%s/%s)",
@@ -556,12 +612,18 @@ func (b *bulkIndexers) start(
 		return err
 	}
 
+	deadLetter, err := newDeadLetter(ctx, esClient, cfg, set, host, signal, b.telemetryBuilder, reprocess)
+	if err != nil {
+		return fmt.Errorf("error starting dead letter: %w", err)
+	}
+	b.deadLetter = deadLetter
+
 	for _, mode := range allowedMappingModes {
 		requireDataStream := mode == MappingOTel || mode == MappingECS
 		modeSpecificErrorHintFunc := func(index, errorType string) string {
 			return getErrorHint(mode, index, errorType)
 		}
-		bi := newBulkIndexer(esClient, cfg, requireDataStream, b.telemetryBuilder, set.Logger, modeSpecificErrorHintFunc)
+		bi := newBulkIndexer(esClient, cfg, requireDataStream, b.telemetryBuilder, set.Logger, modeSpecificErrorHintFunc, deadLetter)
 		b.modes[mode] = &wgTrackingBulkIndexer{bulkIndexer: bi, wg: &b.wg}
 	}
 
@@ -569,21 +631,26 @@ func (b *bulkIndexers) start(
 		return getErrorHint(MappingNone, index, errorType)
 	}
 
-	profilingEvents := newBulkIndexer(esClient, cfg, true, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc)
+	profilingEvents := newBulkIndexer(esClient, cfg, true, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc, deadLetter)
 	b.profilingEvents = &wgTrackingBulkIndexer{bulkIndexer: profilingEvents, wg: &b.wg}
 
-	profilingStackTraces := newBulkIndexer(esClient, cfg, false, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc)
+	profilingStackTraces := newBulkIndexer(esClient, cfg, false, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc, deadLetter)
 	b.profilingStackTraces = &wgTrackingBulkIndexer{bulkIndexer: profilingStackTraces, wg: &b.wg}
 
-	profilingStackFrames := newBulkIndexer(esClient, cfg, false, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc)
+	profilingStackFrames := newBulkIndexer(esClient, cfg, false, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc, deadLetter)
 	b.profilingStackFrames = &wgTrackingBulkIndexer{bulkIndexer: profilingStackFrames, wg: &b.wg}
 
-	profilingExecutables := newBulkIndexer(esClient, cfg, false, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc)
+	profilingExecutables := newBulkIndexer(esClient, cfg, false, b.telemetryBuilder, set.Logger, mappingModeNoneErrorHintFunc, deadLetter)
 	b.profilingExecutables = &wgTrackingBulkIndexer{bulkIndexer: profilingExecutables, wg: &b.wg}
 	return nil
 }
 
 func (b *bulkIndexers) shutdown(ctx context.Context) error {
+	// The replay of the dead letter is stopped first, as it exports the
+	// source records of its documents through the bulk indexers.
+	if dl, ok := b.deadLetter.(*storageDeadLetter); ok {
+		dl.stopReplay()
+	}
 	for _, bi := range b.modes {
 		if bi == nil {
 			continue
@@ -623,6 +690,11 @@ func (b *bulkIndexers) shutdown(ctx context.Context) error {
 		return ctx.Err()
 	case <-doneCh:
 	}
+	// The dead letter is shut down once all sessions have ended,
+	// as they may write rejected documents to it.
+	if b.deadLetter != nil {
+		return b.deadLetter.shutdown(ctx)
+	}
 	return nil
 }
 
@@ -651,7 +723,7 @@ type errBulkIndexerSession struct {
 	err error
 }
 
-func (s errBulkIndexerSession) Add(context.Context, string, string, string, io.WriterTo, map[string]string, string) error {
+func (s errBulkIndexerSession) Add(context.Context, string, string, string, io.WriterTo, map[string]string, string, sourceRecord) error {
 	return fmt.Errorf("creating bulk indexer session failed, cannot add item: %w", s.err)
 }
 
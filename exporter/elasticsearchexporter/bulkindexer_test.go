@@ -115,12 +115,12 @@ func TestSyncBulkIndexer(t *testing.T) {
 			require.NoError(t, err)
 
 			core, observed := observer.New(zap.NewAtomicLevelAt(zapcore.DebugLevel))
-			bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil)
+			bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil, nil)
 
 			info := client.Info{Metadata: client.NewMetadata(map[string][]string{"x-test": {"test"}})}
 			ctx := client.NewContext(t.Context(), info)
 			session := bi.StartSession(ctx)
-			assert.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate))
+			assert.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate, nil))
 			assert.Equal(t, int64(0), reqCnt.Load()) // requests will not flush unless flush is called explicitly
 			assert.NoError(t, session.Flush(ctx))
 			assert.Equal(t, int64(1), reqCnt.Load())
@@ -269,7 +269,7 @@ func TestSyncBulkIndexerRequestRetriesMetric(t *testing.T) {
 			require.NoError(t, err)
 
 			core, _ := observer.New(zap.NewAtomicLevelAt(zapcore.DebugLevel))
-			bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil)
+			bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil, nil)
 
 			info := client.Info{Metadata: client.NewMetadata(map[string][]string{"x-test": {"test"}})}
 			ctx := client.NewContext(t.Context(), info)
@@ -277,7 +277,7 @@ func TestSyncBulkIndexerRequestRetriesMetric(t *testing.T) {
 
 			// Add multiple documents to test batch retry count
 			for i := 0; i < tt.docsCount; i++ {
-				assert.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate))
+				assert.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate, nil))
 			}
 
 			assert.Equal(t, int64(0), reqCnt.Load()) // requests will not flush unless flush is called explicitly
@@ -348,10 +348,10 @@ func TestSyncBulkIndexerFlushErrorPermanence(t *testing.T) {
 			)
 			require.NoError(t, err)
 
-			bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.NewNop(), nil)
+			bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.NewNop(), nil, nil)
 
 			session := bi.StartSession(t.Context())
-			require.NoError(t, session.Add(t.Context(), "foo", "", "", strings.NewReader(`{"foo":"bar"}`), nil, docappender.ActionCreate))
+			require.NoError(t, session.Add(t.Context(), "foo", "", "", strings.NewReader(`{"foo":"bar"}`), nil, docappender.ActionCreate, nil))
 
 			err = session.Flush(t.Context())
 			require.Error(t, err)
@@ -402,14 +402,14 @@ func TestBulkIndexerLogsStatusCode(t *testing.T) {
 	require.NoError(t, err)
 
 	core, observed := observer.New(zap.NewAtomicLevelAt(zapcore.DebugLevel))
-	bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil)
+	bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil, nil)
 
 	ctx := t.Context()
 	session := bi.StartSession(ctx)
 	// Add initial document to ensure we have at least one document to process.
-	require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate))
+	require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate, nil))
 	for range statuses {
-		require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate))
+		require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate, nil))
 	}
 	require.NoError(t, session.Flush(ctx))
 	session.End()
@@ -467,7 +467,7 @@ func TestNewBulkIndexer(t *testing.T) {
 	client, err := newElasticsearchClient(t.Context(), cfg, componenttest.NewNopHost(), componenttest.NewTelemetry().NewTelemetrySettings(), "")
 	require.NoError(t, err)
 
-	bi := newBulkIndexer(client, cfg, true, nil, nil, nil)
+	bi := newBulkIndexer(client, cfg, true, nil, nil, nil, nil)
 	t.Cleanup(func() { bi.Close(t.Context()) })
 }
 
@@ -525,10 +525,10 @@ func TestBulkResponseFilterPath(t *testing.T) {
 			tb, err := metadata.NewTelemetryBuilder(metadatatest.NewSettings(ct).TelemetrySettings)
 			require.NoError(t, err)
 
-			syncBI := newSyncBulkIndexer(esClient, cfg, false, tb, zaptest.NewLogger(t), nil)
+			syncBI := newSyncBulkIndexer(esClient, cfg, false, tb, zaptest.NewLogger(t), nil, nil)
 			ctx := t.Context()
 			session := syncBI.StartSession(ctx)
-			require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate))
+			require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate, nil))
 			require.NoError(t, session.Flush(ctx))
 			session.End()
 			require.NoError(t, syncBI.Close(ctx))
@@ -708,11 +708,11 @@ func TestSyncBulkIndexer_SuppressConflictErrors(t *testing.T) {
 	require.NoError(t, err)
 
 	core, observed := observer.New(zap.NewAtomicLevelAt(zapcore.DebugLevel))
-	bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil)
+	bi := newSyncBulkIndexer(esClient, &cfg, false, tb, zap.New(core), nil, nil)
 
 	ctx := t.Context()
 	session := bi.StartSession(ctx)
-	require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate))
+	require.NoError(t, session.Add(ctx, "foo", "", "", strings.NewReader(`{"foo": "bar"}`), nil, docappender.ActionCreate, nil))
 
 	require.NoError(t, session.Flush(ctx))
 	session.End()
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -93,6 +94,10 @@ type Config struct {
 	// If set to true, document level version conflict exceptions (409) will not be logged.
 	SuppressConflictErrors bool `mapstructure:"suppress_conflict_errors"`
 
+	// DeadLetter configures where the documents rejected by Elasticsearch are
+	// written to, instead of being dropped.
+	DeadLetter DeadLetterSettings `mapstructure:"dead_letter"`
+
 	// TelemetrySettings contains settings useful for testing/debugging purposes.
 	// This is experimental and may change at any time.
 	TelemetrySettings TelemetrySettings `mapstructure:"telemetry"`
@@ -152,6 +157,37 @@ type TelemetrySettings struct {
 	_ struct{}
 }
 
+// DeadLetterSettings defines the dead letter of the documents rejected by
+// Elasticsearch, e.g. because of mapping conflicts. Rejected documents are
+// written to either an index or a storage extension, along with their original
+// index, bulk action and rejection error.
+//
+// The dead letter is disabled if neither Index nor Storage is set.
+type DeadLetterSettings struct {
+	// Index is the index the rejected documents are written to.
+	Index string `mapstructure:"index"`
+
+	// Storage is the ID of the storage extension the rejected documents are written to.
+	Storage *component.ID `mapstructure:"storage"`
+
+	// ReplayInterval is the interval at which the documents of the storage are
+	// exported again, e.g. once their mappings are fixed. Documents rejected
+	// again are kept in the storage. Zero disables the replay.
+	ReplayInterval time.Duration `mapstructure:"replay_interval"`
+
+	// ReplayMaxAttempts is the number of times a document of the storage is
+	// replayed before it is dropped, if it keeps being rejected. Zero replays
+	// the documents until they are accepted.
+	ReplayMaxAttempts int `mapstructure:"replay_max_attempts"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (s DeadLetterSettings) enabled() bool {
+	return s.Index != "" || s.Storage != nil
+}
+
 type LogstashFormatSettings struct {
 	Enabled         bool   `mapstructure:"enabled"`
 	PrefixSeparator string `mapstructure:"prefix_separator"`
@@ -406,6 +442,19 @@ func (cfg *Config) Validate() error {
 		return errors.New("must not specify both traces_index and traces_dynamic_index; traces_index should be empty unless all documents should be sent to the same index")
 	}
 
+	if cfg.DeadLetter.Index != "" && cfg.DeadLetter.Storage != nil {
+		return errors.New("must not specify both dead_letter::index and dead_letter::storage")
+	}
+	if cfg.DeadLetter.ReplayInterval < 0 {
+		return errors.New("dead_letter::replay_interval should be non-negative")
+	}
+	if cfg.DeadLetter.ReplayInterval > 0 && cfg.DeadLetter.Storage == nil {
+		return errors.New("dead_letter::replay_interval requires dead_letter::storage")
+	}
+	if cfg.DeadLetter.ReplayMaxAttempts < 0 {
+		return errors.New("dead_letter::replay_max_attempts should be non-negative")
+	}
+
 	uniq := map[string]struct{}{}
 	for i, k := range cfg.MetadataKeys {
 		kl := strings.ToLower(k)
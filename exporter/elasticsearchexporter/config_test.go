@@ -143,6 +143,9 @@ func TestConfig(t *testing.T) {
 					PrefixSeparator: "-",
 					DateFormat:      "%Y.%m.%d",
 				},
+				DeadLetter: DeadLetterSettings{
+					ReplayMaxAttempts: 10,
+				},
 				TelemetrySettings: TelemetrySettings{
 					LogFailedDocsInputRateLimit: time.Second,
 				},
@@ -220,6 +223,9 @@ func TestConfig(t *testing.T) {
 					PrefixSeparator: "-",
 					DateFormat:      "%Y.%m.%d",
 				},
+				DeadLetter: DeadLetterSettings{
+					ReplayMaxAttempts: 10,
+				},
 				TelemetrySettings: TelemetrySettings{
 					LogFailedDocsInputRateLimit: time.Second,
 				},
@@ -297,6 +303,9 @@ func TestConfig(t *testing.T) {
 					PrefixSeparator: "-",
 					DateFormat:      "%Y.%m.%d",
 				},
+				DeadLetter: DeadLetterSettings{
+					ReplayMaxAttempts: 10,
+				},
 				TelemetrySettings: TelemetrySettings{
 					LogFailedDocsInputRateLimit: time.Second,
 				},
@@ -453,6 +462,25 @@ func TestConfig(t *testing.T) {
 				cfg.Retry.RetryOnDocumentStatus = []int{}
 			}),
 		},
+		{
+			id:         component.NewIDWithName(metadata.Type, "dead_letter_index"),
+			configFile: "config.yaml",
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "https://elastic.example.com:9200"
+				cfg.DeadLetter.Index = "otel-dead-letter"
+			}),
+		},
+		{
+			id:         component.NewIDWithName(metadata.Type, "dead_letter_storage"),
+			configFile: "config.yaml",
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "https://elastic.example.com:9200"
+				storageID := component.MustNewID("file_storage")
+				cfg.DeadLetter.Storage = &storageID
+				cfg.DeadLetter.ReplayInterval = 5 * time.Minute
+				cfg.DeadLetter.ReplayMaxAttempts = 3
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -559,6 +587,37 @@ func TestConfig_Validate(t *testing.T) {
 			}),
 			err: `metadata_keys must be case-insenstive and unique, found duplicate: x-test-1`,
 		},
+		"both dead_letter index and storage specified": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				storageID := component.MustNewID("file_storage")
+				cfg.DeadLetter.Index = "otel-dead-letter"
+				cfg.DeadLetter.Storage = &storageID
+			}),
+			err: `must not specify both dead_letter::index and dead_letter::storage`,
+		},
+		"dead_letter replay_interval without storage": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				cfg.DeadLetter.Index = "otel-dead-letter"
+				cfg.DeadLetter.ReplayInterval = time.Minute
+			}),
+			err: `dead_letter::replay_interval requires dead_letter::storage`,
+		},
+		"negative dead_letter replay_interval": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				cfg.DeadLetter.ReplayInterval = -time.Minute
+			}),
+			err: `dead_letter::replay_interval should be non-negative`,
+		},
+		"negative dead_letter replay_max_attempts": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				cfg.DeadLetter.ReplayMaxAttempts = -1
+			}),
+			err: `dead_letter::replay_max_attempts should be non-negative`,
+		},
 	}
 
 	for name, tt := range tests {
@@ -0,0 +1,544 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+	"github.com/elastic/go-docappender/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/datapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/metadata"
+)
+
+const (
+	deadLetterReadIndexKey  = "dead_letter_read_index"
+	deadLetterWriteIndexKey = "dead_letter_write_index"
+	deadLetterKeyPrefix     = "dead_letter_"
+
+	// deadLetterReplayBatchSize is the number of documents of the storage
+	// indexed by each bulk request of a replay.
+	deadLetterReplayBatchSize = 1000
+)
+
+// deadLetter receives the documents rejected by Elasticsearch.
+type deadLetter interface {
+	// write writes the rejected documents to the dead letter.
+	write(ctx context.Context, docs []deadLetterDocument) error
+
+	// shutdown stops any background processing, and releases the
+	// resources of the dead letter.
+	shutdown(ctx context.Context) error
+}
+
+// deadLetterDocument wraps a document rejected by Elasticsearch. The rejected
+// document, its bulk action line and the telemetry record it was encoded from
+// are kept as strings, so that they are not subject to the mappings of the dead
+// letter index, and can be exported again as they were originally sent.
+type deadLetterDocument struct {
+	Timestamp   time.Time `json:"@timestamp"`
+	Index       string    `json:"elasticsearch.index"`
+	Action      string    `json:"elasticsearch.action"`
+	Document    string    `json:"document"`
+	StatusCode  int       `json:"http.response.status_code"`
+	ErrorType   string    `json:"error.type"`
+	ErrorReason string    `json:"error.reason,omitempty"`
+
+	// Record is the OTLP/JSON encoded telemetry record the document was
+	// encoded from, along with its resource and scope, if any.
+	Record string `json:"otlp.record,omitempty"`
+
+	// ReplayAttempts is the number of times the document was replayed from
+	// the storage, and rejected again.
+	ReplayAttempts int `json:"dead_letter.replay_attempts,omitempty"`
+}
+
+func newDeadLetterDocument(item docappender.BulkIndexerResponseItem, timestamp time.Time) deadLetterDocument {
+	// The input holds the action line and the document line of the item.
+	action, document, _ := strings.Cut(strings.TrimSuffix(item.Input, "\n"), "\n")
+	return deadLetterDocument{
+		Timestamp:   timestamp,
+		Index:       item.Index,
+		Action:      action,
+		Document:    document,
+		StatusCode:  item.Status,
+		ErrorType:   item.Error.Type,
+		ErrorReason: item.Error.Reason,
+	}
+}
+
+// replayKey identifies the document across its replays.
+func (doc deadLetterDocument) replayKey() string {
+	if doc.Record != "" {
+		return doc.Record
+	}
+	return doc.Document
+}
+
+// sourceRecord encodes the telemetry record a document was encoded from, along
+// with its resource and scope, as OTLP/JSON. It is only called for the documents
+// written to the dead letter, while the record is being exported.
+type sourceRecord func() ([]byte, error)
+
+// sourceRecords holds the source records of the documents added to a bulk
+// indexer session, by document.
+type sourceRecords map[string][]sourceRecord
+
+// take removes and encodes the source record of a document, if any.
+func (r sourceRecords) take(document string) (string, error) {
+	records := r[document]
+	if len(records) == 0 {
+		return "", nil
+	}
+	if len(records) == 1 {
+		delete(r, document)
+	} else {
+		r[document] = records[1:]
+	}
+	record, err := records[0]()
+	if err != nil {
+		return "", err
+	}
+	return string(record), nil
+}
+
+// recordingWriterTo records the document written to the bulk indexer, to
+// find its source record once it has been rejected.
+type recordingWriterTo struct {
+	io.WriterTo
+	document bytes.Buffer
+}
+
+func (w *recordingWriterTo) WriteTo(dst io.Writer) (int64, error) {
+	return w.WriterTo.WriteTo(io.MultiWriter(dst, &w.document))
+}
+
+func logSourceRecord(ec encodingContext, record plog.LogRecord) sourceRecord {
+	return func() ([]byte, error) {
+		ld := plog.NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		ec.resource.CopyTo(rl.Resource())
+		rl.SetSchemaUrl(ec.resourceSchemaURL)
+		sl := rl.ScopeLogs().AppendEmpty()
+		ec.scope.CopyTo(sl.Scope())
+		sl.SetSchemaUrl(ec.scopeSchemaURL)
+		record.CopyTo(sl.LogRecords().AppendEmpty())
+		return (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	}
+}
+
+func spanSourceRecord(ec encodingContext, span ptrace.Span) sourceRecord {
+	return func() ([]byte, error) {
+		td := ptrace.NewTraces()
+		rs := td.ResourceSpans().AppendEmpty()
+		ec.resource.CopyTo(rs.Resource())
+		rs.SetSchemaUrl(ec.resourceSchemaURL)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ec.scope.CopyTo(ss.Scope())
+		ss.SetSchemaUrl(ec.scopeSchemaURL)
+		span.CopyTo(ss.Spans().AppendEmpty())
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	}
+}
+
+// dataPointsSourceRecord encodes the data points of a document, each within a
+// copy of its metric.
+func dataPointsSourceRecord(ec encodingContext, dataPoints []datapoints.DataPoint) sourceRecord {
+	return func() ([]byte, error) {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		ec.resource.CopyTo(rm.Resource())
+		rm.SetSchemaUrl(ec.resourceSchemaURL)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		ec.scope.CopyTo(sm.Scope())
+		sm.SetSchemaUrl(ec.scopeSchemaURL)
+		for _, dp := range dataPoints {
+			appendDataPointMetric(sm.Metrics(), dp)
+		}
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	}
+}
+
+func appendDataPointMetric(metrics pmetric.MetricSlice, dp datapoints.DataPoint) {
+	src := dp.Metric()
+	m := metrics.AppendEmpty()
+	m.SetName(src.Name())
+	m.SetDescription(src.Description())
+	m.SetUnit(src.Unit())
+	src.Metadata().CopyTo(m.Metadata())
+	switch dp := dp.(type) {
+	case datapoints.Number:
+		if src.Type() == pmetric.MetricTypeSum {
+			sum := m.SetEmptySum()
+			sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+			sum.SetIsMonotonic(src.Sum().IsMonotonic())
+			dp.NumberDataPoint.CopyTo(sum.DataPoints().AppendEmpty())
+		} else {
+			dp.NumberDataPoint.CopyTo(m.SetEmptyGauge().DataPoints().AppendEmpty())
+		}
+	case datapoints.Histogram:
+		histogram := m.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(src.Histogram().AggregationTemporality())
+		dp.HistogramDataPoint.CopyTo(histogram.DataPoints().AppendEmpty())
+	case datapoints.ExponentialHistogram:
+		histogram := m.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+		dp.ExponentialHistogramDataPoint.CopyTo(histogram.DataPoints().AppendEmpty())
+	case datapoints.Summary:
+		dp.SummaryDataPoint.CopyTo(m.SetEmptySummary().DataPoints().AppendEmpty())
+	}
+}
+
+// bulkActionMetadata holds the metadata of a bulk action line.
+type bulkActionMetadata struct {
+	Index             string            `json:"_index"`
+	DocumentID        string            `json:"_id"`
+	Pipeline          string            `json:"pipeline"`
+	DynamicTemplates  map[string]string `json:"dynamic_templates"`
+	RequireDataStream bool              `json:"require_data_stream"`
+}
+
+// parseBulkAction returns the action and metadata of a bulk action line,
+// e.g. {"create":{"_index":"logs-generic-default"}}.
+func parseBulkAction(line string) (string, bulkActionMetadata, error) {
+	var actions map[string]bulkActionMetadata
+	if err := json.Unmarshal([]byte(line), &actions); err != nil {
+		return "", bulkActionMetadata{}, fmt.Errorf("invalid bulk action %q: %w", line, err)
+	}
+	if len(actions) != 1 {
+		return "", bulkActionMetadata{}, fmt.Errorf("invalid bulk action %q: expected a single action", line)
+	}
+	for action, meta := range actions {
+		return action, meta, nil
+	}
+	return "", bulkActionMetadata{}, nil
+}
+
+func newDeadLetter(
+	ctx context.Context,
+	client elastictransport.Interface,
+	config *Config,
+	set exporter.Settings,
+	host component.Host,
+	signal pipeline.Signal,
+	tb *metadata.TelemetryBuilder,
+	reprocess func(context.Context, []string) error,
+) (deadLetter, error) {
+	switch {
+	case config.DeadLetter.Index != "":
+		return &indexDeadLetter{
+			index:       config.DeadLetter.Index,
+			bulkIndexer: newBulkIndexer(client, config, false, tb, set.Logger, nil, nil),
+		}, nil
+	case config.DeadLetter.Storage != nil:
+		storageClient, err := getStorageClient(ctx, host, *config.DeadLetter.Storage, set.ID, signal)
+		if err != nil {
+			return nil, err
+		}
+		dl := &storageDeadLetter{
+			client:            storageClient,
+			logger:            set.Logger,
+			replayMaxAttempts: config.DeadLetter.ReplayMaxAttempts,
+			reprocess:         reprocess,
+		}
+		if err := dl.loadIndices(ctx); err != nil {
+			return nil, errors.Join(err, storageClient.Close(ctx))
+		}
+		if config.DeadLetter.ReplayInterval > 0 {
+			// Documents rejected again while being replayed are written back to the storage.
+			dl.replayIndexers = [2]bulkIndexer{
+				newBulkIndexer(client, config, false, tb, set.Logger, nil, dl),
+				newBulkIndexer(client, config, true, tb, set.Logger, nil, dl),
+			}
+			dl.startReplay(config.DeadLetter.ReplayInterval)
+		}
+		return dl, nil
+	}
+	return nil, nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, signal pipeline.Signal) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension %q found", storageID)
+	}
+	// The exporters of the different signals share the component ID.
+	return storageExt.GetClient(ctx, component.KindExporter, componentID, signal.String()+"_dead_letter")
+}
+
+// indexDeadLetter indexes the rejected documents to an index.
+type indexDeadLetter struct {
+	index       string
+	bulkIndexer bulkIndexer
+}
+
+func (d *indexDeadLetter) write(ctx context.Context, docs []deadLetterDocument) error {
+	session := d.bulkIndexer.StartSession(ctx)
+	defer session.End()
+	for _, doc := range docs {
+		body, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if err := session.Add(ctx, d.index, "", "", bytes.NewReader(body), nil, docappender.ActionCreate, nil); err != nil {
+			return err
+		}
+	}
+	return session.Flush(ctx)
+}
+
+func (d *indexDeadLetter) shutdown(ctx context.Context) error {
+	return d.bulkIndexer.Close(ctx)
+}
+
+// storageDeadLetter writes the rejected documents to a storage extension, as a
+// queue of documents, and optionally replays them to their original index.
+type storageDeadLetter struct {
+	client storage.Client
+	logger *zap.Logger
+
+	// replayMaxAttempts is the number of replays of a document after
+	// which it is dropped, unlimited if zero.
+	replayMaxAttempts int
+
+	// replayIndexers index the replayed documents without a source
+	// record, depending on whether they require a data stream.
+	replayIndexers [2]bulkIndexer
+	// reprocess exports the source records of the replayed documents
+	// again, if any.
+	reprocess func(context.Context, []string) error
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+
+	// mu protects the indices of the queue. The read index is only
+	// advanced by the replay, which runs in a single goroutine.
+	mu         sync.Mutex
+	readIndex  uint64
+	writeIndex uint64
+}
+
+func deadLetterKey(index uint64) string {
+	return deadLetterKeyPrefix + strconv.FormatUint(index, 10)
+}
+
+func (d *storageDeadLetter) loadIndices(ctx context.Context) error {
+	readOp := storage.GetOperation(deadLetterReadIndexKey)
+	writeOp := storage.GetOperation(deadLetterWriteIndexKey)
+	if err := d.client.Batch(ctx, readOp, writeOp); err != nil {
+		return fmt.Errorf("failed to load dead letter indices: %w", err)
+	}
+	var err error
+	if d.readIndex, err = parseDeadLetterIndex(readOp.Value); err != nil {
+		return err
+	}
+	if d.writeIndex, err = parseDeadLetterIndex(writeOp.Value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func parseDeadLetterIndex(value []byte) (uint64, error) {
+	if value == nil {
+		return 0, nil
+	}
+	index, err := strconv.ParseUint(string(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid dead letter index %q: %w", value, err)
+	}
+	return index, nil
+}
+
+// replayAttemptsKey is the context key of the replay attempts of the
+// documents being replayed, by replay key.
+type replayAttemptsKey struct{}
+
+func (d *storageDeadLetter) write(ctx context.Context, docs []deadLetterDocument) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// The documents rejected again while being replayed keep their
+	// number of replay attempts.
+	if attempts, ok := ctx.Value(replayAttemptsKey{}).(map[string]int); ok {
+		for i := range docs {
+			docs[i].ReplayAttempts = attempts[docs[i].replayKey()]
+		}
+	}
+	ops := make([]*storage.Operation, 0, len(docs)+1)
+	for i, doc := range docs {
+		value, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, storage.SetOperation(deadLetterKey(d.writeIndex+uint64(i)), value))
+	}
+	writeIndex := d.writeIndex + uint64(len(docs))
+	ops = append(ops, storage.SetOperation(deadLetterWriteIndexKey, []byte(strconv.FormatUint(writeIndex, 10))))
+	if err := d.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	d.writeIndex = writeIndex
+	return nil
+}
+
+func (d *storageDeadLetter) startReplay(interval time.Duration) {
+	var ctx context.Context
+	ctx, d.cancel = context.WithCancel(context.Background())
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := d.replay(ctx); err != nil && ctx.Err() == nil {
+					d.logger.Error("failed to replay dead letter documents", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// replay exports the source records of the documents of the storage again,
+// or indexes the documents without a source record to their original index,
+// in batches. The documents of a batch are removed from the storage once they
+// are exported, the rejected ones having been written back to it.
+func (d *storageDeadLetter) replay(ctx context.Context) error {
+	d.mu.Lock()
+	end := d.writeIndex
+	d.mu.Unlock()
+
+	for start := d.readIndex; start < end; start += deadLetterReplayBatchSize {
+		batchEnd := min(start+deadLetterReplayBatchSize, end)
+		if err := d.replayBatch(ctx, start, batchEnd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *storageDeadLetter) replayBatch(ctx context.Context, start, end uint64) error {
+	ops := make([]*storage.Operation, 0, end-start)
+	for index := start; index < end; index++ {
+		ops = append(ops, storage.GetOperation(deadLetterKey(index)))
+	}
+	if err := d.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+	attempts := make(map[string]int, len(ops))
+	ctx = context.WithValue(ctx, replayAttemptsKey{}, attempts)
+	var records []string
+
+	var sessions [2]bulkIndexerSession
+	defer func() {
+		for _, session := range sessions {
+			if session != nil {
+				session.End()
+			}
+		}
+	}()
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		var doc deadLetterDocument
+		if err := json.Unmarshal(op.Value, &doc); err != nil {
+			d.logger.Error("dropping invalid dead letter document", zap.String("key", op.Key), zap.Error(err))
+			continue
+		}
+		action, meta, err := parseBulkAction(doc.Action)
+		if err != nil {
+			d.logger.Error("dropping invalid dead letter document", zap.String("key", op.Key), zap.Error(err))
+			continue
+		}
+		if d.replayMaxAttempts > 0 && doc.ReplayAttempts >= d.replayMaxAttempts {
+			d.logger.Error("dropping dead letter document rejected too many times",
+				zap.String("key", op.Key),
+				zap.String("index", meta.Index),
+				zap.Int("replay_attempts", doc.ReplayAttempts),
+				zap.String("error.type", doc.ErrorType),
+			)
+			continue
+		}
+		attempts[doc.replayKey()] = doc.ReplayAttempts + 1
+		if doc.Record != "" && d.reprocess != nil {
+			records = append(records, doc.Record)
+			continue
+		}
+		i := 0
+		if meta.RequireDataStream {
+			i = 1
+		}
+		if sessions[i] == nil {
+			sessions[i] = d.replayIndexers[i].StartSession(ctx)
+		}
+		if err := sessions[i].Add(ctx, meta.Index, meta.DocumentID, meta.Pipeline, strings.NewReader(doc.Document), meta.DynamicTemplates, action, nil); err != nil {
+			return err
+		}
+	}
+	for _, session := range sessions {
+		if session == nil {
+			continue
+		}
+		if err := session.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	if len(records) > 0 {
+		if err := d.reprocess(ctx, records); err != nil {
+			return err
+		}
+	}
+
+	deleteOps := make([]*storage.Operation, 0, end-start+1)
+	for index := start; index < end; index++ {
+		deleteOps = append(deleteOps, storage.DeleteOperation(deadLetterKey(index)))
+	}
+	deleteOps = append(deleteOps, storage.SetOperation(deadLetterReadIndexKey, []byte(strconv.FormatUint(end, 10))))
+	if err := d.client.Batch(ctx, deleteOps...); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.readIndex = end
+	d.mu.Unlock()
+	return nil
+}
+
+// stopReplay stops the replay, and waits for the documents being replayed
+// to be exported.
+func (d *storageDeadLetter) stopReplay() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+func (d *storageDeadLetter) shutdown(ctx context.Context) error {
+	d.stopReplay()
+	return d.client.Close(ctx)
+}
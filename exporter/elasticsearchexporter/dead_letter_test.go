@@ -0,0 +1,497 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+	"github.com/elastic/go-docappender/v2"
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/datapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/metadatatest"
+)
+
+// deadLetterTestTransport records the bulk requests, and responds to them with
+// the statuses returned by statusFunc for each of their items.
+type deadLetterTestTransport struct {
+	statusFunc func(action bulkActionMetadata) (int, string)
+
+	mu       sync.Mutex
+	requests [][]deadLetterTestItem
+}
+
+type deadLetterTestItem struct {
+	action   string
+	document string
+}
+
+func (tr *deadLetterTestTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var items []deadLetterTestItem
+	var responses []map[string]docappender.BulkIndexerResponseItem
+	body := r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		var err error
+		if body, err = gzip.NewReader(r.Body); err != nil {
+			return nil, err
+		}
+	}
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		action := scanner.Text()
+		scanner.Scan()
+		items = append(items, deadLetterTestItem{action: action, document: scanner.Text()})
+
+		_, meta, err := parseBulkAction(action)
+		if err != nil {
+			return nil, err
+		}
+		var response docappender.BulkIndexerResponseItem
+		response.Index = meta.Index
+		response.Status, response.Error.Type = tr.statusFunc(meta)
+		if response.Error.Type != "" {
+			response.Error.Reason = "rejected"
+		}
+		responses = append(responses, map[string]docappender.BulkIndexerResponseItem{docappender.ActionCreate: response})
+	}
+
+	respBody, err := json.Marshal(map[string]any{"items": responses})
+	if err != nil {
+		return nil, err
+	}
+
+	tr.mu.Lock()
+	tr.requests = append(tr.requests, items)
+	tr.mu.Unlock()
+	return &http.Response{
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+		Body:       io.NopCloser(bytes.NewReader(respBody)),
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (tr *deadLetterTestTransport) items() []deadLetterTestItem {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	var items []deadLetterTestItem
+	for _, request := range tr.requests {
+		items = append(items, request...)
+	}
+	return items
+}
+
+func newDeadLetterTestClient(t *testing.T, tr *deadLetterTestTransport) elastictransport.Interface {
+	client, err := elastictransport.New(elastictransport.Config{
+		URLs:      []*url.URL{{Scheme: "http", Host: "localhost:9200"}},
+		Transport: tr,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestIndexDeadLetter(t *testing.T) {
+	tr := &deadLetterTestTransport{statusFunc: func(action bulkActionMetadata) (int, string) {
+		switch action.DocumentID {
+		case "rejected":
+			return http.StatusBadRequest, "document_parsing_exception"
+		case "duplicate":
+			return http.StatusConflict, "version_conflict_engine_exception"
+		}
+		return http.StatusCreated, ""
+	}}
+	client := newDeadLetterTestClient(t, tr)
+
+	includeSourceOnError := true
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.IncludeSourceOnError = &includeSourceOnError
+		cfg.DeadLetter.Index = "otel-dead-letter"
+	})
+	ct := componenttest.NewTelemetry()
+	tb, err := metadata.NewTelemetryBuilder(metadatatest.NewSettings(ct).TelemetrySettings)
+	require.NoError(t, err)
+
+	dl := &indexDeadLetter{
+		index:       cfg.DeadLetter.Index,
+		bulkIndexer: newSyncBulkIndexer(client, cfg, false, tb, zap.NewNop(), nil, nil),
+	}
+	bi := newSyncBulkIndexer(client, cfg, false, tb, zap.NewNop(), nil, dl)
+
+	session := bi.StartSession(t.Context())
+	for _, id := range []string{"indexed", "rejected", "duplicate"} {
+		record := func() ([]byte, error) { return []byte(`{"record":"` + id + `"}`), nil }
+		require.NoError(t, session.Add(t.Context(), "logs-generic-default", id, "", strings.NewReader(`{"id":"`+id+`"}`), nil, docappender.ActionCreate, record))
+	}
+	require.NoError(t, session.Flush(t.Context()))
+	session.End()
+	require.NoError(t, bi.Close(t.Context()))
+	require.NoError(t, dl.shutdown(t.Context()))
+
+	// Rejections of duplicates are not written to the dead letter.
+	items := tr.items()
+	require.Len(t, items, 4)
+	assert.JSONEq(t, `{"create":{"_index":"otel-dead-letter"}}`, items[3].action)
+
+	var doc deadLetterDocument
+	require.NoError(t, json.Unmarshal([]byte(items[3].document), &doc))
+	assert.False(t, doc.Timestamp.IsZero())
+	doc.Timestamp = doc.Timestamp.UTC()
+	assert.Equal(t, deadLetterDocument{
+		Timestamp:   doc.Timestamp,
+		Index:       "logs-generic-default",
+		Action:      items[1].action,
+		Document:    `{"id":"rejected"}`,
+		StatusCode:  http.StatusBadRequest,
+		ErrorType:   "document_parsing_exception",
+		ErrorReason: "rejected",
+		Record:      `{"record":"rejected"}`,
+	}, doc)
+
+	metadatatest.AssertEqualElasticsearchDocsDeadLettered(t, ct, []metricdata.DataPoint[int64]{
+		{Value: 1},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestStorageDeadLetter(t *testing.T) {
+	var reject atomic.Bool
+	reject.Store(true)
+	tr := &deadLetterTestTransport{statusFunc: func(bulkActionMetadata) (int, string) {
+		if reject.Load() {
+			return http.StatusBadRequest, "document_parsing_exception"
+		}
+		return http.StatusCreated, ""
+	}}
+	client := newDeadLetterTestClient(t, tr)
+
+	storageID := component.MustNewID("file_storage")
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.DeadLetter.Storage = &storageID
+	})
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	storageClient := &pqStorageClient{data: make(map[string][]byte)}
+	dl := newTestStorageDeadLetter(t, client, cfg, storageClient)
+
+	// The rejected documents are written to the storage.
+	for _, requireDataStream := range []bool{false, true} {
+		bi := newSyncBulkIndexer(client, cfg, requireDataStream, tb, zap.NewNop(), nil, dl)
+		session := bi.StartSession(t.Context())
+		require.NoError(t, session.Add(t.Context(), "metrics-generic-default", "", "pipeline", strings.NewReader(`{"a":1}`), map[string]string{"a": "summary"}, docappender.ActionCreate, nil))
+		require.NoError(t, session.Flush(t.Context()))
+		session.End()
+	}
+	original := tr.items()
+	require.Len(t, original, 2)
+	assert.Len(t, storageClient.data, 3)
+	assert.Equal(t, []byte("2"), storageClient.data[deadLetterWriteIndexKey])
+
+	// The documents rejected again while replayed are written back to the storage.
+	require.NoError(t, dl.replay(t.Context()))
+	assert.Len(t, tr.items(), 4)
+	assert.NotContains(t, storageClient.data, deadLetterKey(0))
+	assert.NotContains(t, storageClient.data, deadLetterKey(1))
+	assert.Contains(t, storageClient.data, deadLetterKey(2))
+	assert.Contains(t, storageClient.data, deadLetterKey(3))
+	assert.Equal(t, []byte("2"), storageClient.data[deadLetterReadIndexKey])
+	var doc deadLetterDocument
+	require.NoError(t, json.Unmarshal(storageClient.data[deadLetterKey(2)], &doc))
+	assert.Equal(t, 1, doc.ReplayAttempts)
+
+	// The documents are indexed as originally sent once accepted.
+	reject.Store(false)
+	require.NoError(t, dl.replay(t.Context()))
+	items := tr.items()
+	require.Len(t, items, 6)
+	for i, item := range items[4:] {
+		assert.JSONEq(t, original[i].action, item.action)
+		assert.JSONEq(t, original[i].document, item.document)
+	}
+	assert.Equal(t, map[string][]byte{
+		deadLetterReadIndexKey:  []byte("4"),
+		deadLetterWriteIndexKey: []byte("4"),
+	}, storageClient.data)
+
+	// The indices are restored from the storage.
+	restored := &storageDeadLetter{client: storageClient, logger: zap.NewNop()}
+	require.NoError(t, restored.loadIndices(t.Context()))
+	assert.Equal(t, uint64(4), restored.readIndex)
+	assert.Equal(t, uint64(4), restored.writeIndex)
+	require.NoError(t, dl.shutdown(t.Context()))
+}
+
+func TestStorageDeadLetterReplayMaxAttempts(t *testing.T) {
+	tr := &deadLetterTestTransport{statusFunc: func(bulkActionMetadata) (int, string) {
+		return http.StatusBadRequest, "document_parsing_exception"
+	}}
+	client := newDeadLetterTestClient(t, tr)
+
+	storageID := component.MustNewID("file_storage")
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.DeadLetter.Storage = &storageID
+		cfg.DeadLetter.ReplayMaxAttempts = 2
+	})
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	storageClient := &pqStorageClient{data: make(map[string][]byte)}
+	dl := newTestStorageDeadLetter(t, client, cfg, storageClient)
+
+	bi := newSyncBulkIndexer(client, cfg, false, tb, zap.NewNop(), nil, dl)
+	session := bi.StartSession(t.Context())
+	require.NoError(t, session.Add(t.Context(), "logs-generic-default", "", "", strings.NewReader(`{"a":1}`), nil, docappender.ActionCreate, nil))
+	require.NoError(t, session.Flush(t.Context()))
+	session.End()
+
+	// The document is replayed until it has been rejected replay_max_attempts times, then dropped.
+	for range 3 {
+		require.NoError(t, dl.replay(t.Context()))
+	}
+	assert.Len(t, tr.items(), 3)
+	assert.Equal(t, map[string][]byte{
+		deadLetterReadIndexKey:  []byte("3"),
+		deadLetterWriteIndexKey: []byte("3"),
+	}, storageClient.data)
+	require.NoError(t, dl.shutdown(t.Context()))
+}
+
+func TestStorageDeadLetterReprocess(t *testing.T) {
+	tr := &deadLetterTestTransport{statusFunc: func(bulkActionMetadata) (int, string) {
+		return http.StatusBadRequest, "document_parsing_exception"
+	}}
+	client := newDeadLetterTestClient(t, tr)
+
+	storageID := component.MustNewID("file_storage")
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.DeadLetter.Storage = &storageID
+	})
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	storageClient := &pqStorageClient{data: make(map[string][]byte)}
+	dl := newTestStorageDeadLetter(t, client, cfg, storageClient)
+	var reprocessed []string
+	dl.reprocess = func(ctx context.Context, records []string) error {
+		reprocessed = append(reprocessed, records...)
+		// The records are rejected again when exported.
+		docs := make([]deadLetterDocument, len(records))
+		for i, record := range records {
+			docs[i] = deadLetterDocument{Document: `{"a":1}`, Record: record}
+		}
+		return dl.write(ctx, docs)
+	}
+
+	bi := newSyncBulkIndexer(client, cfg, false, tb, zap.NewNop(), nil, dl)
+	session := bi.StartSession(t.Context())
+	record := func() ([]byte, error) { return []byte(`{"resourceLogs":[]}`), nil }
+	require.NoError(t, session.Add(t.Context(), "logs-generic-default", "", "", strings.NewReader(`{"a":1}`), nil, docappender.ActionCreate, record))
+	require.NoError(t, session.Add(t.Context(), "logs-generic-default", "", "", strings.NewReader(`{"b":2}`), nil, docappender.ActionCreate, nil))
+	require.NoError(t, session.Flush(t.Context()))
+	session.End()
+	require.Len(t, tr.items(), 2)
+
+	// The documents with a source record are exported again from it, the
+	// other ones are indexed again.
+	require.NoError(t, dl.replay(t.Context()))
+	assert.Equal(t, []string{`{"resourceLogs":[]}`}, reprocessed)
+	items := tr.items()
+	require.Len(t, items, 3)
+	assert.JSONEq(t, `{"b":2}`, items[2].document)
+
+	// The records rejected again keep their number of replay attempts.
+	var doc deadLetterDocument
+	for _, key := range []string{deadLetterKey(2), deadLetterKey(3)} {
+		require.NoError(t, json.Unmarshal(storageClient.data[key], &doc))
+		assert.Equal(t, 1, doc.ReplayAttempts)
+	}
+	require.NoError(t, dl.shutdown(t.Context()))
+}
+
+func TestDataPointsSourceRecord(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "shop")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(1)
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(2)
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("memory")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(0.5)
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	histogram.Histogram().DataPoints().AppendEmpty().SetCount(3)
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("size")
+	summary.SetEmptySummary().DataPoints().AppendEmpty().SetCount(4)
+
+	ec := encodingContext{resource: rm.Resource(), scope: sm.Scope()}
+	record, err := dataPointsSourceRecord(ec, []datapoints.DataPoint{
+		datapoints.NewNumber(sum, sum.Sum().DataPoints().At(1)),
+		datapoints.NewNumber(gauge, gauge.Gauge().DataPoints().At(0)),
+		datapoints.NewHistogram(histogram, histogram.Histogram().DataPoints().At(0)),
+		datapoints.NewSummary(summary, summary.Summary().DataPoints().At(0)),
+	})()
+	require.NoError(t, err)
+
+	// The record holds the data points of the document only, each within its metric.
+	sum.Sum().DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool { return dp.IntValue() == 1 })
+	actual, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(record)
+	require.NoError(t, err)
+	assert.Equal(t, md, actual)
+}
+
+// newTestStorageDeadLetter returns a storage dead letter replaying its
+// documents with synchronous bulk indexers, without a replay interval.
+func newTestStorageDeadLetter(t *testing.T, client elastictransport.Interface, cfg *Config, storageClient storage.Client) *storageDeadLetter {
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	dl := &storageDeadLetter{
+		client:            storageClient,
+		logger:            zap.NewNop(),
+		replayMaxAttempts: cfg.DeadLetter.ReplayMaxAttempts,
+	}
+	require.NoError(t, dl.loadIndices(t.Context()))
+	dl.replayIndexers = [2]bulkIndexer{
+		newSyncBulkIndexer(client, cfg, false, tb, zap.NewNop(), nil, dl),
+		newSyncBulkIndexer(client, cfg, true, tb, zap.NewNop(), nil, dl),
+	}
+	return dl
+}
+
+func TestExporterDeadLetter(t *testing.T) {
+	rec := newBulkRecorder()
+	server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+		rec.Record(docs)
+		responses := make([]itemResponse, len(docs))
+		for i, doc := range docs {
+			responses[i].Status = http.StatusOK
+			if actionJSONToIndex(t, doc.Action) != "otel-dead-letter" {
+				responses[i].Status = http.StatusBadRequest
+			}
+		}
+		return responses, nil
+	})
+
+	exporter := newTestLogsExporter(t, server.URL, func(cfg *Config) {
+		cfg.DeadLetter.Index = "otel-dead-letter"
+	})
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("rejected")
+	mustSendLogs(t, exporter, logs)
+
+	items := rec.WaitItems(2)
+	require.Len(t, items, 2)
+	assert.Equal(t, "otel-dead-letter", actionJSONToIndex(t, items[1].Action))
+	var doc deadLetterDocument
+	require.NoError(t, json.Unmarshal(items[1].Document, &doc))
+	assert.JSONEq(t, string(items[0].Action), doc.Action)
+	assert.JSONEq(t, string(items[0].Document), doc.Document)
+	assert.Equal(t, http.StatusBadRequest, doc.StatusCode)
+	// The log record is kept along with the document.
+	record, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(doc.Record))
+	require.NoError(t, err)
+	require.Equal(t, 1, record.LogRecordCount())
+	assert.Equal(t, "rejected", record.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestExporterDeadLetterReplay(t *testing.T) {
+	var rejected atomic.Bool
+	rec := newBulkRecorder()
+	server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+		rec.Record(docs)
+		// Only the first attempt is rejected.
+		if rejected.CompareAndSwap(false, true) {
+			return itemsReportStatus(docs, http.StatusBadRequest)
+		}
+		return itemsAllOK(docs)
+	})
+
+	storageID := component.MustNewID("file_storage")
+	exporter := newUnstartedTestLogsExporter(t, server.URL, func(cfg *Config) {
+		cfg.DeadLetter.Storage = &storageID
+		cfg.DeadLetter.ReplayInterval = 10 * time.Millisecond
+	})
+	host := &pqTestHost{ext: map[component.ID]component.Component{
+		storageID: &pqStorageExtension{},
+	}}
+	require.NoError(t, exporter.Start(t.Context(), host))
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.Background())) //nolint:usetesting
+	})
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("replayed")
+	mustSendLogs(t, exporter, logs)
+
+	items := rec.WaitItems(2)
+	require.Len(t, items, 2)
+	assertItemRequests(t, items[:1], items[1:], true)
+}
+
+func TestDeadLetterStartErrors(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	exporter := newUnstartedTestLogsExporter(t, "http://localhost:9200", func(cfg *Config) {
+		cfg.DeadLetter.Storage = &storageID
+	})
+	err := exporter.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `storage extension "file_storage" not found`)
+	assert.NoError(t, exporter.Shutdown(t.Context()))
+
+	exporter = newUnstartedTestLogsExporter(t, "http://localhost:9200", func(cfg *Config) {
+		cfg.DeadLetter.Storage = &storageID
+	})
+	host := &mockHost{extensions: map[component.ID]component.Component{
+		storageID: &mockAuthClient{},
+	}}
+	err = exporter.Start(t.Context(), host)
+	assert.ErrorContains(t, err, `non-storage extension "file_storage" found`)
+	assert.NoError(t, exporter.Shutdown(t.Context()))
+}
+
+func TestParseBulkAction(t *testing.T) {
+	action, meta, err := parseBulkAction(`{"create":{"_id":"id","_index":"index","pipeline":"pipeline","dynamic_templates":{"a":"summary"},"require_data_stream":true}}`)
+	require.NoError(t, err)
+	assert.Equal(t, docappender.ActionCreate, action)
+	assert.Equal(t, bulkActionMetadata{
+		Index:             "index",
+		DocumentID:        "id",
+		Pipeline:          "pipeline",
+		DynamicTemplates:  map[string]string{"a": "summary"},
+		RequireDataStream: true,
+	}, meta)
+
+	_, _, err = parseBulkAction(`{"create":{}`)
+	assert.ErrorContains(t, err, "invalid bulk action")
+	_, _, err = parseBulkAction(`{"create":{},"update":{}}`)
+	assert.ErrorContains(t, err, "expected a single action")
+}
@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -33,6 +34,7 @@ import (
 type elasticsearchExporter struct {
 	set                 exporter.Settings
 	config              *Config
+	signal              pipeline.Signal
 	index               string
 	logstashFormat      LogstashFormatSettings
 	defaultMappingMode  MappingMode
@@ -47,7 +49,7 @@ type elasticsearchExporter struct {
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
-func newExporter(cfg *Config, set exporter.Settings, index string) (*elasticsearchExporter, error) {
+func newExporter(cfg *Config, set exporter.Settings, signal pipeline.Signal, index string) (*elasticsearchExporter, error) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize internal telemetry: %w", err)
@@ -63,6 +65,7 @@ func newExporter(cfg *Config, set exporter.Settings, index string) (*elasticsear
 	exporter := &elasticsearchExporter{
 		set:                 set,
 		config:              cfg,
+		signal:              signal,
 		index:               index,
 		logstashFormat:      cfg.LogstashFormat,
 		allowedMappingModes: allowedMappingModes,
@@ -84,7 +87,7 @@ func newExporter(cfg *Config, set exporter.Settings, index string) (*elasticsear
 }
 
 func (e *elasticsearchExporter) Start(ctx context.Context, host component.Host) error {
-	if err := e.bulkIndexers.start(ctx, e.config, e.set, host, e.allowedMappingModes); err != nil {
+	if err := e.bulkIndexers.start(ctx, e.config, e.set, host, e.signal, e.allowedMappingModes, e.reprocessDeadLetterRecords); err != nil {
 		return fmt.Errorf("error starting bulk indexers: %w", err)
 	}
 	return nil
@@ -101,6 +104,47 @@ func (e *elasticsearchExporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// reprocessDeadLetterRecords exports the source records of the documents of
+// the dead letter again, as if they had been received by the exporter.
+func (e *elasticsearchExporter) reprocessDeadLetterRecords(ctx context.Context, records []string) error {
+	switch e.signal {
+	case pipeline.SignalLogs:
+		ld := plog.NewLogs()
+		for _, record := range records {
+			recordLogs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(record))
+			if err != nil {
+				e.set.Logger.Error("dropping invalid dead letter record", zap.Error(err))
+				continue
+			}
+			recordLogs.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+		}
+		return e.pushLogsData(ctx, ld)
+	case pipeline.SignalMetrics:
+		md := pmetric.NewMetrics()
+		for _, record := range records {
+			recordMetrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics([]byte(record))
+			if err != nil {
+				e.set.Logger.Error("dropping invalid dead letter record", zap.Error(err))
+				continue
+			}
+			recordMetrics.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+		}
+		return e.pushMetricsData(ctx, md)
+	case pipeline.SignalTraces:
+		td := ptrace.NewTraces()
+		for _, record := range records {
+			recordTraces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces([]byte(record))
+			if err != nil {
+				e.set.Logger.Error("dropping invalid dead letter record", zap.Error(err))
+				continue
+			}
+			recordTraces.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+		}
+		return e.pushTraceData(ctx, td)
+	}
+	return fmt.Errorf("dead letter records of signal %q can't be exported", e.signal)
+}
+
 func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
 	defaultMappingMode, err := e.getRequestMappingMode(ctx)
 	if err != nil {
@@ -181,7 +225,7 @@ func (e *elasticsearchExporter) pushLogRecord(
 	}
 
 	// not recycling after Add returns an error as we don't know if it's already recycled
-	return bulkIndexerSession.Add(ctx, index.Index, docID, pipeline, buf, nil, docappender.ActionCreate)
+	return bulkIndexerSession.Add(ctx, index.Index, docID, pipeline, buf, nil, docappender.ActionCreate, logSourceRecord(ec, record))
 }
 
 type dataPointsGroup struct {
@@ -322,13 +366,14 @@ func (e *elasticsearchExporter) pushMetricsData(ctx context.Context, metrics pme
 			encoder := e.documentEncoders[int(key.mappingMode)]
 			session := sessions.StartSession(ctx, key.mappingMode)
 
+			ec := encodingContext{
+				resource:          dpGroup.resource,
+				resourceSchemaURL: dpGroup.resourceSchemaURL,
+				scope:             dpGroup.scope,
+				scopeSchemaURL:    dpGroup.scopeSchemaURL,
+			}
 			dynamicTemplates, err := encoder.encodeMetrics(
-				encodingContext{
-					resource:          dpGroup.resource,
-					resourceSchemaURL: dpGroup.resourceSchemaURL,
-					scope:             dpGroup.scope,
-					scopeSchemaURL:    dpGroup.scopeSchemaURL,
-				},
+				ec,
 				dpGroup.dataPoints,
 				&validationErrs,
 				key.index,
@@ -339,7 +384,7 @@ func (e *elasticsearchExporter) pushMetricsData(ctx context.Context, metrics pme
 				errs = append(errs, err)
 				continue
 			}
-			if err := session.Add(ctx, key.index.Index, "", "", buf, dynamicTemplates, docappender.ActionCreate); err != nil {
+			if err := session.Add(ctx, key.index.Index, "", "", buf, dynamicTemplates, docappender.ActionCreate, dataPointsSourceRecord(ec, dpGroup.dataPoints)); err != nil {
 				// not recycling after Add returns an error as we don't know if it's already recycled
 				if cerr := ctx.Err(); cerr != nil {
 					return cerr
@@ -444,7 +489,7 @@ func (e *elasticsearchExporter) pushTraceRecord(
 		return fmt.Errorf("failed to encode trace record: %w", err)
 	}
 	// not recycling after Add returns an error as we don't know if it's already recycled
-	return bulkIndexerSession.Add(ctx, index.Index, docID, "", buf, nil, docappender.ActionCreate)
+	return bulkIndexerSession.Add(ctx, index.Index, docID, "", buf, nil, docappender.ActionCreate, spanSourceRecord(ec, span))
 }
 
 func (e *elasticsearchExporter) pushSpanEvent(
@@ -472,8 +517,10 @@ func (e *elasticsearchExporter) pushSpanEvent(
 		buf.Recycle()
 		return err
 	}
+	// The span events have no source record of their own, as exporting
+	// their span again would also index the span.
 	// not recycling after Add returns an error as we don't know if it's already recycled
-	return bulkIndexerSession.Add(ctx, index.Index, docID, "", buf, nil, docappender.ActionCreate)
+	return bulkIndexerSession.Add(ctx, index.Index, docID, "", buf, nil, docappender.ActionCreate, nil)
 }
 
 // controlAttrs holds the values of control-channel attributes the orchestrator
@@ -614,20 +661,20 @@ func (*elasticsearchExporter) pushProfileRecord(
 	return encoder.encodeProfile(ec, dic, profile, func(buf *bytes.Buffer, docID, index string) error {
 		switch index {
 		case otelserializer.StackTraceIndex:
-			return stackTracesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate)
+			return stackTracesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate, nil)
 		case otelserializer.StackFrameIndex:
-			return stackFramesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate)
+			return stackFramesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate, nil)
 		case otelserializer.AllEventsIndex:
-			return eventsSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate)
+			return eventsSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate, nil)
 		case otelserializer.ExecutablesIndex:
-			return executablesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionUpdate)
+			return executablesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionUpdate, nil)
 		case otelserializer.ExecutablesSymQueueIndex,
 			otelserializer.LeafFramesSymQueueIndex,
 			otelserializer.HostsMetadataIndex:
 			// These regular indices have a low write-frequency and can share the executablesSession.
-			return executablesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate)
+			return executablesSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate, nil)
 		default:
-			return defaultSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate)
+			return defaultSession.Add(ctx, index, docID, "", buf, nil, docappender.ActionCreate, nil)
 		}
 	})
 }
@@ -21,6 +21,8 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper"
 	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/metadata"
 )
@@ -87,6 +89,9 @@ func createDefaultConfig() component.Config {
 			LogFailedDocsInputRateLimit: time.Second,
 		},
 		IncludeSourceOnError: nil,
+		DeadLetter: DeadLetterSettings{
+			ReplayMaxAttempts: 10,
+		},
 	}
 }
 
@@ -103,7 +108,7 @@ func createLogsExporter(
 	handleDeprecatedConfig(cf, set.Logger)
 	handleTelemetryConfig(cf, set.Logger)
 
-	exporter, err := newExporter(cf, set, cf.LogsIndex)
+	exporter, err := newExporter(cf, set, pipeline.SignalLogs, cf.LogsIndex)
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +138,7 @@ func createMetricsExporter(
 	handleDeprecatedConfig(cf, set.Logger)
 	handleTelemetryConfig(cf, set.Logger)
 
-	exporter, err := newExporter(cf, set, cf.MetricsIndex)
+	exporter, err := newExporter(cf, set, pipeline.SignalMetrics, cf.MetricsIndex)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +167,7 @@ func createTracesExporter(ctx context.Context,
 	handleDeprecatedConfig(cf, set.Logger)
 	handleTelemetryConfig(cf, set.Logger)
 
-	exporter, err := newExporter(cf, set, cf.TracesIndex)
+	exporter, err := newExporter(cf, set, pipeline.SignalTraces, cf.TracesIndex)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +201,7 @@ func createProfilesExporter(
 	handleDeprecatedConfig(cf, set.Logger)
 	handleTelemetryConfig(cf, set.Logger)
 
-	exporter, err := newExporter(cf, set, "")
+	exporter, err := newExporter(cf, set, xpipeline.SignalProfiles, "")
 	if err != nil {
 		return nil, err
 	}
@@ -27,6 +27,7 @@ type TelemetryBuilder struct {
 	registrations                         []metric.Registration
 	ElasticsearchBulkRequestsCount        metric.Int64Counter
 	ElasticsearchBulkRequestsLatency      metric.Float64Histogram
+	ElasticsearchDocsDeadLettered         metric.Int64Counter
 	ElasticsearchDocsProcessed            metric.Int64Counter
 	ElasticsearchDocsReceived             metric.Int64Counter
 	ElasticsearchDocsRetried              metric.Int64Counter
@@ -77,6 +78,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithExplicitBucketBoundaries([]float64{0, 0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}...),
 	)
 	errs = errors.Join(errs, err)
+	builder.ElasticsearchDocsDeadLettered, err = builder.meter.Int64Counter(
+		"otelcol.elasticsearch.docs.dead_lettered",
+		metric.WithDescription("Count of documents rejected by Elasticsearch and written to the dead letter. [Alpha]"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ElasticsearchDocsProcessed, err = builder.meter.Int64Counter(
 		"otelcol.elasticsearch.docs.processed",
 		metric.WithDescription("Count of documents flushed to Elasticsearch. [Alpha]"),
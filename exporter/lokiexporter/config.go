@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/otlptranslator"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	encodingProtobuf = "protobuf"
+	encodingJSON     = "json"
+
+	lineFormatJSON   = "json"
+	lineFormatLogfmt = "logfmt"
+	lineFormatRaw    = "raw"
+)
+
+var (
+	errEndpointRequired             = errors.New("endpoint is required")
+	errNegativeCardinalityLimit     = errors.New("labels::cardinality_limit must be non-negative")
+	errTenantMetadataKeyNotIncluded = errors.New("tenant::metadata_key must be present in sending_queue::batch::partition::metadata_keys if batching is enabled")
+)
+
+// Config defines configuration for the Loki exporter.
+type Config struct {
+	ClientConfig confighttp.ClientConfig                                  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueConfig  configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	RetryConfig  configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+
+	// Encoding is the encoding of the push requests, either "protobuf", in which
+	// case the requests are compressed with snappy, or "json".
+	//
+	// Defaults to "protobuf".
+	Encoding string `mapstructure:"encoding"`
+
+	// LineFormat is the format of the log lines, either "json", "logfmt" or "raw".
+	// With "raw", the line is the body of the log record.
+	//
+	// Defaults to "json".
+	LineFormat string `mapstructure:"line_format"`
+
+	// Labels holds the attributes promoted to stream labels.
+	Labels LabelsConfig `mapstructure:"labels"`
+
+	// StructuredMetadata holds the attributes sent as structured metadata of the
+	// log entries.
+	StructuredMetadata AttributesConfig `mapstructure:"structured_metadata"`
+
+	// Tenant holds how the tenant of the push requests is set.
+	Tenant TenantConfig `mapstructure:"tenant"`
+}
+
+// AttributesConfig selects resource and log record attributes.
+type AttributesConfig struct {
+	// ResourceAttributes are the names of the selected resource attributes.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// RecordAttributes are the names of the selected log record attributes.
+	RecordAttributes []string `mapstructure:"record_attributes"`
+}
+
+// LabelsConfig holds the attributes promoted to stream labels.
+type LabelsConfig struct {
+	AttributesConfig `mapstructure:",squash"`
+
+	// CardinalityLimit is the maximum number of distinct values of each label.
+	// Once the limit is reached, the new values of the label are replaced with
+	// "__overflow__", and their attribute is kept in the log line. Zero means no limit.
+	//
+	// Defaults to 1000.
+	CardinalityLimit int `mapstructure:"cardinality_limit"`
+}
+
+// TenantConfig holds how the tenant of the push requests, sent in the
+// X-Scope-OrgID header, is set.
+type TenantConfig struct {
+	// MetadataKey is the key of the client metadata holding the tenant.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// Default is the tenant of the data without client metadata for MetadataKey.
+	// No tenant header is sent when empty.
+	Default string `mapstructure:"default"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.ClientConfig.Endpoint == "" {
+		errs = append(errs, errEndpointRequired)
+	}
+	switch c.Encoding {
+	case encodingProtobuf, encodingJSON:
+	default:
+		errs = append(errs, fmt.Errorf("encoding %q is not supported, must be one of %q or %q", c.Encoding, encodingProtobuf, encodingJSON))
+	}
+	switch c.LineFormat {
+	case lineFormatJSON, lineFormatLogfmt, lineFormatRaw:
+	default:
+		errs = append(errs, fmt.Errorf("line_format %q is not supported, must be one of %q, %q or %q", c.LineFormat, lineFormatJSON, lineFormatLogfmt, lineFormatRaw))
+	}
+	if c.Labels.CardinalityLimit < 0 {
+		errs = append(errs, errNegativeCardinalityLimit)
+	}
+	if err := c.Labels.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("labels::%w", err))
+	}
+	if err := c.StructuredMetadata.validate(); err != nil {
+		errs = append(errs, fmt.Errorf("structured_metadata::%w", err))
+	}
+	for _, attr := range c.Labels.ResourceAttributes {
+		if slices.Contains(c.StructuredMetadata.ResourceAttributes, attr) {
+			errs = append(errs, fmt.Errorf("resource attribute %q can't be both a label and structured metadata", attr))
+		}
+	}
+	for _, attr := range c.Labels.RecordAttributes {
+		if slices.Contains(c.StructuredMetadata.RecordAttributes, attr) {
+			errs = append(errs, fmt.Errorf("record attribute %q can't be both a label and structured metadata", attr))
+		}
+	}
+	if err := validateTenantMetadataKey(c); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (c AttributesConfig) validate() error {
+	var errs []error
+	namer := otlptranslator.LabelNamer{}
+	for _, attr := range c.ResourceAttributes {
+		if _, err := namer.Build(attr); err != nil {
+			errs = append(errs, fmt.Errorf("resource_attributes: invalid attribute %q: %w", attr, err))
+		}
+	}
+	for _, attr := range c.RecordAttributes {
+		if _, err := namer.Build(attr); err != nil {
+			errs = append(errs, fmt.Errorf("record_attributes: invalid attribute %q: %w", attr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateTenantMetadataKey ensures the batches keep the client metadata holding
+// the tenant, as batching removes all client metadata keys by default.
+func validateTenantMetadataKey(c *Config) error {
+	if c.Tenant.MetadataKey == "" || !c.QueueConfig.HasValue() || !c.QueueConfig.Get().Batch.HasValue() {
+		return nil
+	}
+	partitionMetadataKeys := c.QueueConfig.Get().Batch.Get().Partition.MetadataKeys
+	// The metadata keys are case-insensitive.
+	if !slices.ContainsFunc(partitionMetadataKeys, func(key string) bool { return strings.EqualFold(key, c.Tenant.MetadataKey) }) {
+		return fmt.Errorf("%w: %q not found in partition keys=%v", errTenantMetadataKeyNotIncluded, c.Tenant.MetadataKey, partitionMetadataKeys)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "http://loki:3100/loki/api/v1/push"
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "https://loki:3100/loki/api/v1/push"
+				cfg.ClientConfig.Timeout = 10 * time.Second
+				cfg.Encoding = "json"
+				cfg.LineFormat = "logfmt"
+				cfg.Labels = LabelsConfig{
+					AttributesConfig: AttributesConfig{
+						ResourceAttributes: []string{"service.name", "k8s.namespace.name"},
+						RecordAttributes:   []string{"level"},
+					},
+					CardinalityLimit: 50,
+				}
+				cfg.StructuredMetadata = AttributesConfig{
+					ResourceAttributes: []string{"k8s.pod.name"},
+					RecordAttributes:   []string{"trace_id"},
+				}
+				cfg.Tenant = TenantConfig{
+					MetadataKey: "x-tenant",
+					Default:     "default-tenant",
+				}
+				batch := cfg.QueueConfig.Get().Batch.GetOrInsertDefault()
+				batch.Partition.MetadataKeys = []string{"X-Tenant"}
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			expectedErr: "endpoint is required",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_encoding"),
+			expectedErr: `encoding "xml" is not supported, must be one of "protobuf" or "json"` + "\n" +
+				`line_format "yaml" is not supported, must be one of "json", "logfmt" or "raw"`,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_labels"),
+			expectedErr: "labels::cardinality_limit must be non-negative\n" +
+				`labels::resource_attributes: invalid attribute "": label name is empty` + "\n" +
+				`resource attribute "service.name" can't be both a label and structured metadata`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "tenant_not_partitioned"),
+			expectedErr: `tenant::metadata_key must be present in sending_queue::batch::partition::metadata_keys if batching is enabled: "x-tenant" not found in partition keys=[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
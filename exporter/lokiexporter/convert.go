@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/otlptranslator"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+const (
+	// overflowLabelValue replaces the values of a label exceeding its cardinality limit.
+	overflowLabelValue = "__overflow__"
+
+	// Loki rejects the streams without labels, so the streams without any of the
+	// selected labels get the exporter label of the Loki translator.
+	defaultLabelName  = "exporter"
+	defaultLabelValue = "OTLP"
+)
+
+// selectedAttribute is an attribute selected as a label or structured metadata,
+// with its name normalized for Loki.
+type selectedAttribute struct {
+	attribute string
+	name      string
+}
+
+func selectAttributes(attrs []string) ([]selectedAttribute, error) {
+	namer := otlptranslator.LabelNamer{}
+	selected := make([]selectedAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		name, err := namer.Build(attr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %q: %w", attr, err)
+		}
+		selected = append(selected, selectedAttribute{attribute: attr, name: name})
+	}
+	return selected, nil
+}
+
+// stream holds the entries of a stream of a push request.
+type stream struct {
+	labels  model.LabelSet
+	entries []push.Entry
+}
+
+// converter converts logs into the streams of a push request.
+type converter struct {
+	lineFormat string
+
+	resourceLabels   []selectedAttribute
+	recordLabels     []selectedAttribute
+	resourceMetadata []selectedAttribute
+	recordMetadata   []selectedAttribute
+
+	limiter *cardinalityLimiter
+}
+
+func newConverter(cfg *Config, logger *zap.Logger) (*converter, error) {
+	c := &converter{
+		lineFormat: cfg.LineFormat,
+		limiter:    newCardinalityLimiter(cfg.Labels.CardinalityLimit, logger),
+	}
+	var err error
+	if c.resourceLabels, err = selectAttributes(cfg.Labels.ResourceAttributes); err != nil {
+		return nil, err
+	}
+	if c.recordLabels, err = selectAttributes(cfg.Labels.RecordAttributes); err != nil {
+		return nil, err
+	}
+	if c.resourceMetadata, err = selectAttributes(cfg.StructuredMetadata.ResourceAttributes); err != nil {
+		return nil, err
+	}
+	if c.recordMetadata, err = selectAttributes(cfg.StructuredMetadata.RecordAttributes); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// convert groups the log records into streams by labels. The streams are sorted by
+// labels, and the entries of each stream by timestamp, as Loki expects the entries
+// of a stream in order. The log records failing to be encoded are dropped, and
+// their errors returned.
+func (c *converter) convert(ld plog.Logs) ([]stream, []error) {
+	streams := map[string]*stream{}
+	var errs []error
+	for _, rl := range ld.ResourceLogs().All() {
+		resourceLabels, resourceKeep := c.labels(c.resourceLabels, rl.Resource().Attributes())
+		resourceMetadata := structuredMetadata(c.resourceMetadata, rl.Resource().Attributes())
+		// The attributes promoted to labels or structured metadata are removed from the line.
+		resource := rl.Resource()
+		if removed := removedAttributes(rl.Resource().Attributes(), c.resourceLabels, resourceKeep, c.resourceMetadata); len(removed) > 0 {
+			resource = pcommon.NewResource()
+			rl.Resource().CopyTo(resource)
+			removeAttributes(resource.Attributes(), removed)
+		}
+
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				labels, recordKeep := c.labels(c.recordLabels, lr.Attributes())
+				labels = resourceLabels.Merge(labels)
+				if len(labels) == 0 {
+					labels = model.LabelSet{defaultLabelName: defaultLabelValue}
+				}
+				record := lr
+				if removed := removedAttributes(lr.Attributes(), c.recordLabels, recordKeep, c.recordMetadata); len(removed) > 0 {
+					record = plog.NewLogRecord()
+					lr.CopyTo(record)
+					removeAttributes(record.Attributes(), removed)
+				}
+
+				line, err := c.line(record, resource, sl.Scope())
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to encode log record: %w", err))
+					continue
+				}
+				entry := push.Entry{
+					Timestamp: timestamp(lr),
+					Line:      line,
+				}
+				if recordMetadata := structuredMetadata(c.recordMetadata, lr.Attributes()); len(resourceMetadata)+len(recordMetadata) > 0 {
+					entry.StructuredMetadata = append(slices.Clone(resourceMetadata), recordMetadata...)
+				}
+
+				key := labels.String()
+				s, ok := streams[key]
+				if !ok {
+					s = &stream{labels: labels}
+					streams[key] = s
+				}
+				s.entries = append(s.entries, entry)
+			}
+		}
+	}
+
+	sorted := make([]stream, 0, len(streams))
+	for _, key := range slices.Sorted(maps.Keys(streams)) {
+		s := streams[key]
+		slices.SortStableFunc(s.entries, func(a, b push.Entry) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+		sorted = append(sorted, *s)
+	}
+	return sorted, errs
+}
+
+// labels returns the labels of the selected attributes, and the attributes to keep
+// in the line as their label value exceeds the cardinality limit.
+func (c *converter) labels(selected []selectedAttribute, attrs pcommon.Map) (model.LabelSet, map[string]bool) {
+	labels := model.LabelSet{}
+	var keep map[string]bool
+	for _, attr := range selected {
+		value, ok := attrs.Get(attr.attribute)
+		if !ok {
+			continue
+		}
+		labelValue := value.AsString()
+		if !c.limiter.allow(attr.name, labelValue) {
+			labelValue = overflowLabelValue
+			if keep == nil {
+				keep = map[string]bool{}
+			}
+			keep[attr.attribute] = true
+		}
+		labels[model.LabelName(attr.name)] = model.LabelValue(labelValue)
+	}
+	return labels, keep
+}
+
+func structuredMetadata(selected []selectedAttribute, attrs pcommon.Map) push.LabelsAdapter {
+	var labels push.LabelsAdapter
+	for _, attr := range selected {
+		if value, ok := attrs.Get(attr.attribute); ok {
+			labels = append(labels, push.LabelAdapter{Name: attr.name, Value: value.AsString()})
+		}
+	}
+	return labels
+}
+
+// removedAttributes returns the attributes to remove from the line, among the
+// ones of the map.
+func removedAttributes(attrs pcommon.Map, labels []selectedAttribute, keep map[string]bool, metadataAttrs []selectedAttribute) map[string]bool {
+	removed := map[string]bool{}
+	for _, attr := range labels {
+		if _, ok := attrs.Get(attr.attribute); ok && !keep[attr.attribute] {
+			removed[attr.attribute] = true
+		}
+	}
+	for _, attr := range metadataAttrs {
+		if _, ok := attrs.Get(attr.attribute); ok {
+			removed[attr.attribute] = true
+		}
+	}
+	return removed
+}
+
+func removeAttributes(attrs pcommon.Map, removed map[string]bool) {
+	attrs.RemoveIf(func(key string, _ pcommon.Value) bool {
+		return removed[key]
+	})
+}
+
+func (c *converter) line(lr plog.LogRecord, resource pcommon.Resource, scope pcommon.InstrumentationScope) (string, error) {
+	switch c.lineFormat {
+	case lineFormatLogfmt:
+		return loki.EncodeLogfmt(lr, resource, scope)
+	case lineFormatRaw:
+		return lr.Body().AsString(), nil
+	default:
+		return loki.Encode(lr, resource, scope)
+	}
+}
+
+// timestamp returns the timestamp of the log record, falling back to its observed
+// timestamp, and then to the current time.
+func timestamp(lr plog.LogRecord) time.Time {
+	if lr.Timestamp() != 0 {
+		return lr.Timestamp().AsTime()
+	}
+	if lr.ObservedTimestamp() != 0 {
+		return lr.ObservedTimestamp().AsTime()
+	}
+	return time.Now()
+}
+
+// cardinalityLimiter bounds the number of distinct values of each label over the
+// lifetime of the exporter.
+type cardinalityLimiter struct {
+	limit  int
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	values map[string]map[string]struct{}
+}
+
+func newCardinalityLimiter(limit int, logger *zap.Logger) *cardinalityLimiter {
+	return &cardinalityLimiter{
+		limit:  limit,
+		logger: logger,
+		values: map[string]map[string]struct{}{},
+	}
+}
+
+// allow reports whether the value of the label is within the cardinality limit.
+func (l *cardinalityLimiter) allow(name, value string) bool {
+	if l.limit == 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	values, ok := l.values[name]
+	if !ok {
+		values = map[string]struct{}{}
+		l.values[name] = values
+	}
+	if _, ok := values[value]; ok {
+		return true
+	}
+	if len(values) >= l.limit {
+		return false
+	}
+	values[value] = struct{}{}
+	if len(values) == l.limit {
+		l.logger.Warn("Label reached its cardinality limit, its new values are replaced",
+			zap.String("label", name), zap.Int("limit", l.limit), zap.String("replacement", overflowLabelValue))
+	}
+	return true
+}
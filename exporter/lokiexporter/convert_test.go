@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func newTestConverter(t *testing.T, mutate func(*Config)) *converter {
+	cfg := createDefaultConfig().(*Config)
+	mutate(cfg)
+	c, err := newConverter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return c
+}
+
+func TestConvert(t *testing.T) {
+	c := newTestConverter(t, func(cfg *Config) {
+		cfg.LineFormat = lineFormatLogfmt
+		cfg.Labels.ResourceAttributes = []string{"service.name", "k8s.namespace.name"}
+		cfg.Labels.RecordAttributes = []string{"level"}
+		cfg.StructuredMetadata.ResourceAttributes = []string{"k8s.pod.name"}
+		cfg.StructuredMetadata.RecordAttributes = []string{"trace.id"}
+	})
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	rl.Resource().Attributes().PutStr("k8s.pod.name", "api-1")
+	rl.Resource().Attributes().PutStr("host.name", "node-1")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for i, level := range []string{"info", "error", "info"} {
+		lr := records.AppendEmpty()
+		// The records are out of order.
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(int64(10-i), 0)))
+		lr.Body().SetStr("msg=message")
+		lr.Attributes().PutStr("level", level)
+		lr.Attributes().PutStr("trace.id", "abc")
+	}
+	// The records without any of the selected attributes get the default label.
+	other := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	other.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+	other.Body().SetStr("msg=other")
+
+	streams, errs := c.convert(logs)
+	require.Empty(t, errs)
+	require.Len(t, streams, 3)
+
+	// The streams are sorted by labels.
+	assert.Equal(t, model.LabelSet{"exporter": "OTLP"}, streams[0].labels)
+	assert.Equal(t, []push.Entry{{Timestamp: time.Unix(1, 0).UTC(), Line: "msg=other"}}, streams[0].entries)
+
+	assert.Equal(t, model.LabelSet{"service_name": "api", "level": "error"}, streams[1].labels)
+	require.Len(t, streams[1].entries, 1)
+
+	// The entries of a stream are sorted by timestamp.
+	assert.Equal(t, model.LabelSet{"service_name": "api", "level": "info"}, streams[2].labels)
+	require.Len(t, streams[2].entries, 2)
+	assert.Equal(t, time.Unix(8, 0).UTC(), streams[2].entries[0].Timestamp)
+	assert.Equal(t, time.Unix(10, 0).UTC(), streams[2].entries[1].Timestamp)
+
+	// The labels and structured metadata are removed from the line.
+	for _, entry := range streams[2].entries {
+		assert.Equal(t, "msg=message resource_host.name=node-1", entry.Line)
+		assert.Equal(t, push.LabelsAdapter{
+			{Name: "k8s_pod_name", Value: "api-1"},
+			{Name: "trace_id", Value: "abc"},
+		}, entry.StructuredMetadata)
+	}
+
+	// The logs are not modified.
+	_, ok := rl.Resource().Attributes().Get("service.name")
+	assert.True(t, ok)
+	_, ok = records.At(0).Attributes().Get("level")
+	assert.True(t, ok)
+}
+
+func TestConvertLineFormats(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("msg=message")
+	lr.Attributes().PutStr("user", "alice")
+
+	tests := []struct {
+		lineFormat string
+		expected   string
+	}{
+		{lineFormat: lineFormatJSON, expected: `{"body":"msg=message","attributes":{"user":"alice"}}`},
+		{lineFormat: lineFormatLogfmt, expected: "msg=message attribute_user=alice"},
+		{lineFormat: lineFormatRaw, expected: "msg=message"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lineFormat, func(t *testing.T) {
+			c := newTestConverter(t, func(cfg *Config) { cfg.LineFormat = tt.lineFormat })
+			streams, errs := c.convert(logs)
+			require.Empty(t, errs)
+			require.Len(t, streams, 1)
+			assert.Equal(t, model.LabelSet{"service_name": "api"}, streams[0].labels)
+			assert.Equal(t, tt.expected, streams[0].entries[0].Line)
+		})
+	}
+}
+
+func TestConvertCardinalityLimit(t *testing.T) {
+	c := newTestConverter(t, func(cfg *Config) {
+		cfg.LineFormat = lineFormatLogfmt
+		cfg.Labels.RecordAttributes = []string{"user"}
+		cfg.Labels.CardinalityLimit = 2
+	})
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, user := range []string{"alice", "bob", "carol", "alice"} {
+		lr := records.AppendEmpty()
+		lr.Body().SetStr("msg=login")
+		lr.Attributes().PutStr("user", user)
+	}
+
+	streams, errs := c.convert(logs)
+	require.Empty(t, errs)
+	require.Len(t, streams, 3)
+	// The values exceeding the limit are replaced, and kept in the line.
+	assert.Equal(t, model.LabelSet{"user": "__overflow__"}, streams[0].labels)
+	require.Len(t, streams[0].entries, 1)
+	assert.Equal(t, "msg=login attribute_user=carol", streams[0].entries[0].Line)
+	// The values seen before reaching the limit are still allowed.
+	assert.Equal(t, model.LabelSet{"user": "alice"}, streams[1].labels)
+	require.Len(t, streams[1].entries, 2)
+	assert.Equal(t, "msg=login", streams[1].entries[1].Line)
+	assert.Equal(t, model.LabelSet{"user": "bob"}, streams[2].labels)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+)
+
+const (
+	pbContentType   = "application/x-protobuf"
+	jsonContentType = "application/json"
+)
+
+// marshaler marshals the streams into the body of a push request.
+type marshaler interface {
+	marshal(streams []stream) ([]byte, error)
+	contentType() string
+}
+
+func newMarshaler(encoding string) marshaler {
+	if encoding == encodingJSON {
+		return jsonMarshaler{}
+	}
+	return protobufMarshaler{}
+}
+
+// protobufMarshaler marshals the streams into a snappy-compressed protobuf push request.
+type protobufMarshaler struct{}
+
+func (protobufMarshaler) marshal(streams []stream) ([]byte, error) {
+	req := push.PushRequest{Streams: make([]push.Stream, 0, len(streams))}
+	for _, s := range streams {
+		req.Streams = append(req.Streams, push.Stream{
+			Labels:  s.labels.String(),
+			Entries: s.entries,
+		})
+	}
+	buf, err := req.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, buf), nil
+}
+
+func (protobufMarshaler) contentType() string {
+	return pbContentType
+}
+
+// jsonMarshaler marshals the streams into a JSON push request, e.g.
+// {"streams":[{"stream":{"service_name":"api"},"values":[["1700000000000000000","line",{"trace_id":"..."}]]}]}.
+type jsonMarshaler struct{}
+
+type jsonPushRequest struct {
+	Streams []jsonStream `json:"streams"`
+}
+
+type jsonStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][]any           `json:"values"`
+}
+
+func (jsonMarshaler) marshal(streams []stream) ([]byte, error) {
+	req := jsonPushRequest{Streams: make([]jsonStream, 0, len(streams))}
+	for _, s := range streams {
+		js := jsonStream{
+			Stream: make(map[string]string, len(s.labels)),
+			Values: make([][]any, 0, len(s.entries)),
+		}
+		for name, value := range s.labels {
+			js.Stream[string(name)] = string(value)
+		}
+		for _, entry := range s.entries {
+			value := []any{strconv.FormatInt(entry.Timestamp.UnixNano(), 10), entry.Line}
+			if len(entry.StructuredMetadata) > 0 {
+				entryMetadata := make(map[string]string, len(entry.StructuredMetadata))
+				for _, label := range entry.StructuredMetadata {
+					entryMetadata[label.Name] = label.Value
+				}
+				value = append(value, entryMetadata)
+			}
+			js.Values = append(js.Values, value)
+		}
+		req.Streams = append(req.Streams, js)
+	}
+	return json.Marshal(req)
+}
+
+func (jsonMarshaler) contentType() string {
+	return jsonContentType
+}
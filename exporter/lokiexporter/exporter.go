@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const (
+	headerTenant     = "X-Scope-OrgID"
+	headerRetryAfter = "Retry-After"
+
+	// maxErrorBodySize bounds the part of the error responses included in the errors.
+	maxErrorBodySize = 1024
+)
+
+type lokiExporter struct {
+	config    *Config
+	settings  component.TelemetrySettings
+	logger    *zap.Logger
+	userAgent string
+
+	converter *converter
+	marshaler marshaler
+	client    *http.Client
+}
+
+func newExporter(cfg *Config, set exporter.Settings) (*lokiExporter, error) {
+	converter, err := newConverter(cfg, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return &lokiExporter{
+		config:   cfg,
+		settings: set.TelemetrySettings,
+		logger:   set.Logger,
+		userAgent: fmt.Sprintf("%s/%s (%s/%s)",
+			set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH),
+		converter: converter,
+		marshaler: newMarshaler(cfg.Encoding),
+	}, nil
+}
+
+func (e *lokiExporter) start(ctx context.Context, host component.Host) error {
+	client, err := e.config.ClientConfig.ToClient(ctx, host.GetExtensions(), e.settings)
+	if err != nil {
+		return err
+	}
+	e.client = client
+	return nil
+}
+
+func (e *lokiExporter) shutdown(context.Context) error {
+	if e.client != nil {
+		e.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+// pushLogs pushes the logs to Loki, in a single push request. The log records
+// failing to be encoded are dropped.
+func (e *lokiExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	streams, errs := e.converter.convert(ld)
+	if len(errs) > 0 {
+		e.logger.Warn("Dropping log records failing to be encoded", zap.Int("dropped", len(errs)), zap.Error(errors.Join(errs...)))
+	}
+	if len(streams) == 0 {
+		if len(errs) > 0 {
+			return consumererror.NewPermanent(errors.Join(errs...))
+		}
+		return nil
+	}
+
+	body, err := e.marshaler.marshal(streams)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal push request: %w", err))
+	}
+	return e.push(ctx, body)
+}
+
+func (e *lokiExporter) push(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.ClientConfig.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", e.marshaler.contentType())
+	req.Header.Set("User-Agent", e.userAgent)
+	if tenant := e.tenant(ctx); tenant != "" {
+		req.Header.Set(headerTenant, tenant)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("push request to %s responded with HTTP status code %d: %s",
+		e.config.ClientConfig.Endpoint, resp.StatusCode, bytes.TrimSpace(respBody))
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		var retryAfter time.Duration
+		if seconds, parseErr := strconv.Atoi(resp.Header.Get(headerRetryAfter)); parseErr == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return exporterhelper.NewThrottleRetry(err, retryAfter)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return err
+	default:
+		// Loki rejects invalid data, e.g. entries too old or out of order, with
+		// client errors, which can't be retried.
+		return consumererror.NewPermanent(err)
+	}
+}
+
+// tenant returns the tenant of the push request, from the client metadata of the
+// context if configured.
+func (e *lokiExporter) tenant(ctx context.Context) string {
+	if e.config.Tenant.MetadataKey != "" {
+		if values := client.FromContext(ctx).Metadata.Get(e.config.Tenant.MetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return e.config.Tenant.Default
+}
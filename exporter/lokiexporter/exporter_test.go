@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/metadata"
+)
+
+// pushRequest is a push request received by the test server.
+type pushRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// runServer starts a server responding to the push requests with the given status code.
+func runServer(t *testing.T, statusCode int) (string, func() []pushRequest) {
+	var mu sync.Mutex
+	var requests []pushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/loki/api/v1/push", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		requests = append(requests, pushRequest{header: r.Header, body: body})
+		mu.Unlock()
+		if statusCode == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "30")
+		}
+		w.WriteHeader(statusCode)
+		if statusCode >= http.StatusBadRequest {
+			_, _ = w.Write([]byte("entry too far behind"))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/loki/api/v1/push", func() []pushRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func newTestExporter(t *testing.T, endpoint string, mutate func(*Config)) *lokiExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = endpoint
+	mutate(cfg)
+	exp, err := newExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, exp.start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.shutdown(context.Background())) })
+	return exp
+}
+
+func testLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	for i, body := range []string{"second", "first"} {
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, int64(2-i))))
+		lr.Body().SetStr(body)
+		lr.Attributes().PutStr("trace.id", body)
+	}
+	return logs
+}
+
+func TestPushProtobuf(t *testing.T) {
+	endpoint, requests := runServer(t, http.StatusNoContent)
+	exp := newTestExporter(t, endpoint, func(cfg *Config) {
+		cfg.LineFormat = lineFormatRaw
+		cfg.StructuredMetadata.RecordAttributes = []string{"trace.id"}
+	})
+	require.NoError(t, exp.pushLogs(t.Context(), testLogs()))
+
+	received := requests()
+	require.Len(t, received, 1)
+	assert.Equal(t, "application/x-protobuf", received[0].header.Get("Content-Type"))
+	assert.Empty(t, received[0].header.Get("X-Scope-OrgID"))
+
+	buf, err := snappy.Decode(nil, received[0].body)
+	require.NoError(t, err)
+	var req push.PushRequest
+	require.NoError(t, req.Unmarshal(buf))
+	require.Len(t, req.Streams, 1)
+	assert.Equal(t, `{service_name="api"}`, req.Streams[0].Labels)
+	assert.Equal(t, []push.Entry{
+		{
+			Timestamp:          time.Unix(0, 1).UTC(),
+			Line:               "first",
+			StructuredMetadata: push.LabelsAdapter{{Name: "trace_id", Value: "first"}},
+		},
+		{
+			Timestamp:          time.Unix(0, 2).UTC(),
+			Line:               "second",
+			StructuredMetadata: push.LabelsAdapter{{Name: "trace_id", Value: "second"}},
+		},
+	}, req.Streams[0].Entries)
+}
+
+func TestPushJSON(t *testing.T) {
+	endpoint, requests := runServer(t, http.StatusNoContent)
+	exp := newTestExporter(t, endpoint, func(cfg *Config) {
+		cfg.Encoding = encodingJSON
+		cfg.LineFormat = lineFormatRaw
+		cfg.StructuredMetadata.RecordAttributes = []string{"trace.id"}
+	})
+	require.NoError(t, exp.pushLogs(t.Context(), testLogs()))
+
+	received := requests()
+	require.Len(t, received, 1)
+	assert.Equal(t, "application/json", received[0].header.Get("Content-Type"))
+	assert.JSONEq(t, `{"streams":[{
+		"stream":{"service_name":"api"},
+		"values":[["1","first",{"trace_id":"first"}],["2","second",{"trace_id":"second"}]]
+	}]}`, string(received[0].body))
+}
+
+func TestPushTenant(t *testing.T) {
+	endpoint, requests := runServer(t, http.StatusNoContent)
+	exp := newTestExporter(t, endpoint, func(cfg *Config) {
+		cfg.Tenant = TenantConfig{MetadataKey: "x-tenant", Default: "fallback"}
+	})
+
+	ctx := client.NewContext(t.Context(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"team-a"}}),
+	})
+	require.NoError(t, exp.pushLogs(ctx, testLogs()))
+	require.NoError(t, exp.pushLogs(t.Context(), testLogs()))
+
+	received := requests()
+	require.Len(t, received, 2)
+	assert.Equal(t, "team-a", received[0].header.Get("X-Scope-OrgID"))
+	assert.Equal(t, "fallback", received[1].header.Get("X-Scope-OrgID"))
+}
+
+func TestPushErrors(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   func(err error) error
+	}{
+		{
+			statusCode: http.StatusBadRequest,
+			expected:   consumererror.NewPermanent,
+		},
+		{
+			statusCode: http.StatusTooManyRequests,
+			expected: func(err error) error {
+				return exporterhelper.NewThrottleRetry(err, 30*time.Second)
+			},
+		},
+		{
+			statusCode: http.StatusInternalServerError,
+			expected:   func(err error) error { return err },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			endpoint, _ := runServer(t, tt.statusCode)
+			exp := newTestExporter(t, endpoint, func(*Config) {})
+
+			err := exp.pushLogs(t.Context(), testLogs())
+			expected := fmt.Errorf("push request to %s responded with HTTP status code %d: entry too far behind", endpoint, tt.statusCode)
+			assert.Equal(t, tt.expected(expected), err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+var errInvalidQoS = errors.New("qos must be 0, 1 or 2")
+
+// Config defines configuration for the MQTT exporter.
+type Config struct {
+	TimeoutSettings  exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueBatchConfig configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	BackOffConfig    configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	ClientConfig     mqtt.ClientConfig                                        `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be published.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be published.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be published.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds signal-specific configuration for the MQTT exporter.
+type SignalConfig struct {
+	// Topic is the topic the messages of the signal type are published to. Resource
+	// attributes are referenced with %{<attribute>}, in which case the data is published
+	// to a topic per distinct value.
+	//
+	// The default depends on the signal type:
+	//  - "otlp/traces" for traces
+	//  - "otlp/metrics" for metrics
+	//  - "otlp/logs" for logs
+	Topic string `mapstructure:"topic"`
+
+	// QoS is the quality of service of the published messages, either 0, 1 or 2. With
+	// a QoS of 1 or 2, the data is retried until the broker acknowledges it (default 1).
+	QoS int `mapstructure:"qos"`
+
+	// Retain asks the broker to retain the last message of each topic, and deliver it to
+	// the clients subscribing to the topic later.
+	Retain bool `mapstructure:"retain"`
+
+	// Encoding holds the encoding of messages for the signal type, either "otlp_proto",
+	// "otlp_json" or the ID of an encoding extension.
+	//
+	// Defaults to "otlp_proto".
+	Encoding string `mapstructure:"encoding"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if err := c.Logs.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := c.Traces.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (c SignalConfig) Validate() error {
+	var errs []error
+	if _, err := parseTopicTemplate(c.Topic); err != nil {
+		errs = append(errs, fmt.Errorf("topic: %w", err))
+	}
+	if c.QoS < 0 || c.QoS > 2 {
+		errs = append(errs, errInvalidQoS)
+	}
+	return errors.Join(errs...)
+}
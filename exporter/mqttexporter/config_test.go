@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "ssl://broker:8883"
+				cfg.ClientConfig.ProtocolVersion = "5"
+				cfg.ClientConfig.ClientID = "collector"
+				cfg.ClientConfig.Username = "user"
+				cfg.ClientConfig.Password = "pass"
+				cfg.TimeoutSettings.Timeout = 10 * time.Second
+				storage := component.MustNewID("file_storage")
+				cfg.QueueBatchConfig.Get().StorageID = &storage
+				cfg.Logs = SignalConfig{
+					Topic:    "factory/%{factory.line}/logs",
+					QoS:      2,
+					Retain:   true,
+					Encoding: "otlp_json",
+				}
+				cfg.Metrics.QoS = 0
+				cfg.Traces.Encoding = "jaeger_encoding"
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_topic"),
+			expectedErr: `logs::topic: invalid topic "logs/+": wildcards are not allowed` + "\n" +
+				`traces::topic: unterminated attribute reference in topic "traces/%{service.name"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "empty_topic"),
+			expectedErr: "metrics::topic: topic must not be empty",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_qos"),
+			expectedErr: "traces::qos must be 0, 1 or 2",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			expectedErr: "endpoint is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+const (
+	defaultLogsTopic    = "otlp/logs"
+	defaultMetricsTopic = "otlp/metrics"
+	defaultTracesTopic  = "otlp/traces"
+	defaultQoS          = 1
+	defaultEncoding     = "otlp_proto"
+)
+
+// NewFactory creates the MQTT exporter factory.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		TimeoutSettings:  exporterhelper.NewDefaultTimeoutConfig(),
+		BackOffConfig:    configretry.NewDefaultBackOffConfig(),
+		QueueBatchConfig: configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		ClientConfig:     mqtt.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Topic:    defaultLogsTopic,
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Topic:    defaultMetricsTopic,
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Topic:    defaultTracesTopic,
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	oCfg := *cfg.(*Config) // Clone the config
+	exp, err := newTracesExporter(oCfg, set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	oCfg := *cfg.(*Config) // Clone the config
+	exp, err := newMetricsExporter(oCfg, set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	oCfg := *cfg.(*Config) // Clone the config
+	exp, err := newLogsExporter(oCfg, set)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func exporterhelperOptions(
+	cfg Config,
+	startFunc component.StartFunc,
+	shutdownFunc component.ShutdownFunc,
+) []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(cfg.TimeoutSettings),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+		exporterhelper.WithQueue(cfg.QueueBatchConfig),
+		exporterhelper.WithStart(startFunc),
+		exporterhelper.WithShutdown(shutdownFunc),
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+// mqttExporter holds the connection of a signal exporter, and publishes its messages.
+type mqttExporter struct {
+	cfg        Config
+	signalType string
+	signal     SignalConfig
+	logger     *zap.Logger
+	topic      *messaging.DestinationTemplate
+	client     mqtt.Client
+}
+
+func newMQTTExporter(cfg Config, set exporter.Settings, signalType string, signal SignalConfig) (*mqttExporter, error) {
+	topic, err := parseTopicTemplate(signal.Topic)
+	if err != nil {
+		return nil, err
+	}
+	return &mqttExporter{
+		cfg:        cfg,
+		signalType: signalType,
+		signal:     signal,
+		logger:     set.Logger,
+		topic:      topic,
+	}, nil
+}
+
+func (e *mqttExporter) connect(ctx context.Context, host component.Host) error {
+	client, err := mqtt.Connect(ctx, e.cfg.ClientConfig, host, e.logger, mqtt.ConnectOptions{
+		ClientIDSuffix: e.signalType,
+	})
+	if err != nil {
+		return err
+	}
+	e.client = client
+	return nil
+}
+
+func (e *mqttExporter) Close(ctx context.Context) error {
+	if e.client != nil {
+		return e.client.Disconnect(ctx)
+	}
+	return nil
+}
+
+// publish publishes the data to the topic, and waits for the acknowledgement of the
+// broker unless the QoS is 0.
+func (e *mqttExporter) publish(ctx context.Context, topic string, data []byte) error {
+	err := e.client.Publish(ctx, mqtt.Message{
+		Topic:    topic,
+		Payload:  data,
+		QoS:      byte(e.signal.QoS),
+		Retained: e.signal.Retain,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to topic %q: %w", topic, err)
+	}
+	return nil
+}
+
+type tracesExporter struct {
+	*mqttExporter
+	marshaler ptrace.Marshaler
+}
+
+func newTracesExporter(cfg Config, set exporter.Settings) (*tracesExporter, error) {
+	base, err := newMQTTExporter(cfg, set, "traces", cfg.Traces)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesExporter{mqttExporter: base}, nil
+}
+
+func (e *tracesExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewTracesMarshaler(e.cfg.Traces.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx, host)
+}
+
+func (e *tracesExporter) exportData(ctx context.Context, td ptrace.Traces) error {
+	groups := messaging.GroupTraces(td, e.topic)
+	for i, group := range groups {
+		data, err := e.marshaler.MarshalTraces(group.Data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal traces: %w", err))
+		}
+		if err := e.publish(ctx, group.Name, data); err != nil {
+			// Only the traces not published yet are retried.
+			return consumererror.NewTraces(err, messaging.RemainingTraces(groups[i:]))
+		}
+	}
+	return nil
+}
+
+type metricsExporter struct {
+	*mqttExporter
+	marshaler pmetric.Marshaler
+}
+
+func newMetricsExporter(cfg Config, set exporter.Settings) (*metricsExporter, error) {
+	base, err := newMQTTExporter(cfg, set, "metrics", cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsExporter{mqttExporter: base}, nil
+}
+
+func (e *metricsExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewMetricsMarshaler(e.cfg.Metrics.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx, host)
+}
+
+func (e *metricsExporter) exportData(ctx context.Context, md pmetric.Metrics) error {
+	groups := messaging.GroupMetrics(md, e.topic)
+	for i, group := range groups {
+		data, err := e.marshaler.MarshalMetrics(group.Data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal metrics: %w", err))
+		}
+		if err := e.publish(ctx, group.Name, data); err != nil {
+			// Only the metrics not published yet are retried.
+			return consumererror.NewMetrics(err, messaging.RemainingMetrics(groups[i:]))
+		}
+	}
+	return nil
+}
+
+type logsExporter struct {
+	*mqttExporter
+	marshaler plog.Marshaler
+}
+
+func newLogsExporter(cfg Config, set exporter.Settings) (*logsExporter, error) {
+	base, err := newMQTTExporter(cfg, set, "logs", cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+	return &logsExporter{mqttExporter: base}, nil
+}
+
+func (e *logsExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewLogsMarshaler(e.cfg.Logs.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx, host)
+}
+
+func (e *logsExporter) exportData(ctx context.Context, ld plog.Logs) error {
+	groups := messaging.GroupLogs(ld, e.topic)
+	for i, group := range groups {
+		data, err := e.marshaler.MarshalLogs(group.Data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal logs: %w", err))
+		}
+		if err := e.publish(ctx, group.Name, data); err != nil {
+			// Only the logs not published yet are retried.
+			return consumererror.NewLogs(err, messaging.RemainingLogs(groups[i:]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+var protocolVersions = []string{mqtt.ProtocolVersion311, mqtt.ProtocolVersion5}
+
+// runBroker starts an in-process broker, which denies publishing to the denied/# topics.
+func runBroker(t *testing.T) (*mochi.Server, string) {
+	broker := mochi.New(&mochi.Options{InlineClient: true, Logger: slog.New(slog.DiscardHandler)})
+	require.NoError(t, broker.AddHook(new(auth.Hook), &auth.Options{Ledger: &auth.Ledger{
+		Auth: auth.AuthRules{{Allow: true}},
+		ACL:  auth.ACLRules{{Filters: auth.Filters{"denied/#": auth.Deny}}},
+	}}))
+	tcp := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	require.NoError(t, broker.AddListener(tcp))
+	require.NoError(t, broker.Serve())
+	t.Cleanup(func() { _ = broker.Close() })
+	return broker, "tcp://" + tcp.Address()
+}
+
+// packetSink collects the messages published to the topics of an inline subscription.
+type packetSink struct {
+	mu      sync.Mutex
+	packets []packets.Packet
+}
+
+func subscribe(t *testing.T, broker *mochi.Server, filter string) *packetSink {
+	sink := &packetSink{}
+	require.NoError(t, broker.Subscribe(filter, 1, func(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		sink.packets = append(sink.packets, pk)
+	}))
+	return sink
+}
+
+// wait waits for the given number of messages, and returns them.
+func (s *packetSink) wait(t *testing.T, count int) []packets.Packet {
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.packets) >= count
+	}, 10*time.Second, 10*time.Millisecond)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]packets.Packet(nil), s.packets...)
+}
+
+func newTestConfig(endpoint, protocolVersion string) Config {
+	cfg := *createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = endpoint
+	cfg.ClientConfig.ProtocolVersion = protocolVersion
+	return cfg
+}
+
+func TestExportTopics(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+			sink := subscribe(t, broker, "factory/#")
+
+			cfg := newTestConfig(endpoint, version)
+			cfg.Logs.Topic = "factory/%{factory.line}/logs"
+			exp, err := newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+			logs := plog.NewLogs()
+			for _, line := range []string{"line1", "line2", "line1"} {
+				rl := logs.ResourceLogs().AppendEmpty()
+				rl.Resource().Attributes().PutStr("factory.line", line)
+				rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(line)
+			}
+			require.NoError(t, exp.exportData(t.Context(), logs))
+
+			// The resources are grouped by topic, in their order of appearance.
+			expected := []struct {
+				topic   string
+				records int
+			}{
+				{topic: "factory/line1/logs", records: 2},
+				{topic: "factory/line2/logs", records: 1},
+			}
+			received := sink.wait(t, len(expected))
+			require.Len(t, received, len(expected))
+			for i, e := range expected {
+				assert.Equal(t, e.topic, received[i].TopicName)
+				assert.Equal(t, byte(defaultQoS), received[i].FixedHeader.Qos)
+
+				ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(received[i].Payload)
+				require.NoError(t, err)
+				assert.Equal(t, e.records, ld.LogRecordCount())
+			}
+		})
+	}
+}
+
+func TestExportQoS(t *testing.T) {
+	for _, version := range protocolVersions {
+		for _, qos := range []int{0, 1, 2} {
+			t.Run(version+"/qos_"+strconv.Itoa(qos), func(t *testing.T) {
+				broker, endpoint := runBroker(t)
+				sink := subscribe(t, broker, defaultTracesTopic)
+
+				cfg := newTestConfig(endpoint, version)
+				cfg.Traces.QoS = qos
+				exp, err := newTracesExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+				require.NoError(t, err)
+				require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+				defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+				traces := ptrace.NewTraces()
+				traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+				require.NoError(t, exp.exportData(t.Context(), traces))
+
+				received := sink.wait(t, 1)
+				assert.Equal(t, byte(qos), received[0].FixedHeader.Qos)
+				assert.False(t, received[0].FixedHeader.Retain)
+				td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(received[0].Payload)
+				require.NoError(t, err)
+				assert.Equal(t, traces, td)
+			})
+		}
+	}
+}
+
+func TestExportRetain(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+
+			cfg := newTestConfig(endpoint, version)
+			cfg.Metrics.Encoding = "otlp_json"
+			cfg.Metrics.Retain = true
+			exp, err := newMetricsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+			for _, value := range []float64{21.5, 22} {
+				metrics := pmetric.NewMetrics()
+				m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+				m.SetName("temperature")
+				m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(value)
+				require.NoError(t, exp.exportData(t.Context(), metrics))
+			}
+
+			// Only the last retained message is delivered to the subscriptions made after
+			// it was published.
+			received := subscribe(t, broker, defaultMetricsTopic).wait(t, 1)
+			require.Len(t, received, 1)
+			assert.True(t, received[0].FixedHeader.Retain)
+			md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(received[0].Payload)
+			require.NoError(t, err)
+			assert.Equal(t, 22.0, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleValue())
+		})
+	}
+}
+
+func TestExportAfterReconnect(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+			sink := subscribe(t, broker, defaultLogsTopic)
+
+			cfg := newTestConfig(endpoint, version)
+			cfg.ClientConfig.ClientID = "collector"
+			exp, err := newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+			cl, ok := broker.Clients.Get("collector-logs")
+			require.True(t, ok)
+			cl.Stop(errors.New("connection lost"))
+
+			// The logs failing to be published while the connection is lost are retried,
+			// until the exporter has reconnected.
+			logs := plog.NewLogs()
+			logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message")
+			require.Eventually(t, func() bool {
+				ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+				defer cancel()
+				return exp.exportData(ctx, logs) == nil
+			}, 10*time.Second, 100*time.Millisecond)
+
+			received := sink.wait(t, 1)
+			assert.Equal(t, defaultLogsTopic, received[0].TopicName)
+		})
+	}
+}
+
+func TestExportNotAuthorized(t *testing.T) {
+	broker, endpoint := runBroker(t)
+	sink := subscribe(t, broker, "#")
+
+	cfg := newTestConfig(endpoint, mqtt.ProtocolVersion5)
+	cfg.Logs.Topic = "%{zone}/logs"
+	exp, err := newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	// Only the topic of the first resource can be published to.
+	logs := plog.NewLogs()
+	for _, zone := range []string{"allowed", "denied"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("zone", zone)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+	err = exp.exportData(t.Context(), logs)
+	require.ErrorContains(t, err, `failed to publish to topic "denied/logs"`)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// Only the logs not published are retried.
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	require.Equal(t, 1, logsErr.Data().ResourceLogs().Len())
+	zone, _ := logsErr.Data().ResourceLogs().At(0).Resource().Attributes().Get("zone")
+	assert.Equal(t, "denied", zone.Str())
+
+	received := sink.wait(t, 1)
+	require.Len(t, received, 1)
+	assert.Equal(t, "allowed/logs", received[0].TopicName)
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+)
+
+// levelReplacer replaces the characters of attribute values which would change the
+// levels of a topic, or turn it into a wildcard.
+var levelReplacer = strings.NewReplacer("/", "_", "+", "_", "#", "_", "\x00", "_")
+
+// parseTopicTemplate parses a topic referencing resource attributes with %{<attribute>}.
+func parseTopicTemplate(topic string) (*messaging.DestinationTemplate, error) {
+	return messaging.ParseDestinationTemplate("topic", topic, levelReplacer, validateTopic)
+}
+
+// validateTopic checks that messages can be published to the topic.
+func validateTopic(topic string) error {
+	switch {
+	case strings.HasPrefix(topic, "$"):
+		return errors.New("topics starting with '$' are reserved by the broker")
+	case strings.ContainsAny(topic, "+#"):
+		return errors.New("wildcards are not allowed")
+	case strings.ContainsRune(topic, 0):
+		return errors.New("null characters are not allowed")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseTopicTemplate(t *testing.T) {
+	tests := []struct {
+		topic  string
+		static bool
+		err    string
+	}{
+		{topic: "otlp/logs", static: true},
+		{topic: "/otlp//logs", static: true},
+		{topic: "otlp/%{service.name}"},
+		{topic: "otlp/%{service.namespace}-%{service.name}/logs"},
+		{topic: "", err: "topic must not be empty"},
+		{topic: "otlp/#", err: `invalid topic "otlp/#": wildcards are not allowed`},
+		{topic: "otlp/+/logs", err: `invalid topic "otlp/+/logs": wildcards are not allowed`},
+		{topic: "$SYS/logs", err: `invalid topic "$SYS/logs": topics starting with '$' are reserved by the broker`},
+		{topic: "otlp/\x00", err: "invalid topic \"otlp/\\x00\": null characters are not allowed"},
+		{topic: "otlp/%{}", err: `empty attribute reference in topic "otlp/%{}"`},
+		{topic: "otlp/%{service.name", err: `unterminated attribute reference in topic "otlp/%{service.name"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			template, err := parseTopicTemplate(tt.topic)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.static, template.IsStatic())
+		})
+	}
+}
+
+func TestRenderTopic(t *testing.T) {
+	template, err := parseTopicTemplate("factory/%{factory.line}/%{device.id}")
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("factory.line", "line1")
+	attributes.PutStr("device.id", "plc/7+#")
+	assert.Equal(t, "factory/line1/plc_7__", template.Render(attributes))
+}
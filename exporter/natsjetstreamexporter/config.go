@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsjetstreamexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+)
+
+var (
+	errBatchPartitionMetadataKeysRequired = errors.New("sending_queue::batch::partition::metadata_keys must be configured when include_metadata_keys is set and batching is enabled")
+	errIncludeMetadataKeysNotPartitioned  = errors.New("sending_queue::batch::partition::metadata_keys must include all include_metadata_keys values")
+)
+
+// Config defines configuration for the NATS JetStream exporter.
+type Config struct {
+	TimeoutSettings  exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueBatchConfig configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	BackOffConfig    configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	ClientConfig     natsjetstream.ClientConfig                               `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be published.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be published.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be published.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// IncludeMetadataKeys indicates the receiver's client metadata keys to propagate as message headers.
+	IncludeMetadataKeys []string `mapstructure:"include_metadata_keys"`
+
+	// Headers sets static headers on every published message.
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// SignalConfig holds signal-specific configuration for the NATS JetStream exporter.
+type SignalConfig struct {
+	// Subject is the subject the messages of the signal type are published to. It must be
+	// captured by a JetStream stream. Resource attributes are referenced with %{<attribute>},
+	// in which case the data is published to a subject per distinct value.
+	//
+	// The default depends on the signal type:
+	//  - "otlp.traces" for traces
+	//  - "otlp.metrics" for metrics
+	//  - "otlp.logs" for logs
+	Subject string `mapstructure:"subject"`
+
+	// Encoding holds the encoding of messages for the signal type, either "otlp_proto",
+	// "otlp_json" or the ID of an encoding extension.
+	//
+	// Defaults to "otlp_proto".
+	Encoding string `mapstructure:"encoding"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if _, err := parseSubjectTemplate(c.Logs.Subject); err != nil {
+		errs = append(errs, fmt.Errorf("logs::subject: %w", err))
+	}
+	if _, err := parseSubjectTemplate(c.Metrics.Subject); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::subject: %w", err))
+	}
+	if _, err := parseSubjectTemplate(c.Traces.Subject); err != nil {
+		errs = append(errs, fmt.Errorf("traces::subject: %w", err))
+	}
+	if err := validateBatchPartitionerKeys(c); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// validateBatchPartitionerKeys checks that batches are not mixing data of different client
+// metadata, whose keys are published as headers.
+func validateBatchPartitionerKeys(c *Config) error {
+	if len(c.IncludeMetadataKeys) == 0 || !c.QueueBatchConfig.HasValue() || !c.QueueBatchConfig.Get().Batch.HasValue() {
+		return nil
+	}
+	partitionMetadataKeys := c.QueueBatchConfig.Get().Batch.Get().Partition.MetadataKeys
+	if len(partitionMetadataKeys) == 0 {
+		return errBatchPartitionMetadataKeysRequired
+	}
+	partitionMetadataKeySet := make(map[string]struct{}, len(partitionMetadataKeys))
+	for _, key := range partitionMetadataKeys {
+		partitionMetadataKeySet[key] = struct{}{}
+	}
+	for _, includeKey := range c.IncludeMetadataKeys {
+		if _, ok := partitionMetadataKeySet[includeKey]; !ok {
+			return fmt.Errorf("%w: missing %q from sending_queue::batch::partition::metadata_keys=%v",
+				errIncludeMetadataKeysNotPartitioned,
+				includeKey,
+				partitionMetadataKeys,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsjetstreamexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "nats://nats:4222"
+				cfg.ClientConfig.Auth.Token = "secret"
+				cfg.TimeoutSettings.Timeout = 10 * time.Second
+				cfg.QueueBatchConfig = configoptional.Some(func() exporterhelper.QueueBatchConfig {
+					queue := exporterhelper.NewDefaultQueueConfig()
+					queue.Batch.GetOrInsertDefault().Partition.MetadataKeys = []string{"tenant"}
+					return queue
+				}())
+				cfg.Logs = SignalConfig{Subject: "logs.%{service.name}", Encoding: "otlp_json"}
+				cfg.Traces.Encoding = "jaeger_encoding"
+				cfg.IncludeMetadataKeys = []string{"tenant"}
+				cfg.Headers = map[string]string{"X-Source": "collector"}
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_subject"),
+			expectedErr: `logs::subject: invalid subject "logs.*": wildcards are not allowed` + "\n" +
+				`traces::subject: unterminated attribute reference in subject "traces.%{service.name"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "empty_subject"),
+			expectedErr: "metrics::subject: subject must not be empty",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "metadata_keys_not_partitioned"),
+			expectedErr: errBatchPartitionMetadataKeysRequired.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			expectedErr: "endpoint is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestValidateBatchPartitionerKeys(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.IncludeMetadataKeys = []string{"tenant", "region"}
+	cfg.QueueBatchConfig.Get().Batch.GetOrInsertDefault().Partition.MetadataKeys = []string{"tenant"}
+	assert.ErrorIs(t, cfg.Validate(), errIncludeMetadataKeysNotPartitioned)
+
+	cfg.QueueBatchConfig.Get().Batch.Get().Partition.MetadataKeys = []string{"region", "tenant"}
+	assert.NoError(t, cfg.Validate())
+
+	// Without batching, the requests are never merged.
+	cfg.QueueBatchConfig.Get().Batch = configoptional.None[exporterhelper.BatchConfig]()
+	assert.NoError(t, cfg.Validate())
+}
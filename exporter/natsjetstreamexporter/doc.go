@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package natsjetstreamexporter publishes telemetry to NATS JetStream.
+package natsjetstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsjetstreamexporter"
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsjetstreamexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+)
+
+// natsExporter holds the connection shared by the signal exporters, and publishes their messages.
+type natsExporter struct {
+	cfg     Config
+	logger  *zap.Logger
+	subject *messaging.DestinationTemplate
+	conn    *nats.Conn
+	js      jetstream.JetStream
+}
+
+func newNATSExporter(cfg Config, set exporter.Settings, signal SignalConfig) (*natsExporter, error) {
+	subject, err := parseSubjectTemplate(signal.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return &natsExporter{
+		cfg:     cfg,
+		logger:  set.Logger,
+		subject: subject,
+	}, nil
+}
+
+func (e *natsExporter) connect(ctx context.Context) error {
+	conn, js, err := natsjetstream.Connect(ctx, e.cfg.ClientConfig, e.logger)
+	if err != nil {
+		return err
+	}
+	e.conn, e.js = conn, js
+	return nil
+}
+
+func (e *natsExporter) Close(context.Context) error {
+	if e.conn != nil {
+		e.conn.Close()
+	}
+	return nil
+}
+
+// headers returns the headers of the messages published for the given context.
+func (e *natsExporter) headers(ctx context.Context) nats.Header {
+	header := nats.Header{}
+	for key, value := range e.cfg.Headers {
+		header.Set(key, value)
+	}
+	info := client.FromContext(ctx)
+	for _, key := range e.cfg.IncludeMetadataKeys {
+		for _, value := range info.Metadata.Get(key) {
+			header.Add(key, value)
+		}
+	}
+	return header
+}
+
+// publish publishes the data to the subject, and waits for the acknowledgement of the stream.
+func (e *natsExporter) publish(ctx context.Context, subject string, data []byte, header nats.Header) error {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	msg.Header = header
+	if _, err := e.js.PublishMsg(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish to subject %q: %w", subject, err)
+	}
+	return nil
+}
+
+type tracesExporter struct {
+	*natsExporter
+	marshaler ptrace.Marshaler
+}
+
+func newTracesExporter(cfg Config, set exporter.Settings) (*tracesExporter, error) {
+	base, err := newNATSExporter(cfg, set, cfg.Traces)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesExporter{natsExporter: base}, nil
+}
+
+func (e *tracesExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewTracesMarshaler(e.cfg.Traces.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx)
+}
+
+func (e *tracesExporter) exportData(ctx context.Context, td ptrace.Traces) error {
+	header := e.headers(ctx)
+	groups := messaging.GroupTraces(td, e.subject)
+	for i, group := range groups {
+		data, err := e.marshaler.MarshalTraces(group.Data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal traces: %w", err))
+		}
+		if err := e.publish(ctx, group.Name, data, header); err != nil {
+			// Only the traces not published yet are retried.
+			return consumererror.NewTraces(err, messaging.RemainingTraces(groups[i:]))
+		}
+	}
+	return nil
+}
+
+type metricsExporter struct {
+	*natsExporter
+	marshaler pmetric.Marshaler
+}
+
+func newMetricsExporter(cfg Config, set exporter.Settings) (*metricsExporter, error) {
+	base, err := newNATSExporter(cfg, set, cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsExporter{natsExporter: base}, nil
+}
+
+func (e *metricsExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewMetricsMarshaler(e.cfg.Metrics.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx)
+}
+
+func (e *metricsExporter) exportData(ctx context.Context, md pmetric.Metrics) error {
+	header := e.headers(ctx)
+	groups := messaging.GroupMetrics(md, e.subject)
+	for i, group := range groups {
+		data, err := e.marshaler.MarshalMetrics(group.Data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal metrics: %w", err))
+		}
+		if err := e.publish(ctx, group.Name, data, header); err != nil {
+			// Only the metrics not published yet are retried.
+			return consumererror.NewMetrics(err, messaging.RemainingMetrics(groups[i:]))
+		}
+	}
+	return nil
+}
+
+type logsExporter struct {
+	*natsExporter
+	marshaler plog.Marshaler
+}
+
+func newLogsExporter(cfg Config, set exporter.Settings) (*logsExporter, error) {
+	base, err := newNATSExporter(cfg, set, cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+	return &logsExporter{natsExporter: base}, nil
+}
+
+func (e *logsExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewLogsMarshaler(e.cfg.Logs.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx)
+}
+
+func (e *logsExporter) exportData(ctx context.Context, ld plog.Logs) error {
+	header := e.headers(ctx)
+	groups := messaging.GroupLogs(ld, e.subject)
+	for i, group := range groups {
+		data, err := e.marshaler.MarshalLogs(group.Data)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal logs: %w", err))
+		}
+		if err := e.publish(ctx, group.Name, data, header); err != nil {
+			// Only the logs not published yet are retried.
+			return consumererror.NewLogs(err, messaging.RemainingLogs(groups[i:]))
+		}
+	}
+	return nil
+}
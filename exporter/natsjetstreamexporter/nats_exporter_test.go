@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsjetstreamexporter/internal/metadata"
+)
+
+// runServer starts an embedded NATS server with JetStream enabled, and creates a
+// stream capturing the subjects published to by default.
+func runServer(t *testing.T) (*server.Server, jetstream.Stream) {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		NoLog:     true,
+		NoSigs:    true,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	require.True(t, srv.ReadyForConnections(10*time.Second))
+	t.Cleanup(srv.Shutdown)
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	stream, err := js.CreateStream(t.Context(), jetstream.StreamConfig{
+		Name:     "OTLP",
+		Subjects: []string{"otlp.>"},
+	})
+	require.NoError(t, err)
+	return srv, stream
+}
+
+func newTestConfig(srv *server.Server) Config {
+	cfg := *createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = srv.ClientURL()
+	return cfg
+}
+
+// getMessage returns the message of the stream with the given sequence.
+func getMessage(t *testing.T, stream jetstream.Stream, seq uint64) *jetstream.RawStreamMsg {
+	msg, err := stream.GetMsg(t.Context(), seq)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestExportLogs(t *testing.T) {
+	srv, stream := runServer(t)
+
+	cfg := newTestConfig(srv)
+	cfg.Logs.Subject = "otlp.logs.%{service.name}"
+	cfg.IncludeMetadataKeys = []string{"tenant"}
+	cfg.Headers = map[string]string{"X-Source": "collector"}
+	exp, err := newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	logs := plog.NewLogs()
+	for _, service := range []string{"cart", "checkout", "cart"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(service)
+	}
+	ctx := client.NewContext(t.Context(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}, "ignored": {"value"}}),
+	})
+	require.NoError(t, exp.exportData(ctx, logs))
+
+	// The resources are grouped by subject, in their order of appearance.
+	expected := []struct {
+		subject string
+		records int
+	}{
+		{subject: "otlp.logs.cart", records: 2},
+		{subject: "otlp.logs.checkout", records: 1},
+	}
+	for i, e := range expected {
+		msg := getMessage(t, stream, uint64(i+1))
+		assert.Equal(t, e.subject, msg.Subject)
+		assert.Equal(t, "acme", msg.Header.Get("tenant"))
+		assert.Equal(t, "collector", msg.Header.Get("X-Source"))
+		assert.Empty(t, msg.Header.Get("ignored"))
+
+		received, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(msg.Data)
+		require.NoError(t, err)
+		assert.Equal(t, e.records, received.LogRecordCount())
+	}
+}
+
+func TestExportMetrics(t *testing.T) {
+	srv, stream := runServer(t)
+
+	cfg := newTestConfig(srv)
+	cfg.Metrics.Encoding = "otlp_json"
+	exp, err := newMetricsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	metrics := pmetric.NewMetrics()
+	m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, exp.exportData(t.Context(), metrics))
+
+	msg := getMessage(t, stream, 1)
+	assert.Equal(t, defaultMetricsSubject, msg.Subject)
+	received, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(msg.Data)
+	require.NoError(t, err)
+	assert.Equal(t, metrics, received)
+}
+
+func TestExportTraces(t *testing.T) {
+	srv, stream := runServer(t)
+
+	exp, err := newTracesExporter(newTestConfig(srv), exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.exportData(t.Context(), traces))
+
+	msg := getMessage(t, stream, 1)
+	assert.Equal(t, defaultTracesSubject, msg.Subject)
+	received, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(msg.Data)
+	require.NoError(t, err)
+	assert.Equal(t, traces, received)
+}
+
+func TestExportWithoutStream(t *testing.T) {
+	srv, stream := runServer(t)
+
+	cfg := newTestConfig(srv)
+	cfg.Logs.Subject = "%{service.name}.logs"
+	exp, err := newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	// Only the subject of the first resource is captured by the stream.
+	logs := plog.NewLogs()
+	for _, service := range []string{"otlp", "other"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	}
+	err = exp.exportData(t.Context(), logs)
+	require.ErrorContains(t, err, `failed to publish to subject "other.logs"`)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// Only the logs not published are retried.
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	require.Equal(t, 1, logsErr.Data().ResourceLogs().Len())
+	name, _ := logsErr.Data().ResourceLogs().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "other", name.Str())
+
+	info, err := stream.Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.State.Msgs)
+}
+
+func TestStartErrors(t *testing.T) {
+	srv, _ := runServer(t)
+
+	cfg := newTestConfig(srv)
+	cfg.Logs.Encoding = "unknown"
+	exp, err := newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	assert.ErrorContains(t, exp.Start(t.Context(), componenttest.NewNopHost()), `invalid encoding "unknown"`)
+
+	cfg = newTestConfig(srv)
+	cfg.ClientConfig.Endpoint = "nats://127.0.0.1:1"
+	cfg.ClientConfig.ConnectTimeout = 100 * time.Millisecond
+	exp, err = newLogsExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	assert.ErrorContains(t, exp.Start(t.Context(), componenttest.NewNopHost()), "failed to connect")
+	assert.NoError(t, exp.Close(t.Context()))
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsjetstreamexporter"
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+)
+
+// tokenReplacer replaces the characters of attribute values which would change the
+// tokens of a subject, or turn it into a wildcard.
+var tokenReplacer = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "\t", "_", "\r", "_", "\n", "_")
+
+// parseSubjectTemplate parses a subject referencing resource attributes with %{<attribute>}.
+func parseSubjectTemplate(subject string) (*messaging.DestinationTemplate, error) {
+	return messaging.ParseDestinationTemplate("subject", subject, tokenReplacer, validateSubject)
+}
+
+// validateSubject checks that messages can be published to the subject.
+func validateSubject(subject string) error {
+	for token := range strings.SplitSeq(subject, ".") {
+		switch {
+		case token == "":
+			return errors.New("tokens must not be empty")
+		case strings.ContainsAny(token, "*>"):
+			return errors.New("wildcards are not allowed")
+		case strings.ContainsAny(token, " \t\r\n"):
+			return errors.New("whitespaces are not allowed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseSubjectTemplate(t *testing.T) {
+	tests := []struct {
+		subject string
+		static  bool
+		err     string
+	}{
+		{subject: "otlp.logs", static: true},
+		{subject: "otlp.%{service.name}"},
+		{subject: "otlp.%{service.namespace}-%{service.name}.logs"},
+		{subject: "", err: "subject must not be empty"},
+		{subject: "otlp..logs", err: `invalid subject "otlp..logs": tokens must not be empty`},
+		{subject: "otlp.>", err: `invalid subject "otlp.>": wildcards are not allowed`},
+		{subject: "otlp logs", err: `invalid subject "otlp logs": whitespaces are not allowed`},
+		{subject: "otlp.%{}", err: `empty attribute reference in subject "otlp.%{}"`},
+		{subject: "otlp.%{service.name", err: `unterminated attribute reference in subject "otlp.%{service.name"`},
+		{subject: "%{service.name}.", err: `invalid subject "%{service.name}.": tokens must not be empty`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			template, err := parseSubjectTemplate(tt.subject)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.static, template.IsStatic())
+		})
+	}
+}
+
+func TestRenderSubject(t *testing.T) {
+	template, err := parseSubjectTemplate("otlp.%{service.namespace}.%{service.name}")
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("service.namespace", "shop")
+	attributes.PutStr("service.name", "check out.v2*>")
+	assert.Equal(t, "otlp.shop.check_out_v2__", template.Render(attributes))
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	hypertablesAuto     = "auto"
+	hypertablesEnabled  = "enabled"
+	hypertablesDisabled = "disabled"
+)
+
+// Config defines configuration for the PostgreSQL exporter.
+type Config struct {
+	TimeoutSettings exporterhelper.TimeoutConfig                             `mapstructure:",squash"`
+	BackOffConfig   configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	QueueSettings   configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+
+	// Endpoint is the PostgreSQL connection string, either as a URL or in the keyword/value format,
+	// e.g. `postgres://localhost:5432/otel?sslmode=disable`.
+	Endpoint string `mapstructure:"endpoint"`
+	// Username is the authentication username. It overrides the user of the connection string.
+	Username string `mapstructure:"username"`
+	// Password is the authentication password. It overrides the password of the connection string.
+	Password configopaque.String `mapstructure:"password"`
+	// Schema is the PostgreSQL schema of the tables. default is `public`.
+	Schema string `mapstructure:"schema"`
+	// Table is the names of the tables.
+	Table Table `mapstructure:"table"`
+	// CreateSchema if set to true will create the schema and the tables at startup. default is true.
+	CreateSchema bool `mapstructure:"create_schema"`
+	// Timescale configures the TimescaleDB hypertables; ignored if CreateSchema is false.
+	Timescale TimescaleConfig `mapstructure:"timescale"`
+}
+
+// Table defines the names of the tables.
+type Table struct {
+	// Logs is the table name for logs. default is `otel_logs`.
+	Logs string `mapstructure:"logs"`
+	// Traces is the table name for traces. default is `otel_traces`.
+	Traces string `mapstructure:"traces"`
+	// Metrics is the prefix of the table names for metrics, suffixed with the metric type,
+	// e.g. `otel_metrics_gauge`. default is `otel_metrics`.
+	Metrics string `mapstructure:"metrics"`
+}
+
+// TimescaleConfig defines how the tables are turned into TimescaleDB hypertables.
+type TimescaleConfig struct {
+	// Hypertables is one of `auto` (default), creating hypertables when the timescaledb extension is installed
+	// in the database, `enabled`, failing at startup when it is not, or `disabled`.
+	Hypertables string `mapstructure:"hypertables"`
+	// ChunkInterval is the time interval covered by each chunk of the hypertables. default is 24h.
+	ChunkInterval time.Duration `mapstructure:"chunk_interval"`
+	// Retention is the age after which the chunks are dropped. 0 means the data is kept forever.
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+var errConfigNoEndpoint = errors.New("endpoint must be specified")
+
+// Validate the PostgreSQL exporter configuration.
+func (cfg *Config) Validate() (err error) {
+	if cfg.Endpoint == "" {
+		err = errors.Join(err, errConfigNoEndpoint)
+	} else if _, e := pgx.ParseConfig(cfg.Endpoint); e != nil {
+		err = errors.Join(err, fmt.Errorf("invalid endpoint: %w", e))
+	}
+
+	if cfg.Schema == "" {
+		err = errors.Join(err, errors.New("schema must be specified"))
+	}
+	if cfg.Table.Logs == "" || cfg.Table.Traces == "" || cfg.Table.Metrics == "" {
+		err = errors.Join(err, errors.New("table::logs, table::traces and table::metrics must be specified"))
+	}
+
+	switch cfg.Timescale.Hypertables {
+	case hypertablesAuto, hypertablesEnabled, hypertablesDisabled:
+	default:
+		err = errors.Join(err, fmt.Errorf("timescale::hypertables must be one of %q, %q or %q", hypertablesAuto, hypertablesEnabled, hypertablesDisabled))
+	}
+	if cfg.Timescale.ChunkInterval <= 0 {
+		err = errors.Join(err, errors.New("timescale::chunk_interval must be positive"))
+	}
+	if cfg.Timescale.Retention < 0 {
+		err = errors.Join(err, errors.New("timescale::retention must not be negative"))
+	}
+	if cfg.Timescale.Retention > 0 && cfg.Timescale.Hypertables == hypertablesDisabled {
+		err = errors.Join(err, errors.New("timescale::retention requires the hypertables"))
+	}
+
+	return err
+}
+
+// buildPoolConfig returns the configuration of the connection pool.
+func (cfg *Config) buildPoolConfig() (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	connConfig := poolConfig.ConnConfig
+	// Override username and password if specified in config.
+	if cfg.Username != "" {
+		connConfig.User = cfg.Username
+		connConfig.Password = string(cfg.Password)
+	}
+	if _, ok := connConfig.RuntimeParams["application_name"]; !ok {
+		connConfig.RuntimeParams["application_name"] = "otelcol"
+	}
+	// The exporter never sends parameterized statements, the simple protocol saves the round-trips
+	// preparing them and keeps it working behind poolers in transaction mode.
+	connConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+
+	return poolConfig, nil
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "postgres://localhost:5432/otel?sslmode=disable"
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "postgres://localhost:5432/otel?sslmode=disable"
+				cfg.Username = "otel"
+				cfg.Password = "secret"
+				cfg.Schema = "telemetry"
+				cfg.Table = Table{Logs: "logs", Traces: "spans", Metrics: "metrics"}
+				cfg.CreateSchema = false
+				cfg.Timescale = TimescaleConfig{
+					Hypertables:   hypertablesEnabled,
+					ChunkInterval: 6 * time.Hour,
+					Retention:     30 * 24 * time.Hour,
+				}
+				cfg.TimeoutSettings.Timeout = 10 * time.Second
+				cfg.BackOffConfig.MaxElapsedTime = 300 * time.Second
+				queue := cfg.QueueSettings.Get()
+				queue.NumConsumers = 2
+				queue.QueueSize = 100
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_endpoint"),
+			expectedErr: "invalid endpoint: cannot parse `postgres://localhost:port/otel`",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_endpoint"),
+			expectedErr: "endpoint must be specified",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_timescale"),
+			expectedErr: `timescale::hypertables must be one of "auto", "enabled" or "disabled"` + "\n" +
+				"timescale::chunk_interval must be positive\n" +
+				"timescale::retention must not be negative",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "retention_without_hypertables"),
+			expectedErr: "timescale::retention requires the hypertables",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestBuildPoolConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "host=localhost port=5433 user=postgres password=postgres dbname=otel"
+	cfg.Username = "otel"
+	cfg.Password = "secret"
+
+	poolConfig, err := cfg.buildPoolConfig()
+	require.NoError(t, err)
+	connConfig := poolConfig.ConnConfig
+	assert.Equal(t, "localhost", connConfig.Host)
+	assert.Equal(t, uint16(5433), connConfig.Port)
+	assert.Equal(t, "otel", connConfig.Database)
+	assert.Equal(t, "otel", connConfig.User)
+	assert.Equal(t, "secret", connConfig.Password)
+	assert.Equal(t, "otelcol", connConfig.RuntimeParams["application_name"])
+	assert.Equal(t, pgx.QueryExecModeSimpleProtocol, connConfig.DefaultQueryExecMode)
+}
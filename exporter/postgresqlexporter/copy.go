@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// copyBuffer encodes the rows copied into a table in the text format of COPY FROM STDIN:
+// one row per line, tab-separated columns and \N for NULL.
+// See https://www.postgresql.org/docs/current/sql-copy.html#id-1.9.3.55.9.2.
+type copyBuffer struct {
+	table *table
+	buf   []byte
+	rows  int
+	// newRow is true until the first column of the current row is written.
+	newRow bool
+	// err is the first error encoding a column.
+	err error
+}
+
+func newCopyBuffer(t *table) *copyBuffer {
+	return &copyBuffer{table: t, newRow: true}
+}
+
+// separate writes the separator preceding a column.
+func (b *copyBuffer) separate() {
+	if b.newRow {
+		b.newRow = false
+		return
+	}
+	b.buf = append(b.buf, '\t')
+}
+
+// endRow terminates the current row.
+func (b *copyBuffer) endRow() {
+	b.buf = append(b.buf, '\n')
+	b.rows++
+	b.newRow = true
+}
+
+func (b *copyBuffer) null() {
+	b.separate()
+	b.buf = append(b.buf, `\N`...)
+}
+
+// text writes a string, escaping the characters with a special meaning in the COPY format.
+func (b *copyBuffer) text(s string) {
+	b.separate()
+	s = sanitize(s)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			b.buf = append(b.buf, `\\`...)
+		case '\t':
+			b.buf = append(b.buf, `\t`...)
+		case '\n':
+			b.buf = append(b.buf, `\n`...)
+		case '\r':
+			b.buf = append(b.buf, `\r`...)
+		default:
+			b.buf = append(b.buf, c)
+		}
+	}
+}
+
+// optionalText writes a string, or NULL if it is empty.
+func (b *copyBuffer) optionalText(s string) {
+	if s == "" {
+		b.null()
+		return
+	}
+	b.text(s)
+}
+
+func (b *copyBuffer) int(v int64) {
+	b.separate()
+	b.buf = strconv.AppendInt(b.buf, v, 10)
+}
+
+func (b *copyBuffer) uint(v uint64) {
+	b.separate()
+	b.buf = strconv.AppendUint(b.buf, v, 10)
+}
+
+func (b *copyBuffer) float(v float64) {
+	b.separate()
+	b.buf = appendFloat(b.buf, v)
+}
+
+// optionalFloat writes a float, or NULL if it is not set.
+func (b *copyBuffer) optionalFloat(v float64, ok bool) {
+	if !ok {
+		b.null()
+		return
+	}
+	b.float(v)
+}
+
+func (b *copyBuffer) bool(v bool) {
+	b.separate()
+	if v {
+		b.buf = append(b.buf, 't')
+	} else {
+		b.buf = append(b.buf, 'f')
+	}
+}
+
+// timestamp writes a timestamp with an explicit UTC offset, PostgreSQL rounds it to microseconds.
+func (b *copyBuffer) timestamp(ts pcommon.Timestamp) {
+	b.separate()
+	b.buf = ts.AsTime().UTC().AppendFormat(b.buf, "2006-01-02 15:04:05.999999999")
+	b.buf = append(b.buf, "+00"...)
+}
+
+// optionalTimestamp writes a timestamp, or NULL if it is not set.
+func (b *copyBuffer) optionalTimestamp(ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.null()
+		return
+	}
+	b.timestamp(ts)
+}
+
+// uints writes an array of integers, e.g. {1,2,3}.
+func (b *copyBuffer) uints(v []uint64) {
+	b.separate()
+	b.buf = append(b.buf, '{')
+	for i, n := range v {
+		if i > 0 {
+			b.buf = append(b.buf, ',')
+		}
+		b.buf = strconv.AppendUint(b.buf, n, 10)
+	}
+	b.buf = append(b.buf, '}')
+}
+
+// floats writes an array of floats, e.g. {0.5,1,NaN}.
+func (b *copyBuffer) floats(v []float64) {
+	b.separate()
+	b.buf = append(b.buf, '{')
+	for i, f := range v {
+		if i > 0 {
+			b.buf = append(b.buf, ',')
+		}
+		b.buf = appendFloat(b.buf, f)
+	}
+	b.buf = append(b.buf, '}')
+}
+
+// json writes a value encoded as JSON.
+func (b *copyBuffer) json(v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		b.null()
+		return
+	}
+	b.text(string(data))
+}
+
+// attributes writes a map of attributes as a JSON object.
+func (b *copyBuffer) attributes(m pcommon.Map) {
+	b.json(rawMap(m))
+}
+
+// appendFloat appends a float in the format of the float8 type, e.g. Infinity rather than +Inf.
+func appendFloat(buf []byte, f float64) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(buf, "NaN"...)
+	case math.IsInf(f, 1):
+		return append(buf, "Infinity"...)
+	case math.IsInf(f, -1):
+		return append(buf, "-Infinity"...)
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, 64)
+}
+
+// sanitize makes a string acceptable by PostgreSQL, which rejects invalid UTF-8 and NUL characters
+// in text and JSONB values.
+func sanitize(s string) string {
+	if strings.IndexByte(s, 0) >= 0 {
+		s = strings.ReplaceAll(s, "\x00", "")
+	}
+	return strings.ToValidUTF8(s, "�")
+}
+
+// rawMap returns the attributes as a map encodable as JSON.
+func rawMap(m pcommon.Map) map[string]any {
+	raw := make(map[string]any, m.Len())
+	for k, v := range m.All() {
+		raw[sanitize(k)] = rawValue(v)
+	}
+	return raw
+}
+
+// rawValue returns the value encodable as JSON. JSON has no representation of the non-finite floats,
+// they are encoded as strings.
+func rawValue(v pcommon.Value) any {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		return sanitize(v.Str())
+	case pcommon.ValueTypeInt:
+		return v.Int()
+	case pcommon.ValueTypeDouble:
+		f := v.Double()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return string(appendFloat(nil, f))
+		}
+		return f
+	case pcommon.ValueTypeBool:
+		return v.Bool()
+	case pcommon.ValueTypeMap:
+		return rawMap(v.Map())
+	case pcommon.ValueTypeSlice:
+		raw := make([]any, 0, v.Slice().Len())
+		for _, e := range v.Slice().All() {
+			raw = append(raw, rawValue(e))
+		}
+		return raw
+	case pcommon.ValueTypeBytes:
+		return v.Bytes().AsRaw()
+	default:
+		return nil
+	}
+}
+
+// rawTimestamp returns the timestamp encodable as JSON.
+func rawTimestamp(ts pcommon.Timestamp) string {
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
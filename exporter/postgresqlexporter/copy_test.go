@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestCopyBuffer(t *testing.T) {
+	b := newCopyBuffer(&table{name: "test"})
+	b.text("tab\tnewline\nreturn\rbackslash\\")
+	b.optionalText("")
+	b.int(-1)
+	b.uint(math.MaxUint64)
+	b.bool(true)
+	b.endRow()
+	b.float(math.NaN())
+	b.float(math.Inf(1))
+	b.float(math.Inf(-1))
+	b.optionalFloat(0, false)
+	b.floats([]float64{0.25, 1e21})
+	b.uints(nil)
+	b.endRow()
+	b.timestamp(pcommon.NewTimestampFromTime(time.Date(2025, 1, 2, 3, 4, 5, 6000, time.FixedZone("CET", 3600))))
+	b.optionalTimestamp(0)
+	b.endRow()
+
+	assert.NoError(t, b.err)
+	assert.Equal(t, 3, b.rows)
+	assert.Equal(t, "tab\\tnewline\\nreturn\\rbackslash\\\\\t\\N\t-1\t18446744073709551615\tt\n"+
+		"NaN\tInfinity\t-Infinity\t\\N\t{0.25,1e+21}\t{}\n"+
+		"2025-01-02 02:04:05.000006+00\t\\N\n", string(b.buf))
+}
+
+func TestCopyBufferAttributes(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("str", "nul\x00 invalid\xff")
+	attrs.PutDouble("nan", math.NaN())
+	attrs.PutEmptyBytes("bytes").FromRaw([]byte("hi"))
+	attrs.PutEmptySlice("slice").AppendEmpty().SetBool(true)
+	attrs.PutEmptyMap("map").PutInt("int", 1)
+	attrs.PutEmpty("empty")
+
+	b := newCopyBuffer(&table{name: "test"})
+	b.attributes(attrs)
+
+	assert.NoError(t, b.err)
+	assert.JSONEq(t, `{
+		"str": "nul invalid�",
+		"nan": "NaN",
+		"bytes": "aGk=",
+		"slice": [true],
+		"map": {"int": 1},
+		"empty": null
+	}`, string(b.buf))
+}
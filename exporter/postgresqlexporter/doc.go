@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package postgresqlexporter implements an exporter that copies logs, traces and metrics into PostgreSQL,
+// optionally using TimescaleDB hypertables.
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/sqltemplates"
+)
+
+// table is a table the exporter copies rows into.
+type table struct {
+	name string
+	ddl  *template.Template
+	// copySQL is the COPY statement copying rows into the table.
+	copySQL string
+}
+
+func newTable(schema, name string, ddl *template.Template, columns ...string) *table {
+	quoted := make([]string, 0, len(columns))
+	for _, c := range columns {
+		quoted = append(quoted, pgx.Identifier{c}.Sanitize())
+	}
+	return &table{
+		name:    name,
+		ddl:     ddl,
+		copySQL: fmt.Sprintf("COPY %s (%s) FROM STDIN", pgx.Identifier{schema, name}.Sanitize(), strings.Join(quoted, ", ")),
+	}
+}
+
+// client is the connection pool shared by the exporters of a signal.
+type client struct {
+	cfg    *Config
+	logger *zap.Logger
+	tables []*table
+	pool   *pgxpool.Pool
+}
+
+func newClient(cfg *Config, logger *zap.Logger, tables ...*table) *client {
+	return &client{
+		cfg:    cfg,
+		logger: logger,
+		tables: tables,
+	}
+}
+
+func (c *client) start(ctx context.Context, _ component.Host) error {
+	poolConfig, err := c.cfg.buildPoolConfig()
+	if err != nil {
+		return err
+	}
+	c.pool, err = pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	if !c.cfg.CreateSchema {
+		return nil
+	}
+	if err := c.createSchema(ctx); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+	return nil
+}
+
+func (c *client) shutdown(_ context.Context) error {
+	if c.pool != nil {
+		c.pool.Close()
+	}
+	return nil
+}
+
+// createSchema creates the schema and the tables, and turns them into hypertables when TimescaleDB is used.
+func (c *client) createSchema(ctx context.Context) error {
+	hypertables, err := c.useHypertables(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := sqltemplates.TableData{
+		Schema:        c.cfg.Schema,
+		ChunkInterval: c.cfg.Timescale.ChunkInterval,
+		Retention:     c.cfg.Timescale.Retention,
+	}
+	if err := c.exec(ctx, sqltemplates.CreateSchemaTmpl, data); err != nil {
+		return err
+	}
+	for _, t := range c.tables {
+		data.Table = t.name
+		if err := c.exec(ctx, t.ddl, data); err != nil {
+			return fmt.Errorf("table %s: %w", t.name, err)
+		}
+		if !hypertables {
+			continue
+		}
+		if err := c.exec(ctx, sqltemplates.CreateHypertableTmpl, data); err != nil {
+			return fmt.Errorf("hypertable %s: %w", t.name, err)
+		}
+		if data.Retention > 0 {
+			if err := c.exec(ctx, sqltemplates.AddRetentionPolicyTmpl, data); err != nil {
+				return fmt.Errorf("retention policy of %s: %w", t.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// useHypertables returns whether the tables are turned into hypertables.
+func (c *client) useHypertables(ctx context.Context) (bool, error) {
+	if c.cfg.Timescale.Hypertables == hypertablesDisabled {
+		return false, nil
+	}
+
+	var version string
+	err := c.pool.QueryRow(ctx, sqltemplates.TimescaleVersion).Scan(&version)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if c.cfg.Timescale.Hypertables == hypertablesEnabled {
+			return false, errors.New("the timescaledb extension is not installed")
+		}
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to look up the timescaledb extension: %w", err)
+	}
+	c.logger.Info("Using TimescaleDB hypertables", zap.String("version", version))
+	return true, nil
+}
+
+func (c *client) exec(ctx context.Context, tmpl *template.Template, data sqltemplates.TableData) error {
+	var sql strings.Builder
+	if err := tmpl.Execute(&sql, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", tmpl.Name(), err)
+	}
+	_, err := c.pool.Exec(ctx, sql.String())
+	return err
+}
+
+// copyFrom copies the rows of the buffers into their tables, within a single transaction when
+// several tables are involved so that a retry does not duplicate the rows.
+func (c *client) copyFrom(ctx context.Context, buffers ...*copyBuffer) error {
+	var nonEmpty []*copyBuffer
+	for _, b := range buffers {
+		if b.err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to encode rows of %s: %w", b.table.name, b.err))
+		}
+		if b.rows > 0 {
+			nonEmpty = append(nonEmpty, b)
+		}
+	}
+
+	var err error
+	switch len(nonEmpty) {
+	case 0:
+		return nil
+	case 1:
+		var conn *pgxpool.Conn
+		conn, err = c.pool.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Release()
+		err = copyBuffers(ctx, conn.Conn().PgConn(), nonEmpty)
+	default:
+		err = pgx.BeginFunc(ctx, c.pool, func(tx pgx.Tx) error {
+			return copyBuffers(ctx, tx.Conn().PgConn(), nonEmpty)
+		})
+	}
+	if isPermanent(err) {
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
+
+func copyBuffers(ctx context.Context, conn *pgconn.PgConn, buffers []*copyBuffer) error {
+	for _, b := range buffers {
+		if _, err := conn.CopyFrom(ctx, bytes.NewReader(b.buf), b.table.copySQL); err != nil {
+			return fmt.Errorf("failed to copy rows into %s: %w", b.table.name, err)
+		}
+	}
+	return nil
+}
+
+// serviceName returns the service.name resource attribute, or an empty string if it is not set.
+func serviceName(attrs pcommon.Map) string {
+	if v, ok := attrs.Get(string(conventions.ServiceNameKey)); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+// isPermanent returns whether the error is caused by the rows themselves or the schema,
+// and retrying would not help.
+func isPermanent(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || len(pgErr.Code) < 2 {
+		return false
+	}
+	switch pgErr.Code[:2] {
+	// Data exception, integrity constraint violation, syntax error or access rule violation.
+	case "22", "23", "42":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/sqltemplates"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+type logsExporter struct {
+	*client
+	table *table
+}
+
+func newLogsExporter(logger *zap.Logger, cfg *Config) *logsExporter {
+	t := newTable(cfg.Schema, cfg.Table.Logs, sqltemplates.LogsCreateTableTmpl,
+		"timestamp",
+		"observed_timestamp",
+		"trace_id",
+		"span_id",
+		"trace_flags",
+		"severity_text",
+		"severity_number",
+		"service_name",
+		"body",
+		"event_name",
+		"resource_schema_url",
+		"resource_attributes",
+		"scope_schema_url",
+		"scope_name",
+		"scope_version",
+		"scope_attributes",
+		"log_attributes",
+	)
+	return &logsExporter{
+		client: newClient(cfg, logger, t),
+		table:  t,
+	}
+}
+
+func (e *logsExporter) pushLogsData(ctx context.Context, ld plog.Logs) error {
+	b := newCopyBuffer(e.table)
+	for _, rl := range ld.ResourceLogs().All() {
+		res := rl.Resource()
+		serviceName := serviceName(res.Attributes())
+		for _, sl := range rl.ScopeLogs().All() {
+			scope := sl.Scope()
+			for _, lr := range sl.LogRecords().All() {
+				// The timestamp is optional, the observed timestamp is set by the collector.
+				ts := lr.Timestamp()
+				if ts == 0 {
+					ts = lr.ObservedTimestamp()
+				}
+				b.timestamp(ts)
+				b.optionalTimestamp(lr.ObservedTimestamp())
+				b.optionalText(traceutil.TraceIDToHexOrEmptyString(lr.TraceID()))
+				b.optionalText(traceutil.SpanIDToHexOrEmptyString(lr.SpanID()))
+				b.int(int64(lr.Flags()))
+				b.optionalText(lr.SeverityText())
+				b.int(int64(lr.SeverityNumber()))
+				b.optionalText(serviceName)
+				b.text(lr.Body().AsString())
+				b.optionalText(lr.EventName())
+				b.optionalText(rl.SchemaUrl())
+				b.attributes(res.Attributes())
+				b.optionalText(sl.SchemaUrl())
+				b.optionalText(scope.Name())
+				b.optionalText(scope.Version())
+				b.attributes(scope.Attributes())
+				b.attributes(lr.Attributes())
+				b.endRow()
+			}
+		}
+	}
+	return e.copyFrom(ctx, b)
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/pgtest"
+)
+
+func testLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.38.0")
+	rl.Resource().Attributes().PutStr("service.name", "api")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("io.opentelemetry.test")
+	sl.Scope().SetVersion("1.0.0")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC)))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC)))
+	lr.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	lr.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	lr.SetSeverityText("ERROR")
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.Body().SetStr("failed\tto\\connect\nretrying")
+	lr.Attributes().PutStr("user", "alice")
+	lr.Attributes().PutDouble("ratio", 0.5)
+	return logs
+}
+
+func TestPushLogsData(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(*Config) {}))
+	startClient(t, exp.client)
+
+	require.NoError(t, exp.pushLogsData(t.Context(), testLogs()))
+
+	rows := server.Rows("otel_logs")
+	require.Len(t, rows, 1)
+	assert.Equal(t, pgtest.Row{
+		"timestamp":           "2025-01-02 03:04:05.123456789+00",
+		"observed_timestamp":  "2025-01-02 03:04:06+00",
+		"trace_id":            "0102030405060708090a0b0c0d0e0f10",
+		"span_id":             "0102030405060708",
+		"trace_flags":         "1",
+		"severity_text":       "ERROR",
+		"severity_number":     "17",
+		"service_name":        "api",
+		"body":                "failed\tto\\connect\nretrying",
+		"resource_schema_url": "https://opentelemetry.io/schemas/1.38.0",
+		"resource_attributes": `{"service.name":"api"}`,
+		"scope_name":          "io.opentelemetry.test",
+		"scope_version":       "1.0.0",
+		"scope_attributes":    `{}`,
+		"log_attributes":      `{"ratio":0.5,"user":"alice"}`,
+	}, rows[0])
+}
+
+func TestPushLogsDataWithoutTimestamp(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(*Config) {}))
+	startClient(t, exp.client)
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC)))
+	lr.Body().SetEmptyMap().PutStr("message", "hello")
+	require.NoError(t, exp.pushLogsData(t.Context(), logs))
+
+	rows := server.Rows("otel_logs")
+	require.Len(t, rows, 1)
+	// The observed timestamp replaces the missing timestamp, the missing values are NULL.
+	assert.Equal(t, pgtest.Row{
+		"timestamp":           "2025-01-02 03:04:06+00",
+		"observed_timestamp":  "2025-01-02 03:04:06+00",
+		"trace_flags":         "0",
+		"severity_number":     "0",
+		"body":                `{"message":"hello"}`,
+		"resource_attributes": `{}`,
+		"scope_attributes":    `{}`,
+		"log_attributes":      `{}`,
+	}, rows[0])
+}
+
+func TestPushLogsDataEmpty(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(cfg *Config) {
+		cfg.CreateSchema = false
+	}))
+	startClient(t, exp.client)
+
+	require.NoError(t, exp.pushLogsData(t.Context(), plog.NewLogs()))
+	assert.Empty(t, server.Rows("otel_logs"))
+}
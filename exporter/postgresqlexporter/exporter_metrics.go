@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
+
+import (
+	"context"
+	"slices"
+	"text/template"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/sqltemplates"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+const (
+	gaugeSuffix        = "_gauge"
+	sumSuffix          = "_sum"
+	histogramSuffix    = "_histogram"
+	expHistogramSuffix = "_exponential_histogram"
+	summarySuffix      = "_summary"
+)
+
+// metricColumns are the columns shared by the tables of all the metric types.
+var metricColumns = []string{
+	"resource_schema_url",
+	"resource_attributes",
+	"scope_schema_url",
+	"scope_name",
+	"scope_version",
+	"scope_attributes",
+	"service_name",
+	"metric_name",
+	"metric_description",
+	"metric_unit",
+	"attributes",
+	"start_timestamp",
+	"timestamp",
+	"flags",
+	"exemplars",
+}
+
+type metricsExporter struct {
+	*client
+	tables map[pmetric.MetricType]*table
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) *metricsExporter {
+	newMetricTable := func(suffix string, ddl *template.Template, columns ...string) *table {
+		return newTable(cfg.Schema, cfg.Table.Metrics+suffix, ddl, slices.Concat(metricColumns, columns)...)
+	}
+	tables := map[pmetric.MetricType]*table{
+		pmetric.MetricTypeGauge: newMetricTable(gaugeSuffix, sqltemplates.MetricsGaugeCreateTableTmpl,
+			"value",
+		),
+		pmetric.MetricTypeSum: newMetricTable(sumSuffix, sqltemplates.MetricsSumCreateTableTmpl,
+			"value",
+			"aggregation_temporality",
+			"is_monotonic",
+		),
+		pmetric.MetricTypeHistogram: newMetricTable(histogramSuffix, sqltemplates.MetricsHistogramCreateTableTmpl,
+			"count",
+			"sum",
+			"bucket_counts",
+			"explicit_bounds",
+			"min",
+			"max",
+			"aggregation_temporality",
+		),
+		pmetric.MetricTypeExponentialHistogram: newMetricTable(expHistogramSuffix, sqltemplates.MetricsExpHistogramCreateTableTmpl,
+			"count",
+			"sum",
+			"scale",
+			"zero_count",
+			"zero_threshold",
+			"positive_offset",
+			"positive_bucket_counts",
+			"negative_offset",
+			"negative_bucket_counts",
+			"min",
+			"max",
+			"aggregation_temporality",
+		),
+		pmetric.MetricTypeSummary: newMetricTable(summarySuffix, sqltemplates.MetricsSummaryCreateTableTmpl,
+			"count",
+			"sum",
+			"quantiles",
+			"quantile_values",
+		),
+	}
+	return &metricsExporter{
+		client: newClient(cfg, logger,
+			tables[pmetric.MetricTypeGauge],
+			tables[pmetric.MetricTypeSum],
+			tables[pmetric.MetricTypeHistogram],
+			tables[pmetric.MetricTypeExponentialHistogram],
+			tables[pmetric.MetricTypeSummary],
+		),
+		tables: tables,
+	}
+}
+
+// exemplar is the JSON representation of an exemplar in the exemplars column.
+type exemplar struct {
+	Timestamp          string         `json:"timestamp"`
+	Value              any            `json:"value"`
+	TraceID            string         `json:"trace_id,omitempty"`
+	SpanID             string         `json:"span_id,omitempty"`
+	FilteredAttributes map[string]any `json:"filtered_attributes"`
+}
+
+// dataPoint is implemented by the data points of all the metric types.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+	Flags() pmetric.DataPointFlags
+}
+
+// metricRow writes the columns shared by the data points of a metric.
+type metricRow struct {
+	rl     pmetric.ResourceMetrics
+	sm     pmetric.ScopeMetrics
+	metric pmetric.Metric
+	// serviceName is the service.name of the resource.
+	serviceName string
+}
+
+func (r metricRow) write(b *copyBuffer, dp dataPoint, exemplars pmetric.ExemplarSlice) {
+	res := r.rl.Resource()
+	scope := r.sm.Scope()
+	b.optionalText(r.rl.SchemaUrl())
+	b.attributes(res.Attributes())
+	b.optionalText(r.sm.SchemaUrl())
+	b.optionalText(scope.Name())
+	b.optionalText(scope.Version())
+	b.attributes(scope.Attributes())
+	b.optionalText(r.serviceName)
+	b.text(r.metric.Name())
+	b.optionalText(r.metric.Description())
+	b.optionalText(r.metric.Unit())
+	b.attributes(dp.Attributes())
+	b.optionalTimestamp(dp.StartTimestamp())
+	b.timestamp(dp.Timestamp())
+	b.int(int64(dp.Flags()))
+
+	raw := make([]exemplar, 0, exemplars.Len())
+	for _, e := range exemplars.All() {
+		var value any
+		if e.ValueType() == pmetric.ExemplarValueTypeInt {
+			value = e.IntValue()
+		} else {
+			value = rawValue(pcommon.NewValueDouble(e.DoubleValue()))
+		}
+		raw = append(raw, exemplar{
+			Timestamp:          rawTimestamp(e.Timestamp()),
+			Value:              value,
+			TraceID:            traceutil.TraceIDToHexOrEmptyString(e.TraceID()),
+			SpanID:             traceutil.SpanIDToHexOrEmptyString(e.SpanID()),
+			FilteredAttributes: rawMap(e.FilteredAttributes()),
+		})
+	}
+	b.json(raw)
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	buffers := make(map[pmetric.MetricType]*copyBuffer, len(e.tables))
+	for metricType, t := range e.tables {
+		buffers[metricType] = newCopyBuffer(t)
+	}
+
+	for _, rl := range md.ResourceMetrics().All() {
+		serviceName := serviceName(rl.Resource().Attributes())
+		for _, sm := range rl.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				r := metricRow{rl: rl, sm: sm, metric: metric, serviceName: serviceName}
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					b := buffers[pmetric.MetricTypeGauge]
+					for _, dp := range metric.Gauge().DataPoints().All() {
+						r.write(b, dp, dp.Exemplars())
+						b.float(numberValue(dp))
+						b.endRow()
+					}
+				case pmetric.MetricTypeSum:
+					b := buffers[pmetric.MetricTypeSum]
+					sum := metric.Sum()
+					for _, dp := range sum.DataPoints().All() {
+						r.write(b, dp, dp.Exemplars())
+						b.float(numberValue(dp))
+						b.int(int64(sum.AggregationTemporality()))
+						b.bool(sum.IsMonotonic())
+						b.endRow()
+					}
+				case pmetric.MetricTypeHistogram:
+					b := buffers[pmetric.MetricTypeHistogram]
+					histogram := metric.Histogram()
+					for _, dp := range histogram.DataPoints().All() {
+						r.write(b, dp, dp.Exemplars())
+						b.uint(dp.Count())
+						b.optionalFloat(dp.Sum(), dp.HasSum())
+						b.uints(dp.BucketCounts().AsRaw())
+						b.floats(dp.ExplicitBounds().AsRaw())
+						b.optionalFloat(dp.Min(), dp.HasMin())
+						b.optionalFloat(dp.Max(), dp.HasMax())
+						b.int(int64(histogram.AggregationTemporality()))
+						b.endRow()
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					b := buffers[pmetric.MetricTypeExponentialHistogram]
+					histogram := metric.ExponentialHistogram()
+					for _, dp := range histogram.DataPoints().All() {
+						r.write(b, dp, dp.Exemplars())
+						b.uint(dp.Count())
+						b.optionalFloat(dp.Sum(), dp.HasSum())
+						b.int(int64(dp.Scale()))
+						b.uint(dp.ZeroCount())
+						b.float(dp.ZeroThreshold())
+						b.int(int64(dp.Positive().Offset()))
+						b.uints(dp.Positive().BucketCounts().AsRaw())
+						b.int(int64(dp.Negative().Offset()))
+						b.uints(dp.Negative().BucketCounts().AsRaw())
+						b.optionalFloat(dp.Min(), dp.HasMin())
+						b.optionalFloat(dp.Max(), dp.HasMax())
+						b.int(int64(histogram.AggregationTemporality()))
+						b.endRow()
+					}
+				case pmetric.MetricTypeSummary:
+					b := buffers[pmetric.MetricTypeSummary]
+					for _, dp := range metric.Summary().DataPoints().All() {
+						r.write(b, dp, pmetric.NewExemplarSlice())
+						b.uint(dp.Count())
+						b.float(dp.Sum())
+						quantiles := make([]float64, 0, dp.QuantileValues().Len())
+						values := make([]float64, 0, dp.QuantileValues().Len())
+						for _, q := range dp.QuantileValues().All() {
+							quantiles = append(quantiles, q.Quantile())
+							values = append(values, q.Value())
+						}
+						b.floats(quantiles)
+						b.floats(values)
+						b.endRow()
+					}
+				default:
+					e.logger.Debug("Dropping metric of unsupported type",
+						zap.String("name", metric.Name()),
+						zap.Stringer("type", metric.Type()))
+				}
+			}
+		}
+	}
+
+	return e.copyFrom(ctx,
+		buffers[pmetric.MetricTypeGauge],
+		buffers[pmetric.MetricTypeSum],
+		buffers[pmetric.MetricTypeHistogram],
+		buffers[pmetric.MetricTypeExponentialHistogram],
+		buffers[pmetric.MetricTypeSummary],
+	)
+}
+
+// numberValue returns the value of a number data point as a float.
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/pgtest"
+)
+
+var (
+	testStart = pcommon.NewTimestampFromTime(time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC))
+	testTime  = pcommon.NewTimestampFromTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+)
+
+func testMetrics() pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "api")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("io.opentelemetry.test")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("memory.usage")
+	gauge.SetUnit("By")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(testTime)
+	dp.SetIntValue(1024)
+	dp.Attributes().PutStr("state", "used")
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(testTime)
+	exemplar.SetDoubleValue(math.Inf(1))
+	exemplar.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDescription("Number of requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(testStart)
+	dp.SetTimestamp(testTime)
+	dp.SetDoubleValue(12.5)
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(testStart)
+	hdp.SetTimestamp(testTime)
+	hdp.SetCount(3)
+	hdp.SetSum(0.6)
+	hdp.SetMin(0.1)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 0})
+	hdp.ExplicitBounds().FromRaw([]float64{0.1, 0.5})
+
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("latency.exp")
+	expHistogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	edp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(testTime)
+	edp.SetCount(4)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("duration")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(testTime)
+	sdp.SetCount(10)
+	sdp.SetSum(math.NaN())
+	for q, v := range map[float64]float64{0.5: 1, 0.99: 3} {
+		qv := sdp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(v)
+	}
+	sdp.QuantileValues().Sort(func(a, b pmetric.SummaryDataPointValueAtQuantile) bool {
+		return a.Quantile() < b.Quantile()
+	})
+	return metrics
+}
+
+func TestPushMetricsData(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newMetricsExporter(zap.NewNop(), newTestConfig(server, func(*Config) {}))
+	startClient(t, exp.client)
+
+	require.NoError(t, exp.pushMetricsData(t.Context(), testMetrics()))
+
+	common := func(name string, fields pgtest.Row) pgtest.Row {
+		row := pgtest.Row{
+			"resource_attributes": `{"service.name":"api"}`,
+			"scope_name":          "io.opentelemetry.test",
+			"scope_attributes":    `{}`,
+			"service_name":        "api",
+			"metric_name":         name,
+			"attributes":          `{}`,
+			"timestamp":           "2025-01-02 03:04:05+00",
+			"flags":               "0",
+			"exemplars":           `[]`,
+		}
+		for k, v := range fields {
+			row[k] = v
+		}
+		return row
+	}
+
+	assert.Equal(t, []pgtest.Row{common("memory.usage", pgtest.Row{
+		"metric_unit": "By",
+		"attributes":  `{"state":"used"}`,
+		"exemplars":   `[{"timestamp":"2025-01-02T03:04:05Z","value":"Infinity","trace_id":"0102030405060708090a0b0c0d0e0f10","filtered_attributes":{}}]`,
+		"value":       "1024",
+	})}, server.Rows("otel_metrics_gauge"))
+
+	assert.Equal(t, []pgtest.Row{common("requests", pgtest.Row{
+		"metric_description":      "Number of requests",
+		"start_timestamp":         "2025-01-02 03:04:00+00",
+		"value":                   "12.5",
+		"aggregation_temporality": "2",
+		"is_monotonic":            "t",
+	})}, server.Rows("otel_metrics_sum"))
+
+	assert.Equal(t, []pgtest.Row{common("latency", pgtest.Row{
+		"start_timestamp":         "2025-01-02 03:04:00+00",
+		"count":                   "3",
+		"sum":                     "0.6",
+		"bucket_counts":           "{1,2,0}",
+		"explicit_bounds":         "{0.1,0.5}",
+		"min":                     "0.1",
+		"aggregation_temporality": "1",
+	})}, server.Rows("otel_metrics_histogram"))
+
+	assert.Equal(t, []pgtest.Row{common("latency.exp", pgtest.Row{
+		"count":                   "4",
+		"scale":                   "2",
+		"zero_count":              "1",
+		"zero_threshold":          "0",
+		"positive_offset":         "-1",
+		"positive_bucket_counts":  "{1,2}",
+		"negative_offset":         "0",
+		"negative_bucket_counts":  "{}",
+		"aggregation_temporality": "1",
+	})}, server.Rows("otel_metrics_exponential_histogram"))
+
+	assert.Equal(t, []pgtest.Row{common("duration", pgtest.Row{
+		"count":           "10",
+		"sum":             "NaN",
+		"quantiles":       "{0.5,0.99}",
+		"quantile_values": "{1,3}",
+	})}, server.Rows("otel_metrics_summary"))
+}
+
+func TestPushMetricsDataRollback(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newMetricsExporter(zap.NewNop(), newTestConfig(server, func(*Config) {}))
+	startClient(t, exp.client)
+	server.FailCopy("otel_metrics_summary", "53100", "disk full")
+
+	require.Error(t, exp.pushMetricsData(t.Context(), testMetrics()))
+
+	// The data points are copied into the tables in a single transaction, the rows copied before the failure
+	// are rolled back.
+	for _, suffix := range []string{gaugeSuffix, sumSuffix, histogramSuffix, expHistogramSuffix, summarySuffix} {
+		assert.Empty(t, server.Rows("otel_metrics"+suffix))
+	}
+	queries := server.Queries()
+	assert.Equal(t, []string{"begin", "rollback"}, queries[len(queries)-2:])
+}
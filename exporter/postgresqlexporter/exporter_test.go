@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/pgtest"
+)
+
+func newTestConfig(server *pgtest.Server, mutate func(*Config)) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.Endpoint()
+	mutate(cfg)
+	return cfg
+}
+
+// startClient starts the client of an exporter, shut down at the end of the test.
+func startClient(t *testing.T, c *client) {
+	require.NoError(t, c.start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, c.shutdown(context.Background())) })
+}
+
+func TestCreateSchema(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(cfg *Config) {
+		cfg.Schema = "telemetry"
+		cfg.Table.Logs = `my "logs"`
+	}))
+	startClient(t, exp.client)
+
+	queries := server.Queries()
+	require.Len(t, queries, 3)
+	assert.Equal(t, "SELECT extversion FROM pg_extension WHERE extname = 'timescaledb';\n", queries[0])
+	assert.Equal(t, "CREATE SCHEMA IF NOT EXISTS \"telemetry\";\n", queries[1])
+	assert.Contains(t, queries[2], `CREATE TABLE IF NOT EXISTS "telemetry"."my ""logs""" (`)
+	assert.Contains(t, queries[2], `CREATE INDEX IF NOT EXISTS "my ""logs""_trace_id_idx" ON "telemetry"."my ""logs""" (trace_id)`)
+}
+
+func TestCreateSchemaDisabled(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(cfg *Config) {
+		cfg.CreateSchema = false
+	}))
+	startClient(t, exp.client)
+
+	assert.Empty(t, server.Queries())
+}
+
+func TestCreateHypertables(t *testing.T) {
+	server := pgtest.NewServer(t)
+	server.SetTimescale("2.17.2")
+	exp := newTracesExporter(zap.NewNop(), newTestConfig(server, func(cfg *Config) {
+		cfg.Timescale.ChunkInterval = time.Hour
+		cfg.Timescale.Retention = 7 * 24 * time.Hour
+	}))
+	startClient(t, exp.client)
+
+	queries := server.Queries()
+	require.Len(t, queries, 5)
+	assert.Contains(t, queries[2], `CREATE TABLE IF NOT EXISTS "public"."otel_traces" (`)
+	assert.Equal(t, `SELECT create_hypertable('"public"."otel_traces"', 'timestamp', chunk_time_interval => INTERVAL '3600000000 microseconds', create_default_indexes => FALSE, if_not_exists => TRUE);`+"\n", queries[3])
+	assert.Equal(t, `SELECT add_retention_policy('"public"."otel_traces"', INTERVAL '604800000000 microseconds', if_not_exists => TRUE);`+"\n", queries[4])
+}
+
+func TestCreateHypertablesModes(t *testing.T) {
+	tests := []struct {
+		hypertables string
+		timescale   string
+		expectedErr string
+		hypertable  bool
+	}{
+		{hypertables: hypertablesAuto, timescale: "2.17.2", hypertable: true},
+		{hypertables: hypertablesAuto},
+		{hypertables: hypertablesEnabled, timescale: "2.17.2", hypertable: true},
+		{hypertables: hypertablesEnabled, expectedErr: "failed to create schema: the timescaledb extension is not installed"},
+		{hypertables: hypertablesDisabled, timescale: "2.17.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hypertables+"/"+tt.timescale, func(t *testing.T) {
+			server := pgtest.NewServer(t)
+			server.SetTimescale(tt.timescale)
+			c := newLogsExporter(zap.NewNop(), newTestConfig(server, func(cfg *Config) {
+				cfg.Timescale.Hypertables = tt.hypertables
+			})).client
+			t.Cleanup(func() { assert.NoError(t, c.shutdown(context.Background())) })
+
+			err := c.start(t.Context(), componenttest.NewNopHost())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			var hypertable bool
+			for _, query := range server.Queries() {
+				hypertable = hypertable || strings.HasPrefix(query, "SELECT create_hypertable(")
+			}
+			assert.Equal(t, tt.hypertable, hypertable)
+		})
+	}
+}
+
+func TestCopyErrors(t *testing.T) {
+	tests := []struct {
+		code      string
+		permanent bool
+	}{
+		{code: "22P02", permanent: true},
+		{code: "23502", permanent: true},
+		{code: "42P01", permanent: true},
+		{code: "53300"},
+		{code: "57P01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			server := pgtest.NewServer(t)
+			exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(*Config) {}))
+			startClient(t, exp.client)
+			server.FailCopy("", tt.code, "copy failed")
+
+			err := exp.pushLogsData(t.Context(), testLogs())
+			var pgErr *pgconn.PgError
+			require.ErrorAs(t, err, &pgErr)
+			assert.Equal(t, tt.code, pgErr.Code)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestCopyConnectionError(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newLogsExporter(zap.NewNop(), newTestConfig(server, func(cfg *Config) {
+		cfg.CreateSchema = false
+	}))
+	startClient(t, exp.client)
+	exp.pool.Close()
+
+	err := exp.pushLogsData(t.Context(), testLogs())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestIsPermanent(t *testing.T) {
+	assert.True(t, isPermanent(&pgconn.PgError{Code: "22003"}))
+	assert.False(t, isPermanent(&pgconn.PgError{Code: "40001"}))
+	assert.False(t, isPermanent(&pgconn.PgError{}))
+	assert.False(t, isPermanent(errors.New("connection reset by peer")))
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/sqltemplates"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+type tracesExporter struct {
+	*client
+	table *table
+}
+
+func newTracesExporter(logger *zap.Logger, cfg *Config) *tracesExporter {
+	t := newTable(cfg.Schema, cfg.Table.Traces, sqltemplates.TracesCreateTableTmpl,
+		"timestamp",
+		"end_timestamp",
+		"duration",
+		"trace_id",
+		"span_id",
+		"parent_span_id",
+		"trace_state",
+		"span_name",
+		"span_kind",
+		"service_name",
+		"resource_schema_url",
+		"resource_attributes",
+		"scope_schema_url",
+		"scope_name",
+		"scope_version",
+		"scope_attributes",
+		"span_attributes",
+		"status_code",
+		"status_message",
+		"events",
+		"links",
+	)
+	return &tracesExporter{
+		client: newClient(cfg, logger, t),
+		table:  t,
+	}
+}
+
+// spanEvent is the JSON representation of a span event in the events column.
+type spanEvent struct {
+	Timestamp  string         `json:"timestamp"`
+	Name       string         `json:"name"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// spanLink is the JSON representation of a span link in the links column.
+type spanLink struct {
+	TraceID    string         `json:"trace_id"`
+	SpanID     string         `json:"span_id"`
+	TraceState string         `json:"trace_state,omitempty"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+func (e *tracesExporter) pushTraceData(ctx context.Context, td ptrace.Traces) error {
+	b := newCopyBuffer(e.table)
+	for _, rs := range td.ResourceSpans().All() {
+		res := rs.Resource()
+		serviceName := serviceName(res.Attributes())
+		for _, ss := range rs.ScopeSpans().All() {
+			scope := ss.Scope()
+			for _, span := range ss.Spans().All() {
+				b.timestamp(span.StartTimestamp())
+				b.timestamp(span.EndTimestamp())
+				b.int(int64(span.EndTimestamp() - span.StartTimestamp()))
+				b.text(traceutil.TraceIDToHexOrEmptyString(span.TraceID()))
+				b.text(traceutil.SpanIDToHexOrEmptyString(span.SpanID()))
+				b.optionalText(traceutil.SpanIDToHexOrEmptyString(span.ParentSpanID()))
+				b.optionalText(span.TraceState().AsRaw())
+				b.text(span.Name())
+				b.text(span.Kind().String())
+				b.optionalText(serviceName)
+				b.optionalText(rs.SchemaUrl())
+				b.attributes(res.Attributes())
+				b.optionalText(ss.SchemaUrl())
+				b.optionalText(scope.Name())
+				b.optionalText(scope.Version())
+				b.attributes(scope.Attributes())
+				b.attributes(span.Attributes())
+				b.text(span.Status().Code().String())
+				b.optionalText(span.Status().Message())
+
+				events := make([]spanEvent, 0, span.Events().Len())
+				for _, event := range span.Events().All() {
+					events = append(events, spanEvent{
+						Timestamp:  rawTimestamp(event.Timestamp()),
+						Name:       sanitize(event.Name()),
+						Attributes: rawMap(event.Attributes()),
+					})
+				}
+				b.json(events)
+
+				links := make([]spanLink, 0, span.Links().Len())
+				for _, link := range span.Links().All() {
+					links = append(links, spanLink{
+						TraceID:    traceutil.TraceIDToHexOrEmptyString(link.TraceID()),
+						SpanID:     traceutil.SpanIDToHexOrEmptyString(link.SpanID()),
+						TraceState: sanitize(link.TraceState().AsRaw()),
+						Attributes: rawMap(link.Attributes()),
+					})
+				}
+				b.json(links)
+				b.endRow()
+			}
+		}
+	}
+	return e.copyFrom(ctx, b)
+}
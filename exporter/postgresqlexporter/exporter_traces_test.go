@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/pgtest"
+)
+
+func TestPushTraceData(t *testing.T) {
+	server := pgtest.NewServer(t)
+	exp := newTracesExporter(zap.NewNop(), newTestConfig(server, func(*Config) {}))
+	startClient(t, exp.client)
+
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "api")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("io.opentelemetry.test")
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetParentSpanID(pcommon.SpanID{8, 7, 6, 5, 4, 3, 2, 1})
+	span.TraceState().FromRaw("vendor=value")
+	span.SetName("GET /users")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(1500 * time.Millisecond)))
+	span.Attributes().PutInt("http.response.status_code", 500)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("internal error")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+	event.Attributes().PutStr("exception.type", "IOError")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	link.SetSpanID(pcommon.SpanID{1, 1, 1, 1, 1, 1, 1, 1})
+
+	require.NoError(t, exp.pushTraceData(t.Context(), traces))
+
+	rows := server.Rows("otel_traces")
+	require.Len(t, rows, 1)
+	assert.Equal(t, pgtest.Row{
+		"timestamp":           "2025-01-02 03:04:05+00",
+		"end_timestamp":       "2025-01-02 03:04:06.5+00",
+		"duration":            "1500000000",
+		"trace_id":            "0102030405060708090a0b0c0d0e0f10",
+		"span_id":             "0102030405060708",
+		"parent_span_id":      "0807060504030201",
+		"trace_state":         "vendor=value",
+		"span_name":           "GET /users",
+		"span_kind":           "Server",
+		"service_name":        "api",
+		"resource_attributes": `{"service.name":"api"}`,
+		"scope_name":          "io.opentelemetry.test",
+		"scope_attributes":    `{}`,
+		"span_attributes":     `{"http.response.status_code":500}`,
+		"status_code":         "Error",
+		"status_message":      "internal error",
+		"events":              `[{"timestamp":"2025-01-02T03:04:06Z","name":"exception","attributes":{"exception.type":"IOError"}}]`,
+		"links":               `[{"trace_id":"100f0e0d0c0b0a090807060504030201","span_id":"0101010101010101","attributes":{}}]`,
+	}, rows[0])
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package postgresqlexporter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+const (
+	postgresImage  = "postgres:17-alpine"
+	timescaleImage = "timescale/timescaledb:2.17.2-pg17"
+)
+
+func TestIntegration(t *testing.T) {
+	for _, image := range []string{postgresImage, timescaleImage} {
+		t.Run(image, func(t *testing.T) {
+			endpoint := startPostgres(t, image)
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = endpoint
+			cfg.Schema = "otel_int_test"
+			if image == timescaleImage {
+				cfg.Timescale.Hypertables = hypertablesEnabled
+				cfg.Timescale.Retention = 30 * 24 * time.Hour
+			}
+
+			conn, err := pgx.Connect(t.Context(), endpoint)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = conn.Close(context.Background()) })
+
+			t.Run("logs", func(t *testing.T) {
+				exp := newLogsExporter(zap.NewNop(), cfg)
+				startIntegrationClient(t, exp.client)
+				require.NoError(t, exp.pushLogsData(t.Context(), testLogs()))
+
+				var body, user string
+				require.NoError(t, conn.QueryRow(t.Context(),
+					`SELECT body, log_attributes->>'user' FROM otel_int_test.otel_logs WHERE service_name = 'api'`,
+				).Scan(&body, &user))
+				assert.Equal(t, "failed\tto\\connect\nretrying", body)
+				assert.Equal(t, "alice", user)
+			})
+
+			t.Run("metrics", func(t *testing.T) {
+				exp := newMetricsExporter(zap.NewNop(), cfg)
+				startIntegrationClient(t, exp.client)
+				require.NoError(t, exp.pushMetricsData(t.Context(), testMetrics()))
+
+				for _, suffix := range []string{gaugeSuffix, sumSuffix, histogramSuffix, expHistogramSuffix, summarySuffix} {
+					var count int
+					require.NoError(t, conn.QueryRow(t.Context(),
+						fmt.Sprintf("SELECT count(*) FROM otel_int_test.otel_metrics%s", suffix),
+					).Scan(&count))
+					assert.Equal(t, 1, count, suffix)
+				}
+				var bucketCounts []int64
+				require.NoError(t, conn.QueryRow(t.Context(),
+					"SELECT bucket_counts FROM otel_int_test.otel_metrics_histogram",
+				).Scan(&bucketCounts))
+				assert.Equal(t, []int64{1, 2, 0}, bucketCounts)
+			})
+
+			if image != timescaleImage {
+				return
+			}
+			t.Run("hypertables", func(t *testing.T) {
+				var count int
+				require.NoError(t, conn.QueryRow(t.Context(),
+					"SELECT count(*) FROM timescaledb_information.hypertables WHERE hypertable_schema = 'otel_int_test'",
+				).Scan(&count))
+				// The logs table and the five metrics tables.
+				assert.Equal(t, 6, count)
+			})
+		})
+	}
+}
+
+func startIntegrationClient(t *testing.T, c *client) {
+	require.NoError(t, c.start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, c.shutdown(context.Background())) })
+}
+
+func startPostgres(t *testing.T, image string) string {
+	req := testcontainers.ContainerRequest{
+		Image:        image,
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "otel",
+			"POSTGRES_PASSWORD": "otel",
+			"POSTGRES_DB":       "otel",
+		},
+		WaitingFor: wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).
+			WithStartupTimeout(2 * time.Minute),
+	}
+	container, err := testcontainers.GenericContainer(t.Context(), testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, container.Terminate(context.Background())) })
+
+	host, err := container.Host(t.Context())
+	require.NoError(t, err)
+	port, err := container.MappedPort(t.Context(), "5432")
+	require.NoError(t, err)
+	return fmt.Sprintf("postgres://otel:otel@%s:%s/otel?sslmode=disable", host, port.Port())
+}
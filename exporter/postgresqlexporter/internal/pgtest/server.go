@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pgtest implements an in-process stand-in for a PostgreSQL server, speaking just enough
+// of the wire protocol for the exporter tests: the startup, simple queries, transactions and COPY FROM STDIN.
+package pgtest // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/pgtest"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// copyStatement matches the COPY statements sent by the exporter, e.g. COPY "public"."otel_logs" ("timestamp", "body") FROM STDIN.
+var copyStatement = regexp.MustCompile(`^COPY "(?:[^"]|"")*"\."((?:[^"]|"")*)" \((.*)\) FROM STDIN$`)
+
+// Row is a row copied into a table, by column. The NULL columns are absent.
+type Row map[string]string
+
+// Server is a PostgreSQL stand-in recording the statements and the copied rows.
+type Server struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu        sync.Mutex
+	conns     map[net.Conn]struct{}
+	queries   []string
+	rows      map[string][]Row
+	timescale string
+	copyErrs  map[string]*pgproto3.ErrorResponse
+}
+
+// NewServer starts a server, closed at the end of the test.
+func NewServer(tb testing.TB) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("failed to listen: %v", err)
+	}
+	s := &Server{
+		listener: listener,
+		conns:    map[net.Conn]struct{}{},
+		rows:     map[string][]Row{},
+		copyErrs: map[string]*pgproto3.ErrorResponse{},
+	}
+	s.wg.Go(s.serve)
+	tb.Cleanup(s.close)
+	return s
+}
+
+// Endpoint returns the connection string of the server.
+func (s *Server) Endpoint() string {
+	return fmt.Sprintf("postgres://otel@%s/otel?sslmode=disable", s.listener.Addr())
+}
+
+// SetTimescale makes the server report the timescaledb extension as installed, with the given version.
+func (s *Server) SetTimescale(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timescale = version
+}
+
+// FailCopy makes the server reject the following COPY statements into a table, or into any table
+// if it is empty, with the given SQLSTATE code.
+func (s *Server) FailCopy(table, code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.copyErrs[table] = &pgproto3.ErrorResponse{Severity: "ERROR", Code: code, Message: message}
+}
+
+// Queries returns the statements received by the server, other than COPY.
+func (s *Server) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+// Rows returns the rows copied into a table and committed.
+func (s *Server) Rows(table string) []Row {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Row(nil), s.rows[table]...)
+}
+
+func (s *Server) close() {
+	_ = s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	var conns sync.WaitGroup
+	defer conns.Wait()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		conns.Go(func() {
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				_ = conn.Close()
+			}()
+			_ = s.handle(conn)
+		})
+	}
+}
+
+// session is the state of a connection.
+type session struct {
+	backend *pgproto3.Backend
+	// pending are the rows copied in the current transaction, by table.
+	pending map[string][]Row
+	// txStatus is the transaction status reported to the client: idle, in transaction or failed.
+	txStatus byte
+}
+
+func (s *Server) handle(conn net.Conn) error {
+	backend := pgproto3.NewBackend(conn, conn)
+	msg, err := backend.ReceiveStartupMessage()
+	if err != nil {
+		return err
+	}
+	if _, ok := msg.(*pgproto3.StartupMessage); !ok {
+		return fmt.Errorf("unexpected startup message %T", msg)
+	}
+	backend.Send(&pgproto3.AuthenticationOk{})
+	backend.Send(&pgproto3.ParameterStatus{Name: "server_version", Value: "17.0"})
+	backend.Send(&pgproto3.ParameterStatus{Name: "standard_conforming_strings", Value: "on"})
+	backend.Send(&pgproto3.ParameterStatus{Name: "client_encoding", Value: "UTF8"})
+	backend.Send(&pgproto3.BackendKeyData{ProcessID: 1, SecretKey: []byte{0, 0, 0, 1}})
+	backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+	if err := backend.Flush(); err != nil {
+		return err
+	}
+
+	sess := &session{backend: backend, pending: map[string][]Row{}, txStatus: 'I'}
+	for {
+		msg, err := backend.Receive()
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.Query:
+			if err := s.query(sess, msg.String); err != nil {
+				return err
+			}
+		case *pgproto3.Terminate:
+			return nil
+		default:
+			return fmt.Errorf("unsupported message %T", msg)
+		}
+	}
+}
+
+func (s *Server) query(sess *session, sql string) error {
+	if match := copyStatement.FindStringSubmatch(sql); match != nil {
+		return s.copyFrom(sess, unquote(match[1]), match[2])
+	}
+
+	s.mu.Lock()
+	s.queries = append(s.queries, sql)
+	timescale := s.timescale
+	s.mu.Unlock()
+
+	command := strings.ToUpper(strings.Fields(sql + " -")[0])
+	switch {
+	case command == "BEGIN":
+		sess.txStatus = 'T'
+	case command == "COMMIT" && sess.txStatus == 'E':
+		command = "ROLLBACK"
+		fallthrough
+	case command == "ROLLBACK":
+		clear(sess.pending)
+		sess.txStatus = 'I'
+	case command == "COMMIT":
+		s.commit(sess)
+		sess.txStatus = 'I'
+	case strings.Contains(sql, "pg_extension"):
+		sess.backend.Send(&pgproto3.RowDescription{Fields: []pgproto3.FieldDescription{
+			{Name: []byte("extversion"), DataTypeOID: 25, DataTypeSize: -1, TypeModifier: -1},
+		}})
+		rows := 0
+		if timescale != "" {
+			sess.backend.Send(&pgproto3.DataRow{Values: [][]byte{[]byte(timescale)}})
+			rows++
+		}
+		command = fmt.Sprintf("SELECT %d", rows)
+	}
+	sess.backend.Send(&pgproto3.CommandComplete{CommandTag: []byte(command)})
+	sess.backend.Send(&pgproto3.ReadyForQuery{TxStatus: sess.txStatus})
+	return sess.backend.Flush()
+}
+
+func (s *Server) copyFrom(sess *session, table, columnList string) error {
+	var columns []string
+	for _, c := range strings.Split(columnList, ", ") {
+		columns = append(columns, unquote(strings.Trim(c, `"`)))
+	}
+	sess.backend.Send(&pgproto3.CopyInResponse{
+		OverallFormat:     0,
+		ColumnFormatCodes: make([]uint16, len(columns)),
+	})
+	if err := sess.backend.Flush(); err != nil {
+		return err
+	}
+
+	var data strings.Builder
+copyLoop:
+	for {
+		msg, err := sess.backend.Receive()
+		if err != nil {
+			return err
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+			data.Write(msg.Data)
+		case *pgproto3.CopyDone:
+			break copyLoop
+		case *pgproto3.CopyFail:
+			return s.fail(sess, &pgproto3.ErrorResponse{Severity: "ERROR", Code: "57014", Message: msg.Message})
+		default:
+			return fmt.Errorf("unexpected message %T during COPY", msg)
+		}
+	}
+
+	s.mu.Lock()
+	copyErr, ok := s.copyErrs[table]
+	if !ok {
+		copyErr = s.copyErrs[""]
+	}
+	s.mu.Unlock()
+	if copyErr != nil {
+		return s.fail(sess, copyErr)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(data.String(), "\n"), "\n")
+	for _, line := range lines {
+		fields := strings.Split(line, "\t")
+		if len(fields) != len(columns) {
+			return s.fail(sess, &pgproto3.ErrorResponse{
+				Severity: "ERROR",
+				Code:     "22P04",
+				Message:  fmt.Sprintf("expected %d columns, got %d", len(columns), len(fields)),
+			})
+		}
+		row := Row{}
+		for i, field := range fields {
+			if field != `\N` {
+				row[columns[i]] = unescape(field)
+			}
+		}
+		sess.pending[table] = append(sess.pending[table], row)
+	}
+	if sess.txStatus == 'I' {
+		s.commit(sess)
+	}
+
+	sess.backend.Send(&pgproto3.CommandComplete{CommandTag: fmt.Appendf(nil, "COPY %d", len(lines))})
+	sess.backend.Send(&pgproto3.ReadyForQuery{TxStatus: sess.txStatus})
+	return sess.backend.Flush()
+}
+
+// fail reports an error, failing the current transaction.
+func (s *Server) fail(sess *session, errResponse *pgproto3.ErrorResponse) error {
+	if sess.txStatus == 'I' {
+		clear(sess.pending)
+	} else {
+		sess.txStatus = 'E'
+	}
+	sess.backend.Send(errResponse)
+	sess.backend.Send(&pgproto3.ReadyForQuery{TxStatus: sess.txStatus})
+	return sess.backend.Flush()
+}
+
+func (s *Server) commit(sess *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for table, rows := range sess.pending {
+		s.rows[table] = append(s.rows[table], rows...)
+	}
+	clear(sess.pending)
+}
+
+func unquote(identifier string) string {
+	return strings.ReplaceAll(identifier, `""`, `"`)
+}
+
+// unescape decodes a column in the text format of COPY.
+func unescape(field string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\r`, "\r")
+	return replacer.Replace(field)
+}
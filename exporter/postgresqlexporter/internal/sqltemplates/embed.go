@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sqltemplates contains the DDL statements creating the schema of the PostgreSQL exporter.
+package sqltemplates // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/postgresqlexporter/internal/sqltemplates"
+
+import (
+	_ "embed"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// templateFuncs provides helper functions available in all SQL templates.
+var templateFuncs = template.FuncMap{
+	// ident wraps a PostgreSQL identifier in double quotes, escaping any embedded double quotes.
+	"ident": func(s string) string {
+		return pgx.Identifier{s}.Sanitize()
+	},
+	// literal wraps a string constant in single quotes, escaping any embedded single quotes.
+	"literal": func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	},
+}
+
+// newTemplate creates a named template with the shared function map.
+func newTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(templateFuncs).Parse(text))
+}
+
+// TableData contains the template parameters of the statements creating a table.
+type TableData struct {
+	Schema        string
+	Table         string
+	ChunkInterval time.Duration
+	Retention     time.Duration
+}
+
+//go:embed schema.sql
+var createSchema string
+
+//go:embed timescale_version.sql
+var TimescaleVersion string
+
+//go:embed hypertable.sql
+var createHypertable string
+
+//go:embed retention_policy.sql
+var addRetentionPolicy string
+
+// Parsed templates for the schema (text/template).
+var (
+	CreateSchemaTmpl       = newTemplate("schema", createSchema)
+	CreateHypertableTmpl   = newTemplate("hypertable", createHypertable)
+	AddRetentionPolicyTmpl = newTemplate("retention_policy", addRetentionPolicy)
+)
+
+// LOGS
+
+//go:embed logs_table.sql
+var logsCreateTable string
+
+var LogsCreateTableTmpl = newTemplate("logs_table", logsCreateTable)
+
+// TRACES
+
+//go:embed traces_table.sql
+var tracesCreateTable string
+
+var TracesCreateTableTmpl = newTemplate("traces_table", tracesCreateTable)
+
+// METRICS
+
+//go:embed metrics_gauge_table.sql
+var metricsGaugeCreateTable string
+
+//go:embed metrics_sum_table.sql
+var metricsSumCreateTable string
+
+//go:embed metrics_histogram_table.sql
+var metricsHistogramCreateTable string
+
+//go:embed metrics_exp_histogram_table.sql
+var metricsExpHistogramCreateTable string
+
+//go:embed metrics_summary_table.sql
+var metricsSummaryCreateTable string
+
+// Parsed templates for metrics (text/template).
+var (
+	MetricsGaugeCreateTableTmpl        = newTemplate("metrics_gauge_table", metricsGaugeCreateTable)
+	MetricsSumCreateTableTmpl          = newTemplate("metrics_sum_table", metricsSumCreateTable)
+	MetricsHistogramCreateTableTmpl    = newTemplate("metrics_histogram_table", metricsHistogramCreateTable)
+	MetricsExpHistogramCreateTableTmpl = newTemplate("metrics_exp_histogram_table", metricsExpHistogramCreateTable)
+	MetricsSummaryCreateTableTmpl      = newTemplate("metrics_summary_table", metricsSummaryCreateTable)
+)
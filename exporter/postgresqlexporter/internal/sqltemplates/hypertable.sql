@@ -0,0 +1 @@
+SELECT create_hypertable({{literal (printf "%s.%s" (ident .Schema) (ident .Table))}}, 'timestamp', chunk_time_interval => INTERVAL '{{.ChunkInterval.Microseconds}} microseconds', create_default_indexes => FALSE, if_not_exists => TRUE);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS {{ident .Schema}}.{{ident .Table}} (
+    "timestamp" TIMESTAMPTZ NOT NULL,
+    observed_timestamp TIMESTAMPTZ,
+    trace_id TEXT,
+    span_id TEXT,
+    trace_flags INTEGER NOT NULL,
+    severity_text TEXT,
+    severity_number INTEGER NOT NULL,
+    service_name TEXT,
+    body TEXT,
+    event_name TEXT,
+    resource_schema_url TEXT,
+    resource_attributes JSONB NOT NULL,
+    scope_schema_url TEXT,
+    scope_name TEXT,
+    scope_version TEXT,
+    scope_attributes JSONB NOT NULL,
+    log_attributes JSONB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_timestamp_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} ("timestamp" DESC);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_service_name_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} (service_name, "timestamp" DESC);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_trace_id_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} (trace_id) WHERE trace_id IS NOT NULL;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS {{ident .Schema}}.{{ident .Table}} (
+    resource_schema_url TEXT,
+    resource_attributes JSONB NOT NULL,
+    scope_schema_url TEXT,
+    scope_name TEXT,
+    scope_version TEXT,
+    scope_attributes JSONB NOT NULL,
+    service_name TEXT,
+    metric_name TEXT NOT NULL,
+    metric_description TEXT,
+    metric_unit TEXT,
+    attributes JSONB NOT NULL,
+    start_timestamp TIMESTAMPTZ,
+    "timestamp" TIMESTAMPTZ NOT NULL,
+    flags INTEGER NOT NULL,
+    exemplars JSONB NOT NULL,
+    count BIGINT NOT NULL,
+    sum DOUBLE PRECISION NOT NULL,
+    quantiles DOUBLE PRECISION[] NOT NULL,
+    quantile_values DOUBLE PRECISION[] NOT NULL
+);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_timestamp_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} ("timestamp" DESC);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_metric_name_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} (metric_name, "timestamp" DESC);
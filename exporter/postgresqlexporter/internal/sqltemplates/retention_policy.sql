@@ -0,0 +1 @@
+SELECT add_retention_policy({{literal (printf "%s.%s" (ident .Schema) (ident .Table))}}, INTERVAL '{{.Retention.Microseconds}} microseconds', if_not_exists => TRUE);
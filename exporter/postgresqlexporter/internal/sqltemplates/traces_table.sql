@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS {{ident .Schema}}.{{ident .Table}} (
+    "timestamp" TIMESTAMPTZ NOT NULL,
+    end_timestamp TIMESTAMPTZ NOT NULL,
+    duration BIGINT NOT NULL,
+    trace_id TEXT NOT NULL,
+    span_id TEXT NOT NULL,
+    parent_span_id TEXT,
+    trace_state TEXT,
+    span_name TEXT NOT NULL,
+    span_kind TEXT NOT NULL,
+    service_name TEXT,
+    resource_schema_url TEXT,
+    resource_attributes JSONB NOT NULL,
+    scope_schema_url TEXT,
+    scope_name TEXT,
+    scope_version TEXT,
+    scope_attributes JSONB NOT NULL,
+    span_attributes JSONB NOT NULL,
+    status_code TEXT NOT NULL,
+    status_message TEXT,
+    events JSONB NOT NULL,
+    links JSONB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_timestamp_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} ("timestamp" DESC);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_service_name_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} (service_name, "timestamp" DESC);
+CREATE INDEX IF NOT EXISTS {{ident (printf "%s_trace_id_idx" .Table)}} ON {{ident .Schema}}.{{ident .Table}} (trace_id);
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/redisstreamsexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+)
+
+var (
+	errStreamRequired = errors.New("stream is required")
+	errNegativeMaxLen = errors.New("max_len must not be negative")
+)
+
+// Config defines configuration for the Redis Streams exporter.
+type Config struct {
+	TimeoutSettings  exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueBatchConfig configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	BackOffConfig    configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+	ClientConfig     redisstreams.ClientConfig                                `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be added.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be added.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be added.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// MaxLen is the number of entries the streams are trimmed to when an entry is added.
+	// The streams are not trimmed when it is 0 (default 100000).
+	MaxLen int64 `mapstructure:"max_len"`
+
+	// ApproximateTrimming trims the streams to at least MaxLen entries, only removing whole
+	// nodes of the streams, which is much more efficient than exact trimming (default true).
+	ApproximateTrimming bool `mapstructure:"approximate_trimming"`
+}
+
+// SignalConfig holds signal-specific configuration for the Redis Streams exporter.
+type SignalConfig struct {
+	// Stream is the key of the stream the entries of the signal type are added to. The
+	// stream is created if it doesn't exist.
+	//
+	// The default depends on the signal type:
+	//  - "otlp:traces" for traces
+	//  - "otlp:metrics" for metrics
+	//  - "otlp:logs" for logs
+	Stream string `mapstructure:"stream"`
+
+	// Encoding holds the encoding of the entries for the signal type, either "otlp_proto",
+	// "otlp_json" or the ID of an encoding extension.
+	//
+	// Defaults to "otlp_proto".
+	Encoding string `mapstructure:"encoding"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Logs.Stream == "" {
+		errs = append(errs, fmt.Errorf("logs::%w", errStreamRequired))
+	}
+	if c.Metrics.Stream == "" {
+		errs = append(errs, fmt.Errorf("metrics::%w", errStreamRequired))
+	}
+	if c.Traces.Stream == "" {
+		errs = append(errs, fmt.Errorf("traces::%w", errStreamRequired))
+	}
+	if c.MaxLen < 0 {
+		errs = append(errs, errNegativeMaxLen)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/redisstreamsexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "redis:6379"
+				cfg.ClientConfig.Password = "secret"
+				cfg.ClientConfig.DB = 2
+				cfg.TimeoutSettings.Timeout = 10 * time.Second
+				cfg.Logs = SignalConfig{Stream: "agents:logs", Encoding: "otlp_json"}
+				cfg.Traces.Encoding = "jaeger_encoding"
+				cfg.MaxLen = 5000
+				cfg.ApproximateTrimming = false
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid"),
+			expectedErr: "metrics::stream is required\nmax_len must not be negative",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			expectedErr: "endpoint is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/redisstreamsexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/redisstreamsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+)
+
+const (
+	defaultLogsStream    = "otlp:logs"
+	defaultMetricsStream = "otlp:metrics"
+	defaultTracesStream  = "otlp:traces"
+	defaultEncoding      = "otlp_proto"
+	defaultMaxLen        = 100000
+)
+
+// NewFactory creates the Redis Streams exporter factory.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		TimeoutSettings:  exporterhelper.NewDefaultTimeoutConfig(),
+		BackOffConfig:    configretry.NewDefaultBackOffConfig(),
+		QueueBatchConfig: configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		ClientConfig:     redisstreams.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Stream:   defaultLogsStream,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Stream:   defaultMetricsStream,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Stream:   defaultTracesStream,
+			Encoding: defaultEncoding,
+		},
+		MaxLen:              defaultMaxLen,
+		ApproximateTrimming: true,
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	oCfg := *cfg.(*Config) // Clone the config
+	exp := newTracesExporter(oCfg)
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	oCfg := *cfg.(*Config) // Clone the config
+	exp := newMetricsExporter(oCfg)
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	oCfg := *cfg.(*Config) // Clone the config
+	exp := newLogsExporter(oCfg)
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		&oCfg,
+		exp.exportData,
+		exporterhelperOptions(oCfg, exp.Start, exp.Close)...,
+	)
+}
+
+func exporterhelperOptions(
+	cfg Config,
+	startFunc component.StartFunc,
+	shutdownFunc component.ShutdownFunc,
+) []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(cfg.TimeoutSettings),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+		exporterhelper.WithQueue(cfg.QueueBatchConfig),
+		exporterhelper.WithStart(startFunc),
+		exporterhelper.WithShutdown(shutdownFunc),
+	}
+}
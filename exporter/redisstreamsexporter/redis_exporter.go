@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/redisstreamsexporter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+)
+
+// redisExporter holds the client of a signal exporter, and adds its entries to the stream.
+type redisExporter struct {
+	cfg    Config
+	stream string
+	client *redis.Client
+}
+
+func (e *redisExporter) connect(ctx context.Context) error {
+	c, err := redisstreams.Connect(ctx, e.cfg.ClientConfig)
+	if err != nil {
+		return err
+	}
+	e.client = c
+	return nil
+}
+
+func (e *redisExporter) Close(context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+// add adds an entry holding the data to the stream, trimming the stream to the
+// configured length.
+func (e *redisExporter) add(ctx context.Context, data []byte) error {
+	err := e.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: e.stream,
+		MaxLen: e.cfg.MaxLen,
+		Approx: e.cfg.ApproximateTrimming,
+		Values: []any{redisstreams.DataField, data},
+	}).Err()
+	if err == nil {
+		return nil
+	}
+	// The key holds a value which isn't a stream, retrying won't help.
+	if strings.HasPrefix(err.Error(), "WRONGTYPE") {
+		return consumererror.NewPermanent(fmt.Errorf("failed to add entry to stream %q: %w", e.stream, err))
+	}
+	return fmt.Errorf("failed to add entry to stream %q: %w", e.stream, err)
+}
+
+type tracesExporter struct {
+	*redisExporter
+	marshaler ptrace.Marshaler
+}
+
+func newTracesExporter(cfg Config) *tracesExporter {
+	return &tracesExporter{redisExporter: &redisExporter{cfg: cfg, stream: cfg.Traces.Stream}}
+}
+
+func (e *tracesExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewTracesMarshaler(e.cfg.Traces.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx)
+}
+
+func (e *tracesExporter) exportData(ctx context.Context, td ptrace.Traces) error {
+	data, err := e.marshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal traces: %w", err))
+	}
+	return e.add(ctx, data)
+}
+
+type metricsExporter struct {
+	*redisExporter
+	marshaler pmetric.Marshaler
+}
+
+func newMetricsExporter(cfg Config) *metricsExporter {
+	return &metricsExporter{redisExporter: &redisExporter{cfg: cfg, stream: cfg.Metrics.Stream}}
+}
+
+func (e *metricsExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewMetricsMarshaler(e.cfg.Metrics.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx)
+}
+
+func (e *metricsExporter) exportData(ctx context.Context, md pmetric.Metrics) error {
+	data, err := e.marshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal metrics: %w", err))
+	}
+	return e.add(ctx, data)
+}
+
+type logsExporter struct {
+	*redisExporter
+	marshaler plog.Marshaler
+}
+
+func newLogsExporter(cfg Config) *logsExporter {
+	return &logsExporter{redisExporter: &redisExporter{cfg: cfg, stream: cfg.Logs.Stream}}
+}
+
+func (e *logsExporter) Start(ctx context.Context, host component.Host) error {
+	marshaler, err := messaging.NewLogsMarshaler(e.cfg.Logs.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = marshaler
+	return e.connect(ctx)
+}
+
+func (e *logsExporter) exportData(ctx context.Context, ld plog.Logs) error {
+	data, err := e.marshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to marshal logs: %w", err))
+	}
+	return e.add(ctx, data)
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+)
+
+func newTestConfig(srv *miniredis.Miniredis) Config {
+	cfg := *createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = srv.Addr()
+	return cfg
+}
+
+// getData returns the data of the entries of the stream.
+func getData(t *testing.T, srv *miniredis.Miniredis, stream string) [][]byte {
+	entries, err := srv.Stream(stream)
+	require.NoError(t, err)
+	var data [][]byte
+	for _, entry := range entries {
+		require.Equal(t, []string{redisstreams.DataField}, entry.Values[:1])
+		data = append(data, []byte(entry.Values[1]))
+	}
+	return data
+}
+
+func testLogs(body string) plog.Logs {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	return logs
+}
+
+// argsRecorder records the arguments of the commands processed by a client.
+type argsRecorder struct {
+	mu   sync.Mutex
+	args [][]any
+}
+
+func (*argsRecorder) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (r *argsRecorder) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if cmd.Name() == "xadd" {
+			r.mu.Lock()
+			r.args = append(r.args, cmd.Args())
+			r.mu.Unlock()
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (*argsRecorder) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestExportEntries(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	exp := newLogsExporter(newTestConfig(srv))
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	// Each export adds an entry, holding the encoded data in its data field.
+	for _, body := range []string{"first", "second"} {
+		require.NoError(t, exp.exportData(t.Context(), testLogs(body)))
+	}
+
+	data := getData(t, srv, defaultLogsStream)
+	require.Len(t, data, 2)
+	for i, body := range []string{"first", "second"} {
+		received, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(data[i])
+		require.NoError(t, err)
+		assert.Equal(t, testLogs(body), received)
+	}
+}
+
+func TestExportTrimming(t *testing.T) {
+	tests := []struct {
+		name                string
+		maxLen              int64
+		approximateTrimming bool
+		expectedArgs        []any
+		expectedBodies      []string
+	}{
+		{
+			name:           "exact",
+			maxLen:         2,
+			expectedArgs:   []any{"xadd", defaultLogsStream, "maxlen", "=", int64(2), "*"},
+			expectedBodies: []string{"second", "third"},
+		},
+		{
+			// Redis trims approximately to whole nodes of the stream, which miniredis doesn't.
+			name:                "approximate",
+			maxLen:              2,
+			approximateTrimming: true,
+			expectedArgs:        []any{"xadd", defaultLogsStream, "maxlen", "~", int64(2), "*"},
+			expectedBodies:      []string{"second", "third"},
+		},
+		{
+			name:           "unlimited",
+			maxLen:         0,
+			expectedArgs:   []any{"xadd", defaultLogsStream, "*"},
+			expectedBodies: []string{"first", "second", "third"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := miniredis.RunT(t)
+
+			cfg := newTestConfig(srv)
+			cfg.MaxLen = tt.maxLen
+			cfg.ApproximateTrimming = tt.approximateTrimming
+			exp := newLogsExporter(cfg)
+			require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+			recorder := &argsRecorder{}
+			exp.client.AddHook(recorder)
+
+			for _, body := range []string{"first", "second", "third"} {
+				require.NoError(t, exp.exportData(t.Context(), testLogs(body)))
+			}
+
+			require.Len(t, recorder.args, 3)
+			for _, args := range recorder.args {
+				// The arguments are followed by the data field and its value.
+				require.Len(t, args, len(tt.expectedArgs)+2)
+				assert.Equal(t, tt.expectedArgs, args[:len(tt.expectedArgs)])
+				assert.Equal(t, redisstreams.DataField, args[len(tt.expectedArgs)])
+			}
+
+			// The oldest entries are removed.
+			data := getData(t, srv, defaultLogsStream)
+			require.Len(t, data, len(tt.expectedBodies))
+			for i, body := range tt.expectedBodies {
+				received, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(data[i])
+				require.NoError(t, err)
+				assert.Equal(t, testLogs(body), received)
+			}
+		})
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	exp := newLogsExporter(newTestConfig(srv))
+	require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Close(context.Background())) }()
+
+	// The key holds a value which isn't a stream.
+	require.NoError(t, srv.Set(defaultLogsStream, "value"))
+	err := exp.exportData(t.Context(), testLogs("message"))
+	require.ErrorContains(t, err, `failed to add entry to stream "otlp:logs": WRONGTYPE`)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	srv.Close()
+	err = exp.exportData(t.Context(), testLogs("message"))
+	require.ErrorContains(t, err, `failed to add entry to stream "otlp:logs"`)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestStartErrors(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	cfg := newTestConfig(srv)
+	srv.Close()
+	exp := newLogsExporter(cfg)
+	assert.ErrorContains(t, exp.Start(t.Context(), componenttest.NewNopHost()), "failed to connect")
+	assert.NoError(t, exp.Close(t.Context()))
+}
@@ -5,13 +5,16 @@ package syslogexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 )
 
 var (
@@ -20,6 +23,8 @@ var (
 	errUnsupportedNetwork  = errors.New("unsupported network: network is required, only tcp/udp/unix supported")
 	errUnsupportedProtocol = errors.New("unsupported protocol: Only rfc5424 and rfc3164 supported")
 	errOctetCounting       = errors.New("octet counting is only supported for rfc5424 protocol")
+
+	errUnsupportedMessageFormat = errors.New("unsupported message format: only text, cef and leef supported")
 )
 
 // Config defines configuration for Syslog exporter.
@@ -41,6 +46,20 @@ type Config struct {
 	// TLS struct exposes TLS client configuration.
 	TLS configtls.ClientConfig `mapstructure:"tls"`
 
+	// Mapping overrides the record attributes the syslog fields are read from with OTTL value expressions.
+	Mapping MappingConfig `mapstructure:"mapping"`
+
+	// Format of the syslog message part
+	// options: text, cef, leef
+	MessageFormat string `mapstructure:"message_format"`
+	// CEF configures the message part when message_format is cef.
+	CEF CEFConfig `mapstructure:"cef"`
+	// LEEF configures the message part when message_format is leef.
+	LEEF LEEFConfig `mapstructure:"leef"`
+
+	// WriteBatch limits how many messages are sent in a single TCP write.
+	WriteBatch WriteBatchConfig `mapstructure:"write_batch"`
+
 	QueueSettings   configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
 	BackOffConfig   configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
 	TimeoutSettings exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -76,6 +95,15 @@ func (cfg *Config) Validate() error {
 		invalidFields = append(invalidFields, errOctetCounting)
 	}
 
+	invalidFields = append(invalidFields, cfg.validateMessage()...)
+
+	if cfg.WriteBatch.MaxMessages < 0 {
+		invalidFields = append(invalidFields, errors.New("write_batch::max_messages must not be negative"))
+	}
+	if cfg.WriteBatch.MaxBytes < 0 {
+		invalidFields = append(invalidFields, errors.New("write_batch::max_bytes must not be negative"))
+	}
+
 	if len(invalidFields) > 0 {
 		return errors.Join(invalidFields...)
 	}
@@ -83,6 +111,170 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// MappingConfig defines OTTL value expressions, evaluated in the log context, for the fields of the
+// syslog message. A field without an expression keeps reading its record attribute.
+type MappingConfig struct {
+	// Priority of the message, mutually exclusive with facility and severity.
+	Priority string `mapstructure:"priority"`
+	// Facility of the message, either a number or a name such as local4.
+	Facility string `mapstructure:"facility"`
+	// Severity of the message, either a number or a name such as notice.
+	Severity string `mapstructure:"severity"`
+	// Version of the rfc5424 message.
+	Version string `mapstructure:"version"`
+	// Timestamp of the message, either a time or nanoseconds since the epoch.
+	Timestamp string `mapstructure:"timestamp"`
+	Hostname  string `mapstructure:"hostname"`
+	AppName   string `mapstructure:"app_name"`
+	ProcID    string `mapstructure:"proc_id"`
+	MsgID     string `mapstructure:"msg_id"`
+	Message   string `mapstructure:"message"`
+	// StructuredData elements of the rfc5424 message, written in the configured order.
+	StructuredData []StructuredDataElementConfig `mapstructure:"structured_data"`
+}
+
+// StructuredDataElementConfig defines a single rfc5424 structured data element.
+type StructuredDataElementConfig struct {
+	// ID of the element, e.g. exampleSDID@32473.
+	ID string `mapstructure:"id"`
+	// Params maps parameter names to OTTL value expressions.
+	Params map[string]string `mapstructure:"params"`
+}
+
+func (m *MappingConfig) isEmpty() bool {
+	return m.Priority == "" && m.Facility == "" && m.Severity == "" && m.Version == "" && m.Timestamp == "" &&
+		m.Hostname == "" && m.AppName == "" && m.ProcID == "" && m.MsgID == "" && m.Message == "" &&
+		len(m.StructuredData) == 0
+}
+
+// CEFConfig defines the header and extensions of ArcSight Common Event Format messages.
+type CEFConfig struct {
+	DeviceVendor  string `mapstructure:"device_vendor"`
+	DeviceProduct string `mapstructure:"device_product"`
+	DeviceVersion string `mapstructure:"device_version"`
+	// SignatureID is an OTTL value expression for the event class ID, 0 if not set.
+	SignatureID string `mapstructure:"signature_id"`
+	// Name is an OTTL value expression for the event name, the log body if not set.
+	Name string `mapstructure:"name"`
+	// Severity is an OTTL value expression for the event severity, derived from the
+	// log severity number if not set.
+	Severity string `mapstructure:"severity"`
+	// Extensions maps extension keys to OTTL value expressions.
+	Extensions map[string]string `mapstructure:"extensions"`
+}
+
+// LEEFConfig defines the header and attributes of IBM Log Event Extended Format messages.
+type LEEFConfig struct {
+	// Version of the format
+	// options: 1.0, 2.0
+	Version        string `mapstructure:"version"`
+	Vendor         string `mapstructure:"vendor"`
+	Product        string `mapstructure:"product"`
+	ProductVersion string `mapstructure:"product_version"`
+	// EventID is an OTTL value expression for the event ID, 0 if not set.
+	EventID string `mapstructure:"event_id"`
+	// Delimiter between the attributes, only configurable with version 2.0.
+	Delimiter string `mapstructure:"delimiter"`
+	// Attributes maps attribute keys to OTTL value expressions.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+// WriteBatchConfig limits the size of the TCP writes. A zero value means no limit.
+type WriteBatchConfig struct {
+	// MaxMessages is the maximum number of messages per write.
+	MaxMessages int `mapstructure:"max_messages"`
+	// MaxBytes is the maximum size of a write in bytes. A single message larger than
+	// the limit is written on its own.
+	MaxBytes int `mapstructure:"max_bytes"`
+}
+
+func (cfg *Config) validateMessage() []error {
+	var invalidFields []error
+
+	m := cfg.Mapping
+	if m.Priority != "" && (m.Facility != "" || m.Severity != "") {
+		invalidFields = append(invalidFields, errors.New("mapping::priority cannot be combined with mapping::facility or mapping::severity"))
+	}
+	expressions := [][2]string{
+		{"mapping::priority", m.Priority},
+		{"mapping::facility", m.Facility},
+		{"mapping::severity", m.Severity},
+		{"mapping::version", m.Version},
+		{"mapping::timestamp", m.Timestamp},
+		{"mapping::hostname", m.Hostname},
+		{"mapping::app_name", m.AppName},
+		{"mapping::proc_id", m.ProcID},
+		{"mapping::msg_id", m.MsgID},
+		{"mapping::message", m.Message},
+	}
+	for i, sd := range m.StructuredData {
+		if sd.ID == "" {
+			invalidFields = append(invalidFields, fmt.Errorf("mapping::structured_data[%d]: id must be specified", i))
+		}
+		for _, name := range sortedKeys(sd.Params) {
+			if sd.Params[name] == "" {
+				invalidFields = append(invalidFields, fmt.Errorf("mapping::structured_data[%d]::params::%s must not be empty", i, name))
+			}
+			expressions = append(expressions, [2]string{fmt.Sprintf("mapping::structured_data[%d]::params::%s", i, name), sd.Params[name]})
+		}
+	}
+
+	switch cfg.MessageFormat {
+	case "", messageFormatText:
+	case messageFormatCEF:
+		expressions = append(expressions,
+			[2]string{"cef::signature_id", cfg.CEF.SignatureID},
+			[2]string{"cef::name", cfg.CEF.Name},
+			[2]string{"cef::severity", cfg.CEF.Severity},
+		)
+		for _, key := range sortedKeys(cfg.CEF.Extensions) {
+			if !isAlphanumeric(key) {
+				invalidFields = append(invalidFields, fmt.Errorf("cef::extensions: key %q must be alphanumeric", key))
+			}
+			if cfg.CEF.Extensions[key] == "" {
+				invalidFields = append(invalidFields, fmt.Errorf("cef::extensions::%s must not be empty", key))
+			}
+			expressions = append(expressions, [2]string{"cef::extensions::" + key, cfg.CEF.Extensions[key]})
+		}
+	case messageFormatLEEF:
+		switch cfg.LEEF.Version {
+		case leefVersion1:
+			if cfg.LEEF.Delimiter != "" {
+				invalidFields = append(invalidFields, errors.New("leef::delimiter is only supported with version 2.0"))
+			}
+		case leefVersion2:
+			if len([]rune(cfg.LEEF.Delimiter)) > 1 {
+				invalidFields = append(invalidFields, errors.New("leef::delimiter must be a single character"))
+			}
+		default:
+			invalidFields = append(invalidFields, fmt.Errorf("leef::version must be one of %q or %q", leefVersion1, leefVersion2))
+		}
+		expressions = append(expressions, [2]string{"leef::event_id", cfg.LEEF.EventID})
+		for _, key := range sortedKeys(cfg.LEEF.Attributes) {
+			if cfg.LEEF.Attributes[key] == "" {
+				invalidFields = append(invalidFields, fmt.Errorf("leef::attributes::%s must not be empty", key))
+			}
+			expressions = append(expressions, [2]string{"leef::attributes::" + key, cfg.LEEF.Attributes[key]})
+		}
+	default:
+		invalidFields = append(invalidFields, errUnsupportedMessageFormat)
+	}
+
+	parser, err := newParser(component.TelemetrySettings{Logger: zap.NewNop()})
+	if err != nil {
+		return append(invalidFields, err)
+	}
+	for _, expr := range expressions {
+		if expr[1] == "" {
+			continue
+		}
+		if _, err := parser.ParseValueExpression(expr[1]); err != nil {
+			invalidFields = append(invalidFields, fmt.Errorf("%s: %w", expr[0], err))
+		}
+	}
+	return invalidFields
+}
+
 const (
 	// Syslog Network
 	DefaultNetwork = string(confignet.TransportTypeTCP)
@@ -90,4 +282,6 @@ const (
 	DefaultPort = 514
 	// Syslog Protocol
 	DefaultProtocol = "rfc5424"
+	// Syslog message format
+	DefaultMessageFormat = messageFormatText
 )
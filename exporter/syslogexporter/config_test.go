@@ -65,6 +65,104 @@ func TestValidate(t *testing.T) {
 			},
 			err: "invalid endpoint: endpoint is required but it is not configured",
 		},
+		{
+			name: "valid mapping",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc5424",
+				Mapping: MappingConfig{
+					Facility: `"local4"`,
+					Severity: `log.attributes["level"]`,
+					AppName:  `resource.attributes["service.name"]`,
+					Message:  "log.body",
+					StructuredData: []StructuredDataElementConfig{{
+						ID:     "origin@32473",
+						Params: map[string]string{"ip": `resource.attributes["host.ip"]`},
+					}},
+				},
+				MessageFormat: "cef",
+				CEF: CEFConfig{
+					DeviceVendor: "acme",
+					Extensions:   map[string]string{"src": `log.attributes["client.address"]`},
+				},
+				WriteBatch: WriteBatchConfig{MaxMessages: 100, MaxBytes: 65536},
+			},
+		},
+		{
+			name: "invalid mapping",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc5424",
+				Mapping: MappingConfig{
+					Priority: "34",
+					Severity: `"err"`,
+					Hostname: "log.unknown",
+					StructuredData: []StructuredDataElementConfig{{
+						Params: map[string]string{"ip": ""},
+					}},
+				},
+			},
+			err: "mapping::priority cannot be combined with mapping::facility or mapping::severity" + "\n" +
+				"mapping::structured_data[0]: id must be specified" + "\n" +
+				"mapping::structured_data[0]::params::ip must not be empty" + "\n" +
+				`mapping::hostname: segment "unknown" from path "log.unknown" is not a valid path nor a valid OTTL keyword for the log context - review https://github.com/open-telemetry/opentelemetry-collector-contrib/tree/main/pkg/ottl/contexts/ottllog to see all valid paths`,
+		},
+		{
+			name: "invalid message format",
+			cfg: &Config{
+				Port:          514,
+				Endpoint:      "host.domain.com",
+				Network:       "tcp",
+				Protocol:      "rfc5424",
+				MessageFormat: "json",
+			},
+			err: "unsupported message format: only text, cef and leef supported",
+		},
+		{
+			name: "invalid cef extensions",
+			cfg: &Config{
+				Port:          514,
+				Endpoint:      "host.domain.com",
+				Network:       "tcp",
+				Protocol:      "rfc5424",
+				MessageFormat: "cef",
+				CEF: CEFConfig{
+					Extensions: map[string]string{"src.ip": "log.body"},
+				},
+			},
+			err: `cef::extensions: key "src.ip" must be alphanumeric`,
+		},
+		{
+			name: "invalid leef",
+			cfg: &Config{
+				Port:          514,
+				Endpoint:      "host.domain.com",
+				Network:       "tcp",
+				Protocol:      "rfc5424",
+				MessageFormat: "leef",
+				LEEF: LEEFConfig{
+					Version:   "1.0",
+					Delimiter: "^",
+				},
+			},
+			err: "leef::delimiter is only supported with version 2.0",
+		},
+		{
+			name: "invalid write batch",
+			cfg: &Config{
+				Port:       514,
+				Endpoint:   "host.domain.com",
+				Network:    "tcp",
+				Protocol:   "rfc5424",
+				WriteBatch: WriteBatchConfig{MaxMessages: -1, MaxBytes: -1},
+			},
+			err: "write_batch::max_messages must not be negative" + "\n" +
+				"write_batch::max_bytes must not be negative",
+		},
 	}
 	for _, testInstance := range tests {
 		t.Run(testInstance.name, func(t *testing.T) {
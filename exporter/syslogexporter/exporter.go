@@ -23,6 +23,7 @@ type syslogexporter struct {
 	logger    *zap.Logger
 	tlsConfig *tls.Config
 	formatter formatter
+	mapper    *recordMapper
 }
 
 func initExporter(cfg *Config, createSettings exporter.Settings) (*syslogexporter, error) {
@@ -35,11 +36,17 @@ func initExporter(cfg *Config, createSettings exporter.Settings) (*syslogexporte
 		}
 	}
 
+	mapper, err := newRecordMapper(cfg, createSettings.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
 	s := &syslogexporter{
 		config:    cfg,
 		logger:    createSettings.Logger,
 		tlsConfig: loadedTLSConfig,
 		formatter: createFormatter(cfg.Protocol, cfg.EnableOctetCounting),
+		mapper:    mapper,
 	}
 
 	s.logger.Info("Syslog Exporter configured",
@@ -85,33 +92,54 @@ func (se *syslogexporter) pushLogsData(ctx context.Context, logs plog.Logs) erro
 }
 
 func (se *syslogexporter) exportBatch(ctx context.Context, logs plog.Logs) error {
-	var payload strings.Builder
+	var messages []string
 	for i := 0; i < logs.ResourceLogs().Len(); i++ {
 		resourceLogs := logs.ResourceLogs().At(i)
 		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
 			scopeLogs := resourceLogs.ScopeLogs().At(j)
 			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
 				logRecord := scopeLogs.LogRecords().At(k)
-				formatted := se.formatter.format(logRecord)
-				payload.WriteString(formatted)
+				messages = append(messages, se.format(ctx, resourceLogs, scopeLogs, logRecord))
 			}
 		}
 	}
 
-	if payload.Len() > 0 {
+	if len(messages) > 0 {
 		sender, err := connect(ctx, se.logger, se.config, se.tlsConfig)
 		if err != nil {
 			return consumererror.NewLogs(err, logs)
 		}
 		defer sender.close()
-		err = sender.Write(ctx, payload.String())
-		if err != nil {
-			return consumererror.NewLogs(err, logs)
+		for written := 0; written < len(messages); {
+			n := se.writeBatchLen(messages[written:])
+			err = sender.Write(ctx, strings.Join(messages[written:written+n], ""))
+			if err != nil {
+				if written == 0 {
+					return consumererror.NewLogs(err, logs)
+				}
+				// Only the messages of the failed and the following writes are retried.
+				return consumererror.NewLogs(err, skipLogRecords(logs, written))
+			}
+			written += n
 		}
 	}
 	return nil
 }
 
+// writeBatchLen returns the number of leading messages that fit in a single write.
+func (se *syslogexporter) writeBatchLen(messages []string) int {
+	limits := se.config.WriteBatch
+	size := 0
+	for i, msg := range messages {
+		if i > 0 && (limits.MaxMessages > 0 && i >= limits.MaxMessages ||
+			limits.MaxBytes > 0 && size+len(msg) > limits.MaxBytes) {
+			return i
+		}
+		size += len(msg)
+	}
+	return len(messages)
+}
+
 func (se *syslogexporter) exportNonBatch(ctx context.Context, logs plog.Logs) error {
 	sender, err := connect(ctx, se.logger, se.config, se.tlsConfig)
 	if err != nil {
@@ -129,7 +157,7 @@ func (se *syslogexporter) exportNonBatch(ctx context.Context, logs plog.Logs) er
 			droppedScopeLogs := droppedResourceLogs.ScopeLogs().AppendEmpty()
 			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
 				logRecord := scopeLogs.LogRecords().At(k)
-				formatted := se.formatter.format(logRecord)
+				formatted := se.format(ctx, resourceLogs, scopeLogs, logRecord)
 				err = sender.Write(ctx, formatted)
 				if err != nil {
 					errs = append(errs, err)
@@ -147,3 +175,10 @@ func (se *syslogexporter) exportNonBatch(ctx context.Context, logs plog.Logs) er
 
 	return nil
 }
+
+func (se *syslogexporter) format(ctx context.Context, resourceLogs plog.ResourceLogs, scopeLogs plog.ScopeLogs, logRecord plog.LogRecord) string {
+	if se.mapper != nil {
+		logRecord = se.mapper.apply(ctx, resourceLogs, scopeLogs, logRecord)
+	}
+	return se.formatter.format(logRecord)
+}
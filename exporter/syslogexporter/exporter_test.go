@@ -389,3 +389,75 @@ func TestTLSConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteBatchLen(t *testing.T) {
+	messages := []string{"aaaa\n", "bb\n", "cccccc\n", "d\n"}
+	tests := []struct {
+		name     string
+		limits   WriteBatchConfig
+		expected []int
+	}{
+		{
+			name:     "unlimited",
+			expected: []int{4},
+		},
+		{
+			name:     "max messages",
+			limits:   WriteBatchConfig{MaxMessages: 3},
+			expected: []int{3, 1},
+		},
+		{
+			name:     "max bytes",
+			limits:   WriteBatchConfig{MaxBytes: 8},
+			expected: []int{2, 1, 1},
+		},
+		{
+			name:     "message larger than max bytes",
+			limits:   WriteBatchConfig{MaxBytes: 4},
+			expected: []int{1, 1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := &syslogexporter{config: &Config{WriteBatch: tt.limits}}
+			var lens []int
+			for written := 0; written < len(messages); {
+				n := exp.writeBatchLen(messages[written:])
+				lens = append(lens, n)
+				written += n
+			}
+			assert.Equal(t, tt.expected, lens)
+		})
+	}
+}
+
+func TestTCPSyslogExportMappingAndWriteBatch(t *testing.T) {
+	cfg := createTCPTestConfig()
+	cfg.Protocol = "rfc3164"
+	cfg.Mapping.AppName = `log.attributes["service"]`
+	cfg.MessageFormat = messageFormatCEF
+	cfg.CEF = CEFConfig{DeviceVendor: "Acme", DeviceProduct: "Shop", DeviceVersion: "1.0", Name: `log.attributes["message"]`}
+	cfg.WriteBatch.MaxMessages = 1
+	test := prepareTCPExporterTest(t, cfg, false)
+	defer test.srv.Close()
+
+	logs := plog.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, service := range []string{"cart", "payment"} {
+		logRecord := logRecords.AppendEmpty()
+		exampleLog(t).CopyTo(logRecord)
+		logRecord.Attributes().PutStr("service", service)
+	}
+	require.NoError(t, test.exp.pushLogsData(t.Context(), logs))
+
+	require.NoError(t, test.srv.SetDeadline(time.Now().Add(time.Second*1)))
+	conn, err := test.srv.AcceptTCP()
+	require.NoError(t, err, "could not accept connection")
+	defer conn.Close()
+	b, err := io.ReadAll(conn)
+	require.NoError(t, err, "could not read all")
+	assert.Equal(t,
+		"<165>Aug 24 12:14:15 192.0.2.1 cart: CEF:0|Acme|Shop|1.0|0|It's time to make the do-nuts.|0|\n"+
+			"<165>Aug 24 12:14:15 192.0.2.1 payment: CEF:0|Acme|Shop|1.0|0|It's time to make the do-nuts.|0|\n",
+		string(b))
+}
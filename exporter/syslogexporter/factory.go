@@ -32,6 +32,8 @@ func createDefaultConfig() component.Config {
 		Port:            DefaultPort,
 		Network:         DefaultNetwork,
 		Protocol:        DefaultProtocol,
+		MessageFormat:   DefaultMessageFormat,
+		LEEF:            LEEFConfig{Version: leefVersion2},
 		BackOffConfig:   configretry.NewDefaultBackOffConfig(),
 		QueueSettings:   qs,
 		TimeoutSettings: exporterhelper.NewDefaultTimeoutConfig(),
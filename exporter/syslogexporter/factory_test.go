@@ -26,9 +26,11 @@ func TestCreateDefaultConfig(t *testing.T) {
 	cfg := createDefaultConfig()
 
 	assert.Equal(t, &Config{
-		Port:     514,
-		Network:  "tcp",
-		Protocol: "rfc5424",
+		Port:          514,
+		Network:       "tcp",
+		Protocol:      "rfc5424",
+		MessageFormat: "text",
+		LEEF:          LEEFConfig{Version: "2.0"},
 		QueueSettings: configoptional.Default(func() exporterhelper.QueueBatchConfig {
 			queue := exporterhelper.NewDefaultQueueConfig()
 			queue.NumConsumers = 10
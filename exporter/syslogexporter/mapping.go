@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+const (
+	defaultFacility = defaultPriority / 8
+	defaultSeverity = defaultPriority % 8
+	maxPriority     = 191
+)
+
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"ntp":      12,
+	"audit":    13,
+	"alert":    14,
+	"clock":    15,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+var severities = map[string]int{
+	"emerg":         0,
+	"emergency":     0,
+	"alert":         1,
+	"crit":          2,
+	"critical":      2,
+	"err":           3,
+	"error":         3,
+	"warning":       4,
+	"warn":          4,
+	"notice":        5,
+	"info":          6,
+	"informational": 6,
+	"debug":         7,
+}
+
+type valueExpression = *ottl.ValueExpression[*ottllog.TransformContext]
+
+type namedExpression struct {
+	name string
+	expr valueExpression
+}
+
+type structuredDataElement struct {
+	id     string
+	params []namedExpression
+}
+
+// evaluator evaluates OTTL value expressions. Failed evaluations are logged and reported as missing
+// values, so that the affected field falls back to its default.
+type evaluator struct {
+	logger *zap.Logger
+}
+
+func (e evaluator) eval(ctx context.Context, expr valueExpression, tCtx *ottllog.TransformContext) (any, bool) {
+	if expr == nil {
+		return nil, false
+	}
+	val, err := expr.Eval(ctx, tCtx)
+	if err != nil {
+		e.logger.Debug("Failed to evaluate expression", zap.Stringer("expression", expr), zap.Error(err))
+		return nil, false
+	}
+	return val, val != nil
+}
+
+// evalString evaluates expr and converts the result to a string. It reports false if the expression
+// could not be evaluated or resolved to nil.
+func (e evaluator) evalString(ctx context.Context, expr valueExpression, tCtx *ottllog.TransformContext) (string, bool) {
+	val, ok := e.eval(ctx, expr, tCtx)
+	if !ok {
+		return "", false
+	}
+	return valueToString(val), true
+}
+
+func valueToString(val any) string {
+	switch typed := val.(type) {
+	case string:
+		return typed
+	case pcommon.Value:
+		return typed.AsString()
+	case pcommon.Map:
+		v := pcommon.NewValueMap()
+		typed.CopyTo(v.Map())
+		return v.AsString()
+	case pcommon.Slice:
+		v := pcommon.NewValueSlice()
+		typed.CopyTo(v.Slice())
+		return v.AsString()
+	case time.Time:
+		return typed.Format(time.RFC3339Nano)
+	}
+	v := pcommon.NewValueEmpty()
+	if err := v.FromRaw(val); err != nil {
+		return fmt.Sprint(val)
+	}
+	return v.AsString()
+}
+
+// recordMapper sets the attributes the formatters read from the configured mapping.
+type recordMapper struct {
+	evaluator
+	priority       valueExpression
+	facility       valueExpression
+	severity       valueExpression
+	version        valueExpression
+	timestamp      valueExpression
+	hostname       valueExpression
+	appName        valueExpression
+	procID         valueExpression
+	msgID          valueExpression
+	message        valueExpression
+	structuredData []structuredDataElement
+	// messageFormatter builds the message part, it is nil for text messages.
+	messageFormatter messageFormatter
+}
+
+// newRecordMapper returns nil if neither a mapping nor a structured message format is configured,
+// in which case the log records are formatted as they are.
+func newRecordMapper(cfg *Config, set component.TelemetrySettings) (*recordMapper, error) {
+	if cfg.Mapping.isEmpty() && (cfg.MessageFormat == "" || cfg.MessageFormat == messageFormatText) {
+		return nil, nil
+	}
+	parser, err := newParser(set)
+	if err != nil {
+		return nil, err
+	}
+	m := &recordMapper{evaluator: evaluator{logger: set.Logger}}
+	for _, field := range []struct {
+		name string
+		expr string
+		dst  *valueExpression
+	}{
+		{"priority", cfg.Mapping.Priority, &m.priority},
+		{"facility", cfg.Mapping.Facility, &m.facility},
+		{"severity", cfg.Mapping.Severity, &m.severity},
+		{"version", cfg.Mapping.Version, &m.version},
+		{"timestamp", cfg.Mapping.Timestamp, &m.timestamp},
+		{"hostname", cfg.Mapping.Hostname, &m.hostname},
+		{"app_name", cfg.Mapping.AppName, &m.appName},
+		{"proc_id", cfg.Mapping.ProcID, &m.procID},
+		{"msg_id", cfg.Mapping.MsgID, &m.msgID},
+		{"message", cfg.Mapping.Message, &m.message},
+	} {
+		if *field.dst, err = parseOptional(parser, field.expr); err != nil {
+			return nil, fmt.Errorf("mapping::%s: %w", field.name, err)
+		}
+	}
+	for i, sd := range cfg.Mapping.StructuredData {
+		params, err := parseNamed(parser, sd.Params)
+		if err != nil {
+			return nil, fmt.Errorf("mapping::structured_data[%d]: %w", i, err)
+		}
+		m.structuredData = append(m.structuredData, structuredDataElement{id: sd.ID, params: params})
+	}
+
+	switch cfg.MessageFormat {
+	case messageFormatCEF:
+		m.messageFormatter, err = newCEFFormatter(cfg.CEF, parser, m.evaluator)
+	case messageFormatLEEF:
+		m.messageFormatter, err = newLEEFFormatter(cfg.LEEF, parser, m.evaluator)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// apply returns a copy of the log record with the mapped fields written to the attributes and the
+// timestamp the formatters read. Fields without a mapping keep their original attributes.
+func (m *recordMapper) apply(ctx context.Context, resourceLogs plog.ResourceLogs, scopeLogs plog.ScopeLogs, logRecord plog.LogRecord) plog.LogRecord {
+	tCtx := ottllog.NewTransformContextPtr(resourceLogs, scopeLogs, logRecord)
+	defer tCtx.Close()
+
+	mapped := plog.NewLogRecord()
+	logRecord.CopyTo(mapped)
+	attrs := mapped.Attributes()
+
+	m.applyPriority(ctx, tCtx, attrs)
+	if val, ok := m.eval(ctx, m.timestamp, tCtx); ok {
+		switch typed := val.(type) {
+		case time.Time:
+			mapped.SetTimestamp(pcommon.NewTimestampFromTime(typed))
+		case int64:
+			mapped.SetTimestamp(pcommon.Timestamp(typed))
+		default:
+			m.logger.Debug("Ignoring timestamp of unsupported type", zap.String("type", fmt.Sprintf("%T", val)))
+		}
+	}
+	for _, field := range []struct {
+		attr string
+		expr valueExpression
+	}{
+		{version, m.version},
+		{hostname, m.hostname},
+		{app, m.appName},
+		{pid, m.procID},
+		{msgID, m.msgID},
+		{message, m.message},
+	} {
+		if val, ok := m.evalString(ctx, field.expr, tCtx); ok {
+			attrs.PutStr(field.attr, val)
+		}
+	}
+
+	if len(m.structuredData) > 0 {
+		sd := attrs.PutEmptyMap(structuredData)
+		for _, element := range m.structuredData {
+			params := sd.PutEmptyMap(element.id)
+			for _, param := range element.params {
+				if val, ok := m.evalString(ctx, param.expr, tCtx); ok {
+					params.PutStr(param.name, val)
+				}
+			}
+		}
+	}
+
+	if m.messageFormatter != nil {
+		attrs.PutStr(message, m.messageFormatter.formatMessage(ctx, tCtx))
+	}
+	return mapped
+}
+
+func (m *recordMapper) applyPriority(ctx context.Context, tCtx *ottllog.TransformContext, attrs pcommon.Map) {
+	if val, ok := m.eval(ctx, m.priority, tCtx); ok {
+		if p, ok := parseCode(val, nil, maxPriority); ok {
+			attrs.PutInt(priority, int64(p))
+		} else {
+			m.logger.Debug("Ignoring invalid priority", zap.Any("priority", val))
+		}
+		return
+	}
+	if m.facility == nil && m.severity == nil {
+		return
+	}
+
+	// Start from the priority of the record, so that only the mapped part of it is replaced.
+	facility, severity := defaultFacility, defaultSeverity
+	if p, found := attrs.Get(priority); found {
+		if code, ok := parseCode(p.AsString(), nil, maxPriority); ok {
+			facility, severity = code/8, code%8
+		}
+	}
+	if val, ok := m.eval(ctx, m.facility, tCtx); ok {
+		if f, ok := parseCode(val, facilities, 23); ok {
+			facility = f
+		} else {
+			m.logger.Debug("Ignoring invalid facility", zap.Any("facility", val))
+		}
+	}
+	if val, ok := m.eval(ctx, m.severity, tCtx); ok {
+		if s, ok := parseCode(val, severities, 7); ok {
+			severity = s
+		} else {
+			m.logger.Debug("Ignoring invalid severity", zap.Any("severity", val))
+		}
+	}
+	attrs.PutInt(priority, int64(facility*8+severity))
+}
+
+// parseCode converts an integer, a numeric string or one of the given names to a code
+// in the range 0 to limit.
+func parseCode(val any, names map[string]int, limit int) (int, bool) {
+	var code int
+	switch typed := val.(type) {
+	case int64:
+		code = int(typed)
+	case string:
+		if named, ok := names[strings.ToLower(typed)]; ok {
+			return named, true
+		}
+		parsed, err := strconv.Atoi(typed)
+		if err != nil {
+			return 0, false
+		}
+		code = parsed
+	default:
+		return 0, false
+	}
+	if code < 0 || code > limit {
+		return 0, false
+	}
+	return code, true
+}
+
+func newParser(set component.TelemetrySettings) (ottl.Parser[*ottllog.TransformContext], error) {
+	return ottllog.NewParser(filterottl.StandardLogFuncs(), set, ottllog.EnablePathContextNames())
+}
+
+func parseOptional(parser ottl.Parser[*ottllog.TransformContext], expr string) (valueExpression, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return parser.ParseValueExpression(expr)
+}
+
+// parseNamed parses the expressions of the map, ordered by name.
+func parseNamed(parser ottl.Parser[*ottllog.TransformContext], exprs map[string]string) ([]namedExpression, error) {
+	named := make([]namedExpression, 0, len(exprs))
+	for _, name := range sortedKeys(exprs) {
+		expr, err := parser.ParseValueExpression(exprs[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		named = append(named, namedExpression{name: name, expr: expr})
+	}
+	return named, nil
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func mappingTestLogs() plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().PutStr("service.name", "checkout")
+	resourceLogs.Resource().Attributes().PutStr("host.name", "web-1")
+	logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)))
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Date(2025, 3, 4, 5, 6, 8, 0, time.UTC)))
+	logRecord.SetSeverityNumber(plog.SeverityNumberError)
+	logRecord.SetSeverityText("error")
+	logRecord.Body().SetStr("payment failed")
+	logRecord.Attributes().PutStr("appname", "original")
+	logRecord.Attributes().PutInt("priority", 14)
+	logRecord.Attributes().PutStr("user", "alice")
+	logRecord.Attributes().PutInt("pid", 4242)
+	return logs
+}
+
+func applyMapping(t *testing.T, cfg *Config) plog.LogRecord {
+	require.NoError(t, cfg.Validate())
+	mapper, err := newRecordMapper(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NotNil(t, mapper)
+
+	logs := mappingTestLogs()
+	resourceLogs := logs.ResourceLogs().At(0)
+	scopeLogs := resourceLogs.ScopeLogs().At(0)
+	return mapper.apply(t.Context(), resourceLogs, scopeLogs, scopeLogs.LogRecords().At(0))
+}
+
+func TestNewRecordMapperWithoutMapping(t *testing.T) {
+	mapper, err := newRecordMapper(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Nil(t, mapper)
+}
+
+func TestRecordMapper(t *testing.T) {
+	cfg := createTCPTestConfig()
+	cfg.Endpoint = "localhost"
+	cfg.Mapping = MappingConfig{
+		Severity:  `log.severity_text`,
+		Hostname:  `resource.attributes["host.name"]`,
+		AppName:   `resource.attributes["service.name"]`,
+		ProcID:    `log.attributes["pid"]`,
+		MsgID:     `log.attributes["missing"]`,
+		Message:   `log.body`,
+		Timestamp: `log.observed_time`,
+		StructuredData: []StructuredDataElementConfig{
+			{ID: "user@32473", Params: map[string]string{"name": `log.attributes["user"]`}},
+			{ID: "origin", Params: map[string]string{"software": `"otelcol"`, "enterpriseId": `"32473"`}},
+		},
+	}
+
+	mapped := applyMapping(t, cfg)
+	assert.Equal(t,
+		"<11>1 2025-03-04T05:06:08Z web-1 checkout 4242 - [user@32473 name=\"alice\"][origin enterpriseId=\"32473\" software=\"otelcol\"] payment failed\n",
+		newRFC5424Formatter(false).format(mapped))
+}
+
+func TestRecordMapperPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		mapping  MappingConfig
+		expected int64
+	}{
+		{
+			name:     "priority",
+			mapping:  MappingConfig{Priority: "86"},
+			expected: 86,
+		},
+		{
+			name:     "facility name",
+			mapping:  MappingConfig{Facility: `"local4"`},
+			expected: 20*8 + 6,
+		},
+		{
+			name:     "facility and severity",
+			mapping:  MappingConfig{Facility: "4", Severity: `"warning"`},
+			expected: 4*8 + 4,
+		},
+		{
+			name:     "invalid severity keeps the record priority",
+			mapping:  MappingConfig{Severity: "12"},
+			expected: 14,
+		},
+		{
+			name:     "invalid priority keeps the record priority",
+			mapping:  MappingConfig{Priority: `"high"`},
+			expected: 14,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTCPTestConfig()
+			cfg.Endpoint = "localhost"
+			cfg.Mapping = tt.mapping
+
+			mapped := applyMapping(t, cfg)
+			p, found := mapped.Attributes().Get(priority)
+			require.True(t, found)
+			assert.Equal(t, tt.expected, p.Int())
+		})
+	}
+}
+
+func TestRecordMapperKeepsOriginalRecord(t *testing.T) {
+	cfg := createTCPTestConfig()
+	cfg.Endpoint = "localhost"
+	cfg.Mapping.AppName = `resource.attributes["service.name"]`
+	require.NoError(t, cfg.Validate())
+	mapper, err := newRecordMapper(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	logs := mappingTestLogs()
+	resourceLogs := logs.ResourceLogs().At(0)
+	scopeLogs := resourceLogs.ScopeLogs().At(0)
+	logRecord := scopeLogs.LogRecords().At(0)
+	mapped := mapper.apply(t.Context(), resourceLogs, scopeLogs, logRecord)
+
+	assert.Equal(t, "checkout", getAttributeValueOrDefault(mapped, app, ""))
+	assert.Equal(t, "original", getAttributeValueOrDefault(logRecord, app, ""))
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+const (
+	messageFormatText = "text"
+	messageFormatCEF  = "cef"
+	messageFormatLEEF = "leef"
+)
+
+const (
+	leefVersion1 = "1.0"
+	leefVersion2 = "2.0"
+
+	defaultEventID = "0"
+)
+
+var (
+	// The header fields can't hold line breaks, which are replaced with spaces.
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	leefHeaderEscaper   = cefHeaderEscaper
+)
+
+// messageFormatter builds the message part of a syslog message from the log record.
+type messageFormatter interface {
+	formatMessage(ctx context.Context, tCtx *ottllog.TransformContext) string
+}
+
+type cefFormatter struct {
+	evaluator
+	header      string
+	signatureID valueExpression
+	name        valueExpression
+	severity    valueExpression
+	extensions  []namedExpression
+}
+
+func newCEFFormatter(cfg CEFConfig, parser ottl.Parser[*ottllog.TransformContext], e evaluator) (*cefFormatter, error) {
+	f := &cefFormatter{
+		evaluator: e,
+		header: fmt.Sprintf("CEF:0|%s|%s|%s|",
+			cefHeaderEscaper.Replace(cfg.DeviceVendor),
+			cefHeaderEscaper.Replace(cfg.DeviceProduct),
+			cefHeaderEscaper.Replace(cfg.DeviceVersion)),
+	}
+	var err error
+	if f.signatureID, err = parseOptional(parser, cfg.SignatureID); err != nil {
+		return nil, fmt.Errorf("cef::signature_id: %w", err)
+	}
+	if f.name, err = parseOptional(parser, cfg.Name); err != nil {
+		return nil, fmt.Errorf("cef::name: %w", err)
+	}
+	if f.severity, err = parseOptional(parser, cfg.Severity); err != nil {
+		return nil, fmt.Errorf("cef::severity: %w", err)
+	}
+	if f.extensions, err = parseNamed(parser, cfg.Extensions); err != nil {
+		return nil, fmt.Errorf("cef::extensions: %w", err)
+	}
+	return f, nil
+}
+
+func (f *cefFormatter) formatMessage(ctx context.Context, tCtx *ottllog.TransformContext) string {
+	logRecord := tCtx.GetLogRecord()
+	signatureID, ok := f.evalString(ctx, f.signatureID, tCtx)
+	if !ok {
+		signatureID = defaultEventID
+	}
+	name, ok := f.evalString(ctx, f.name, tCtx)
+	if !ok {
+		name = logRecord.Body().AsString()
+	}
+	severity, ok := f.evalString(ctx, f.severity, tCtx)
+	if !ok {
+		severity = strconv.Itoa(cefSeverity(logRecord.SeverityNumber()))
+	}
+
+	var b strings.Builder
+	b.WriteString(f.header)
+	b.WriteString(cefHeaderEscaper.Replace(signatureID))
+	b.WriteByte('|')
+	b.WriteString(cefHeaderEscaper.Replace(name))
+	b.WriteByte('|')
+	b.WriteString(cefHeaderEscaper.Replace(severity))
+	b.WriteByte('|')
+	first := true
+	for _, ext := range f.extensions {
+		val, ok := f.evalString(ctx, ext.expr, tCtx)
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(ext.name)
+		b.WriteByte('=')
+		b.WriteString(cefExtensionEscaper.Replace(val))
+	}
+	return b.String()
+}
+
+// cefSeverity scales the OpenTelemetry severity number range 1-24 to the CEF severity range 0-10.
+func cefSeverity(severityNumber plog.SeverityNumber) int {
+	if severityNumber <= plog.SeverityNumberTrace {
+		return 0
+	}
+	if severityNumber > plog.SeverityNumberFatal4 {
+		return 10
+	}
+	return int(severityNumber-plog.SeverityNumberTrace) * 10 / int(plog.SeverityNumberFatal4-plog.SeverityNumberTrace)
+}
+
+type leefFormatter struct {
+	evaluator
+	header     string
+	version2   bool
+	delimiter  string
+	escaper    *strings.Replacer
+	eventID    valueExpression
+	attributes []namedExpression
+}
+
+func newLEEFFormatter(cfg LEEFConfig, parser ottl.Parser[*ottllog.TransformContext], e evaluator) (*leefFormatter, error) {
+	delimiter := cfg.Delimiter
+	if delimiter == "" {
+		delimiter = "\t"
+	}
+	f := &leefFormatter{
+		evaluator: e,
+		header: fmt.Sprintf("LEEF:%s|%s|%s|%s|",
+			cfg.Version,
+			leefHeaderEscaper.Replace(cfg.Vendor),
+			leefHeaderEscaper.Replace(cfg.Product),
+			leefHeaderEscaper.Replace(cfg.ProductVersion)),
+		version2:  cfg.Version == leefVersion2,
+		delimiter: delimiter,
+		escaper:   strings.NewReplacer(`\`, `\\`, delimiter, `\`+delimiter, "\r\n", `\n`, "\n", `\n`, "\r", `\n`),
+	}
+	var err error
+	if f.eventID, err = parseOptional(parser, cfg.EventID); err != nil {
+		return nil, fmt.Errorf("leef::event_id: %w", err)
+	}
+	if f.attributes, err = parseNamed(parser, cfg.Attributes); err != nil {
+		return nil, fmt.Errorf("leef::attributes: %w", err)
+	}
+	return f, nil
+}
+
+func (f *leefFormatter) formatMessage(ctx context.Context, tCtx *ottllog.TransformContext) string {
+	eventID, ok := f.evalString(ctx, f.eventID, tCtx)
+	if !ok {
+		eventID = defaultEventID
+	}
+
+	var b strings.Builder
+	b.WriteString(f.header)
+	b.WriteString(leefHeaderEscaper.Replace(eventID))
+	b.WriteByte('|')
+	if f.version2 {
+		// Version 2.0 declares the delimiter in the header, tabs are written as a hex value.
+		if f.delimiter == "\t" {
+			b.WriteString("x09")
+		} else {
+			b.WriteString(f.delimiter)
+		}
+		b.WriteByte('|')
+	}
+	first := true
+	for _, attr := range f.attributes {
+		val, ok := f.evalString(ctx, attr.expr, tCtx)
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteString(f.delimiter)
+		}
+		first = false
+		b.WriteString(attr.name)
+		b.WriteByte('=')
+		b.WriteString(f.escaper.Replace(val))
+	}
+	return b.String()
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestCEFMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		cef      CEFConfig
+		expected string
+	}{
+		{
+			name: "defaults",
+			cef: CEFConfig{
+				DeviceVendor:  "Acme",
+				DeviceProduct: "Shop|Web",
+				DeviceVersion: "1.0",
+			},
+			expected: `CEF:0|Acme|Shop\|Web|1.0|0|payment failed|6|`,
+		},
+		{
+			name: "expressions",
+			cef: CEFConfig{
+				DeviceVendor:  "Acme",
+				DeviceProduct: "Shop",
+				DeviceVersion: "1.0",
+				SignatureID:   `"PAY-1"`,
+				Name:          `Concat(["payment", "declined"], " | ")`,
+				Severity:      "9",
+				Extensions: map[string]string{
+					"suser": `log.attributes["user"]`,
+					"msg":   `"a=b\\c\nd"`,
+					"dpid":  `log.attributes["pid"]`,
+					"cs1":   `log.attributes["missing"]`,
+				},
+			},
+			expected: `CEF:0|Acme|Shop|1.0|PAY-1|payment \| declined|9|dpid=4242 msg=a\=b\\c\nd suser=alice`,
+		},
+		{
+			name: "line breaks in header",
+			cef: CEFConfig{
+				DeviceVendor:  "Acme",
+				DeviceProduct: "Shop",
+				DeviceVersion: "1.0",
+				SignatureID:   `"PAY\r\n1"`,
+				Name:          `Concat([log.body, "retrying"], "\n")`,
+				Severity:      `"9\r"`,
+			},
+			expected: `CEF:0|Acme|Shop|1.0|PAY 1|payment failed retrying|9 |`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTCPTestConfig()
+			cfg.Endpoint = "localhost"
+			cfg.MessageFormat = messageFormatCEF
+			cfg.CEF = tt.cef
+
+			mapped := applyMapping(t, cfg)
+			assert.Equal(t, tt.expected, getAttributeValueOrDefault(mapped, message, ""))
+		})
+	}
+}
+
+func TestLEEFMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		leef     LEEFConfig
+		expected string
+	}{
+		{
+			name: "version 1.0",
+			leef: LEEFConfig{
+				Version:        "1.0",
+				Vendor:         "Acme",
+				Product:        "Shop",
+				ProductVersion: "1.0",
+				Attributes: map[string]string{
+					"usrName": `log.attributes["user"]`,
+					"sev":     `log.severity_number`,
+				},
+			},
+			expected: "LEEF:1.0|Acme|Shop|1.0|0|sev=17\tusrName=alice",
+		},
+		{
+			name: "version 2.0 with tab delimiter",
+			leef: LEEFConfig{
+				Version:        "2.0",
+				Vendor:         "Acme",
+				Product:        "Shop",
+				ProductVersion: "1.0",
+				EventID:        `"PAY-1"`,
+				Attributes: map[string]string{
+					"usrName": `log.attributes["user"]`,
+				},
+			},
+			expected: "LEEF:2.0|Acme|Shop|1.0|PAY-1|x09|usrName=alice",
+		},
+		{
+			name: "version 2.0 with custom delimiter",
+			leef: LEEFConfig{
+				Version:        "2.0",
+				Vendor:         "Acme",
+				Product:        "Shop",
+				ProductVersion: "1.0",
+				Delimiter:      "^",
+				Attributes: map[string]string{
+					"usrName": `log.attributes["user"]`,
+					"msg":     `"a^b"`,
+				},
+			},
+			expected: `LEEF:2.0|Acme|Shop|1.0|0|^|msg=a\^b^usrName=alice`,
+		},
+		{
+			name: "line breaks in header",
+			leef: LEEFConfig{
+				Version:        "1.0",
+				Vendor:         "Acme",
+				Product:        "Shop",
+				ProductVersion: "1.0",
+				EventID:        `"PAY\n1"`,
+			},
+			expected: "LEEF:1.0|Acme|Shop|1.0|PAY 1|",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createTCPTestConfig()
+			cfg.Endpoint = "localhost"
+			cfg.MessageFormat = messageFormatLEEF
+			cfg.LEEF = tt.leef
+
+			mapped := applyMapping(t, cfg)
+			assert.Equal(t, tt.expected, getAttributeValueOrDefault(mapped, message, ""))
+		})
+	}
+}
+
+func TestCEFSeverity(t *testing.T) {
+	assert.Equal(t, 0, cefSeverity(plog.SeverityNumberUnspecified))
+	assert.Equal(t, 0, cefSeverity(plog.SeverityNumberTrace))
+	assert.Equal(t, 3, cefSeverity(plog.SeverityNumberInfo))
+	assert.Equal(t, 5, cefSeverity(plog.SeverityNumberWarn))
+	assert.Equal(t, 6, cefSeverity(plog.SeverityNumberError))
+	assert.Equal(t, 10, cefSeverity(plog.SeverityNumberFatal4))
+}
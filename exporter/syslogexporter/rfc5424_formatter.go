@@ -80,18 +80,18 @@ func (*rfc5424Formatter) formatStructuredData(logRecord plog.LogRecord) string {
 	}
 
 	var sdBuilder strings.Builder
-	for key, val := range structuredDataAttributeValue.Map().AsRaw() {
-		sdElements := []string{key}
-		vval, ok := val.(map[string]any)
-		if !ok {
+	// The elements and their parameters are formatted in the order of the map, which
+	// is the order they were added in.
+	for key, val := range structuredDataAttributeValue.Map().All() {
+		if val.Type() != pcommon.ValueTypeMap {
 			continue
 		}
-		for k, v := range vval {
-			vv, ok := v.(string)
-			if !ok {
+		sdElements := []string{key}
+		for k, v := range val.Map().All() {
+			if v.Type() != pcommon.ValueTypeStr {
 				continue
 			}
-			sdElements = append(sdElements, fmt.Sprintf("%s=%q", k, vv))
+			sdElements = append(sdElements, fmt.Sprintf("%s=%q", k, v.Str()))
 		}
 		fmt.Fprint(&sdBuilder, sdElements)
 	}
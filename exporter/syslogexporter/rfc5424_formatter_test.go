@@ -189,3 +189,33 @@ func TestRFC5424Formatter_NanoFractionIsTruncatedToMicro(t *testing.T) {
 	octetCounting := newRFC5424Formatter(true).format(logRecord)
 	assert.True(t, strings.HasPrefix(octetCounting, fmt.Sprintf("%d ", len(actual))))
 }
+
+func TestRFC5424Formatter_StructuredDataOrder(t *testing.T) {
+	logRecord := plog.NewLogRecord()
+	logRecord.Attributes().PutInt("priority", 165)
+	logRecord.Attributes().PutInt("version", 1)
+	structuredData := logRecord.Attributes().PutEmptyMap("structured_data")
+	exampleSDID := structuredData.PutEmptyMap("exampleSDID@32473")
+	exampleSDID.PutStr("iut", "3")
+	exampleSDID.PutStr("eventSource", "Application")
+	exampleSDID.PutStr("eventID", "1011")
+	origin := structuredData.PutEmptyMap("origin")
+	origin.PutStr("software", "otelcol")
+	origin.PutStr("ip", "192.0.2.1")
+	meta := structuredData.PutEmptyMap("meta")
+	meta.PutStr("sequenceId", "29")
+	meta.PutStr("language", "en")
+	meta.PutStr("sysUpTime", "4711")
+	timestamp, err := time.Parse(rfc5424.RFC3339MICRO, "2003-08-24T05:14:15.000003-07:00")
+	require.NoError(t, err)
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+
+	// The SD-ELEMENTs and their parameters keep the order of the attributes.
+	expected := "<165>1 2003-08-24T12:14:15.000003Z - - - - " +
+		`[exampleSDID@32473 iut="3" eventSource="Application" eventID="1011"]` +
+		`[origin software="otelcol" ip="192.0.2.1"]` +
+		`[meta sequenceId="29" language="en" sysUpTime="4711"]` + "\n"
+	for range 10 {
+		assert.Equal(t, expected, newRFC5424Formatter(false).format(logRecord))
+	}
+}
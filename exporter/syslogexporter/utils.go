@@ -3,7 +3,12 @@
 
 package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
 
 type errorWithCount struct {
 	err   error
@@ -45,3 +50,44 @@ func deduplicateErrors(errs []error) []error {
 	}
 	return uniqueErrors
 }
+
+// skipLogRecords returns a copy of logs without its first n log records.
+func skipLogRecords(logs plog.Logs, n int) plog.Logs {
+	remaining := plog.NewLogs()
+	logs.CopyTo(remaining)
+	remaining.ResourceLogs().RemoveIf(func(resourceLogs plog.ResourceLogs) bool {
+		resourceLogs.ScopeLogs().RemoveIf(func(scopeLogs plog.ScopeLogs) bool {
+			scopeLogs.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+				if n > 0 {
+					n--
+					return true
+				}
+				return false
+			})
+			return scopeLogs.LogRecords().Len() == 0
+		})
+		return resourceLogs.ScopeLogs().Len() == 0
+	})
+	return remaining
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isAlphanumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 func TestDeduplicateErrors(t *testing.T) {
@@ -57,3 +59,25 @@ func TestDeduplicateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSkipLogRecords(t *testing.T) {
+	logs := plog.NewLogs()
+	for i := range 2 {
+		resourceLogs := logs.ResourceLogs().AppendEmpty()
+		for j := range 2 {
+			logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+			logRecords.AppendEmpty().Body().SetStr(fmt.Sprintf("%d.%d.0", i, j))
+			logRecords.AppendEmpty().Body().SetStr(fmt.Sprintf("%d.%d.1", i, j))
+		}
+	}
+
+	remaining := skipLogRecords(logs, 3)
+
+	assert.Equal(t, 8, logs.LogRecordCount())
+	assert.Equal(t, 5, remaining.LogRecordCount())
+	require.Equal(t, 2, remaining.ResourceLogs().Len())
+	first := remaining.ResourceLogs().At(0).ScopeLogs()
+	require.Equal(t, 1, first.Len())
+	assert.Equal(t, "0.1.1", first.At(0).LogRecords().At(0).Body().Str())
+	assert.Equal(t, 2, remaining.ResourceLogs().At(1).ScopeLogs().Len())
+}
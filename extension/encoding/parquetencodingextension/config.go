@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+var _ confmap.Validator = (*Config)(nil)
+
+type Config struct {
+	// Compression is the codec used to compress the column chunks: none, snappy, gzip or zstd.
+	Compression string `mapstructure:"compression"`
+	// PromotedAttributes are the attributes stored in their own columns rather than in the attribute maps.
+	PromotedAttributes PromotedAttributesConfig `mapstructure:"promoted_attributes"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// PromotedAttributesConfig lists the attribute keys promoted to their own string column, per level.
+type PromotedAttributesConfig struct {
+	// Resource attributes are promoted to resource_<key> columns.
+	Resource []string `mapstructure:"resource"`
+	// Scope attributes are promoted to scope_<key> columns.
+	Scope []string `mapstructure:"scope"`
+	// Record attributes, of the log records, spans or data points, are promoted to attribute_<key> columns.
+	Record []string `mapstructure:"record"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	switch c.Compression {
+	case compressionNone, compressionSnappy, compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("unsupported compression: %q", c.Compression)
+	}
+
+	var errs []error
+	columns := map[string]string{}
+	for _, level := range []struct {
+		prefix string
+		keys   []string
+	}{
+		{resourcePrefix, c.PromotedAttributes.Resource},
+		{scopePrefix, c.PromotedAttributes.Scope},
+		{recordPrefix, c.PromotedAttributes.Record},
+	} {
+		for _, key := range level.keys {
+			if key == "" {
+				errs = append(errs, errors.New("promoted attribute keys must not be empty"))
+				continue
+			}
+			column := promotedColumnName(level.prefix, key)
+			if reservedColumns[column] {
+				errs = append(errs, fmt.Errorf("promoted attribute %q maps to reserved column %q", key, column))
+				continue
+			}
+			if other, ok := columns[column]; ok {
+				errs = append(errs, fmt.Errorf("promoted attributes %q and %q both map to column %q", other, key, column))
+				continue
+			}
+			columns[column] = key
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{Compression: compressionSnappy},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "zstd"),
+			expected: &Config{
+				Compression: compressionZstd,
+				PromotedAttributes: PromotedAttributesConfig{
+					Resource: []string{"service.name", "k8s.namespace.name"},
+					Scope:    []string{"library.language"},
+					Record:   []string{"http.route"},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_compression"),
+			expectedErr: `unsupported compression: "lz77"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "empty_key"),
+			expectedErr: "promoted attribute keys must not be empty",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "reserved_column"),
+			expectedErr: `promoted attribute "name" maps to reserved column "scope_name"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicate_column"),
+			expectedErr: `promoted attributes "service.name" and "service_name" both map to column "resource_service_name"`,
+		},
+	}
+
+	for _, tt := range tests {
+		name := strings.ReplaceAll(tt.id.String(), "/", "_")
+		t.Run(name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package parquetencodingextension implements an encoding extension marshaling and unmarshaling
+// logs, traces and metric data points to and from Parquet files.
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
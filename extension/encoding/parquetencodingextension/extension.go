@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"context"
+
+	"github.com/parquet-go/parquet-go"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.TracesMarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.TracesUnmarshalerExtension  = (*parquetExtension)(nil)
+	_ encoding.LogsMarshalerExtension      = (*parquetExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.MetricsMarshalerExtension   = (*parquetExtension)(nil)
+	_ encoding.MetricsUnmarshalerExtension = (*parquetExtension)(nil)
+)
+
+type parquetExtension struct {
+	config         *Config
+	schema         recordSchema
+	logsOptions    []parquet.WriterOption
+	tracesOptions  []parquet.WriterOption
+	metricsOptions []parquet.WriterOption
+}
+
+func newExtension(config *Config) (*parquetExtension, error) {
+	rs := newRecordSchema(config.PromotedAttributes)
+	logsOptions, err := rs.writerOptions(rs.schema("logs", logColumns()), config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	tracesOptions, err := rs.writerOptions(rs.schema("spans", spanColumns()), config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	metricsOptions, err := rs.writerOptions(rs.schema("metrics", metricColumns()), config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetExtension{
+		config:         config,
+		schema:         rs,
+		logsOptions:    logsOptions,
+		tracesOptions:  tracesOptions,
+		metricsOptions: metricsOptions,
+	}, nil
+}
+
+func (*parquetExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (*parquetExtension) Shutdown(context.Context) error {
+	return nil
+}
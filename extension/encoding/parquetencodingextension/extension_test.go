@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/ptracetest"
+)
+
+var (
+	testTraceID = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	testSpanID  = pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+)
+
+var promotedConfig = PromotedAttributesConfig{
+	Resource: []string{"service.name"},
+	Scope:    []string{"library.language"},
+	Record:   []string{"http.route"},
+}
+
+func newTestExtension(t *testing.T, compression string, promoted PromotedAttributesConfig) *parquetExtension {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Compression = compression
+	cfg.PromotedAttributes = promoted
+	require.NoError(t, cfg.Validate())
+	ext, err := factory.Create(t.Context(), extensiontest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, ext.Shutdown(t.Context()))
+	})
+	return ext.(*parquetExtension)
+}
+
+func fillResource(resource pcommon.Resource, service string) {
+	resource.Attributes().PutStr("service.name", service)
+	resource.Attributes().PutStr("host.name", "host-1")
+}
+
+func fillScope(scope pcommon.InstrumentationScope) {
+	scope.SetName("scope")
+	scope.SetVersion("1.0.0")
+	scope.Attributes().PutStr("library.language", "go")
+}
+
+func testLogs() plog.Logs {
+	logs := plog.NewLogs()
+	for _, service := range []string{"checkout", "cart"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+		fillResource(rl.Resource(), service)
+		sl := rl.ScopeLogs().AppendEmpty()
+		fillScope(sl.Scope())
+		for i, body := range []string{"first", "second"} {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.SetTimestamp(pcommon.Timestamp(1700000000000000000 + i))
+			lr.SetObservedTimestamp(pcommon.Timestamp(1700000000000000100 + i))
+			lr.SetSeverityNumber(plog.SeverityNumberWarn)
+			lr.SetSeverityText("WARN")
+			lr.Body().SetStr(body)
+			lr.SetTraceID(testTraceID)
+			lr.SetSpanID(testSpanID)
+			lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+			lr.SetEventName("event")
+			lr.SetDroppedAttributesCount(2)
+			lr.Attributes().PutStr("http.route", "/cart")
+			lr.Attributes().PutStr("http.method", "GET")
+		}
+		// Log records without promoted attribute nor body.
+		sl.LogRecords().AppendEmpty().SetTimestamp(1700000000000000010)
+	}
+	return logs
+}
+
+func testTraces() ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	fillResource(rs.Resource(), "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	fillScope(ss.Scope())
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(testTraceID)
+	span.SetSpanID(testSpanID)
+	span.SetParentSpanID(pcommon.SpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+	span.TraceState().FromRaw("vendor=value")
+	span.SetFlags(1)
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(1700000000000000000)
+	span.SetEndTimestamp(1700000000500000000)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("failed")
+	span.SetDroppedAttributesCount(1)
+	span.Attributes().PutStr("http.route", "/cart")
+	span.Attributes().PutStr("http.method", "GET")
+	event := span.Events().AppendEmpty()
+	event.SetTimestamp(1700000000100000000)
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "timeout")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+	link.SetSpanID(pcommon.SpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1}))
+	link.TraceState().FromRaw("other=value")
+	link.Attributes().PutStr("link.kind", "follows")
+
+	child := ss.Spans().AppendEmpty()
+	child.SetTraceID(testTraceID)
+	child.SetSpanID(pcommon.SpanID([8]byte{2, 2, 2, 2, 2, 2, 2, 2}))
+	child.SetParentSpanID(testSpanID)
+	child.SetName("SELECT")
+	child.SetKind(ptrace.SpanKindClient)
+	child.SetStartTimestamp(1700000000200000000)
+	child.SetEndTimestamp(1700000000300000000)
+	child.Status().SetCode(ptrace.StatusCodeOk)
+	return traces
+}
+
+func testMetrics() pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	fillResource(rm.Resource(), "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	fillScope(sm.Scope())
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("memory.usage")
+	gauge.SetUnit("By")
+	gauge.SetDescription("Memory usage")
+	gauge.SetEmptyGauge()
+	for _, route := range []string{"/cart", "/checkout"} {
+		dp := gauge.Gauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(1700000000000000000)
+		dp.SetDoubleValue(1024.5)
+		dp.Attributes().PutStr("http.route", route)
+	}
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	sdp := sum.Sum().DataPoints().AppendEmpty()
+	sdp.SetStartTimestamp(1690000000000000000)
+	sdp.SetTimestamp(1700000000000000000)
+	sdp.SetIntValue(42)
+	sdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	sdp.Attributes().PutStr("http.route", "/cart")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetUnit("ms")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(1690000000000000000)
+	hdp.SetTimestamp(1700000000000000000)
+	hdp.SetCount(6)
+	hdp.SetSum(120)
+	hdp.SetMin(1)
+	hdp.SetMax(80)
+	hdp.ExplicitBounds().FromRaw([]float64{10, 50})
+	hdp.BucketCounts().FromRaw([]uint64{3, 2, 1})
+
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("latency.exponential")
+	exponential.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	edp := exponential.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(1700000000000000000)
+	edp.SetCount(7)
+	edp.SetSum(10.5)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.SetZeroThreshold(0.001)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 2, 3})
+	edp.Negative().SetOffset(1)
+	edp.Negative().BucketCounts().FromRaw([]uint64{0})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("latency.summary")
+	qdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	qdp.SetTimestamp(1700000000000000000)
+	qdp.SetCount(10)
+	qdp.SetSum(100)
+	q := qdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(9)
+	q = qdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(30)
+	return metrics
+}
+
+func TestLogsRoundTrip(t *testing.T) {
+	for _, compression := range []string{compressionNone, compressionSnappy, compressionGzip, compressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			ext := newTestExtension(t, compression, promotedConfig)
+			buf, err := ext.MarshalLogs(testLogs())
+			require.NoError(t, err)
+
+			logs, err := ext.UnmarshalLogs(buf)
+			require.NoError(t, err)
+			require.NoError(t, plogtest.CompareLogs(testLogs(), logs))
+		})
+	}
+}
+
+func TestTracesRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, compressionSnappy, promotedConfig)
+	buf, err := ext.MarshalTraces(testTraces())
+	require.NoError(t, err)
+
+	traces, err := ext.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	require.NoError(t, ptracetest.CompareTraces(testTraces(), traces))
+}
+
+func TestMetricsRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, compressionSnappy, promotedConfig)
+	buf, err := ext.MarshalMetrics(testMetrics())
+	require.NoError(t, err)
+
+	metrics, err := ext.UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	require.NoError(t, pmetrictest.CompareMetrics(testMetrics(), metrics))
+}
+
+func TestPromotedColumns(t *testing.T) {
+	ext := newTestExtension(t, compressionNone, promotedConfig)
+	buf, err := ext.MarshalLogs(testLogs())
+	require.NoError(t, err)
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+	for _, column := range []string{"resource_service_name", "scope_library_language", "attribute_http_route"} {
+		_, ok := f.Schema().Lookup(column)
+		assert.True(t, ok, "missing promoted column %q", column)
+	}
+	metadata, ok := f.Lookup(promotedColumnsMetadataKey)
+	require.True(t, ok)
+	assert.JSONEq(t, `[
+		{"column": "resource_service_name", "level": "resource", "key": "service.name"},
+		{"column": "scope_library_language", "level": "scope", "key": "library.language"},
+		{"column": "attribute_http_route", "level": "attribute", "key": "http.route"}
+	]`, metadata)
+
+	// Files are unmarshaled with the promoted columns recorded in their metadata, whatever the configuration.
+	logs, err := newTestExtension(t, compressionNone, PromotedAttributesConfig{}).UnmarshalLogs(buf)
+	require.NoError(t, err)
+	require.NoError(t, plogtest.CompareLogs(testLogs(), logs))
+}
+
+func TestNonStringValues(t *testing.T) {
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetEmptyMap().PutStr("message", "hello")
+	lr.Attributes().PutInt("http.status_code", 200)
+	lr.Attributes().PutEmptySlice("tags").AppendEmpty().SetStr("a")
+
+	ext := newTestExtension(t, compressionSnappy, PromotedAttributesConfig{Record: []string{"http.status_code"}})
+	buf, err := ext.MarshalLogs(logs)
+	require.NoError(t, err)
+
+	got, err := ext.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	gotRecord := got.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, `{"message":"hello"}`, gotRecord.Body().Str())
+	assert.Equal(t, map[string]any{
+		"http.status_code": "200",
+		"tags":             `["a"]`,
+	}, gotRecord.Attributes().AsRaw())
+}
+
+func TestUnmarshalInvalidFile(t *testing.T) {
+	ext := newTestExtension(t, compressionSnappy, PromotedAttributesConfig{})
+	_, err := ext.UnmarshalLogs([]byte("not parquet"))
+	require.ErrorContains(t, err, "failed to open parquet file")
+	_, err = ext.UnmarshalTraces([]byte("not parquet"))
+	require.ErrorContains(t, err, "failed to open parquet file")
+	_, err = ext.UnmarshalMetrics([]byte("not parquet"))
+	require.ErrorContains(t, err, "failed to open parquet file")
+}
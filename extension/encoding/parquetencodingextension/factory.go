@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension/internal/metadata"
+)
+
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createExtension(_ context.Context, _ extension.Settings, config component.Config) (extension.Extension, error) {
+	return newExtension(config.(*Config))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{Compression: compressionSnappy}
+}
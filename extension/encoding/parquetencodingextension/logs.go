@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"github.com/parquet-go/parquet-go"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	columnTime           = "time"
+	columnObservedTime   = "observed_time"
+	columnSeverityNumber = "severity_number"
+	columnSeverityText   = "severity_text"
+	columnBody           = "body"
+	columnEventName      = "event_name"
+)
+
+func logColumns() parquet.Group {
+	return parquet.Group{
+		columnTime:              parquet.Timestamp(parquet.Nanosecond),
+		columnObservedTime:      parquet.Timestamp(parquet.Nanosecond),
+		columnSeverityNumber:    parquet.Int(32),
+		columnSeverityText:      parquet.String(),
+		columnBody:              parquet.String(),
+		columnTraceID:           parquet.String(),
+		columnSpanID:            parquet.String(),
+		columnFlags:             parquet.Int(32),
+		columnEventName:         parquet.String(),
+		columnDroppedAttributes: parquet.Int(32),
+	}
+}
+
+// MarshalLogs writes a row per log record. Bodies that are not strings are stored as JSON.
+func (ex *parquetExtension) MarshalLogs(logs plog.Logs) ([]byte, error) {
+	rows := make([]map[string]any, 0, logs.LogRecordCount())
+	for _, rl := range logs.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				row := map[string]any{
+					columnTime:              int64(lr.Timestamp()),
+					columnObservedTime:      int64(lr.ObservedTimestamp()),
+					columnSeverityNumber:    int32(lr.SeverityNumber()),
+					columnSeverityText:      lr.SeverityText(),
+					columnBody:              lr.Body().AsString(),
+					columnTraceID:           traceIDToString(lr.TraceID()),
+					columnSpanID:            spanIDToString(lr.SpanID()),
+					columnFlags:             int32(lr.Flags()),
+					columnEventName:         lr.EventName(),
+					columnDroppedAttributes: int32(lr.DroppedAttributesCount()),
+				}
+				ex.schema.resourceToRow(rl.Resource(), rl.SchemaUrl(), row)
+				ex.schema.scopeToRow(sl.Scope(), sl.SchemaUrl(), row)
+				ex.schema.record.toRow(lr.Attributes(), row)
+				rows = append(rows, row)
+			}
+		}
+	}
+	return writeRows(rows, ex.logsOptions)
+}
+
+// UnmarshalLogs reads the log records, grouped by resource and scope. Bodies and attributes are read as strings.
+func (*parquetExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	resources := map[string]plog.ResourceLogs{}
+	scopes := map[string]plog.ScopeLogs{}
+	err := readRows(buf, func(rs recordSchema, row map[string]any) {
+		rKey := resourceKey(row)
+		rl, ok := resources[rKey]
+		if !ok {
+			rl = logs.ResourceLogs().AppendEmpty()
+			rl.SetSchemaUrl(stringValue(row[columnResourceSchemaURL]))
+			rs.resource.fromRow(row, rl.Resource().Attributes())
+			resources[rKey] = rl
+		}
+		sKey := rKey + scopeKey(row)
+		sl, ok := scopes[sKey]
+		if !ok {
+			sl = rl.ScopeLogs().AppendEmpty()
+			sl.SetSchemaUrl(stringValue(row[columnScopeSchemaURL]))
+			sl.Scope().SetName(stringValue(row[columnScopeName]))
+			sl.Scope().SetVersion(stringValue(row[columnScopeVersion]))
+			rs.scope.fromRow(row, sl.Scope().Attributes())
+			scopes[sKey] = sl
+		}
+
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(timestampValue(row[columnTime]))
+		lr.SetObservedTimestamp(timestampValue(row[columnObservedTime]))
+		lr.SetSeverityNumber(plog.SeverityNumber(int64Value(row[columnSeverityNumber])))
+		lr.SetSeverityText(stringValue(row[columnSeverityText]))
+		if body := stringValue(row[columnBody]); body != "" {
+			lr.Body().SetStr(body)
+		}
+		lr.SetTraceID(traceIDValue(row[columnTraceID]))
+		lr.SetSpanID(spanIDValue(row[columnSpanID]))
+		lr.SetFlags(plog.LogRecordFlags(int64Value(row[columnFlags])))
+		lr.SetEventName(stringValue(row[columnEventName]))
+		lr.SetDroppedAttributesCount(uint32(int64Value(row[columnDroppedAttributes])))
+		rs.record.fromRow(row, lr.Attributes())
+	})
+	return logs, err
+}
@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"github.com/parquet-go/parquet-go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	columnMetricName             = "metric_name"
+	columnMetricDescription      = "metric_description"
+	columnMetricUnit             = "metric_unit"
+	columnMetricType             = "metric_type"
+	columnAggregationTemporality = "aggregation_temporality"
+	columnIsMonotonic            = "is_monotonic"
+	columnValueDouble            = "value_double"
+	columnValueInt               = "value_int"
+	columnCount                  = "count"
+	columnSum                    = "sum"
+	columnMin                    = "min"
+	columnMax                    = "max"
+	columnExplicitBounds         = "explicit_bounds"
+	columnBucketCounts           = "bucket_counts"
+	columnScale                  = "scale"
+	columnZeroCount              = "zero_count"
+	columnZeroThreshold          = "zero_threshold"
+	columnPositiveOffset         = "positive_offset"
+	columnPositiveBucketCounts   = "positive_bucket_counts"
+	columnNegativeOffset         = "negative_offset"
+	columnNegativeBucketCounts   = "negative_bucket_counts"
+	columnQuantiles              = "quantiles"
+	columnQuantileValues         = "quantile_values"
+)
+
+var (
+	metricTypes = map[string]pmetric.MetricType{
+		pmetric.MetricTypeGauge.String():                pmetric.MetricTypeGauge,
+		pmetric.MetricTypeSum.String():                  pmetric.MetricTypeSum,
+		pmetric.MetricTypeHistogram.String():            pmetric.MetricTypeHistogram,
+		pmetric.MetricTypeExponentialHistogram.String(): pmetric.MetricTypeExponentialHistogram,
+		pmetric.MetricTypeSummary.String():              pmetric.MetricTypeSummary,
+	}
+	aggregationTemporalities = map[string]pmetric.AggregationTemporality{
+		pmetric.AggregationTemporalityUnspecified.String(): pmetric.AggregationTemporalityUnspecified,
+		pmetric.AggregationTemporalityDelta.String():       pmetric.AggregationTemporalityDelta,
+		pmetric.AggregationTemporalityCumulative.String():  pmetric.AggregationTemporalityCumulative,
+	}
+)
+
+func metricColumns() parquet.Group {
+	return parquet.Group{
+		columnMetricName:             parquet.String(),
+		columnMetricDescription:      parquet.String(),
+		columnMetricUnit:             parquet.String(),
+		columnMetricType:             parquet.String(),
+		columnAggregationTemporality: parquet.String(),
+		columnIsMonotonic:            parquet.Leaf(parquet.BooleanType),
+		columnStartTime:              parquet.Timestamp(parquet.Nanosecond),
+		columnTime:                   parquet.Timestamp(parquet.Nanosecond),
+		columnFlags:                  parquet.Int(32),
+		columnValueDouble:            parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		columnValueInt:               parquet.Optional(parquet.Int(64)),
+		columnCount:                  parquet.Optional(parquet.Int(64)),
+		columnSum:                    parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		columnMin:                    parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		columnMax:                    parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		columnExplicitBounds:         parquet.List(parquet.Leaf(parquet.DoubleType)),
+		columnBucketCounts:           parquet.List(parquet.Int(64)),
+		columnScale:                  parquet.Optional(parquet.Int(32)),
+		columnZeroCount:              parquet.Optional(parquet.Int(64)),
+		columnZeroThreshold:          parquet.Optional(parquet.Leaf(parquet.DoubleType)),
+		columnPositiveOffset:         parquet.Optional(parquet.Int(32)),
+		columnPositiveBucketCounts:   parquet.List(parquet.Int(64)),
+		columnNegativeOffset:         parquet.Optional(parquet.Int(32)),
+		columnNegativeBucketCounts:   parquet.List(parquet.Int(64)),
+		columnQuantiles:              parquet.List(parquet.Leaf(parquet.DoubleType)),
+		columnQuantileValues:         parquet.List(parquet.Leaf(parquet.DoubleType)),
+	}
+}
+
+// MarshalMetrics writes a row per data point, repeating the name and type of the metric. Exemplars are not stored.
+func (ex *parquetExtension) MarshalMetrics(metrics pmetric.Metrics) ([]byte, error) {
+	rows := make([]map[string]any, 0, metrics.DataPointCount())
+	for _, rm := range metrics.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				appendRow := func(attrs pcommon.Map, row map[string]any) {
+					row[columnMetricName] = m.Name()
+					row[columnMetricDescription] = m.Description()
+					row[columnMetricUnit] = m.Unit()
+					row[columnMetricType] = m.Type().String()
+					if _, ok := row[columnAggregationTemporality]; !ok {
+						row[columnAggregationTemporality] = pmetric.AggregationTemporalityUnspecified.String()
+					}
+					if _, ok := row[columnIsMonotonic]; !ok {
+						row[columnIsMonotonic] = false
+					}
+					for _, column := range []string{columnExplicitBounds, columnBucketCounts, columnPositiveBucketCounts, columnNegativeBucketCounts, columnQuantiles, columnQuantileValues} {
+						if _, ok := row[column]; !ok {
+							row[column] = []any{}
+						}
+					}
+					ex.schema.resourceToRow(rm.Resource(), rm.SchemaUrl(), row)
+					ex.schema.scopeToRow(sm.Scope(), sm.SchemaUrl(), row)
+					ex.schema.record.toRow(attrs, row)
+					rows = append(rows, row)
+				}
+
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					for _, dp := range m.Gauge().DataPoints().All() {
+						appendRow(dp.Attributes(), numberDataPointRow(dp))
+					}
+				case pmetric.MetricTypeSum:
+					for _, dp := range m.Sum().DataPoints().All() {
+						row := numberDataPointRow(dp)
+						row[columnAggregationTemporality] = m.Sum().AggregationTemporality().String()
+						row[columnIsMonotonic] = m.Sum().IsMonotonic()
+						appendRow(dp.Attributes(), row)
+					}
+				case pmetric.MetricTypeHistogram:
+					for _, dp := range m.Histogram().DataPoints().All() {
+						row := map[string]any{
+							columnStartTime:              int64(dp.StartTimestamp()),
+							columnTime:                   int64(dp.Timestamp()),
+							columnFlags:                  int32(dp.Flags()),
+							columnAggregationTemporality: m.Histogram().AggregationTemporality().String(),
+							columnCount:                  int64(dp.Count()),
+							columnExplicitBounds:         float64sToList(dp.ExplicitBounds().AsRaw()),
+							columnBucketCounts:           uint64sToList(dp.BucketCounts().AsRaw()),
+						}
+						if dp.HasSum() {
+							row[columnSum] = dp.Sum()
+						}
+						if dp.HasMin() {
+							row[columnMin] = dp.Min()
+						}
+						if dp.HasMax() {
+							row[columnMax] = dp.Max()
+						}
+						appendRow(dp.Attributes(), row)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for _, dp := range m.ExponentialHistogram().DataPoints().All() {
+						row := map[string]any{
+							columnStartTime:              int64(dp.StartTimestamp()),
+							columnTime:                   int64(dp.Timestamp()),
+							columnFlags:                  int32(dp.Flags()),
+							columnAggregationTemporality: m.ExponentialHistogram().AggregationTemporality().String(),
+							columnCount:                  int64(dp.Count()),
+							columnScale:                  dp.Scale(),
+							columnZeroCount:              int64(dp.ZeroCount()),
+							columnZeroThreshold:          dp.ZeroThreshold(),
+							columnPositiveOffset:         dp.Positive().Offset(),
+							columnPositiveBucketCounts:   uint64sToList(dp.Positive().BucketCounts().AsRaw()),
+							columnNegativeOffset:         dp.Negative().Offset(),
+							columnNegativeBucketCounts:   uint64sToList(dp.Negative().BucketCounts().AsRaw()),
+						}
+						if dp.HasSum() {
+							row[columnSum] = dp.Sum()
+						}
+						if dp.HasMin() {
+							row[columnMin] = dp.Min()
+						}
+						if dp.HasMax() {
+							row[columnMax] = dp.Max()
+						}
+						appendRow(dp.Attributes(), row)
+					}
+				case pmetric.MetricTypeSummary:
+					for _, dp := range m.Summary().DataPoints().All() {
+						quantiles := make([]any, 0, dp.QuantileValues().Len())
+						values := make([]any, 0, dp.QuantileValues().Len())
+						for _, q := range dp.QuantileValues().All() {
+							quantiles = append(quantiles, q.Quantile())
+							values = append(values, q.Value())
+						}
+						appendRow(dp.Attributes(), map[string]any{
+							columnStartTime:      int64(dp.StartTimestamp()),
+							columnTime:           int64(dp.Timestamp()),
+							columnFlags:          int32(dp.Flags()),
+							columnCount:          int64(dp.Count()),
+							columnSum:            dp.Sum(),
+							columnQuantiles:      quantiles,
+							columnQuantileValues: values,
+						})
+					}
+				}
+			}
+		}
+	}
+	return writeRows(rows, ex.metricsOptions)
+}
+
+func numberDataPointRow(dp pmetric.NumberDataPoint) map[string]any {
+	row := map[string]any{
+		columnStartTime: int64(dp.StartTimestamp()),
+		columnTime:      int64(dp.Timestamp()),
+		columnFlags:     int32(dp.Flags()),
+	}
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		row[columnValueDouble] = dp.DoubleValue()
+	case pmetric.NumberDataPointValueTypeInt:
+		row[columnValueInt] = dp.IntValue()
+	}
+	return row
+}
+
+// UnmarshalMetrics reads the data points, grouped by resource, scope and metric. Attributes are read as strings.
+func (*parquetExtension) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	metrics := pmetric.NewMetrics()
+	resources := map[string]pmetric.ResourceMetrics{}
+	scopes := map[string]pmetric.ScopeMetrics{}
+	ms := map[string]pmetric.Metric{}
+	err := readRows(buf, func(rs recordSchema, row map[string]any) {
+		metricType, ok := metricTypes[stringValue(row[columnMetricType])]
+		if !ok {
+			return
+		}
+
+		rKey := resourceKey(row)
+		rm, ok := resources[rKey]
+		if !ok {
+			rm = metrics.ResourceMetrics().AppendEmpty()
+			rm.SetSchemaUrl(stringValue(row[columnResourceSchemaURL]))
+			rs.resource.fromRow(row, rm.Resource().Attributes())
+			resources[rKey] = rm
+		}
+		sKey := rKey + scopeKey(row)
+		sm, ok := scopes[sKey]
+		if !ok {
+			sm = rm.ScopeMetrics().AppendEmpty()
+			sm.SetSchemaUrl(stringValue(row[columnScopeSchemaURL]))
+			sm.Scope().SetName(stringValue(row[columnScopeName]))
+			sm.Scope().SetVersion(stringValue(row[columnScopeVersion]))
+			rs.scope.fromRow(row, sm.Scope().Attributes())
+			scopes[sKey] = sm
+		}
+		temporality := aggregationTemporalities[stringValue(row[columnAggregationTemporality])]
+		isMonotonic := boolValue(row[columnIsMonotonic])
+		mKey := sKey + fmt.Sprint(row[columnMetricName], row[columnMetricType], row[columnMetricUnit],
+			row[columnMetricDescription], temporality, isMonotonic)
+		m, ok := ms[mKey]
+		if !ok {
+			m = sm.Metrics().AppendEmpty()
+			m.SetName(stringValue(row[columnMetricName]))
+			m.SetDescription(stringValue(row[columnMetricDescription]))
+			m.SetUnit(stringValue(row[columnMetricUnit]))
+			switch metricType {
+			case pmetric.MetricTypeGauge:
+				m.SetEmptyGauge()
+			case pmetric.MetricTypeSum:
+				m.SetEmptySum().SetAggregationTemporality(temporality)
+				m.Sum().SetIsMonotonic(isMonotonic)
+			case pmetric.MetricTypeHistogram:
+				m.SetEmptyHistogram().SetAggregationTemporality(temporality)
+			case pmetric.MetricTypeExponentialHistogram:
+				m.SetEmptyExponentialHistogram().SetAggregationTemporality(temporality)
+			case pmetric.MetricTypeSummary:
+				m.SetEmptySummary()
+			}
+			ms[mKey] = m
+		}
+
+		switch metricType {
+		case pmetric.MetricTypeGauge:
+			numberDataPointFromRow(rs, row, m.Gauge().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeSum:
+			numberDataPointFromRow(rs, row, m.Sum().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeHistogram:
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(timestampValue(row[columnStartTime]))
+			dp.SetTimestamp(timestampValue(row[columnTime]))
+			dp.SetFlags(pmetric.DataPointFlags(int64Value(row[columnFlags])))
+			dp.SetCount(uint64(int64Value(row[columnCount])))
+			if v := row[columnSum]; v != nil {
+				dp.SetSum(float64Value(v))
+			}
+			if v := row[columnMin]; v != nil {
+				dp.SetMin(float64Value(v))
+			}
+			if v := row[columnMax]; v != nil {
+				dp.SetMax(float64Value(v))
+			}
+			dp.ExplicitBounds().FromRaw(float64List(row[columnExplicitBounds]))
+			dp.BucketCounts().FromRaw(uint64List(row[columnBucketCounts]))
+			rs.record.fromRow(row, dp.Attributes())
+		case pmetric.MetricTypeExponentialHistogram:
+			dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(timestampValue(row[columnStartTime]))
+			dp.SetTimestamp(timestampValue(row[columnTime]))
+			dp.SetFlags(pmetric.DataPointFlags(int64Value(row[columnFlags])))
+			dp.SetCount(uint64(int64Value(row[columnCount])))
+			if v := row[columnSum]; v != nil {
+				dp.SetSum(float64Value(v))
+			}
+			if v := row[columnMin]; v != nil {
+				dp.SetMin(float64Value(v))
+			}
+			if v := row[columnMax]; v != nil {
+				dp.SetMax(float64Value(v))
+			}
+			dp.SetScale(int32(int64Value(row[columnScale])))
+			dp.SetZeroCount(uint64(int64Value(row[columnZeroCount])))
+			dp.SetZeroThreshold(float64Value(row[columnZeroThreshold]))
+			dp.Positive().SetOffset(int32(int64Value(row[columnPositiveOffset])))
+			dp.Positive().BucketCounts().FromRaw(uint64List(row[columnPositiveBucketCounts]))
+			dp.Negative().SetOffset(int32(int64Value(row[columnNegativeOffset])))
+			dp.Negative().BucketCounts().FromRaw(uint64List(row[columnNegativeBucketCounts]))
+			rs.record.fromRow(row, dp.Attributes())
+		case pmetric.MetricTypeSummary:
+			dp := m.Summary().DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(timestampValue(row[columnStartTime]))
+			dp.SetTimestamp(timestampValue(row[columnTime]))
+			dp.SetFlags(pmetric.DataPointFlags(int64Value(row[columnFlags])))
+			dp.SetCount(uint64(int64Value(row[columnCount])))
+			dp.SetSum(float64Value(row[columnSum]))
+			quantiles := float64List(row[columnQuantiles])
+			values := float64List(row[columnQuantileValues])
+			for i := 0; i < len(quantiles) && i < len(values); i++ {
+				q := dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(quantiles[i])
+				q.SetValue(values[i])
+			}
+			rs.record.fromRow(row, dp.Attributes())
+		}
+	})
+	return metrics, err
+}
+
+func numberDataPointFromRow(rs recordSchema, row map[string]any, dp pmetric.NumberDataPoint) {
+	dp.SetStartTimestamp(timestampValue(row[columnStartTime]))
+	dp.SetTimestamp(timestampValue(row[columnTime]))
+	dp.SetFlags(pmetric.DataPointFlags(int64Value(row[columnFlags])))
+	if v := row[columnValueDouble]; v != nil {
+		dp.SetDoubleValue(float64Value(v))
+	} else if v := row[columnValueInt]; v != nil {
+		dp.SetIntValue(int64Value(v))
+	}
+	rs.record.fromRow(row, dp.Attributes())
+}
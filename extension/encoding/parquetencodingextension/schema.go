@@ -0,0 +1,427 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	resourcePrefix = "resource"
+	scopePrefix    = "scope"
+	recordPrefix   = "attribute"
+
+	// promotedColumnsMetadataKey is the key of the file metadata mapping the promoted columns to their
+	// attribute, so that files can be unmarshaled whatever the configuration of the unmarshaling extension.
+	promotedColumnsMetadataKey = "otel.promoted_attributes"
+
+	// readBatchSize is the number of rows read at once when unmarshaling.
+	readBatchSize = 1024
+)
+
+// Columns shared by all the signals.
+const (
+	columnResourceSchemaURL  = "resource_schema_url"
+	columnResourceAttributes = "resource_attributes"
+	columnScopeName          = "scope_name"
+	columnScopeVersion       = "scope_version"
+	columnScopeSchemaURL     = "scope_schema_url"
+	columnScopeAttributes    = "scope_attributes"
+	columnAttributes         = "attributes"
+	columnDroppedAttributes  = "dropped_attributes_count"
+	columnFlags              = "flags"
+	columnTraceID            = "trace_id"
+	columnSpanID             = "span_id"
+)
+
+// reservedColumns are the common columns that promoted attributes must not override. The columns of the
+// signals don't start with the prefixes of the promoted columns.
+var reservedColumns = map[string]bool{
+	columnResourceSchemaURL:  true,
+	columnResourceAttributes: true,
+	columnScopeName:          true,
+	columnScopeVersion:       true,
+	columnScopeSchemaURL:     true,
+	columnScopeAttributes:    true,
+}
+
+func promotedColumnName(prefix, key string) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteByte('_')
+	for _, r := range key {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+// promotedColumn is an attribute stored in its own column.
+type promotedColumn struct {
+	Column string `json:"column"`
+	Level  string `json:"level"`
+	Key    string `json:"key"`
+}
+
+// attributeColumns stores the attributes of a level in a map column and promoted columns.
+type attributeColumns struct {
+	mapColumn string
+	promoted  []promotedColumn
+}
+
+func newAttributeColumns(mapColumn, level string, keys []string) attributeColumns {
+	ac := attributeColumns{mapColumn: mapColumn}
+	for _, key := range keys {
+		ac.promoted = append(ac.promoted, promotedColumn{
+			Column: promotedColumnName(level, key),
+			Level:  level,
+			Key:    key,
+		})
+	}
+	return ac
+}
+
+func (ac attributeColumns) addToSchema(group parquet.Group) {
+	group[ac.mapColumn] = parquet.Map(parquet.String(), parquet.String())
+	for _, p := range ac.promoted {
+		group[p.Column] = parquet.Optional(parquet.String())
+	}
+}
+
+// toRow stores the attributes in the row. Attribute values are stored as strings, maps and slices as JSON.
+func (ac attributeColumns) toRow(attrs pcommon.Map, row map[string]any) {
+	for _, p := range ac.promoted {
+		row[p.Column] = nil
+	}
+	m := make(map[string]any, attrs.Len())
+	for k, v := range attrs.All() {
+		promoted := false
+		for _, p := range ac.promoted {
+			if p.Key == k {
+				row[p.Column] = v.AsString()
+				promoted = true
+				break
+			}
+		}
+		if !promoted {
+			m[k] = v.AsString()
+		}
+	}
+	row[ac.mapColumn] = m
+}
+
+// fromRow restores the attributes, as strings, from the map column and the promoted columns.
+func (ac attributeColumns) fromRow(row map[string]any, attrs pcommon.Map) {
+	if m, ok := row[ac.mapColumn].(map[string]any); ok {
+		attrs.EnsureCapacity(len(m) + len(ac.promoted))
+		for k, v := range m {
+			attrs.PutStr(k, stringValue(v))
+		}
+	}
+	for _, p := range ac.promoted {
+		if v, ok := row[p.Column]; ok && v != nil {
+			attrs.PutStr(p.Key, stringValue(v))
+		}
+	}
+}
+
+// recordSchema holds the attribute columns of the three levels of a signal.
+type recordSchema struct {
+	resource attributeColumns
+	scope    attributeColumns
+	record   attributeColumns
+}
+
+func newRecordSchema(cfg PromotedAttributesConfig) recordSchema {
+	return recordSchema{
+		resource: newAttributeColumns(columnResourceAttributes, resourcePrefix, cfg.Resource),
+		scope:    newAttributeColumns(columnScopeAttributes, scopePrefix, cfg.Scope),
+		record:   newAttributeColumns(columnAttributes, recordPrefix, cfg.Record),
+	}
+}
+
+// recordSchemaFromFile returns the attribute columns of a file, using the promoted columns recorded in its metadata.
+func recordSchemaFromFile(f *parquet.File) (recordSchema, error) {
+	rs := newRecordSchema(PromotedAttributesConfig{})
+	value, ok := f.Lookup(promotedColumnsMetadataKey)
+	if !ok {
+		return rs, nil
+	}
+	var promoted []promotedColumn
+	if err := json.Unmarshal([]byte(value), &promoted); err != nil {
+		return rs, fmt.Errorf("invalid %s metadata: %w", promotedColumnsMetadataKey, err)
+	}
+	for _, p := range promoted {
+		switch p.Level {
+		case resourcePrefix:
+			rs.resource.promoted = append(rs.resource.promoted, p)
+		case scopePrefix:
+			rs.scope.promoted = append(rs.scope.promoted, p)
+		case recordPrefix:
+			rs.record.promoted = append(rs.record.promoted, p)
+		}
+	}
+	return rs, nil
+}
+
+// schema returns the parquet schema of the signal, made of its own columns and the common columns.
+func (rs recordSchema) schema(name string, columns parquet.Group) *parquet.Schema {
+	group := parquet.Group{
+		columnResourceSchemaURL: parquet.String(),
+		columnScopeName:         parquet.String(),
+		columnScopeVersion:      parquet.String(),
+		columnScopeSchemaURL:    parquet.String(),
+	}
+	for column, node := range columns {
+		group[column] = node
+	}
+	rs.resource.addToSchema(group)
+	rs.scope.addToSchema(group)
+	rs.record.addToSchema(group)
+	return parquet.NewSchema(name, group)
+}
+
+func (rs recordSchema) promoted() []promotedColumn {
+	promoted := make([]promotedColumn, 0, len(rs.resource.promoted)+len(rs.scope.promoted)+len(rs.record.promoted))
+	promoted = append(promoted, rs.resource.promoted...)
+	promoted = append(promoted, rs.scope.promoted...)
+	return append(promoted, rs.record.promoted...)
+}
+
+func (rs recordSchema) resourceToRow(resource pcommon.Resource, schemaURL string, row map[string]any) {
+	row[columnResourceSchemaURL] = schemaURL
+	rs.resource.toRow(resource.Attributes(), row)
+}
+
+func (rs recordSchema) scopeToRow(scope pcommon.InstrumentationScope, schemaURL string, row map[string]any) {
+	row[columnScopeName] = scope.Name()
+	row[columnScopeVersion] = scope.Version()
+	row[columnScopeSchemaURL] = schemaURL
+	rs.scope.toRow(scope.Attributes(), row)
+}
+
+// writerOptions returns the options of the writers of the files, recording the promoted columns in the metadata.
+func (rs recordSchema) writerOptions(schema *parquet.Schema, compression string) ([]parquet.WriterOption, error) {
+	options := []parquet.WriterOption{schema}
+	switch compression {
+	case compressionSnappy:
+		options = append(options, parquet.Compression(&parquet.Snappy))
+	case compressionGzip:
+		options = append(options, parquet.Compression(&parquet.Gzip))
+	case compressionZstd:
+		options = append(options, parquet.Compression(&parquet.Zstd))
+	default:
+		options = append(options, parquet.Compression(&parquet.Uncompressed))
+	}
+	if promoted := rs.promoted(); len(promoted) > 0 {
+		value, err := json.Marshal(promoted)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, parquet.KeyValueMetadata(promotedColumnsMetadataKey, string(value)))
+	}
+	return options, nil
+}
+
+// writeRows writes the rows to a parquet file.
+func writeRows(rows []map[string]any, options []parquet.WriterOption) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := parquet.NewGenericWriter[map[string]any](&buf, options...)
+	if _, err := writer.Write(rows); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readRows opens the parquet file and calls fn for every row.
+func readRows(buf []byte, fn func(rs recordSchema, row map[string]any)) error {
+	f, err := parquet.OpenFile(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return fmt.Errorf("failed to open parquet file: %w", err)
+	}
+	rs, err := recordSchemaFromFile(f)
+	if err != nil {
+		return err
+	}
+
+	reader := parquet.NewGenericReader[map[string]any](bytes.NewReader(buf), f.Schema())
+	defer reader.Close()
+	rows := make([]map[string]any, readBatchSize)
+	for {
+		for i := range rows {
+			rows[i] = map[string]any{}
+		}
+		n, err := reader.Read(rows)
+		for _, row := range rows[:n] {
+			fn(rs, row)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read parquet rows: %w", err)
+		}
+	}
+}
+
+// resourceKey identifies the resource of a row, to group the rows read back. fmt prints maps sorted by key.
+func resourceKey(row map[string]any) string {
+	return fmt.Sprint(levelColumns(row, resourcePrefix))
+}
+
+// scopeKey identifies the scope of a row within its resource.
+func scopeKey(row map[string]any) string {
+	return fmt.Sprint(levelColumns(row, scopePrefix))
+}
+
+// levelColumns returns the values of the columns of a level, including its promoted columns.
+func levelColumns(row map[string]any, level string) map[string]any {
+	columns := map[string]any{}
+	for column, v := range row {
+		if strings.HasPrefix(column, level+"_") {
+			columns[column] = v
+		}
+	}
+	return columns
+}
+
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func int64Value(v any) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case int32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case int:
+		return int64(v)
+	case time.Time:
+		return v.UnixNano()
+	default:
+		return 0
+	}
+}
+
+func float64Value(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+func boolValue(v any) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+func timestampValue(v any) pcommon.Timestamp {
+	return pcommon.Timestamp(int64Value(v))
+}
+
+func traceIDToString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func spanIDToString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func traceIDValue(v any) pcommon.TraceID {
+	var id pcommon.TraceID
+	b, err := hex.DecodeString(stringValue(v))
+	if err == nil && len(b) == len(id) {
+		copy(id[:], b)
+	}
+	return id
+}
+
+func spanIDValue(v any) pcommon.SpanID {
+	var id pcommon.SpanID
+	b, err := hex.DecodeString(stringValue(v))
+	if err == nil && len(b) == len(id) {
+		copy(id[:], b)
+	}
+	return id
+}
+
+// listValue returns the elements of a list column.
+func listValue(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+func float64List(v any) []float64 {
+	l := listValue(v)
+	values := make([]float64, len(l))
+	for i, e := range l {
+		values[i] = float64Value(e)
+	}
+	return values
+}
+
+func uint64List(v any) []uint64 {
+	l := listValue(v)
+	values := make([]uint64, len(l))
+	for i, e := range l {
+		values[i] = uint64(int64Value(e))
+	}
+	return values
+}
+
+func float64sToList(values []float64) []any {
+	l := make([]any, len(values))
+	for i, v := range values {
+		l[i] = v
+	}
+	return l
+}
+
+func uint64sToList(values []uint64) []any {
+	l := make([]any, len(values))
+	for i, v := range values {
+		l[i] = int64(v)
+	}
+	return l
+}
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"github.com/parquet-go/parquet-go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	columnParentSpanID  = "parent_span_id"
+	columnTraceState    = "trace_state"
+	columnName          = "name"
+	columnKind          = "kind"
+	columnStartTime     = "start_time"
+	columnEndTime       = "end_time"
+	columnDuration      = "duration"
+	columnStatusCode    = "status_code"
+	columnStatusMessage = "status_message"
+	columnEvents        = "events"
+	columnLinks         = "links"
+)
+
+var (
+	spanKinds = map[string]ptrace.SpanKind{
+		ptrace.SpanKindUnspecified.String(): ptrace.SpanKindUnspecified,
+		ptrace.SpanKindInternal.String():    ptrace.SpanKindInternal,
+		ptrace.SpanKindServer.String():      ptrace.SpanKindServer,
+		ptrace.SpanKindClient.String():      ptrace.SpanKindClient,
+		ptrace.SpanKindProducer.String():    ptrace.SpanKindProducer,
+		ptrace.SpanKindConsumer.String():    ptrace.SpanKindConsumer,
+	}
+	statusCodes = map[string]ptrace.StatusCode{
+		ptrace.StatusCodeUnset.String(): ptrace.StatusCodeUnset,
+		ptrace.StatusCodeOk.String():    ptrace.StatusCodeOk,
+		ptrace.StatusCodeError.String(): ptrace.StatusCodeError,
+	}
+)
+
+func spanColumns() parquet.Group {
+	return parquet.Group{
+		columnTraceID:           parquet.String(),
+		columnSpanID:            parquet.String(),
+		columnParentSpanID:      parquet.String(),
+		columnTraceState:        parquet.String(),
+		columnFlags:             parquet.Int(32),
+		columnName:              parquet.String(),
+		columnKind:              parquet.String(),
+		columnStartTime:         parquet.Timestamp(parquet.Nanosecond),
+		columnEndTime:           parquet.Timestamp(parquet.Nanosecond),
+		columnDuration:          parquet.Int(64),
+		columnStatusCode:        parquet.String(),
+		columnStatusMessage:     parquet.String(),
+		columnDroppedAttributes: parquet.Int(32),
+		columnEvents: parquet.List(parquet.Group{
+			columnTime:       parquet.Timestamp(parquet.Nanosecond),
+			columnName:       parquet.String(),
+			columnAttributes: parquet.Map(parquet.String(), parquet.String()),
+		}),
+		columnLinks: parquet.List(parquet.Group{
+			columnTraceID:    parquet.String(),
+			columnSpanID:     parquet.String(),
+			columnTraceState: parquet.String(),
+			columnAttributes: parquet.Map(parquet.String(), parquet.String()),
+		}),
+	}
+}
+
+// MarshalTraces writes a row per span, the events and links of the span being stored in list columns.
+func (ex *parquetExtension) MarshalTraces(traces ptrace.Traces) ([]byte, error) {
+	rows := make([]map[string]any, 0, traces.SpanCount())
+	for _, rs := range traces.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				events := make([]any, 0, span.Events().Len())
+				for _, event := range span.Events().All() {
+					events = append(events, map[string]any{
+						columnTime:       int64(event.Timestamp()),
+						columnName:       event.Name(),
+						columnAttributes: attributesToMap(event.Attributes()),
+					})
+				}
+				links := make([]any, 0, span.Links().Len())
+				for _, link := range span.Links().All() {
+					links = append(links, map[string]any{
+						columnTraceID:    traceIDToString(link.TraceID()),
+						columnSpanID:     spanIDToString(link.SpanID()),
+						columnTraceState: link.TraceState().AsRaw(),
+						columnAttributes: attributesToMap(link.Attributes()),
+					})
+				}
+				row := map[string]any{
+					columnTraceID:           traceIDToString(span.TraceID()),
+					columnSpanID:            spanIDToString(span.SpanID()),
+					columnParentSpanID:      spanIDToString(span.ParentSpanID()),
+					columnTraceState:        span.TraceState().AsRaw(),
+					columnFlags:             int32(span.Flags()),
+					columnName:              span.Name(),
+					columnKind:              span.Kind().String(),
+					columnStartTime:         int64(span.StartTimestamp()),
+					columnEndTime:           int64(span.EndTimestamp()),
+					columnDuration:          int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					columnStatusCode:        span.Status().Code().String(),
+					columnStatusMessage:     span.Status().Message(),
+					columnDroppedAttributes: int32(span.DroppedAttributesCount()),
+					columnEvents:            events,
+					columnLinks:             links,
+				}
+				ex.schema.resourceToRow(rs.Resource(), rs.SchemaUrl(), row)
+				ex.schema.scopeToRow(ss.Scope(), ss.SchemaUrl(), row)
+				ex.schema.record.toRow(span.Attributes(), row)
+				rows = append(rows, row)
+			}
+		}
+	}
+	return writeRows(rows, ex.tracesOptions)
+}
+
+// UnmarshalTraces reads the spans, grouped by resource and scope. Attributes are read as strings.
+func (*parquetExtension) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+	resources := map[string]ptrace.ResourceSpans{}
+	scopes := map[string]ptrace.ScopeSpans{}
+	err := readRows(buf, func(rs recordSchema, row map[string]any) {
+		rKey := resourceKey(row)
+		rspans, ok := resources[rKey]
+		if !ok {
+			rspans = traces.ResourceSpans().AppendEmpty()
+			rspans.SetSchemaUrl(stringValue(row[columnResourceSchemaURL]))
+			rs.resource.fromRow(row, rspans.Resource().Attributes())
+			resources[rKey] = rspans
+		}
+		sKey := rKey + scopeKey(row)
+		ss, ok := scopes[sKey]
+		if !ok {
+			ss = rspans.ScopeSpans().AppendEmpty()
+			ss.SetSchemaUrl(stringValue(row[columnScopeSchemaURL]))
+			ss.Scope().SetName(stringValue(row[columnScopeName]))
+			ss.Scope().SetVersion(stringValue(row[columnScopeVersion]))
+			rs.scope.fromRow(row, ss.Scope().Attributes())
+			scopes[sKey] = ss
+		}
+
+		span := ss.Spans().AppendEmpty()
+		span.SetTraceID(traceIDValue(row[columnTraceID]))
+		span.SetSpanID(spanIDValue(row[columnSpanID]))
+		span.SetParentSpanID(spanIDValue(row[columnParentSpanID]))
+		span.TraceState().FromRaw(stringValue(row[columnTraceState]))
+		span.SetFlags(uint32(int64Value(row[columnFlags])))
+		span.SetName(stringValue(row[columnName]))
+		span.SetKind(spanKinds[stringValue(row[columnKind])])
+		span.SetStartTimestamp(timestampValue(row[columnStartTime]))
+		span.SetEndTimestamp(timestampValue(row[columnEndTime]))
+		span.Status().SetCode(statusCodes[stringValue(row[columnStatusCode])])
+		span.Status().SetMessage(stringValue(row[columnStatusMessage]))
+		span.SetDroppedAttributesCount(uint32(int64Value(row[columnDroppedAttributes])))
+		rs.record.fromRow(row, span.Attributes())
+		for _, e := range listValue(row[columnEvents]) {
+			fields, _ := e.(map[string]any)
+			event := span.Events().AppendEmpty()
+			event.SetTimestamp(timestampValue(fields[columnTime]))
+			event.SetName(stringValue(fields[columnName]))
+			mapToAttributes(fields[columnAttributes], event.Attributes())
+		}
+		for _, l := range listValue(row[columnLinks]) {
+			fields, _ := l.(map[string]any)
+			link := span.Links().AppendEmpty()
+			link.SetTraceID(traceIDValue(fields[columnTraceID]))
+			link.SetSpanID(spanIDValue(fields[columnSpanID]))
+			link.TraceState().FromRaw(stringValue(fields[columnTraceState]))
+			mapToAttributes(fields[columnAttributes], link.Attributes())
+		}
+	})
+	return traces, err
+}
+
+// attributesToMap returns the attributes as a map column value, for attributes without promoted columns.
+func attributesToMap(attrs pcommon.Map) map[string]any {
+	m := make(map[string]any, attrs.Len())
+	for k, v := range attrs.All() {
+		m[k] = v.AsString()
+	}
+	return m
+}
+
+func mapToAttributes(v any, attrs pcommon.Map) {
+	m, _ := v.(map[string]any)
+	attrs.EnsureCapacity(len(m))
+	for k, v := range m {
+		attrs.PutStr(k, stringValue(v))
+	}
+}
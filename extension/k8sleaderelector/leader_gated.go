@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sleaderelector // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8sleaderelector"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// ComponentFactory creates a new instance of the component run by a leader gated component.
+type ComponentFactory func() (component.Component, error)
+
+// NewLeaderGatedComponent returns a component that only runs while the leader elector extension with the given
+// ID holds the leadership. A new instance is created with the factory and started every time the leadership is
+// acquired, and shut down when the leadership is lost, so that components that can't be restarted, such as
+// scraper controllers, can be used. Components resuming from persisted state, such as a storage extension shared
+// by all the collector instances, pick up where the previous leader stopped.
+func NewLeaderGatedComponent(electorID component.ID, logger *zap.Logger, factory ComponentFactory) component.Component {
+	return &leaderGatedComponent{
+		electorID: electorID,
+		logger:    logger,
+		factory:   factory,
+	}
+}
+
+type leaderGatedComponent struct {
+	electorID component.ID
+	logger    *zap.Logger
+	factory   ComponentFactory
+
+	mu      sync.Mutex
+	host    component.Host
+	current component.Component
+	stopped bool
+}
+
+func (lgc *leaderGatedComponent) Start(_ context.Context, host component.Host) error {
+	ext := host.GetExtensions()[lgc.electorID]
+	if ext == nil {
+		return fmt.Errorf("unknown k8s leader elector %q", lgc.electorID)
+	}
+	elector, ok := ext.(LeaderElection)
+	if !ok {
+		return fmt.Errorf("the extension %T is not implement k8sleaderelector.LeaderElection", ext)
+	}
+
+	lgc.mu.Lock()
+	lgc.host = host
+	lgc.mu.Unlock()
+
+	lgc.logger.Info("registering the component in leader election")
+	// The callbacks remain registered for the lifetime of the component, allowing it to start again when the
+	// leadership is regained.
+	elector.SetCallBackFuncs(lgc.startLeading, lgc.stopLeading)
+	return nil
+}
+
+func (lgc *leaderGatedComponent) startLeading(ctx context.Context) {
+	lgc.mu.Lock()
+	defer lgc.mu.Unlock()
+	if lgc.stopped || lgc.current != nil {
+		return
+	}
+
+	comp, err := lgc.factory()
+	if err != nil {
+		lgc.logger.Error("failed to create the component after acquiring leadership", zap.Error(err))
+		return
+	}
+	if err := comp.Start(ctx, lgc.host); err != nil {
+		lgc.logger.Error("failed to start the component after acquiring leadership", zap.Error(err))
+		if shutdownErr := comp.Shutdown(ctx); shutdownErr != nil {
+			lgc.logger.Error("failed to shut down the component", zap.Error(shutdownErr))
+		}
+		return
+	}
+	lgc.current = comp
+	lgc.logger.Info("component started as leader")
+}
+
+func (lgc *leaderGatedComponent) stopLeading() {
+	lgc.mu.Lock()
+	defer lgc.mu.Unlock()
+	lgc.logger.Info("no longer leader, stopping")
+	if err := lgc.shutdownCurrent(context.Background()); err != nil {
+		lgc.logger.Error("failed to shut down the component after losing leadership", zap.Error(err))
+	}
+}
+
+func (lgc *leaderGatedComponent) Shutdown(ctx context.Context) error {
+	lgc.mu.Lock()
+	defer lgc.mu.Unlock()
+	lgc.stopped = true
+	return lgc.shutdownCurrent(ctx)
+}
+
+func (lgc *leaderGatedComponent) shutdownCurrent(ctx context.Context) error {
+	if lgc.current == nil {
+		return nil
+	}
+	err := lgc.current.Shutdown(ctx)
+	lgc.current = nil
+	return err
+}
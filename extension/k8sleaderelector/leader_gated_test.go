@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sleaderelector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+type fakeElector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	onStart StartCallback
+	onStop  StopCallback
+}
+
+func (fe *fakeElector) SetCallBackFuncs(onStart StartCallback, onStop StopCallback) {
+	fe.onStart = onStart
+	fe.onStop = onStop
+}
+
+type electorHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (eh *electorHost) GetExtensions() map[component.ID]component.Component {
+	return eh.extensions
+}
+
+type countingComponent struct {
+	started  int
+	shutdown int
+	startErr error
+}
+
+func (cc *countingComponent) Start(context.Context, component.Host) error {
+	cc.started++
+	return cc.startErr
+}
+
+func (cc *countingComponent) Shutdown(context.Context) error {
+	cc.shutdown++
+	return nil
+}
+
+func TestLeaderGatedComponent(t *testing.T) {
+	electorID := component.MustNewID("k8s_leader_elector")
+	elector := &fakeElector{}
+	host := &electorHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{electorID: elector},
+	}
+
+	var instances []*countingComponent
+	lgc := NewLeaderGatedComponent(electorID, zap.NewNop(), func() (component.Component, error) {
+		cc := &countingComponent{}
+		instances = append(instances, cc)
+		return cc, nil
+	})
+	require.NoError(t, lgc.Start(t.Context(), host))
+	assert.Empty(t, instances, "nothing runs before the leadership is acquired")
+
+	elector.onStart(t.Context())
+	require.Len(t, instances, 1)
+	assert.Equal(t, 1, instances[0].started)
+	// Acquiring the leadership again while leading doesn't create another instance
+	elector.onStart(t.Context())
+	require.Len(t, instances, 1)
+
+	elector.onStop()
+	assert.Equal(t, 1, instances[0].shutdown)
+
+	// A new instance is created when the leadership is regained
+	elector.onStart(t.Context())
+	require.Len(t, instances, 2)
+	assert.Equal(t, 1, instances[1].started)
+
+	require.NoError(t, lgc.Shutdown(t.Context()))
+	assert.Equal(t, 1, instances[1].shutdown)
+
+	// The component doesn't start again after shutdown
+	elector.onStart(t.Context())
+	assert.Len(t, instances, 2)
+}
+
+func TestLeaderGatedComponentStartError(t *testing.T) {
+	electorID := component.MustNewID("k8s_leader_elector")
+	elector := &fakeElector{}
+	host := &electorHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{electorID: elector},
+	}
+
+	cc := &countingComponent{startErr: errors.New("start failed")}
+	lgc := NewLeaderGatedComponent(electorID, zap.NewNop(), func() (component.Component, error) {
+		return cc, nil
+	})
+	require.NoError(t, lgc.Start(t.Context(), host))
+	elector.onStart(t.Context())
+	assert.Equal(t, 1, cc.started)
+	assert.Equal(t, 1, cc.shutdown, "a component that failed to start is shut down")
+
+	elector.onStop()
+	assert.Equal(t, 1, cc.shutdown)
+	require.NoError(t, lgc.Shutdown(t.Context()))
+}
+
+func TestLeaderGatedComponentUnknownElector(t *testing.T) {
+	lgc := NewLeaderGatedComponent(component.MustNewID("k8s_leader_elector"), zap.NewNop(), func() (component.Component, error) {
+		return &countingComponent{}, nil
+	})
+	require.ErrorContains(t, lgc.Start(t.Context(), componenttest.NewNopHost()), "unknown k8s leader elector")
+
+	host := &electorHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.MustNewID("k8s_leader_elector"): &countingComponent{}},
+	}
+	require.ErrorContains(t, lgc.Start(t.Context(), host), "is not implement k8sleaderelector.LeaderElection")
+}
@@ -14,13 +14,19 @@ import (
 
 type FakeHost struct {
 	FakeLeaderElection *FakeLeaderElection
+	// Extensions are returned along with the fake leader elector, e.g. a storage extension.
+	Extensions map[component.ID]component.Component
 }
 
 func (fh *FakeHost) GetExtensions() map[component.ID]component.Component {
 	extID := component.MustNewID("k8s_leader_elector")
-	return map[component.ID]component.Component{
+	extensions := map[component.ID]component.Component{
 		extID: fh.FakeLeaderElection,
 	}
+	for id, ext := range fh.Extensions {
+		extensions[id] = ext
+	}
+	return extensions
 }
 
 func (*FakeHost) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
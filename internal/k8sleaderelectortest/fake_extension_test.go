@@ -20,6 +20,23 @@ func TestFakeHost_GetExtensions(t *testing.T) {
 	}
 }
 
+func TestFakeHost_GetExtensionsWithExtraExtensions(t *testing.T) {
+	fakeLE := &FakeLeaderElection{}
+	storageID := component.MustNewID("file_storage")
+	storageExt := &FakeLeaderElection{}
+	host := &FakeHost{
+		FakeLeaderElection: fakeLE,
+		Extensions:         map[component.ID]component.Component{storageID: storageExt},
+	}
+	exts := host.GetExtensions()
+	if exts[component.MustNewID("k8s_leader_elector")] != fakeLE {
+		t.Errorf("expected extension to be fakeLE, got %v", exts[component.MustNewID("k8s_leader_elector")])
+	}
+	if exts[storageID] != storageExt {
+		t.Errorf("expected extension to be storageExt, got %v", exts[storageID])
+	}
+}
+
 func TestFakeHost_GetExporters(t *testing.T) {
 	host := &FakeHost{}
 	if host.GetExporters() != nil {
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// MissingAttributeValue replaces the references to the attributes missing from a resource.
+const MissingAttributeValue = "unknown"
+
+// DestinationTemplate is a destination, e.g. a topic or a subject, referencing resource
+// attributes with %{<attribute>}.
+type DestinationTemplate struct {
+	parts    []templatePart
+	replacer *strings.Replacer
+}
+
+type templatePart struct {
+	literal string
+	// attribute is the referenced resource attribute, if any.
+	attribute string
+}
+
+// ParseDestinationTemplate parses the destination of the given kind, e.g. "topic".
+// The attribute values are escaped with replacer when rendered, and validate checks
+// the destination rendered for a resource without attributes.
+func ParseDestinationTemplate(kind, destination string, replacer *strings.Replacer, validate func(string) error) (*DestinationTemplate, error) {
+	if destination == "" {
+		return nil, fmt.Errorf("%s must not be empty", kind)
+	}
+	t := &DestinationTemplate{replacer: replacer}
+	rest := destination
+	for rest != "" {
+		start := strings.Index(rest, "%{")
+		if start < 0 {
+			t.parts = append(t.parts, templatePart{literal: rest})
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated attribute reference in %s %q", kind, destination)
+		}
+		attribute := rest[start+2 : start+end]
+		if attribute == "" {
+			return nil, fmt.Errorf("empty attribute reference in %s %q", kind, destination)
+		}
+		if start > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:start]})
+		}
+		t.parts = append(t.parts, templatePart{attribute: attribute})
+		rest = rest[start+end+1:]
+	}
+	if err := validate(t.Render(pcommon.NewMap())); err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", kind, destination, err)
+	}
+	return t, nil
+}
+
+// IsStatic returns whether the template doesn't reference any attribute.
+func (t *DestinationTemplate) IsStatic() bool {
+	return len(t.parts) == 1 && t.parts[0].attribute == ""
+}
+
+// Render returns the destination for a resource with the given attributes.
+func (t *DestinationTemplate) Render(attributes pcommon.Map) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.attribute == "" {
+			b.WriteString(part.literal)
+			continue
+		}
+		value := MissingAttributeValue
+		if v, ok := attributes.Get(part.attribute); ok && v.AsString() != "" {
+			value = t.replacer.Replace(v.AsString())
+		}
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+// Destination holds the data sent to a destination.
+type Destination[T any] struct {
+	Name string
+	Data T
+}
+
+// GroupTraces groups the resources of the traces by their destination, in the order
+// of their first occurrence.
+func GroupTraces(td ptrace.Traces, template *DestinationTemplate) []Destination[ptrace.Traces] {
+	return group(td, template, td.ResourceSpans().All(), ptrace.NewTraces,
+		func(rs ptrace.ResourceSpans, dest ptrace.Traces) { rs.CopyTo(dest.ResourceSpans().AppendEmpty()) })
+}
+
+// RemainingTraces returns the traces of the destinations.
+func RemainingTraces(destinations []Destination[ptrace.Traces]) ptrace.Traces {
+	return remaining(destinations, ptrace.NewTraces,
+		func(src, dest ptrace.Traces) { src.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans()) })
+}
+
+// GroupMetrics groups the resources of the metrics by their destination, in the order
+// of their first occurrence.
+func GroupMetrics(md pmetric.Metrics, template *DestinationTemplate) []Destination[pmetric.Metrics] {
+	return group(md, template, md.ResourceMetrics().All(), pmetric.NewMetrics,
+		func(rm pmetric.ResourceMetrics, dest pmetric.Metrics) {
+			rm.CopyTo(dest.ResourceMetrics().AppendEmpty())
+		})
+}
+
+// RemainingMetrics returns the metrics of the destinations.
+func RemainingMetrics(destinations []Destination[pmetric.Metrics]) pmetric.Metrics {
+	return remaining(destinations, pmetric.NewMetrics,
+		func(src, dest pmetric.Metrics) { src.ResourceMetrics().MoveAndAppendTo(dest.ResourceMetrics()) })
+}
+
+// GroupLogs groups the resources of the logs by their destination, in the order of
+// their first occurrence.
+func GroupLogs(ld plog.Logs, template *DestinationTemplate) []Destination[plog.Logs] {
+	return group(ld, template, ld.ResourceLogs().All(), plog.NewLogs,
+		func(rl plog.ResourceLogs, dest plog.Logs) { rl.CopyTo(dest.ResourceLogs().AppendEmpty()) })
+}
+
+// RemainingLogs returns the logs of the destinations.
+func RemainingLogs(destinations []Destination[plog.Logs]) plog.Logs {
+	return remaining(destinations, plog.NewLogs,
+		func(src, dest plog.Logs) { src.ResourceLogs().MoveAndAppendTo(dest.ResourceLogs()) })
+}
+
+type resource interface {
+	Resource() pcommon.Resource
+}
+
+func group[T any, R resource](data T, template *DestinationTemplate, resources iter.Seq2[int, R], newData func() T, appendResource func(R, T)) []Destination[T] {
+	if template.IsStatic() {
+		return []Destination[T]{{Name: template.Render(pcommon.NewMap()), Data: data}}
+	}
+	var destinations []Destination[T]
+	index := map[string]int{}
+	for _, r := range resources {
+		name := template.Render(r.Resource().Attributes())
+		i, ok := index[name]
+		if !ok {
+			i = len(destinations)
+			index[name] = i
+			destinations = append(destinations, Destination[T]{Name: name, Data: newData()})
+		}
+		appendResource(r, destinations[i].Data)
+	}
+	return destinations
+}
+
+func remaining[T any](destinations []Destination[T], newData func() T, moveAndAppend func(src, dest T)) T {
+	if len(destinations) == 1 {
+		return destinations[0].Data
+	}
+	data := newData()
+	for _, destination := range destinations {
+		moveAndAppend(destination.Data, data)
+	}
+	return data
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var testReplacer = strings.NewReplacer("/", "_")
+
+func validateTestDestination(destination string) error {
+	if strings.Contains(destination, "//") {
+		return errors.New("empty levels are not allowed")
+	}
+	return nil
+}
+
+func parseTestTemplate(t *testing.T, destination string) *DestinationTemplate {
+	template, err := ParseDestinationTemplate("path", destination, testReplacer, validateTestDestination)
+	require.NoError(t, err)
+	return template
+}
+
+func TestParseDestinationTemplate(t *testing.T) {
+	tests := []struct {
+		destination string
+		static      bool
+		err         string
+	}{
+		{destination: "otlp/logs", static: true},
+		{destination: "otlp/%{service.name}"},
+		{destination: "%{service.namespace}-%{service.name}/logs"},
+		{destination: "", err: "path must not be empty"},
+		{destination: "otlp/%{}", err: `empty attribute reference in path "otlp/%{}"`},
+		{destination: "otlp/%{service.name", err: `unterminated attribute reference in path "otlp/%{service.name"`},
+		{destination: "otlp//logs", err: `invalid path "otlp//logs": empty levels are not allowed`},
+		// The references are validated as rendered for a resource without attributes.
+		{destination: "otlp/%{service.name}//", err: `invalid path "otlp/%{service.name}//": empty levels are not allowed`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			template, err := ParseDestinationTemplate("path", tt.destination, testReplacer, validateTestDestination)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.static, template.IsStatic())
+		})
+	}
+}
+
+func TestRenderDestination(t *testing.T) {
+	template := parseTestTemplate(t, "otlp/%{service.namespace}/%{service.name}")
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("service.namespace", "shop")
+	attributes.PutStr("service.name", "check/out")
+	assert.Equal(t, "otlp/shop/check_out", template.Render(attributes))
+
+	// Missing and empty attributes are rendered as "unknown".
+	attributes = pcommon.NewMap()
+	attributes.PutStr("service.name", "")
+	assert.Equal(t, "otlp/unknown/unknown", template.Render(attributes))
+
+	attributes.PutInt("service.name", 42)
+	assert.Equal(t, "otlp/unknown/42", template.Render(attributes))
+}
+
+func TestGroupTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"cart", "payment", "cart"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(service)
+	}
+
+	destinations := GroupTraces(td, parseTestTemplate(t, "otlp/%{service.name}"))
+	require.Len(t, destinations, 2)
+	assert.Equal(t, "otlp/cart", destinations[0].Name)
+	assert.Equal(t, 2, destinations[0].Data.ResourceSpans().Len())
+	assert.Equal(t, "otlp/payment", destinations[1].Name)
+	assert.Equal(t, 1, destinations[1].Data.ResourceSpans().Len())
+
+	assert.Equal(t, 1, RemainingTraces(destinations[1:]).SpanCount())
+	assert.Equal(t, 3, RemainingTraces(destinations).SpanCount())
+
+	// A static template sends the data as is.
+	destinations = GroupTraces(td, parseTestTemplate(t, "otlp/traces"))
+	require.Len(t, destinations, 1)
+	assert.Equal(t, "otlp/traces", destinations[0].Name)
+	assert.Equal(t, td, destinations[0].Data)
+	assert.Equal(t, td, RemainingTraces(destinations))
+}
+
+func TestGroupMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"cart", "payment", "cart"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	}
+
+	destinations := GroupMetrics(md, parseTestTemplate(t, "otlp/%{service.name}"))
+	require.Len(t, destinations, 2)
+	assert.Equal(t, "otlp/cart", destinations[0].Name)
+	assert.Equal(t, 2, destinations[0].Data.ResourceMetrics().Len())
+	assert.Equal(t, "otlp/payment", destinations[1].Name)
+	assert.Equal(t, 1, destinations[1].Data.ResourceMetrics().Len())
+
+	assert.Equal(t, 1, RemainingMetrics(destinations[1:]).DataPointCount())
+	assert.Equal(t, 3, RemainingMetrics(destinations).DataPointCount())
+}
+
+func TestGroupLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, service := range []string{"cart", "payment", "cart"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(service)
+	}
+
+	destinations := GroupLogs(ld, parseTestTemplate(t, "otlp/%{service.name}"))
+	require.Len(t, destinations, 2)
+	assert.Equal(t, "otlp/cart", destinations[0].Name)
+	assert.Equal(t, 2, destinations[0].Data.ResourceLogs().Len())
+	assert.Equal(t, "otlp/payment", destinations[1].Name)
+	assert.Equal(t, 1, destinations[1].Data.ResourceLogs().Len())
+
+	assert.Equal(t, 1, RemainingLogs(destinations[1:]).LogRecordCount())
+	assert.Equal(t, 3, RemainingLogs(destinations).LogRecordCount())
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package messaging holds the encoding of the OTLP data, and the handling of the
+// received data, shared by the components of the message brokers.
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// EncodingOTLPProto is the encoding of the OTLP protobuf messages.
+	EncodingOTLPProto = "otlp_proto"
+	// EncodingOTLPJSON is the encoding of the OTLP JSON messages.
+	EncodingOTLPJSON = "otlp_json"
+)
+
+// ErrUnknownEncodingExtension is returned when the encoding is neither an OTLP encoding
+// nor the ID of an extension of the collector.
+var ErrUnknownEncodingExtension = errors.New("unknown encoding extension")
+
+// NewTracesMarshaler returns the marshaler of the OTLP encoding, or of the encoding extension.
+func NewTracesMarshaler(encoding string, host component.Host) (ptrace.Marshaler, error) {
+	switch encoding {
+	case EncodingOTLPProto:
+		return &ptrace.ProtoMarshaler{}, nil
+	case EncodingOTLPJSON:
+		return &ptrace.JSONMarshaler{}, nil
+	}
+	return loadEncodingExtension[ptrace.Marshaler](host, encoding, "traces marshaler")
+}
+
+// NewMetricsMarshaler returns the marshaler of the OTLP encoding, or of the encoding extension.
+func NewMetricsMarshaler(encoding string, host component.Host) (pmetric.Marshaler, error) {
+	switch encoding {
+	case EncodingOTLPProto:
+		return &pmetric.ProtoMarshaler{}, nil
+	case EncodingOTLPJSON:
+		return &pmetric.JSONMarshaler{}, nil
+	}
+	return loadEncodingExtension[pmetric.Marshaler](host, encoding, "metrics marshaler")
+}
+
+// NewLogsMarshaler returns the marshaler of the OTLP encoding, or of the encoding extension.
+func NewLogsMarshaler(encoding string, host component.Host) (plog.Marshaler, error) {
+	switch encoding {
+	case EncodingOTLPProto:
+		return &plog.ProtoMarshaler{}, nil
+	case EncodingOTLPJSON:
+		return &plog.JSONMarshaler{}, nil
+	}
+	return loadEncodingExtension[plog.Marshaler](host, encoding, "logs marshaler")
+}
+
+// NewTracesUnmarshaler returns the unmarshaler of the OTLP encoding, or of the encoding extension.
+func NewTracesUnmarshaler(encoding string, host component.Host) (ptrace.Unmarshaler, error) {
+	switch encoding {
+	case EncodingOTLPProto:
+		return &ptrace.ProtoUnmarshaler{}, nil
+	case EncodingOTLPJSON:
+		return &ptrace.JSONUnmarshaler{}, nil
+	}
+	return loadEncodingExtension[ptrace.Unmarshaler](host, encoding, "traces unmarshaler")
+}
+
+// NewMetricsUnmarshaler returns the unmarshaler of the OTLP encoding, or of the encoding extension.
+func NewMetricsUnmarshaler(encoding string, host component.Host) (pmetric.Unmarshaler, error) {
+	switch encoding {
+	case EncodingOTLPProto:
+		return &pmetric.ProtoUnmarshaler{}, nil
+	case EncodingOTLPJSON:
+		return &pmetric.JSONUnmarshaler{}, nil
+	}
+	return loadEncodingExtension[pmetric.Unmarshaler](host, encoding, "metrics unmarshaler")
+}
+
+// NewLogsUnmarshaler returns the unmarshaler of the OTLP encoding, or of the encoding extension.
+func NewLogsUnmarshaler(encoding string, host component.Host) (plog.Unmarshaler, error) {
+	switch encoding {
+	case EncodingOTLPProto:
+		return &plog.ProtoUnmarshaler{}, nil
+	case EncodingOTLPJSON:
+		return &plog.JSONUnmarshaler{}, nil
+	}
+	return loadEncodingExtension[plog.Unmarshaler](host, encoding, "logs unmarshaler")
+}
+
+// loadEncodingExtension tries to load an available extension for the given encoding.
+func loadEncodingExtension[T any](host component.Host, encoding, kind string) (T, error) {
+	var zero T
+	var extensionID component.ID
+	if err := extensionID.UnmarshalText([]byte(encoding)); err != nil {
+		return zero, fmt.Errorf("invalid encoding %q: %w", encoding, err)
+	}
+	encodingExtension, ok := host.GetExtensions()[extensionID]
+	if !ok {
+		return zero, fmt.Errorf("invalid encoding %q: %w", encoding, ErrUnknownEncodingExtension)
+	}
+	extension, ok := encodingExtension.(T)
+	if !ok {
+		return zero, fmt.Errorf("extension %q is not a %s", encoding, kind)
+	}
+	return extension, nil
+}
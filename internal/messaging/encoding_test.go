@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logsEncodingExtension encodes the body of the first log record as is.
+type logsEncodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (logsEncodingExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return []byte(ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str()), nil
+}
+
+func (logsEncodingExtension) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(string(data))
+	return ld, nil
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (h extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "test")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message")
+	return ld
+}
+
+func TestOTLPEncodings(t *testing.T) {
+	for _, encoding := range []string{EncodingOTLPProto, EncodingOTLPJSON} {
+		t.Run(encoding, func(t *testing.T) {
+			host := componenttest.NewNopHost()
+			_, err := NewTracesMarshaler(encoding, host)
+			require.NoError(t, err)
+			_, err = NewTracesUnmarshaler(encoding, host)
+			require.NoError(t, err)
+			_, err = NewMetricsMarshaler(encoding, host)
+			require.NoError(t, err)
+			_, err = NewMetricsUnmarshaler(encoding, host)
+			require.NoError(t, err)
+
+			marshaler, err := NewLogsMarshaler(encoding, host)
+			require.NoError(t, err)
+			unmarshaler, err := NewLogsUnmarshaler(encoding, host)
+			require.NoError(t, err)
+			data, err := marshaler.MarshalLogs(testLogs())
+			require.NoError(t, err)
+			ld, err := unmarshaler.UnmarshalLogs(data)
+			require.NoError(t, err)
+			assert.Equal(t, testLogs(), ld)
+		})
+	}
+}
+
+func TestEncodingExtension(t *testing.T) {
+	host := extensionsHost{component.MustNewID("text_encoding"): logsEncodingExtension{}}
+
+	marshaler, err := NewLogsMarshaler("text_encoding", host)
+	require.NoError(t, err)
+	data, err := marshaler.MarshalLogs(testLogs())
+	require.NoError(t, err)
+	assert.Equal(t, "message", string(data))
+
+	unmarshaler, err := NewLogsUnmarshaler("text_encoding", host)
+	require.NoError(t, err)
+	ld, err := unmarshaler.UnmarshalLogs(data)
+	require.NoError(t, err)
+	assert.Equal(t, "message", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	_, err = NewTracesMarshaler("text_encoding", host)
+	assert.EqualError(t, err, `extension "text_encoding" is not a traces marshaler`)
+	_, err = NewMetricsUnmarshaler("text_encoding", host)
+	assert.EqualError(t, err, `extension "text_encoding" is not a metrics unmarshaler`)
+
+	_, err = NewLogsUnmarshaler("unknown", host)
+	assert.ErrorIs(t, err, ErrUnknownEncodingExtension)
+	_, err = NewLogsMarshaler("invalid/", host)
+	assert.ErrorContains(t, err, `invalid encoding "invalid/"`)
+}
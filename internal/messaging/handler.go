@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// SignalHandler unmarshals the data of the messages of a signal type, and passes it
+// to the next consumer.
+type SignalHandler interface {
+	// Start loads the unmarshaler of the encoding.
+	Start(host component.Host, encoding string) error
+
+	// Handle unmarshals the data, sets the attributes on its resources, and passes it to
+	// the next consumer. Data failing to be unmarshaled returns a permanent error.
+	Handle(ctx context.Context, obsrecv *receiverhelper.ObsReport, data []byte, attrs map[string]string) error
+}
+
+// NewTracesHandler returns the handler of the messages holding traces.
+func NewTracesHandler(next consumer.Traces) SignalHandler {
+	return &tracesHandler{consumer: next}
+}
+
+// NewMetricsHandler returns the handler of the messages holding metrics.
+func NewMetricsHandler(next consumer.Metrics) SignalHandler {
+	return &metricsHandler{consumer: next}
+}
+
+// NewLogsHandler returns the handler of the messages holding logs.
+func NewLogsHandler(next consumer.Logs) SignalHandler {
+	return &logsHandler{consumer: next}
+}
+
+func putAttributes(resource pcommon.Resource, attrs map[string]string) {
+	for key, value := range attrs {
+		resource.Attributes().PutStr(key, value)
+	}
+}
+
+type tracesHandler struct {
+	consumer    consumer.Traces
+	unmarshaler ptrace.Unmarshaler
+	encoding    string
+}
+
+func (h *tracesHandler) Start(host component.Host, encoding string) error {
+	unmarshaler, err := NewTracesUnmarshaler(encoding, host)
+	if err != nil {
+		return err
+	}
+	h.unmarshaler, h.encoding = unmarshaler, encoding
+	return nil
+}
+
+func (h *tracesHandler) Handle(ctx context.Context, obsrecv *receiverhelper.ObsReport, data []byte, attrs map[string]string) error {
+	obsCtx := obsrecv.StartTracesOp(ctx)
+	traces, err := h.unmarshaler.UnmarshalTraces(data)
+	if err != nil {
+		obsrecv.EndTracesOp(obsCtx, h.encoding, 0, err)
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal traces: %w", err))
+	}
+	for _, rs := range traces.ResourceSpans().All() {
+		putAttributes(rs.Resource(), attrs)
+	}
+	err = h.consumer.ConsumeTraces(obsCtx, traces)
+	obsrecv.EndTracesOp(obsCtx, h.encoding, traces.SpanCount(), err)
+	return err
+}
+
+type metricsHandler struct {
+	consumer    consumer.Metrics
+	unmarshaler pmetric.Unmarshaler
+	encoding    string
+}
+
+func (h *metricsHandler) Start(host component.Host, encoding string) error {
+	unmarshaler, err := NewMetricsUnmarshaler(encoding, host)
+	if err != nil {
+		return err
+	}
+	h.unmarshaler, h.encoding = unmarshaler, encoding
+	return nil
+}
+
+func (h *metricsHandler) Handle(ctx context.Context, obsrecv *receiverhelper.ObsReport, data []byte, attrs map[string]string) error {
+	obsCtx := obsrecv.StartMetricsOp(ctx)
+	metrics, err := h.unmarshaler.UnmarshalMetrics(data)
+	if err != nil {
+		obsrecv.EndMetricsOp(obsCtx, h.encoding, 0, err)
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal metrics: %w", err))
+	}
+	for _, rm := range metrics.ResourceMetrics().All() {
+		putAttributes(rm.Resource(), attrs)
+	}
+	err = h.consumer.ConsumeMetrics(obsCtx, metrics)
+	obsrecv.EndMetricsOp(obsCtx, h.encoding, metrics.DataPointCount(), err)
+	return err
+}
+
+type logsHandler struct {
+	consumer    consumer.Logs
+	unmarshaler plog.Unmarshaler
+	encoding    string
+}
+
+func (h *logsHandler) Start(host component.Host, encoding string) error {
+	unmarshaler, err := NewLogsUnmarshaler(encoding, host)
+	if err != nil {
+		return err
+	}
+	h.unmarshaler, h.encoding = unmarshaler, encoding
+	return nil
+}
+
+func (h *logsHandler) Handle(ctx context.Context, obsrecv *receiverhelper.ObsReport, data []byte, attrs map[string]string) error {
+	obsCtx := obsrecv.StartLogsOp(ctx)
+	logs, err := h.unmarshaler.UnmarshalLogs(data)
+	if err != nil {
+		obsrecv.EndLogsOp(obsCtx, h.encoding, 0, err)
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal logs: %w", err))
+	}
+	for _, rl := range logs.ResourceLogs().All() {
+		putAttributes(rl.Resource(), attrs)
+	}
+	err = h.consumer.ConsumeLogs(obsCtx, logs)
+	obsrecv.EndLogsOp(obsCtx, h.encoding, logs.LogRecordCount(), err)
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newObsReport(t *testing.T) *receiverhelper.ObsReport {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             component.MustNewID("test"),
+		Transport:              "test",
+		ReceiverCreateSettings: receivertest.NewNopSettings(component.MustNewType("test")),
+	})
+	require.NoError(t, err)
+	return obsrecv
+}
+
+func TestHandleTraces(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	handler := NewTracesHandler(sink)
+	require.NoError(t, handler.Start(componenttest.NewNopHost(), EncodingOTLPProto))
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(t.Context(), newObsReport(t), data, nil))
+
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, traces, sink.AllTraces()[0])
+}
+
+func TestHandleMetrics(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	handler := NewMetricsHandler(sink)
+	require.NoError(t, handler.Start(componenttest.NewNopHost(), EncodingOTLPJSON))
+
+	metrics := pmetric.NewMetrics()
+	m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("temperature")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(21.5)
+	data, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(t.Context(), newObsReport(t), data, map[string]string{"zone": "north"}))
+
+	expected := pmetric.NewMetrics()
+	metrics.CopyTo(expected)
+	expected.ResourceMetrics().At(0).Resource().Attributes().PutStr("zone", "north")
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, expected, sink.AllMetrics()[0])
+}
+
+func TestHandleLogs(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	handler := NewLogsHandler(sink)
+	require.NoError(t, handler.Start(componenttest.NewNopHost(), EncodingOTLPProto))
+
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs())
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(t.Context(), newObsReport(t), data, map[string]string{"service.name": "overridden"}))
+
+	expected := testLogs()
+	expected.ResourceLogs().At(0).Resource().Attributes().PutStr("service.name", "overridden")
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, expected, sink.AllLogs()[0])
+}
+
+func TestHandleErrors(t *testing.T) {
+	obsrecv := newObsReport(t)
+
+	handler := NewLogsHandler(consumertest.NewNop())
+	assert.ErrorIs(t, handler.Start(componenttest.NewNopHost(), "unknown"), ErrUnknownEncodingExtension)
+
+	// Data failing to be unmarshaled is never consumed again.
+	require.NoError(t, handler.Start(componenttest.NewNopHost(), EncodingOTLPProto))
+	err := handler.Handle(t.Context(), obsrecv, []byte{0x0a, 0xff}, nil)
+	require.ErrorContains(t, err, "failed to unmarshal logs")
+	assert.True(t, consumererror.IsPermanent(err))
+
+	// The errors of the next consumer are returned as is.
+	consumeErr := errors.New("consume error")
+	handler = NewLogsHandler(consumertest.NewErr(consumeErr))
+	require.NoError(t, handler.Start(componenttest.NewNopHost(), EncodingOTLPProto))
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs())
+	require.NoError(t, err)
+	err = handler.Handle(t.Context(), obsrecv, data, nil)
+	assert.ErrorIs(t, err, consumeErr)
+	assert.False(t, consumererror.IsPermanent(err))
+}
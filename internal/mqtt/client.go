@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// Message is a message received from, or published to, the broker.
+type Message struct {
+	Topic    string
+	Payload  []byte
+	QoS      byte
+	Retained bool
+}
+
+// Subscription is a topic filter subscribed to, with the maximum QoS of the messages
+// delivered by the broker.
+type Subscription struct {
+	Filter string
+	QoS    byte
+}
+
+// MessageHandler handles the messages received on the subscriptions. The messages
+// are acknowledged once the handler returns.
+type MessageHandler func(Message)
+
+// ConnectOptions holds the settings of a connection specific to its user.
+type ConnectOptions struct {
+	// ClientIDSuffix is appended to the configured client ID, so that each signal type
+	// has its own session.
+	ClientIDSuffix string
+
+	// Subscriptions are subscribed to on every connection, their messages being passed
+	// to Handler.
+	Subscriptions []Subscription
+	Handler       MessageHandler
+}
+
+// Client is a connection to an MQTT broker, which is reconnected when it is lost.
+type Client interface {
+	// Publish publishes the message, and waits for the acknowledgement of the broker
+	// when its QoS is 1 or 2.
+	Publish(ctx context.Context, msg Message) error
+
+	// Disconnect closes the connection.
+	Disconnect(ctx context.Context) error
+}
+
+// Connect connects to the broker with the protocol version of the configuration, and
+// subscribes to the subscriptions of the options.
+func Connect(ctx context.Context, cfg ClientConfig, host component.Host, logger *zap.Logger, opts ConnectOptions) (Client, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS != nil {
+		var err error
+		if tlsConfig, err = cfg.TLS.LoadTLSConfig(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+	}
+	creds, err := newCredentialsProvider(ctx, cfg, host)
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := cfg.clientID(opts.ClientIDSuffix)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.With(zap.String("endpoint", cfg.Endpoint), zap.String("client_id", clientID))
+
+	if cfg.ProtocolVersion == ProtocolVersion5 {
+		return connectV5(ctx, cfg, clientID, tlsConfig, creds, logger, opts)
+	}
+	return connectV311(cfg, clientID, tlsConfig, creds, logger, opts)
+}
+
+// clientID returns the configured client ID with the suffix, or a random client ID.
+func (c ClientConfig) clientID(suffix string) (string, error) {
+	if c.ClientID != "" {
+		if suffix == "" {
+			return c.ClientID, nil
+		}
+		return c.ClientID + "-" + suffix, nil
+	}
+	// MQTT 3.1.1 brokers only have to accept client IDs of up to 23 characters.
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate client ID: %w", err)
+	}
+	return "otelcol-" + hex.EncodeToString(b), nil
+}
+
+// credentialsProvider returns the username and password of a connection.
+type credentialsProvider func(ctx context.Context) (username, password string, err error)
+
+// newCredentialsProvider returns the credentials of the authenticator extension, or the
+// configured username and password.
+func newCredentialsProvider(ctx context.Context, cfg ClientConfig, host component.Host) (credentialsProvider, error) {
+	if !cfg.Auth.HasValue() {
+		return func(context.Context) (string, string, error) {
+			return cfg.Username, string(cfg.Password), nil
+		}, nil
+	}
+	authenticator, err := cfg.Auth.Get().GetGRPCClientAuthenticator(ctx, host.GetExtensions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load authenticator: %w", err)
+	}
+	perRPCCredentials, err := authenticator.PerRPCCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load authenticator: %w", err)
+	}
+	return func(ctx context.Context) (string, string, error) {
+		metadata, err := perRPCCredentials.GetRequestMetadata(ctx, cfg.Endpoint)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get credentials: %w", err)
+		}
+		for key, value := range metadata {
+			if strings.EqualFold(key, "authorization") {
+				return parseAuthorization(cfg.Username, value)
+			}
+		}
+		return "", "", errors.New("authenticator did not provide an authorization")
+	}, nil
+}
+
+// parseAuthorization returns the credentials of a basic authorization, or the bearer
+// token as password.
+func parseAuthorization(username, authorization string) (string, string, error) {
+	scheme, value, _ := strings.Cut(authorization, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid basic authorization: %w", err)
+		}
+		user, password, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return "", "", errors.New("invalid basic authorization: missing password")
+		}
+		return user, password, nil
+	case "bearer":
+		return username, value, nil
+	default:
+		return "", "", fmt.Errorf("unsupported authorization scheme %q", scheme)
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+)
+
+// runBroker starts an in-process broker, accepting the clients authorized by the ledger,
+// or all the clients when it is nil.
+func runBroker(t *testing.T, ledger *auth.Ledger) string {
+	broker := mochi.New(&mochi.Options{Logger: slog.New(slog.DiscardHandler)})
+	if ledger == nil {
+		require.NoError(t, broker.AddHook(new(auth.AllowHook), nil))
+	} else {
+		require.NoError(t, broker.AddHook(new(auth.Hook), &auth.Options{Ledger: ledger}))
+	}
+	tcp := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	require.NoError(t, broker.AddListener(tcp))
+	require.NoError(t, broker.Serve())
+	t.Cleanup(func() { _ = broker.Close() })
+	return "tcp://" + tcp.Address()
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*ClientConfig)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*ClientConfig) {},
+		},
+		{
+			name:   "missing endpoint",
+			modify: func(c *ClientConfig) { c.Endpoint = "" },
+			err:    "endpoint is required",
+		},
+		{
+			name:   "unsupported scheme",
+			modify: func(c *ClientConfig) { c.Endpoint = "http://localhost:1883" },
+			err:    `unsupported endpoint scheme "http"`,
+		},
+		{
+			name:   "invalid protocol version",
+			modify: func(c *ClientConfig) { c.ProtocolVersion = "3.1" },
+			err:    `protocol_version must be either "3.1.1" or "5"`,
+		},
+		{
+			name: "invalid durations",
+			modify: func(c *ClientConfig) {
+				c.KeepAlive = 24 * time.Hour
+				c.ConnectTimeout = 0
+			},
+			err: "keep_alive must be between 0 and 18h12m15s\nconnect_timeout must be positive",
+		},
+		{
+			name:   "persistent session without client ID",
+			modify: func(c *ClientConfig) { c.CleanSession = false },
+			err:    "client_id is required when clean_session is false",
+		},
+		{
+			name:   "password without username",
+			modify: func(c *ClientConfig) { c.Password = "password" },
+			err:    "password requires username with protocol_version 3.1.1",
+		},
+		{
+			name: "password without username with MQTT 5",
+			modify: func(c *ClientConfig) {
+				c.ProtocolVersion = ProtocolVersion5
+				c.Password = "password"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			tt.modify(&cfg)
+			if tt.err == "" {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), tt.err)
+			}
+		})
+	}
+}
+
+type messageSink struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (s *messageSink) handle(msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+}
+
+func (s *messageSink) all() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	for _, version := range []string{ProtocolVersion311, ProtocolVersion5} {
+		t.Run(version, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			cfg.Endpoint = runBroker(t, nil)
+			cfg.ProtocolVersion = version
+			cfg.ClientID = "collector"
+
+			sink := &messageSink{}
+			subscriber, err := Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{
+				ClientIDSuffix: "subscriber",
+				Subscriptions:  []Subscription{{Filter: "factory/+/telemetry", QoS: 1}},
+				Handler:        sink.handle,
+			})
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, subscriber.Disconnect(context.Background())) }()
+
+			publisher, err := Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{ClientIDSuffix: "publisher"})
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, publisher.Disconnect(context.Background())) }()
+
+			require.NoError(t, publisher.Publish(t.Context(), Message{Topic: "factory/line1/telemetry", Payload: []byte("data"), QoS: 1}))
+			require.NoError(t, publisher.Publish(t.Context(), Message{Topic: "factory/line1/status", Payload: []byte("ignored"), QoS: 1}))
+			require.NoError(t, publisher.Publish(t.Context(), Message{Topic: "factory/line2/telemetry", Payload: []byte("retained"), QoS: 0, Retained: true}))
+
+			require.Eventually(t, func() bool { return len(sink.all()) == 2 }, 10*time.Second, 10*time.Millisecond)
+			messages := sink.all()
+			assert.Equal(t, Message{Topic: "factory/line1/telemetry", Payload: []byte("data"), QoS: 1}, messages[0])
+			assert.Equal(t, "factory/line2/telemetry", messages[1].Topic)
+			assert.Equal(t, []byte("retained"), messages[1].Payload)
+		})
+	}
+}
+
+func TestConnectAuthentication(t *testing.T) {
+	ledger := &auth.Ledger{
+		Auth: auth.AuthRules{{Username: "user", Password: "password", Allow: true}},
+	}
+	for _, version := range []string{ProtocolVersion311, ProtocolVersion5} {
+		t.Run(version, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			cfg.Endpoint = runBroker(t, ledger)
+			cfg.ProtocolVersion = version
+			cfg.ConnectTimeout = time.Second
+			cfg.Username = "user"
+			cfg.Password = "password"
+
+			client, err := Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{})
+			require.NoError(t, err)
+			assert.NoError(t, client.Disconnect(t.Context()))
+
+			cfg.Password = "wrong"
+			_, err = Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{})
+			assert.ErrorContains(t, err, "failed to connect")
+		})
+	}
+}
+
+type authenticator struct {
+	component.StartFunc
+	component.ShutdownFunc
+	authorization string
+}
+
+var _ extensionauth.GRPCClient = (*authenticator)(nil)
+
+func (a *authenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return a, nil
+}
+
+func (a *authenticator) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": a.authorization}, nil
+}
+
+func (*authenticator) RequireTransportSecurity() bool {
+	return false
+}
+
+type authHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h authHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestConnectAuthenticator(t *testing.T) {
+	ledger := &auth.Ledger{
+		Auth: auth.AuthRules{
+			{Username: "user", Password: "password", Allow: true},
+			{Username: "device", Password: "token", Allow: true},
+		},
+	}
+	id := component.MustNewID("authenticator")
+	tests := []struct {
+		name          string
+		authorization string
+		username      string
+		err           string
+	}{
+		{
+			name:          "basic",
+			authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("user:password")),
+		},
+		{
+			name:          "bearer",
+			authorization: "Bearer token",
+			username:      "device",
+		},
+		{
+			name:          "unsupported",
+			authorization: "Digest value",
+			err:           "failed to connect",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			cfg.Endpoint = runBroker(t, ledger)
+			cfg.ProtocolVersion = ProtocolVersion5
+			cfg.ConnectTimeout = time.Second
+			cfg.Username = tt.username
+			cfg.Auth = configoptional.Some(configauth.Config{AuthenticatorID: id})
+			host := authHost{
+				Host:       componenttest.NewNopHost(),
+				extensions: map[component.ID]component.Component{id: &authenticator{authorization: tt.authorization}},
+			}
+
+			client, err := Connect(t.Context(), cfg, host, zap.NewNop(), ConnectOptions{})
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, client.Disconnect(t.Context()))
+		})
+	}
+
+	cfg := NewDefaultClientConfig()
+	cfg.Auth = configoptional.Some(configauth.Config{AuthenticatorID: id})
+	_, err := Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{})
+	assert.ErrorContains(t, err, "failed to load authenticator")
+}
+
+func TestParseAuthorization(t *testing.T) {
+	username, password, err := parseAuthorization("", "basic "+base64.StdEncoding.EncodeToString([]byte("user:pass:word")))
+	require.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass:word", password)
+
+	_, _, err = parseAuthorization("", "Basic "+base64.StdEncoding.EncodeToString([]byte("user")))
+	assert.EqualError(t, err, "invalid basic authorization: missing password")
+
+	_, _, err = parseAuthorization("", "Basic !")
+	assert.ErrorContains(t, err, "invalid basic authorization")
+}
+
+func TestClientID(t *testing.T) {
+	cfg := NewDefaultClientConfig()
+	first, err := cfg.clientID("logs")
+	require.NoError(t, err)
+	second, err := cfg.clientID("logs")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.LessOrEqual(t, len(first), 23)
+
+	cfg.ClientID = "collector"
+	id, err := cfg.clientID("logs")
+	require.NoError(t, err)
+	assert.Equal(t, "collector-logs", id)
+}
+
+func TestConnectErrors(t *testing.T) {
+	for _, version := range []string{ProtocolVersion311, ProtocolVersion5} {
+		t.Run(version, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			cfg.Endpoint = "tcp://127.0.0.1:1"
+			cfg.ProtocolVersion = version
+			cfg.ConnectTimeout = 200 * time.Millisecond
+			_, err := Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{})
+			assert.ErrorContains(t, err, `failed to connect to "tcp://127.0.0.1:1"`)
+		})
+	}
+
+	cfg := NewDefaultClientConfig()
+	cfg.TLS = &configtls.ClientConfig{Config: configtls.Config{CAFile: "missing.pem"}}
+	_, err := Connect(t.Context(), cfg, componenttest.NewNopHost(), zap.NewNop(), ConnectOptions{})
+	assert.ErrorContains(t, err, "failed to load TLS config")
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	pahov3 "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+)
+
+const (
+	// protocolVersion311 is the protocol level of MQTT 3.1.1.
+	protocolVersion311 = 4
+	// subscribeFailure is the return code of a refused subscription.
+	subscribeFailure = 0x80
+	// disconnectQuiesce is the time given to the pending work to complete on disconnection, in milliseconds.
+	disconnectQuiesce = 250
+)
+
+// v311Client is a connection with the MQTT 3.1.1 protocol.
+type v311Client struct {
+	client pahov3.Client
+}
+
+func connectV311(cfg ClientConfig, clientID string, tlsConfig *tls.Config, creds credentialsProvider, logger *zap.Logger, opts ConnectOptions) (Client, error) {
+	subscribed := make(chan error, 1)
+	handler := func(_ pahov3.Client, msg pahov3.Message) {
+		opts.Handler(Message{
+			Topic:    msg.Topic(),
+			Payload:  msg.Payload(),
+			QoS:      msg.Qos(),
+			Retained: msg.Retained(),
+		})
+	}
+
+	options := pahov3.NewClientOptions().
+		AddBroker(cfg.Endpoint).
+		SetClientID(clientID).
+		SetProtocolVersion(protocolVersion311).
+		SetCleanSession(cfg.CleanSession).
+		SetKeepAlive(cfg.KeepAlive).
+		SetConnectTimeout(cfg.ConnectTimeout).
+		SetAutoReconnect(true).
+		SetMaxReconnectInterval(time.Minute).
+		SetTLSConfig(tlsConfig).
+		SetCredentialsProvider(func() (string, string) {
+			username, password, err := creds(context.Background())
+			if err != nil {
+				logger.Warn("Failed to get credentials", zap.Error(err))
+			}
+			return username, password
+		}).
+		SetConnectionLostHandler(func(_ pahov3.Client, err error) {
+			logger.Warn("Lost connection to MQTT broker", zap.Error(err))
+		}).
+		SetOnConnectHandler(func(client pahov3.Client) {
+			logger.Info("Connected to MQTT broker")
+			if len(opts.Subscriptions) == 0 {
+				return
+			}
+			err := subscribeV311(client, opts.Subscriptions, cfg.ConnectTimeout, handler)
+			if err != nil {
+				logger.Error("Failed to subscribe", zap.Error(err))
+			}
+			select {
+			case subscribed <- err:
+			default:
+			}
+		})
+
+	if opts.Handler != nil {
+		// Handles the messages delivered from a persistent session before subscribing.
+		options.SetDefaultPublishHandler(handler)
+	}
+
+	client := pahov3.NewClient(options)
+	token := client.Connect()
+	if !token.WaitTimeout(cfg.ConnectTimeout) {
+		client.Disconnect(0)
+		return nil, fmt.Errorf("failed to connect to %q: timeout", cfg.Endpoint)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to %q: %w", cfg.Endpoint, err)
+	}
+	if len(opts.Subscriptions) > 0 {
+		var err error
+		select {
+		case err = <-subscribed:
+		case <-time.After(cfg.ConnectTimeout):
+			err = errors.New("timeout")
+		}
+		if err != nil {
+			client.Disconnect(disconnectQuiesce)
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+	}
+	return &v311Client{client: client}, nil
+}
+
+func subscribeV311(client pahov3.Client, subscriptions []Subscription, timeout time.Duration, handler pahov3.MessageHandler) error {
+	filters := make(map[string]byte, len(subscriptions))
+	for _, s := range subscriptions {
+		filters[s.Filter] = s.QoS
+	}
+	token := client.SubscribeMultiple(filters, handler)
+	if !token.WaitTimeout(timeout) {
+		return errors.New("subscription timeout")
+	}
+	if err := token.Error(); err != nil {
+		return err
+	}
+	subscribeToken, ok := token.(*pahov3.SubscribeToken)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for _, s := range subscriptions {
+		if code, ok := subscribeToken.Result()[s.Filter]; ok && code == subscribeFailure {
+			errs = append(errs, fmt.Errorf("subscription to %q refused", s.Filter))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *v311Client) Publish(ctx context.Context, msg Message) error {
+	token := c.client.Publish(msg.Topic, msg.QoS, msg.Retained, msg.Payload)
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *v311Client) Disconnect(context.Context) error {
+	c.client.Disconnect(disconnectQuiesce)
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"go.uber.org/zap"
+)
+
+// v5Client is a connection with the MQTT 5 protocol.
+type v5Client struct {
+	cm     *autopaho.ConnectionManager
+	cancel context.CancelFunc
+}
+
+func connectV5(ctx context.Context, cfg ClientConfig, clientID string, tlsConfig *tls.Config, creds credentialsProvider, logger *zap.Logger, opts ConnectOptions) (Client, error) {
+	serverURL, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	// connCtx holds the lifetime of the connection, which is reconnected until it is cancelled.
+	connCtx, cancel := context.WithCancel(context.Background())
+	var lastErr atomic.Value
+	subscribed := make(chan error, 1)
+
+	pahoCfg := autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{serverURL},
+		TlsCfg:                        tlsConfig,
+		KeepAlive:                     uint16(cfg.KeepAlive / time.Second),
+		CleanStartOnInitialConnection: cfg.CleanSession,
+		ConnectTimeout:                cfg.ConnectTimeout,
+		ReconnectBackoff:              autopaho.NewExponentialBackoff(time.Second, time.Minute, 2*time.Second, 2),
+		ConnectPacketBuilder: func(connect *paho.Connect, _ *url.URL) (*paho.Connect, error) {
+			username, password, err := creds(connCtx)
+			if err != nil {
+				return nil, err
+			}
+			connect.UsernameFlag, connect.Username = username != "", username
+			connect.PasswordFlag, connect.Password = password != "", []byte(password)
+			return connect, nil
+		},
+		OnConnectionUp: func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+			logger.Info("Connected to MQTT broker")
+			if len(opts.Subscriptions) == 0 {
+				return
+			}
+			// The callback must not block.
+			go func() {
+				err := subscribeV5(connCtx, cm, opts.Subscriptions)
+				if err != nil {
+					logger.Error("Failed to subscribe", zap.Error(err))
+				}
+				select {
+				case subscribed <- err:
+				default:
+				}
+			}()
+		},
+		OnConnectError: func(err error) {
+			lastErr.Store(err)
+			logger.Warn("Failed to connect to MQTT broker", zap.Error(err))
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: clientID,
+			OnClientError: func(err error) {
+				logger.Warn("MQTT client error", zap.Error(err))
+			},
+			OnServerDisconnect: func(disconnect *paho.Disconnect) {
+				logger.Warn("Disconnected by MQTT broker", zap.Uint8("reason_code", disconnect.ReasonCode))
+			},
+		},
+	}
+	if !cfg.CleanSession {
+		// The session is kept by the broker while the client is disconnected.
+		pahoCfg.SessionExpiryInterval = math.MaxUint32
+	}
+	if opts.Handler != nil {
+		pahoCfg.OnPublishReceived = []func(paho.PublishReceived) (bool, error){
+			func(received paho.PublishReceived) (bool, error) {
+				opts.Handler(Message{
+					Topic:    received.Packet.Topic,
+					Payload:  received.Packet.Payload,
+					QoS:      received.Packet.QoS,
+					Retained: received.Packet.Retain,
+				})
+				return true, nil
+			},
+		}
+	}
+
+	cm, err := autopaho.NewConnection(connCtx, pahoCfg)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to connect to %q: %w", cfg.Endpoint, err)
+	}
+	client := &v5Client{cm: cm, cancel: cancel}
+
+	awaitCtx, cancelAwait := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancelAwait()
+	err = cm.AwaitConnection(awaitCtx)
+	if err == nil && len(opts.Subscriptions) > 0 {
+		select {
+		case err = <-subscribed:
+		case <-awaitCtx.Done():
+			err = awaitCtx.Err()
+		}
+	}
+	if err != nil {
+		if connectErr, ok := lastErr.Load().(error); ok {
+			err = connectErr
+		}
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to connect to %q: %w", cfg.Endpoint, err)
+	}
+	return client, nil
+}
+
+func subscribeV5(ctx context.Context, cm *autopaho.ConnectionManager, subscriptions []Subscription) error {
+	subscribe := &paho.Subscribe{}
+	for _, s := range subscriptions {
+		subscribe.Subscriptions = append(subscribe.Subscriptions, paho.SubscribeOptions{Topic: s.Filter, QoS: s.QoS})
+	}
+	suback, err := cm.Subscribe(ctx, subscribe)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, reason := range suback.Reasons {
+		if reason >= 0x80 && i < len(subscriptions) {
+			errs = append(errs, fmt.Errorf("subscription to %q refused with reason code 0x%02x", subscriptions[i].Filter, reason))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *v5Client) Publish(ctx context.Context, msg Message) error {
+	_, err := c.cm.Publish(ctx, &paho.Publish{
+		Topic:   msg.Topic,
+		Payload: msg.Payload,
+		QoS:     msg.QoS,
+		Retain:  msg.Retained,
+	})
+	return err
+}
+
+func (c *v5Client) Disconnect(ctx context.Context) error {
+	defer c.cancel()
+	return c.cm.Disconnect(ctx)
+}
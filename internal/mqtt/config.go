@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	// ProtocolVersion311 is the MQTT 3.1.1 protocol version.
+	ProtocolVersion311 = "3.1.1"
+	// ProtocolVersion5 is the MQTT 5 protocol version.
+	ProtocolVersion5 = "5"
+
+	defaultEndpoint       = "tcp://localhost:1883"
+	defaultKeepAlive      = 30 * time.Second
+	defaultConnectTimeout = 10 * time.Second
+)
+
+var (
+	errEndpointRequired        = errors.New("endpoint is required")
+	errInvalidProtocolVersion  = errors.New(`protocol_version must be either "3.1.1" or "5"`)
+	errInvalidKeepAlive        = fmt.Errorf("keep_alive must be between 0 and %s", maxKeepAlive)
+	errNonPositiveTimeout      = errors.New("connect_timeout must be positive")
+	errPersistentSessionNoID   = errors.New("client_id is required when clean_session is false")
+	errPasswordWithoutUsername = errors.New("password requires username with protocol_version 3.1.1")
+
+	// maxKeepAlive is the maximum keep alive of the protocol, sent in seconds on 16 bits.
+	maxKeepAlive = math.MaxUint16 * time.Second
+
+	// schemes maps the supported endpoint schemes to whether they use TLS.
+	schemes = map[string]bool{
+		"tcp":   false,
+		"mqtt":  false,
+		"ws":    false,
+		"ssl":   true,
+		"tls":   true,
+		"mqtts": true,
+		"wss":   true,
+	}
+)
+
+// ClientConfig holds the settings of the connection to the MQTT broker, shared by the
+// MQTT receiver and exporter.
+type ClientConfig struct {
+	// Endpoint is the URL of the broker, with the tcp, mqtt, ssl, tls, mqtts, ws or wss
+	// scheme (default tcp://localhost:1883).
+	Endpoint string `mapstructure:"endpoint"`
+
+	// ProtocolVersion is the version of the MQTT protocol, either "3.1.1" or "5"
+	// (default "3.1.1").
+	ProtocolVersion string `mapstructure:"protocol_version"`
+
+	// ClientID identifies the client with the broker. Each signal type uses its own
+	// connection, whose client ID is suffixed with the signal type. A random client ID
+	// is used for each connection when it is empty.
+	ClientID string `mapstructure:"client_id"`
+
+	// CleanSession discards the session, including its subscriptions and the messages
+	// not delivered yet, when the client connects (default true).
+	CleanSession bool `mapstructure:"clean_session"`
+
+	// KeepAlive is the maximum interval between two packets sent to the broker (default 30s).
+	KeepAlive time.Duration `mapstructure:"keep_alive"`
+
+	// ConnectTimeout is the timeout of the connection to the broker (default 10s).
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	// Username and Password authenticate with the broker.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	// Auth is the authenticator extension providing the credentials, on every connection.
+	// Basic credentials are used as username and password, and bearer tokens as password.
+	Auth configoptional.Optional[configauth.Config] `mapstructure:"auth"`
+
+	// TLS holds the TLS configuration of the connection, used with the ssl, tls, mqtts
+	// and wss schemes.
+	TLS *configtls.ClientConfig `mapstructure:"tls"`
+}
+
+// NewDefaultClientConfig returns the default settings of the connection.
+func NewDefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Endpoint:        defaultEndpoint,
+		ProtocolVersion: ProtocolVersion311,
+		CleanSession:    true,
+		KeepAlive:       defaultKeepAlive,
+		ConnectTimeout:  defaultConnectTimeout,
+	}
+}
+
+func (c ClientConfig) Validate() error {
+	var errs []error
+	if c.Endpoint == "" {
+		errs = append(errs, errEndpointRequired)
+	} else if u, err := url.Parse(c.Endpoint); err != nil {
+		errs = append(errs, fmt.Errorf("invalid endpoint: %w", err))
+	} else if _, ok := schemes[strings.ToLower(u.Scheme)]; !ok {
+		errs = append(errs, fmt.Errorf("unsupported endpoint scheme %q", u.Scheme))
+	}
+	if c.ProtocolVersion != ProtocolVersion311 && c.ProtocolVersion != ProtocolVersion5 {
+		errs = append(errs, errInvalidProtocolVersion)
+	}
+	if c.KeepAlive < 0 || c.KeepAlive > maxKeepAlive {
+		errs = append(errs, errInvalidKeepAlive)
+	}
+	if c.ConnectTimeout <= 0 {
+		errs = append(errs, errNonPositiveTimeout)
+	}
+	if !c.CleanSession && c.ClientID == "" {
+		errs = append(errs, errPersistentSessionNoID)
+	}
+	if c.ProtocolVersion == ProtocolVersion311 && c.Password != "" && c.Username == "" {
+		errs = append(errs, errPasswordWithoutUsername)
+	}
+	return errors.Join(errs...)
+}
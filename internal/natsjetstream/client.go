@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstream // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+)
+
+// Connect connects to the NATS servers of the configuration, and returns the connection
+// along with its JetStream context. The disconnections and reconnections are logged.
+func Connect(ctx context.Context, cfg ClientConfig, logger *zap.Logger) (*nats.Conn, jetstream.JetStream, error) {
+	opts, err := connectOptions(ctx, cfg, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	conn, err := nats.Connect(cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %q: %w", cfg.Endpoint, err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	return conn, js, nil
+}
+
+func connectOptions(ctx context.Context, cfg ClientConfig, logger *zap.Logger) ([]nats.Option, error) {
+	opts := []nats.Option{
+		nats.Timeout(cfg.ConnectTimeout),
+		nats.ReconnectWait(cfg.ReconnectWait),
+		nats.MaxReconnects(cfg.MaxReconnects),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				logger.Warn("Disconnected from NATS server", zap.Error(err))
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			logger.Info("Reconnected to NATS server", zap.String("url", conn.ConnectedUrlRedacted()))
+		}),
+		nats.ErrorHandler(func(_ *nats.Conn, sub *nats.Subscription, err error) {
+			fields := []zap.Field{zap.Error(err)}
+			if sub != nil {
+				fields = append(fields, zap.String("subject", sub.Subject))
+			}
+			logger.Error("NATS connection error", fields...)
+		}),
+	}
+	if cfg.Name != "" {
+		opts = append(opts, nats.Name(cfg.Name))
+	}
+
+	if cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		if tlsConfig != nil {
+			opts = append(opts, nats.Secure(tlsConfig))
+		}
+	}
+
+	auth := cfg.Auth
+	switch {
+	case auth.Username != "":
+		opts = append(opts, nats.UserInfo(auth.Username, string(auth.Password)))
+	case auth.Token != "":
+		opts = append(opts, nats.Token(string(auth.Token)))
+	case auth.NKeyFile != "":
+		opt, err := nats.NkeyOptionFromSeed(auth.NKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load NKey seed: %w", err)
+		}
+		opts = append(opts, opt)
+	case auth.CredentialsFile != "":
+		opts = append(opts, nats.UserCredentials(auth.CredentialsFile))
+	}
+	return opts, nil
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+)
+
+func runServer(t *testing.T, opts *server.Options) *server.Server {
+	opts.Host = "127.0.0.1"
+	opts.Port = -1
+	opts.NoLog = true
+	opts.NoSigs = true
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go srv.Start()
+	require.True(t, srv.ReadyForConnections(10*time.Second))
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*ClientConfig)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*ClientConfig) {},
+		},
+		{
+			name:   "missing endpoint",
+			modify: func(c *ClientConfig) { c.Endpoint = "" },
+			err:    "endpoint is required",
+		},
+		{
+			name: "negative durations",
+			modify: func(c *ClientConfig) {
+				c.ConnectTimeout = -time.Second
+				c.ReconnectWait = -time.Second
+			},
+			err: "connect_timeout must not be negative\nreconnect_wait must not be negative",
+		},
+		{
+			name: "multiple auth methods",
+			modify: func(c *ClientConfig) {
+				c.Auth.Username = "user"
+				c.Auth.Token = "token"
+			},
+			err: "only one of auth::username, auth::token, auth::nkey_file or auth::credentials_file can be set",
+		},
+		{
+			name:   "password without username",
+			modify: func(c *ClientConfig) { c.Auth.Password = "password" },
+			err:    "auth::password requires auth::username",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			tt.modify(&cfg)
+			if tt.err == "" {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), tt.err)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	srv := runServer(t, &server.Options{Username: "user", Password: "password"})
+
+	cfg := NewDefaultClientConfig()
+	cfg.Endpoint = srv.ClientURL()
+	cfg.Name = "collector"
+	cfg.Auth.Username = "user"
+	cfg.Auth.Password = "password"
+	conn, js, err := Connect(t.Context(), cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.True(t, conn.IsConnected())
+	assert.Equal(t, "collector", conn.Opts.Name)
+
+	_, err = js.AccountInfo(t.Context())
+	assert.NoError(t, err)
+}
+
+func TestConnectToken(t *testing.T) {
+	srv := runServer(t, &server.Options{Authorization: "secret"})
+
+	cfg := NewDefaultClientConfig()
+	cfg.Endpoint = srv.ClientURL()
+	cfg.Auth.Token = "secret"
+	conn, _, err := Connect(t.Context(), cfg, zap.NewNop())
+	require.NoError(t, err)
+	conn.Close()
+
+	cfg.Auth.Token = "wrong"
+	_, _, err = Connect(t.Context(), cfg, zap.NewNop())
+	assert.ErrorContains(t, err, "Authorization Violation")
+}
+
+func TestConnectErrors(t *testing.T) {
+	cfg := NewDefaultClientConfig()
+	cfg.TLS = &configtls.ClientConfig{Config: configtls.Config{CAFile: "missing.pem"}}
+	_, _, err := Connect(t.Context(), cfg, zap.NewNop())
+	assert.ErrorContains(t, err, "failed to load TLS config")
+
+	cfg = NewDefaultClientConfig()
+	cfg.Auth.NKeyFile = "missing.nk"
+	_, _, err = Connect(t.Context(), cfg, zap.NewNop())
+	assert.ErrorContains(t, err, "failed to load NKey seed")
+
+	cfg = NewDefaultClientConfig()
+	cfg.Endpoint = "nats://127.0.0.1:1"
+	cfg.ConnectTimeout = 100 * time.Millisecond
+	_, _, err = Connect(t.Context(), cfg, zap.NewNop())
+	assert.ErrorContains(t, err, `failed to connect to "nats://127.0.0.1:1"`)
+}
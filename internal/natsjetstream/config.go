@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstream // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	defaultEndpoint       = "nats://localhost:4222"
+	defaultConnectTimeout = 2 * time.Second
+	defaultReconnectWait  = 2 * time.Second
+)
+
+var (
+	errEndpointRequired      = errors.New("endpoint is required")
+	errMultipleAuthMethods   = errors.New("only one of auth::username, auth::token, auth::nkey_file or auth::credentials_file can be set")
+	errPasswordWithoutUser   = errors.New("auth::password requires auth::username")
+	errNegativeTimeout       = errors.New("connect_timeout must not be negative")
+	errNegativeReconnectWait = errors.New("reconnect_wait must not be negative")
+)
+
+// ClientConfig holds the settings of the connection to the NATS servers, shared by the
+// NATS JetStream receiver and exporter.
+type ClientConfig struct {
+	// Endpoint is the URL of the NATS server, or a comma separated list of the URLs of
+	// the servers of a cluster (default nats://localhost:4222).
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Name is the name of the connection, reported to the servers.
+	Name string `mapstructure:"name"`
+
+	// Auth holds the credentials used to authenticate with the servers.
+	Auth AuthConfig `mapstructure:"auth"`
+
+	// TLS holds the TLS configuration of the connection. The connection is not encrypted
+	// when it is not set, unless the endpoint uses the tls:// scheme.
+	TLS *configtls.ClientConfig `mapstructure:"tls"`
+
+	// ConnectTimeout is the timeout of the connection to a server (default 2s).
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	// ReconnectWait is the time waited before reconnecting to a server (default 2s).
+	ReconnectWait time.Duration `mapstructure:"reconnect_wait"`
+
+	// MaxReconnects is the number of reconnection attempts before the connection is closed.
+	// A negative value reconnects forever (default -1).
+	MaxReconnects int `mapstructure:"max_reconnects"`
+}
+
+// AuthConfig holds the credentials of the connection. At most one of the authentication
+// methods can be set.
+type AuthConfig struct {
+	// Username and Password authenticate with a user and its password.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	// Token authenticates with a token.
+	Token configopaque.String `mapstructure:"token"`
+
+	// NKeyFile is the path to a file holding an NKey seed.
+	NKeyFile string `mapstructure:"nkey_file"`
+
+	// CredentialsFile is the path to a credentials file holding a user JWT and its NKey seed.
+	CredentialsFile string `mapstructure:"credentials_file"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultClientConfig returns the default settings of the connection.
+func NewDefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Endpoint:       defaultEndpoint,
+		ConnectTimeout: defaultConnectTimeout,
+		ReconnectWait:  defaultReconnectWait,
+		MaxReconnects:  -1,
+	}
+}
+
+func (c ClientConfig) Validate() error {
+	var errs []error
+	if c.Endpoint == "" {
+		errs = append(errs, errEndpointRequired)
+	}
+	if c.ConnectTimeout < 0 {
+		errs = append(errs, errNegativeTimeout)
+	}
+	if c.ReconnectWait < 0 {
+		errs = append(errs, errNegativeReconnectWait)
+	}
+	if err := c.Auth.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (c AuthConfig) Validate() error {
+	methods := 0
+	for _, set := range []bool{c.Username != "", c.Token != "", c.NKeyFile != "", c.CredentialsFile != ""} {
+		if set {
+			methods++
+		}
+	}
+	if methods > 1 {
+		return errMultipleAuthMethods
+	}
+	if c.Password != "" && c.Username == "" {
+		return errPasswordWithoutUser
+	}
+	return nil
+}
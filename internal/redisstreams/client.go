@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreams // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DataField is the field of the stream entries holding the encoded telemetry.
+const DataField = "data"
+
+// Connect creates a client of the Redis server of the configuration, and checks that
+// the server can be reached.
+func Connect(ctx context.Context, cfg ClientConfig) (*redis.Client, error) {
+	opts := &redis.Options{
+		Addr:     cfg.Endpoint,
+		Username: cfg.Username,
+		Password: string(cfg.Password),
+		DB:       cfg.DB,
+	}
+	if cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to %q: %w", cfg.Endpoint, err)
+	}
+	return client, nil
+}
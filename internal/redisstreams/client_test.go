@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreams
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*ClientConfig)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*ClientConfig) {},
+		},
+		{
+			name:   "missing endpoint",
+			modify: func(c *ClientConfig) { c.Endpoint = "" },
+			err:    "endpoint is required",
+		},
+		{
+			name: "negative db and username without password",
+			modify: func(c *ClientConfig) {
+				c.DB = -1
+				c.Username = "user"
+			},
+			err: "db must not be negative\nusername requires password",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			tt.modify(&cfg)
+			if tt.err == "" {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), tt.err)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	srv := miniredis.RunT(t)
+	srv.RequireUserAuth("user", "password")
+
+	cfg := NewDefaultClientConfig()
+	cfg.Endpoint = srv.Addr()
+	cfg.Username = "user"
+	cfg.Password = "password"
+	cfg.DB = 2
+	client, err := Connect(t.Context(), cfg)
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Set(t.Context(), "key", "value", 0).Err())
+	value, err := srv.DB(2).Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestConnectErrors(t *testing.T) {
+	srv := miniredis.RunT(t)
+	srv.RequireUserAuth("user", "password")
+
+	cfg := NewDefaultClientConfig()
+	cfg.Endpoint = srv.Addr()
+	cfg.Username = "user"
+	cfg.Password = "wrong"
+	_, err := Connect(t.Context(), cfg)
+	assert.ErrorContains(t, err, "WRONGPASS")
+
+	cfg.TLS = &configtls.ClientConfig{Config: configtls.Config{CAFile: "missing.pem"}}
+	_, err = Connect(t.Context(), cfg)
+	assert.ErrorContains(t, err, "failed to load TLS config")
+}
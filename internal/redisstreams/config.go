@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreams // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const defaultEndpoint = "localhost:6379"
+
+var (
+	errEndpointRequired    = errors.New("endpoint is required")
+	errNegativeDB          = errors.New("db must not be negative")
+	errPasswordWithoutUser = errors.New("username requires password")
+)
+
+// ClientConfig holds the settings of the connection to the Redis server, shared by the
+// Redis Streams receiver and exporter.
+type ClientConfig struct {
+	// Endpoint is the address of the Redis server, as host:port (default localhost:6379).
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Username is the ACL user used to authenticate with the server. The default user is
+	// used when it is not set.
+	Username string `mapstructure:"username"`
+
+	// Password is the password used to authenticate with the server.
+	Password configopaque.String `mapstructure:"password"`
+
+	// DB is the database selected after connecting to the server (default 0).
+	DB int `mapstructure:"db"`
+
+	// TLS holds the TLS configuration of the connection. The connection is not encrypted
+	// when it is not set.
+	TLS *configtls.ClientConfig `mapstructure:"tls"`
+}
+
+// NewDefaultClientConfig returns the default settings of the connection.
+func NewDefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Endpoint: defaultEndpoint,
+	}
+}
+
+func (c ClientConfig) Validate() error {
+	var errs []error
+	if c.Endpoint == "" {
+		errs = append(errs, errEndpointRequired)
+	}
+	if c.DB < 0 {
+		errs = append(errs, errNegativeDB)
+	}
+	if c.Username != "" && c.Password == "" {
+		errs = append(errs, errPasswordWithoutUser)
+	}
+	return errors.Join(errs...)
+}
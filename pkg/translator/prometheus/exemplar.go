@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// AddExemplarLabel sets a label of a Prometheus exemplar on the OpenTelemetry exemplar. The trace_id and
+// span_id labels set the trace and span IDs when they are valid hex strings, shorter IDs being left-padded
+// with zeros. The other labels, and the IDs that can't be decoded, are added to the filtered attributes.
+// See https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#exemplars
+func AddExemplarLabel(exemplar pmetric.Exemplar, name, value string) {
+	switch strings.ToLower(name) {
+	case ExemplarTraceIDKey:
+		var tid [16]byte
+		if err := decodeAndCopyToLowerBytes(tid[:], []byte(value)); err == nil {
+			exemplar.SetTraceID(tid)
+			return
+		}
+	case ExemplarSpanIDKey:
+		var sid [8]byte
+		if err := decodeAndCopyToLowerBytes(sid[:], []byte(value)); err == nil {
+			exemplar.SetSpanID(sid)
+			return
+		}
+	}
+	exemplar.FilteredAttributes().PutStr(name, value)
+}
+
+/*
+	decodeAndCopyToLowerBytes copies src to dst on lower bytes instead of higher
+
+1. If len(src) > len(dst) -> copy first len(dst) bytes as it is. Example -> src = []byte{0xab,0xcd,0xef,0xgh,0xij}, dst = [2]byte, result dst = [2]byte{0xab, 0xcd}
+2. If len(src) = len(dst) -> copy src to dst as it is
+3. If len(src) < len(dst) -> prepend required 0s and then add src to dst. Example -> src = []byte{0xab, 0xcd}, dst = [8]byte, result dst = [8]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xab, 0xcd}
+*/
+func decodeAndCopyToLowerBytes(dst, src []byte) error {
+	var err error
+	decodedLen := hex.DecodedLen(len(src))
+	if decodedLen >= len(dst) {
+		_, err = hex.Decode(dst, src[:hex.EncodedLen(len(dst))])
+	} else {
+		_, err = hex.Decode(dst[len(dst)-decodedLen:], src)
+	}
+	return err
+}
@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// CustomBucketsSchema is the schema of the native histograms with custom buckets (NHCB).
+	CustomBucketsSchema int32 = -53
+
+	minExponentialSchema int32 = -4
+	maxExponentialSchema int32 = 8
+
+	// staleNaN is the bit pattern of the Prometheus staleness marker.
+	staleNaN uint64 = 0x7ff0000000000002
+)
+
+var (
+	errInvalidSchema = errors.New("invalid native histogram schema")
+	errNegativeCount = errors.New("native histogram has negative counts")
+)
+
+// BucketSpan is a span of consecutive buckets of a native histogram.
+type BucketSpan struct {
+	// Offset is the gap to the previous span, or the starting index of the first span.
+	Offset int32
+	// Length is the number of consecutive buckets in the span.
+	Length uint32
+}
+
+// NativeHistogram is a Prometheus native histogram, as scraped or received through remote write.
+// Integer histograms hold their buckets as deltas to the previous bucket, float histograms hold
+// absolute counts.
+type NativeHistogram struct {
+	Schema        int32
+	ZeroThreshold float64
+	Sum           float64
+
+	IsFloat bool
+	// Count and ZeroCount are the counts of integer histograms.
+	Count     uint64
+	ZeroCount uint64
+	// FloatCount and FloatZeroCount are the counts of float histograms.
+	FloatCount     float64
+	FloatZeroCount float64
+
+	PositiveSpans []BucketSpan
+	NegativeSpans []BucketSpan
+	// PositiveDeltas and NegativeDeltas are the buckets of integer histograms.
+	PositiveDeltas []int64
+	NegativeDeltas []int64
+	// PositiveCounts and NegativeCounts are the buckets of float histograms.
+	PositiveCounts []float64
+	NegativeCounts []float64
+
+	// CustomValues are the upper bounds of the buckets of histograms with custom buckets. Without
+	// custom values, the histogram only has the +Inf bucket.
+	CustomValues []float64
+}
+
+// IsCustomBuckets returns whether the histogram has custom buckets, which are converted to explicit bucket histograms.
+func (h *NativeHistogram) IsCustomBuckets() bool {
+	return h.Schema == CustomBucketsSchema
+}
+
+// IsStale returns whether the histogram is a Prometheus staleness marker.
+func (h *NativeHistogram) IsStale() bool {
+	return math.Float64bits(h.Sum) == staleNaN
+}
+
+// Validate returns an error when the histogram can't be converted.
+func (h *NativeHistogram) Validate() error {
+	if !h.IsCustomBuckets() && (h.Schema < minExponentialSchema || h.Schema > maxExponentialSchema) {
+		return fmt.Errorf("%w: %d", errInvalidSchema, h.Schema)
+	}
+	if h.hasNegativeCounts() {
+		return errNegativeCount
+	}
+	return nil
+}
+
+func (h *NativeHistogram) hasNegativeCounts() bool {
+	if h.IsFloat {
+		if h.FloatCount < 0 || h.FloatZeroCount < 0 {
+			return true
+		}
+		for _, counts := range [][]float64{h.PositiveCounts, h.NegativeCounts} {
+			for _, count := range counts {
+				if count < 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, deltas := range [][]int64{h.PositiveDeltas, h.NegativeDeltas} {
+		var absolute int64
+		for _, delta := range deltas {
+			absolute += delta
+			if absolute < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *NativeHistogram) count() uint64 {
+	if h.IsFloat {
+		return uint64(h.FloatCount)
+	}
+	return h.Count
+}
+
+// NativeHistogramToExponentialHistogramDataPoint sets the count, sum, zero bucket and buckets of the
+// exponential histogram data point from a native histogram with an exponential schema. The timestamps,
+// attributes and exemplars of the data point are left to the caller.
+//
+// Buckets above the largest finite float64 are dropped, and their counts are removed from the count of
+// the data point. The counts of float histograms are truncated to integers.
+// See https://opentelemetry.io/docs/specs/otel/compatibility/prometheus_and_openmetrics/#exponential-histograms
+func NativeHistogramToExponentialHistogramDataPoint(h *NativeHistogram, dp pmetric.ExponentialHistogramDataPoint) {
+	// We do not set Min or Max as native histograms don't have that information.
+	dp.SetScale(h.Schema)
+	dp.SetZeroThreshold(h.ZeroThreshold)
+	if h.IsStale() {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		// The count, sum and buckets are initialized to 0, so we don't need to set them.
+		return
+	}
+
+	dp.SetSum(h.Sum)
+
+	// The maximum bucket index is derived from the formula (2^(2^-n))^i <= MaxFloat64.
+	// MaxFloat64 is approx 2^1024. So (2^-n) * i <= 1024 => i <= 1024 * 2^n.
+	// The bucket containing MaxFloat64 has index i_max = 1024 * 2^n.
+	// The next bucket (i_max + 1) is the +Inf overflow bucket, which is also allowed.
+	// Buckets with an index strictly greater than i_max + 1 must be dropped.
+	// See https://prometheus.io/docs/specs/native_histograms/#schema for more information.
+	overflowLimit := int32(math.Ldexp(1024, int(h.Schema))) + 1
+	var dropped uint64
+	if h.IsFloat {
+		dp.SetZeroCount(uint64(h.FloatZeroCount))
+		dropped += convertBuckets(h.PositiveSpans, absoluteCounts(h.PositiveCounts), dp.Positive(), overflowLimit)
+		dropped += convertBuckets(h.NegativeSpans, absoluteCounts(h.NegativeCounts), dp.Negative(), overflowLimit)
+	} else {
+		dp.SetZeroCount(h.ZeroCount)
+		dropped += convertBuckets(h.PositiveSpans, deltaCounts(h.PositiveDeltas), dp.Positive(), overflowLimit)
+		dropped += convertBuckets(h.NegativeSpans, deltaCounts(h.NegativeDeltas), dp.Negative(), overflowLimit)
+	}
+
+	count := h.count()
+	if dropped > count {
+		// The counts are inconsistent, the count is clamped rather than wrapped around.
+		count = dropped
+	}
+	dp.SetCount(count - dropped)
+}
+
+// NativeHistogramToHistogramDataPoint sets the count, sum, bounds and bucket counts of the explicit bucket
+// histogram data point from a native histogram with custom buckets. The timestamps, attributes and exemplars
+// of the data point are left to the caller.
+func NativeHistogramToHistogramDataPoint(h *NativeHistogram, dp pmetric.HistogramDataPoint) {
+	if h.IsStale() {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		dp.SetCount(h.count())
+		dp.SetSum(h.Sum)
+	}
+
+	dp.ExplicitBounds().FromRaw(h.CustomValues)
+	// There is one more bucket than custom values, the last one is the +Inf bucket.
+	bucketCounts := make([]uint64, len(h.CustomValues)+1)
+	bucketIdx := 0
+	next := absoluteCounts(h.PositiveCounts)
+	if !h.IsFloat {
+		next = deltaCounts(h.PositiveDeltas)
+	}
+	for _, span := range h.PositiveSpans {
+		bucketIdx += int(span.Offset)
+		for range span.Length {
+			count, ok := next()
+			if !ok {
+				break
+			}
+			if bucketIdx >= 0 && bucketIdx < len(bucketCounts) {
+				bucketCounts[bucketIdx] = count
+			}
+			bucketIdx++
+		}
+	}
+	dp.BucketCounts().FromRaw(bucketCounts)
+}
+
+// convertBuckets sets the OpenTelemetry buckets from the Prometheus spans, and returns the sum of the counts
+// of the buckets above the overflow limit, which are dropped.
+func convertBuckets(spans []BucketSpan, next func() (uint64, bool), buckets pmetric.ExponentialHistogramDataPointBuckets, overflowLimit int32) uint64 {
+	if len(spans) == 0 {
+		return 0
+	}
+	// -1 because OTEL offset are for the lower bound, not the upper bound
+	buckets.SetOffset(spans[0].Offset - 1)
+	counts := buckets.BucketCounts()
+
+	var dropped uint64
+	idx := spans[0].Offset
+	for spanIdx, span := range spans {
+		if spanIdx > 0 {
+			for range span.Offset {
+				if idx <= overflowLimit {
+					counts.Append(0)
+				}
+				idx++
+			}
+		}
+		for range span.Length {
+			count, ok := next()
+			if !ok {
+				return dropped
+			}
+			if idx <= overflowLimit {
+				counts.Append(count)
+			} else {
+				dropped += count
+			}
+			idx++
+		}
+	}
+	return dropped
+}
+
+// deltaCounts iterates over the absolute counts of buckets encoded as deltas to the previous bucket.
+func deltaCounts(deltas []int64) func() (uint64, bool) {
+	var i int
+	var count int64
+	return func() (uint64, bool) {
+		if i >= len(deltas) {
+			return 0, false
+		}
+		count += deltas[i]
+		i++
+		return uint64(count), true
+	}
+}
+
+// absoluteCounts iterates over the counts of float buckets, truncated to integers.
+func absoluteCounts(counts []float64) func() (uint64, bool) {
+	var i int
+	return func() (uint64, bool) {
+		if i >= len(counts) {
+			return 0, false
+		}
+		i++
+		return uint64(counts[i-1]), true
+	}
+}
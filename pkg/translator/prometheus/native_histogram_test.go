@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheus
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+)
+
+// The testdata/native_histograms golden files are shared with the Prometheus receivers, which must
+// convert the same histograms to byte-identical data points.
+func TestNativeHistogramGolden(t *testing.T) {
+	dirs, err := os.ReadDir(filepath.Join("testdata", "native_histograms"))
+	require.NoError(t, err)
+	require.NotEmpty(t, dirs)
+
+	for _, dir := range dirs {
+		t.Run(dir.Name(), func(t *testing.T) {
+			dirPath := filepath.Join("testdata", "native_histograms", dir.Name())
+			data, err := os.ReadFile(filepath.Join(dirPath, "histogram.json"))
+			require.NoError(t, err)
+			var h NativeHistogram
+			require.NoError(t, json.Unmarshal(data, &h))
+			require.NoError(t, h.Validate())
+
+			actual := pmetric.NewMetrics()
+			metric := actual.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetName(dir.Name())
+			if h.IsCustomBuckets() {
+				NativeHistogramToHistogramDataPoint(&h, metric.SetEmptyHistogram().DataPoints().AppendEmpty())
+			} else {
+				NativeHistogramToExponentialHistogramDataPoint(&h, metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty())
+			}
+
+			expectedFile := filepath.Join(dirPath, "expected.yaml")
+			// golden.WriteMetrics(t, expectedFile, actual, golden.SkipMetricTimestampNormalization())
+			expected, err := golden.ReadMetrics(expectedFile)
+			require.NoError(t, err)
+
+			marshaler := &pmetric.ProtoMarshaler{}
+			expectedBytes, err := marshaler.MarshalMetrics(expected)
+			require.NoError(t, err)
+			actualBytes, err := marshaler.MarshalMetrics(actual)
+			require.NoError(t, err)
+			assert.Equal(t, expectedBytes, actualBytes)
+		})
+	}
+}
+
+func TestNativeHistogramValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		h    NativeHistogram
+		err  error
+	}{
+		{
+			desc: "exponential",
+			h:    NativeHistogram{Schema: 8, PositiveSpans: []BucketSpan{{Length: 2}}, PositiveDeltas: []int64{2, -1}},
+		},
+		{
+			desc: "invalid schema",
+			h:    NativeHistogram{Schema: 9},
+			err:  errInvalidSchema,
+		},
+		{
+			desc: "custom buckets without custom values",
+			h:    NativeHistogram{Schema: CustomBucketsSchema, PositiveSpans: []BucketSpan{{Length: 1}}, PositiveDeltas: []int64{3}},
+		},
+		{
+			desc: "negative integer bucket",
+			h:    NativeHistogram{Schema: 0, NegativeSpans: []BucketSpan{{Length: 2}}, NegativeDeltas: []int64{1, -2}},
+			err:  errNegativeCount,
+		},
+		{
+			desc: "negative float count",
+			h:    NativeHistogram{Schema: 0, IsFloat: true, FloatCount: -1},
+			err:  errNegativeCount,
+		},
+		{
+			desc: "negative float custom bucket",
+			h:    NativeHistogram{Schema: CustomBucketsSchema, IsFloat: true, CustomValues: []float64{1}, PositiveCounts: []float64{-1}},
+			err:  errNegativeCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.ErrorIs(t, tt.h.Validate(), tt.err)
+		})
+	}
+}
+
+func TestStaleNativeHistogram(t *testing.T) {
+	h := NativeHistogram{
+		Schema:         2,
+		ZeroThreshold:  0.5,
+		Sum:            math.Float64frombits(staleNaN),
+		Count:          3,
+		PositiveSpans:  []BucketSpan{{Length: 1}},
+		PositiveDeltas: []int64{3},
+	}
+	require.True(t, h.IsStale())
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	NativeHistogramToExponentialHistogramDataPoint(&h, dp)
+	assert.True(t, dp.Flags().NoRecordedValue())
+	assert.Equal(t, int32(2), dp.Scale())
+	assert.Equal(t, 0.5, dp.ZeroThreshold())
+	assert.Zero(t, dp.Count())
+	assert.Zero(t, dp.Sum())
+	assert.Zero(t, dp.Positive().BucketCounts().Len())
+
+	h.Schema = CustomBucketsSchema
+	h.CustomValues = []float64{1}
+	hdp := pmetric.NewHistogramDataPoint()
+	NativeHistogramToHistogramDataPoint(&h, hdp)
+	assert.True(t, hdp.Flags().NoRecordedValue())
+	assert.Zero(t, hdp.Count())
+	assert.Equal(t, []float64{1}, hdp.ExplicitBounds().AsRaw())
+}
+
+func TestAddExemplarLabel(t *testing.T) {
+	exemplar := pmetric.NewExemplar()
+	AddExemplarLabel(exemplar, "Trace_ID", "0102030405060708090a0b0c0d0e0f10")
+	AddExemplarLabel(exemplar, "span_id", "0a0b0c")
+	AddExemplarLabel(exemplar, "user", "alice")
+
+	assert.Equal(t, [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, [16]byte(exemplar.TraceID()))
+	// Shorter IDs are left-padded
+	assert.Equal(t, [8]byte{0, 0, 0, 0, 0, 10, 11, 12}, [8]byte(exemplar.SpanID()))
+	assert.Equal(t, map[string]any{"user": "alice"}, exemplar.FilteredAttributes().AsRaw())
+
+	// Invalid IDs are kept as attributes
+	exemplar = pmetric.NewExemplar()
+	AddExemplarLabel(exemplar, "trace_id", "not-hex")
+	assert.True(t, exemplar.TraceID().IsEmpty())
+	assert.Equal(t, map[string]any{"trace_id": "not-hex"}, exemplar.FilteredAttributes().AsRaw())
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	dbip "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+	ip2location "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -95,6 +99,20 @@ func TestLoadConfig(t *testing.T) {
 			id:                    component.NewIDWithName(metadata.Type, "invalid_error_mode"),
 			unmarshalErrorMessage: "unknown error mode not_a_mode",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "multiple_providers"),
+			expected: &Config{
+				Context: resource,
+				Providers: map[string]provider.Config{
+					"maxmind":         &maxmind.Config{DatabasePath: "/tmp/GeoLite2-City.mmdb"},
+					"maxmind/asn":     &maxmind.Config{DatabasePath: "/tmp/GeoLite2-ASN.mmdb", ReloadInterval: time.Hour},
+					"dbip":            &dbip.Config{Config: ipdb.Config{DatabasePath: "/tmp/dbip-city-lite.csv"}},
+					"ip2location/asn": &ip2location.Config{Config: ipdb.Config{DatabasePath: "/tmp/IP2LOCATION-LITE-ASN.CSV", ReloadInterval: 24 * time.Hour}},
+				},
+				Attributes: defaultAttributes,
+				ErrorMode:  ottl.PropagateError,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -6,6 +6,7 @@ package geoipprocessor // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -17,6 +18,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	dbip "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+	ip2location "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -34,7 +37,9 @@ var (
 
 // providerFactories is a map that stores GeoIPProviderFactory instances, keyed by the provider type.
 var providerFactories = map[string]provider.GeoIPProviderFactory{
-	maxmind.TypeStr: &maxmind.Factory{},
+	maxmind.TypeStr:     &maxmind.Factory{},
+	dbip.TypeStr:        &dbip.Factory{},
+	ip2location.TypeStr: &ip2location.Factory{},
 }
 
 // NewFactory creates a new processor factory with default configuration,
@@ -44,9 +49,16 @@ func NewFactory() processor.Factory {
 }
 
 // getProviderFactory retrieves the GeoIPProviderFactory for the given key.
+// The key is the provider type, optionally followed by a slash and a name, e.g. `maxmind/asn`,
+// to configure several providers of the same type.
 // It returns the factory and a boolean indicating whether the factory was found.
 func getProviderFactory(key string) (provider.GeoIPProviderFactory, bool) {
-	if factory, ok := providerFactories[key]; ok {
+	return lookupProviderFactory(providerFactories, key)
+}
+
+func lookupProviderFactory(factories map[string]provider.GeoIPProviderFactory, key string) (provider.GeoIPProviderFactory, bool) {
+	providerType, _, _ := strings.Cut(key, "/")
+	if factory, ok := factories[providerType]; ok {
 		return factory, true
 	}
 
@@ -72,8 +84,8 @@ func createGeoIPProviders(
 	providers := make([]provider.GeoIPProvider, 0, len(config.Providers))
 
 	for key, cfg := range config.Providers {
-		factory := factories[key]
-		if factory == nil {
+		factory, ok := lookupProviderFactory(factories, key)
+		if !ok {
 			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
 		}
 
@@ -87,3 +87,16 @@ func TestCreateProcessor_FailedProvider(t *testing.T) {
 	_, err := factory.CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
 	assert.EqualError(t, err, fmt.Errorf("failed to create provider for key %q: %w", providerKey, errors.New("error creating provider")).Error())
 }
+
+func TestGetProviderFactory(t *testing.T) {
+	for _, key := range []string{"maxmind", "maxmind/asn", "dbip", "dbip/city", "ip2location", "ip2location/asn"} {
+		t.Run(key, func(t *testing.T) {
+			factory, ok := getProviderFactory(key)
+			assert.True(t, ok)
+			assert.NotNil(t, factory)
+		})
+	}
+
+	_, ok := getProviderFactory("unknown/asn")
+	assert.False(t, ok)
+}
@@ -117,6 +117,8 @@ func (g *geoIPProcessor) processAttributes(ctx context.Context, metadata pcommon
 		switch geoAttr.Value.Type() {
 		case attribute.FLOAT64:
 			metadata.PutDouble(string(geoAttr.Key), geoAttr.Value.AsFloat64())
+		case attribute.INT64:
+			metadata.PutInt(string(geoAttr.Key), geoAttr.Value.AsInt64())
 		case attribute.STRING:
 			metadata.PutStr(string(geoAttr.Key), geoAttr.Value.AsString())
 		}
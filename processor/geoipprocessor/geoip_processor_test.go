@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/otel/attribute"
@@ -265,3 +266,37 @@ func TestProcessorShutdownError(t *testing.T) {
 
 	assert.EqualError(t, processor.shutdown(t.Context()), "test error 1; test error 2")
 }
+
+func TestProcessAttributesMultipleProviders(t *testing.T) {
+	cityProvider := &providerMock{
+		LocationF: func(context.Context, netip.Addr) (attribute.Set, error) {
+			return attribute.NewSet(attribute.String(conventions.AttributeGeoCountryIsoCode, "AU")), nil
+		},
+	}
+	asnProvider := &providerMock{
+		LocationF: func(context.Context, netip.Addr) (attribute.Set, error) {
+			return attribute.NewSet(
+				attribute.Int64(conventions.AttributeASNumber, 1221),
+				attribute.String(conventions.AttributeASOrganizationName, "Telstra Pty Ltd"),
+			), nil
+		},
+	}
+	ispProvider := &providerMock{
+		LocationF: func(context.Context, netip.Addr) (attribute.Set, error) {
+			return attribute.Set{}, provider.ErrNoMetadataFound
+		},
+	}
+	cfg := &Config{Context: resource, Attributes: defaultAttributes}
+	geoProcessor := newGeoIPProcessor(cfg, []provider.GeoIPProvider{cityProvider, asnProvider, ispProvider}, processortest.NewNopSettings(metadata.Type))
+
+	attributes := pcommon.NewMap()
+	attributes.PutStr("client.address", "1.128.0.1")
+	require.NoError(t, geoProcessor.processAttributes(t.Context(), attributes))
+
+	assert.Equal(t, map[string]any{
+		"client.address":                        "1.128.0.1",
+		conventions.AttributeGeoCountryIsoCode:  "AU",
+		conventions.AttributeASNumber:           int64(1221),
+		conventions.AttributeASOrganizationName: "Telstra Pty Ltd",
+	}, attributes.AsRaw())
+}
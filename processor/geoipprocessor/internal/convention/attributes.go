@@ -38,4 +38,12 @@ const (
 
 	// AttributeGeoLocationLon represents the attribute name for the longitude.
 	AttributeGeoLocationLon = string(conventions.GeoLocationLonKey)
+	// AttributeASNumber represents the attribute name for the autonomous system number.
+	AttributeASNumber = "as.number"
+	// AttributeASOrganizationName represents the attribute name for the organization registering the autonomous system.
+	AttributeASOrganizationName = "as.organization.name"
+	// AttributeISPName represents the attribute name for the internet service provider name.
+	AttributeISPName = "isp.name"
+	// AttributeISPOrganizationName represents the attribute name for the organization associated with the IP address by the internet service provider.
+	AttributeISPOrganizationName = "isp.organization.name"
 )
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+// Config defines configuration for DB-IP provider.
+type Config struct {
+	ipdb.Config `mapstructure:",squash"`
+}
+
+var _ provider.Config = (*Config)(nil)
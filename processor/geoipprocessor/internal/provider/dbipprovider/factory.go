@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+const (
+	// TypeStr the value of "type" key in configuration.
+	TypeStr = "dbip"
+)
+
+// Factory is the Factory for the DB-IP GeoIP provider.
+type Factory struct{}
+
+var _ provider.GeoIPProviderFactory = (*Factory)(nil)
+
+// CreateDefaultConfig creates the default configuration for the Provider.
+func (*Factory) CreateDefaultConfig() provider.Config {
+	return &Config{}
+}
+
+// CreateGeoIPProvider creates a provider based on this config.
+func (*Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+	dbipConfig := cfg.(*Config)
+	return provider.NewReloadingProvider(dbipConfig.DatabasePath, dbipConfig.ReloadInterval, settings.Logger, func() (provider.GeoIPProvider, error) {
+		return newDBIPProvider(dbipConfig)
+	})
+}
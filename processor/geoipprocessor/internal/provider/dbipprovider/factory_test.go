@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+func ipdbConfig(path string) ipdb.Config {
+	return ipdb.Config{DatabasePath: path}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.IsType(t, &Config{}, cfg)
+}
+
+func TestCreateProvider(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{Config: ipdb.Config{DatabasePath: "testdata/dbip-country-lite.csv", ReloadInterval: time.Minute}}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	attributes, err := provider.Location(t.Context(), netip.MustParseAddr("1.0.1.1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, attributes.Len())
+	assert.NoError(t, provider.Close(t.Context()))
+}
+
+func TestCreateProviderError(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{Config: ipdb.Config{DatabasePath: "testdata/missing.mmdb"}}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorContains(t, err, "could not open geoip database")
+	assert.Nil(t, provider)
+}
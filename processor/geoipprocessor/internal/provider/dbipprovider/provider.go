@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/dbipprovider"
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strconv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+// Number of columns of the DB-IP Lite CSV databases, see https://db-ip.com/db/lite.php
+const (
+	countryColumns = 3 // ip_start, ip_end, country
+	asnColumns     = 4 // ip_start, ip_end, asn, as_organization
+	cityColumns    = 8 // ip_start, ip_end, continent, country, stateprov, city, latitude, longitude
+)
+
+// newDBIPProvider opens the DB-IP database. MMDB databases are read directly from the file, while
+// CSV databases are loaded in memory.
+func newDBIPProvider(cfg *Config) (provider.GeoIPProvider, error) {
+	format, err := cfg.DatabaseFormat()
+	if err != nil {
+		return nil, err
+	}
+	if format == ipdb.FormatMMDB {
+		db, err := ipdb.OpenMMDB(cfg.DatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		return ipdb.NewProvider(db), nil
+	}
+
+	db, err := loadCSV(cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	return ipdb.NewProvider(ipdb.InMemory(db)), nil
+}
+
+// loadCSV loads a DB-IP Lite CSV database. The kind of database, country, ASN or city, is
+// identified from its number of columns.
+func loadCSV(path string) (*ipdb.RangeDB, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open geoip database: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	db := ipdb.NewRangeDB()
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read geoip database: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if err := addRow(db, row); err != nil {
+			return nil, fmt.Errorf("invalid geoip database row at line %d: %w", line, err)
+		}
+	}
+	db.Build()
+	return db, nil
+}
+
+func addRow(db *ipdb.RangeDB, row []string) error {
+	start, err := netip.ParseAddr(row[0])
+	if err != nil {
+		return err
+	}
+	end, err := netip.ParseAddr(row[1])
+	if err != nil {
+		return err
+	}
+
+	var record ipdb.Record
+	switch len(row) {
+	case countryColumns:
+		record.CountryISOCode = db.Intern(row[2])
+	case asnColumns:
+		asn, err := strconv.ParseUint(row[2], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid autonomous system number: %w", err)
+		}
+		record.ASNumber = uint(asn)
+		record.ASOrganization = db.Intern(row[3])
+	case cityColumns:
+		record.ContinentCode = db.Intern(row[2])
+		record.CountryISOCode = db.Intern(row[3])
+		record.RegionName = db.Intern(row[4])
+		record.CityName = db.Intern(row[5])
+		if row[6] != "" && row[7] != "" {
+			if record.Latitude, err = strconv.ParseFloat(row[6], 64); err != nil {
+				return fmt.Errorf("invalid latitude: %w", err)
+			}
+			if record.Longitude, err = strconv.ParseFloat(row[7], 64); err != nil {
+				return fmt.Errorf("invalid longitude: %w", err)
+			}
+			record.HasLocation = true
+		}
+	default:
+		return fmt.Errorf("unsupported DB-IP CSV database with %d columns", len(row))
+	}
+	return db.Add(start, end, record)
+}
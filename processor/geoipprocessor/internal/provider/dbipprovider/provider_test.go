@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbip
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+func TestProviderLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		databasePath  string
+		ip            string
+		expected      []attribute.KeyValue
+		expectedError error
+	}{
+		{
+			name:         "city database IPv4",
+			databasePath: "testdata/dbip-city-lite.csv",
+			ip:           "1.0.2.1",
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Fuzhou"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "CN"),
+				attribute.String(conventions.AttributeGeoContinentCode, "AS"),
+				attribute.String(conventions.AttributeGeoRegionName, "Fujian"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 26.0614),
+				attribute.Float64(conventions.AttributeGeoLocationLon, 119.306),
+			},
+		},
+		{
+			name:         "city database IPv6",
+			databasePath: "testdata/dbip-city-lite.csv",
+			ip:           "2001:4860:4860::8888",
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Mountain View, Santa Clara County"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+				attribute.String(conventions.AttributeGeoContinentCode, "NA"),
+				attribute.String(conventions.AttributeGeoRegionName, "California"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 37.4056),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -122.0775),
+			},
+		},
+		{
+			name:          "city database no match",
+			databasePath:  "testdata/dbip-city-lite.csv",
+			ip:            "1.0.4.1",
+			expectedError: provider.ErrNoMetadataFound,
+		},
+		{
+			name:         "country database",
+			databasePath: "testdata/dbip-country-lite.csv",
+			ip:           "1.0.0.1",
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "AU"),
+			},
+		},
+		{
+			name:         "ASN database",
+			databasePath: "testdata/dbip-asn-lite.csv",
+			ip:           "1.0.0.1",
+			expected: []attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 13335),
+				attribute.String(conventions.AttributeASOrganizationName, "Cloudflare, Inc."),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newDBIPProvider(&Config{Config: ipdbConfig(tt.databasePath)})
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, p.Close(t.Context())) }()
+
+			attributes, err := p.Location(t.Context(), netip.MustParseAddr(tt.ip))
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			expected := attribute.NewSet(tt.expected...)
+			assert.True(t, expected.Equals(&attributes), "unexpected attributes: %v", attributes.ToSlice())
+		})
+	}
+}
+
+func TestProviderInvalidDatabase(t *testing.T) {
+	_, err := newDBIPProvider(&Config{Config: ipdbConfig("testdata/dbip-invalid.csv")})
+	assert.ErrorContains(t, err, "invalid geoip database row at line 1: unsupported DB-IP CSV database with 5 columns")
+
+	_, err = newDBIPProvider(&Config{Config: ipdbConfig("testdata/missing.csv")})
+	assert.ErrorContains(t, err, "could not open geoip database")
+}
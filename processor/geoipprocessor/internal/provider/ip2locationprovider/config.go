@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+// Config defines configuration for IP2Location provider.
+type Config struct {
+	ipdb.Config `mapstructure:",squash"`
+}
+
+var _ provider.Config = (*Config)(nil)
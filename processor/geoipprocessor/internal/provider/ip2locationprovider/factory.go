@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+const (
+	// TypeStr the value of "type" key in configuration.
+	TypeStr = "ip2location"
+)
+
+// Factory is the Factory for the IP2Location GeoIP provider.
+type Factory struct{}
+
+var _ provider.GeoIPProviderFactory = (*Factory)(nil)
+
+// CreateDefaultConfig creates the default configuration for the Provider.
+func (*Factory) CreateDefaultConfig() provider.Config {
+	return &Config{}
+}
+
+// CreateGeoIPProvider creates a provider based on this config.
+func (*Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+	ip2locationConfig := cfg.(*Config)
+	return provider.NewReloadingProvider(ip2locationConfig.DatabasePath, ip2locationConfig.ReloadInterval, settings.Logger, func() (provider.GeoIPProvider, error) {
+		return newIP2LocationProvider(ip2locationConfig)
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.IsType(t, &Config{}, cfg)
+}
+
+func TestCreateProvider(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{Config: ipdb.Config{DatabasePath: "testdata/IP2LOCATION-LITE-DB11.CSV", ReloadInterval: time.Minute}}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	attributes, err := provider.Location(t.Context(), netip.MustParseAddr("1.0.1.1"))
+	require.NoError(t, err)
+	assert.Equal(t, 8, attributes.Len())
+	assert.NoError(t, provider.Close(t.Context()))
+}
+
+func TestCreateProviderError(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{Config: ipdb.Config{DatabasePath: "testdata/missing.BIN", Format: ipdb.FormatMMDB}}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorContains(t, err, "could not open geoip database")
+	assert.Nil(t, provider)
+}
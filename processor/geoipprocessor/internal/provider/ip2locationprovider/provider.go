@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/netip"
+	"os"
+	"strconv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+// Number of columns of the IP2Location LITE CSV databases, see https://lite.ip2location.com/database
+const (
+	db1Columns  = 4  // ip_from, ip_to, country_code, country_name
+	asnColumns  = 5  // ip_from, ip_to, cidr, asn, as
+	db3Columns  = 6  // DB1 columns, region_name, city_name
+	db5Columns  = 8  // DB3 columns, latitude, longitude
+	db9Columns  = 9  // DB5 columns, zip_code
+	db11Columns = 10 // DB9 columns, time_zone
+)
+
+// emptyValue is the value of the IP2Location fields without data.
+const emptyValue = "-"
+
+// newIP2LocationProvider opens the IP2Location database. MMDB databases are read directly from the
+// file, while CSV databases are loaded in memory.
+func newIP2LocationProvider(cfg *Config) (provider.GeoIPProvider, error) {
+	format, err := cfg.DatabaseFormat()
+	if err != nil {
+		return nil, err
+	}
+	if format == ipdb.FormatMMDB {
+		db, err := ipdb.OpenMMDB(cfg.DatabasePath)
+		if err != nil {
+			return nil, err
+		}
+		return ipdb.NewProvider(db), nil
+	}
+
+	db, err := loadCSV(cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	return ipdb.NewProvider(ipdb.InMemory(db)), nil
+}
+
+// loadCSV loads an IP2Location LITE CSV database, in either its IPv4 or IPv6 edition. The kind of
+// database is identified from its number of columns.
+func loadCSV(path string) (*ipdb.RangeDB, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open geoip database: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	db := ipdb.NewRangeDB()
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read geoip database: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if err := addRow(db, row); err != nil {
+			return nil, fmt.Errorf("invalid geoip database row at line %d: %w", line, err)
+		}
+	}
+	db.Build()
+	return db, nil
+}
+
+func addRow(db *ipdb.RangeDB, row []string) error {
+	if len(row) < db1Columns {
+		return fmt.Errorf("unsupported IP2Location CSV database with %d columns", len(row))
+	}
+	start, end, err := parseRange(row[0], row[1])
+	if err != nil {
+		return err
+	}
+
+	value := func(i int) string {
+		if row[i] == emptyValue {
+			return ""
+		}
+		return db.Intern(row[i])
+	}
+
+	var record ipdb.Record
+	switch len(row) {
+	case asnColumns:
+		if row[3] != emptyValue {
+			asn, err := strconv.ParseUint(row[3], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid autonomous system number: %w", err)
+			}
+			record.ASNumber = uint(asn)
+		}
+		record.ASOrganization = value(4)
+	case db1Columns, db3Columns, db5Columns, db9Columns, db11Columns:
+		record.CountryISOCode = value(2)
+		record.CountryName = value(3)
+		if len(row) >= db3Columns {
+			record.RegionName = value(4)
+			record.CityName = value(5)
+		}
+		if len(row) >= db5Columns {
+			if record.Latitude, err = strconv.ParseFloat(row[6], 64); err != nil {
+				return fmt.Errorf("invalid latitude: %w", err)
+			}
+			if record.Longitude, err = strconv.ParseFloat(row[7], 64); err != nil {
+				return fmt.Errorf("invalid longitude: %w", err)
+			}
+			// The rows without data have 0 coordinates.
+			record.HasLocation = record.CountryISOCode != ""
+		}
+		if len(row) >= db9Columns {
+			record.PostalCode = value(8)
+		}
+		if len(row) >= db11Columns {
+			record.Timezone = value(9)
+		}
+	default:
+		return fmt.Errorf("unsupported IP2Location CSV database with %d columns", len(row))
+	}
+	return db.Add(start, end, record)
+}
+
+// parseRange parses the decimal IP numbers of a range. IPv4 databases use 32-bit numbers, while
+// IPv6 databases use 128-bit numbers, with IPv4 addresses in the IPv4-mapped range.
+func parseRange(from, to string) (netip.Addr, netip.Addr, error) {
+	start, ok := new(big.Int).SetString(from, 10)
+	if !ok {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid IP number %q", from)
+	}
+	end, ok := new(big.Int).SetString(to, 10)
+	if !ok {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid IP number %q", to)
+	}
+	ipv4 := end.IsUint64() && end.Uint64() <= math.MaxUint32
+	startAddr, err := ipFromNumber(start, ipv4)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+	endAddr, err := ipFromNumber(end, ipv4)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, err
+	}
+	return startAddr, endAddr, nil
+}
+
+func ipFromNumber(n *big.Int, ipv4 bool) (netip.Addr, error) {
+	if n.Sign() < 0 || n.BitLen() > 128 {
+		return netip.Addr{}, fmt.Errorf("invalid IP number %s", n)
+	}
+	if ipv4 {
+		var b [4]byte
+		n.FillBytes(b[:])
+		return netip.AddrFrom4(b), nil
+	}
+	var b [16]byte
+	n.FillBytes(b[:])
+	return netip.AddrFrom16(b), nil
+}
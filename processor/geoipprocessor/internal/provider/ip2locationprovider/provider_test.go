@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2location
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+)
+
+func TestProviderLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		databasePath  string
+		ip            string
+		expected      []attribute.KeyValue
+		expectedError error
+	}{
+		{
+			name:         "DB11 database",
+			databasePath: "testdata/IP2LOCATION-LITE-DB11.CSV",
+			ip:           "1.0.0.1",
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Los Angeles"),
+				attribute.String(conventions.AttributeGeoCountryName, "United States of America"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+				attribute.String(conventions.AttributeGeoRegionName, "California"),
+				attribute.String(conventions.AttributeGeoPostalCode, "90001"),
+				attribute.String(conventions.AttributeGeoTimezone, "-07:00"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 34.05223),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -118.24368),
+			},
+		},
+		{
+			name:          "DB11 database reserved range",
+			databasePath:  "testdata/IP2LOCATION-LITE-DB11.CSV",
+			ip:            "0.1.2.3",
+			expectedError: provider.ErrNoMetadataFound,
+		},
+		{
+			name:         "DB1 IPv6 database with an IPv4 address",
+			databasePath: "testdata/IP2LOCATION-LITE-DB1.IPV6.CSV",
+			ip:           "1.0.2.1",
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCountryName, "China"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "CN"),
+			},
+		},
+		{
+			name:         "DB1 IPv6 database with an IPv6 address",
+			databasePath: "testdata/IP2LOCATION-LITE-DB1.IPV6.CSV",
+			ip:           "2001:4860:4860::8888",
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCountryName, "United States of America"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+			},
+		},
+		{
+			name:         "ASN database",
+			databasePath: "testdata/IP2LOCATION-LITE-ASN.CSV",
+			ip:           "1.0.0.1",
+			expected: []attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 13335),
+				attribute.String(conventions.AttributeASOrganizationName, "CloudFlare Inc."),
+			},
+		},
+		{
+			name:          "ASN database unassigned range",
+			databasePath:  "testdata/IP2LOCATION-LITE-ASN.CSV",
+			ip:            "1.0.1.1",
+			expectedError: provider.ErrNoMetadataFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newIP2LocationProvider(&Config{Config: ipdb.Config{DatabasePath: tt.databasePath}})
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, p.Close(t.Context())) }()
+
+			attributes, err := p.Location(t.Context(), netip.MustParseAddr(tt.ip))
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			expected := attribute.NewSet(tt.expected...)
+			assert.True(t, expected.Equals(&attributes), "unexpected attributes: %v", attributes.ToSlice())
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		from, to      string
+		start, end    string
+		expectedError string
+	}{
+		{from: "16777216", to: "16777471", start: "1.0.0.0", end: "1.0.0.255"},
+		{from: "0", to: "4294967295", start: "0.0.0.0", end: "255.255.255.255"},
+		{from: "281470698520576", to: "281470698520831", start: "1.0.0.0", end: "1.0.0.255"},
+		{from: "42541956101370907050197289607612071936", to: "42541956180599069564461627201156022271", start: "2001:4860::", end: "2001:4860:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{from: "1.0.0.0", to: "16777471", expectedError: "invalid IP number"},
+		{from: "0", to: "340282366920938463463374607431768211456", expectedError: "invalid IP number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"-"+tt.to, func(t *testing.T) {
+			start, end, err := parseRange(tt.from, tt.to)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.start, start.Unmap().String())
+			assert.Equal(t, tt.end, end.Unmap().String())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatCSV is the format of databases distributed as comma-separated values.
+	FormatCSV = "csv"
+	// FormatMMDB is the MaxMind DB format, used by GeoIP2 compatible databases.
+	FormatMMDB = "mmdb"
+)
+
+// Config defines the configuration shared by the providers reading a database in either CSV or MMDB format.
+type Config struct {
+	// DatabasePath section allows specifying a local database
+	// file to retrieve the geographical metadata from.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// Format of the database file, either `csv` or `mmdb`.
+	// By default, the format is inferred from the extension of the database file.
+	Format string `mapstructure:"format"`
+
+	// ReloadInterval is the interval between checks for changes of the database file.
+	// When the file is modified or replaced, the database is reopened.
+	// The default value (0) disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+// Validate checks the database path, format and reload interval.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local geoIP database path must be provided")
+	}
+	if _, err := c.DatabaseFormat(); err != nil {
+		return err
+	}
+	if c.ReloadInterval < 0 {
+		return fmt.Errorf("invalid reload_interval: %v, the duration should be positive", c.ReloadInterval)
+	}
+	return nil
+}
+
+// DatabaseFormat returns the configured format, or the one inferred from the database file extension.
+func (c *Config) DatabaseFormat() (string, error) {
+	format := strings.ToLower(c.Format)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(c.DatabasePath)), ".")
+		if format != FormatCSV && format != FormatMMDB {
+			return "", fmt.Errorf("cannot infer the database format from %q, the format must be set to %q or %q", c.DatabasePath, FormatCSV, FormatMMDB)
+		}
+	}
+	if format != FormatCSV && format != FormatMMDB {
+		return "", fmt.Errorf("unknown database format %q, available values: %s, %s", c.Format, FormatCSV, FormatMMDB)
+	}
+	return format, nil
+}
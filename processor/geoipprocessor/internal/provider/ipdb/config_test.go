@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         Config
+		expectedFormat string
+		expectedErr    string
+	}{
+		{
+			name:        "missing path",
+			config:      Config{},
+			expectedErr: "a local geoIP database path must be provided",
+		},
+		{
+			name:           "csv extension",
+			config:         Config{DatabasePath: "/data/IP2LOCATION-LITE-DB11.CSV"},
+			expectedFormat: FormatCSV,
+		},
+		{
+			name:           "mmdb extension",
+			config:         Config{DatabasePath: "/data/dbip-city-lite.mmdb"},
+			expectedFormat: FormatMMDB,
+		},
+		{
+			name:           "explicit format",
+			config:         Config{DatabasePath: "/data/dbip-city-lite", Format: "CSV"},
+			expectedFormat: FormatCSV,
+		},
+		{
+			name:        "unknown extension",
+			config:      Config{DatabasePath: "/data/dbip-city-lite.csv.gz"},
+			expectedErr: `cannot infer the database format from "/data/dbip-city-lite.csv.gz"`,
+		},
+		{
+			name:        "unknown format",
+			config:      Config{DatabasePath: "/data/IP2LOCATION-LITE-DB11.BIN", Format: "bin"},
+			expectedErr: `unknown database format "bin"`,
+		},
+		{
+			name:        "negative reload interval",
+			config:      Config{DatabasePath: "/data/dbip-city-lite.mmdb", ReloadInterval: -time.Second},
+			expectedErr: "invalid reload_interval: -1s, the duration should be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			format, err := tt.config.DatabaseFormat()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFormat, format)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/oschwald/maxminddb-golang/v2"
+)
+
+// mmdbNames holds the localized names of a GeoIP2 compatible record.
+type mmdbNames struct {
+	English string `maxminddb:"en"`
+}
+
+// mmdbRecord is the union of the fields of the GeoIP2 compatible City, Country, ASN and ISP
+// records. Decoding every record into it supports the databases of other vendors, whatever
+// database type their metadata declares.
+type mmdbRecord struct {
+	City struct {
+		Names mmdbNames `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Continent struct {
+		Names mmdbNames `maxminddb:"names"`
+		Code  string    `maxminddb:"code"`
+	} `maxminddb:"continent"`
+	Country struct {
+		Names   mmdbNames `maxminddb:"names"`
+		ISOCode string    `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+		TimeZone  string   `maxminddb:"time_zone"`
+	} `maxminddb:"location"`
+	Postal struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"postal"`
+	Subdivisions []struct {
+		Names   mmdbNames `maxminddb:"names"`
+		ISOCode string    `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+
+	AutonomousSystemNumber       uint   `maxminddb:"autonomous_system_number"`
+	AutonomousSystemOrganization string `maxminddb:"autonomous_system_organization"`
+	ISP                          string `maxminddb:"isp"`
+	Organization                 string `maxminddb:"organization"`
+}
+
+// MMDB is a database in MaxMind DB format.
+type MMDB struct {
+	reader *maxminddb.Reader
+}
+
+// OpenMMDB opens the MaxMind DB file at path.
+func OpenMMDB(path string) (*MMDB, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open geoip database: %w", err)
+	}
+	return &MMDB{reader: reader}, nil
+}
+
+// DatabaseType returns the database type declared in the metadata of the database.
+func (db *MMDB) DatabaseType() string {
+	return db.reader.Metadata.DatabaseType
+}
+
+// Lookup returns the record of ip, if the database contains it.
+func (db *MMDB) Lookup(ip netip.Addr) (*Record, bool, error) {
+	result := db.reader.Lookup(ip)
+	if err := result.Err(); err != nil {
+		return nil, false, err
+	}
+	if !result.Found() {
+		return nil, false, nil
+	}
+	var r mmdbRecord
+	if err := result.Decode(&r); err != nil {
+		return nil, false, err
+	}
+
+	record := &Record{
+		CityName:       r.City.Names.English,
+		CountryName:    r.Country.Names.English,
+		CountryISOCode: r.Country.ISOCode,
+		ContinentName:  r.Continent.Names.English,
+		ContinentCode:  r.Continent.Code,
+		PostalCode:     r.Postal.Code,
+		Timezone:       r.Location.TimeZone,
+		ASNumber:       r.AutonomousSystemNumber,
+		ASOrganization: r.AutonomousSystemOrganization,
+		ISP:            r.ISP,
+		Organization:   r.Organization,
+	}
+	if len(r.Subdivisions) > 0 {
+		// The most specific subdivision is located at the last array position.
+		subdivision := r.Subdivisions[len(r.Subdivisions)-1]
+		record.RegionName = subdivision.Names.English
+		record.RegionISOCode = subdivision.ISOCode
+	}
+	if r.Location.Latitude != nil && r.Location.Longitude != nil {
+		record.HasLocation = true
+		record.Latitude = *r.Location.Latitude
+		record.Longitude = *r.Location.Longitude
+	}
+	return record, true, nil
+}
+
+// Close unmaps the database file from virtual memory.
+func (db *MMDB) Close() error {
+	return db.reader.Close()
+}
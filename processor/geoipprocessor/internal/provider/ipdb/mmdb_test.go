@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb
+
+import (
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// writeMMDB writes a database of the given type with a single network.
+func writeMMDB(t *testing.T, databaseType, network string, value mmdbtype.Map) string {
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: databaseType, IPVersion: 6, RecordSize: 28})
+	require.NoError(t, err)
+	_, ipNet, err := net.ParseCIDR(network)
+	require.NoError(t, err)
+	require.NoError(t, tree.Insert(ipNet, value))
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	_, err = tree.WriteTo(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return path
+}
+
+func names(name string) mmdbtype.Map {
+	return mmdbtype.Map{"names": mmdbtype.Map{"en": mmdbtype.String(name), "fr": mmdbtype.String("ignored")}}
+}
+
+func TestMMDBProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		databaseType string
+		value        mmdbtype.Map
+		expected     []attribute.KeyValue
+	}{
+		{
+			name:         "IP2Location city database",
+			databaseType: "IP2LITE-DB11",
+			value: mmdbtype.Map{
+				"city":    names("Los Angeles"),
+				"country": mmdbtype.Map{"names": names("United States")["names"], "iso_code": mmdbtype.String("US")},
+				"location": mmdbtype.Map{
+					"latitude":  mmdbtype.Float64(34.05),
+					"longitude": mmdbtype.Float64(-118.24),
+					"time_zone": mmdbtype.String("America/Los_Angeles"),
+				},
+				"postal": mmdbtype.Map{"code": mmdbtype.String("90001")},
+				"subdivisions": mmdbtype.Slice{
+					mmdbtype.Map{"names": names("California")["names"], "iso_code": mmdbtype.String("CA")},
+				},
+			},
+			expected: []attribute.KeyValue{
+				attribute.String(conventions.AttributeGeoCityName, "Los Angeles"),
+				attribute.String(conventions.AttributeGeoCountryName, "United States"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+				attribute.String(conventions.AttributeGeoRegionName, "California"),
+				attribute.String(conventions.AttributeGeoRegionIsoCode, "CA"),
+				attribute.String(conventions.AttributeGeoPostalCode, "90001"),
+				attribute.String(conventions.AttributeGeoTimezone, "America/Los_Angeles"),
+				attribute.Float64(conventions.AttributeGeoLocationLat, 34.05),
+				attribute.Float64(conventions.AttributeGeoLocationLon, -118.24),
+			},
+		},
+		{
+			name:         "DB-IP ASN database",
+			databaseType: "DBIP-ASN-Lite (compat=GeoLite2-ASN)",
+			value: mmdbtype.Map{
+				"autonomous_system_number":       mmdbtype.Uint32(13335),
+				"autonomous_system_organization": mmdbtype.String("Cloudflare, Inc."),
+			},
+			expected: []attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 13335),
+				attribute.String(conventions.AttributeASOrganizationName, "Cloudflare, Inc."),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := OpenMMDB(writeMMDB(t, tt.databaseType, "1.0.0.0/24", tt.value))
+			require.NoError(t, err)
+			assert.Equal(t, tt.databaseType, db.DatabaseType())
+			p := NewProvider(db)
+
+			attributes, err := p.Location(t.Context(), netip.MustParseAddr("1.0.0.1"))
+			require.NoError(t, err)
+			expected := attribute.NewSet(tt.expected...)
+			assert.True(t, expected.Equals(&attributes), "unexpected attributes: %v", attributes.ToSlice())
+
+			_, err = p.Location(t.Context(), netip.MustParseAddr("1.0.1.1"))
+			assert.ErrorIs(t, err, provider.ErrNoMetadataFound)
+			assert.NoError(t, p.Close(t.Context()))
+		})
+	}
+}
+
+func TestOpenMMDBError(t *testing.T) {
+	_, err := OpenMMDB(filepath.Join(t.TempDir(), "missing.mmdb"))
+	assert.ErrorContains(t, err, "could not open geoip database")
+}
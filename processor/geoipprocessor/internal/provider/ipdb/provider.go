@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ipdb provides the building blocks of the providers reading IP databases in CSV or MMDB format.
+package ipdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+
+import (
+	"context"
+	"net/netip"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// Database looks up the record of an IP address.
+type Database interface {
+	Lookup(ip netip.Addr) (*Record, bool, error)
+	Close() error
+}
+
+// memoryDatabase adapts a RangeDB to Database.
+type memoryDatabase struct {
+	*RangeDB
+}
+
+func (db memoryDatabase) Lookup(ip netip.Addr) (*Record, bool, error) {
+	record, found := db.RangeDB.Lookup(ip)
+	return record, found, nil
+}
+
+func (memoryDatabase) Close() error {
+	return nil
+}
+
+// InMemory returns a Database looking up the ranges of db.
+func InMemory(db *RangeDB) Database {
+	return memoryDatabase{RangeDB: db}
+}
+
+type databaseProvider struct {
+	db Database
+}
+
+var _ provider.GeoIPProvider = (*databaseProvider)(nil)
+
+// NewProvider returns a GeoIPProvider looking up IP addresses in db.
+func NewProvider(db Database) provider.GeoIPProvider {
+	return &databaseProvider{db: db}
+}
+
+// Location implements provider.GeoIPProvider. If no metadata is found in the database, provider.ErrNoMetadataFound is returned.
+func (p *databaseProvider) Location(_ context.Context, ip netip.Addr) (attribute.Set, error) {
+	record, found, err := p.db.Lookup(ip)
+	if err != nil {
+		return attribute.Set{}, err
+	}
+	if !found {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	attributes := record.Attributes()
+	if len(attributes) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(attributes...), nil
+}
+
+// Close releases the resources of the database.
+func (p *databaseProvider) Close(context.Context) error {
+	return p.db.Close()
+}
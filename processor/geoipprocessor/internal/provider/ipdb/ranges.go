@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"sort"
+)
+
+// key is a 128-bit IP address. IPv4 addresses use their IPv4-mapped IPv6 form, so that the IPv4
+// ranges of IPv4 and IPv6 databases are looked up the same way.
+type key struct {
+	hi, lo uint64
+}
+
+func keyOf(ip netip.Addr) key {
+	b := ip.As16()
+	return key{hi: binary.BigEndian.Uint64(b[:8]), lo: binary.BigEndian.Uint64(b[8:])}
+}
+
+func (k key) less(other key) bool {
+	return k.hi < other.hi || (k.hi == other.hi && k.lo < other.lo)
+}
+
+type ipRange struct {
+	start, end key
+	record     int
+}
+
+// RangeDB is an in-memory database of IP ranges, as distributed in CSV format.
+// Ranges are added with Add, then Build must be called before any Lookup.
+type RangeDB struct {
+	ranges  []ipRange
+	records []Record
+	// recordIndex deduplicates the records while the database is built.
+	recordIndex map[Record]int
+	// stringIndex deduplicates the strings of the records while the database is built.
+	stringIndex map[string]string
+}
+
+// NewRangeDB creates an empty RangeDB.
+func NewRangeDB() *RangeDB {
+	return &RangeDB{
+		recordIndex: map[Record]int{},
+		stringIndex: map[string]string{},
+	}
+}
+
+// Intern returns a shared copy of s, to reduce the memory used by the repeated values of a database.
+func (db *RangeDB) Intern(s string) string {
+	if interned, ok := db.stringIndex[s]; ok {
+		return interned
+	}
+	db.stringIndex[s] = s
+	return s
+}
+
+// Add adds the range of IP addresses from start to end, both included, with the given record.
+func (db *RangeDB) Add(start, end netip.Addr, record Record) error {
+	startKey, endKey := keyOf(start), keyOf(end)
+	if endKey.less(startKey) {
+		return fmt.Errorf("invalid IP range: %s is after %s", start, end)
+	}
+	index, ok := db.recordIndex[record]
+	if !ok {
+		index = len(db.records)
+		db.records = append(db.records, record)
+		db.recordIndex[record] = index
+	}
+	db.ranges = append(db.ranges, ipRange{start: startKey, end: endKey, record: index})
+	return nil
+}
+
+// Build sorts the ranges for lookups and releases the memory only needed while adding ranges.
+func (db *RangeDB) Build() {
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return db.ranges[i].start.less(db.ranges[j].start)
+	})
+	db.recordIndex = nil
+	db.stringIndex = nil
+}
+
+// Len returns the number of ranges of the database.
+func (db *RangeDB) Len() int {
+	return len(db.ranges)
+}
+
+// Lookup returns the record of the range containing ip.
+func (db *RangeDB) Lookup(ip netip.Addr) (*Record, bool) {
+	k := keyOf(ip)
+	// Find the first range starting after ip: the previous one is the only candidate.
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return k.less(db.ranges[i].start)
+	})
+	if i == 0 {
+		return nil, false
+	}
+	r := db.ranges[i-1]
+	if r.end.less(k) {
+		return nil, false
+	}
+	return &db.records[r.record], true
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeDB(t *testing.T) {
+	db := NewRangeDB()
+	au := Record{CountryISOCode: db.Intern("AU")}
+	cn := Record{CountryISOCode: db.Intern("CN")}
+	us := Record{CountryISOCode: db.Intern("US")}
+	// Ranges are added out of order, Build sorts them.
+	require.NoError(t, db.Add(netip.MustParseAddr("1.0.1.0"), netip.MustParseAddr("1.0.3.255"), cn))
+	require.NoError(t, db.Add(netip.MustParseAddr("1.0.0.0"), netip.MustParseAddr("1.0.0.255"), au))
+	require.NoError(t, db.Add(netip.MustParseAddr("2001:4860::"), netip.MustParseAddr("2001:4860:ffff:ffff:ffff:ffff:ffff:ffff"), us))
+	require.NoError(t, db.Add(netip.MustParseAddr("1.0.8.0"), netip.MustParseAddr("1.0.8.0"), au))
+	assert.ErrorContains(t, db.Add(netip.MustParseAddr("1.0.9.0"), netip.MustParseAddr("1.0.8.255"), au), "invalid IP range")
+	db.Build()
+	assert.Equal(t, 4, db.Len())
+	assert.Len(t, db.records, 3, "identical records must be shared")
+
+	tests := []struct {
+		ip       string
+		expected string
+	}{
+		{ip: "0.255.255.255"},
+		{ip: "1.0.0.0", expected: "AU"},
+		{ip: "1.0.0.255", expected: "AU"},
+		{ip: "1.0.1.0", expected: "CN"},
+		{ip: "1.0.2.128", expected: "CN"},
+		{ip: "1.0.3.255", expected: "CN"},
+		{ip: "1.0.4.0"},
+		{ip: "1.0.8.0", expected: "AU"},
+		{ip: "1.0.8.1"},
+		// IPv4-mapped IPv6 addresses are looked up as IPv4 addresses.
+		{ip: "::ffff:1.0.0.1", expected: "AU"},
+		{ip: "2001:4860:4860::8888", expected: "US"},
+		{ip: "2001:4861::"},
+		{ip: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			record, found := db.Lookup(netip.MustParseAddr(tt.ip))
+			if tt.expected == "" {
+				assert.False(t, found)
+				return
+			}
+			require.True(t, found)
+			assert.Equal(t, tt.expected, record.CountryISOCode)
+		})
+	}
+}
+
+func TestEmptyRangeDB(t *testing.T) {
+	db := NewRangeDB()
+	db.Build()
+	_, found := db.Lookup(netip.MustParseAddr("1.2.3.4"))
+	assert.False(t, found)
+}
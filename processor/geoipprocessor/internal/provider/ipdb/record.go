@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ipdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ipdb"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+)
+
+// Record holds the metadata of an IP address. It is comparable, so that identical records of
+// consecutive IP ranges can be shared.
+type Record struct {
+	CityName       string
+	CountryName    string
+	CountryISOCode string
+	ContinentName  string
+	ContinentCode  string
+	RegionName     string
+	RegionISOCode  string
+	PostalCode     string
+	Timezone       string
+	HasLocation    bool
+	Latitude       float64
+	Longitude      float64
+
+	ASNumber       uint
+	ASOrganization string
+	ISP            string
+	Organization   string
+}
+
+// Attributes returns the non-empty fields of the record, named according to the internal geo IP conventions.
+func (r *Record) Attributes() []attribute.KeyValue {
+	attributes := make([]attribute.KeyValue, 0, 16)
+	appendIfNotEmpty := func(keyName, value string) {
+		if value != "" {
+			attributes = append(attributes, attribute.String(keyName, value))
+		}
+	}
+
+	appendIfNotEmpty(conventions.AttributeGeoCityName, r.CityName)
+	appendIfNotEmpty(conventions.AttributeGeoCountryName, r.CountryName)
+	appendIfNotEmpty(conventions.AttributeGeoCountryIsoCode, r.CountryISOCode)
+	appendIfNotEmpty(conventions.AttributeGeoContinentName, r.ContinentName)
+	appendIfNotEmpty(conventions.AttributeGeoContinentCode, r.ContinentCode)
+	appendIfNotEmpty(conventions.AttributeGeoPostalCode, r.PostalCode)
+	appendIfNotEmpty(conventions.AttributeGeoRegionName, r.RegionName)
+	appendIfNotEmpty(conventions.AttributeGeoRegionIsoCode, r.RegionISOCode)
+	appendIfNotEmpty(conventions.AttributeGeoTimezone, r.Timezone)
+	if r.HasLocation {
+		attributes = append(attributes, attribute.Float64(conventions.AttributeGeoLocationLat, r.Latitude), attribute.Float64(conventions.AttributeGeoLocationLon, r.Longitude))
+	}
+
+	if r.ASNumber != 0 {
+		attributes = append(attributes, attribute.Int64(conventions.AttributeASNumber, int64(r.ASNumber)))
+	}
+	appendIfNotEmpty(conventions.AttributeASOrganizationName, r.ASOrganization)
+	appendIfNotEmpty(conventions.AttributeISPName, r.ISP)
+	appendIfNotEmpty(conventions.AttributeISPOrganizationName, r.Organization)
+
+	return attributes
+}
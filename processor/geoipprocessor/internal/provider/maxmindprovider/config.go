@@ -5,6 +5,8 @@ package maxmind // import "github.com/open-telemetry/opentelemetry-collector-con
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
 )
@@ -14,6 +16,11 @@ type Config struct {
 	// DatabasePath section allows specifying a local GeoIP database
 	// file to retrieve the geographical metadata from.
 	DatabasePath string `mapstructure:"database_path"`
+
+	// ReloadInterval is the interval between checks for changes of the database file.
+	// When the file is modified or replaced, the database is reopened.
+	// The default value (0) disables reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
 }
 
 var _ provider.Config = (*Config)(nil)
@@ -23,5 +30,8 @@ func (c *Config) Validate() error {
 	if c.DatabasePath == "" {
 		return errors.New("a local geoIP database path must be provided")
 	}
+	if c.ReloadInterval < 0 {
+		return fmt.Errorf("invalid reload_interval: %v, the duration should be positive", c.ReloadInterval)
+	}
 	return nil
 }
@@ -27,7 +27,13 @@ func (*Factory) CreateDefaultConfig() provider.Config {
 }
 
 // CreateGeoIPProvider creates a provider based on this config.
-func (*Factory) CreateGeoIPProvider(_ context.Context, _ processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+func (*Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
 	maxMindConfig := cfg.(*Config)
-	return newMaxMindProvider(maxMindConfig)
+	return provider.NewReloadingProvider(maxMindConfig.DatabasePath, maxMindConfig.ReloadInterval, settings.Logger, func() (provider.GeoIPProvider, error) {
+		geoProvider, err := newMaxMindProvider(maxMindConfig)
+		if err != nil {
+			return nil, err
+		}
+		return geoProvider, nil
+	})
 }
@@ -4,12 +4,17 @@
 package maxmind
 
 import (
+	"net/netip"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/processor/processortest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider/testdata"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -29,3 +34,20 @@ func TestCreateProvider(t *testing.T) {
 	assert.ErrorContains(t, err, "could not open geoip database")
 	assert.Nil(t, provider)
 }
+
+func TestCreateReloadingProvider(t *testing.T) {
+	tmpDBfiles := testdata.GenerateLocalDB(t, "./testdata")
+
+	factory := &Factory{}
+	cfg := &Config{
+		DatabasePath:   filepath.Join(tmpDBfiles, "GeoLite2-ASN-Test.mmdb"),
+		ReloadInterval: time.Minute,
+	}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	attributes, err := provider.Location(t.Context(), netip.MustParseAddr("1.128.0.1"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, attributes.Len())
+	assert.NoError(t, provider.Close(t.Context()))
+}
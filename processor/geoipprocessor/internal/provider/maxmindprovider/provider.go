@@ -21,6 +21,26 @@ var (
 	defaultLanguageCode = "en"
 	geoIP2CityDBType    = "GeoIP2-City"
 	geoLite2CityDBType  = "GeoLite2-City"
+	// cityDBTypes holds the database types supporting City lookups, including the Country
+	// databases and the compatible DB-IP databases.
+	cityDBTypes = map[string]bool{
+		geoIP2CityDBType:              true,
+		geoLite2CityDBType:            true,
+		"GeoIP2-Country":              true,
+		"GeoLite2-Country":            true,
+		"DBIP-City-Lite":              true,
+		"DBIP-Country-Lite":           true,
+		"DBIP-Country":                true,
+		"DBIP-Location (compat=City)": true,
+	}
+	asnDBTypes = map[string]bool{
+		"GeoLite2-ASN":                        true,
+		"DBIP-ASN-Lite (compat=GeoLite2-ASN)": true,
+	}
+	ispDBTypes = map[string]bool{
+		"GeoIP2-ISP":           true,
+		"GeoIP2-Precision-ISP": true,
+	}
 
 	errUnsupportedDB = errors.New("unsupported geo IP database type")
 )
@@ -42,20 +62,26 @@ func newMaxMindProvider(cfg *Config) (*maxMindProvider, error) {
 	return &maxMindProvider{geoReader: geoReader, langCode: defaultLanguageCode}, nil
 }
 
-// Location implements provider.GeoIPProvider for MaxMind. If a non City database type is used or no metadata is found in the database, an error will be returned.
+// Location implements provider.GeoIPProvider for MaxMind. If an unsupported database type is used or no metadata is found in the database, an error will be returned.
 func (g *maxMindProvider) Location(_ context.Context, ipAddress netip.Addr) (attribute.Set, error) {
-	switch g.geoReader.Metadata().DatabaseType {
-	case geoIP2CityDBType, geoLite2CityDBType:
-		attrs, err := g.cityAttributes(ipAddress)
-		if err != nil {
-			return attribute.Set{}, err
-		} else if len(*attrs) == 0 {
-			return attribute.Set{}, provider.ErrNoMetadataFound
-		}
-		return attribute.NewSet(*attrs...), nil
+	var attrs *[]attribute.KeyValue
+	var err error
+	switch dbType := g.geoReader.Metadata().DatabaseType; {
+	case cityDBTypes[dbType]:
+		attrs, err = g.cityAttributes(ipAddress)
+	case asnDBTypes[dbType]:
+		attrs, err = g.asnAttributes(ipAddress)
+	case ispDBTypes[dbType]:
+		attrs, err = g.ispAttributes(ipAddress)
 	default:
-		return attribute.Set{}, fmt.Errorf("%w type: %s", errUnsupportedDB, g.geoReader.Metadata().DatabaseType)
+		return attribute.Set{}, fmt.Errorf("%w type: %s", errUnsupportedDB, dbType)
 	}
+	if err != nil {
+		return attribute.Set{}, err
+	} else if len(*attrs) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	return attribute.NewSet(*attrs...), nil
 }
 
 // Close unmaps the geo database file from virtual memory and returns the
@@ -109,3 +135,47 @@ func (g *maxMindProvider) cityAttributes(ipAddress netip.Addr) (*[]attribute.Key
 
 	return &attributes, err
 }
+
+// asnAttributes returns the autonomous system number and organization associated to the provided IP.
+func (g *maxMindProvider) asnAttributes(ipAddress netip.Addr) (*[]attribute.KeyValue, error) {
+	attributes := make([]attribute.KeyValue, 0, 2)
+
+	asn, err := g.geoReader.ASN(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if asn.AutonomousSystemNumber != 0 {
+		attributes = append(attributes, attribute.Int64(conventions.AttributeASNumber, int64(asn.AutonomousSystemNumber)))
+	}
+	if asn.AutonomousSystemOrganization != "" {
+		attributes = append(attributes, attribute.String(conventions.AttributeASOrganizationName, asn.AutonomousSystemOrganization))
+	}
+
+	return &attributes, nil
+}
+
+// ispAttributes returns the internet service provider and autonomous system metadata associated to the provided IP.
+func (g *maxMindProvider) ispAttributes(ipAddress netip.Addr) (*[]attribute.KeyValue, error) {
+	attributes := make([]attribute.KeyValue, 0, 4)
+
+	isp, err := g.geoReader.ISP(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	appendIfNotEmpty := func(keyName, value string) {
+		if value != "" {
+			attributes = append(attributes, attribute.String(keyName, value))
+		}
+	}
+
+	if isp.AutonomousSystemNumber != 0 {
+		attributes = append(attributes, attribute.Int64(conventions.AttributeASNumber, int64(isp.AutonomousSystemNumber)))
+	}
+	appendIfNotEmpty(conventions.AttributeASOrganizationName, isp.AutonomousSystemOrganization)
+	appendIfNotEmpty(conventions.AttributeISPName, isp.ISP)
+	appendIfNotEmpty(conventions.AttributeISPOrganizationName, isp.Organization)
+
+	return &attributes, nil
+}
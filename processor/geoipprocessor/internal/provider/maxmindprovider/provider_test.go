@@ -46,8 +46,14 @@ func TestProviderLocation(t *testing.T) {
 		{
 			name:           "unsupported database type",
 			sourceIP:       netip.AddrFrom4([4]byte{0, 0, 0, 0}),
-			testDatabase:   "GeoIP2-ISP-Test.mmdb",
-			expectedErrMsg: "unsupported geo IP database type type: GeoIP2-ISP",
+			testDatabase:   "GeoIP2-Domain-Test.mmdb",
+			expectedErrMsg: "unsupported geo IP database type type: GeoIP2-Domain",
+		},
+		{
+			name:           "no IP metadata in ASN database",
+			sourceIP:       netip.AddrFrom4([4]byte{1, 2, 3, 4}),
+			testDatabase:   "GeoLite2-ASN-Test.mmdb",
+			expectedErrMsg: "no geo IP metadata found",
 		},
 		{
 			name:           "no IP metadata in database",
@@ -87,6 +93,26 @@ func TestProviderLocation(t *testing.T) {
 				attribute.Float64(conventions.AttributeGeoLocationLon, 1),
 			}...),
 		},
+		{
+			name:         "autonomous system attributes using GeoLite2-ASN database",
+			sourceIP:     netip.AddrFrom4([4]byte{1, 128, 0, 1}),
+			testDatabase: "GeoLite2-ASN-Test.mmdb",
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 1221),
+				attribute.String(conventions.AttributeASOrganizationName, "Telstra Pty Ltd"),
+			}...),
+		},
+		{
+			name:         "ISP attributes using GeoIP2-ISP database",
+			sourceIP:     netip.AddrFrom4([4]byte{1, 128, 0, 1}),
+			testDatabase: "GeoIP2-ISP-Test.mmdb",
+			expectedAttributes: attribute.NewSet([]attribute.KeyValue{
+				attribute.Int64(conventions.AttributeASNumber, 1221),
+				attribute.String(conventions.AttributeASOrganizationName, "Telstra Pty Ltd"),
+				attribute.String(conventions.AttributeISPName, "Telstra Internet"),
+				attribute.String(conventions.AttributeISPOrganizationName, "Telstra Internet"),
+			}...),
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// reloadingProvider wraps a GeoIPProvider reading a database file, and replaces it with a new
+// provider when the file is modified or replaced on disk.
+type reloadingProvider struct {
+	path     string
+	interval time.Duration
+	open     func() (GeoIPProvider, error)
+	logger   *zap.Logger
+
+	// mu guards current: lookups hold the read lock so that a replaced provider
+	// is only closed once no lookup uses it anymore.
+	mu      sync.RWMutex
+	current GeoIPProvider
+	// info is the state of the file when it was last opened.
+	info os.FileInfo
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+var _ GeoIPProvider = (*reloadingProvider)(nil)
+
+// NewReloadingProvider opens a GeoIPProvider for the database file at path using open. When interval
+// is positive, the file is checked for changes every interval, and the provider is reopened when the
+// file is modified or replaced. If reopening fails, the previous provider keeps serving lookups.
+func NewReloadingProvider(path string, interval time.Duration, logger *zap.Logger, open func() (GeoIPProvider, error)) (GeoIPProvider, error) {
+	if interval <= 0 {
+		return open()
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat geoip database: %w", err)
+	}
+	current, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &reloadingProvider{
+		path:     path,
+		interval: interval,
+		open:     open,
+		logger:   logger,
+		current:  current,
+		info:     info,
+		done:     make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go p.watch()
+	return p, nil
+}
+
+// Location implements GeoIPProvider using the latest opened database.
+func (p *reloadingProvider) Location(ctx context.Context, ip netip.Addr) (attribute.Set, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.current.Location(ctx, ip)
+}
+
+// Close stops watching the database file and closes the current provider.
+func (p *reloadingProvider) Close(ctx context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current.Close(ctx)
+}
+
+func (p *reloadingProvider) watch() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.reloadIfChanged()
+		}
+	}
+}
+
+func (p *reloadingProvider) reloadIfChanged() {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		// The file might be temporarily missing while it is being replaced.
+		if !errors.Is(err, os.ErrNotExist) {
+			p.logger.Warn("could not stat geoip database", zap.String("path", p.path), zap.Error(err))
+		}
+		return
+	}
+	if os.SameFile(info, p.info) && info.ModTime().Equal(p.info.ModTime()) && info.Size() == p.info.Size() {
+		return
+	}
+	// Record the new state even if opening fails, so that a broken file is not reopened on every
+	// check: writing the complete file changes its state again.
+	p.info = info
+
+	next, err := p.open()
+	if err != nil {
+		p.logger.Warn("could not reload geoip database, keeping the previous one", zap.String("path", p.path), zap.Error(err))
+		return
+	}
+
+	p.mu.Lock()
+	previous := p.current
+	p.current = next
+	p.mu.Unlock()
+
+	if err := previous.Close(context.Background()); err != nil {
+		p.logger.Warn("could not close the previous geoip database", zap.String("path", p.path), zap.Error(err))
+	}
+	p.logger.Info("reloaded geoip database", zap.String("path", p.path))
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zaptest"
+)
+
+// fileProvider is a GeoIPProvider returning the content of the database file it was opened with.
+type fileProvider struct {
+	content string
+	closed  atomic.Bool
+}
+
+func (p *fileProvider) Location(context.Context, netip.Addr) (attribute.Set, error) {
+	return attribute.NewSet(attribute.String("content", p.content)), nil
+}
+
+func (p *fileProvider) Close(context.Context) error {
+	p.closed.Store(true)
+	return nil
+}
+
+func openFileProvider(path string, opened *[]*fileProvider) func() (GeoIPProvider, error) {
+	return func() (GeoIPProvider, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if string(content) == "invalid" {
+			return nil, errors.New("invalid database")
+		}
+		p := &fileProvider{content: string(content)}
+		*opened = append(*opened, p)
+		return p, nil
+	}
+}
+
+func location(t *testing.T, p GeoIPProvider) string {
+	attributes, err := p.Location(t.Context(), netip.MustParseAddr("1.2.3.4"))
+	require.NoError(t, err)
+	value, _ := attributes.Value("content")
+	return value.AsString()
+}
+
+// replaceFile atomically replaces the file at path, like database update tools do.
+func replaceFile(t *testing.T, path, content string) {
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestReloadingProviderDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	var opened []*fileProvider
+	p, err := NewReloadingProvider(path, 0, zaptest.NewLogger(t), openFileProvider(path, &opened))
+	require.NoError(t, err)
+	require.Len(t, opened, 1)
+	assert.Same(t, opened[0], p)
+}
+
+func TestReloadingProviderOpenError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	var opened []*fileProvider
+	_, err := NewReloadingProvider(path, time.Millisecond, zaptest.NewLogger(t), openFileProvider(path, &opened))
+	assert.ErrorContains(t, err, "could not stat geoip database")
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	_, err = NewReloadingProvider(path, time.Millisecond, zaptest.NewLogger(t), openFileProvider(path, &opened))
+	assert.EqualError(t, err, "invalid database")
+}
+
+func TestReloadingProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+
+	var opened []*fileProvider
+	p, err := NewReloadingProvider(path, 10*time.Millisecond, zaptest.NewLogger(t), openFileProvider(path, &opened))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", location(t, p))
+
+	replaceFile(t, path, "v2")
+	assert.Eventually(t, func() bool {
+		return location(t, p) == "v2"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, opened[0].closed.Load(), "the replaced provider must be closed")
+
+	// A database that cannot be opened keeps the previous one.
+	replaceFile(t, path, "invalid")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "v2", location(t, p))
+
+	replaceFile(t, path, "v3")
+	assert.Eventually(t, func() bool {
+		return location(t, p) == "v3"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, p.Close(t.Context()))
+	assert.True(t, opened[len(opened)-1].closed.Load())
+}
@@ -29,6 +29,21 @@ const (
 
 	// attributeField is the name of the attribute field
 	attributeField = "attributes"
+
+	// templateSourceDrain derives log templates with the Drain algorithm
+	templateSourceDrain = "drain"
+
+	// templateSourceAttribute reads log templates from a log record attribute
+	templateSourceAttribute = "attribute"
+
+	// defaultTemplateAttribute is the default template attribute, matching the drain processor
+	defaultTemplateAttribute = "log.record.template"
+
+	// defaultTemplateParametersAttribute is the default template parameters attribute
+	defaultTemplateParametersAttribute = "log.record.template.parameters"
+
+	// defaultMaxParameterSamples is the default number of distinct values kept per template parameter
+	defaultMaxParameterSamples = 10
 )
 
 // Config errors
@@ -51,6 +66,41 @@ type Config struct {
 	// MetadataCardinalityLimit limits the number of unique metadata combinations
 	// tracked simultaneously. 0 (default) means unbounded.
 	MetadataCardinalityLimit uint32 `mapstructure:"metadata_cardinality_limit"`
+	// Template configures deduplication on the template of the log records
+	// instead of the exact equality of their fields.
+	Template TemplateConfig `mapstructure:"template"`
+}
+
+// TemplateConfig is the configuration of the template deduplication mode.
+type TemplateConfig struct {
+	// Enabled switches the processor to the template mode: log records sharing
+	// the same template are aggregated, whatever the values of its parameters.
+	Enabled bool `mapstructure:"enabled"`
+	// Source of the templates, either `drain` to derive them from the log body
+	// with the Drain algorithm, or `attribute` to read them from Attribute, e.g.
+	// when written by the drain processor.
+	Source string `mapstructure:"source"`
+	// Attribute is the log record attribute holding the template. It is read
+	// with the `attribute` source and written on the emitted logs.
+	Attribute string `mapstructure:"attribute"`
+	// ParametersAttribute is the attribute of the emitted logs holding the
+	// sampled values of the template parameters.
+	ParametersAttribute string `mapstructure:"parameters_attribute"`
+	// MaxParameterSamples is the maximum number of distinct values sampled per
+	// template parameter and interval. 0 disables the sampling.
+	MaxParameterSamples int `mapstructure:"max_parameter_samples"`
+	// TreeDepth is the max depth of the Drain parse tree. Minimum: 3.
+	TreeDepth int `mapstructure:"tree_depth"`
+	// MergeThreshold is the minimum token-match ratio (0.0–1.0) required to
+	// merge a log line into an existing Drain cluster.
+	MergeThreshold float64 `mapstructure:"merge_threshold"`
+	// MaxNodeChildren is the maximum number of children per node of the Drain parse tree.
+	MaxNodeChildren int `mapstructure:"max_node_children"`
+	// MaxClusters is the maximum number of Drain clusters tracked. When the limit
+	// is reached, the least recently used cluster is evicted. 0 means unlimited.
+	MaxClusters int `mapstructure:"max_clusters"`
+	// ExtraDelimiters are additional token delimiters beyond whitespace.
+	ExtraDelimiters []string `mapstructure:"extra_delimiters"`
 }
 
 // createDefaultConfig returns the default config for the processor.
@@ -64,6 +114,16 @@ func createDefaultConfig() component.Config {
 		Conditions:               []string{},
 		MetadataKeys:             []string{},
 		MetadataCardinalityLimit: 0,
+		Template: TemplateConfig{
+			Source:              templateSourceDrain,
+			Attribute:           defaultTemplateAttribute,
+			ParametersAttribute: defaultTemplateParametersAttribute,
+			MaxParameterSamples: defaultMaxParameterSamples,
+			TreeDepth:           4,
+			MergeThreshold:      0.4,
+			MaxNodeChildren:     100,
+			ExtraDelimiters:     []string{},
+		},
 	}
 }
 
@@ -101,6 +161,48 @@ func (c Config) Validate() error {
 		return err
 	}
 
+	if c.Template.Enabled {
+		if len(c.IncludeFields) > 0 {
+			return errors.New("cannot define both include_fields and template")
+		}
+		if err := c.Template.Validate(); err != nil {
+			return fmt.Errorf("template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the template configuration
+func (c TemplateConfig) Validate() error {
+	switch c.Source {
+	case templateSourceDrain:
+		if c.TreeDepth < 3 {
+			return errors.New("tree_depth must be at least 3")
+		}
+		if c.MergeThreshold < 0 || c.MergeThreshold > 1 {
+			return errors.New("merge_threshold must be between 0 and 1")
+		}
+		if c.MaxNodeChildren <= 0 {
+			return errors.New("max_node_children must be greater than 0")
+		}
+		if c.MaxClusters < 0 {
+			return errors.New("max_clusters must not be negative")
+		}
+	case templateSourceAttribute:
+	default:
+		return fmt.Errorf("unknown source %q, available values: %s, %s", c.Source, templateSourceDrain, templateSourceAttribute)
+	}
+
+	if c.Attribute == "" {
+		return errors.New("attribute must be set")
+	}
+	if c.MaxParameterSamples < 0 {
+		return errors.New("max_parameter_samples must not be negative")
+	}
+	if c.MaxParameterSamples > 0 && c.ParametersAttribute == "" {
+		return errors.New("parameters_attribute must be set when max_parameter_samples is greater than 0")
+	}
 	return nil
 }
 
@@ -148,6 +148,101 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: errors.New("cannot define both exclude_fields and include_fields"),
 		},
+		{
+			desc: "valid template config",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				ExcludeFields:     []string{"attributes.trace_id"},
+				Template:          enabledTemplateConfig(func(*TemplateConfig) {}),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "valid template config reading an attribute",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template: enabledTemplateConfig(func(c *TemplateConfig) {
+					c.Source = templateSourceAttribute
+					c.TreeDepth = 0
+				}),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "invalid config defines both include_fields and template",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				IncludeFields:     []string{"body.thing"},
+				Template:          enabledTemplateConfig(func(*TemplateConfig) {}),
+			},
+			expectedErr: errors.New("cannot define both include_fields and template"),
+		},
+		{
+			desc: "invalid template source",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template:          enabledTemplateConfig(func(c *TemplateConfig) { c.Source = "regex" }),
+			},
+			expectedErr: errors.New(`template: unknown source "regex", available values: drain, attribute`),
+		},
+		{
+			desc: "invalid template tree depth",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template:          enabledTemplateConfig(func(c *TemplateConfig) { c.TreeDepth = 2 }),
+			},
+			expectedErr: errors.New("template: tree_depth must be at least 3"),
+		},
+		{
+			desc: "invalid template merge threshold",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template:          enabledTemplateConfig(func(c *TemplateConfig) { c.MergeThreshold = 1.5 }),
+			},
+			expectedErr: errors.New("template: merge_threshold must be between 0 and 1"),
+		},
+		{
+			desc: "invalid template attribute",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template:          enabledTemplateConfig(func(c *TemplateConfig) { c.Attribute = "" }),
+			},
+			expectedErr: errors.New("template: attribute must be set"),
+		},
+		{
+			desc: "invalid template parameters attribute",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template:          enabledTemplateConfig(func(c *TemplateConfig) { c.ParametersAttribute = "" }),
+			},
+			expectedErr: errors.New("template: parameters_attribute must be set when max_parameter_samples is greater than 0"),
+		},
+		{
+			desc: "disabled template config is not validated",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Template:          TemplateConfig{Source: "regex"},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -161,3 +256,11 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+// enabledTemplateConfig returns the default template config, enabled and modified by fn.
+func enabledTemplateConfig(fn func(*TemplateConfig)) TemplateConfig {
+	cfg := createDefaultConfig().(*Config).Template
+	cfg.Enabled = true
+	fn(&cfg)
+	return cfg
+}
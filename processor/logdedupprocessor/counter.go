@@ -30,16 +30,19 @@ type logAggregator struct {
 	timezone          *time.Location
 	telemetryBuilder  *metadata.TelemetryBuilder
 	dedupFields       []string
+	templates         *templateMatcher
 }
 
 // newLogAggregator creates a new LogCounter.
-func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string) *logAggregator {
+// templates is nil unless the log records are deduplicated on their template.
+func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string, templates *templateMatcher) *logAggregator {
 	return &logAggregator{
 		resources:         make(map[uint64]*resourceAggregator),
 		logCountAttribute: logCountAttribute,
 		timezone:          timezone,
 		telemetryBuilder:  telemetryBuilder,
 		dedupFields:       dedupFields,
+		templates:         templates,
 	}
 }
 
@@ -73,6 +76,14 @@ func (l *logAggregator) Export(ctx context.Context) plog.Logs {
 				lr.Attributes().PutStr(firstObservedTSAttr, firstTimestampStr)
 				lastTimestampStr := logAggregator.lastObservedTimestamp.In(l.timezone).Format(time.RFC3339)
 				lr.Attributes().PutStr(lastObservedTSAttr, lastTimestampStr)
+
+				// Add the template and the sampled values of its parameters
+				if logAggregator.template != nil {
+					lr.Attributes().PutStr(l.templates.attribute, logAggregator.template.template())
+					if l.templates.maxSamples > 0 {
+						logAggregator.template.putParameters(lr.Attributes().PutEmptySlice(l.templates.parametersAttribute))
+					}
+				}
 			}
 		}
 	}
@@ -85,7 +96,7 @@ func (l *logAggregator) Add(resource pcommon.Resource, scope pcommon.Instrumenta
 	key := getResourceKey(resource)
 	resourceAggregator, ok := l.resources[key]
 	if !ok {
-		resourceAggregator = newResourceAggregator(resource, l.dedupFields)
+		resourceAggregator = newResourceAggregator(resource, l.dedupFields, l.templates)
 		l.resources[key] = resourceAggregator
 	}
 	resourceAggregator.Add(scope, logRecord)
@@ -101,16 +112,18 @@ type resourceAggregator struct {
 	resource      pcommon.Resource
 	scopeCounters map[uint64]*scopeAggregator
 	dedupFields   []string
+	templates     *templateMatcher
 }
 
 // newResourceAggregator creates a new ResourceCounter.
-func newResourceAggregator(resource pcommon.Resource, dedupFields []string) *resourceAggregator {
+func newResourceAggregator(resource pcommon.Resource, dedupFields []string, templates *templateMatcher) *resourceAggregator {
 	cloneResource := pcommon.NewResource()
 	resource.CopyTo(cloneResource)
 	return &resourceAggregator{
 		resource:      cloneResource,
 		scopeCounters: make(map[uint64]*scopeAggregator),
 		dedupFields:   dedupFields,
+		templates:     templates,
 	}
 }
 
@@ -119,7 +132,7 @@ func (r *resourceAggregator) Add(scope pcommon.InstrumentationScope, logRecord p
 	key := getScopeKey(scope)
 	scopeAggregator, ok := r.scopeCounters[key]
 	if !ok {
-		scopeAggregator = newScopeAggregator(scope, r.dedupFields)
+		scopeAggregator = newScopeAggregator(scope, r.dedupFields, r.templates)
 		r.scopeCounters[key] = scopeAggregator
 	}
 	scopeAggregator.Add(logRecord)
@@ -130,21 +143,38 @@ type scopeAggregator struct {
 	scope       pcommon.InstrumentationScope
 	logCounters map[uint64]*logCounter
 	dedupFields []string
+	templates   *templateMatcher
 }
 
 // newScopeAggregator creates a new ScopeCounter.
-func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string) *scopeAggregator {
+func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string, templates *templateMatcher) *scopeAggregator {
 	cloneScope := pcommon.NewInstrumentationScope()
 	scope.CopyTo(cloneScope)
 	return &scopeAggregator{
 		scope:       cloneScope,
 		logCounters: make(map[uint64]*logCounter),
 		dedupFields: dedupFields,
+		templates:   templates,
 	}
 }
 
 // Add increments the counter that the logRecord matches.
+// In template mode, log records are matched on their template if they have one.
 func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
+	if s.templates != nil {
+		if tmpl, ok := s.templates.match(logRecord); ok {
+			lc, ok := s.logCounters[tmpl.key]
+			if !ok {
+				lc = newLogCounter(logRecord)
+				lc.template = newTemplateSample(s.templates.maxSamples)
+				s.logCounters[tmpl.key] = lc
+			}
+			lc.template.add(tmpl)
+			lc.Increment()
+			return
+		}
+	}
+
 	key := getLogKey(logRecord, s.dedupFields)
 	lc, ok := s.logCounters[key]
 	if !ok {
@@ -160,6 +190,8 @@ type logCounter struct {
 	firstObservedTimestamp time.Time
 	lastObservedTimestamp  time.Time
 	count                  int64
+	// template is nil unless the log records are matched on their template.
+	template *templateSample
 }
 
 // newLogCounter creates a new AttributeCounter.
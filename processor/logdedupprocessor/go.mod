@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/processor/logde
 go 1.25.0
 
 require (
+	github.com/jaeyo/go-drain3 v0.1.2
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.159.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden v0.159.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl v0.159.0
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain wraps the go-drain3 library behind the minimal API used by the
+// log deduplication processor to group log lines by template. It mirrors the
+// wrapper of the drain processor, which cannot be imported across modules.
+//
+// Thread safety: Drain is NOT goroutine-safe. Callers must serialize access
+// (e.g. with a sync.Mutex).
+package drain // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/drain"
+
+import (
+	"math"
+	"strings"
+
+	drain3 "github.com/jaeyo/go-drain3/pkg/drain3"
+)
+
+// Config holds parameters for the Drain parse tree.
+type Config struct {
+	// Depth is the maximum depth of the parse tree. Minimum 3 (go-drain3 requirement).
+	Depth int
+	// SimThreshold is the similarity threshold in [0.0, 1.0].
+	SimThreshold float64
+	// MaxChildren is the maximum number of children per tree node.
+	MaxChildren int
+	// MaxClusters limits the number of tracked clusters via LRU eviction.
+	// 0 means effectively unlimited (internally mapped to math.MaxInt32).
+	MaxClusters int
+	// ExtraDelimiters are additional token delimiters beyond whitespace.
+	ExtraDelimiters []string
+}
+
+// Drain wraps the go-drain3 log clustering engine.
+type Drain struct {
+	inner           *drain3.Drain
+	extraDelimiters []string
+}
+
+// NewDrain constructs a Drain instance from the provided Config.
+func NewDrain(cfg Config) (*Drain, error) {
+	maxClusters := cfg.MaxClusters
+	if maxClusters <= 0 {
+		// go-drain3 uses an LRU which requires a positive size; use MaxInt32 as
+		// "effectively unlimited" — the LRU doesn't pre-allocate so this is safe.
+		maxClusters = math.MaxInt32
+	}
+
+	inner, err := drain3.NewDrain(
+		drain3.WithDepth(int64(cfg.Depth)),
+		drain3.WithSimTh(cfg.SimThreshold),
+		drain3.WithMaxChildren(int64(cfg.MaxChildren)),
+		drain3.WithMaxCluster(maxClusters),
+		drain3.WithExtraDelimiter(cfg.ExtraDelimiters),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Drain{inner: inner, extraDelimiters: cfg.ExtraDelimiters}, nil
+}
+
+// Tokenise splits s the same way go-drain3 does internally: trim whitespace,
+// replace each configured extra delimiter with a space, then split on " ".
+// Template tokens returned by Train are aligned with the tokens of the line.
+func (d *Drain) Tokenise(s string) []string {
+	s = strings.TrimSpace(s)
+	for _, delim := range d.extraDelimiters {
+		s = strings.ReplaceAll(s, delim, " ")
+	}
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, " ")
+}
+
+// Train feeds line to the Drain tree, updating or creating a cluster.
+// It returns the identifier of the cluster and the tokens of its template.
+// Identifiers are stable for the lifetime of a cluster, while its template
+// becomes more generic as lines are added. ok is false if go-drain3 did not
+// return a cluster.
+func (d *Drain) Train(line string) (clusterID int64, tokens []string, ok bool, err error) {
+	cluster, _, err := d.inner.AddLogMessage(line)
+	if err != nil || cluster == nil {
+		return 0, nil, false, err
+	}
+	return cluster.ClusterId, cluster.LogTemplateTokens, true, nil
+}
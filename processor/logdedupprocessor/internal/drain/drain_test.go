@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package drain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultCfg() Config {
+	return Config{
+		Depth:        4,
+		SimThreshold: 0.4,
+		MaxChildren:  100,
+	}
+}
+
+func TestNewDrainInvalidDepth(t *testing.T) {
+	cfg := defaultCfg()
+	cfg.Depth = 2
+	_, err := NewDrain(cfg)
+	assert.Error(t, err)
+}
+
+func TestTrainSharesCluster(t *testing.T) {
+	d, err := NewDrain(defaultCfg())
+	require.NoError(t, err)
+
+	id1, tokens, ok, err := d.Train("user alice logged in from 10.0.0.1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"user", "alice", "logged", "in", "from", "10.0.0.1"}, tokens)
+
+	id2, tokens, ok, err := d.Train("user bob logged in from 10.0.0.2")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, id1, id2, "similar lines must share a cluster")
+	assert.Equal(t, []string{"user", "<*>", "logged", "in", "from", "<*>"}, tokens)
+
+	id3, _, ok, err := d.Train("disk /dev/sda is full")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.NotEqual(t, id1, id3)
+}
+
+func TestTokenise(t *testing.T) {
+	cfg := defaultCfg()
+	cfg.ExtraDelimiters = []string{"="}
+	d, err := NewDrain(cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user", "alice", "status", "200"}, d.Tokenise("  user alice status=200 "))
+	assert.Nil(t, d.Tokenise("   "))
+}
@@ -72,6 +72,7 @@ type multiShardAggregator struct {
 	timezone          *time.Location
 	telemetryBuilder  *metadata.TelemetryBuilder
 	includeFields     []string
+	templates         *templateMatcher
 
 	shards map[attribute.Set]*aggregatorShard
 	// lock protects the shards map during concurrent lookups and creation.
@@ -118,7 +119,7 @@ func (m *multiShardAggregator) getOrCreateShard(info client.Info, aset attribute
 		md[k] = info.Metadata.Get(k)
 	}
 	shard = &aggregatorShard{
-		aggregator: newLogAggregator(m.logCountAttribute, m.timezone, m.telemetryBuilder, m.includeFields),
+		aggregator: newLogAggregator(m.logCountAttribute, m.timezone, m.telemetryBuilder, m.includeFields, m.templates),
 		clientInfo: client.Info{
 			Metadata: client.NewMetadata(md),
 		},
@@ -181,10 +182,20 @@ func newProcessor(cfg *Config, nextConsumer consumer.Logs, settings processor.Se
 	}
 	sort.Strings(metadataKeys)
 
+	// The template matcher is shared by all the aggregators, so that Drain
+	// clusters are learnt from all the logs.
+	var templates *templateMatcher
+	if cfg.Template.Enabled {
+		templates, err = newTemplateMatcher(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create template matcher: %w", err)
+		}
+	}
+
 	var agg shardedAggregator
 	if len(metadataKeys) == 0 {
 		agg = &singleShardAggregator{
-			aggregator: newLogAggregator(cfg.LogCountAttribute, timezone, telemetryBuilder, cfg.IncludeFields),
+			aggregator: newLogAggregator(cfg.LogCountAttribute, timezone, telemetryBuilder, cfg.IncludeFields, templates),
 		}
 	} else {
 		if cfg.MetadataCardinalityLimit == 0 {
@@ -199,6 +210,7 @@ func newProcessor(cfg *Config, nextConsumer consumer.Logs, settings processor.Se
 			timezone:                 timezone,
 			telemetryBuilder:         telemetryBuilder,
 			includeFields:            cfg.IncludeFields,
+			templates:                templates,
 			shards:                   make(map[attribute.Set]*aggregatorShard),
 		}
 	}
@@ -447,6 +447,38 @@ func TestProcessorIncludeFields(t *testing.T) {
 	}
 }
 
+func TestProcessorTemplate(t *testing.T) {
+	logsSink := &consumertest.LogsSink{}
+	cfg := &Config{
+		LogCountAttribute: defaultLogCountAttribute,
+		Timezone:          defaultTimezone,
+		Interval:          1 * time.Second,
+		Conditions:        []string{},
+		ExcludeFields:     []string{},
+		Template:          enabledTemplateConfig(func(*TemplateConfig) {}),
+	}
+	p, err := createLogsProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, logsSink)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+
+	logs, err := golden.ReadLogs(filepath.Join("testdata", "input", "templateLogs.yaml"))
+	require.NoError(t, err)
+	require.NoError(t, p.ConsumeLogs(t.Context(), logs))
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() > 0
+	}, 3*time.Second, 200*time.Millisecond)
+
+	expectedLogs, err := golden.ReadLogs(filepath.Join("testdata", "expected", "templateLogs.yaml"))
+	require.NoError(t, err)
+
+	allSinkLogs := logsSink.AllLogs()
+	require.Len(t, allSinkLogs, 1)
+	require.NoError(t, plogtest.CompareLogs(expectedLogs, allSinkLogs[0], plogtest.IgnoreObservedTimestamp(), plogtest.IgnoreTimestamp(), plogtest.IgnoreLogRecordsOrder(), plogtest.IgnoreLogRecordAttributeValue("first_observed_timestamp"), plogtest.IgnoreLogRecordAttributeValue("last_observed_timestamp")))
+
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
 // contextCapturingLogsSink implements consumer.Logs and captures both the logs
 // and contexts passed to ConsumeLogs.
 type contextCapturingLogsSink struct {
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/drain"
+)
+
+// drainWildcard is the token go-drain3 uses for the parameters of a template.
+const drainWildcard = "<*>"
+
+// templateMatcher finds the template of log records, either by training a Drain
+// parse tree with their body or by reading it from an attribute.
+// It is not goroutine-safe, the processor serializes the calls.
+type templateMatcher struct {
+	// drain is nil when the templates are read from attribute.
+	drain               *drain.Drain
+	attribute           string
+	parametersAttribute string
+	maxSamples          int
+}
+
+// logTemplate is the template matched by a log record.
+type logTemplate struct {
+	// key identifies the template. With Drain it identifies the cluster, whose
+	// template becomes more generic over time.
+	key uint64
+	// tokens are the tokens of the template.
+	tokens []string
+	// values are the tokens of the log record, aligned with the template tokens,
+	// or nil if they cannot be aligned.
+	values []string
+}
+
+func newTemplateMatcher(cfg TemplateConfig) (*templateMatcher, error) {
+	m := &templateMatcher{
+		attribute:           cfg.Attribute,
+		parametersAttribute: cfg.ParametersAttribute,
+		maxSamples:          cfg.MaxParameterSamples,
+	}
+	if cfg.Source == templateSourceDrain {
+		d, err := drain.NewDrain(drain.Config{
+			Depth:           cfg.TreeDepth,
+			SimThreshold:    cfg.MergeThreshold,
+			MaxChildren:     cfg.MaxNodeChildren,
+			MaxClusters:     cfg.MaxClusters,
+			ExtraDelimiters: cfg.ExtraDelimiters,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.drain = d
+	}
+	return m, nil
+}
+
+// match returns the template of the log record. It returns false if the log
+// record has no template, e.g. when its body is empty, in which case the log
+// record is deduplicated on exact equality.
+func (m *templateMatcher) match(logRecord plog.LogRecord) (logTemplate, bool) {
+	body := logRecord.Body().AsString()
+
+	if m.drain == nil {
+		value, ok := logRecord.Attributes().Get(m.attribute)
+		if !ok || value.Type() != pcommon.ValueTypeStr || value.Str() == "" {
+			return logTemplate{}, false
+		}
+		// Templates are tokenised on spaces, like the drain processor does by default.
+		tokens := strings.Split(value.Str(), " ")
+		return logTemplate{
+			key:    pdatautil.Hash64(pdatautil.WithString(value.Str())),
+			tokens: tokens,
+			values: alignedValues(tokens, strings.Split(strings.TrimSpace(body), " ")),
+		}, true
+	}
+
+	values := m.drain.Tokenise(body)
+	if len(values) == 0 {
+		return logTemplate{}, false
+	}
+	clusterID, tokens, ok, err := m.drain.Train(body)
+	if err != nil || !ok {
+		return logTemplate{}, false
+	}
+	return logTemplate{
+		key:    pdatautil.Hash64(pdatautil.WithString(strconv.FormatInt(clusterID, 10))),
+		tokens: tokens,
+		values: alignedValues(tokens, values),
+	}, true
+}
+
+func alignedValues(tokens, values []string) []string {
+	if len(tokens) != len(values) {
+		return nil
+	}
+	return values
+}
+
+// isParameter returns true if the template token is a parameter: the Drain
+// wildcard, or a mask token such as `<ip>` written by the drain processor.
+func isParameter(token string) bool {
+	return token == drainWildcard || (len(token) > 2 && token[0] == '<' && token[len(token)-1] == '>')
+}
+
+// templateSample holds the template of the log records aggregated by a
+// logCounter and a bounded sample of the distinct values of its parameters.
+type templateSample struct {
+	tokens     []string
+	parameters map[int][]string
+	maxSamples int
+}
+
+func newTemplateSample(maxSamples int) *templateSample {
+	return &templateSample{
+		parameters: make(map[int][]string),
+		maxSamples: maxSamples,
+	}
+}
+
+// add records the template matched by a log record and samples its parameters.
+func (s *templateSample) add(tmpl logTemplate) {
+	if s.maxSamples > 0 {
+		for i, token := range tmpl.tokens {
+			if !isParameter(token) {
+				continue
+			}
+			// A Drain template only becomes more generic. If the position was not
+			// a parameter of the previous template, all the previous log records
+			// had the previous token at this position.
+			if i < len(s.tokens) && len(s.tokens) == len(tmpl.tokens) && !isParameter(s.tokens[i]) {
+				s.sample(i, s.tokens[i])
+			}
+			if tmpl.values != nil {
+				s.sample(i, tmpl.values[i])
+			}
+		}
+	}
+	s.tokens = tmpl.tokens
+}
+
+func (s *templateSample) sample(position int, value string) {
+	values := s.parameters[position]
+	if len(values) >= s.maxSamples {
+		return
+	}
+	for _, v := range values {
+		if v == value {
+			return
+		}
+	}
+	s.parameters[position] = append(values, value)
+}
+
+// template returns the template as a string.
+func (s *templateSample) template() string {
+	return strings.Join(s.tokens, " ")
+}
+
+// putParameters puts the sampled values of each parameter, in the order of the
+// parameters in the template, as a slice of slices.
+func (s *templateSample) putParameters(parameters pcommon.Slice) {
+	for i, token := range s.tokens {
+		if !isParameter(token) {
+			continue
+		}
+		values := parameters.AppendEmpty().SetEmptySlice()
+		values.EnsureCapacity(len(s.parameters[i]))
+		for _, v := range s.parameters[i] {
+			values.AppendEmpty().SetStr(v)
+		}
+	}
+}
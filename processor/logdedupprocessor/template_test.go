@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
+)
+
+func newTestLogRecord(body string, attributes map[string]any) plog.LogRecord {
+	lr := plog.NewLogRecord()
+	lr.Body().SetStr(body)
+	_ = lr.Attributes().FromRaw(attributes)
+	return lr
+}
+
+func TestTemplateMatcherDrain(t *testing.T) {
+	m, err := newTemplateMatcher(enabledTemplateConfig(func(*TemplateConfig) {}))
+	require.NoError(t, err)
+
+	t1, ok := m.match(newTestLogRecord("user alice logged in from 10.0.0.1", nil))
+	require.True(t, ok)
+	assert.Equal(t, []string{"user", "alice", "logged", "in", "from", "10.0.0.1"}, t1.tokens)
+
+	t2, ok := m.match(newTestLogRecord("user bob logged in from 10.0.0.2", nil))
+	require.True(t, ok)
+	assert.Equal(t, t1.key, t2.key, "the cluster must be the same while its template changes")
+	assert.Equal(t, []string{"user", "<*>", "logged", "in", "from", "<*>"}, t2.tokens)
+	assert.Equal(t, []string{"user", "bob", "logged", "in", "from", "10.0.0.2"}, t2.values)
+
+	t3, ok := m.match(newTestLogRecord("disk /dev/sda is full", nil))
+	require.True(t, ok)
+	assert.NotEqual(t, t1.key, t3.key)
+
+	_, ok = m.match(newTestLogRecord("  ", nil))
+	assert.False(t, ok, "empty bodies have no template")
+}
+
+func TestTemplateMatcherAttribute(t *testing.T) {
+	m, err := newTemplateMatcher(enabledTemplateConfig(func(c *TemplateConfig) { c.Source = templateSourceAttribute }))
+	require.NoError(t, err)
+	assert.Nil(t, m.drain)
+
+	t1, ok := m.match(newTestLogRecord("connection from 10.0.0.1 refused", map[string]any{defaultTemplateAttribute: "connection from <ip> refused"}))
+	require.True(t, ok)
+	assert.Equal(t, []string{"connection", "from", "10.0.0.1", "refused"}, t1.values)
+
+	t2, ok := m.match(newTestLogRecord("connection from 10.0.0.2 reset by peer", map[string]any{defaultTemplateAttribute: "connection from <ip> reset by peer"}))
+	require.True(t, ok)
+	assert.NotEqual(t, t1.key, t2.key)
+
+	// The body does not align with the template, the parameters cannot be sampled.
+	t3, ok := m.match(newTestLogRecord("connection from  10.0.0.3 refused", map[string]any{defaultTemplateAttribute: "connection from <ip> refused"}))
+	require.True(t, ok)
+	assert.Equal(t, t1.key, t3.key)
+	assert.Nil(t, t3.values)
+
+	_, ok = m.match(newTestLogRecord("no template", nil))
+	assert.False(t, ok)
+	_, ok = m.match(newTestLogRecord("no template", map[string]any{defaultTemplateAttribute: 1}))
+	assert.False(t, ok)
+}
+
+func TestTemplateSample(t *testing.T) {
+	sample := newTemplateSample(2)
+	sample.add(logTemplate{tokens: []string{"user", "alice", "status", "200"}, values: []string{"user", "alice", "status", "200"}})
+	sample.add(logTemplate{tokens: []string{"user", "alice", "status", "200"}, values: []string{"user", "alice", "status", "200"}})
+	// The template generalizes, the previous value of the new parameter is sampled.
+	sample.add(logTemplate{tokens: []string{"user", "<*>", "status", "200"}, values: []string{"user", "bob", "status", "200"}})
+	sample.add(logTemplate{tokens: []string{"user", "<*>", "status", "<*>"}, values: []string{"user", "bob", "status", "500"}})
+	sample.add(logTemplate{tokens: []string{"user", "<*>", "status", "<*>"}, values: []string{"user", "carol", "status", "404"}})
+	sample.add(logTemplate{tokens: []string{"user", "<*>", "status", "<*>"}})
+
+	assert.Equal(t, "user <*> status <*>", sample.template())
+	parameters := pcommon.NewSlice()
+	sample.putParameters(parameters)
+	assert.Equal(t, []any{
+		[]any{"alice", "bob"},
+		[]any{"200", "500"},
+	}, parameters.AsRaw())
+}
+
+func TestLogAggregatorTemplate(t *testing.T) {
+	oldTimeNow := timeNow
+	defer func() {
+		timeNow = oldTimeNow
+	}()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	templates, err := newTemplateMatcher(enabledTemplateConfig(func(*TemplateConfig) {}))
+	require.NoError(t, err)
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, telemetryBuilder, nil, templates)
+
+	resource := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+	for _, body := range []string{
+		"request 1f3a completed in 12ms",
+		"request 8c2e completed in 7ms",
+		"request 5d41 completed in 12ms",
+		"cache miss",
+	} {
+		aggregator.Add(resource, scope, newTestLogRecord(body, map[string]any{"request.id": body}))
+	}
+
+	logs := aggregator.Export(t.Context())
+	require.Equal(t, 2, logs.LogRecordCount())
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	byTemplate := map[string]plog.LogRecord{}
+	for i := 0; i < records.Len(); i++ {
+		tmpl, ok := records.At(i).Attributes().Get(defaultTemplateAttribute)
+		require.True(t, ok)
+		byTemplate[tmpl.Str()] = records.At(i)
+	}
+
+	lr, ok := byTemplate["request <*> completed in <*>"]
+	require.True(t, ok)
+	// The first log record of the template is emitted.
+	assert.Equal(t, "request 1f3a completed in 12ms", lr.Body().Str())
+	assert.Equal(t, map[string]any{
+		"request.id":                       "request 1f3a completed in 12ms",
+		defaultLogCountAttribute:           int64(3),
+		firstObservedTSAttr:                now.Format(time.RFC3339),
+		lastObservedTSAttr:                 now.Format(time.RFC3339),
+		defaultTemplateAttribute:           "request <*> completed in <*>",
+		defaultTemplateParametersAttribute: []any{[]any{"1f3a", "8c2e", "5d41"}, []any{"12ms", "7ms"}},
+	}, lr.Attributes().AsRaw())
+
+	lr, ok = byTemplate["cache miss"]
+	require.True(t, ok)
+	count, _ := lr.Attributes().Get(defaultLogCountAttribute)
+	assert.Equal(t, int64(1), count.Int())
+	parameters, _ := lr.Attributes().Get(defaultTemplateParametersAttribute)
+	assert.Equal(t, 0, parameters.Slice().Len())
+}
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/config/configopaque"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/url"
 )
 
@@ -26,6 +27,9 @@ const (
 	MD5        HashFunction = "md5"
 	HMACSHA256 HashFunction = "hmac-sha256"
 	HMACSHA512 HashFunction = "hmac-sha512"
+	// HMACFormatPreserving replaces values with a token keeping their format,
+	// e.g. digits are replaced by digits, derived from an HMAC-SHA256 of the value.
+	HMACFormatPreserving HashFunction = "hmac-format-preserving"
 )
 
 type Config struct {
@@ -48,9 +52,9 @@ type Config struct {
 	// and masking with a fixed string is performed.
 	HashFunction HashFunction `mapstructure:"hash_function"`
 
-	// HMACKey is the secret key used for HMAC hashing when HashFunction is set to hmac-sha256 or hmac-sha512.
-	// This should be loaded from a secure source like environment variables.
-	// Minimum length: 32 bytes for HMAC-SHA256, 64 bytes for HMAC-SHA512.
+	// HMACKey is the secret key used for HMAC hashing when HashFunction is set to hmac-sha256, hmac-sha512
+	// or hmac-format-preserving. This should be loaded from a secure source like environment variables.
+	// Minimum length: 32 bytes for HMAC-SHA256 and format preserving tokens, 64 bytes for HMAC-SHA512.
 	HMACKey configopaque.String `mapstructure:"hmac_key"`
 
 	// IgnoredKeys is a list of span attribute keys that are not redacted.
@@ -71,6 +75,11 @@ type Config struct {
 	// allowed span attributes. Values that match are masked.
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// PIIDetectors is a list of built-in detectors for structured sensitive values,
+	// such as credit card numbers, IBANs or cloud access keys. Values found by a
+	// detector in attribute values and log bodies are masked like blocked values.
+	PIIDetectors []string `mapstructure:"pii_detectors"`
+
 	// AllowedValues is a list of regular expressions for allowing values of
 	// blocked span attributes. Values that match are not masked.
 	AllowedValues []string `mapstructure:"allowed_values"`
@@ -116,17 +125,20 @@ func (u *HashFunction) UnmarshalText(text []byte) error {
 	case strings.ToLower(HMACSHA512.String()):
 		*u = HMACSHA512
 		return nil
+	case strings.ToLower(HMACFormatPreserving.String()):
+		*u = HMACFormatPreserving
+		return nil
 	case strings.ToLower(None.String()):
 		*u = None
 		return nil
 	}
-	return fmt.Errorf("unknown HashFunction %s, allowed functions are %s, %s, %s, %s and %s", str, SHA1, SHA3, MD5, HMACSHA256, HMACSHA512)
+	return fmt.Errorf("unknown HashFunction %s, allowed functions are %s, %s, %s, %s, %s and %s", str, SHA1, SHA3, MD5, HMACSHA256, HMACSHA512, HMACFormatPreserving)
 }
 
 // Validate validates the configuration
 func (cfg *Config) Validate() error {
 	// Validate HMAC key requirements
-	if cfg.HashFunction == HMACSHA256 || cfg.HashFunction == HMACSHA512 {
+	if cfg.HashFunction == HMACSHA256 || cfg.HashFunction == HMACSHA512 || cfg.HashFunction == HMACFormatPreserving {
 		key := string(cfg.HMACKey)
 		if key == "" {
 			return fmt.Errorf("hmac_key must not be empty when hash_function is %q", cfg.HashFunction)
@@ -143,5 +155,9 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if _, err := pii.NewDetectors(cfg.PIIDetectors); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -33,6 +33,7 @@ func TestLoadConfig(t *testing.T) {
 				IgnoredKeys:        []string{"safe_attribute"},
 				BlockedValues:      []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
 				BlockedKeyPatterns: []string{".*(token|api_key).*"},
+				PIIDetectors:       []string{"credit_card", "email"},
 				HashFunction:       MD5,
 				AllowedValues:      []string{".+@mycompany.com"},
 				Summary:            debug,
@@ -167,6 +168,38 @@ func TestValidateHMACKey(t *testing.T) {
 			expectError:   true,
 			errorContains: "hmac_key must be at least 64 bytes long",
 		},
+		{
+			name: "valid format preserving tokens with sufficient key length",
+			config: &Config{
+				HashFunction: HMACFormatPreserving,
+				HMACKey:      "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", // 32 bytes
+			},
+			expectError: false,
+		},
+		{
+			name: "key too short for format preserving tokens",
+			config: &Config{
+				HashFunction: HMACFormatPreserving,
+				HMACKey:      "short-key",
+			},
+			expectError:   true,
+			errorContains: "hmac_key must be at least 32 bytes long",
+		},
+		{
+			name: "known PII detectors",
+			config: &Config{
+				PIIDetectors: []string{"credit_card", "email", "aws_access_key"},
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown PII detector",
+			config: &Config{
+				PIIDetectors: []string{"ssn"},
+			},
+			expectError:   true,
+			errorContains: "unknown PII detector \"ssn\"",
+		},
 		{
 			name: "no validation for non-HMAC hash functions",
 			config: &Config{
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pii // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+const (
+	CreditCard      = "credit_card"
+	IBAN            = "iban"
+	Email           = "email"
+	Phone           = "phone"
+	JWT             = "jwt"
+	AWSAccessKey    = "aws_access_key"
+	GCPAPIKey       = "gcp_api_key"
+	AzureStorageKey = "azure_storage_key"
+)
+
+// Names lists the built-in detectors in the order they are documented.
+var Names = []string{CreditCard, IBAN, Email, Phone, JWT, AWSAccessKey, GCPAPIKey, AzureStorageKey}
+
+// rule is a pattern matching a candidate value, and an optional validation
+// rejecting the candidates that only look like the value.
+type rule struct {
+	pattern *regexp.Regexp
+	// group is the submatch replaced by the detector, 0 for the whole match.
+	group    int
+	validate func(string) bool
+}
+
+var rules = map[string][]rule{
+	// Card numbers start with an industry identifier from 2 to 6 and have 13 to 19
+	// digits, optionally grouped with spaces or dashes.
+	CreditCard: {{
+		pattern:  regexp.MustCompile(`\b[2-6]\d(?:[ -]?\d){11,17}\b`),
+		validate: isValidLuhn,
+	}},
+	IBAN: {{
+		pattern:  regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?\b`),
+		validate: isValidIBAN,
+	}},
+	Email: {{
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`),
+	}},
+	// Phone numbers must either use the international + prefix, or the
+	// (555) 123-4567 and 555-123-4567 national formats, so that dates, versions
+	// and plain numbers are not detected.
+	Phone: {{
+		pattern:  regexp.MustCompile(`(?:\+\d{1,3}[ .-]?(?:\(\d{1,4}\)[ .-]?)?\d{1,4}(?:[ .-]?\d{1,4}){1,5}|\(\d{3}\) ?\d{3}[ .-]\d{4}|\b\d{3}[.-]\d{3}[.-]\d{4})\b`),
+		validate: isValidPhone,
+	}},
+	JWT: {{
+		pattern:  regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		validate: isValidJWT,
+	}},
+	AWSAccessKey: {
+		{pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[A-Z2-7]{16}\b`)},
+		{pattern: regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+]{40})\b`), group: 1},
+	},
+	GCPAPIKey: {{
+		pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`),
+	}},
+	AzureStorageKey: {{
+		pattern: regexp.MustCompile(`AccountKey=([A-Za-z0-9+/]{86}==)`),
+		group:   1,
+	}},
+}
+
+// Detector finds one kind of sensitive value in a string.
+type Detector struct {
+	name  string
+	rules []rule
+}
+
+// NewDetectors returns the detectors with the given names, preserving their order.
+func NewDetectors(names []string) ([]*Detector, error) {
+	detectors := make([]*Detector, 0, len(names))
+	for _, name := range names {
+		r, ok := rules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown PII detector %q, allowed detectors are %s", name, strings.Join(Names, ", "))
+		}
+		detectors = append(detectors, &Detector{name: name, rules: r})
+	}
+	return detectors, nil
+}
+
+// Name returns the name of the detector as used in the configuration.
+func (d *Detector) Name() string {
+	return d.name
+}
+
+// Replace returns val with every detected value replaced by the result of replace.
+func (d *Detector) Replace(val string, replace func(string) string) string {
+	for _, r := range d.rules {
+		val = r.replace(val, replace)
+	}
+	return val
+}
+
+func (r rule) replace(val string, replace func(string) string) string {
+	matches := r.pattern.FindAllStringSubmatchIndex(val, -1)
+	if len(matches) == 0 {
+		return val
+	}
+	var sb strings.Builder
+	replaced := false
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*r.group], m[2*r.group+1]
+		if start < 0 {
+			continue
+		}
+		match := val[start:end]
+		if r.validate != nil && !r.validate(match) {
+			continue
+		}
+		sb.WriteString(val[last:start])
+		sb.WriteString(replace(match))
+		last = end
+		replaced = true
+	}
+	if !replaced {
+		return val
+	}
+	sb.WriteString(val[last:])
+	return sb.String()
+}
+
+func digits(val string) []byte {
+	d := make([]byte, 0, len(val))
+	for i := 0; i < len(val); i++ {
+		if val[i] >= '0' && val[i] <= '9' {
+			d = append(d, val[i]-'0')
+		}
+	}
+	return d
+}
+
+func isValidLuhn(val string) bool {
+	d := digits(val)
+	if len(d) < 13 || len(d) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range d {
+		n := int(d[len(d)-1-i])
+		if i%2 == 1 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// isValidIBAN checks the ISO 13616 length and mod-97 checksum of an IBAN.
+func isValidIBAN(val string) bool {
+	iban := strings.ReplaceAll(val, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	var sb strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		if c >= 'A' && c <= 'Z' {
+			sb.WriteString(fmt.Sprint(c - 'A' + 10))
+		} else {
+			sb.WriteRune(c)
+		}
+	}
+	n, ok := new(big.Int).SetString(sb.String(), 10)
+	return ok && n.Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// isValidPhone checks the number of digits allowed by E.164.
+func isValidPhone(val string) bool {
+	n := len(digits(val))
+	return n >= 7 && n <= 15
+}
+
+// isValidJWT checks that the header of the token is a JSON object naming its algorithm.
+func isValidJWT(val string) bool {
+	header, _, _ := strings.Cut(val, ".")
+	decoded, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(decoded, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["alg"]
+	return ok
+}
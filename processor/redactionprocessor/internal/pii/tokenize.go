@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pii // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+	"unicode"
+)
+
+// Tokenize returns a deterministic token with the format of val: every digit is
+// replaced by a digit, every letter by an ASCII letter of the same case, and
+// every other character is kept. The token is derived from an HMAC-SHA256 of
+// val, so the same value always produces the same token for a given key, while
+// the token does not reveal the value without the key.
+func Tokenize(val string, key []byte) string {
+	stream := newKeyStream(val, key)
+	var sb strings.Builder
+	sb.Grow(len(val))
+	for _, c := range val {
+		switch {
+		case unicode.IsDigit(c):
+			sb.WriteByte('0' + stream.next(10))
+		case unicode.IsUpper(c):
+			sb.WriteByte('A' + stream.next(26))
+		case unicode.IsLetter(c):
+			sb.WriteByte('a' + stream.next(26))
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// keyStream expands the HMAC of a value into as many pseudo-random bytes as
+// needed, by hashing the HMAC with an increasing counter.
+type keyStream struct {
+	key     []byte
+	seed    []byte
+	block   []byte
+	counter uint32
+}
+
+func newKeyStream(val string, key []byte) *keyStream {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(val))
+	return &keyStream{key: key, seed: mac.Sum(nil)}
+}
+
+// next returns a uniformly distributed number lower than n, which must be lower than 256.
+func (s *keyStream) next(n int) byte {
+	// Reject the bytes above the largest multiple of n to avoid a modulo bias.
+	limit := 256 - 256%n
+	for {
+		if len(s.block) == 0 {
+			s.refill()
+		}
+		b := s.block[0]
+		s.block = s.block[1:]
+		if int(b) < limit {
+			return byte(int(b) % n)
+		}
+	}
+}
+
+func (s *keyStream) refill() {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(s.seed)
+	mac.Write(binary.BigEndian.AppendUint32(nil, s.counter))
+	s.counter++
+	s.block = mac.Sum(nil)
+}
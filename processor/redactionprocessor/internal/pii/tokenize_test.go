@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pii
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	tests := []string{
+		"4111-1111-1111-1111",
+		"john.doe+test@example.co.uk",
+		"GB82 WEST 1234 5698 7654 32",
+		"+44 (20) 7946 0958",
+		testJWT,
+		"Zoë",
+		"",
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			token := Tokenize(input, key)
+			assert.Equal(t, token, Tokenize(input, key), "tokens must be deterministic")
+			if input != "" {
+				assert.NotEqual(t, input, token)
+			}
+
+			in, out := []rune(input), []rune(token)
+			assert.Len(t, out, len(in))
+			for i := range in {
+				switch {
+				case unicode.IsDigit(in[i]):
+					assert.True(t, unicode.IsDigit(out[i]))
+				case unicode.IsUpper(in[i]):
+					assert.True(t, unicode.IsUpper(out[i]))
+				case unicode.IsLetter(in[i]):
+					assert.True(t, unicode.IsLower(out[i]))
+				default:
+					assert.Equal(t, in[i], out[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTokenizeDependsOnKeyAndValue(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	other := []byte("fedcba9876543210fedcba9876543210")
+	assert.NotEqual(t, Tokenize("4111111111111111", key), Tokenize("4111111111111111", other))
+	assert.NotEqual(t, Tokenize("4111111111111111", key), Tokenize("4111111111111112", key))
+}
+
+func TestTokenizeLongValue(t *testing.T) {
+	// Values longer than a single HMAC block must not repeat the key stream.
+	token := Tokenize(strings.Repeat("0", 200), []byte("key"))
+	assert.NotEqual(t, token[:100], token[100:])
+}
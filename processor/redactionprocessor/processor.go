@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/pii"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/url"
 )
 
@@ -48,6 +49,8 @@ type redaction struct {
 	allowRegexList []*regexp.Regexp
 	// Attribute keys blocked in a span
 	blockKeyRegexList []*regexp.Regexp
+	// Built-in detectors of sensitive values
+	piiDetectors []*pii.Detector
 	// Hash function to hash blocked values
 	hashFunction HashFunction
 	// Redaction processor configuration
@@ -86,6 +89,11 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		return nil, fmt.Errorf("failed to process allow list: %w", err)
 	}
 
+	piiDetectors, err := pii.NewDetectors(config.PIIDetectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process PII detectors: %w", err)
+	}
+
 	var urlSanitizer *url.URLSanitizer
 	if config.URLSanitization.Enabled {
 		urlSanitizer, err = url.NewURLSanitizer(config.URLSanitization)
@@ -102,6 +110,7 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		blockRegexList:     blockRegexList,
 		allowRegexList:     allowRegexList,
 		blockKeyRegexList:  blockKeysRegexList,
+		piiDetectors:       piiDetectors,
 		hashFunction:       config.HashFunction,
 		config:             config,
 		logger:             logger,
@@ -384,25 +393,30 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(ignoredKeys, attributes, "", redactionIgnoredCount)
 }
 
-//nolint:gosec
 func (s *redaction) maskValue(val string, regex *regexp.Regexp) string {
-	hashFunc := func(match string) string {
-		switch s.hashFunction {
-		case SHA1:
-			return hashString(match, sha1.New())
-		case SHA3:
-			return hashString(match, hash.Hash(sha3.New256()))
-		case MD5:
-			return hashString(match, md5.New())
-		case HMACSHA256:
-			return hashStringHMAC(match, s.config.HMACKey, sha256.New)
-		case HMACSHA512:
-			return hashStringHMAC(match, s.config.HMACKey, sha512.New)
-		default:
-			return "****"
-		}
+	return regex.ReplaceAllStringFunc(val, s.hashValue)
+}
+
+// hashValue returns the replacement of a blocked value according to the configured hash function
+//
+//nolint:gosec
+func (s *redaction) hashValue(match string) string {
+	switch s.hashFunction {
+	case SHA1:
+		return hashString(match, sha1.New())
+	case SHA3:
+		return hashString(match, hash.Hash(sha3.New256()))
+	case MD5:
+		return hashString(match, md5.New())
+	case HMACSHA256:
+		return hashStringHMAC(match, s.config.HMACKey, sha256.New)
+	case HMACSHA512:
+		return hashStringHMAC(match, s.config.HMACKey, sha512.New)
+	case HMACFormatPreserving:
+		return pii.Tokenize(match, []byte(string(s.config.HMACKey)))
+	default:
+		return "****"
 	}
-	return regex.ReplaceAllStringFunc(val, hashFunc)
 }
 
 func hashString(input string, hasher hash.Hash) string {
@@ -447,6 +461,8 @@ func (s *redaction) processStringValueForAttribute(strVal, attributeKey, dbSyste
 		}
 	}
 
+	strVal = s.replaceDetectedValues(strVal)
+
 	if s.urlSanitizer != nil {
 		strVal = s.urlSanitizer.SanitizeAttributeURL(strVal, attributeKey)
 	}
@@ -471,6 +487,8 @@ func (s *redaction) processStringValueForLogBody(strVal string) string {
 		}
 	}
 
+	strVal = s.replaceDetectedValues(strVal)
+
 	if s.urlSanitizer != nil {
 		strVal = s.urlSanitizer.SanitizeURL(strVal)
 	}
@@ -486,6 +504,14 @@ func (s *redaction) processStringValueForLogBody(strVal string) string {
 	return strVal
 }
 
+// replaceDetectedValues masks the values found by the built-in PII detectors
+func (s *redaction) replaceDetectedValues(strVal string) string {
+	for _, detector := range s.piiDetectors {
+		strVal = detector.Replace(strVal, s.hashValue)
+	}
+	return strVal
+}
+
 func (s *redaction) shouldMaskKey(k string) bool {
 	// Mask any blocked keys for the other attributes
 	for _, compiledRE := range s.blockKeyRegexList {
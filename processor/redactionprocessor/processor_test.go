@@ -2568,3 +2568,72 @@ func TestDBObfuscationErrorInAttribute(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "SELECT * FROM users WHERE id = ?", val.Str())
 }
+
+func TestPIIDetectorsOnAttributesAndLogBody(t *testing.T) {
+	cfg := &Config{
+		AllowAllKeys: true,
+		PIIDetectors: []string{"credit_card", "email", "jwt"},
+		Summary:      debug,
+	}
+
+	inLogs := plog.NewLogs()
+	logRecord := inLogs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Attributes().PutStr("user", "jane@example.com")
+	logRecord.Attributes().PutStr("order", "4111111111111112")
+	logRecord.Body().SetEmptyMap().PutStr("message", "payment with 4111 1111 1111 1111 by jane@example.com")
+
+	processor, err := newRedaction(t.Context(), cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	outLogs, err := processor.processLogs(t.Context(), inLogs)
+	require.NoError(t, err)
+
+	outLog := outLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	// The order number fails the Luhn check and is kept.
+	assert.Equal(t, map[string]any{
+		"user":                   "****",
+		"order":                  "4111111111111112",
+		redactionMaskedKeys:      "user",
+		redactionMaskedCount:     int64(1),
+		redactionBodyMaskedKeys:  "message",
+		redactionBodyMaskedCount: int64(1),
+	}, outLog.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"message": "payment with **** by ****"}, outLog.Body().AsRaw())
+}
+
+func TestPIIDetectorsFormatPreservingTokens(t *testing.T) {
+	cfg := &Config{
+		AllowAllKeys:  true,
+		PIIDetectors:  []string{"credit_card", "email"},
+		BlockedValues: []string{"user-[0-9]+"},
+		HashFunction:  HMACFormatPreserving,
+		HMACKey:       configopaque.String("0123456789abcdef0123456789abcdef"),
+		Summary:       "silent",
+	}
+	processor, err := newRedaction(t.Context(), cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("card", "4111-1111-1111-1111")
+	span.Attributes().PutStr("user", "user-42")
+	_, err = processor.processTraces(t.Context(), traces)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Body().SetStr("charged 4111-1111-1111-1111 for jane.doe@example.com")
+	_, err = processor.processLogs(t.Context(), logs)
+	require.NoError(t, err)
+
+	card, _ := span.Attributes().Get("card")
+	assert.Regexp(t, `^\d{4}-\d{4}-\d{4}-\d{4}$`, card.Str())
+	assert.NotEqual(t, "4111-1111-1111-1111", card.Str())
+	user, _ := span.Attributes().Get("user")
+	assert.Regexp(t, `^[a-z]{4}-\d{2}$`, user.Str())
+
+	// The same card number produces the same token across signals.
+	body := logRecord.Body().Str()
+	assert.Contains(t, body, "charged "+card.Str()+" for ")
+	assert.Regexp(t, `for [a-z]{4}\.[a-z]{3}@[a-z]{7}\.[a-z]{3}$`, body)
+	assert.NotContains(t, body, "jane.doe@example.com")
+}
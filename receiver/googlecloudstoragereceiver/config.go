@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/strftime"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+var (
+	errNameRequired         = errors.New("bucket.name is required")
+	errModeRequired         = errors.New("either starttime/endtime or pubsub configuration must be provided")
+	errModeConflict         = errors.New("starttime/endtime and pubsub configuration cannot be used together")
+	errEndTimeRequired      = errors.New("when starttime is specified, endtime is required")
+	errStartTimeRequired    = errors.New("when endtime is specified, starttime is required")
+	errEndTimeBeforeStart   = errors.New("endtime must be after starttime")
+	errSubscriptionRequired = errors.New("pubsub.subscription is required")
+	errSubscriptionInvalid  = errors.New("pubsub.subscription must be of the form projects/<project>/subscriptions/<subscription>")
+)
+
+// subscriptionPattern matches the fully qualified name of a Pub/Sub subscription.
+var subscriptionPattern = regexp.MustCompile(`^projects/([^/]+)/subscriptions/[^/]+$`)
+
+// Config defines the configuration for the Google Cloud Storage receiver.
+type Config struct {
+	// Encoding is the encoding extension used to unmarshal the objects. If left empty, the objects
+	// are unmarshaled as OTLP JSON, the default of the Google Cloud Storage exporter.
+	Encoding *component.ID `mapstructure:"encoding"`
+	Bucket   bucketConfig  `mapstructure:"bucket"`
+
+	// UniverseDomain is the universe domain for the Google Cloud Storage service.
+	// Defaults to "googleapis.com". Set to support Sovereign Cloud regions.
+	// See https://pkg.go.dev/google.golang.org/api/option#WithUniverseDomain
+	UniverseDomain string `mapstructure:"universe_domain"`
+
+	// StartTime and EndTime select the time partitions, or the creation times of the objects when
+	// bucket.partition.format is not set, read in time range mode.
+	StartTime string `mapstructure:"starttime"`
+	EndTime   string `mapstructure:"endtime"`
+
+	// PubSub configures reading the objects notified on a Pub/Sub subscription.
+	PubSub *PubSubConfig `mapstructure:"pubsub"`
+
+	Notifications Notifications `mapstructure:"notifications"`
+}
+
+// bucketConfig matches the bucket configuration of the Google Cloud Storage exporter, so that the
+// receiver can find the objects written by the exporter.
+type bucketConfig struct {
+	// Name of the bucket storing the objects.
+	Name string `mapstructure:"name"`
+
+	// FilePrefix is the prefix of the object names, after the partition path (if any).
+	FilePrefix string `mapstructure:"file_prefix"`
+
+	// Partition configures the time-based partition format and prefix.
+	Partition partitionConfig `mapstructure:"partition"`
+}
+
+type partitionConfig struct {
+	// Format is the strftime format of the time-based partitions, formatted in UTC.
+	// Example: "year=%Y/month=%m/day=%d/hour=%H"
+	Format string `mapstructure:"format"`
+
+	// Prefix of the partition path, applied before the time-based partition structure.
+	Prefix string `mapstructure:"prefix"`
+}
+
+// PubSubConfig holds the Pub/Sub subscription receiving the Cloud Storage object notifications.
+type PubSubConfig struct {
+	// Subscription is the fully qualified name of the subscription:
+	// projects/<project>/subscriptions/<subscription>.
+	Subscription string `mapstructure:"subscription"`
+	// Endpoint overrides the Pub/Sub endpoint, leave empty for the default endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+	// Insecure disables the transport security, only has effect if Endpoint is not empty.
+	Insecure bool `mapstructure:"insecure"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Notifications groups optional notification sources.
+type Notifications struct {
+	OpAMP *component.ID `mapstructure:"opampextension"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ confmap.Validator = (*Config)(nil)
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Bucket: bucketConfig{
+			FilePrefix: "logs",
+		},
+	}
+}
+
+func (c *partitionConfig) Validate() error {
+	if _, err := strftime.New(c.Format); err != nil {
+		return fmt.Errorf("invalid partition format: %w", err)
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Bucket.Name == "" {
+		errs = append(errs, errNameRequired)
+	}
+
+	hasStartTime := c.StartTime != ""
+	hasEndTime := c.EndTime != ""
+	hasPubSub := c.PubSub != nil
+	switch {
+	case !hasStartTime && !hasEndTime && !hasPubSub:
+		errs = append(errs, errModeRequired)
+	case (hasStartTime || hasEndTime) && hasPubSub:
+		errs = append(errs, errModeConflict)
+	case hasStartTime && !hasEndTime:
+		errs = append(errs, errEndTimeRequired)
+	case !hasStartTime && hasEndTime:
+		errs = append(errs, errStartTimeRequired)
+	}
+
+	if hasStartTime && hasEndTime {
+		startTime, startErr := parseTime(c.StartTime, "starttime")
+		if startErr != nil {
+			errs = append(errs, startErr)
+		}
+		endTime, endErr := parseTime(c.EndTime, "endtime")
+		if endErr != nil {
+			errs = append(errs, endErr)
+		}
+		if startErr == nil && endErr == nil && !endTime.After(startTime) {
+			errs = append(errs, errEndTimeBeforeStart)
+		}
+		if c.Bucket.Partition.Format != "" {
+			if _, err := determineTimeStep(c.Bucket.Partition.Format); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if hasPubSub {
+		switch {
+		case c.PubSub.Subscription == "":
+			errs = append(errs, errSubscriptionRequired)
+		case !subscriptionPattern.MatchString(c.PubSub.Subscription):
+			errs = append(errs, errSubscriptionInvalid)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// projectID returns the project of the Pub/Sub subscription.
+func (c *PubSubConfig) projectID() string {
+	if m := subscriptionPattern.FindStringSubmatch(c.Subscription); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func parseTime(timeStr, configName string) (time.Time, error) {
+	layouts := []string{time.RFC3339, "2006-01-02 15:04", time.DateOnly}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, timeStr); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %s (%s), accepted formats: %s", configName, timeStr, strings.Join(layouts, ", "))
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	encoding := component.NewIDWithName(component.MustNewType("foo"), "bar")
+	opampExtension := component.NewIDWithName(component.MustNewType("opamp"), "bar")
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "bucket.name is required\neither starttime/endtime or pubsub configuration must be provided",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "1"),
+			errorMessage: "unable to parse starttime (a date), accepted formats: 2006-01-02T15:04:05Z07:00, 2006-01-02 15:04, 2006-01-02\nunable to parse endtime (2024-02-03a), accepted formats: 2006-01-02T15:04:05Z07:00, 2006-01-02 15:04, 2006-01-02\nno time step found for partition format %Y/%m",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				Bucket: bucketConfig{
+					Name:       "archive",
+					FilePrefix: "logs",
+				},
+				StartTime: "2024-01-31 15:00",
+				EndTime:   "2024-02-03",
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "3"),
+			expected: &Config{
+				Encoding: &encoding,
+				Bucket: bucketConfig{
+					Name:       "archive",
+					FilePrefix: "traces",
+					Partition: partitionConfig{
+						Format: "year=%Y/month=%m/day=%d/hour=%H",
+						Prefix: "otel",
+					},
+				},
+				UniverseDomain: "example.com",
+				StartTime:      "2024-01-31T15:00:00Z",
+				EndTime:        "2024-02-03T00:00:00Z",
+				Notifications: Notifications{
+					OpAMP: &opampExtension,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "4"),
+			expected: &Config{
+				Bucket: bucketConfig{
+					Name:       "archive",
+					FilePrefix: "logs",
+					Partition: partitionConfig{
+						Prefix: "otel",
+					},
+				},
+				PubSub: &PubSubConfig{
+					Subscription: "projects/my-project/subscriptions/archive",
+					Endpoint:     "localhost:8085",
+					Insecure:     true,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "5"),
+			errorMessage: "starttime/endtime and pubsub configuration cannot be used together\nendtime must be after starttime\npubsub.subscription must be of the form projects/<project>/subscriptions/<subscription>",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "6"),
+			errorMessage: "endtime must be after starttime",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "7"),
+			errorMessage: "no time step found for partition format %Y",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "8"),
+			errorMessage: "pubsub.subscription must be of the form projects/<project>/subscriptions/<subscription>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.errorMessage != "" {
+				assert.EqualError(t, confmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, confmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestPubSubProjectID(t *testing.T) {
+	cfg := &PubSubConfig{Subscription: "projects/my-project/subscriptions/archive"}
+	assert.Equal(t, "my-project", cfg.projectID())
+	cfg.Subscription = "archive"
+	assert.Empty(t, cfg.projectID())
+}
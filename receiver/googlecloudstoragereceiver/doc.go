@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package googlecloudstoragereceiver implements a receiver that can be used by the
+// OpenTelemetry collector to retrieve telemetry previously stored in Google Cloud
+// Storage by the Google Cloud Storage Exporter.
+package googlecloudstoragereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver"
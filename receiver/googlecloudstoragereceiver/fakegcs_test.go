@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeObject struct {
+	content []byte
+	created time.Time
+}
+
+// fakeGCSServer is a local fake of the Cloud Storage JSON API, serving the listing and the download of objects.
+type fakeGCSServer struct {
+	t       *testing.T
+	mu      sync.Mutex
+	buckets map[string]map[string]fakeObject
+}
+
+// newFakeGCSServer starts a fake Cloud Storage server, targeted by the clients through the STORAGE_EMULATOR_HOST
+// environment variable.
+func newFakeGCSServer(t *testing.T) *fakeGCSServer {
+	fake := &fakeGCSServer{t: t, buckets: map[string]map[string]fakeObject{}}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	t.Setenv("STORAGE_EMULATOR_HOST", server.Listener.Addr().String())
+	return fake
+}
+
+func (f *fakeGCSServer) putObject(bucket, name string, content []byte, created time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.buckets[bucket] == nil {
+		f.buckets[bucket] = map[string]fakeObject{}
+	}
+	f.buckets[bucket][name] = fakeObject{content: content, created: created}
+}
+
+func (f *fakeGCSServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/storage/v1/b/") && strings.HasSuffix(path, "/o"):
+		bucket, _ := url.PathUnescape(strings.TrimSuffix(strings.TrimPrefix(path, "/storage/v1/b/"), "/o"))
+		f.listObjects(w, bucket, r.URL.Query().Get("prefix"))
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/download/storage/v1/b/"):
+		bucket, name, _ := strings.Cut(strings.TrimPrefix(path, "/download/storage/v1/b/"), "/o/")
+		f.getObject(w, bucket, name)
+	case r.Method == http.MethodGet:
+		// XML API reads: /<bucket>/<object>
+		bucket, name, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		f.getObject(w, bucket, name)
+	default:
+		f.t.Errorf("Unexpected request: %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGCSServer) listObjects(w http.ResponseWriter, bucket, prefix string) {
+	items := []map[string]any{}
+	for name, object := range f.buckets[bucket] {
+		if strings.HasPrefix(name, prefix) {
+			items = append(items, map[string]any{
+				"kind":        "storage#object",
+				"bucket":      bucket,
+				"name":        name,
+				"size":        strconv.Itoa(len(object.content)),
+				"timeCreated": object.created.Format(time.RFC3339Nano),
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i]["name"].(string) < items[j]["name"].(string)
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"kind":  "storage#objects",
+		"items": items,
+	})
+}
+
+func (f *fakeGCSServer) getObject(w http.ResponseWriter, bucket, escapedName string) {
+	name, err := url.PathUnescape(escapedName)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	object, ok := f.buckets[bucket][name]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(object.content)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/storage"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// Attributes of the Cloud Storage Pub/Sub notifications.
+// See: https://cloud.google.com/storage/docs/pubsub-notifications#attributes
+const (
+	attributeEventType = "eventType"
+	attributeBucketID  = "bucketId"
+	attributeObjectID  = "objectId"
+
+	eventTypeObjectFinalize = "OBJECT_FINALIZE"
+)
+
+// pubSubNotificationReader reads the objects notified on a Pub/Sub subscription when they are created.
+type pubSubNotificationReader struct {
+	logger          *zap.Logger
+	bucket          *storage.BucketHandle
+	bucketName      string
+	partitionPrefix string
+	fileNamePrefix  string
+	subscriber      *pubsub.Subscriber
+}
+
+func newPubSubNotificationReader(logger *zap.Logger, bucket *storage.BucketHandle, client *pubsub.Client, cfg *Config) *pubSubNotificationReader {
+	return &pubSubNotificationReader{
+		logger:          logger,
+		bucket:          bucket,
+		bucketName:      cfg.Bucket.Name,
+		partitionPrefix: cfg.Bucket.Partition.Prefix,
+		fileNamePrefix:  fileNamePrefix(cfg.Bucket.FilePrefix),
+		subscriber:      client.Subscriber(cfg.PubSub.Subscription),
+	}
+}
+
+// readAll implements the objectReader interface, receiving notifications until the context is cancelled.
+func (r *pubSubNotificationReader) readAll(ctx context.Context, _ string, callback objectCallback) error {
+	r.logger.Info("Starting Pub/Sub notification processing",
+		zap.String("subscription", r.subscriber.String()),
+		zap.String("bucket", r.bucketName),
+		zap.String("prefix", r.partitionPrefix))
+	err := r.subscriber.Receive(ctx, func(ctx context.Context, message *pubsub.Message) {
+		if r.processMessage(ctx, message, callback) {
+			message.Ack()
+		} else {
+			// The message is delivered again, after the ack deadline.
+			message.Nack()
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		r.logger.Error("Error receiving notifications from Pub/Sub", zap.Error(err))
+		return err
+	}
+	r.logger.Info("Context canceled, stopping Pub/Sub notification processing")
+	return ctx.Err()
+}
+
+// processMessage processes the object of a notification, returning false if it must be delivered again.
+func (r *pubSubNotificationReader) processMessage(ctx context.Context, message *pubsub.Message, callback objectCallback) bool {
+	eventType := message.Attributes[attributeEventType]
+	bucket := message.Attributes[attributeBucketID]
+	name := message.Attributes[attributeObjectID]
+	if eventType != eventTypeObjectFinalize {
+		r.logger.Debug("Skipping notification", zap.String("event_type", eventType), zap.String("name", name))
+		return true
+	}
+	if bucket != r.bucketName {
+		r.logger.Debug("Skipping object from different bucket",
+			zap.String("bucket", bucket),
+			zap.String("target_bucket", r.bucketName))
+		return true
+	}
+	if !r.matches(name) {
+		r.logger.Debug("Skipping object not matching prefix",
+			zap.String("name", name),
+			zap.String("prefix", r.partitionPrefix),
+			zap.String("file_prefix", r.fileNamePrefix))
+		return true
+	}
+
+	r.logger.Info("Processing new object", zap.String("bucket", bucket), zap.String("name", name))
+	content, err := retrieveObject(ctx, r.bucket, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			// Nothing more can be done for deleted objects.
+			r.logger.Warn("Object does not exist", zap.String("bucket", bucket), zap.String("name", name))
+			return true
+		}
+		r.logger.Warn("Failed to get object", zap.String("bucket", bucket), zap.String("name", name), zap.Error(err))
+		return false
+	}
+	if err := callback(ctx, name, content); err != nil {
+		if consumererror.IsPermanent(err) {
+			// The object is dropped, as processing it again fails the same way.
+			r.logger.Error("Dropping object content", zap.String("bucket", bucket), zap.String("name", name), zap.Error(err))
+			return true
+		}
+		r.logger.Error("Failed to process object content", zap.String("name", name), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// matches returns whether the object is under the partition prefix and has the file name prefix of the exporter.
+func (r *pubSubNotificationReader) matches(name string) bool {
+	if !strings.HasPrefix(name, r.partitionPrefix) {
+		return false
+	}
+	return strings.HasPrefix(name, r.fileNamePrefix) || strings.Contains(name, "/"+r.fileNamePrefix)
+}
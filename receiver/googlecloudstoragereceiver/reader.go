@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+// objectCallback is a function that processes the content of a single object.
+type objectCallback func(ctx context.Context, name string, content []byte) error
+
+// objectReader defines a common interface for the components reading objects from a bucket.
+type objectReader interface {
+	// readAll processes all the objects matching the criteria of the reader and passes their content to the callback.
+	readAll(ctx context.Context, telemetryType string, callback objectCallback) error
+}
+
+// retrieveObject downloads the content of an object.
+func retrieveObject(ctx context.Context, bucket *storage.BucketHandle, name string) ([]byte, error) {
+	reader, err := bucket.Object(name).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %q: %w", name, err)
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %q: %w", name, err)
+	}
+	return content, nil
+}
+
+// fileNamePrefix returns the prefix of the file names written by the exporter with the given file prefix:
+// "<file_prefix>_", or the file prefix itself when it ends with "/".
+func fileNamePrefix(filePrefix string) string {
+	if filePrefix == "" || strings.HasSuffix(filePrefix, "/") {
+		return filePrefix
+	}
+	return filePrefix + "_"
+}
+
+// joinPath joins the non-empty segments of an object name, adding "/" between segments not ending with one.
+func joinPath(segments ...string) string {
+	var sb strings.Builder
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "/") {
+			sb.WriteByte('/')
+		}
+		sb.WriteString(segment)
+	}
+	return sb.String()
+}
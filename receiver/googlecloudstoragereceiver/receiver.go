@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/storage"
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	telemetryTypeLogs   = "logs"
+	telemetryTypeTraces = "traces"
+
+	formatOTLPJSON = "otlp_json"
+)
+
+var (
+	errNotLogsUnmarshaler   = errors.New("extension is not a logs unmarshaler")
+	errNotTracesUnmarshaler = errors.New("extension is not a traces unmarshaler")
+)
+
+type receiverProcessor interface {
+	// start loads the unmarshaler of the processor.
+	start(host component.Host, encoding *component.ID) error
+	processReceivedData(ctx context.Context, receiver *gcsReceiver, name string, data []byte) error
+}
+
+type gcsReceiver struct {
+	cfg           *Config
+	logger        *zap.Logger
+	obsrecv       *receiverhelper.ObsReport
+	telemetryType string
+	dataProcessor receiverProcessor
+	notifier      statusNotifier
+
+	storageClient *storage.Client
+	pubsubClient  *pubsub.Client
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+func newGCSReceiver(cfg *Config, telemetryType string, settings receiver.Settings, processor receiverProcessor) (*gcsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "gcs",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &gcsReceiver{
+		cfg:           cfg,
+		logger:        settings.Logger,
+		obsrecv:       obsrecv,
+		telemetryType: telemetryType,
+		dataProcessor: processor,
+		notifier:      newNotifier(cfg, settings.Logger),
+	}, nil
+}
+
+func (r *gcsReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.notifier != nil {
+		if err := r.notifier.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	if err := r.dataProcessor.start(host, r.cfg.Encoding); err != nil {
+		return err
+	}
+
+	var clientOpts []option.ClientOption
+	if r.cfg.UniverseDomain != "" {
+		clientOpts = append(clientOpts, option.WithUniverseDomain(r.cfg.UniverseDomain))
+	}
+	// The client uses the STORAGE_EMULATOR_HOST environment variable, when set, to target a fake server.
+	storageClient, err := storage.NewClient(ctx, clientOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create storage client: %w", err)
+	}
+	r.storageClient = storageClient
+	bucket := storageClient.Bucket(r.cfg.Bucket.Name)
+
+	var reader objectReader
+	if r.cfg.PubSub != nil {
+		if r.pubsubClient, err = r.newPubSubClient(ctx, clientOpts); err != nil {
+			return fmt.Errorf("failed to create pubsub client: %w", err)
+		}
+		reader = newPubSubNotificationReader(r.logger, bucket, r.pubsubClient, r.cfg)
+	} else {
+		if reader, err = newTimeRangeReader(r.logger, bucket, r.notifier, r.cfg); err != nil {
+			return err
+		}
+	}
+
+	var cancelCtx context.Context
+	cancelCtx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		_ = reader.readAll(cancelCtx, r.telemetryType, r.receiveBytes)
+	}()
+	return nil
+}
+
+func (r *gcsReceiver) newPubSubClient(ctx context.Context, clientOpts []option.ClientOption) (*pubsub.Client, error) {
+	if r.cfg.PubSub.Endpoint != "" {
+		if r.cfg.PubSub.Insecure {
+			conn, err := grpc.NewClient(r.cfg.PubSub.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return nil, err
+			}
+			// the connection is closed with the client
+			clientOpts = append(clientOpts, option.WithGRPCConn(conn))
+		} else {
+			clientOpts = append(clientOpts, option.WithEndpoint(r.cfg.PubSub.Endpoint))
+		}
+	}
+	return pubsub.NewClient(ctx, r.cfg.PubSub.projectID(), clientOpts...)
+}
+
+func (r *gcsReceiver) Shutdown(ctx context.Context) error {
+	var errs []error
+	if r.notifier != nil {
+		errs = append(errs, r.notifier.Shutdown(ctx))
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.pubsubClient != nil {
+		errs = append(errs, r.pubsubClient.Close())
+	}
+	if r.storageClient != nil {
+		errs = append(errs, r.storageClient.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// receiveBytes decompresses the objects compressed by the exporter before processing them. The objects which can't
+// be decompressed or unmarshaled are returned a permanent error, as reading them again doesn't help.
+func (r *gcsReceiver) receiveBytes(ctx context.Context, name string, data []byte) (err error) {
+	if len(data) == 0 {
+		return nil
+	}
+	if strings.HasSuffix(name, ".gz") {
+		var reader *gzip.Reader
+		reader, err = gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to decompress %q: %w", name, err))
+		}
+		defer func() {
+			if closeErr := reader.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}()
+		name = strings.TrimSuffix(name, ".gz")
+		data, err = io.ReadAll(reader)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to decompress %q: %w", name, err))
+		}
+	} else if strings.HasSuffix(name, ".zst") {
+		var reader *zstd.Decoder
+		reader, err = zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to decompress %q: %w", name, err))
+		}
+		defer reader.Close()
+		name = strings.TrimSuffix(name, ".zst")
+		data, err = io.ReadAll(reader)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to decompress %q: %w", name, err))
+		}
+	}
+	return r.dataProcessor.processReceivedData(ctx, r, name, data)
+}
+
+type tracesReceiver struct {
+	consumer    consumer.Traces
+	unmarshaler ptrace.Unmarshaler
+	format      string
+}
+
+func newGCSTracesReceiver(cfg *Config, traces consumer.Traces, settings receiver.Settings) (*gcsReceiver, error) {
+	return newGCSReceiver(cfg, telemetryTypeTraces, settings, &tracesReceiver{consumer: traces})
+}
+
+func (r *tracesReceiver) start(host component.Host, encoding *component.ID) error {
+	r.unmarshaler, r.format = &ptrace.JSONUnmarshaler{}, formatOTLPJSON
+	if encoding != nil {
+		unmarshaler, err := loadExtension[ptrace.Unmarshaler](host, *encoding, errNotTracesUnmarshaler)
+		if err != nil {
+			return fmt.Errorf("failed to load traces extension: %w", err)
+		}
+		r.unmarshaler, r.format = unmarshaler, encoding.String()
+	}
+	return nil
+}
+
+func (r *tracesReceiver) processReceivedData(ctx context.Context, rcvr *gcsReceiver, name string, data []byte) error {
+	rcvr.logger.Debug("Processing trace object", zap.String("name", name), zap.String("format", r.format))
+	traces, err := r.unmarshaler.UnmarshalTraces(data)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal traces from %q: %w", name, err))
+	}
+	obsCtx := rcvr.obsrecv.StartTracesOp(ctx)
+	err = r.consumer.ConsumeTraces(ctx, traces)
+	rcvr.obsrecv.EndTracesOp(obsCtx, r.format, traces.SpanCount(), err)
+	return err
+}
+
+type logsReceiver struct {
+	consumer    consumer.Logs
+	unmarshaler plog.Unmarshaler
+	format      string
+}
+
+func newGCSLogsReceiver(cfg *Config, logs consumer.Logs, settings receiver.Settings) (*gcsReceiver, error) {
+	return newGCSReceiver(cfg, telemetryTypeLogs, settings, &logsReceiver{consumer: logs})
+}
+
+func (r *logsReceiver) start(host component.Host, encoding *component.ID) error {
+	r.unmarshaler, r.format = &plog.JSONUnmarshaler{}, formatOTLPJSON
+	if encoding != nil {
+		unmarshaler, err := loadExtension[plog.Unmarshaler](host, *encoding, errNotLogsUnmarshaler)
+		if err != nil {
+			return fmt.Errorf("failed to load logs extension: %w", err)
+		}
+		r.unmarshaler, r.format = unmarshaler, encoding.String()
+	}
+	return nil
+}
+
+func (r *logsReceiver) processReceivedData(ctx context.Context, rcvr *gcsReceiver, name string, data []byte) error {
+	rcvr.logger.Debug("Processing log object", zap.String("name", name), zap.String("format", r.format))
+	logs, err := r.unmarshaler.UnmarshalLogs(data)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal logs from %q: %w", name, err))
+	}
+	obsCtx := rcvr.obsrecv.StartLogsOp(ctx)
+	err = r.consumer.ConsumeLogs(ctx, logs)
+	rcvr.obsrecv.EndLogsOp(obsCtx, r.format, logs.LogRecordCount(), err)
+	return err
+}
+
+// loadExtension tries to load an available extension for the given id.
+func loadExtension[T any](host component.Host, id component.ID, errNotUnmarshaler error) (T, error) {
+	var zero T
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return zero, fmt.Errorf("unknown extension %q", id)
+	}
+	extT, ok := ext.(T)
+	if !ok {
+		return zero, fmt.Errorf("extension %q: %w", id, errNotUnmarshaler)
+	}
+	return extT, nil
+}
@@ -0,0 +1,433 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	"github.com/klauspost/compress/zstd"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampcustommessages"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver/internal/metadata"
+)
+
+const testBucket = "archive"
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func newTestHost(extensions map[component.ID]component.Component) component.Host {
+	return &testHost{Host: componenttest.NewNopHost(), extensions: extensions}
+}
+
+func testLogsJSON(t *testing.T, body string) []byte {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	data, err := (&plog.JSONMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	return data
+}
+
+func gzipData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func logBodies(sink *consumertest.LogsSink) []string {
+	var bodies []string
+	for _, logs := range sink.AllLogs() {
+		for _, rl := range logs.ResourceLogs().All() {
+			for _, sl := range rl.ScopeLogs().All() {
+				for _, lr := range sl.LogRecords().All() {
+					bodies = append(bodies, lr.Body().Str())
+				}
+			}
+		}
+	}
+	return bodies
+}
+
+func startLogsReceiver(t *testing.T, cfg *Config, host component.Host) *consumertest.LogsSink {
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(t.Context(), host))
+	t.Cleanup(func() {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+	return sink
+}
+
+func TestTimeRangeReceiver(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	created := time.Date(2024, time.January, 31, 15, 30, 0, 0, time.UTC)
+	fake.putObject(testBucket, "otel/year=2024/month=01/day=31/hour=14/logs_before", testLogsJSON(t, "before"), created)
+	fake.putObject(testBucket, "otel/year=2024/month=01/day=31/hour=15/logs_plain", testLogsJSON(t, "plain"), created)
+	fake.putObject(testBucket, "otel/year=2024/month=01/day=31/hour=15/traces_other", testLogsJSON(t, "other"), created)
+	fake.putObject(testBucket, "otel/year=2024/month=01/day=31/hour=16/logs_gzip.gz", gzipData(t, testLogsJSON(t, "gzip")), created)
+	fake.putObject(testBucket, "otel/year=2024/month=01/day=31/hour=17/logs_zstd.zst", zstdData(t, testLogsJSON(t, "zstd")), created)
+	fake.putObject(testBucket, "otel/year=2024/month=01/day=31/hour=18/logs_after", testLogsJSON(t, "after"), created)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.Bucket.Partition.Prefix = "otel"
+	cfg.Bucket.Partition.Format = "year=%Y/month=%m/day=%d/hour=%H"
+	cfg.StartTime = "2024-01-31T15:30:00Z"
+	cfg.EndTime = "2024-01-31T18:00:00Z"
+	sink := startLogsReceiver(t, cfg, componenttest.NewNopHost())
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"plain", "gzip", "zstd"}, logBodies(sink))
+}
+
+func TestTimeRangeReceiverWithoutPartitions(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	fake.putObject(testBucket, "logs_before", testLogsJSON(t, "before"), time.Date(2024, time.January, 30, 0, 0, 0, 0, time.UTC))
+	fake.putObject(testBucket, "logs_inside", testLogsJSON(t, "inside"), time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC))
+	fake.putObject(testBucket, "logs_after", testLogsJSON(t, "after"), time.Date(2024, time.February, 3, 0, 0, 0, 0, time.UTC))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.StartTime = "2024-01-31"
+	cfg.EndTime = "2024-02-03"
+	sink := startLogsReceiver(t, cfg, componenttest.NewNopHost())
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"inside"}, logBodies(sink))
+}
+
+type fakeTracesUnmarshaler struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (fakeTracesUnmarshaler) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(string(buf))
+	return traces, nil
+}
+
+func TestTimeRangeReceiverEncoding(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	fake.putObject(testBucket, "2024/01/31/traces/span", []byte("span"), time.Now())
+
+	encoding := component.MustNewID("fake_encoding")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.Bucket.FilePrefix = "traces/"
+	cfg.Bucket.Partition.Format = "%Y/%m/%d"
+	cfg.StartTime = "2024-01-31"
+	cfg.EndTime = "2024-02-01"
+	cfg.Encoding = &encoding
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.TracesSink)
+	rcvr, err := NewFactory().CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.ErrorContains(t, rcvr.Start(t.Context(), componenttest.NewNopHost()), `unknown extension "fake_encoding"`)
+	require.NoError(t, rcvr.Shutdown(t.Context()))
+
+	rcvr, err = NewFactory().CreateTraces(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(t.Context(), newTestHost(map[component.ID]component.Component{
+		encoding: fakeTracesUnmarshaler{},
+	})))
+	defer func() {
+		require.NoError(t, rcvr.Shutdown(t.Context()))
+	}()
+	require.Eventually(t, func() bool {
+		return sink.SpanCount() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "span", sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+// recordingRegistry records the messages sent through the OpAMP custom capability.
+type recordingRegistry struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu       sync.Mutex
+	messages []plog.Logs
+}
+
+func (r *recordingRegistry) Register(string, ...opampcustommessages.CustomCapabilityRegisterOption) (opampcustommessages.CustomCapabilityHandler, error) {
+	return r, nil
+}
+
+func (*recordingRegistry) Message() <-chan *protobufs.CustomMessage {
+	return nil
+}
+
+func (r *recordingRegistry) SendMessage(_ string, message []byte) (chan struct{}, error) {
+	logs, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(message)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, logs)
+	return nil, nil
+}
+
+func (*recordingRegistry) Unregister() {}
+
+func (r *recordingRegistry) statuses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var statuses []string
+	for _, logs := range r.messages {
+		status, _ := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("ingest_status")
+		statuses = append(statuses, status.Str())
+	}
+	return statuses
+}
+
+func TestTimeRangeReceiverOpAMPNotifications(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	fake.putObject(testBucket, "2024/01/31/00/logs_object", testLogsJSON(t, "object"), time.Now())
+
+	opamp := component.MustNewID("opamp")
+	registry := &recordingRegistry{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.Bucket.Partition.Format = "%Y/%m/%d/%H"
+	cfg.StartTime = "2024-01-31 00:00"
+	cfg.EndTime = "2024-01-31 02:00"
+	cfg.Notifications.OpAMP = &opamp
+	sink := startLogsReceiver(t, cfg, newTestHost(map[component.ID]component.Component{opamp: registry}))
+
+	assert.Eventually(t, func() bool {
+		statuses := registry.statuses()
+		return len(statuses) > 0 && statuses[len(statuses)-1] == IngestStatusCompleted
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{IngestStatusIngesting, IngestStatusIngesting, IngestStatusCompleted}, registry.statuses())
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestTimeRangeReceiverOpAMPFailure(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	fake.putObject(testBucket, "logs_invalid", []byte("not json"), time.Date(2024, time.January, 31, 1, 0, 0, 0, time.UTC))
+
+	opamp := component.MustNewID("opamp")
+	registry := &recordingRegistry{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.StartTime = "2024-01-31"
+	cfg.EndTime = "2024-02-01"
+	cfg.Notifications.OpAMP = &opamp
+	startLogsReceiver(t, cfg, newTestHost(map[component.ID]component.Component{opamp: registry}))
+
+	assert.Eventually(t, func() bool {
+		return len(registry.statuses()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{IngestStatusIngesting, IngestStatusFailed}, registry.statuses())
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	failure, _ := registry.messages[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("failure_message")
+	assert.Contains(t, failure.Str(), `failed to unmarshal logs from "logs_invalid"`)
+}
+
+// newPubSubServer returns a Pub/Sub server with the projects/my-project/subscriptions/gcs subscription.
+func newPubSubServer(t *testing.T) *pstest.Server {
+	srv := pstest.NewServer()
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Close())
+	})
+	_, err := srv.GServer.CreateTopic(t.Context(), &pb.Topic{Name: "projects/my-project/topics/gcs"})
+	require.NoError(t, err)
+	_, err = srv.GServer.CreateSubscription(t.Context(), &pb.Subscription{
+		Topic:              "projects/my-project/topics/gcs",
+		Name:               "projects/my-project/subscriptions/gcs",
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+	return srv
+}
+
+// publishNotification publishes the notification of an object on the topic of the subscription, returning its ID.
+func publishNotification(srv *pstest.Server, eventType, bucket, name string) string {
+	return srv.Publish("projects/my-project/topics/gcs", []byte("{}"), map[string]string{
+		attributeEventType: eventType,
+		attributeBucketID:  bucket,
+		attributeObjectID:  name,
+	})
+}
+
+func TestPubSubReceiver(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	srv := newPubSubServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.Bucket.Partition.Prefix = "otel"
+	cfg.PubSub = &PubSubConfig{
+		Subscription: "projects/my-project/subscriptions/gcs",
+		Endpoint:     srv.Addr,
+		Insecure:     true,
+	}
+	sink := startLogsReceiver(t, cfg, componenttest.NewNopHost())
+
+	notify := func(eventType, bucket, name string) string {
+		return publishNotification(srv, eventType, bucket, name)
+	}
+	fake.putObject(testBucket, "otel/2024/logs_first.gz", gzipData(t, testLogsJSON(t, "first")), time.Now())
+	fake.putObject(testBucket, "other/logs_other", testLogsJSON(t, "other prefix"), time.Now())
+	fake.putObject(testBucket, "otel/traces_other", testLogsJSON(t, "other file prefix"), time.Now())
+	fake.putObject("other", "otel/logs_other", testLogsJSON(t, "other bucket"), time.Now())
+	ignored := []string{
+		notify("OBJECT_DELETE", testBucket, "otel/2024/logs_first.gz"),
+		notify(eventTypeObjectFinalize, "other", "otel/logs_other"),
+		notify(eventTypeObjectFinalize, testBucket, "other/logs_other"),
+		notify(eventTypeObjectFinalize, testBucket, "otel/traces_other"),
+		notify(eventTypeObjectFinalize, testBucket, "otel/logs_deleted"),
+	}
+	notify(eventTypeObjectFinalize, testBucket, "otel/2024/logs_first.gz")
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"first"}, logBodies(sink))
+	// The skipped notifications, including the one of a deleted object, are acknowledged.
+	assert.Eventually(t, func() bool {
+		for _, id := range ignored {
+			if msg := srv.Message(id); msg == nil || msg.Acks == 0 {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestPubSubReceiverRedelivery(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	srv := newPubSubServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.PubSub = &PubSubConfig{
+		Subscription: "projects/my-project/subscriptions/gcs",
+		Endpoint:     srv.Addr,
+		Insecure:     true,
+	}
+	require.NoError(t, cfg.Validate())
+	consumer := &failingOnceConsumer{}
+	rcvr, err := NewFactory().CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+
+	fake.putObject(testBucket, "logs_object", testLogsJSON(t, "object"), time.Now())
+	publishNotification(srv, eventTypeObjectFinalize, testBucket, "logs_object")
+
+	// The notification is not acknowledged when the consumer fails, and the object is read again.
+	assert.Eventually(t, func() bool {
+		return consumer.LogRecordCount() == 1
+	}, 30*time.Second, 10*time.Millisecond)
+	consumer.mu.Lock()
+	defer consumer.mu.Unlock()
+	assert.Equal(t, 2, consumer.calls)
+}
+
+func TestPubSubReceiverInvalidObject(t *testing.T) {
+	fake := newFakeGCSServer(t)
+	srv := newPubSubServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Bucket.Name = testBucket
+	cfg.PubSub = &PubSubConfig{
+		Subscription: "projects/my-project/subscriptions/gcs",
+		Endpoint:     srv.Addr,
+		Insecure:     true,
+	}
+	sink := startLogsReceiver(t, cfg, componenttest.NewNopHost())
+
+	fake.putObject(testBucket, "logs_not_gzip.gz", testLogsJSON(t, "not gzip"), time.Now())
+	fake.putObject(testBucket, "logs_not_json", []byte("not json"), time.Now())
+	invalid := []string{
+		publishNotification(srv, eventTypeObjectFinalize, testBucket, "logs_not_gzip.gz"),
+		publishNotification(srv, eventTypeObjectFinalize, testBucket, "logs_not_json"),
+	}
+	fake.putObject(testBucket, "logs_valid", testLogsJSON(t, "valid"), time.Now())
+	publishNotification(srv, eventTypeObjectFinalize, testBucket, "logs_valid")
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"valid"}, logBodies(sink))
+	// The notifications of the objects which can't be decoded are acknowledged, and not delivered again.
+	assert.Eventually(t, func() bool {
+		for _, id := range invalid {
+			if msg := srv.Message(id); msg == nil || msg.Acks == 0 {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	for _, id := range invalid {
+		assert.Equal(t, 1, srv.Message(id).Deliveries)
+	}
+}
+
+// failingOnceConsumer fails to consume the first logs.
+type failingOnceConsumer struct {
+	consumertest.LogsSink
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *failingOnceConsumer) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	c.mu.Lock()
+	c.calls++
+	first := c.calls == 1
+	c.mu.Unlock()
+	if first {
+		return errors.New("consumer failed")
+	}
+	return c.LogsSink.ConsumeLogs(ctx, logs)
+}
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package googlecloudstoragereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/googlecloudstoragereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/lestrrat-go/strftime"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+)
+
+// timeRangeReader reads the objects of the time partitions, or created, between the start and end times.
+type timeRangeReader struct {
+	logger          *zap.Logger
+	bucket          *storage.BucketHandle
+	partitionPrefix string
+	partitionFormat *strftime.Strftime
+	filePrefix      string
+	startTime       time.Time
+	endTime         time.Time
+	notifier        statusNotifier
+}
+
+func newTimeRangeReader(logger *zap.Logger, bucket *storage.BucketHandle, notifier statusNotifier, cfg *Config) (*timeRangeReader, error) {
+	startTime, err := parseTime(cfg.StartTime, "starttime")
+	if err != nil {
+		return nil, err
+	}
+	endTime, err := parseTime(cfg.EndTime, "endtime")
+	if err != nil {
+		return nil, err
+	}
+
+	var partitionFormat *strftime.Strftime
+	if cfg.Bucket.Partition.Format != "" {
+		partitionFormat, err = strftime.New(cfg.Bucket.Partition.Format)
+		if err != nil {
+			// should not happen here, prevented by config.Validate
+			return nil, fmt.Errorf("failed to parse partition format: %w", err)
+		}
+	}
+
+	return &timeRangeReader{
+		logger:          logger,
+		bucket:          bucket,
+		partitionPrefix: cfg.Bucket.Partition.Prefix,
+		partitionFormat: partitionFormat,
+		filePrefix:      cfg.Bucket.FilePrefix,
+		startTime:       startTime.UTC(),
+		endTime:         endTime.UTC(),
+		notifier:        notifier,
+	}, nil
+}
+
+// readAll implements the objectReader interface
+func (r *timeRangeReader) readAll(ctx context.Context, telemetryType string, callback objectCallback) error {
+	r.logger.Info("Start reading telemetry", zap.Time("start_time", r.startTime), zap.Time("end_time", r.endTime))
+	if r.partitionFormat == nil {
+		// Without time partitions, the objects are selected on their creation time.
+		r.sendStatus(ctx, telemetryType, IngestStatusIngesting, r.startTime, "")
+		if err := r.readObjects(ctx, r.objectPrefix(r.startTime), true, callback); err != nil {
+			r.sendStatus(ctx, telemetryType, IngestStatusFailed, r.startTime, err.Error())
+			r.logger.Error("Error reading telemetry", zap.Error(err))
+			return err
+		}
+	} else {
+		timeStep, err := determineTimeStep(r.partitionFormat.Pattern())
+		if err != nil {
+			return err
+		}
+		// The first partition is the one holding the start time.
+		for currentTime := r.startTime.Truncate(timeStep); currentTime.Before(r.endTime); currentTime = currentTime.Add(timeStep) {
+			r.sendStatus(ctx, telemetryType, IngestStatusIngesting, currentTime, "")
+
+			select {
+			case <-ctx.Done():
+				r.sendStatus(ctx, telemetryType, IngestStatusFailed, currentTime, ctx.Err().Error())
+				r.logger.Error("Context cancelled, stopping reading telemetry", zap.Time("time", currentTime))
+				return ctx.Err()
+			default:
+				r.logger.Info("Reading telemetry", zap.Time("time", currentTime))
+				if err := r.readObjects(ctx, r.objectPrefix(currentTime), false, callback); err != nil {
+					r.sendStatus(ctx, telemetryType, IngestStatusFailed, currentTime, err.Error())
+					r.logger.Error("Error reading telemetry", zap.Error(err), zap.Time("time", currentTime))
+					return err
+				}
+			}
+		}
+	}
+	r.sendStatus(ctx, telemetryType, IngestStatusCompleted, r.endTime, "")
+	r.logger.Info("Finished reading telemetry", zap.Time("start_time", r.startTime), zap.Time("end_time", r.endTime))
+	return nil
+}
+
+// readObjects reads the objects with the given prefix, only keeping those created in the time range when
+// filterCreated is true.
+func (r *timeRangeReader) readObjects(ctx context.Context, prefix string, filterCreated bool, callback objectCallback) error {
+	r.logger.Debug("Finding telemetry with prefix", zap.String("prefix", prefix))
+	it := r.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	found := false
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list objects with prefix %q: %w", prefix, err)
+		}
+		if filterCreated && (attrs.Created.Before(r.startTime) || !attrs.Created.Before(r.endTime)) {
+			continue
+		}
+		found = true
+
+		content, err := retrieveObject(ctx, r.bucket, attrs.Name)
+		if err != nil {
+			return err
+		}
+		r.logger.Debug("Retrieved telemetry", zap.String("name", attrs.Name))
+		if err := callback(ctx, attrs.Name, content); err != nil {
+			return err
+		}
+	}
+	if !found {
+		r.logger.Info("No telemetry found", zap.String("prefix", prefix))
+	}
+	return nil
+}
+
+// objectPrefix returns the prefix of the objects written by the exporter at the given time:
+// <partition.prefix>/<partition.format>/<file_prefix>_
+func (r *timeRangeReader) objectPrefix(t time.Time) string {
+	timeKey := ""
+	if r.partitionFormat != nil {
+		timeKey = r.partitionFormat.FormatString(t.UTC())
+	}
+	dir := joinPath(r.partitionPrefix, timeKey)
+	if dir != "" && !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+	return dir + fileNamePrefix(r.filePrefix)
+}
+
+func (r *timeRangeReader) sendStatus(ctx context.Context, telemetryType, status string, ingestTime time.Time, failureMessage string) {
+	if r.notifier != nil {
+		r.notifier.SendStatus(ctx, statusNotification{
+			TelemetryType:  telemetryType,
+			IngestStatus:   status,
+			StartTime:      r.startTime,
+			EndTime:        r.endTime,
+			IngestTime:     ingestTime,
+			FailureMessage: failureMessage,
+		})
+	}
+}
+
+// determineTimeStep returns the duration between two consecutive partitions of the format.
+func determineTimeStep(partitionFormat string) (time.Duration, error) {
+	format, err := strftime.New(partitionFormat)
+	if err != nil {
+		return 0, fmt.Errorf("invalid partition format: %w", err)
+	}
+	startTime := time.Date(2025, time.December, 5, 11, 30, 0, 0, time.UTC)
+	startTimeKey := format.FormatString(startTime)
+	for _, step := range []time.Duration{time.Minute, time.Hour, 24 * time.Hour} {
+		if format.FormatString(startTime.Add(step)) != startTimeKey {
+			return step, nil
+		}
+	}
+	return 0, fmt.Errorf("no time step found for partition format %s", partitionFormat)
+}
@@ -19,11 +19,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/nfsscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
@@ -78,9 +80,15 @@ func TestLoadConfig(t *testing.T) {
 						}
 						return cfg
 					}(),
+					component.MustNewType("hwmon"):     hwmonscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("nfs"):       nfsscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("processes"): processesscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("paging"):    pagingscraper.NewFactory().CreateDefaultConfig(),
+					component.MustNewType("pressure"): func() component.Config {
+						cfg := pressurescraper.NewFactory().CreateDefaultConfig()
+						cfg.(*pressurescraper.Config).Cgroups = []string{"/system.slice/*"}
+						return cfg
+					}(),
 					component.MustNewType("process"): func() component.Config {
 						cfg := processscraper.NewFactory().CreateDefaultConfig()
 						cfg.(*processscraper.Config).Include = processscraper.MatchConfig{
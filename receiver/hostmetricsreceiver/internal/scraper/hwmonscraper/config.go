@@ -0,0 +1,14 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hwmonscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper/internal/metadata"
+)
+
+// Config relating to Hardware Sensors Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	MetricsBuilderConfig metadata.MetricsBuilderConfig `mapstructure:",squash"`
+}
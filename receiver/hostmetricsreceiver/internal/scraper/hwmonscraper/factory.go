@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hwmonscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper"
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/scraper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper/internal/metadata"
+)
+
+var (
+	supportedOS      = runtime.GOOS == "linux"
+	errUnsupportedOS = errors.New("the hwmon scraper is only available on Linux")
+)
+
+// NewFactory for Hardware Sensors scraper.
+func NewFactory() scraper.Factory {
+	return scraper.NewFactory(metadata.Type, createDefaultConfig, scraper.WithMetrics(createMetricsScraper, metadata.MetricsStability))
+}
+
+// createDefaultConfig creates the default configuration for the Scraper.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MetricsBuilderConfig: metadata.NewDefaultMetricsBuilderConfig(),
+	}
+}
+
+// createMetricsScraper creates a scraper based on provided config.
+func createMetricsScraper(
+	_ context.Context,
+	settings scraper.Settings,
+	cfg component.Config,
+) (scraper.Metrics, error) {
+	if !supportedOS {
+		return nil, errUnsupportedOS
+	}
+
+	s := newHwmonScraper(settings, cfg.(*Config))
+
+	return scraper.NewMetrics(
+		s.scrape,
+		scraper.WithStart(s.start),
+	)
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hwmonscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper"
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Kinds of the sensor inputs that are reported, see https://docs.kernel.org/hwmon/sysfs-interface.html
+const (
+	kindTemperature = "temp"
+	kindFan         = "fan"
+	kindVoltage     = "in"
+)
+
+// inputScales convert the values of the inputs, in millidegree Celsius, RPM and millivolt, to the units of the metrics
+var inputScales = map[string]float64{
+	kindTemperature: 1000,
+	kindFan:         1,
+	kindVoltage:     1000,
+}
+
+// sensor is an input of a hwmon device, its values are in the units of the metrics
+type sensor struct {
+	// id is the hwmon device and input of the sensor, e.g. hwmon0/temp1
+	id       string
+	chip     string
+	location string
+	kind     string
+	value    float64
+	// max and crit are the limits of temperature sensors, when the driver reports them
+	max  *float64
+	crit *float64
+}
+
+// readSensors returns the temperature, fan and voltage sensors of the hwmon devices under root.
+// Inputs that cannot be read, for instance because the sensor is disconnected, are skipped.
+func readSensors(root string, logger *zap.Logger) ([]sensor, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+	devices, err := filepath.Glob(filepath.Join(root, "hwmon*"))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(devices, compareNatural)
+
+	var sensors []sensor
+	for _, device := range devices {
+		deviceName := filepath.Base(device)
+		chip, err := readString(filepath.Join(device, "name"))
+		if err != nil {
+			chip = deviceName
+		}
+
+		inputs, err := filepath.Glob(filepath.Join(device, "*_input"))
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(inputs, compareNatural)
+		for _, input := range inputs {
+			name := strings.TrimSuffix(filepath.Base(input), "_input")
+			kind := strings.TrimRight(name, "0123456789")
+			scale, ok := inputScales[kind]
+			if !ok {
+				continue
+			}
+
+			value, err := readValue(input, scale)
+			if err != nil {
+				logger.Debug("Failed to read hwmon sensor", zap.String("path", input), zap.Error(err))
+				continue
+			}
+			s := sensor{
+				id:       deviceName + "/" + name,
+				chip:     chip,
+				location: name,
+				kind:     kind,
+				value:    value,
+			}
+			if label, err := readString(filepath.Join(device, name+"_label")); err == nil && label != "" {
+				s.location = label
+			}
+			if kind == kindTemperature {
+				if v, err := readValue(filepath.Join(device, name+"_max"), scale); err == nil {
+					s.max = &v
+				}
+				if v, err := readValue(filepath.Join(device, name+"_crit"), scale); err == nil {
+					s.crit = &v
+				}
+			}
+			sensors = append(sensors, s)
+		}
+	}
+	return sensors, nil
+}
+
+func readString(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readValue reads the integer value of the file, divided by scale
+func readValue(path string, scale float64) (float64, error) {
+	s, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(v) / scale, nil
+}
+
+// compareNatural orders paths by their trailing number, so that hwmon10 comes after hwmon2
+func compareNatural(a, b string) int {
+	prefixA, numA := splitNumber(a)
+	prefixB, numB := splitNumber(b)
+	if c := strings.Compare(prefixA, prefixB); c != 0 {
+		return c
+	}
+	return numA - numB
+}
+
+func splitNumber(path string) (string, int) {
+	name := strings.TrimSuffix(path, "_input")
+	prefix := strings.TrimRight(name, "0123456789")
+	n, _ := strconv.Atoi(name[len(prefix):])
+	return prefix, n
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hwmonscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper/internal/metadata"
+)
+
+const metricsLen = 4
+
+// hwmonScraper for Hardware Sensors Metrics
+type hwmonScraper struct {
+	settings scraper.Settings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	// for mocking
+	now func() time.Time
+}
+
+// newHwmonScraper creates a scraper for the hardware sensors exposed by the hwmon drivers
+func newHwmonScraper(settings scraper.Settings, cfg *Config) *hwmonScraper {
+	return &hwmonScraper{settings: settings, config: cfg, now: time.Now}
+}
+
+func (s *hwmonScraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *hwmonScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(s.now())
+	root := gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "class", "hwmon")
+	sensors, err := readSensors(root, s.settings.Logger)
+	if err != nil {
+		return pmetric.NewMetrics(), scrapererror.NewPartialScrapeError(fmt.Errorf("failed to read hwmon sensors: %w", err), metricsLen)
+	}
+
+	for _, sensor := range sensors {
+		switch sensor.kind {
+		case kindTemperature:
+			s.mb.RecordHwTemperatureDataPoint(now, sensor.value, sensor.id, sensor.chip, sensor.location)
+			if sensor.max != nil {
+				s.mb.RecordHwTemperatureLimitDataPoint(now, *sensor.max, sensor.id, sensor.chip, sensor.location, metadata.AttributeHwLimitTypeHighDegraded)
+			}
+			if sensor.crit != nil {
+				s.mb.RecordHwTemperatureLimitDataPoint(now, *sensor.crit, sensor.id, sensor.chip, sensor.location, metadata.AttributeHwLimitTypeHighCritical)
+			}
+		case kindFan:
+			s.mb.RecordHwFanSpeedDataPoint(now, int64(sensor.value), sensor.id, sensor.chip, sensor.location)
+		case kindVoltage:
+			s.mb.RecordHwVoltageDataPoint(now, sensor.value, sensor.id, sensor.chip, sensor.location)
+		}
+	}
+
+	return s.mb.Emit(), nil
+}
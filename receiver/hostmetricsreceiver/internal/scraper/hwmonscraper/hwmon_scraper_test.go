@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hwmonscraper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/hwmonscraper/internal/metadata"
+)
+
+func scrapeSysfs(t *testing.T, sysPath string, configure func(cfg *Config)) (pmetric.Metrics, error) {
+	cfg := createDefaultConfig().(*Config)
+	if configure != nil {
+		configure(cfg)
+	}
+	s := newHwmonScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	// The sysfs path is resolved the same way as with a root_path
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{common.HostSysEnvKey: sysPath})
+	require.NoError(t, s.start(ctx, componenttest.NewNopHost()))
+	return s.scrape(ctx)
+}
+
+// sensorValues returns the values of the data points of the metric, keyed by the hw.id attribute and the hw.limit_type one when set
+func sensorValues(t *testing.T, metrics pmetric.Metrics, name string) map[string]float64 {
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() != name {
+			continue
+		}
+		values := map[string]float64{}
+		dps := ms.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			key := dps.At(j).Attributes().AsRaw()["hw.id"].(string)
+			if limitType, ok := dps.At(j).Attributes().Get("hw.limit_type"); ok {
+				key += " " + limitType.Str()
+			}
+			if dps.At(j).ValueType() == pmetric.NumberDataPointValueTypeInt {
+				values[key] = float64(dps.At(j).IntValue())
+			} else {
+				values[key] = dps.At(j).DoubleValue()
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func TestScrape(t *testing.T) {
+	metrics, err := scrapeSysfs(t, filepath.Join("testdata", "sys"), func(cfg *Config) {
+		cfg.MetricsBuilderConfig.Metrics.HwTemperatureLimit.Enabled = true
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{
+		"hwmon0/temp1":  45,
+		"hwmon0/temp2":  43.5,
+		"hwmon1/temp1":  35,
+		"hwmon10/temp1": 38.85,
+	}, sensorValues(t, metrics, "hw.temperature"))
+	assert.Equal(t, map[string]float64{
+		"hwmon0/temp1 high.degraded": 80,
+		"hwmon0/temp1 high.critical": 100,
+		"hwmon0/temp2 high.degraded": 80,
+	}, sensorValues(t, metrics, "hw.temperature.limit"))
+	assert.Equal(t, map[string]float64{
+		"hwmon1/fan1": 1200,
+		"hwmon1/fan2": 0,
+	}, sensorValues(t, metrics, "hw.fan.speed"))
+	assert.Equal(t, map[string]float64{
+		"hwmon1/in0": 1.104,
+		"hwmon1/in1": 12.096,
+	}, sensorValues(t, metrics, "hw.voltage"))
+
+	dps := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Gauge().DataPoints()
+	assert.Equal(t, map[string]any{
+		"hw.id":           "hwmon0/temp1",
+		"hw.name":         "coretemp",
+		"sensor_location": "Package id 0",
+	}, dps.At(0).Attributes().AsRaw())
+	// Sensors without a label are located by their input name
+	assert.Equal(t, map[string]any{
+		"hw.id":           "hwmon1/fan1",
+		"hw.name":         "nct6775",
+		"sensor_location": "fan1",
+	}, metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestScrapeWithoutHwmon(t *testing.T) {
+	_, err := scrapeSysfs(t, t.TempDir(), nil)
+	require.ErrorContains(t, err, "failed to read hwmon sensors")
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, metricsLen, partialErr.Failed)
+}
+
+func TestReadSensorsOrder(t *testing.T) {
+	sensors, err := readSensors(filepath.Join("testdata", "sys", "class", "hwmon"), zap.NewNop())
+	require.NoError(t, err)
+
+	var ids []string
+	for _, s := range sensors {
+		ids = append(ids, s.id)
+	}
+	// Devices and inputs are in numerical order, the unreadable input of hwmon2 and the power
+	// and intrusion inputs of hwmon1 are skipped
+	assert.Equal(t, []string{
+		"hwmon0/temp1", "hwmon0/temp2",
+		"hwmon1/fan1", "hwmon1/fan2", "hwmon1/in0", "hwmon1/in1", "hwmon1/temp1",
+		"hwmon10/temp1",
+	}, ids)
+}
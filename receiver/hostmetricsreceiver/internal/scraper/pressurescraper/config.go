@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// Config relating to Pressure Stall Information Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	MetricsBuilderConfig metadata.MetricsBuilderConfig `mapstructure:",squash"`
+	// Cgroups are glob patterns of cgroup v2 paths, relative to the root of the cgroup hierarchy,
+	// whose pressure is reported along with the pressure of the host, e.g. "/kubepods.slice/*/*".
+	Cgroups []string `mapstructure:"cgroups"`
+}
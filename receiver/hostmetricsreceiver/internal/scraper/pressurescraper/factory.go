@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/scraper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+var (
+	supportedOS      = runtime.GOOS == "linux"
+	errUnsupportedOS = errors.New("the pressure scraper is only available on Linux")
+)
+
+// NewFactory for Pressure Stall Information scraper.
+func NewFactory() scraper.Factory {
+	return scraper.NewFactory(metadata.Type, createDefaultConfig, scraper.WithMetrics(createMetricsScraper, metadata.MetricsStability))
+}
+
+// createDefaultConfig creates the default configuration for the Scraper.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MetricsBuilderConfig: metadata.NewDefaultMetricsBuilderConfig(),
+	}
+}
+
+// createMetricsScraper creates a scraper based on provided config.
+func createMetricsScraper(
+	_ context.Context,
+	settings scraper.Settings,
+	config component.Config,
+) (scraper.Metrics, error) {
+	if !supportedOS {
+		return nil, errUnsupportedOS
+	}
+
+	cfg := config.(*Config)
+	for _, pattern := range cfg.Cgroups {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid cgroups pattern %q: %w", pattern, err)
+		}
+	}
+
+	s := newPressureScraper(settings, cfg)
+
+	return scraper.NewMetrics(
+		s.scrape,
+		scraper.WithStart(s.start),
+	)
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+func TestPressureScraper(t *testing.T) {
+	factory := NewFactory()
+	cfg := &Config{}
+
+	scraper, err := factory.CreateMetrics(t.Context(), scrapertest.NewNopSettings(metadata.Type), cfg)
+
+	if supportedOS {
+		assert.NoError(t, err)
+		assert.NotNil(t, scraper)
+	} else {
+		assert.ErrorIs(t, err, errUnsupportedOS)
+		assert.Nil(t, scraper)
+	}
+}
+
+func TestCreateMetricsInvalidCgroupPattern(t *testing.T) {
+	if !supportedOS {
+		t.Skip("the pressure scraper is only available on Linux")
+	}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups = []string{"/kubepods.slice/[a-"}
+
+	_, err := NewFactory().CreateMetrics(t.Context(), scrapertest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorContains(t, err, `invalid cgroups pattern "/kubepods.slice/[a-"`)
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// pressure is the content of a PSI file, see https://docs.kernel.org/accounting/psi.html
+type pressure struct {
+	some *stall
+	// full is nil for the CPU pressure of kernels older than 5.13
+	full *stall
+}
+
+type stall struct {
+	avg10  float64
+	avg60  float64
+	avg300 float64
+	// total is the stalled time in microseconds
+	total uint64
+}
+
+func readPressure(path string) (*pressure, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parsePressure(f)
+}
+
+// parsePressure parses lines such as:
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=7890
+func parsePressure(r io.Reader) (*pressure, error) {
+	p := &pressure{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		s, err := parseStall(fields[1:])
+		if err != nil {
+			return nil, err
+		}
+		switch fields[0] {
+		case "some":
+			p.some = s
+		case "full":
+			p.full = s
+		default:
+			return nil, fmt.Errorf("unexpected pressure line %q", scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if p.some == nil {
+		return nil, fmt.Errorf("missing %q pressure line", "some")
+	}
+	return p, nil
+}
+
+func parseStall(fields []string) (*stall, error) {
+	s := &stall{}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid pressure field %q", field)
+		}
+		var err error
+		switch key {
+		case "avg10":
+			s.avg10, err = strconv.ParseFloat(value, 64)
+		case "avg60":
+			s.avg60, err = strconv.ParseFloat(value, 64)
+		case "avg300":
+			s.avg300, err = strconv.ParseFloat(value, 64)
+		case "total":
+			s.total, err = strconv.ParseUint(value, 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid pressure field %q: %w", field, err)
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/shirou/gopsutil/v4/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const metricsLen = 2
+
+// resources are the resources the kernel reports the pressure of, in the order they are scraped
+var resources = []metadata.AttributeResource{
+	metadata.AttributeResourceCPU,
+	metadata.AttributeResourceMemory,
+	metadata.AttributeResourceIo,
+}
+
+// pressureScraper for Pressure Stall Information Metrics
+type pressureScraper struct {
+	settings scraper.Settings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+	now      func() time.Time
+}
+
+// newPressureScraper creates a scraper for the pressure of the host and of the configured cgroups
+func newPressureScraper(settings scraper.Settings, cfg *Config) *pressureScraper {
+	return &pressureScraper{settings: settings, config: cfg, bootTime: host.BootTimeWithContext, now: time.Now}
+}
+
+func (s *pressureScraper) start(ctx context.Context, _ component.Host) error {
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *pressureScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(s.now())
+
+	cgroupRoot := gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "fs", "cgroup")
+	cgroups, err := matchCgroups(cgroupRoot, s.config.Cgroups)
+	if err != nil {
+		errs.AddPartial(metricsLen, err)
+	}
+	for _, cgroup := range cgroups {
+		for _, resource := range resources {
+			p, err := readPressure(filepath.Join(cgroupRoot, cgroup, resource.String()+".pressure"))
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				// The cgroup was removed since it was matched.
+			case err != nil:
+				errs.AddPartial(metricsLen, fmt.Errorf("failed to read the %s pressure of cgroup %s: %w", resource, cgroup, err))
+			default:
+				s.recordPressure(now, resource, p)
+			}
+		}
+		rb := s.mb.NewResourceBuilder()
+		rb.SetCgroupPath(cgroup)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	procPressure := gopsutilenv.GetEnvWithContext(ctx, string(common.HostProcEnvKey), "/proc", "pressure")
+	for _, resource := range resources {
+		p, err := readPressure(filepath.Join(procPressure, resource.String()))
+		if err != nil {
+			errs.AddPartial(metricsLen, fmt.Errorf("failed to read the %s pressure: %w", resource, err))
+			continue
+		}
+		s.recordPressure(now, resource, p)
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *pressureScraper) recordPressure(now pcommon.Timestamp, resource metadata.AttributeResource, p *pressure) {
+	s.recordStall(now, resource, metadata.AttributeStallTypeSome, p.some)
+	if p.full != nil {
+		s.recordStall(now, resource, metadata.AttributeStallTypeFull, p.full)
+	}
+}
+
+func (s *pressureScraper) recordStall(now pcommon.Timestamp, resource metadata.AttributeResource, stallType metadata.AttributeStallType, stall *stall) {
+	s.mb.RecordSystemPressureStallTimeDataPoint(now, float64(stall.total)/1e6, resource, stallType)
+	// The averages are percentages.
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, stall.avg10/100, resource, stallType, metadata.AttributeWindow10s)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, stall.avg60/100, resource, stallType, metadata.AttributeWindow60s)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, stall.avg300/100, resource, stallType, metadata.AttributeWindow300s)
+}
+
+// matchCgroups returns the sorted paths, relative to root, of the cgroups matching the patterns
+func matchCgroups(root string, patterns []string) ([]string, error) {
+	var cgroups []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			rel, err := filepath.Rel(root, match)
+			if err != nil {
+				continue
+			}
+			cgroup := "/" + filepath.ToSlash(rel)
+			if rel == "." {
+				cgroup = "/"
+			}
+			if !slices.Contains(cgroups, cgroup) {
+				cgroups = append(cgroups, cgroup)
+			}
+		}
+	}
+	slices.Sort(cgroups)
+	return cgroups, nil
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// testdataContext returns a context resolving the procfs and sysfs paths the same way as a root_path set to testdata
+func testdataContext(t *testing.T) context.Context {
+	return context.WithValue(t.Context(), common.EnvKey, common.EnvMap{
+		common.HostProcEnvKey: filepath.Join("testdata", "proc"),
+		common.HostSysEnvKey:  filepath.Join("testdata", "sys"),
+	})
+}
+
+func newTestScraper(t *testing.T, ctx context.Context, cgroups ...string) *pressureScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups = cgroups
+	s := newPressureScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	require.NoError(t, s.start(ctx, componenttest.NewNopHost()))
+	return s
+}
+
+// dataPoints returns the values of the data points of the metric, keyed by their attribute values
+func dataPoints(t *testing.T, rm pmetric.ResourceMetrics, name string) map[string]float64 {
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() != name {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		if ms.At(i).Type() == pmetric.MetricTypeSum {
+			dps = ms.At(i).Sum().DataPoints()
+		} else {
+			dps = ms.At(i).Gauge().DataPoints()
+		}
+		values := map[string]float64{}
+		for j := 0; j < dps.Len(); j++ {
+			var key []string
+			for _, attr := range []string{"system.pressure.resource", "system.pressure.stall.type", "system.pressure.window"} {
+				if v, ok := dps.At(j).Attributes().Get(attr); ok {
+					key = append(key, v.Str())
+				}
+			}
+			values[strings.Join(key, "/")] = dps.At(j).DoubleValue()
+		}
+		return values
+	}
+	require.Failf(t, "missing metric", "metric %s not found", name)
+	return nil
+}
+
+func TestScrapeHostPressure(t *testing.T) {
+	ctx := testdataContext(t)
+	s := newTestScraper(t, ctx)
+
+	metrics, err := s.scrape(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, 0, rm.Resource().Attributes().Len())
+
+	assert.Equal(t, map[string]float64{
+		"cpu/some":    2.5,
+		"cpu/full":    0,
+		"memory/some": 4,
+		"memory/full": 3,
+		"io/some":     1.5,
+		"io/full":     0.75,
+	}, dataPoints(t, rm, "system.pressure.stall.time"))
+
+	ratios := dataPoints(t, rm, "system.pressure.stall.ratio")
+	assert.Len(t, ratios, 18)
+	assert.InDelta(t, 0.015, ratios["cpu/some/10s"], 1e-9)
+	assert.InDelta(t, 0.06, ratios["memory/some/60s"], 1e-9)
+	assert.InDelta(t, 0.005, ratios["io/full/300s"], 1e-9)
+
+	stallTime := rm.ScopeMetrics().At(0).Metrics().At(1)
+	assert.Equal(t, "system.pressure.stall.time", stallTime.Name())
+	assert.True(t, stallTime.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, stallTime.Sum().AggregationTemporality())
+	assert.Equal(t, uint64(100e9), uint64(stallTime.Sum().DataPoints().At(0).StartTimestamp()))
+}
+
+func TestScrapeCgroupPressure(t *testing.T) {
+	ctx := testdataContext(t)
+	s := newTestScraper(t, ctx, "/system.slice/*", "/system.slice/nginx.service", "user.slice", "/missing.slice/*")
+
+	metrics, err := s.scrape(ctx)
+	require.NoError(t, err)
+
+	// One resource per cgroup, then the host
+	require.Equal(t, 4, metrics.ResourceMetrics().Len())
+	for i, cgroup := range []string{"/system.slice/nginx.service", "/system.slice/sshd.service", "/user.slice"} {
+		rm := metrics.ResourceMetrics().At(i)
+		path, ok := rm.Resource().Attributes().Get("cgroup.path")
+		require.True(t, ok)
+		assert.Equal(t, cgroup, path.Str())
+	}
+
+	assert.Equal(t, map[string]float64{
+		"cpu/some":    0.1,
+		"cpu/full":    0,
+		"memory/some": 0.2,
+		"memory/full": 0.15,
+		"io/some":     0.3,
+		"io/full":     0.25,
+	}, dataPoints(t, metrics.ResourceMetrics().At(0), "system.pressure.stall.time"))
+
+	// Only the pressure files that exist are reported
+	assert.Equal(t, map[string]float64{"cpu/some": 0}, dataPoints(t, metrics.ResourceMetrics().At(2), "system.pressure.stall.time"))
+
+	host := metrics.ResourceMetrics().At(3)
+	assert.Equal(t, 0, host.Resource().Attributes().Len())
+	assert.Len(t, dataPoints(t, host, "system.pressure.stall.time"), 6)
+}
+
+func TestScrapePressureUnavailable(t *testing.T) {
+	ctx := context.WithValue(t.Context(), common.EnvKey, common.EnvMap{
+		common.HostProcEnvKey: t.TempDir(),
+	})
+	s := newTestScraper(t, ctx)
+
+	_, err := s.scrape(ctx)
+	require.ErrorContains(t, err, "failed to read the cpu pressure")
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 3*metricsLen, partialErr.Failed)
+}
+
+func TestParsePressure(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		content     string
+		expected    *pressure
+		expectedErr string
+	}{
+		{
+			desc:    "without full line",
+			content: "some avg10=0.01 avg60=0.02 avg300=0.03 total=42\n",
+			expected: &pressure{
+				some: &stall{avg10: 0.01, avg60: 0.02, avg300: 0.03, total: 42},
+			},
+		},
+		{
+			desc:    "some and full lines",
+			content: "some avg10=1.00 avg60=2.00 avg300=3.00 total=4\nfull avg10=5.00 avg60=6.00 avg300=7.00 total=8\n",
+			expected: &pressure{
+				some: &stall{avg10: 1, avg60: 2, avg300: 3, total: 4},
+				full: &stall{avg10: 5, avg60: 6, avg300: 7, total: 8},
+			},
+		},
+		{
+			desc:        "missing some line",
+			content:     "full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			expectedErr: `missing "some" pressure line`,
+		},
+		{
+			desc:        "invalid value",
+			content:     "some avg10=abc avg60=0.00 avg300=0.00 total=0\n",
+			expectedErr: `invalid pressure field "avg10=abc"`,
+		},
+		{
+			desc:        "unexpected line",
+			content:     "most avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			expectedErr: `unexpected pressure line`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := parsePressure(strings.NewReader(tc.content))
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p)
+		})
+	}
+}
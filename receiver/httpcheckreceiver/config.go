@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
@@ -22,11 +23,15 @@ var (
 	errMissingEndpoint = errors.New("at least one of 'endpoint' or 'endpoints' must be specified")
 )
 
+// variablePattern matches the {{name}} references to extracted values in transaction steps
+var variablePattern = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
 // Config defines the configuration for the various elements of the receiver agent.
 type Config struct {
 	ControllerConfig     scraperhelper.ControllerConfig `mapstructure:",squash"`
 	MetricsBuilderConfig metadata.MetricsBuilderConfig  `mapstructure:",squash"`
 	Targets              []*targetConfig                `mapstructure:"targets"`
+	Transactions         []*transactionConfig           `mapstructure:"transactions"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
@@ -60,6 +65,37 @@ type targetConfig struct {
 	Validations     []validationConfig      `mapstructure:"validations"`       // Response validation rules
 }
 
+// transactionConfig defines a synthetic transaction, an ordered list of steps sharing an HTTP client.
+type transactionConfig struct {
+	Name string `mapstructure:"name"`
+	// ClientConfig configures the client used by all the steps, its endpoint is the base URL of relative step endpoints.
+	ClientConfig confighttp.ClientConfig `mapstructure:",squash"`
+	Steps        []*stepConfig           `mapstructure:"steps"`
+}
+
+// stepConfig defines a request of a transaction. The endpoint, header values and body may
+// reference values extracted by earlier steps as {{name}}.
+type stepConfig struct {
+	Name            string             `mapstructure:"name"`
+	Endpoint        string             `mapstructure:"endpoint"`
+	Method          string             `mapstructure:"method"`
+	Headers         map[string]string  `mapstructure:"headers"`
+	Body            string             `mapstructure:"body"`
+	AutoContentType bool               `mapstructure:"auto_content_type"`
+	StatusCode      int                `mapstructure:"status_code"` // Expected status code, by default any status below 400
+	Validations     []validationConfig `mapstructure:"validations"` // Assertions on the response body
+	Extract         []extractConfig    `mapstructure:"extract"`     // Values extracted from the response for the next steps
+}
+
+// extractConfig defines a value extracted from a step response, from exactly one of its sources.
+type extractConfig struct {
+	Name     string `mapstructure:"name"`
+	JSONPath string `mapstructure:"json_path"`
+	// Regex is matched against the response body, the value is its first capture group or the whole match.
+	Regex  string `mapstructure:"regex"`
+	Header string `mapstructure:"header"`
+}
+
 // Unmarshal seeds the embedded ClientConfig with the confighttp defaults before
 // decoding the user-provided configuration. Each target is created by decoding a
 // list element, so without this the embedded ClientConfig would be a zero value.
@@ -101,13 +137,115 @@ func (cfg *targetConfig) Validate() error {
 	return err
 }
 
-// Validate validates the top-level Config by checking each targetConfig.
+// Unmarshal seeds the embedded ClientConfig with the confighttp defaults, see targetConfig.Unmarshal.
+func (cfg *transactionConfig) Unmarshal(conf *confmap.Conf) error {
+	if conf == nil {
+		return nil
+	}
+	cfg.ClientConfig = confighttp.NewDefaultClientConfig()
+	// TODO: See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/49316.
+	cfg.ClientConfig.MaxIdleConns = 0
+	cfg.ClientConfig.IdleConnTimeout = 0
+	cfg.ClientConfig.ForceAttemptHTTP2 = false
+	return conf.Unmarshal(cfg)
+}
+
+// Validate validates a transactionConfig and its steps.
+func (cfg *transactionConfig) Validate() error {
+	var err error
+
+	if cfg.Name == "" {
+		err = multierr.Append(err, errors.New("transaction name must be specified"))
+	}
+	if len(cfg.Steps) == 0 {
+		err = multierr.Append(err, fmt.Errorf("transaction %q: no steps configured", cfg.Name))
+	}
+	if cfg.ClientConfig.Endpoint != "" {
+		if _, parseErr := url.ParseRequestURI(cfg.ClientConfig.Endpoint); parseErr != nil {
+			err = multierr.Append(err, fmt.Errorf("transaction %q: %s: %w", cfg.Name, errInvalidEndpoint.Error(), parseErr))
+		}
+	}
+
+	stepNames := map[string]bool{}
+	// Values extracted by the steps validated so far
+	extracted := map[string]bool{}
+	for i, step := range cfg.Steps {
+		if step.Name == "" {
+			err = multierr.Append(err, fmt.Errorf("transaction %q: step %d: name must be specified", cfg.Name, i))
+			continue
+		}
+		if stepNames[step.Name] {
+			err = multierr.Append(err, fmt.Errorf("transaction %q: step %q is defined more than once", cfg.Name, step.Name))
+		}
+		stepNames[step.Name] = true
+		for _, stepErr := range multierr.Errors(step.validate(cfg.ClientConfig.Endpoint, extracted)) {
+			err = multierr.Append(err, fmt.Errorf("transaction %q: step %q: %w", cfg.Name, step.Name, stepErr))
+		}
+		for _, extract := range step.Extract {
+			extracted[extract.Name] = true
+		}
+	}
+
+	return err
+}
+
+// validate validates a step given the base endpoint of its transaction and the values extracted by earlier steps.
+func (cfg *stepConfig) validate(baseEndpoint string, extracted map[string]bool) error {
+	var err error
+
+	if cfg.Endpoint == "" && baseEndpoint == "" {
+		err = multierr.Append(err, errors.New("endpoint must be specified when the transaction has no endpoint"))
+	}
+
+	references := []string{cfg.Endpoint, cfg.Body}
+	for _, value := range cfg.Headers {
+		references = append(references, value)
+	}
+	for _, reference := range references {
+		for _, match := range variablePattern.FindAllStringSubmatch(reference, -1) {
+			if !extracted[match[1]] {
+				err = multierr.Append(err, fmt.Errorf("%q is not extracted by an earlier step", match[1]))
+			}
+		}
+	}
+
+	names := map[string]bool{}
+	for _, extract := range cfg.Extract {
+		if extract.Name == "" {
+			err = multierr.Append(err, errors.New("extracted value name must be specified"))
+			continue
+		}
+		if names[extract.Name] {
+			err = multierr.Append(err, fmt.Errorf("value %q is extracted more than once", extract.Name))
+		}
+		names[extract.Name] = true
+
+		sources := 0
+		for _, source := range []string{extract.JSONPath, extract.Regex, extract.Header} {
+			if source != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			err = multierr.Append(err, fmt.Errorf("value %q must be extracted by exactly one of json_path, regex or header", extract.Name))
+		}
+		if extract.Regex != "" {
+			if _, regexErr := regexp.Compile(extract.Regex); regexErr != nil {
+				err = multierr.Append(err, fmt.Errorf("value %q: invalid regex: %w", extract.Name, regexErr))
+			}
+		}
+	}
+
+	return err
+}
+
+// Validate validates the top-level Config by checking each targetConfig and transactionConfig.
 func (cfg *Config) Validate() error {
 	var err error
 
-	// Ensure at least one target is configured.
-	if len(cfg.Targets) == 0 {
-		err = multierr.Append(err, errors.New("no targets configured"))
+	// Ensure at least one target or transaction is configured.
+	if len(cfg.Targets) == 0 && len(cfg.Transactions) == 0 {
+		err = multierr.Append(err, errors.New("no targets or transactions configured"))
 	}
 
 	// Validate each targetConfig.
@@ -115,5 +253,14 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, target.Validate())
 	}
 
+	transactionNames := map[string]bool{}
+	for _, transaction := range cfg.Transactions {
+		if transaction.Name != "" && transactionNames[transaction.Name] {
+			err = multierr.Append(err, fmt.Errorf("transaction %q is defined more than once", transaction.Name))
+		}
+		transactionNames[transaction.Name] = true
+		err = multierr.Append(err, transaction.Validate())
+	}
+
 	return err
 }
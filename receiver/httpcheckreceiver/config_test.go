@@ -4,6 +4,7 @@
 package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -110,6 +111,13 @@ func TestValidate(t *testing.T) {
 	clientConfigAutoContentTypeDefault.ForceAttemptHTTP2 = false
 	clientConfigAutoContentTypeDefault.Endpoint = "https://opentelemetry.io"
 
+	clientConfigTransaction := confighttp.NewDefaultClientConfig()
+	// TODO: See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/49316.
+	clientConfigTransaction.MaxIdleConns = 0
+	clientConfigTransaction.IdleConnTimeout = 0
+	clientConfigTransaction.ForceAttemptHTTP2 = false
+	clientConfigTransaction.Endpoint = "https://shop.example.com"
+
 	testCases := []struct {
 		desc        string
 		cfg         *Config
@@ -317,6 +325,88 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			desc: "valid transaction",
+			cfg: &Config{
+				Transactions: []*transactionConfig{
+					{
+						Name:         "checkout",
+						ClientConfig: clientConfigTransaction,
+						Steps: []*stepConfig{
+							{
+								Name:     "login",
+								Endpoint: "/login",
+								Method:   "POST",
+								Extract:  []extractConfig{{Name: "token", JSONPath: "token"}},
+							},
+							{
+								Name:     "search",
+								Endpoint: "https://search.example.com/?q=socks",
+								Headers:  map[string]string{"Authorization": "Bearer {{token}}"},
+								Extract:  []extractConfig{{Name: "item", Regex: `items/(\d+)`}},
+							},
+							{
+								Name:     "add to cart",
+								Endpoint: "/cart/{{ item }}",
+								Body:     `{"token":"{{token}}"}`,
+							},
+						},
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "no targets or transactions",
+			cfg: &Config{
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: errors.New("no targets or transactions configured"),
+		},
+		{
+			desc: "invalid transactions",
+			cfg: &Config{
+				Transactions: []*transactionConfig{
+					{
+						Name: "checkout",
+						Steps: []*stepConfig{
+							{
+								Name: "login",
+								Extract: []extractConfig{
+									{Name: "token", JSONPath: "token", Header: "X-Token"},
+									{Name: "token", Regex: "("},
+									{JSONPath: "id"},
+								},
+							},
+							{
+								Name:     "login",
+								Endpoint: "https://shop.example.com/{{item}}",
+							},
+							{
+								Endpoint: "https://shop.example.com",
+							},
+						},
+					},
+					{
+						Name: "checkout",
+					},
+				},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`transaction "checkout": step "login": endpoint must be specified when the transaction has no endpoint`),
+				errors.New(`transaction "checkout": step "login": value "token" must be extracted by exactly one of json_path, regex or header`),
+				errors.New(`transaction "checkout": step "login": value "token" is extracted more than once`),
+				errors.New("transaction \"checkout\": step \"login\": value \"token\": invalid regex: error parsing regexp: missing closing ): `(`"),
+				errors.New(`transaction "checkout": step "login": extracted value name must be specified`),
+				errors.New(`transaction "checkout": step "login" is defined more than once`),
+				errors.New(`transaction "checkout": step "login": "item" is not extracted by an earlier step`),
+				errors.New(`transaction "checkout": step 2: name must be specified`),
+				errors.New(`transaction "checkout" is defined more than once`),
+				errors.New(`transaction "checkout": no steps configured`),
+			),
+		},
 	}
 
 	for _, tc := range testCases {
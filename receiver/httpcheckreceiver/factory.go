@@ -12,9 +12,9 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/xreceiver"
-	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
@@ -26,6 +26,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		xreceiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		xreceiver.WithTraces(createTracesReceiver, metadata.TracesStability),
 		xreceiver.WithDeprecatedTypeAlias(metadata.DeprecatedType),
 	)
 }
@@ -47,11 +48,25 @@ func createMetricsReceiver(_ context.Context, params receiver.Settings, rConf co
 		return nil, errConfigNotHTTPCheck
 	}
 
-	httpcheckScraper := newScraper(cfg, params)
-	s, err := scraper.NewMetrics(httpcheckScraper.scrape, scraper.WithStart(httpcheckScraper.start))
-	if err != nil {
-		return nil, err
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newHTTPCheckReceiver(cfg, params)
+	})
+	r.Unwrap().(*httpcheckReceiver).nextMetrics = consumer
+	return r, nil
+}
+
+func createTracesReceiver(_ context.Context, params receiver.Settings, rConf component.Config, consumer consumer.Traces) (receiver.Traces, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotHTTPCheck
 	}
 
-	return scraperhelper.NewMetricsController(&cfg.ControllerConfig, params, consumer, scraperhelper.AddMetricsScraper(metadata.Type, s))
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newHTTPCheckReceiver(cfg, params)
+	})
+	r.Unwrap().(*httpcheckReceiver).scraper.nextTraces = consumer
+	return r, nil
 }
+
+// receivers holds the receivers shared by the metrics and traces pipelines
+var receivers = sharedcomponent.NewSharedComponents()
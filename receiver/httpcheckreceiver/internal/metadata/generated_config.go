@@ -496,6 +496,151 @@ func (ms *HttpcheckTLSHandshakeDurationMetricConfig) Validate() error {
 	return nil
 }
 
+// HttpcheckTransactionDurationMetricAttributeKey specifies the key of an attribute for the httpcheck.transaction.duration metric.
+type HttpcheckTransactionDurationMetricAttributeKey string
+
+const (
+	HttpcheckTransactionDurationMetricAttributeKeyTransactionName HttpcheckTransactionDurationMetricAttributeKey = "transaction.name"
+)
+
+// HttpcheckTransactionDurationMetricConfig provides config for the httpcheck.transaction.duration metric.
+type HttpcheckTransactionDurationMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+
+	AggregationStrategy string                                           `mapstructure:"aggregation_strategy"`
+	EnabledAttributes   []HttpcheckTransactionDurationMetricAttributeKey `mapstructure:"attributes"`
+}
+
+func (ms *HttpcheckTransactionDurationMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+func (ms *HttpcheckTransactionDurationMetricConfig) Validate() error {
+	for _, val := range ms.EnabledAttributes {
+		switch val {
+		case HttpcheckTransactionDurationMetricAttributeKeyTransactionName:
+		default:
+			return fmt.Errorf("metric httpcheck.transaction.duration doesn't have an attribute %v, valid attributes: [transaction.name]", val)
+		}
+	}
+
+	switch ms.AggregationStrategy {
+	case AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax:
+	default:
+		return fmt.Errorf("invalid aggregation strategy %q, valid strategies: [%s, %s, %s, %s]", ms.AggregationStrategy, AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax)
+	}
+
+	return nil
+}
+
+// HttpcheckTransactionStatusMetricAttributeKey specifies the key of an attribute for the httpcheck.transaction.status metric.
+type HttpcheckTransactionStatusMetricAttributeKey string
+
+const (
+	HttpcheckTransactionStatusMetricAttributeKeyTransactionName HttpcheckTransactionStatusMetricAttributeKey = "transaction.name"
+)
+
+// HttpcheckTransactionStatusMetricConfig provides config for the httpcheck.transaction.status metric.
+type HttpcheckTransactionStatusMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+
+	AggregationStrategy string                                         `mapstructure:"aggregation_strategy"`
+	EnabledAttributes   []HttpcheckTransactionStatusMetricAttributeKey `mapstructure:"attributes"`
+}
+
+func (ms *HttpcheckTransactionStatusMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+func (ms *HttpcheckTransactionStatusMetricConfig) Validate() error {
+	for _, val := range ms.EnabledAttributes {
+		switch val {
+		case HttpcheckTransactionStatusMetricAttributeKeyTransactionName:
+		default:
+			return fmt.Errorf("metric httpcheck.transaction.status doesn't have an attribute %v, valid attributes: [transaction.name]", val)
+		}
+	}
+
+	switch ms.AggregationStrategy {
+	case AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax:
+	default:
+		return fmt.Errorf("invalid aggregation strategy %q, valid strategies: [%s, %s, %s, %s]", ms.AggregationStrategy, AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax)
+	}
+
+	return nil
+}
+
+// HttpcheckTransactionStepDurationMetricAttributeKey specifies the key of an attribute for the httpcheck.transaction.step.duration metric.
+type HttpcheckTransactionStepDurationMetricAttributeKey string
+
+const (
+	HttpcheckTransactionStepDurationMetricAttributeKeyTransactionName     HttpcheckTransactionStepDurationMetricAttributeKey = "transaction.name"
+	HttpcheckTransactionStepDurationMetricAttributeKeyTransactionStepName HttpcheckTransactionStepDurationMetricAttributeKey = "transaction.step.name"
+)
+
+// HttpcheckTransactionStepDurationMetricConfig provides config for the httpcheck.transaction.step.duration metric.
+type HttpcheckTransactionStepDurationMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+
+	AggregationStrategy string                                               `mapstructure:"aggregation_strategy"`
+	EnabledAttributes   []HttpcheckTransactionStepDurationMetricAttributeKey `mapstructure:"attributes"`
+}
+
+func (ms *HttpcheckTransactionStepDurationMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+func (ms *HttpcheckTransactionStepDurationMetricConfig) Validate() error {
+	for _, val := range ms.EnabledAttributes {
+		switch val {
+		case HttpcheckTransactionStepDurationMetricAttributeKeyTransactionName, HttpcheckTransactionStepDurationMetricAttributeKeyTransactionStepName:
+		default:
+			return fmt.Errorf("metric httpcheck.transaction.step.duration doesn't have an attribute %v, valid attributes: [transaction.name, transaction.step.name]", val)
+		}
+	}
+
+	switch ms.AggregationStrategy {
+	case AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax:
+	default:
+		return fmt.Errorf("invalid aggregation strategy %q, valid strategies: [%s, %s, %s, %s]", ms.AggregationStrategy, AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax)
+	}
+
+	return nil
+}
+
 // HttpcheckValidationFailedMetricAttributeKey specifies the key of an attribute for the httpcheck.validation.failed metric.
 type HttpcheckValidationFailedMetricAttributeKey string
 
@@ -606,6 +751,9 @@ type MetricsConfig struct {
 	HttpcheckStatus                   HttpcheckStatusMetricConfig                   `mapstructure:"httpcheck.status"`
 	HttpcheckTLSCertRemaining         HttpcheckTLSCertRemainingMetricConfig         `mapstructure:"httpcheck.tls.cert_remaining"`
 	HttpcheckTLSHandshakeDuration     HttpcheckTLSHandshakeDurationMetricConfig     `mapstructure:"httpcheck.tls.handshake.duration"`
+	HttpcheckTransactionDuration      HttpcheckTransactionDurationMetricConfig      `mapstructure:"httpcheck.transaction.duration"`
+	HttpcheckTransactionStatus        HttpcheckTransactionStatusMetricConfig        `mapstructure:"httpcheck.transaction.status"`
+	HttpcheckTransactionStepDuration  HttpcheckTransactionStepDurationMetricConfig  `mapstructure:"httpcheck.transaction.step.duration"`
 	HttpcheckValidationFailed         HttpcheckValidationFailedMetricConfig         `mapstructure:"httpcheck.validation.failed"`
 	HttpcheckValidationPassed         HttpcheckValidationPassedMetricConfig         `mapstructure:"httpcheck.validation.passed"`
 }
@@ -662,6 +810,21 @@ func DefaultMetricsConfig() MetricsConfig {
 			AggregationStrategy: AggregationStrategyAvg,
 			EnabledAttributes:   []HttpcheckTLSHandshakeDurationMetricAttributeKey{HttpcheckTLSHandshakeDurationMetricAttributeKeyHTTPURL},
 		},
+		HttpcheckTransactionDuration: HttpcheckTransactionDurationMetricConfig{
+			Enabled:             true,
+			AggregationStrategy: AggregationStrategyAvg,
+			EnabledAttributes:   []HttpcheckTransactionDurationMetricAttributeKey{HttpcheckTransactionDurationMetricAttributeKeyTransactionName},
+		},
+		HttpcheckTransactionStatus: HttpcheckTransactionStatusMetricConfig{
+			Enabled:             true,
+			AggregationStrategy: AggregationStrategySum,
+			EnabledAttributes:   []HttpcheckTransactionStatusMetricAttributeKey{HttpcheckTransactionStatusMetricAttributeKeyTransactionName},
+		},
+		HttpcheckTransactionStepDuration: HttpcheckTransactionStepDurationMetricConfig{
+			Enabled:             false,
+			AggregationStrategy: AggregationStrategyAvg,
+			EnabledAttributes:   []HttpcheckTransactionStepDurationMetricAttributeKey{HttpcheckTransactionStepDurationMetricAttributeKeyTransactionName, HttpcheckTransactionStepDurationMetricAttributeKeyTransactionStepName},
+		},
 		HttpcheckValidationFailed: HttpcheckValidationFailedMetricConfig{
 			Enabled:             false,
 			AggregationStrategy: AggregationStrategySum,
@@ -76,6 +76,21 @@ func TestMetricsBuilderConfig(t *testing.T) {
 						AggregationStrategy: AggregationStrategyAvg,
 						EnabledAttributes:   []HttpcheckTLSHandshakeDurationMetricAttributeKey{HttpcheckTLSHandshakeDurationMetricAttributeKeyHTTPURL},
 					},
+					HttpcheckTransactionDuration: HttpcheckTransactionDurationMetricConfig{
+						Enabled:             true,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []HttpcheckTransactionDurationMetricAttributeKey{HttpcheckTransactionDurationMetricAttributeKeyTransactionName},
+					},
+					HttpcheckTransactionStatus: HttpcheckTransactionStatusMetricConfig{
+						Enabled:             true,
+						AggregationStrategy: AggregationStrategySum,
+						EnabledAttributes:   []HttpcheckTransactionStatusMetricAttributeKey{HttpcheckTransactionStatusMetricAttributeKeyTransactionName},
+					},
+					HttpcheckTransactionStepDuration: HttpcheckTransactionStepDurationMetricConfig{
+						Enabled:             true,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []HttpcheckTransactionStepDurationMetricAttributeKey{HttpcheckTransactionStepDurationMetricAttributeKeyTransactionName, HttpcheckTransactionStepDurationMetricAttributeKeyTransactionStepName},
+					},
 					HttpcheckValidationFailed: HttpcheckValidationFailedMetricConfig{
 						Enabled:             true,
 						AggregationStrategy: AggregationStrategySum,
@@ -143,6 +158,21 @@ func TestMetricsBuilderConfig(t *testing.T) {
 						AggregationStrategy: AggregationStrategyAvg,
 						EnabledAttributes:   []HttpcheckTLSHandshakeDurationMetricAttributeKey{HttpcheckTLSHandshakeDurationMetricAttributeKeyHTTPURL},
 					},
+					HttpcheckTransactionDuration: HttpcheckTransactionDurationMetricConfig{
+						Enabled:             false,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []HttpcheckTransactionDurationMetricAttributeKey{HttpcheckTransactionDurationMetricAttributeKeyTransactionName},
+					},
+					HttpcheckTransactionStatus: HttpcheckTransactionStatusMetricConfig{
+						Enabled:             false,
+						AggregationStrategy: AggregationStrategySum,
+						EnabledAttributes:   []HttpcheckTransactionStatusMetricAttributeKey{HttpcheckTransactionStatusMetricAttributeKeyTransactionName},
+					},
+					HttpcheckTransactionStepDuration: HttpcheckTransactionStepDurationMetricConfig{
+						Enabled:             false,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []HttpcheckTransactionStepDurationMetricAttributeKey{HttpcheckTransactionStepDurationMetricAttributeKeyTransactionName, HttpcheckTransactionStepDurationMetricAttributeKeyTransactionStepName},
+					},
 					HttpcheckValidationFailed: HttpcheckValidationFailedMetricConfig{
 						Enabled:             false,
 						AggregationStrategy: AggregationStrategySum,
@@ -160,7 +190,7 @@ func TestMetricsBuilderConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := loadMetricsBuilderConfig(t, tt.name)
-			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(HttpcheckClientConnectionDurationMetricConfig{}, HttpcheckClientRequestDurationMetricConfig{}, HttpcheckDNSLookupDurationMetricConfig{}, HttpcheckDurationMetricConfig{}, HttpcheckErrorMetricConfig{}, HttpcheckResponseDurationMetricConfig{}, HttpcheckResponseSizeMetricConfig{}, HttpcheckStatusMetricConfig{}, HttpcheckTLSCertRemainingMetricConfig{}, HttpcheckTLSHandshakeDurationMetricConfig{}, HttpcheckValidationFailedMetricConfig{}, HttpcheckValidationPassedMetricConfig{}))
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(HttpcheckClientConnectionDurationMetricConfig{}, HttpcheckClientRequestDurationMetricConfig{}, HttpcheckDNSLookupDurationMetricConfig{}, HttpcheckDurationMetricConfig{}, HttpcheckErrorMetricConfig{}, HttpcheckResponseDurationMetricConfig{}, HttpcheckResponseSizeMetricConfig{}, HttpcheckStatusMetricConfig{}, HttpcheckTLSCertRemainingMetricConfig{}, HttpcheckTLSHandshakeDurationMetricConfig{}, HttpcheckTransactionDurationMetricConfig{}, HttpcheckTransactionStatusMetricConfig{}, HttpcheckTransactionStepDurationMetricConfig{}, HttpcheckValidationFailedMetricConfig{}, HttpcheckValidationPassedMetricConfig{}))
 			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
 		})
 	}
@@ -285,6 +315,42 @@ func TestHttpcheckTLSHandshakeDurationMetricsConfig_Validate(t *testing.T) {
 	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
 }
 
+func TestHttpcheckTransactionDurationMetricsConfig_Validate(t *testing.T) {
+	cfg := DefaultMetricsConfig().HttpcheckTransactionDuration
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnabledAttributes = []HttpcheckTransactionDurationMetricAttributeKey{"invalid"}
+	require.ErrorContains(t, cfg.Validate(), "metric httpcheck.transaction.duration doesn't have an attribute invalid, valid attributes: [transaction.name]")
+
+	cfg = DefaultMetricsConfig().HttpcheckTransactionDuration
+	cfg.AggregationStrategy = "invalid"
+	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
+}
+
+func TestHttpcheckTransactionStatusMetricsConfig_Validate(t *testing.T) {
+	cfg := DefaultMetricsConfig().HttpcheckTransactionStatus
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnabledAttributes = []HttpcheckTransactionStatusMetricAttributeKey{"invalid"}
+	require.ErrorContains(t, cfg.Validate(), "metric httpcheck.transaction.status doesn't have an attribute invalid, valid attributes: [transaction.name]")
+
+	cfg = DefaultMetricsConfig().HttpcheckTransactionStatus
+	cfg.AggregationStrategy = "invalid"
+	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
+}
+
+func TestHttpcheckTransactionStepDurationMetricsConfig_Validate(t *testing.T) {
+	cfg := DefaultMetricsConfig().HttpcheckTransactionStepDuration
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnabledAttributes = []HttpcheckTransactionStepDurationMetricAttributeKey{"invalid"}
+	require.ErrorContains(t, cfg.Validate(), "metric httpcheck.transaction.step.duration doesn't have an attribute invalid, valid attributes: [transaction.name, transaction.step.name]")
+
+	cfg = DefaultMetricsConfig().HttpcheckTransactionStepDuration
+	cfg.AggregationStrategy = "invalid"
+	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
+}
+
 func TestHttpcheckValidationFailedMetricsConfig_Validate(t *testing.T) {
 	cfg := DefaultMetricsConfig().HttpcheckValidationFailed
 	require.NoError(t, cfg.Validate())
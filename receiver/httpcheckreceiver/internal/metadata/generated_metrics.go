@@ -3,13 +3,12 @@
 package metadata
 
 import (
-	"slices"
-	"time"
-
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"slices"
+	"time"
 )
 
 const (
@@ -60,6 +59,18 @@ var MetricsInfo = metricsInfo{
 		Name:       "httpcheck.tls.handshake.duration",
 		Attributes: []string{"http.url"},
 	},
+	HttpcheckTransactionDuration: metricInfo{
+		Name:       "httpcheck.transaction.duration",
+		Attributes: []string{"transaction.name"},
+	},
+	HttpcheckTransactionStatus: metricInfo{
+		Name:       "httpcheck.transaction.status",
+		Attributes: []string{"transaction.name"},
+	},
+	HttpcheckTransactionStepDuration: metricInfo{
+		Name:       "httpcheck.transaction.step.duration",
+		Attributes: []string{"transaction.name", "transaction.step.name"},
+	},
 	HttpcheckValidationFailed: metricInfo{
 		Name:       "httpcheck.validation.failed",
 		Attributes: []string{"http.url", "validation.type"},
@@ -81,6 +92,9 @@ type metricsInfo struct {
 	HttpcheckStatus                   metricInfo
 	HttpcheckTLSCertRemaining         metricInfo
 	HttpcheckTLSHandshakeDuration     metricInfo
+	HttpcheckTransactionDuration      metricInfo
+	HttpcheckTransactionStatus        metricInfo
+	HttpcheckTransactionStepDuration  metricInfo
 	HttpcheckValidationFailed         metricInfo
 	HttpcheckValidationPassed         metricInfo
 }
@@ -1008,6 +1022,278 @@ func newMetricHttpcheckTLSHandshakeDuration(cfg HttpcheckTLSHandshakeDurationMet
 	return m
 }
 
+type metricHttpcheckTransactionDuration struct {
+	data          pmetric.Metric                           // data buffer for generated metric.
+	config        HttpcheckTransactionDurationMetricConfig // metric config provided by user.
+	capacity      int                                      // max observed number of data points added to the metric.
+	aggDataPoints []int64                                  // slice containing number of aggregated datapoints at each index
+}
+
+// init fills httpcheck.transaction.duration metric with initial data.
+func (m *metricHttpcheckTransactionDuration) init() {
+	m.data.SetName("httpcheck.transaction.duration")
+	m.data.SetDescription("Measures the duration of the synthetic transaction, up to its last run step.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+	m.aggDataPoints = m.aggDataPoints[:0]
+}
+
+func (m *metricHttpcheckTransactionDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, transactionNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	if slices.Contains(m.config.EnabledAttributes, HttpcheckTransactionDurationMetricAttributeKeyTransactionName) {
+		dp.Attributes().PutStr("transaction.name", transactionNameAttributeValue)
+	}
+
+	var s string
+	dps := m.data.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dpi := dps.At(i)
+		if dp.Attributes().Equal(dpi.Attributes()) && dp.StartTimestamp() == dpi.StartTimestamp() && dp.Timestamp() == dpi.Timestamp() {
+			switch s = m.config.AggregationStrategy; s {
+			case AggregationStrategySum, AggregationStrategyAvg:
+				dpi.SetIntValue(dpi.IntValue() + val)
+				m.aggDataPoints[i] += 1
+				return
+			case AggregationStrategyMin:
+				if dpi.IntValue() > val {
+					dpi.SetIntValue(val)
+				}
+				return
+			case AggregationStrategyMax:
+				if dpi.IntValue() < val {
+					dpi.SetIntValue(val)
+				}
+				return
+			}
+		}
+	}
+
+	dp.SetIntValue(val)
+	m.aggDataPoints = append(m.aggDataPoints, 1)
+	dp.MoveTo(dps.AppendEmpty())
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTransactionDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTransactionDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		if m.config.AggregationStrategy == AggregationStrategyAvg {
+			for i, aggCount := range m.aggDataPoints {
+				m.data.Gauge().DataPoints().At(i).SetIntValue(m.data.Gauge().DataPoints().At(i).IntValue() / aggCount)
+			}
+		}
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTransactionDuration(cfg HttpcheckTransactionDurationMetricConfig) metricHttpcheckTransactionDuration {
+	m := metricHttpcheckTransactionDuration{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckTransactionStatus struct {
+	data          pmetric.Metric                         // data buffer for generated metric.
+	config        HttpcheckTransactionStatusMetricConfig // metric config provided by user.
+	capacity      int                                    // max observed number of data points added to the metric.
+	aggDataPoints []int64                                // slice containing number of aggregated datapoints at each index
+}
+
+// init fills httpcheck.transaction.status metric with initial data.
+func (m *metricHttpcheckTransactionStatus) init() {
+	m.data.SetName("httpcheck.transaction.status")
+	m.data.SetDescription("1 if all the steps of the synthetic transaction succeeded, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+	m.aggDataPoints = m.aggDataPoints[:0]
+}
+
+func (m *metricHttpcheckTransactionStatus) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, transactionNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	if slices.Contains(m.config.EnabledAttributes, HttpcheckTransactionStatusMetricAttributeKeyTransactionName) {
+		dp.Attributes().PutStr("transaction.name", transactionNameAttributeValue)
+	}
+
+	var s string
+	dps := m.data.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dpi := dps.At(i)
+		if dp.Attributes().Equal(dpi.Attributes()) && dp.StartTimestamp() == dpi.StartTimestamp() && dp.Timestamp() == dpi.Timestamp() {
+			switch s = m.config.AggregationStrategy; s {
+			case AggregationStrategySum, AggregationStrategyAvg:
+				dpi.SetIntValue(dpi.IntValue() + val)
+				m.aggDataPoints[i] += 1
+				return
+			case AggregationStrategyMin:
+				if dpi.IntValue() > val {
+					dpi.SetIntValue(val)
+				}
+				return
+			case AggregationStrategyMax:
+				if dpi.IntValue() < val {
+					dpi.SetIntValue(val)
+				}
+				return
+			}
+		}
+	}
+
+	dp.SetIntValue(val)
+	m.aggDataPoints = append(m.aggDataPoints, 1)
+	dp.MoveTo(dps.AppendEmpty())
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTransactionStatus) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTransactionStatus) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		if m.config.AggregationStrategy == AggregationStrategyAvg {
+			for i, aggCount := range m.aggDataPoints {
+				m.data.Sum().DataPoints().At(i).SetIntValue(m.data.Sum().DataPoints().At(i).IntValue() / aggCount)
+			}
+		}
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTransactionStatus(cfg HttpcheckTransactionStatusMetricConfig) metricHttpcheckTransactionStatus {
+	m := metricHttpcheckTransactionStatus{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckTransactionStepDuration struct {
+	data          pmetric.Metric                               // data buffer for generated metric.
+	config        HttpcheckTransactionStepDurationMetricConfig // metric config provided by user.
+	capacity      int                                          // max observed number of data points added to the metric.
+	aggDataPoints []int64                                      // slice containing number of aggregated datapoints at each index
+}
+
+// init fills httpcheck.transaction.step.duration metric with initial data.
+func (m *metricHttpcheckTransactionStepDuration) init() {
+	m.data.SetName("httpcheck.transaction.step.duration")
+	m.data.SetDescription("Measures the duration of a step of the synthetic transaction.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+	m.aggDataPoints = m.aggDataPoints[:0]
+}
+
+func (m *metricHttpcheckTransactionStepDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, transactionNameAttributeValue string, transactionStepNameAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	if slices.Contains(m.config.EnabledAttributes, HttpcheckTransactionStepDurationMetricAttributeKeyTransactionName) {
+		dp.Attributes().PutStr("transaction.name", transactionNameAttributeValue)
+	}
+	if slices.Contains(m.config.EnabledAttributes, HttpcheckTransactionStepDurationMetricAttributeKeyTransactionStepName) {
+		dp.Attributes().PutStr("transaction.step.name", transactionStepNameAttributeValue)
+	}
+
+	var s string
+	dps := m.data.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dpi := dps.At(i)
+		if dp.Attributes().Equal(dpi.Attributes()) && dp.StartTimestamp() == dpi.StartTimestamp() && dp.Timestamp() == dpi.Timestamp() {
+			switch s = m.config.AggregationStrategy; s {
+			case AggregationStrategySum, AggregationStrategyAvg:
+				dpi.SetIntValue(dpi.IntValue() + val)
+				m.aggDataPoints[i] += 1
+				return
+			case AggregationStrategyMin:
+				if dpi.IntValue() > val {
+					dpi.SetIntValue(val)
+				}
+				return
+			case AggregationStrategyMax:
+				if dpi.IntValue() < val {
+					dpi.SetIntValue(val)
+				}
+				return
+			}
+		}
+	}
+
+	dp.SetIntValue(val)
+	m.aggDataPoints = append(m.aggDataPoints, 1)
+	dp.MoveTo(dps.AppendEmpty())
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTransactionStepDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTransactionStepDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		if m.config.AggregationStrategy == AggregationStrategyAvg {
+			for i, aggCount := range m.aggDataPoints {
+				m.data.Gauge().DataPoints().At(i).SetIntValue(m.data.Gauge().DataPoints().At(i).IntValue() / aggCount)
+			}
+		}
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTransactionStepDuration(cfg HttpcheckTransactionStepDurationMetricConfig) metricHttpcheckTransactionStepDuration {
+	m := metricHttpcheckTransactionStepDuration{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckValidationFailed struct {
 	data          pmetric.Metric                        // data buffer for generated metric.
 	config        HttpcheckValidationFailedMetricConfig // metric config provided by user.
@@ -1214,6 +1500,9 @@ type MetricsBuilder struct {
 	metricHttpcheckStatus                   metricHttpcheckStatus
 	metricHttpcheckTLSCertRemaining         metricHttpcheckTLSCertRemaining
 	metricHttpcheckTLSHandshakeDuration     metricHttpcheckTLSHandshakeDuration
+	metricHttpcheckTransactionDuration      metricHttpcheckTransactionDuration
+	metricHttpcheckTransactionStatus        metricHttpcheckTransactionStatus
+	metricHttpcheckTransactionStepDuration  metricHttpcheckTransactionStepDuration
 	metricHttpcheckValidationFailed         metricHttpcheckValidationFailed
 	metricHttpcheckValidationPassed         metricHttpcheckValidationPassed
 }
@@ -1251,6 +1540,9 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, opt
 		metricHttpcheckStatus:                   newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
 		metricHttpcheckTLSCertRemaining:         newMetricHttpcheckTLSCertRemaining(mbc.Metrics.HttpcheckTLSCertRemaining),
 		metricHttpcheckTLSHandshakeDuration:     newMetricHttpcheckTLSHandshakeDuration(mbc.Metrics.HttpcheckTLSHandshakeDuration),
+		metricHttpcheckTransactionDuration:      newMetricHttpcheckTransactionDuration(mbc.Metrics.HttpcheckTransactionDuration),
+		metricHttpcheckTransactionStatus:        newMetricHttpcheckTransactionStatus(mbc.Metrics.HttpcheckTransactionStatus),
+		metricHttpcheckTransactionStepDuration:  newMetricHttpcheckTransactionStepDuration(mbc.Metrics.HttpcheckTransactionStepDuration),
 		metricHttpcheckValidationFailed:         newMetricHttpcheckValidationFailed(mbc.Metrics.HttpcheckValidationFailed),
 		metricHttpcheckValidationPassed:         newMetricHttpcheckValidationPassed(mbc.Metrics.HttpcheckValidationPassed),
 	}
@@ -1328,6 +1620,9 @@ func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
 	mb.metricHttpcheckTLSCertRemaining.emit(ils.Metrics())
 	mb.metricHttpcheckTLSHandshakeDuration.emit(ils.Metrics())
+	mb.metricHttpcheckTransactionDuration.emit(ils.Metrics())
+	mb.metricHttpcheckTransactionStatus.emit(ils.Metrics())
+	mb.metricHttpcheckTransactionStepDuration.emit(ils.Metrics())
 	mb.metricHttpcheckValidationFailed.emit(ils.Metrics())
 	mb.metricHttpcheckValidationPassed.emit(ils.Metrics())
 
@@ -1401,6 +1696,21 @@ func (mb *MetricsBuilder) RecordHttpcheckTLSHandshakeDurationDataPoint(ts pcommo
 	mb.metricHttpcheckTLSHandshakeDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
 }
 
+// RecordHttpcheckTransactionDurationDataPoint adds a data point to httpcheck.transaction.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckTransactionDurationDataPoint(ts pcommon.Timestamp, val int64, transactionNameAttributeValue string) {
+	mb.metricHttpcheckTransactionDuration.recordDataPoint(mb.startTime, ts, val, transactionNameAttributeValue)
+}
+
+// RecordHttpcheckTransactionStatusDataPoint adds a data point to httpcheck.transaction.status metric.
+func (mb *MetricsBuilder) RecordHttpcheckTransactionStatusDataPoint(ts pcommon.Timestamp, val int64, transactionNameAttributeValue string) {
+	mb.metricHttpcheckTransactionStatus.recordDataPoint(mb.startTime, ts, val, transactionNameAttributeValue)
+}
+
+// RecordHttpcheckTransactionStepDurationDataPoint adds a data point to httpcheck.transaction.step.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckTransactionStepDurationDataPoint(ts pcommon.Timestamp, val int64, transactionNameAttributeValue string, transactionStepNameAttributeValue string) {
+	mb.metricHttpcheckTransactionStepDuration.recordDataPoint(mb.startTime, ts, val, transactionNameAttributeValue, transactionStepNameAttributeValue)
+}
+
 // RecordHttpcheckValidationFailedDataPoint adds a data point to httpcheck.validation.failed metric.
 func (mb *MetricsBuilder) RecordHttpcheckValidationFailedDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, validationTypeAttributeValue string) {
 	mb.metricHttpcheckValidationFailed.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, validationTypeAttributeValue)
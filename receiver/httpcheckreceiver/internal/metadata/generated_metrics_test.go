@@ -68,6 +68,9 @@ func TestMetricsBuilder(t *testing.T) {
 			aggMap["httpcheck.status"] = mb.metricHttpcheckStatus.config.AggregationStrategy
 			aggMap["httpcheck.tls.cert_remaining"] = mb.metricHttpcheckTLSCertRemaining.config.AggregationStrategy
 			aggMap["httpcheck.tls.handshake.duration"] = mb.metricHttpcheckTLSHandshakeDuration.config.AggregationStrategy
+			aggMap["httpcheck.transaction.duration"] = mb.metricHttpcheckTransactionDuration.config.AggregationStrategy
+			aggMap["httpcheck.transaction.status"] = mb.metricHttpcheckTransactionStatus.config.AggregationStrategy
+			aggMap["httpcheck.transaction.step.duration"] = mb.metricHttpcheckTransactionStepDuration.config.AggregationStrategy
 			aggMap["httpcheck.validation.failed"] = mb.metricHttpcheckValidationFailed.config.AggregationStrategy
 			aggMap["httpcheck.validation.passed"] = mb.metricHttpcheckValidationPassed.config.AggregationStrategy
 
@@ -138,6 +141,24 @@ func TestMetricsBuilder(t *testing.T) {
 			if tt.name == "reaggregate_set" {
 				mb.RecordHttpcheckTLSHandshakeDurationDataPoint(ts, 3, "http.url-val-2")
 			}
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckTransactionDurationDataPoint(ts, 1, "transaction.name-val")
+			if tt.name == "reaggregate_set" {
+				mb.RecordHttpcheckTransactionDurationDataPoint(ts, 3, "transaction.name-val-2")
+			}
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckTransactionStatusDataPoint(ts, 1, "transaction.name-val")
+			if tt.name == "reaggregate_set" {
+				mb.RecordHttpcheckTransactionStatusDataPoint(ts, 3, "transaction.name-val-2")
+			}
+
+			allMetricsCount++
+			mb.RecordHttpcheckTransactionStepDurationDataPoint(ts, 1, "transaction.name-val", "transaction.step.name-val")
+			if tt.name == "reaggregate_set" {
+				mb.RecordHttpcheckTransactionStepDurationDataPoint(ts, 3, "transaction.name-val-2", "transaction.step.name-val-2")
+			}
 
 			allMetricsCount++
 			mb.RecordHttpcheckValidationFailedDataPoint(ts, 1, "http.url-val", "validation.type-val")
@@ -164,6 +185,9 @@ func TestMetricsBuilder(t *testing.T) {
 				assert.Empty(t, mb.metricHttpcheckStatus.aggDataPoints)
 				assert.Empty(t, mb.metricHttpcheckTLSCertRemaining.aggDataPoints)
 				assert.Empty(t, mb.metricHttpcheckTLSHandshakeDuration.aggDataPoints)
+				assert.Empty(t, mb.metricHttpcheckTransactionDuration.aggDataPoints)
+				assert.Empty(t, mb.metricHttpcheckTransactionStatus.aggDataPoints)
+				assert.Empty(t, mb.metricHttpcheckTransactionStepDuration.aggDataPoints)
 				assert.Empty(t, mb.metricHttpcheckValidationFailed.aggDataPoints)
 				assert.Empty(t, mb.metricHttpcheckValidationPassed.aggDataPoints)
 			}
@@ -641,6 +665,135 @@ func TestMetricsBuilder(t *testing.T) {
 						_, ok := dp.Attributes().Get("http.url")
 						assert.False(t, ok)
 					}
+				case "httpcheck.transaction.duration":
+					if tt.name != "reaggregate_set" {
+						assert.False(t, validatedMetrics["httpcheck.transaction.duration"], "Found a duplicate in the metrics slice: httpcheck.transaction.duration")
+						validatedMetrics["httpcheck.transaction.duration"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "Measures the duration of the synthetic transaction, up to its last run step.", mi.Description())
+						assert.Equal(t, "ms", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						assert.Equal(t, int64(1), dp.IntValue())
+						transactionNameAttrVal, ok := dp.Attributes().Get("transaction.name")
+						assert.True(t, ok)
+						assert.Equal(t, "transaction.name-val", transactionNameAttrVal.Str())
+					} else {
+						assert.False(t, validatedMetrics["httpcheck.transaction.duration"], "Found a duplicate in the metrics slice: httpcheck.transaction.duration")
+						validatedMetrics["httpcheck.transaction.duration"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "Measures the duration of the synthetic transaction, up to its last run step.", mi.Description())
+						assert.Equal(t, "ms", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						switch aggMap["httpcheck.transaction.duration"] {
+						case "sum":
+							assert.Equal(t, int64(4), dp.IntValue())
+						case "avg":
+							assert.Equal(t, int64(2), dp.IntValue())
+						case "min":
+							assert.Equal(t, int64(1), dp.IntValue())
+						case "max":
+							assert.Equal(t, int64(3), dp.IntValue())
+						}
+						_, ok := dp.Attributes().Get("transaction.name")
+						assert.False(t, ok)
+					}
+				case "httpcheck.transaction.status":
+					if tt.name != "reaggregate_set" {
+						assert.False(t, validatedMetrics["httpcheck.transaction.status"], "Found a duplicate in the metrics slice: httpcheck.transaction.status")
+						validatedMetrics["httpcheck.transaction.status"] = true
+						assert.Equal(t, pmetric.MetricTypeSum, mi.Type())
+						assert.Equal(t, 1, mi.Sum().DataPoints().Len())
+						assert.Equal(t, "1 if all the steps of the synthetic transaction succeeded, otherwise 0.", mi.Description())
+						assert.Equal(t, "1", mi.Unit())
+						assert.False(t, mi.Sum().IsMonotonic())
+						assert.Equal(t, pmetric.AggregationTemporalityCumulative, mi.Sum().AggregationTemporality())
+						dp := mi.Sum().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						assert.Equal(t, int64(1), dp.IntValue())
+						transactionNameAttrVal, ok := dp.Attributes().Get("transaction.name")
+						assert.True(t, ok)
+						assert.Equal(t, "transaction.name-val", transactionNameAttrVal.Str())
+					} else {
+						assert.False(t, validatedMetrics["httpcheck.transaction.status"], "Found a duplicate in the metrics slice: httpcheck.transaction.status")
+						validatedMetrics["httpcheck.transaction.status"] = true
+						assert.Equal(t, pmetric.MetricTypeSum, mi.Type())
+						assert.Equal(t, 1, mi.Sum().DataPoints().Len())
+						assert.Equal(t, "1 if all the steps of the synthetic transaction succeeded, otherwise 0.", mi.Description())
+						assert.Equal(t, "1", mi.Unit())
+						assert.False(t, mi.Sum().IsMonotonic())
+						assert.Equal(t, pmetric.AggregationTemporalityCumulative, mi.Sum().AggregationTemporality())
+						dp := mi.Sum().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						switch aggMap["httpcheck.transaction.status"] {
+						case "sum":
+							assert.Equal(t, int64(4), dp.IntValue())
+						case "avg":
+							assert.Equal(t, int64(2), dp.IntValue())
+						case "min":
+							assert.Equal(t, int64(1), dp.IntValue())
+						case "max":
+							assert.Equal(t, int64(3), dp.IntValue())
+						}
+						_, ok := dp.Attributes().Get("transaction.name")
+						assert.False(t, ok)
+					}
+				case "httpcheck.transaction.step.duration":
+					if tt.name != "reaggregate_set" {
+						assert.False(t, validatedMetrics["httpcheck.transaction.step.duration"], "Found a duplicate in the metrics slice: httpcheck.transaction.step.duration")
+						validatedMetrics["httpcheck.transaction.step.duration"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "Measures the duration of a step of the synthetic transaction.", mi.Description())
+						assert.Equal(t, "ms", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						assert.Equal(t, int64(1), dp.IntValue())
+						transactionNameAttrVal, ok := dp.Attributes().Get("transaction.name")
+						assert.True(t, ok)
+						assert.Equal(t, "transaction.name-val", transactionNameAttrVal.Str())
+						transactionStepNameAttrVal, ok := dp.Attributes().Get("transaction.step.name")
+						assert.True(t, ok)
+						assert.Equal(t, "transaction.step.name-val", transactionStepNameAttrVal.Str())
+					} else {
+						assert.False(t, validatedMetrics["httpcheck.transaction.step.duration"], "Found a duplicate in the metrics slice: httpcheck.transaction.step.duration")
+						validatedMetrics["httpcheck.transaction.step.duration"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "Measures the duration of a step of the synthetic transaction.", mi.Description())
+						assert.Equal(t, "ms", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						switch aggMap["httpcheck.transaction.step.duration"] {
+						case "sum":
+							assert.Equal(t, int64(4), dp.IntValue())
+						case "avg":
+							assert.Equal(t, int64(2), dp.IntValue())
+						case "min":
+							assert.Equal(t, int64(1), dp.IntValue())
+						case "max":
+							assert.Equal(t, int64(3), dp.IntValue())
+						}
+						_, ok := dp.Attributes().Get("transaction.name")
+						assert.False(t, ok)
+						_, ok = dp.Attributes().Get("transaction.step.name")
+						assert.False(t, ok)
+					}
 				case "httpcheck.validation.failed":
 					if tt.name != "reaggregate_set" {
 						assert.False(t, validatedMetrics["httpcheck.validation.failed"], "Found a duplicate in the metrics slice: httpcheck.validation.failed")
@@ -15,5 +15,6 @@ var (
 )
 
 const (
+	TracesStability  = component.StabilityLevelDevelopment
 	MetricsStability = component.StabilityLevelAlpha
 )
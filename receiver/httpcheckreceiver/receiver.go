@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// httpcheckReceiver runs the checks of a configuration once for both the metrics and traces pipelines using it
+type httpcheckReceiver struct {
+	cfg         *Config
+	params      receiver.Settings
+	scraper     *httpcheckScraper
+	nextMetrics consumer.Metrics
+	controller  component.Component
+}
+
+func newHTTPCheckReceiver(cfg *Config, params receiver.Settings) *httpcheckReceiver {
+	return &httpcheckReceiver{
+		cfg:     cfg,
+		params:  params,
+		scraper: newScraper(cfg, params),
+	}
+}
+
+func (r *httpcheckReceiver) Start(ctx context.Context, host component.Host) error {
+	nextMetrics := r.nextMetrics
+	if nextMetrics == nil {
+		// The checks are run by the scraper controller, which requires a metrics consumer that traces only pipelines do not have.
+		var err error
+		if nextMetrics, err = consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil }); err != nil {
+			return err
+		}
+	}
+
+	s, err := scraper.NewMetrics(r.scraper.scrape, scraper.WithStart(r.scraper.start))
+	if err != nil {
+		return err
+	}
+	r.controller, err = scraperhelper.NewMetricsController(&r.cfg.ControllerConfig, r.params, nextMetrics, scraperhelper.AddMetricsScraper(metadata.Type, s))
+	if err != nil {
+		return err
+	}
+	return r.controller.Start(ctx, host)
+}
+
+func (r *httpcheckReceiver) Shutdown(ctx context.Context) error {
+	if r.controller == nil {
+		return nil
+	}
+	return r.controller.Shutdown(ctx)
+}
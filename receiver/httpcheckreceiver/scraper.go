@@ -18,8 +18,10 @@ import (
 
 	"github.com/tidwall/gjson"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -84,10 +86,13 @@ func (t *timingInfo) getDurations() (dnsNs, tcpNs, tlsNs, requestNs, responseNs
 }
 
 type httpcheckScraper struct {
-	clients  []*http.Client
-	cfg      *Config
-	settings component.TelemetrySettings
-	mb       *metadata.MetricsBuilder
+	clients      []*http.Client
+	transactions []transactionCheck
+	cfg          *Config
+	settings     component.TelemetrySettings
+	mb           *metadata.MetricsBuilder
+	// nextTraces receives the traces of the transactions when the receiver is part of a traces pipeline
+	nextTraces consumer.Traces
 }
 
 // extractTLSInfo extracts TLS certificate information from the connection state
@@ -235,19 +240,62 @@ func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err
 	}
 
 	h.cfg.Targets = expandedTargets // Replace targets with expanded targets
+
+	for _, transaction := range h.cfg.Transactions {
+		if transaction.ClientConfig.Timeout == 0 {
+			transaction.ClientConfig.Timeout = 30 * time.Second
+		}
+		client, clientErr := transaction.ClientConfig.ToClient(ctx, host.GetExtensions(), h.settings)
+		if clientErr != nil {
+			h.settings.Logger.Error("failed to initialize HTTP client", zap.String("transaction", transaction.Name), zap.Error(clientErr))
+			err = multierr.Append(err, clientErr)
+			continue
+		}
+		check, checkErr := newTransactionCheck(transaction, client)
+		if checkErr != nil {
+			err = multierr.Append(err, checkErr)
+			continue
+		}
+		h.transactions = append(h.transactions, check)
+	}
 	return err
 }
 
 // scrape performs the HTTP checks and records metrics based on responses.
 func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	if len(h.clients) == 0 {
+	if len(h.clients) == 0 && len(h.transactions) == 0 {
 		return pmetric.NewMetrics(), errClientNotInit
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(len(h.clients))
+	wg.Add(len(h.clients) + len(h.transactions))
 	var mux sync.Mutex
 
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	spans.Scope().SetName(metadata.ScopeName)
+	for _, check := range h.transactions {
+		go func() {
+			defer wg.Done()
+
+			run := h.runTransaction(ctx, check)
+			now := pcommon.NewTimestampFromTime(time.Now())
+
+			mux.Lock()
+			defer mux.Unlock()
+			h.mb.RecordHttpcheckTransactionDurationDataPoint(now, run.end.Sub(run.start).Milliseconds(), check.cfg.Name)
+			status := int64(1)
+			if run.err() != nil {
+				status = 0
+			}
+			h.mb.RecordHttpcheckTransactionStatusDataPoint(now, status, check.cfg.Name)
+			for _, step := range run.steps {
+				h.mb.RecordHttpcheckTransactionStepDurationDataPoint(now, step.end.Sub(step.start).Milliseconds(), check.cfg.Name, step.name)
+			}
+			run.appendSpans(check.cfg.Name, spans.Spans())
+		}()
+	}
+
 	for idx, client := range h.clients {
 		go func(targetClient *http.Client, targetIndex int) {
 			defer wg.Done()
@@ -311,15 +359,7 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 
 			// Set Content-Type header automatically if body is present, no Content-Type is set, and auto_content_type is enabled
 			if h.cfg.Targets[targetIndex].Body != "" && req.Header.Get("Content-Type") == "" && h.cfg.Targets[targetIndex].AutoContentType {
-				body := strings.TrimSpace(h.cfg.Targets[targetIndex].Body)
-				switch {
-				case strings.HasPrefix(body, "{") || strings.HasPrefix(body, "["):
-					req.Header.Set("Content-Type", "application/json")
-				case strings.Contains(h.cfg.Targets[targetIndex].Body, "="):
-					req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-				default:
-					req.Header.Set("Content-Type", "text/plain")
-				}
+				req.Header.Set("Content-Type", contentTypeForBody(h.cfg.Targets[targetIndex].Body))
 			}
 
 			// Send the request and measure response time
@@ -444,6 +484,12 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 
 	wg.Wait()
 
+	if h.nextTraces != nil && traces.SpanCount() > 0 {
+		if err := h.nextTraces.ConsumeTraces(ctx, traces); err != nil {
+			h.settings.Logger.Error("failed to consume transaction traces", zap.Error(err))
+		}
+	}
+
 	// Emit metrics and post-process to remove http.status_code when value is 0
 	metrics := h.mb.Emit()
 	removeStatusCodeForZeroValues(metrics)
@@ -451,6 +497,19 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 	return metrics, nil
 }
 
+// contentTypeForBody guesses the Content-Type of a request body
+func contentTypeForBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		return "application/json"
+	case strings.Contains(body, "="):
+		return "application/x-www-form-urlencoded"
+	default:
+		return "text/plain"
+	}
+}
+
 // removeStatusCodeForZeroValues removes the http.status_code attribute from httpcheck.status metrics
 func removeStatusCodeForZeroValues(metrics pmetric.Metrics) {
 	rms := metrics.ResourceMetrics()
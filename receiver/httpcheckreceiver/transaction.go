@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// maxStepResponseSize is the maximum size of the response body of a transaction step,
+// larger responses fail the step.
+const maxStepResponseSize = 10 << 20
+
+// transactionCheck is a configured transaction with the client its steps share
+type transactionCheck struct {
+	cfg    *transactionConfig
+	client *http.Client
+	// regexes holds the compiled regular expressions of the extracted values, by pattern
+	regexes map[string]*regexp.Regexp
+}
+
+// newTransactionCheck compiles the regular expressions of the values extracted by the steps of the transaction.
+func newTransactionCheck(cfg *transactionConfig, client *http.Client) (transactionCheck, error) {
+	check := transactionCheck{cfg: cfg, client: client, regexes: map[string]*regexp.Regexp{}}
+	for _, step := range cfg.Steps {
+		for _, extract := range step.Extract {
+			if extract.Regex == "" {
+				continue
+			}
+			re, err := regexp.Compile(extract.Regex)
+			if err != nil {
+				return transactionCheck{}, fmt.Errorf("transaction %q: step %q: value %q: invalid regex: %w", cfg.Name, step.Name, extract.Name, err)
+			}
+			check.regexes[extract.Regex] = re
+		}
+	}
+	return check, nil
+}
+
+// transactionRun holds the outcome of a run of a transaction, the steps after a failed step are not run.
+type transactionRun struct {
+	traceID pcommon.TraceID
+	spanID  pcommon.SpanID
+	start   time.Time
+	end     time.Time
+	steps   []stepRun
+}
+
+type stepRun struct {
+	name       string
+	spanID     pcommon.SpanID
+	start      time.Time
+	end        time.Time
+	method     string
+	url        string
+	statusCode int
+	err        error
+}
+
+// err returns the error of the failed step, if any
+func (r *transactionRun) err() error {
+	if len(r.steps) == 0 {
+		return nil
+	}
+	last := r.steps[len(r.steps)-1]
+	if last.err != nil {
+		return fmt.Errorf("step %q: %w", last.name, last.err)
+	}
+	return nil
+}
+
+// appendSpans adds the spans of the run to spans: a root span for the transaction and a client span per step.
+func (r *transactionRun) appendSpans(name string, spans ptrace.SpanSlice) {
+	root := spans.AppendEmpty()
+	root.SetTraceID(r.traceID)
+	root.SetSpanID(r.spanID)
+	root.SetName(name)
+	root.SetKind(ptrace.SpanKindInternal)
+	root.SetStartTimestamp(pcommon.NewTimestampFromTime(r.start))
+	root.SetEndTimestamp(pcommon.NewTimestampFromTime(r.end))
+	if err := r.err(); err != nil {
+		root.Status().SetCode(ptrace.StatusCodeError)
+		root.Status().SetMessage(err.Error())
+	}
+
+	for _, step := range r.steps {
+		span := spans.AppendEmpty()
+		span.SetTraceID(r.traceID)
+		span.SetSpanID(step.spanID)
+		span.SetParentSpanID(r.spanID)
+		span.SetName(step.name)
+		span.SetKind(ptrace.SpanKindClient)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(step.start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(step.end))
+		span.Attributes().PutStr("http.request.method", step.method)
+		span.Attributes().PutStr("url.full", step.url)
+		if step.statusCode != 0 {
+			span.Attributes().PutInt("http.response.status_code", int64(step.statusCode))
+		}
+		if step.err != nil {
+			span.Status().SetCode(ptrace.StatusCodeError)
+			span.Status().SetMessage(step.err.Error())
+		}
+	}
+}
+
+// runTransaction runs the steps of the transaction in order until one of them fails.
+// Values extracted from the responses are available to the next steps.
+func (h *httpcheckScraper) runTransaction(ctx context.Context, check transactionCheck) *transactionRun {
+	run := &transactionRun{
+		traceID: newTraceID(),
+		spanID:  newSpanID(),
+		start:   time.Now(),
+	}
+	values := map[string]string{}
+	for _, step := range check.cfg.Steps {
+		sr := stepRun{name: step.Name, spanID: newSpanID()}
+		sr.err = h.runStep(ctx, check, step, run.traceID, values, &sr)
+		run.steps = append(run.steps, sr)
+		if sr.err != nil {
+			h.settings.Logger.Debug("transaction step failed",
+				zap.String("transaction", check.cfg.Name), zap.String("step", step.Name), zap.Error(sr.err))
+			break
+		}
+	}
+	run.end = time.Now()
+	return run
+}
+
+// runStep sends the request of the step with the traceparent of its span, then checks the
+// response against the step assertions and extracts its values.
+func (h *httpcheckScraper) runStep(ctx context.Context, check transactionCheck, step *stepConfig, traceID pcommon.TraceID, values map[string]string, sr *stepRun) error {
+	sr.start = time.Now()
+	defer func() { sr.end = time.Now() }()
+
+	sr.method = step.Method
+	if sr.method == "" {
+		sr.method = http.MethodGet
+	}
+	endpoint, err := resolveStepEndpoint(check.cfg.ClientConfig.Endpoint, expandValues(step.Endpoint, values))
+	if err != nil {
+		return err
+	}
+	sr.url = endpoint
+
+	var requestBody io.Reader = http.NoBody
+	body := expandValues(step.Body, values)
+	if body != "" {
+		requestBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, sr.method, endpoint, requestBody)
+	if err != nil {
+		return err
+	}
+	for key, value := range step.Headers {
+		req.Header.Set(key, expandValues(value, values))
+	}
+	if body != "" && req.Header.Get("Content-Type") == "" && step.AutoContentType {
+		req.Header.Set("Content-Type", contentTypeForBody(body))
+	}
+	req.Header.Set("traceparent", "00-"+traceID.String()+"-"+sr.spanID.String()+"-01")
+
+	resp, err := check.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			h.settings.Logger.Error("failed to close response body", zap.Error(closeErr))
+		}
+	}()
+	sr.statusCode = resp.StatusCode
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, maxStepResponseSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(responseBody) > maxStepResponseSize {
+		return fmt.Errorf("response body larger than %d bytes", maxStepResponseSize)
+	}
+
+	switch {
+	case step.StatusCode != 0 && resp.StatusCode != step.StatusCode:
+		return fmt.Errorf("unexpected status code %d, expected %d", resp.StatusCode, step.StatusCode)
+	case step.StatusCode == 0 && resp.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if _, failed := validateResponse(responseBody, step.Validations); len(failed) > 0 {
+		failedTypes := make([]string, 0, len(failed))
+		for validationType := range failed {
+			failedTypes = append(failedTypes, validationType)
+		}
+		slices.Sort(failedTypes)
+		return fmt.Errorf("failed validations: %s", strings.Join(failedTypes, ", "))
+	}
+
+	for _, extract := range step.Extract {
+		value, ok, extractErr := extractValue(extract, check.regexes[extract.Regex], resp.Header, responseBody)
+		if extractErr != nil {
+			return extractErr
+		}
+		if !ok {
+			return fmt.Errorf("value %q not found in the response", extract.Name)
+		}
+		values[extract.Name] = value
+	}
+	return nil
+}
+
+// extractValue returns the value extracted from the response, and false if the response does not contain it.
+// re is the compiled regular expression of the value, if it is extracted by one.
+func extractValue(extract extractConfig, re *regexp.Regexp, header http.Header, body []byte) (string, bool, error) {
+	switch {
+	case extract.JSONPath != "":
+		result := gjson.GetBytes(body, extract.JSONPath)
+		return result.String(), result.Exists(), nil
+	case re != nil:
+		match := re.FindSubmatch(body)
+		switch {
+		case match == nil:
+			return "", false, nil
+		case len(match) > 1:
+			return string(match[1]), true, nil
+		default:
+			return string(match[0]), true, nil
+		}
+	case extract.Header != "":
+		value := header.Get(extract.Header)
+		return value, value != "", nil
+	}
+	return "", false, errors.New("no source to extract the value from")
+}
+
+// resolveStepEndpoint returns the endpoint of the step, resolved against the transaction endpoint when relative.
+func resolveStepEndpoint(base, endpoint string) (string, error) {
+	if base == "" {
+		return endpoint, nil
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(ref).String(), nil
+}
+
+// expandValues replaces the {{name}} references in s with the extracted values
+func expandValues(s string, values map[string]string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(reference string) string {
+		return values[variablePattern.FindStringSubmatch(reference)[1]]
+	})
+}
+
+func newTraceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
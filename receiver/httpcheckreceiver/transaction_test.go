@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// newShopServer serves a login, search and checkout flow where each request depends on the previous response
+func newShopServer(t *testing.T, traceparents *[]string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*traceparents = append(*traceparents, r.Header.Get("traceparent"))
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/login":
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			if r.Method != http.MethodPost || string(body) != `{"user":"alice"}` || r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, err = w.Write([]byte(`{"token":"s3cr3t"}`))
+			assert.NoError(t, err)
+		case "/search":
+			if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-Cart", "cart-7")
+			_, err := w.Write([]byte(`<a href="/items/42">socks</a>`))
+			assert.NoError(t, err)
+		case "/carts/cart-7/items/42":
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"status":"added"}`))
+			assert.NoError(t, err)
+		case "/catalog":
+			_, err := w.Write(bytes.Repeat([]byte("a"), maxStepResponseSize+1))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newShopTransaction(endpoint string) *transactionConfig {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	// TODO: See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/49316.
+	clientConfig.MaxIdleConns = 0
+	clientConfig.IdleConnTimeout = 0
+	clientConfig.ForceAttemptHTTP2 = false
+	clientConfig.Endpoint = endpoint
+	return &transactionConfig{
+		Name:         "checkout",
+		ClientConfig: clientConfig,
+		Steps: []*stepConfig{
+			{
+				Name:            "login",
+				Endpoint:        "/login",
+				Method:          http.MethodPost,
+				Body:            `{"user":"alice"}`,
+				AutoContentType: true,
+				Extract:         []extractConfig{{Name: "token", JSONPath: "token"}},
+			},
+			{
+				Name:     "search",
+				Endpoint: "/search?q=socks",
+				Headers:  map[string]string{"Authorization": "Bearer {{ token }}"},
+				Validations: []validationConfig{
+					{Contains: "socks"},
+				},
+				Extract: []extractConfig{
+					{Name: "item", Regex: `/items/(\d+)`},
+					{Name: "cart", Header: "X-Cart"},
+				},
+			},
+			{
+				Name:       "add to cart",
+				Endpoint:   "/carts/{{cart}}/items/{{item}}",
+				Method:     http.MethodPut,
+				StatusCode: http.StatusCreated,
+				Validations: []validationConfig{
+					{JSONPath: "status", Equals: "added"},
+				},
+			},
+		},
+	}
+}
+
+func scrapeTransaction(t *testing.T, transaction *transactionConfig) (pmetric.Metrics, *consumertest.TracesSink) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsBuilderConfig.Metrics.HttpcheckTransactionStepDuration.Enabled = true
+	cfg.Transactions = []*transactionConfig{transaction}
+
+	sink := &consumertest.TracesSink{}
+	scraper := newScraper(cfg, receivertest.NewNopSettings(metadata.Type))
+	scraper.nextTraces = sink
+	require.NoError(t, scraper.start(t.Context(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrape(t.Context())
+	require.NoError(t, err)
+	return metrics, sink
+}
+
+func metricsByName(metrics pmetric.Metrics) map[string]pmetric.Metric {
+	found := map[string]pmetric.Metric{}
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		found[ms.At(i).Name()] = ms.At(i)
+	}
+	return found
+}
+
+func TestTransaction(t *testing.T) {
+	var traceparents []string
+	server := newShopServer(t, &traceparents)
+	defer server.Close()
+
+	metrics, sink := scrapeTransaction(t, newShopTransaction(server.URL))
+
+	found := metricsByName(metrics)
+	require.Contains(t, found, "httpcheck.transaction.status")
+	status := found["httpcheck.transaction.status"].Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1), status.IntValue())
+	name, _ := status.Attributes().Get("transaction.name")
+	assert.Equal(t, "checkout", name.Str())
+	assert.Equal(t, 3, found["httpcheck.transaction.step.duration"].Gauge().DataPoints().Len())
+	assert.Equal(t, 1, found["httpcheck.transaction.duration"].Gauge().DataPoints().Len())
+
+	require.Len(t, sink.AllTraces(), 1)
+	spans := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0)
+	assert.Equal(t, metadata.ScopeName, spans.Scope().Name())
+	require.Equal(t, 4, spans.Spans().Len())
+
+	root := spans.Spans().At(0)
+	assert.Equal(t, "checkout", root.Name())
+	assert.Equal(t, ptrace.SpanKindInternal, root.Kind())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, ptrace.StatusCodeUnset, root.Status().Code())
+
+	require.Len(t, traceparents, 3)
+	for i, stepName := range []string{"login", "search", "add to cart"} {
+		span := spans.Spans().At(i + 1)
+		assert.Equal(t, stepName, span.Name())
+		assert.Equal(t, ptrace.SpanKindClient, span.Kind())
+		assert.Equal(t, root.TraceID(), span.TraceID())
+		assert.Equal(t, root.SpanID(), span.ParentSpanID())
+		assert.Equal(t, ptrace.StatusCodeUnset, span.Status().Code())
+		assert.Equal(t, "00-"+root.TraceID().String()+"-"+span.SpanID().String()+"-01", traceparents[i])
+	}
+	lastStep := spans.Spans().At(3).Attributes().AsRaw()
+	assert.Equal(t, map[string]any{
+		"http.request.method":       "PUT",
+		"url.full":                  server.URL + "/carts/cart-7/items/42",
+		"http.response.status_code": int64(201),
+	}, lastStep)
+}
+
+func TestTransactionFailure(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		modify        func(transaction *transactionConfig)
+		expectedSteps int
+		expectedError string
+	}{
+		{
+			desc: "unexpected status code",
+			modify: func(transaction *transactionConfig) {
+				transaction.Steps[0].Body = `{"user":"mallory"}`
+			},
+			expectedSteps: 1,
+			expectedError: `step "login": unexpected status code 401`,
+		},
+		{
+			desc: "failed validation",
+			modify: func(transaction *transactionConfig) {
+				transaction.Steps[1].Validations = []validationConfig{{Contains: "shoes"}, {Regex: "^<b>"}}
+			},
+			expectedSteps: 2,
+			expectedError: `step "search": failed validations: contains, regex`,
+		},
+		{
+			desc: "missing extracted value",
+			modify: func(transaction *transactionConfig) {
+				transaction.Steps[1].Extract[1].Header = "X-Basket"
+			},
+			expectedSteps: 2,
+			expectedError: `step "search": value "cart" not found in the response`,
+		},
+		{
+			desc: "response body too large",
+			modify: func(transaction *transactionConfig) {
+				transaction.Steps[1].Endpoint = "/catalog"
+			},
+			expectedSteps: 2,
+			expectedError: `step "search": response body larger than 10485760 bytes`,
+		},
+		{
+			desc: "status code other than the expected one",
+			modify: func(transaction *transactionConfig) {
+				transaction.Steps[2].StatusCode = http.StatusOK
+			},
+			expectedSteps: 3,
+			expectedError: `step "add to cart": unexpected status code 201, expected 200`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var traceparents []string
+			server := newShopServer(t, &traceparents)
+			defer server.Close()
+
+			transaction := newShopTransaction(server.URL)
+			tc.modify(transaction)
+			metrics, sink := scrapeTransaction(t, transaction)
+
+			found := metricsByName(metrics)
+			assert.Equal(t, int64(0), found["httpcheck.transaction.status"].Sum().DataPoints().At(0).IntValue())
+			assert.Equal(t, tc.expectedSteps, found["httpcheck.transaction.step.duration"].Gauge().DataPoints().Len())
+
+			// Steps after the failed one are not run
+			assert.Len(t, traceparents, tc.expectedSteps)
+			spans := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+			require.Equal(t, tc.expectedSteps+1, spans.Len())
+			assert.Equal(t, ptrace.StatusCodeError, spans.At(0).Status().Code())
+			assert.Equal(t, tc.expectedError, spans.At(0).Status().Message())
+			assert.Equal(t, ptrace.StatusCodeError, spans.At(tc.expectedSteps).Status().Code())
+		})
+	}
+}
+
+func TestNewTransactionCheck(t *testing.T) {
+	transaction := newShopTransaction("http://localhost")
+	check, err := newTransactionCheck(transaction, http.DefaultClient)
+	require.NoError(t, err)
+	// The regular expressions are compiled once, when the receiver starts.
+	require.Contains(t, check.regexes, `/items/(\d+)`)
+	assert.Len(t, check.regexes, 1)
+
+	transaction.Steps[1].Extract[0].Regex = "("
+	_, err = newTransactionCheck(transaction, http.DefaultClient)
+	assert.ErrorContains(t, err, `transaction "checkout": step "search": value "item": invalid regex`)
+}
+
+func TestResolveStepEndpoint(t *testing.T) {
+	testCases := []struct {
+		base     string
+		endpoint string
+		expected string
+	}{
+		{base: "", endpoint: "https://example.com/a", expected: "https://example.com/a"},
+		{base: "https://example.com/api/", endpoint: "items", expected: "https://example.com/api/items"},
+		{base: "https://example.com/api/", endpoint: "/login", expected: "https://example.com/login"},
+		{base: "https://example.com", endpoint: "https://other.com/b", expected: "https://other.com/b"},
+		{base: "https://example.com/health", endpoint: "", expected: "https://example.com/health"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.base+tc.endpoint, func(t *testing.T) {
+			actual, err := resolveStepEndpoint(tc.base, tc.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -52,6 +52,7 @@ type K8sObjectsConfig struct {
 	InitialDelay      time.Duration        `mapstructure:"initial_delay"`
 	ResourceVersion   string               `mapstructure:"resource_version"`
 	ExcludeWatchType  []apiWatch.EventType `mapstructure:"exclude_watch_type"`
+	Diff              DiffConfig           `mapstructure:"diff"`
 	exclude           map[apiWatch.EventType]bool
 	gvr               *schema.GroupVersionResource
 }
@@ -129,6 +130,14 @@ func (c *Config) Validate() error {
 		if len(object.ExcludeNamespaces) != 0 && len(object.Namespaces) != 0 {
 			return errors.New("namespaces and exclude_namespaces cannot both be set at the same time")
 		}
+
+		if object.Mode == k8sinventory.PullMode && object.Diff.Enabled {
+			return errors.New("diff can only be used with watch mode")
+		}
+
+		if err := object.Diff.Validate(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -211,6 +220,13 @@ func (k *K8sObjectsConfig) DeepCopy() *K8sObjectsConfig {
 		copy(copied.ExcludeWatchType, k.ExcludeWatchType)
 	}
 
+	copied.Diff = DiffConfig{
+		Enabled:       k.Diff.Enabled,
+		IncludeFields: append([]string(nil), k.Diff.IncludeFields...),
+		ExcludeFields: append([]string(nil), k.Diff.ExcludeFields...),
+		CacheSize:     k.Diff.CacheSize,
+	}
+
 	copied.exclude = make(map[apiWatch.EventType]bool)
 	maps.Copy(copied.exclude, k.exclude)
 
@@ -101,6 +101,25 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "watch_with_diff"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{
+					AuthType: k8sconfig.AuthTypeServiceAccount,
+				},
+				Objects: []*K8sObjectsConfig{
+					{
+						Name: "deployments",
+						Mode: k8sinventory.WatchMode,
+						Diff: DiffConfig{
+							Enabled:       true,
+							ExcludeFields: []string{"status", "metadata.generation"},
+							CacheSize:     500,
+						},
+					},
+				},
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "top_level_interval"),
 			expected: &Config{
@@ -350,6 +369,51 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: "initial_delay must be less than interval",
 		},
+		{
+			desc: "diff with pull mode is invalid",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				ErrorMode: PropagateError,
+				Objects: []*K8sObjectsConfig{
+					{
+						Name: "pods",
+						Mode: k8sinventory.PullMode,
+						Diff: DiffConfig{Enabled: true},
+					},
+				},
+			},
+			expectedErr: "diff can only be used with watch mode",
+		},
+		{
+			desc: "negative diff cache size is invalid",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				ErrorMode: PropagateError,
+				Objects: []*K8sObjectsConfig{
+					{
+						Name: "pods",
+						Mode: k8sinventory.WatchMode,
+						Diff: DiffConfig{Enabled: true, CacheSize: -1},
+					},
+				},
+			},
+			expectedErr: "diff.cache_size must not be negative",
+		},
+		{
+			desc: "empty diff field path segment is invalid",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				ErrorMode: PropagateError,
+				Objects: []*K8sObjectsConfig{
+					{
+						Name: "pods",
+						Mode: k8sinventory.WatchMode,
+						Diff: DiffConfig{Enabled: true, ExcludeFields: []string{"metadata..labels"}},
+					},
+				},
+			},
+			expectedErr: `invalid diff field path "metadata..labels"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -390,7 +454,13 @@ func TestDeepCopy(t *testing.T) {
 				InitialDelay:     10 * time.Minute,
 				ResourceVersion:  "1",
 				ExcludeWatchType: []apiWatch.EventType{apiWatch.Added},
-				exclude:          map[apiWatch.EventType]bool{apiWatch.Added: true},
+				Diff: DiffConfig{
+					Enabled:       true,
+					IncludeFields: []string{"spec"},
+					ExcludeFields: []string{"spec.replicas"},
+					CacheSize:     10,
+				},
+				exclude: map[apiWatch.EventType]bool{apiWatch.Added: true},
 				gvr: &schema.GroupVersionResource{
 					Group:    "group",
 					Version:  "v1",
@@ -416,6 +486,10 @@ func TestDeepCopy(t *testing.T) {
 			actual.InitialDelay = time.Second
 			actual.ResourceVersion = "changed"
 			actual.ExcludeWatchType[0] = apiWatch.Deleted
+			actual.Diff.Enabled = false
+			actual.Diff.IncludeFields[0] = "changed"
+			actual.Diff.ExcludeFields[0] = "changed"
+			actual.Diff.CacheSize = 1
 			actual.exclude[apiWatch.Bookmark] = true
 			actual.gvr.Group = "changed"
 			actual.gvr.Version = "changed"
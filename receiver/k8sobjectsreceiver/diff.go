@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sjson "k8s.io/apimachinery/pkg/util/json"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	defaultDiffCacheSize = 10000
+	diffStorageKeyFormat = "lastSeenObject/%s"
+)
+
+// alwaysExcludedFields change on every update of an object, they are never part of the change sets.
+var alwaysExcludedFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+}
+
+// DiffConfig configures the diff mode of a watched object, which emits the changes between the
+// versions of the objects instead of the whole objects.
+type DiffConfig struct {
+	// Enabled turns on the diff mode.
+	Enabled bool `mapstructure:"enabled"`
+	// IncludeFields are the dot-separated paths of the fields whose changes are reported, e.g. "spec".
+	// When empty, the changes of all the fields are reported.
+	IncludeFields []string `mapstructure:"include_fields"`
+	// ExcludeFields are the dot-separated paths of the fields whose changes are ignored, e.g. "status".
+	// metadata.managedFields and metadata.resourceVersion are always ignored.
+	ExcludeFields []string `mapstructure:"exclude_fields"`
+	// CacheSize is the maximum number of objects whose last seen version is kept in memory. Default 10000.
+	CacheSize int `mapstructure:"cache_size"`
+}
+
+func (c *DiffConfig) Validate() error {
+	if c.CacheSize < 0 {
+		return errors.New("diff.cache_size must not be negative")
+	}
+	for _, path := range append(append([]string{}, c.IncludeFields...), c.ExcludeFields...) {
+		for _, segment := range strings.Split(path, ".") {
+			if segment == "" {
+				return fmt.Errorf("invalid diff field path %q", path)
+			}
+		}
+	}
+	return nil
+}
+
+// objectDiffer keeps the last seen version of the objects of a watch, and computes the changes of each event.
+// The last seen versions are kept in a bounded LRU cache, and written to the storage client when one is set,
+// so that the changes made while the collector was stopped or that were evicted from the cache are still reported.
+type objectDiffer struct {
+	gvr     schema.GroupVersionResource
+	include [][]string
+	exclude [][]string
+	storage storage.Client
+	logger  *zap.Logger
+
+	mu       sync.Mutex
+	size     int
+	lru      *list.List
+	lastSeen map[string]*list.Element
+}
+
+type lastSeenObject struct {
+	key    string
+	object map[string]any
+}
+
+func newObjectDiffer(cfg DiffConfig, gvr schema.GroupVersionResource, storageClient storage.Client, logger *zap.Logger) *objectDiffer {
+	size := cfg.CacheSize
+	if size == 0 {
+		size = defaultDiffCacheSize
+	}
+	d := &objectDiffer{
+		gvr:      gvr,
+		exclude:  append([][]string{}, alwaysExcludedFields...),
+		storage:  storageClient,
+		logger:   logger,
+		size:     size,
+		lru:      list.New(),
+		lastSeen: map[string]*list.Element{},
+	}
+	for _, path := range cfg.IncludeFields {
+		d.include = append(d.include, strings.Split(path, "."))
+	}
+	for _, path := range cfg.ExcludeFields {
+		d.exclude = append(d.exclude, strings.Split(path, "."))
+	}
+	return d
+}
+
+// diff returns the changes of the object of the event since its last seen version, and records the new version.
+// It returns no changes when there is nothing to emit for the event.
+func (d *objectDiffer) diff(ctx context.Context, eventType apiWatch.EventType, udata *unstructured.Unstructured) []any {
+	key := strings.Join([]string{d.gvr.Group, d.gvr.Resource, udata.GetNamespace(), udata.GetName()}, "/")
+	current := d.filter(udata.Object)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	previous, found := d.get(ctx, key)
+
+	switch eventType {
+	case apiWatch.Deleted:
+		if found {
+			current = previous
+		}
+		d.remove(ctx, key)
+		return []any{change("remove", "", current, nil)}
+	case apiWatch.Added, apiWatch.Modified:
+		var changes []any
+		switch {
+		case found:
+			if changes = diffValues(nil, "", previous, current); len(changes) == 0 {
+				return nil
+			}
+		case eventType == apiWatch.Added:
+			changes = []any{change("add", "", nil, current)}
+		default:
+			// Without the previous version, the changes of the first update are unknown.
+			d.logger.Debug("no previous version of the object, recording it", zap.String("object", key))
+		}
+		d.put(ctx, key, current)
+		return changes
+	default:
+		return nil
+	}
+}
+
+// filter returns a copy of the object restricted to the included fields, without the excluded ones.
+func (d *objectDiffer) filter(object map[string]any) map[string]any {
+	filtered := runtime.DeepCopyJSON(object)
+	if len(d.include) > 0 {
+		included := map[string]any{}
+		for _, path := range d.include {
+			if value, ok := nestedValue(filtered, path); ok {
+				setNestedValue(included, path, value)
+			}
+		}
+		filtered = included
+	}
+	for _, path := range d.exclude {
+		removeNestedValue(filtered, path)
+	}
+	return filtered
+}
+
+func (d *objectDiffer) get(ctx context.Context, key string) (map[string]any, bool) {
+	if elem, ok := d.lastSeen[key]; ok {
+		d.lru.MoveToFront(elem)
+		return elem.Value.(*lastSeenObject).object, true
+	}
+	if d.storage == nil {
+		return nil, false
+	}
+	data, err := d.storage.Get(ctx, fmt.Sprintf(diffStorageKeyFormat, key))
+	if err != nil {
+		d.logger.Warn("failed to retrieve the last seen version of the object", zap.String("object", key), zap.Error(err))
+		return nil, false
+	}
+	// If key is not found, data and error is nil
+	if len(data) == 0 {
+		return nil, false
+	}
+	var object map[string]any
+	// The Kubernetes decoder keeps integers as int64, like in the objects received from the API.
+	if err = k8sjson.Unmarshal(data, &object); err != nil {
+		d.logger.Warn("failed to decode the last seen version of the object", zap.String("object", key), zap.Error(err))
+		return nil, false
+	}
+	d.cache(key, object)
+	return object, true
+}
+
+func (d *objectDiffer) put(ctx context.Context, key string, object map[string]any) {
+	d.cache(key, object)
+	if d.storage == nil {
+		return
+	}
+	data, err := json.Marshal(object)
+	if err == nil {
+		err = d.storage.Set(ctx, fmt.Sprintf(diffStorageKeyFormat, key), data)
+	}
+	if err != nil {
+		d.logger.Warn("failed to persist the last seen version of the object", zap.String("object", key), zap.Error(err))
+	}
+}
+
+func (d *objectDiffer) cache(key string, object map[string]any) {
+	if elem, ok := d.lastSeen[key]; ok {
+		elem.Value.(*lastSeenObject).object = object
+		d.lru.MoveToFront(elem)
+		return
+	}
+	d.lastSeen[key] = d.lru.PushFront(&lastSeenObject{key: key, object: object})
+	if d.lru.Len() > d.size {
+		oldest := d.lru.Back()
+		d.lru.Remove(oldest)
+		delete(d.lastSeen, oldest.Value.(*lastSeenObject).key)
+	}
+}
+
+func (d *objectDiffer) remove(ctx context.Context, key string) {
+	if elem, ok := d.lastSeen[key]; ok {
+		d.lru.Remove(elem)
+		delete(d.lastSeen, key)
+	}
+	if d.storage == nil {
+		return
+	}
+	if err := d.storage.Delete(ctx, fmt.Sprintf(diffStorageKeyFormat, key)); err != nil {
+		d.logger.Warn("failed to delete the last seen version of the object", zap.String("object", key), zap.Error(err))
+	}
+}
+
+// diffValues returns the JSON-patch style operations changing previous into current. Lists are compared
+// item by item, and items added or removed at their end.
+func diffValues(changes []any, path string, previous, current any) []any {
+	switch prev := previous.(type) {
+	case map[string]any:
+		if cur, ok := current.(map[string]any); ok {
+			for _, key := range sortedKeys(prev) {
+				childPath := path + "/" + escapePointer(key)
+				if value, ok := cur[key]; ok {
+					changes = diffValues(changes, childPath, prev[key], value)
+				} else {
+					changes = append(changes, change("remove", childPath, prev[key], nil))
+				}
+			}
+			for _, key := range sortedKeys(cur) {
+				if _, ok := prev[key]; !ok {
+					changes = append(changes, change("add", path+"/"+escapePointer(key), nil, cur[key]))
+				}
+			}
+			return changes
+		}
+	case []any:
+		if cur, ok := current.([]any); ok {
+			for i := 0; i < min(len(prev), len(cur)); i++ {
+				changes = diffValues(changes, path+"/"+strconv.Itoa(i), prev[i], cur[i])
+			}
+			for i := len(prev); i < len(cur); i++ {
+				changes = append(changes, change("add", path+"/"+strconv.Itoa(i), nil, cur[i]))
+			}
+			// Items are removed from the end, so that the indexes of the remaining operations stay valid.
+			for i := len(prev) - 1; i >= len(cur); i-- {
+				changes = append(changes, change("remove", path+"/"+strconv.Itoa(i), prev[i], nil))
+			}
+			return changes
+		}
+	}
+	if !reflect.DeepEqual(previous, current) {
+		changes = append(changes, change("replace", path, previous, current))
+	}
+	return changes
+}
+
+// change returns a JSON-patch operation, along with the value that was replaced or removed.
+func change(op, path string, oldValue, value any) map[string]any {
+	c := map[string]any{
+		"op":   op,
+		"path": path,
+	}
+	if op != "remove" {
+		c["value"] = value
+	}
+	if op != "add" {
+		c["old_value"] = oldValue
+	}
+	return c
+}
+
+// fieldManager returns the manager of the last update of the object, as recorded in its managed fields.
+func fieldManager(object *unstructured.Unstructured) string {
+	var manager string
+	var latest time.Time
+	for _, entry := range object.GetManagedFields() {
+		if entry.Time != nil && !entry.Time.Time.Before(latest) {
+			latest = entry.Time.Time
+			manager = entry.Manager
+		}
+	}
+	return manager
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func nestedValue(object map[string]any, path []string) (any, bool) {
+	var value any = object
+	for _, segment := range path {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func setNestedValue(object map[string]any, path []string, value any) {
+	m := object
+	for _, segment := range path[:len(path)-1] {
+		child, ok := m[segment].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			m[segment] = child
+		}
+		m = child
+	}
+	m[path[len(path)-1]] = value
+}
+
+func removeNestedValue(object map[string]any, path []string) {
+	parent, ok := nestedValue(object, path[:len(path)-1])
+	if m, isMap := parent.(map[string]any); ok && isMap {
+		delete(m, path[len(path)-1])
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func diffPod(resourceVersion string, replicas int64, image string, phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":            "pod1",
+				"namespace":       "default",
+				"resourceVersion": resourceVersion,
+				"labels":          map[string]any{"app.kubernetes.io/name": "web"},
+				"managedFields":   []any{map[string]any{"manager": "kubectl"}},
+			},
+			"spec": map[string]any{
+				"replicas":   replicas,
+				"containers": []any{map[string]any{"name": "web", "image": image}},
+			},
+			"status": map[string]any{"phase": phase},
+		},
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	tests := []struct {
+		desc     string
+		previous any
+		current  any
+		expected []any
+	}{
+		{
+			desc:     "equal",
+			previous: map[string]any{"a": int64(1), "b": []any{"x"}},
+			current:  map[string]any{"a": int64(1), "b": []any{"x"}},
+		},
+		{
+			desc:     "replace, add and remove fields",
+			previous: map[string]any{"a": int64(1), "b": "removed"},
+			current:  map[string]any{"a": int64(2), "c": map[string]any{"d": true}},
+			expected: []any{
+				map[string]any{"op": "replace", "path": "/a", "old_value": int64(1), "value": int64(2)},
+				map[string]any{"op": "remove", "path": "/b", "old_value": "removed"},
+				map[string]any{"op": "add", "path": "/c", "value": map[string]any{"d": true}},
+			},
+		},
+		{
+			desc:     "keys are escaped",
+			previous: map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "web", "a~b": "x"}},
+			current:  map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "api", "a~b": "y"}},
+			expected: []any{
+				map[string]any{"op": "replace", "path": "/labels/app.kubernetes.io~1name", "old_value": "web", "value": "api"},
+				map[string]any{"op": "replace", "path": "/labels/a~0b", "old_value": "x", "value": "y"},
+			},
+		},
+		{
+			desc:     "list items are compared by index",
+			previous: []any{"a", "b", "c"},
+			current:  []any{"a", "x"},
+			expected: []any{
+				map[string]any{"op": "replace", "path": "/1", "old_value": "b", "value": "x"},
+				map[string]any{"op": "remove", "path": "/2", "old_value": "c"},
+			},
+		},
+		{
+			desc:     "list items are added at the end",
+			previous: []any{map[string]any{"image": "web:1"}},
+			current:  []any{map[string]any{"image": "web:2"}, map[string]any{"image": "sidecar:1"}},
+			expected: []any{
+				map[string]any{"op": "replace", "path": "/0/image", "old_value": "web:1", "value": "web:2"},
+				map[string]any{"op": "add", "path": "/1", "value": map[string]any{"image": "sidecar:1"}},
+			},
+		},
+		{
+			desc:     "type change",
+			previous: map[string]any{"a": map[string]any{"b": "c"}},
+			current:  map[string]any{"a": "b"},
+			expected: []any{
+				map[string]any{"op": "replace", "path": "/a", "old_value": map[string]any{"b": "c"}, "value": "b"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffValues(nil, "", tt.previous, tt.current))
+		})
+	}
+}
+
+func TestObjectDifferFilter(t *testing.T) {
+	pod := diffPod("1", 1, "web:1", "Running")
+
+	d := newObjectDiffer(DiffConfig{ExcludeFields: []string{"status", "spec.containers"}}, podsGVR, nil, zap.NewNop())
+	assert.Equal(t, map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":      "pod1",
+			"namespace": "default",
+			"labels":    map[string]any{"app.kubernetes.io/name": "web"},
+		},
+		"spec": map[string]any{"replicas": int64(1)},
+	}, d.filter(pod.Object))
+
+	d = newObjectDiffer(DiffConfig{IncludeFields: []string{"spec.replicas", "metadata", "missing.field"}}, podsGVR, nil, zap.NewNop())
+	assert.Equal(t, map[string]any{
+		"metadata": map[string]any{
+			"name":      "pod1",
+			"namespace": "default",
+			"labels":    map[string]any{"app.kubernetes.io/name": "web"},
+		},
+		"spec": map[string]any{"replicas": int64(1)},
+	}, d.filter(pod.Object))
+
+	// The object of the event is left untouched
+	assert.Contains(t, pod.Object, "status")
+	assert.Contains(t, pod.Object["metadata"], "managedFields")
+}
+
+func TestObjectDifferEvents(t *testing.T) {
+	d := newObjectDiffer(DiffConfig{ExcludeFields: []string{"status"}}, podsGVR, nil, zap.NewNop())
+	ctx := t.Context()
+
+	changes := d.diff(ctx, apiWatch.Added, diffPod("1", 1, "web:1", "Pending"))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "add", changes[0].(map[string]any)["op"])
+	assert.Empty(t, changes[0].(map[string]any)["path"])
+
+	// Only the status and the resource version changed
+	changes = d.diff(ctx, apiWatch.Modified, diffPod("2", 1, "web:1", "Running"))
+	assert.Empty(t, changes)
+
+	changes = d.diff(ctx, apiWatch.Modified, diffPod("3", 2, "web:2", "Running"))
+	assert.Equal(t, []any{
+		map[string]any{"op": "replace", "path": "/spec/containers/0/image", "old_value": "web:1", "value": "web:2"},
+		map[string]any{"op": "replace", "path": "/spec/replicas", "old_value": int64(1), "value": int64(2)},
+	}, changes)
+
+	changes = d.diff(ctx, apiWatch.Deleted, diffPod("4", 2, "web:2", "Succeeded"))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "remove", changes[0].(map[string]any)["op"])
+	assert.Equal(t, int64(2), changes[0].(map[string]any)["old_value"].(map[string]any)["spec"].(map[string]any)["replicas"])
+	assert.Empty(t, d.lastSeen)
+
+	// The changes of the first update of an unknown object are not known
+	changes = d.diff(ctx, apiWatch.Modified, diffPod("5", 1, "web:1", "Running"))
+	assert.Empty(t, changes)
+	assert.Len(t, d.lastSeen, 1)
+
+	changes = d.diff(ctx, apiWatch.Bookmark, diffPod("6", 1, "web:1", "Running"))
+	assert.Empty(t, changes)
+}
+
+func TestObjectDifferCacheSize(t *testing.T) {
+	d := newObjectDiffer(DiffConfig{CacheSize: 2}, podsGVR, nil, zap.NewNop())
+	ctx := t.Context()
+
+	for _, name := range []string{"pod1", "pod2", "pod1", "pod3"} {
+		pod := diffPod("1", 1, "web:1", "Running")
+		pod.SetName(name)
+		d.diff(ctx, apiWatch.Added, pod)
+	}
+
+	// pod2 is the least recently seen object
+	assert.Len(t, d.lastSeen, 2)
+	assert.Contains(t, d.lastSeen, "/pods/default/pod1")
+	assert.Contains(t, d.lastSeen, "/pods/default/pod3")
+	assert.Equal(t, 2, d.lru.Len())
+}
+
+func TestObjectDifferStorage(t *testing.T) {
+	ctx := t.Context()
+	client := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("k8sobjects"), "")
+
+	d := newObjectDiffer(DiffConfig{}, podsGVR, client, zap.NewNop())
+	d.diff(ctx, apiWatch.Added, diffPod("1", 1, "web:1", "Running"))
+
+	// A new differ, e.g. after a restart, finds the last seen version in the storage
+	d = newObjectDiffer(DiffConfig{}, podsGVR, client, zap.NewNop())
+	changes := d.diff(ctx, apiWatch.Modified, diffPod("2", 3, "web:1", "Running"))
+	assert.Equal(t, []any{
+		map[string]any{"op": "replace", "path": "/spec/replicas", "old_value": int64(1), "value": int64(3)},
+	}, changes)
+
+	d.diff(ctx, apiWatch.Deleted, diffPod("3", 3, "web:1", "Running"))
+	data, err := client.Get(ctx, "lastSeenObject//pods/default/pod1")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
@@ -109,6 +110,10 @@ func getObserverFunc(kr *k8sobjectsreceiver) func(ctx context.Context, object *K
 				},
 			)
 		case k8sinventory.WatchMode:
+			var differ *objectDiffer
+			if object.Diff.Enabled {
+				differ = newObjectDiffer(object.Diff, *object.gvr, kr.storageClient, kr.setting.Logger)
+			}
 			return watchobserver.New(
 				kr.client,
 				watchobserver.Config{
@@ -125,7 +130,16 @@ func getObserverFunc(kr *k8sobjectsreceiver) func(ctx context.Context, object *K
 				kr.setting.Logger,
 				kr.storageClient,
 				func(data *apiWatch.Event) {
-					logs, err := watchObjectsToLogData(data, time.Now(), object, kr.setting.BuildInfo.Version)
+					var logs plog.Logs
+					var err error
+					if differ != nil {
+						logs, err = diffObjectsToLogData(ctx, differ, data, time.Now(), object, kr.setting.BuildInfo.Version)
+					} else {
+						logs, err = watchObjectsToLogData(data, time.Now(), object, kr.setting.BuildInfo.Version)
+					}
+					if err == nil && logs.LogRecordCount() == 0 {
+						return
+					}
 					if err != nil {
 						kr.setting.Logger.Error("error converting objects to log data", zap.Error(err))
 					} else {
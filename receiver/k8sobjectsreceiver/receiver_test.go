@@ -346,6 +346,96 @@ func TestWatchObject(t *testing.T) {
 	assert.NoError(t, r.Shutdown(ctx))
 }
 
+func TestWatchObjectDiff(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.ErrorMode = PropagateError
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       k8sinventory.WatchMode,
+			Namespaces: []string{"default"},
+			Diff: DiffConfig{
+				Enabled:       true,
+				ExcludeFields: []string{"status"},
+			},
+		},
+	}
+
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(
+		receivertest.NewNopSettings(metadata.Type),
+		rCfg,
+		consumer,
+	)
+
+	ctx := t.Context()
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	time.Sleep(time.Millisecond * 100)
+	pod := generatePod("pod1", "default", map[string]any{
+		"environment": "production",
+	}, "1")
+	mockClient.createPods(pod)
+	time.Sleep(time.Millisecond * 100)
+	require.Equal(t, 1, consumer.Count())
+
+	// A status update is ignored
+	pod = generatePod("pod1", "default", map[string]any{
+		"environment": "production",
+	}, "2")
+	pod.Object["status"] = map[string]any{"phase": "Running"}
+	mockClient.updatePods(pod)
+	time.Sleep(time.Millisecond * 100)
+	require.Equal(t, 1, consumer.Count())
+
+	pod = generatePod("pod1", "default", map[string]any{
+		"environment": "test",
+	}, "3")
+	mockClient.updatePods(pod)
+	time.Sleep(time.Millisecond * 100)
+	require.Equal(t, 2, consumer.Count())
+
+	logs := consumer.Logs()[1]
+	assert.Equal(t, map[string]any{"k8s.namespace.name": "default"}, logs.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "pod1", record.Attributes().AsRaw()["event.name"])
+	assert.Equal(t, map[string]any{
+		"type": "MODIFIED",
+		"object": map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pods",
+			"metadata": map[string]any{
+				"name":            "pod1",
+				"namespace":       "default",
+				"uid":             "",
+				"resourceVersion": "3",
+			},
+		},
+		"changes": []any{
+			map[string]any{
+				"op":        "replace",
+				"path":      "/metadata/labels/environment",
+				"old_value": "production",
+				"value":     "test",
+			},
+		},
+	}, record.Body().Map().AsRaw())
+
+	mockClient.deletePods(pod)
+	time.Sleep(time.Millisecond * 100)
+	require.Equal(t, 3, consumer.Count())
+
+	assert.NoError(t, r.Shutdown(ctx))
+}
+
 func TestIncludeInitialState(t *testing.T) {
 	t.Parallel()
 
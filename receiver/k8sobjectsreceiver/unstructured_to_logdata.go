@@ -4,6 +4,7 @@
 package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -43,6 +44,49 @@ func watchObjectsToLogData(event *watch.Event, observedAt time.Time, config *K8s
 	}), nil
 }
 
+// diffObjectsToLogData returns the changes of the object of the event, along with the identity of the object
+// and the manager of its last update. The logs are empty when the event has no changes to report.
+func diffObjectsToLogData(ctx context.Context, differ *objectDiffer, event *watch.Event, observedAt time.Time, config *K8sObjectsConfig, version string) (plog.Logs, error) {
+	udata, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		return plog.Logs{}, fmt.Errorf("received data that wasnt unstructure, %v", event)
+	}
+
+	changes := differ.diff(ctx, event.Type, udata)
+	if len(changes) == 0 {
+		return plog.NewLogs(), nil
+	}
+
+	body := map[string]any{
+		"type": string(event.Type),
+		"object": map[string]any{
+			"apiVersion": udata.GetAPIVersion(),
+			"kind":       udata.GetKind(),
+			"metadata": map[string]any{
+				"name":            udata.GetName(),
+				"namespace":       udata.GetNamespace(),
+				"uid":             string(udata.GetUID()),
+				"resourceVersion": udata.GetResourceVersion(),
+			},
+		},
+		"changes": changes,
+	}
+	if manager := fieldManager(udata); manager != "" {
+		body["field_manager"] = manager
+	}
+
+	ul := unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{{Object: body}},
+	}
+
+	return unstructuredListToLogData(&ul, observedAt, config, version, func(attrs pcommon.Map) {
+		if name := udata.GetName(); name != "" {
+			attrs.PutStr("event.domain", "k8s")
+			attrs.PutStr("event.name", name)
+		}
+	}), nil
+}
+
 func pullObjectsToLogData(event *unstructured.UnstructuredList, observedAt time.Time, config *K8sObjectsConfig, version string) plog.Logs {
 	return unstructuredListToLogData(event, observedAt, config, version)
 }
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+var (
+	errTopicsRequired       = errors.New("topics is required")
+	errInvalidQoS           = errors.New("qos must be 0, 1 or 2")
+	errInvalidSharedGroup   = errors.New("shared_group must not contain '/', '+' or '#'")
+	errNegativeTopicLevel   = errors.New("topic_attributes::level must not be negative")
+	errTopicAttributeNoName = errors.New("topic_attributes::attribute is required")
+)
+
+// Config defines configuration for the MQTT receiver.
+type Config struct {
+	ClientConfig mqtt.ClientConfig `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be received.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be received.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be received.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds signal-specific configuration for the MQTT receiver.
+type SignalConfig struct {
+	// Topics are the topic filters subscribed to, which may use the + and # wildcards.
+	//
+	// The default depends on the signal type:
+	//  - "otlp/traces" for traces
+	//  - "otlp/metrics" for metrics
+	//  - "otlp/logs" for logs
+	Topics []string `mapstructure:"topics"`
+
+	// QoS is the maximum quality of service of the messages delivered by the broker,
+	// either 0, 1 or 2 (default 1).
+	QoS int `mapstructure:"qos"`
+
+	// SharedGroup subscribes to the topics with a shared subscription of the group, so
+	// that each message is delivered to only one of the collectors of the group.
+	SharedGroup string `mapstructure:"shared_group"`
+
+	// Encoding holds the encoding of the payloads for the signal type, either
+	// "otlp_proto", "otlp_json" or the ID of an encoding extension.
+	//
+	// Defaults to "otlp_proto".
+	Encoding string `mapstructure:"encoding"`
+
+	// TopicAttributes set resource attributes from the levels of the topics of the
+	// messages, overriding the attributes of the payloads.
+	TopicAttributes []TopicAttribute `mapstructure:"topic_attributes"`
+}
+
+// TopicAttribute maps a level of the topics to a resource attribute.
+type TopicAttribute struct {
+	// Level is the zero-based index of the level of the topic, e.g. 1 for "line1" in
+	// "factory/line1/sensor". The attribute is not set when the topic has fewer levels.
+	Level int `mapstructure:"level"`
+
+	// Attribute is the name of the resource attribute.
+	Attribute string `mapstructure:"attribute"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if err := c.Logs.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := c.Traces.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (c SignalConfig) Validate() error {
+	var errs []error
+	if len(c.Topics) == 0 {
+		errs = append(errs, errTopicsRequired)
+	}
+	for _, topic := range c.Topics {
+		if err := validateTopicFilter(topic); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c.QoS < 0 || c.QoS > 2 {
+		errs = append(errs, errInvalidQoS)
+	}
+	if strings.ContainsAny(c.SharedGroup, "/+#") {
+		errs = append(errs, errInvalidSharedGroup)
+	}
+	for _, attr := range c.TopicAttributes {
+		if attr.Level < 0 {
+			errs = append(errs, errNegativeTopicLevel)
+		}
+		if attr.Attribute == "" {
+			errs = append(errs, errTopicAttributeNoName)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateTopicFilter checks that the wildcards of the filter occupy entire levels,
+// and that the multi-level wildcard is the last level.
+func validateTopicFilter(filter string) error {
+	if filter == "" {
+		return errors.New("topics must not contain an empty topic filter")
+	}
+	if strings.HasPrefix(filter, "$share/") {
+		return fmt.Errorf("topic filter %q must not be a shared subscription, use shared_group instead", filter)
+	}
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		switch {
+		case level == "#" && i != len(levels)-1:
+			return fmt.Errorf("topic filter %q must only use the # wildcard as its last level", filter)
+		case len(level) > 1 && strings.ContainsAny(level, "+#"):
+			return fmt.Errorf("topic filter %q must use the + and # wildcards as entire levels", filter)
+		}
+	}
+	return nil
+}
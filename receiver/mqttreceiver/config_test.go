@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "mqtts://broker:8883"
+				cfg.ClientConfig.ProtocolVersion = "5"
+				cfg.ClientConfig.ClientID = "collector"
+				cfg.ClientConfig.CleanSession = false
+				cfg.ClientConfig.KeepAlive = time.Minute
+				cfg.ClientConfig.Username = "user"
+				cfg.ClientConfig.Password = "pass"
+				cfg.ClientConfig.TLS = &configtls.ClientConfig{Config: configtls.Config{CAFile: "ca.pem"}}
+				cfg.Logs = SignalConfig{
+					Topics:      []string{"factory/+/logs", "devices/#"},
+					QoS:         2,
+					SharedGroup: "collectors",
+					Encoding:    "otlp_json",
+					TopicAttributes: []TopicAttribute{
+						{Level: 1, Attribute: "factory.line"},
+					},
+				}
+				cfg.Metrics.QoS = 0
+				cfg.Traces.Encoding = "jaeger_encoding"
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_qos"),
+			expectedErr: "metrics::qos must be 0, 1 or 2",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_topics"),
+			expectedErr: `logs::topic filter "factory/#/logs" must only use the # wildcard as its last level` +
+				"\n" + `topic filter "factory/line+/logs" must use the + and # wildcards as entire levels` +
+				"\n" + `topic filter "$share/group/logs" must not be a shared subscription, use shared_group instead`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_topics"),
+			expectedErr: "traces::topics is required",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_shared_group"),
+			expectedErr: "logs::shared_group must not contain '/', '+' or '#'",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_topic_attributes"),
+			expectedErr: "logs::topic_attributes::level must not be negative\ntopic_attributes::attribute is required",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_protocol_version"),
+			expectedErr: `protocol_version must be either "3.1.1" or "5"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package mqttreceiver receives telemetry from the topics of an MQTT broker.
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
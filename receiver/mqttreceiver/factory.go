@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+const (
+	defaultEncoding     = "otlp_proto"
+	defaultQoS          = 1
+	defaultLogsTopic    = "otlp/logs"
+	defaultMetricsTopic = "otlp/metrics"
+	defaultTracesTopic  = "otlp/traces"
+)
+
+// NewFactory creates the MQTT receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: mqtt.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Topics:   []string{defaultLogsTopic},
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Topics:   []string{defaultMetricsTopic},
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Topics:   []string{defaultTracesTopic},
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	oCfg := cfg.(*Config)
+	return newMQTTReceiver(oCfg, set, "traces", oCfg.Traces, messaging.NewTracesHandler(nextConsumer))
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	oCfg := cfg.(*Config)
+	return newMQTTReceiver(oCfg, set, "metrics", oCfg.Metrics, messaging.NewMetricsHandler(nextConsumer))
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	oCfg := cfg.(*Config)
+	return newMQTTReceiver(oCfg, set, "logs", oCfg.Logs, messaging.NewLogsHandler(nextConsumer))
+}
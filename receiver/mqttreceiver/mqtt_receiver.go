@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+const transport = "mqtt"
+
+// mqttReceiver subscribes to the topics of a signal type, with a connection of its own.
+type mqttReceiver struct {
+	cfg        *Config
+	signalType string
+	signal     SignalConfig
+	logger     *zap.Logger
+	obsrecv    *receiverhelper.ObsReport
+	handler    messaging.SignalHandler
+
+	client mqtt.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newMQTTReceiver(cfg *Config, set receiver.Settings, signalType string, signal SignalConfig, handler messaging.SignalHandler) (*mqttReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mqttReceiver{
+		cfg:        cfg,
+		signalType: signalType,
+		signal:     signal,
+		logger:     set.Logger,
+		obsrecv:    obsrecv,
+		handler:    handler,
+	}, nil
+}
+
+func (r *mqttReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.handler.Start(host, r.signal.Encoding); err != nil {
+		return err
+	}
+
+	subscriptions := make([]mqtt.Subscription, 0, len(r.signal.Topics))
+	for _, topic := range r.signal.Topics {
+		if r.signal.SharedGroup != "" {
+			topic = "$share/" + r.signal.SharedGroup + "/" + topic
+		}
+		subscriptions = append(subscriptions, mqtt.Subscription{Filter: topic, QoS: byte(r.signal.QoS)})
+	}
+
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	client, err := mqtt.Connect(ctx, r.cfg.ClientConfig, host, r.logger, mqtt.ConnectOptions{
+		ClientIDSuffix: r.signalType,
+		Subscriptions:  subscriptions,
+		Handler:        r.handleMessage,
+	})
+	if err != nil {
+		return err
+	}
+	r.client = client
+	return nil
+}
+
+func (r *mqttReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.client != nil {
+		return r.client.Disconnect(ctx)
+	}
+	return nil
+}
+
+// handleMessage passes the data of the message to the next consumer. The message is
+// acknowledged to the broker once the handler returns, so data failing to be consumed
+// is dropped.
+func (r *mqttReceiver) handleMessage(msg mqtt.Message) {
+	ctx := contextWithMetadata(r.ctx, msg)
+	if err := r.handler.Handle(ctx, r.obsrecv, msg.Payload, r.topicAttributes(msg.Topic)); err != nil {
+		r.logger.Error("Dropping message", zap.String("topic", msg.Topic), zap.Error(err))
+	}
+}
+
+// topicAttributes returns the resource attributes mapped from the levels of the topic.
+func (r *mqttReceiver) topicAttributes(topic string) map[string]string {
+	if len(r.signal.TopicAttributes) == 0 {
+		return nil
+	}
+	levels := strings.Split(topic, "/")
+	attrs := make(map[string]string, len(r.signal.TopicAttributes))
+	for _, attr := range r.signal.TopicAttributes {
+		if attr.Level < len(levels) && levels[attr.Level] != "" {
+			attrs[attr.Attribute] = levels[attr.Level]
+		}
+	}
+	return attrs
+}
+
+// contextWithMetadata propagates the topic and QoS of the message as client metadata.
+func contextWithMetadata(ctx context.Context, msg mqtt.Message) context.Context {
+	return client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(map[string][]string{
+		"mqtt.topic": {msg.Topic},
+		"mqtt.qos":   {strconv.Itoa(int(msg.QoS))},
+	})})
+}
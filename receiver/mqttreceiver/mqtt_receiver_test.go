@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+var protocolVersions = []string{mqtt.ProtocolVersion311, mqtt.ProtocolVersion5}
+
+// runBroker starts an in-process broker, whose inline client publishes the messages
+// of the tests.
+func runBroker(t *testing.T) (*mochi.Server, string) {
+	broker := mochi.New(&mochi.Options{InlineClient: true, Logger: slog.New(slog.DiscardHandler)})
+	require.NoError(t, broker.AddHook(new(auth.AllowHook), nil))
+	tcp := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	require.NoError(t, broker.AddListener(tcp))
+	require.NoError(t, broker.Serve())
+	t.Cleanup(func() { _ = broker.Close() })
+	return broker, "tcp://" + tcp.Address()
+}
+
+// metadataSink records the client metadata of the logs it consumes.
+type metadataSink struct {
+	consumertest.LogsSink
+	mu    sync.Mutex
+	infos []client.Info
+}
+
+func (s *metadataSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	s.mu.Lock()
+	s.infos = append(s.infos, client.FromContext(ctx))
+	s.mu.Unlock()
+	return s.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+func (s *metadataSink) metadata(key string) [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make([][]string, 0, len(s.infos))
+	for _, info := range s.infos {
+		values = append(values, info.Metadata.Get(key))
+	}
+	return values
+}
+
+// startLogsReceiver starts a logs receiver connected to the broker.
+func startLogsReceiver(t *testing.T, endpoint, protocolVersion string, next consumer.Logs, configure func(*Config)) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = endpoint
+	cfg.ClientConfig.ProtocolVersion = protocolVersion
+	if configure != nil {
+		configure(cfg)
+	}
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+}
+
+func logsPayload(t *testing.T, body string) []byte {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	return data
+}
+
+func TestTopicAttributes(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+			sink := &metadataSink{}
+			startLogsReceiver(t, endpoint, version, sink, func(cfg *Config) {
+				cfg.Logs.Topics = []string{"factory/+/logs"}
+				cfg.Logs.TopicAttributes = []TopicAttribute{
+					{Level: 1, Attribute: "factory.line"},
+					{Level: 3, Attribute: "missing"},
+				}
+			})
+
+			require.NoError(t, broker.Publish("factory/line1/logs", logsPayload(t, "message"), false, 1))
+			// Messages of the topics not matching the filters are not received.
+			require.NoError(t, broker.Publish("factory/line1/status", logsPayload(t, "status"), false, 1))
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+			attrs := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().AsRaw()
+			assert.Equal(t, map[string]any{"factory.line": "line1"}, attrs)
+			assert.Equal(t, [][]string{{"factory/line1/logs"}}, sink.metadata("mqtt.topic"))
+		})
+	}
+}
+
+func TestQoS(t *testing.T) {
+	tests := []struct {
+		subscriptionQoS int
+		publishQoS      byte
+		expectedQoS     string
+	}{
+		{subscriptionQoS: 0, publishQoS: 2, expectedQoS: "0"},
+		{subscriptionQoS: 1, publishQoS: 2, expectedQoS: "1"},
+		{subscriptionQoS: 2, publishQoS: 2, expectedQoS: "2"},
+		{subscriptionQoS: 2, publishQoS: 0, expectedQoS: "0"},
+	}
+	for _, version := range protocolVersions {
+		for _, tt := range tests {
+			name := version + "/subscription_qos_" + strconv.Itoa(tt.subscriptionQoS) + "/publish_qos_" + strconv.Itoa(int(tt.publishQoS))
+			t.Run(name, func(t *testing.T) {
+				broker, endpoint := runBroker(t)
+				sink := &metadataSink{}
+				startLogsReceiver(t, endpoint, version, sink, func(cfg *Config) {
+					cfg.ClientConfig.ClientID = "collector"
+					cfg.Logs.QoS = tt.subscriptionQoS
+				})
+
+				// The subscription is made with the configured QoS.
+				cl, ok := broker.Clients.Get("collector-logs")
+				require.True(t, ok)
+				subscription, ok := cl.State.Subscriptions.Get(defaultLogsTopic)
+				require.True(t, ok)
+				assert.Equal(t, byte(tt.subscriptionQoS), subscription.Qos)
+
+				// Messages are delivered with the lowest QoS of the publication and the subscription.
+				require.NoError(t, broker.Publish(defaultLogsTopic, logsPayload(t, "message"), false, tt.publishQoS))
+				require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+				assert.Equal(t, [][]string{{tt.expectedQoS}}, sink.metadata("mqtt.qos"))
+			})
+		}
+	}
+}
+
+func TestRetainedMessage(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+
+			// The retained message is delivered when the receiver subscribes, the other
+			// messages published before are not.
+			require.NoError(t, broker.Publish(defaultLogsTopic, logsPayload(t, "not retained"), false, 1))
+			require.NoError(t, broker.Publish(defaultLogsTopic, logsPayload(t, "retained"), true, 1))
+
+			sink := &consumertest.LogsSink{}
+			startLogsReceiver(t, endpoint, version, sink, nil)
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+			assert.Never(t, func() bool { return sink.LogRecordCount() > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+			body := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str()
+			assert.Equal(t, "retained", body)
+		})
+	}
+}
+
+func TestSharedSubscription(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+
+			sinks := []*consumertest.LogsSink{{}, {}}
+			for _, sink := range sinks {
+				startLogsReceiver(t, endpoint, version, sink, func(cfg *Config) {
+					cfg.Logs.SharedGroup = "collectors"
+				})
+			}
+
+			for i := range 10 {
+				require.NoError(t, broker.Publish(defaultLogsTopic, logsPayload(t, strconv.Itoa(i)), false, 1))
+			}
+
+			// Each message is delivered to only one of the receivers of the group.
+			require.Eventually(t, func() bool {
+				return sinks[0].LogRecordCount()+sinks[1].LogRecordCount() == 10
+			}, 10*time.Second, 10*time.Millisecond)
+			assert.Never(t, func() bool {
+				return sinks[0].LogRecordCount()+sinks[1].LogRecordCount() > 10
+			}, 100*time.Millisecond, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestResubscribeOnReconnect(t *testing.T) {
+	for _, version := range protocolVersions {
+		t.Run(version, func(t *testing.T) {
+			broker, endpoint := runBroker(t)
+			sink := &consumertest.LogsSink{}
+			startLogsReceiver(t, endpoint, version, sink, func(cfg *Config) {
+				cfg.ClientConfig.ClientID = "collector"
+			})
+
+			cl, ok := broker.Clients.Get("collector-logs")
+			require.True(t, ok)
+			cl.Stop(errors.New("connection lost"))
+
+			// The session is clean, so the messages are only received once the receiver
+			// has reconnected and subscribed again.
+			require.Eventually(t, func() bool {
+				cl, ok := broker.Clients.Get("collector-logs")
+				return ok && !cl.Closed() && cl.State.Subscriptions.Len() == 1
+			}, 10*time.Second, 10*time.Millisecond)
+			require.NoError(t, broker.Publish(defaultLogsTopic, logsPayload(t, "message"), false, 1))
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestDropInvalidPayload(t *testing.T) {
+	broker, endpoint := runBroker(t)
+	sink := &consumertest.LogsSink{}
+	startLogsReceiver(t, endpoint, mqtt.ProtocolVersion311, sink, nil)
+
+	// The invalid payload is dropped, and the following messages still received.
+	require.NoError(t, broker.Publish(defaultLogsTopic, []byte{0x0a, 0xff}, false, 1))
+	require.NoError(t, broker.Publish(defaultLogsTopic, logsPayload(t, "message"), false, 1))
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Len(t, sink.AllLogs(), 1)
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+)
+
+var (
+	errStreamRequired          = errors.New("stream is required")
+	errDurableRequired         = errors.New("durable is required")
+	errInvalidDurable          = errors.New("durable must not contain whitespaces, '.', '*' or '>'")
+	errNegativeAckWait         = errors.New("consumer::ack_wait must not be negative")
+	errNegativeRedeliveryDelay = errors.New("consumer::redelivery_delay must not be negative")
+)
+
+// deliverPolicies maps the supported deliver policies to their JetStream values.
+var deliverPolicies = map[string]jetstream.DeliverPolicy{
+	"all":              jetstream.DeliverAllPolicy,
+	"last":             jetstream.DeliverLastPolicy,
+	"new":              jetstream.DeliverNewPolicy,
+	"last_per_subject": jetstream.DeliverLastPerSubjectPolicy,
+}
+
+// Config defines configuration for the NATS JetStream receiver.
+type Config struct {
+	ClientConfig natsjetstream.ClientConfig `mapstructure:",squash"`
+
+	// Consumer holds the settings of the durable consumers, shared by the signal types.
+	Consumer ConsumerConfig `mapstructure:"consumer"`
+
+	// Logs holds configuration about how logs should be consumed.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be consumed.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be consumed.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds signal-specific configuration for the NATS JetStream receiver.
+type SignalConfig struct {
+	// Stream is the name of the JetStream stream the messages are read from.
+	//
+	// Defaults to "OTLP".
+	Stream string `mapstructure:"stream"`
+
+	// Subjects filter the messages of the stream read by the consumer. All the
+	// messages of the stream are read when empty.
+	//
+	// The default depends on the signal type:
+	//  - "otlp.traces" for traces
+	//  - "otlp.metrics" for metrics
+	//  - "otlp.logs" for logs
+	Subjects []string `mapstructure:"subjects"`
+
+	// Durable is the name of the durable consumer, which is created on the stream
+	// if it doesn't exist, or updated otherwise.
+	//
+	// The default depends on the signal type:
+	//  - "otelcol_traces" for traces
+	//  - "otelcol_metrics" for metrics
+	//  - "otelcol_logs" for logs
+	Durable string `mapstructure:"durable"`
+
+	// Encoding holds the encoding of messages for the signal type, either "otlp_proto",
+	// "otlp_json" or the ID of an encoding extension.
+	//
+	// Defaults to "otlp_proto".
+	Encoding string `mapstructure:"encoding"`
+}
+
+// ConsumerConfig holds the settings of the durable consumers.
+type ConsumerConfig struct {
+	// DeliverPolicy is where the consumers start reading the stream when they are
+	// created: "all", "last", "new" or "last_per_subject" (default "all").
+	DeliverPolicy string `mapstructure:"deliver_policy"`
+
+	// AckWait is the time the server waits for the acknowledgement of a message
+	// before delivering it again (default 30s).
+	AckWait time.Duration `mapstructure:"ack_wait"`
+
+	// MaxDeliver is the maximum number of deliveries of a message. A negative
+	// value delivers messages until they are acknowledged (default -1).
+	MaxDeliver int `mapstructure:"max_deliver"`
+
+	// MaxAckPending is the maximum number of messages delivered and not yet
+	// acknowledged (default 1000).
+	MaxAckPending int `mapstructure:"max_ack_pending"`
+
+	// RedeliveryDelay is the time waited before a message whose data failed to be
+	// consumed is delivered again (default 1s).
+	RedeliveryDelay time.Duration `mapstructure:"redelivery_delay"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if err := c.Consumer.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Logs.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := c.Traces.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (c ConsumerConfig) Validate() error {
+	var errs []error
+	if _, ok := deliverPolicies[c.DeliverPolicy]; !ok {
+		errs = append(errs, fmt.Errorf("consumer::deliver_policy %q is invalid, must be one of all, last, new or last_per_subject", c.DeliverPolicy))
+	}
+	if c.AckWait < 0 {
+		errs = append(errs, errNegativeAckWait)
+	}
+	if c.RedeliveryDelay < 0 {
+		errs = append(errs, errNegativeRedeliveryDelay)
+	}
+	return errors.Join(errs...)
+}
+
+func (c SignalConfig) Validate() error {
+	switch {
+	case c.Stream == "":
+		return errStreamRequired
+	case c.Durable == "":
+		return errDurableRequired
+	case strings.ContainsAny(c.Durable, " \t\r\n.*>"):
+		return errInvalidDurable
+	}
+	return nil
+}
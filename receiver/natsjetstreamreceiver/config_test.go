@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "nats://nats:4222"
+				cfg.ClientConfig.Name = "collector"
+				cfg.ClientConfig.Auth.Username = "user"
+				cfg.ClientConfig.Auth.Password = "pass"
+				cfg.Consumer = ConsumerConfig{
+					DeliverPolicy:   "new",
+					AckWait:         10 * time.Second,
+					MaxDeliver:      5,
+					MaxAckPending:   100,
+					RedeliveryDelay: 5 * time.Second,
+				}
+				cfg.Logs = SignalConfig{
+					Stream:   "LOGS",
+					Subjects: []string{"logs.>"},
+					Durable:  "collector_logs",
+					Encoding: "otlp_json",
+				}
+				cfg.Metrics.Stream = "METRICS"
+				cfg.Metrics.Subjects = []string{}
+				cfg.Traces.Encoding = "jaeger_encoding"
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_deliver_policy"),
+			expectedErr: `consumer::deliver_policy "first" is invalid, must be one of all, last, new or last_per_subject`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_durable"),
+			expectedErr: "traces::durable must not contain whitespaces, '.', '*' or '>'",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_stream"),
+			expectedErr: "metrics::stream is required",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "negative_durations"),
+			expectedErr: "consumer::ack_wait must not be negative\nconsumer::redelivery_delay must not be negative",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			expectedErr: "endpoint is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package natsjetstreamreceiver receives telemetry from NATS JetStream.
+package natsjetstreamreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver"
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver/internal/metadata"
+)
+
+const (
+	defaultStream          = "OTLP"
+	defaultEncoding        = "otlp_proto"
+	defaultLogsSubject     = "otlp.logs"
+	defaultMetricsSubject  = "otlp.metrics"
+	defaultTracesSubject   = "otlp.traces"
+	defaultLogsDurable     = "otelcol_logs"
+	defaultMetricsDurable  = "otelcol_metrics"
+	defaultTracesDurable   = "otelcol_traces"
+	defaultDeliverPolicy   = "all"
+	defaultAckWait         = 30 * time.Second
+	defaultMaxAckPending   = 1000
+	defaultRedeliveryDelay = time.Second
+)
+
+// NewFactory creates the NATS JetStream receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: natsjetstream.NewDefaultClientConfig(),
+		Consumer: ConsumerConfig{
+			DeliverPolicy:   defaultDeliverPolicy,
+			AckWait:         defaultAckWait,
+			MaxDeliver:      -1,
+			MaxAckPending:   defaultMaxAckPending,
+			RedeliveryDelay: defaultRedeliveryDelay,
+		},
+		Logs: SignalConfig{
+			Stream:   defaultStream,
+			Subjects: []string{defaultLogsSubject},
+			Durable:  defaultLogsDurable,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Stream:   defaultStream,
+			Subjects: []string{defaultMetricsSubject},
+			Durable:  defaultMetricsDurable,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Stream:   defaultStream,
+			Subjects: []string{defaultTracesSubject},
+			Durable:  defaultTracesDurable,
+			Encoding: defaultEncoding,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	oCfg := cfg.(*Config)
+	return newNATSReceiver(oCfg, set, oCfg.Traces, messaging.NewTracesHandler(nextConsumer))
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	oCfg := cfg.(*Config)
+	return newNATSReceiver(oCfg, set, oCfg.Metrics, messaging.NewMetricsHandler(nextConsumer))
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	oCfg := cfg.(*Config)
+	return newNATSReceiver(oCfg, set, oCfg.Logs, messaging.NewLogsHandler(nextConsumer))
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsjetstream"
+)
+
+const transport = "nats"
+
+// natsReceiver reads the messages of a signal type from a durable consumer, and
+// acknowledges them once their data has been consumed.
+type natsReceiver struct {
+	cfg     *Config
+	signal  SignalConfig
+	logger  *zap.Logger
+	obsrecv *receiverhelper.ObsReport
+	handler messaging.SignalHandler
+
+	conn       *nats.Conn
+	consumeCtx jetstream.ConsumeContext
+	cancel     context.CancelFunc
+}
+
+func newNATSReceiver(cfg *Config, set receiver.Settings, signal SignalConfig, handler messaging.SignalHandler) (*natsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &natsReceiver{
+		cfg:     cfg,
+		signal:  signal,
+		logger:  set.Logger,
+		obsrecv: obsrecv,
+		handler: handler,
+	}, nil
+}
+
+func (r *natsReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.handler.Start(host, r.signal.Encoding); err != nil {
+		return err
+	}
+	conn, js, err := natsjetstream.Connect(ctx, r.cfg.ClientConfig, r.logger)
+	if err != nil {
+		return err
+	}
+	r.conn = conn
+
+	cons, err := js.CreateOrUpdateConsumer(ctx, r.signal.Stream, jetstream.ConsumerConfig{
+		Durable:        r.signal.Durable,
+		FilterSubjects: r.signal.Subjects,
+		DeliverPolicy:  deliverPolicies[r.cfg.Consumer.DeliverPolicy],
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        r.cfg.Consumer.AckWait,
+		MaxDeliver:     r.cfg.Consumer.MaxDeliver,
+		MaxAckPending:  r.cfg.Consumer.MaxAckPending,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer %q on stream %q: %w", r.signal.Durable, r.signal.Stream, err)
+	}
+
+	var consumeCtx context.Context
+	consumeCtx, r.cancel = context.WithCancel(context.Background())
+	r.consumeCtx, err = cons.Consume(
+		func(msg jetstream.Msg) {
+			r.handleMessage(consumeCtx, msg)
+		},
+		jetstream.ConsumeErrHandler(func(_ jetstream.ConsumeContext, err error) {
+			r.logger.Warn("Error consuming messages", zap.String("stream", r.signal.Stream), zap.Error(err))
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to consume messages of consumer %q: %w", r.signal.Durable, err)
+	}
+	return nil
+}
+
+func (r *natsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.consumeCtx != nil {
+		// The messages not acknowledged yet are delivered again by the server.
+		r.consumeCtx.Stop()
+		select {
+		case <-r.consumeCtx.Closed():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if r.conn != nil {
+		r.conn.Close()
+	}
+	return nil
+}
+
+// handleMessage acknowledges the message once its data has been consumed. Messages
+// whose data failed to be consumed are delivered again, unless the error is permanent.
+func (r *natsReceiver) handleMessage(ctx context.Context, msg jetstream.Msg) {
+	ctx = contextWithMetadata(ctx, msg)
+	err := r.handler.Handle(ctx, r.obsrecv, msg.Data(), nil)
+	switch {
+	case err == nil:
+		if ackErr := msg.Ack(); ackErr != nil {
+			r.logger.Warn("Failed to acknowledge message", zap.String("subject", msg.Subject()), zap.Error(ackErr))
+		}
+	case consumererror.IsPermanent(err):
+		r.logger.Error("Dropping message", zap.String("subject", msg.Subject()), zap.Error(err))
+		if termErr := msg.Term(); termErr != nil {
+			r.logger.Warn("Failed to terminate message", zap.String("subject", msg.Subject()), zap.Error(termErr))
+		}
+	default:
+		r.logger.Warn("Failed to consume message, it will be delivered again", zap.String("subject", msg.Subject()), zap.Error(err))
+		if nakErr := msg.NakWithDelay(r.cfg.Consumer.RedeliveryDelay); nakErr != nil {
+			r.logger.Warn("Failed to negatively acknowledge message", zap.String("subject", msg.Subject()), zap.Error(nakErr))
+		}
+	}
+}
+
+// contextWithMetadata propagates the headers of the message, along with its subject,
+// stream and stream sequence, as client metadata.
+func contextWithMetadata(ctx context.Context, msg jetstream.Msg) context.Context {
+	m := map[string][]string{
+		"nats.subject": {msg.Subject()},
+	}
+	if metadata, err := msg.Metadata(); err == nil {
+		m["nats.stream"] = []string{metadata.Stream}
+		m["nats.sequence"] = []string{strconv.FormatUint(metadata.Sequence.Stream, 10)}
+	}
+	for key, values := range msg.Headers() {
+		m[key] = append(m[key], values...)
+	}
+	return client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(m)})
+}
@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsjetstreamreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsjetstreamreceiver/internal/metadata"
+)
+
+// runServer starts an embedded NATS server with JetStream enabled, and creates
+// the stream the receiver reads from by default.
+func runServer(t *testing.T) (*server.Server, jetstream.JetStream) {
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		NoLog:     true,
+		NoSigs:    true,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	require.True(t, srv.ReadyForConnections(10*time.Second))
+	t.Cleanup(srv.Shutdown)
+
+	conn, err := nats.Connect(srv.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	_, err = js.CreateStream(t.Context(), jetstream.StreamConfig{
+		Name:     defaultStream,
+		Subjects: []string{"otlp.>"},
+	})
+	require.NoError(t, err)
+	return srv, js
+}
+
+func newTestConfig(srv *server.Server) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = srv.ClientURL()
+	cfg.Consumer.RedeliveryDelay = 10 * time.Millisecond
+	return cfg
+}
+
+func startReceiver(t *testing.T, rcvr component.Component) {
+	require.NoError(t, rcvr.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+}
+
+func publish(t *testing.T, js jetstream.JetStream, subject string, data []byte, header nats.Header) {
+	_, err := js.PublishMsg(t.Context(), &nats.Msg{Subject: subject, Data: data, Header: header})
+	require.NoError(t, err)
+}
+
+// requireAcknowledged waits for all the messages of the stream to be acknowledged
+// by the consumer.
+func requireAcknowledged(t *testing.T, js jetstream.JetStream, durable string, count uint64) {
+	cons, err := js.Consumer(t.Context(), defaultStream, durable)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		info, err := cons.Info(t.Context())
+		return err == nil && info.AckFloor.Stream == count && info.NumAckPending == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func testLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "test")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message")
+	return logs
+}
+
+func TestReceiveLogs(t *testing.T) {
+	srv, js := runServer(t)
+
+	var mu sync.Mutex
+	var infos []client.Info
+	sink := &consumertest.LogsSink{}
+	next, err := consumer.NewLogs(func(ctx context.Context, logs plog.Logs) error {
+		mu.Lock()
+		infos = append(infos, client.FromContext(ctx))
+		mu.Unlock()
+		return sink.ConsumeLogs(ctx, logs)
+	})
+	require.NoError(t, err)
+
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), next)
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs())
+	require.NoError(t, err)
+	publish(t, js, defaultLogsSubject, data, nats.Header{"X-Tenant": []string{"acme"}})
+	// Messages of other subjects are not read by the logs consumer.
+	publish(t, js, defaultTracesSubject, []byte("traces"), nil)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, testLogs(), sink.AllLogs()[0])
+	requireAcknowledged(t, js, defaultLogsDurable, 1)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, infos, 1)
+	assert.Equal(t, []string{defaultLogsSubject}, infos[0].Metadata.Get("nats.subject"))
+	assert.Equal(t, []string{defaultStream}, infos[0].Metadata.Get("nats.stream"))
+	assert.Equal(t, []string{"1"}, infos[0].Metadata.Get("nats.sequence"))
+	assert.Equal(t, []string{"acme"}, infos[0].Metadata.Get("X-Tenant"))
+}
+
+func TestReceiveMetrics(t *testing.T) {
+	srv, js := runServer(t)
+
+	sink := &consumertest.MetricsSink{}
+	cfg := newTestConfig(srv)
+	cfg.Metrics.Encoding = "otlp_json"
+	rcvr, err := createMetricsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	metrics := pmetric.NewMetrics()
+	m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+	publish(t, js, defaultMetricsSubject, data, nil)
+
+	require.Eventually(t, func() bool { return sink.DataPointCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, metrics, sink.AllMetrics()[0])
+	requireAcknowledged(t, js, defaultMetricsDurable, 1)
+}
+
+func TestReceiveTraces(t *testing.T) {
+	srv, js := runServer(t)
+
+	sink := &consumertest.TracesSink{}
+	rcvr, err := createTracesReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), sink)
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+	publish(t, js, defaultTracesSubject, data, nil)
+
+	require.Eventually(t, func() bool { return sink.SpanCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, traces, sink.AllTraces()[0])
+	requireAcknowledged(t, js, defaultTracesDurable, 1)
+}
+
+func TestRedeliveryOnError(t *testing.T) {
+	srv, js := runServer(t)
+
+	var mu sync.Mutex
+	calls := 0
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			return errors.New("pipeline unavailable")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), next)
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs())
+	require.NoError(t, err)
+	publish(t, js, defaultLogsSubject, data, nil)
+
+	requireAcknowledged(t, js, defaultLogsDurable, 1)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, calls)
+}
+
+func TestTerminateOnPermanentError(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		next consumer.Logs
+	}{
+		{
+			name: "unmarshal error",
+			data: []byte{0x0a, 0xff},
+			next: consumertest.NewNop(),
+		},
+		{
+			name: "permanent consumer error",
+			data: func() []byte {
+				data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs())
+				require.NoError(t, err)
+				return data
+			}(),
+			next: consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid logs"))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, js := runServer(t)
+
+			rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), tt.next)
+			require.NoError(t, err)
+			startReceiver(t, rcvr)
+
+			publish(t, js, defaultLogsSubject, tt.data, nil)
+
+			// Terminated messages are acknowledged, and never delivered again.
+			requireAcknowledged(t, js, defaultLogsDurable, 1)
+			cons, err := js.Consumer(t.Context(), defaultStream, defaultLogsDurable)
+			require.NoError(t, err)
+			info, err := cons.Info(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 0, info.NumRedelivered)
+		})
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	srv, _ := runServer(t)
+
+	cfg := newTestConfig(srv)
+	cfg.Logs.Stream = "MISSING"
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	err = rcvr.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `failed to create consumer "otelcol_logs" on stream "MISSING"`)
+	assert.NoError(t, rcvr.Shutdown(t.Context()))
+
+	cfg = newTestConfig(srv)
+	cfg.Logs.Encoding = "unknown"
+	rcvr, err = createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	err = rcvr.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, messaging.ErrUnknownEncodingExtension)
+	assert.NoError(t, rcvr.Shutdown(t.Context()))
+}
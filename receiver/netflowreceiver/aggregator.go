@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+// flowAggregator rolls flows up into byte, packet and flow counts per group of attributes
+type flowAggregator struct {
+	groupBy []string
+	fields  []FieldConfig
+	// groupByFields is set when flows are grouped by fields, which are then decoded for every flow
+	groupByFields bool
+
+	mu     sync.Mutex
+	start  time.Time
+	groups map[string]*flowGroup
+}
+
+type flowGroup struct {
+	attrs   pcommon.Map
+	bytes   int64
+	packets int64
+	flows   int64
+}
+
+func newFlowAggregator(cfg AggregationConfig, fields []FieldConfig, now time.Time) *flowAggregator {
+	a := &flowAggregator{
+		groupBy: cfg.GroupBy,
+		fields:  fields,
+		start:   now,
+		groups:  map[string]*flowGroup{},
+	}
+	for _, key := range cfg.GroupBy {
+		if !groupByKeys[key] {
+			a.groupByFields = true
+		}
+	}
+	return a
+}
+
+// add accounts the flow to its group. Bytes and packets are scaled by the sampling rate of the flow.
+func (a *flowAggregator) add(pm *protoproducer.ProtoProducerMessage) {
+	attrs := pcommon.NewMap()
+	fieldAttrs := pcommon.NewMap()
+	if a.groupByFields {
+		putFieldAttributes(pm, a.fields, fieldAttrs)
+	}
+
+	var key strings.Builder
+	for _, k := range a.groupBy {
+		if !putFlowAttribute(pm, k, attrs) {
+			if v, ok := fieldAttrs.Get(k); ok {
+				v.CopyTo(attrs.PutEmpty(k))
+			}
+		}
+		if v, ok := attrs.Get(k); ok {
+			key.WriteString(v.AsString())
+		}
+		key.WriteByte(0)
+	}
+
+	samplingRate := max(pm.SamplingRate, 1)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	group, ok := a.groups[key.String()]
+	if !ok {
+		group = &flowGroup{attrs: attrs}
+		a.groups[key.String()] = group
+	}
+	group.bytes += int64(pm.Bytes * samplingRate)
+	group.packets += int64(pm.Packets * samplingRate)
+	group.flows++
+}
+
+// flush returns the delta metrics of the flows added since the last flush, and false if there were none.
+func (a *flowAggregator) flush(now time.Time) (pmetric.Metrics, bool) {
+	a.mu.Lock()
+	groups, start := a.groups, a.start
+	a.groups, a.start = map[string]*flowGroup{}, now
+	a.mu.Unlock()
+
+	if len(groups) == 0 {
+		return pmetric.Metrics{}, false
+	}
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(metadata.ScopeName)
+	bytes := newDeltaSum(sm.Metrics(), "flow.io.bytes", "By", "Bytes transferred by the flows.")
+	packets := newDeltaSum(sm.Metrics(), "flow.io.packets", "{packet}", "Packets transferred by the flows.")
+	flows := newDeltaSum(sm.Metrics(), "flow.count", "{flow}", "Number of flows received.")
+	for _, group := range groups {
+		for _, v := range []struct {
+			dps   pmetric.NumberDataPointSlice
+			value int64
+		}{{bytes, group.bytes}, {packets, group.packets}, {flows, group.flows}} {
+			dp := v.dps.AppendEmpty()
+			group.attrs.CopyTo(dp.Attributes())
+			dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+			dp.SetIntValue(v.value)
+		}
+	}
+	return md, true
+}
+
+func newDeltaSum(metrics pmetric.MetricSlice, name, unit, description string) pmetric.NumberDataPointSlice {
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetUnit(unit)
+	m.SetDescription(description)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	return sum.DataPoints()
+}
+
+// putFlowAttribute adds the flow attribute named key to attrs the same way log records have it,
+// and returns false if key is not a flow attribute.
+func putFlowAttribute(pm *protoproducer.ProtoProducerMessage, key string, attrs pcommon.Map) bool {
+	switch key {
+	case string(conventions.SourceAddressKey):
+		addr, _ := netip.AddrFromSlice(pm.SrcAddr)
+		attrs.PutStr(key, addr.String())
+	case string(conventions.SourcePortKey):
+		attrs.PutInt(key, int64(pm.SrcPort))
+	case string(conventions.DestinationAddressKey):
+		addr, _ := netip.AddrFromSlice(pm.DstAddr)
+		attrs.PutStr(key, addr.String())
+	case string(conventions.DestinationPortKey):
+		attrs.PutInt(key, int64(pm.DstPort))
+	case string(conventions.NetworkTransportKey):
+		attrs.PutStr(key, getTransportName(pm.Proto))
+	case string(conventions.NetworkTypeKey):
+		attrs.PutStr(key, getEtypeName(pm.Etype))
+	case "flow.sampler_address":
+		addr, _ := netip.AddrFromSlice(pm.SamplerAddress)
+		attrs.PutStr(key, addr.String())
+	case "flow.in_if":
+		attrs.PutInt(key, int64(pm.InIf))
+	case "flow.out_if":
+		attrs.PutInt(key, int64(pm.OutIf))
+	case "flow.src_as":
+		attrs.PutInt(key, int64(pm.SrcAs))
+	case "flow.dst_as":
+		attrs.PutInt(key, int64(pm.DstAs))
+	case "flow.type":
+		attrs.PutStr(key, getFlowTypeName(int32(pm.Type)))
+	case "flow.observation_domain_id":
+		attrs.PutInt(key, int64(pm.ObservationDomainId))
+	default:
+		return false
+	}
+	return true
+}
+
+// otelMetricsProducerWrapper is a wrapper around a producer.ProducerInterface that aggregates the messages
+type otelMetricsProducerWrapper struct {
+	wrapped    producer.ProducerInterface
+	aggregator *flowAggregator
+	logger     *zap.Logger
+}
+
+// Produce adds the flow messages to the aggregator
+func (o *otelMetricsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) ([]producer.ProducerMessage, error) {
+	defer func() {
+		if pErr := recover(); pErr != nil {
+			errMessage, _ := pErr.(string)
+			o.logger.Error("unexpected error processing the message", zap.String("error", errMessage))
+		}
+	}()
+
+	flowMessageSet, err := o.wrapped.Produce(msg, args)
+	if err != nil {
+		return flowMessageSet, err
+	}
+
+	for _, m := range flowMessageSet {
+		if pm, ok := m.(*protoproducer.ProtoProducerMessage); ok {
+			o.aggregator.add(pm)
+		}
+	}
+
+	return flowMessageSet, nil
+}
+
+func (o *otelMetricsProducerWrapper) Close() {
+	o.wrapped.Close()
+}
+
+func (o *otelMetricsProducerWrapper) Commit(flowMessageSet []producer.ProducerMessage) {
+	o.wrapped.Commit(flowMessageSet)
+}
+
+func newOtelMetricsProducer(wrapped producer.ProducerInterface, aggregator *flowAggregator, logger *zap.Logger) producer.ProducerInterface {
+	return &otelMetricsProducerWrapper{
+		wrapped:    wrapped,
+		aggregator: aggregator,
+		logger:     logger,
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+func testFlow(src, dst []byte, dstPort uint32, bytes, packets, samplingRate uint64) *protoproducer.ProtoProducerMessage {
+	pm := &protoproducer.ProtoProducerMessage{}
+	pm.SrcAddr = src
+	pm.DstAddr = dst
+	pm.SrcPort = 40000
+	pm.DstPort = dstPort
+	pm.Proto = 6
+	pm.Bytes = bytes
+	pm.Packets = packets
+	pm.SamplingRate = samplingRate
+	return pm
+}
+
+// sumsByGroup returns the value of each metric per value of the group attribute
+func sumsByGroup(t *testing.T, md pmetric.Metrics, group string) map[string]map[string]int64 {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	sm := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, metadata.ScopeName, sm.Scope().Name())
+	sums := map[string]map[string]int64{}
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		m := sm.Metrics().At(i)
+		require.Equal(t, pmetric.MetricTypeSum, m.Type())
+		assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+		assert.True(t, m.Sum().IsMonotonic())
+		sums[m.Name()] = map[string]int64{}
+		for j := 0; j < m.Sum().DataPoints().Len(); j++ {
+			dp := m.Sum().DataPoints().At(j)
+			v, ok := dp.Attributes().Get(group)
+			require.True(t, ok)
+			sums[m.Name()][v.AsString()] = dp.IntValue()
+		}
+	}
+	return sums
+}
+
+func TestFlowAggregator(t *testing.T) {
+	start := time.Unix(100, 0)
+	a := newFlowAggregator(AggregationConfig{Interval: time.Minute, GroupBy: []string{"destination.port"}}, nil, start)
+
+	a.add(testFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 443, 1000, 10, 0))
+	a.add(testFlow([]byte{10, 0, 0, 3}, []byte{10, 0, 0, 4}, 443, 500, 5, 1))
+	a.add(testFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 53, 100, 1, 10))
+
+	end := start.Add(time.Minute)
+	md, ok := a.flush(end)
+	require.True(t, ok)
+	assert.Equal(t, map[string]map[string]int64{
+		"flow.io.bytes":   {"443": 1500, "53": 1000},
+		"flow.io.packets": {"443": 15, "53": 10},
+		"flow.count":      {"443": 2, "53": 1},
+	}, sumsByGroup(t, md, "destination.port"))
+
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, 1, dp.Attributes().Len())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(end), dp.Timestamp())
+
+	_, ok = a.flush(end.Add(time.Minute))
+	assert.False(t, ok)
+
+	// The next interval starts at the previous flush
+	a.add(testFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 53, 100, 1, 0))
+	md, ok = a.flush(end.Add(2 * time.Minute))
+	require.True(t, ok)
+	dp = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(end.Add(time.Minute)), dp.StartTimestamp())
+}
+
+func TestFlowAggregatorGroupByFields(t *testing.T) {
+	a := newFlowAggregator(AggregationConfig{GroupBy: []string{"source.address", "acme.tenant_id"}}, testFields, time.Unix(100, 0))
+	protoProducer := newTestProtoProducer(t, testFields)
+	otelMetricsProducer := newOtelMetricsProducer(protoProducer, a, zap.NewNop())
+
+	tenant := func(id byte) netflow.DataField {
+		return netflow.DataField{PenProvided: true, Pen: 99999, Type: 12, Value: []byte{0x00, id}}
+	}
+	message := ipfixPacket(
+		ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 80, 81, 100, 2, tenant(1)),
+		ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 3}, 80, 82, 50, 1, tenant(1)),
+		ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 80, 81, 10, 1, tenant(2)),
+		ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 80, 81, 1, 1),
+	)
+	_, err := otelMetricsProducer.Produce(message, &producer.ProduceArgs{})
+	require.NoError(t, err)
+
+	md, ok := a.flush(time.Unix(160, 0))
+	require.True(t, ok)
+	bytes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "flow.io.bytes", bytes.Name())
+	got := map[int64]int64{}
+	for i := 0; i < bytes.Sum().DataPoints().Len(); i++ {
+		attrs := bytes.Sum().DataPoints().At(i).Attributes()
+		src, _ := attrs.Get("source.address")
+		assert.Equal(t, "10.0.0.1", src.Str())
+		var id int64
+		if v, ok := attrs.Get("acme.tenant_id"); ok {
+			id = v.Int()
+		}
+		got[id] = bytes.Sum().DataPoints().At(i).IntValue()
+	}
+	// Flows without the field are grouped together
+	assert.Equal(t, map[int64]int64{1: 150, 2: 10, 0: 1}, got)
+}
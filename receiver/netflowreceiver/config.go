@@ -5,12 +5,38 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
+const (
+	fieldTypeString = "string"
+	fieldTypeBytes  = "bytes"
+	fieldTypeInt    = "int"
+	fieldTypeIP     = "ip"
+)
+
+// groupByKeys are the flow attributes that flows can be aggregated by, in addition to custom fields.
+var groupByKeys = map[string]bool{
+	"source.address":             true,
+	"source.port":                true,
+	"destination.address":        true,
+	"destination.port":           true,
+	"network.transport":          true,
+	"network.type":               true,
+	"flow.sampler_address":       true,
+	"flow.in_if":                 true,
+	"flow.out_if":                true,
+	"flow.src_as":                true,
+	"flow.dst_as":                true,
+	"flow.type":                  true,
+	"flow.observation_domain_id": true,
+}
+
 // Config represents the receiver config settings within the collector's config.yaml
 type Config struct {
 	// The scheme defines the type of flow data that the listener will receive
-	// The scheme must be one of sflow, netflow, or flow
+	// The scheme must be one of sflow, netflow, or ipfix
 	Scheme string `mapstructure:"scheme"`
 
 	// The hostname or IP address that the listener will bind to
@@ -33,11 +59,43 @@ type Config struct {
 
 	// SendRaw determines whether to send raw flow messages instead of parsing them
 	SendRaw bool `mapstructure:"send_raw"`
+
+	// Fields is a dictionary of NetFlow v9 and IPFIX information elements, including enterprise-specific
+	// ones, that are decoded from templates and added as attributes.
+	Fields []FieldConfig `mapstructure:"fields"`
+
+	// Aggregation configures how flows are rolled up into metrics when the receiver is part of a metrics pipeline
+	Aggregation AggregationConfig `mapstructure:"aggregation"`
+}
+
+// FieldConfig maps a template information element to an attribute
+type FieldConfig struct {
+	// Name is the name of the attribute the value of the information element is added as
+	Name string `mapstructure:"name"`
+
+	// ElementID is the ID of the information element (the field type of NetFlow v9)
+	ElementID uint16 `mapstructure:"element_id"`
+
+	// EnterpriseNumber is the private enterprise number of an enterprise-specific information element.
+	// It is 0 for information elements registered by IANA.
+	EnterpriseNumber uint32 `mapstructure:"enterprise_number"`
+
+	// Type is how the value is decoded, one of string, bytes (hex encoded), int or ip
+	Type string `mapstructure:"type"`
+}
+
+// AggregationConfig configures the aggregation of flows into metrics
+type AggregationConfig struct {
+	// Interval is the interval flows are aggregated over
+	Interval time.Duration `mapstructure:"interval"`
+
+	// GroupBy are the attributes flows are aggregated by
+	GroupBy []string `mapstructure:"group_by"`
 }
 
 // Validate checks if the receiver configuration is valid
 func (cfg *Config) Validate() error {
-	validSchemes := [2]string{"sflow", "netflow"}
+	validSchemes := [3]string{"sflow", "netflow", "ipfix"}
 
 	validScheme := false
 	for _, scheme := range validSchemes {
@@ -47,7 +105,7 @@ func (cfg *Config) Validate() error {
 		}
 	}
 	if !validScheme {
-		return errors.New("scheme must be netflow or sflow")
+		return errors.New("scheme must be netflow, ipfix or sflow")
 	}
 
 	if cfg.Sockets <= 0 {
@@ -66,5 +124,50 @@ func (cfg *Config) Validate() error {
 		return errors.New("port must be greater than 0")
 	}
 
+	if len(cfg.Fields) > 0 && cfg.Scheme == "sflow" {
+		return errors.New("fields are only supported by the netflow and ipfix schemes")
+	}
+
+	fieldNames := map[string]bool{}
+	for _, field := range cfg.Fields {
+		if err := field.validate(); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		if fieldNames[field.Name] {
+			return fmt.Errorf("field %q is defined more than once", field.Name)
+		}
+		fieldNames[field.Name] = true
+	}
+
+	if cfg.Aggregation.Interval <= 0 {
+		return errors.New("aggregation interval must be greater than 0")
+	}
+
+	groupBy := map[string]bool{}
+	for _, key := range cfg.Aggregation.GroupBy {
+		if !groupByKeys[key] && !fieldNames[key] {
+			return fmt.Errorf("aggregation cannot group by %q: it is neither a flow attribute nor a field", key)
+		}
+		if groupBy[key] {
+			return fmt.Errorf("aggregation groups by %q more than once", key)
+		}
+		groupBy[key] = true
+	}
+
+	return nil
+}
+
+func (f *FieldConfig) validate() error {
+	if f.Name == "" {
+		return errors.New("name must be specified")
+	}
+	if f.ElementID == 0 {
+		return errors.New("element_id must be greater than 0")
+	}
+	switch f.Type {
+	case fieldTypeString, fieldTypeBytes, fieldTypeInt, fieldTypeIP:
+	default:
+		return errors.New("type must be string, bytes, int or ip")
+	}
 	return nil
 }
@@ -6,6 +6,7 @@ package netflowreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,10 @@ func TestLoadConfig(t *testing.T) {
 				Sockets:   1,
 				Workers:   1,
 				QueueSize: 1000,
+				Aggregation: AggregationConfig{
+					Interval: defaultAggregationInterval,
+					GroupBy:  defaultGroupBy,
+				},
 			},
 		},
 		{
@@ -48,6 +53,10 @@ func TestLoadConfig(t *testing.T) {
 				Sockets:   1,
 				Workers:   1,
 				QueueSize: 1000,
+				Aggregation: AggregationConfig{
+					Interval: defaultAggregationInterval,
+					GroupBy:  defaultGroupBy,
+				},
 			},
 		},
 		{
@@ -58,6 +67,10 @@ func TestLoadConfig(t *testing.T) {
 				Sockets:   1,
 				Workers:   1,
 				QueueSize: 1000,
+				Aggregation: AggregationConfig{
+					Interval: defaultAggregationInterval,
+					GroupBy:  defaultGroupBy,
+				},
 			},
 		},
 		{
@@ -69,6 +82,28 @@ func TestLoadConfig(t *testing.T) {
 				Workers:   1,
 				QueueSize: 1000,
 				SendRaw:   true,
+				Aggregation: AggregationConfig{
+					Interval: defaultAggregationInterval,
+					GroupBy:  defaultGroupBy,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "ipfix"),
+			expected: &Config{
+				Scheme:    "ipfix",
+				Port:      4739,
+				Sockets:   1,
+				Workers:   1,
+				QueueSize: 1000,
+				Fields: []FieldConfig{
+					{Name: "application.name", ElementID: 96, Type: "string"},
+					{Name: "acme.tenant_id", ElementID: 12, EnterpriseNumber: 99999, Type: "int"},
+				},
+				Aggregation: AggregationConfig{
+					Interval: 30 * time.Second,
+					GroupBy:  []string{"source.address", "destination.address", "acme.tenant_id"},
+				},
 			},
 		},
 	}
@@ -100,7 +135,7 @@ func TestInvalidConfig(t *testing.T) {
 	}{
 		{
 			id:  component.NewIDWithName(metadata.Type, "invalid_schema"),
-			err: "scheme must be netflow or sflow",
+			err: "scheme must be netflow, ipfix or sflow",
 		},
 		{
 			id:  component.NewIDWithName(metadata.Type, "invalid_port"),
@@ -114,6 +149,30 @@ func TestInvalidConfig(t *testing.T) {
 			id:  component.NewIDWithName(metadata.Type, "zero_workers"),
 			err: "workers must be greater than 0",
 		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "sflow_fields"),
+			err: "fields are only supported by the netflow and ipfix schemes",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_field_type"),
+			err: `field "application.name": type must be string, bytes, int or ip`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "missing_element_id"),
+			err: `field "application.name": element_id must be greater than 0`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "duplicate_field"),
+			err: `field "application.name" is defined more than once`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "zero_interval"),
+			err: "aggregation interval must be greater than 0",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_group_by"),
+			err: `aggregation cannot group by "flow.io.bytes": it is neither a flow attribute nor a field`,
+		},
 	}
 
 	for _, tt := range tests {
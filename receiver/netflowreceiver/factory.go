@@ -5,11 +5,13 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,14 +22,20 @@ const (
 	// that for a full queue of 1000 messages, the size in memory will be 9MB.
 	// Source: https://github.com/netsampler/goflow2/blob/v2.2.1/README.md#security-notes-and-assumptions
 	defaultQueueSize = 1_000
+	// defaultAggregationInterval is the interval flows are aggregated over into metrics
+	defaultAggregationInterval = time.Minute
 )
 
+// defaultGroupBy aggregates flows by their 5-tuple
+var defaultGroupBy = []string{"source.address", "source.port", "destination.address", "destination.port", "network.transport"}
+
 // NewFactory creates a factory for netflow receiver.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
 	)
 }
 
@@ -40,6 +48,10 @@ func createDefaultConfig() component.Config {
 		Sockets:   defaultSockets,
 		Workers:   defaultWorkers,
 		QueueSize: defaultQueueSize,
+		Aggregation: AggregationConfig{
+			Interval: defaultAggregationInterval,
+			GroupBy:  defaultGroupBy,
+		},
 	}
 }
 
@@ -47,12 +59,39 @@ func createDefaultConfig() component.Config {
 // We also create the UDP receiver, which is the piece of software that actually listens
 // for incoming netflow traffic on an UDP port.
 func createLogsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
-	conf := *cfg.(*Config)
+	r, err := getOrCreateReceiver(params, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
 
-	nr, err := newNetflowLogsReceiver(params, conf, consumer)
+	r.Unwrap().(*netflowReceiver).logConsumer = consumer
+	return r, nil
+}
+
+// createMetricsReceiver creates a netflow receiver aggregating flows into metrics.
+// It shares the UDP receiver of the logs receiver with the same config.
+func createMetricsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	r, err := getOrCreateReceiver(params, cfg.(*Config))
 	if err != nil {
 		return nil, err
 	}
 
-	return nr, nil
+	r.Unwrap().(*netflowReceiver).metricConsumer = consumer
+	return r, nil
+}
+
+func getOrCreateReceiver(params receiver.Settings, cfg *Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		nr, createErr := newNetflowReceiver(params, *cfg)
+		if createErr != nil {
+			err = createErr
+			return nil
+		}
+		return nr
+	})
+	return r, err
 }
+
+// receivers holds the receivers shared by the logs and metrics pipelines
+var receivers = sharedcomponent.NewSharedComponents()
@@ -29,3 +29,14 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
 }
+
+func TestCreateLogsAndMetricsReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	logsReceiver, err := factory.CreateLogs(t.Context(), set, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	metricsReceiver, err := factory.CreateMetrics(t.Context(), set, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, logsReceiver, metricsReceiver, "logs and metrics pipelines should share the receiver")
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"encoding/hex"
+	"net/netip"
+	"strings"
+
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// firstFieldIndex is the protobuf index of the first field, goflow2 stores the value of
+// fields that are not part of its flow message as unknown protobuf fields.
+const firstFieldIndex = 1000
+
+// newProducerConfig returns the goflow2 producer configuration decoding the given fields
+// from NetFlow v9 and IPFIX templates.
+func newProducerConfig(fields []FieldConfig) *protoproducer.ProducerConfig {
+	cfg := &protoproducer.ProducerConfig{}
+	for i, field := range fields {
+		protoType := string(protoproducer.ProtoString)
+		if field.Type == fieldTypeInt {
+			protoType = string(protoproducer.ProtoVarint)
+		}
+		cfg.Formatter.Protobuf = append(cfg.Formatter.Protobuf, protoproducer.ProtobufFormatterConfig{
+			Name:  field.Name,
+			Index: firstFieldIndex + int32(i),
+			Type:  protoType,
+		})
+		mapping := protoproducer.NetFlowMapField{
+			PenProvided: field.EnterpriseNumber != 0,
+			Type:        field.ElementID,
+			Pen:         field.EnterpriseNumber,
+			Destination: field.Name,
+			Endian:      protoproducer.BigEndian,
+		}
+		cfg.IPFIX.Mapping = append(cfg.IPFIX.Mapping, mapping)
+		cfg.NetFlowV9.Mapping = append(cfg.NetFlowV9.Mapping, mapping)
+	}
+	return cfg
+}
+
+// putFieldAttributes adds the fields decoded from the flow message to attrs
+func putFieldAttributes(pm *protoproducer.ProtoProducerMessage, fields []FieldConfig, attrs pcommon.Map) {
+	if len(fields) == 0 {
+		return
+	}
+	unknown := pm.ProtoReflect().GetUnknown()
+	for len(unknown) > 0 {
+		num, wireType, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return
+		}
+		unknown = unknown[n:]
+		n = protowire.ConsumeFieldValue(num, wireType, unknown)
+		if n < 0 {
+			return
+		}
+		data := unknown[:n]
+		unknown = unknown[n:]
+
+		i := int(num) - firstFieldIndex
+		if i < 0 || i >= len(fields) {
+			continue
+		}
+		field := fields[i]
+		switch wireType {
+		case protowire.VarintType:
+			v, _ := protowire.ConsumeVarint(data)
+			attrs.PutInt(field.Name, int64(v))
+		case protowire.BytesType:
+			v, _ := protowire.ConsumeBytes(data)
+			switch field.Type {
+			case fieldTypeString:
+				attrs.PutStr(field.Name, strings.TrimRight(string(v), "\x00"))
+			case fieldTypeIP:
+				addr, _ := netip.AddrFromSlice(v)
+				attrs.PutStr(field.Name, addr.String())
+			default:
+				attrs.PutStr(field.Name, hex.EncodeToString(v))
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"testing"
+
+	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+var testFields = []FieldConfig{
+	{Name: "application.name", ElementID: 96, Type: "string"},
+	{Name: "acme.tenant_id", ElementID: 12, EnterpriseNumber: 99999, Type: "int"},
+	{Name: "acme.gateway", ElementID: 13, EnterpriseNumber: 99999, Type: "ip"},
+	{Name: "acme.trace", ElementID: 14, EnterpriseNumber: 99999, Type: "bytes"},
+}
+
+func ipfixFlow(src, dst []byte, srcPort, dstPort byte, bytes, packets byte, extra ...netflow.DataField) netflow.DataRecord {
+	return netflow.DataRecord{
+		Values: append([]netflow.DataField{
+			{Type: 8, Value: src},
+			{Type: 12, Value: dst},
+			{Type: 7, Value: []byte{0x00, srcPort}},
+			{Type: 11, Value: []byte{0x00, dstPort}},
+			{Type: 4, Value: []byte{6}},
+			{Type: 1, Value: []byte{0x00, 0x00, 0x00, bytes}},
+			{Type: 2, Value: []byte{0x00, 0x00, 0x00, packets}},
+		}, extra...),
+	}
+}
+
+func ipfixPacket(records ...netflow.DataRecord) *netflow.IPFIXPacket {
+	return &netflow.IPFIXPacket{
+		Version:             10,
+		ExportTime:          0x618aa3a8,
+		SequenceNumber:      42,
+		ObservationDomainId: 7,
+		FlowSets: []any{
+			netflow.DataFlowSet{
+				FlowSetHeader: netflow.FlowSetHeader{Id: 256},
+				Records:       records,
+			},
+		},
+	}
+}
+
+func newTestProtoProducer(t *testing.T, fields []FieldConfig) producer.ProducerInterface {
+	cfgm, err := newProducerConfig(fields).Compile()
+	require.NoError(t, err)
+	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
+	require.NoError(t, err)
+	return protoProducer
+}
+
+func TestProduceIPFIXFields(t *testing.T) {
+	message := ipfixPacket(
+		ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 80, 81, 100, 2,
+			netflow.DataField{Type: 96, Value: []byte("https\x00\x00\x00")},
+			netflow.DataField{PenProvided: true, Pen: 99999, Type: 12, Value: []byte{0x00, 0x2a}},
+			netflow.DataField{PenProvided: true, Pen: 99999, Type: 13, Value: []byte{192, 168, 0, 1}},
+			netflow.DataField{PenProvided: true, Pen: 99999, Type: 14, Value: []byte{0xca, 0xfe}},
+			// Same element ID, other enterprise: not mapped
+			netflow.DataField{PenProvided: true, Pen: 1, Type: 12, Value: []byte{0x01}},
+		),
+		ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 80, 81, 100, 2),
+	)
+
+	sink := &consumertest.LogsSink{}
+	otelLogsProducer := newOtelLogsProducer(newTestProtoProducer(t, testFields), sink, zap.NewNop(), false, testFields)
+	messages, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	records := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	attrs := records.At(0).Attributes().AsRaw()
+	assert.Equal(t, "ipfix", attrs["flow.type"])
+	assert.Equal(t, "10.0.0.1", attrs["source.address"])
+	assert.Equal(t, int64(81), attrs["destination.port"])
+	assert.Equal(t, int64(100), attrs["flow.io.bytes"])
+	assert.Equal(t, int64(7), attrs["flow.observation_domain_id"])
+	assert.Equal(t, "https", attrs["application.name"])
+	assert.Equal(t, int64(42), attrs["acme.tenant_id"])
+	assert.Equal(t, "192.168.0.1", attrs["acme.gateway"])
+	assert.Equal(t, "cafe", attrs["acme.trace"])
+
+	// Fields missing from the flow are not added
+	for _, field := range testFields {
+		_, ok := records.At(1).Attributes().Get(field.Name)
+		assert.False(t, ok, field.Name)
+	}
+}
+
+func TestProduceIPFIXWithoutFields(t *testing.T) {
+	message := ipfixPacket(ipfixFlow([]byte{10, 0, 0, 1}, []byte{10, 0, 0, 2}, 80, 81, 100, 2,
+		netflow.DataField{Type: 96, Value: []byte("https")},
+	))
+
+	sink := &consumertest.LogsSink{}
+	otelLogsProducer := newOtelLogsProducer(newTestProtoProducer(t, nil), sink, zap.NewNop(), false, nil)
+	_, err := otelLogsProducer.Produce(message, &producer.ProduceArgs{})
+	require.NoError(t, err)
+
+	attrs := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	_, ok := attrs.Get("application.name")
+	assert.False(t, ok)
+}
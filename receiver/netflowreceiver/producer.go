@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
@@ -21,6 +22,7 @@ type otelLogsProducerWrapper struct {
 	logConsumer consumer.Logs
 	logger      *zap.Logger
 	sendRaw     bool
+	fields      []FieldConfig
 }
 
 // Produce converts the message into a list log records and sends them to log consumer
@@ -56,7 +58,9 @@ func (o *otelLogsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) (
 			err = addMessageAttributes(msg, &logRecord)
 			if err != nil {
 				o.logger.Error("error adding message attributes", zap.Error(err))
+				continue
 			}
+			putFieldAttributes(msg.(*protoproducer.ProtoProducerMessage), o.fields, logRecord.Attributes())
 		}
 	}
 
@@ -80,11 +84,12 @@ func (o *otelLogsProducerWrapper) Commit(flowMessageSet []producer.ProducerMessa
 	o.wrapped.Commit(flowMessageSet)
 }
 
-func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consumer.Logs, logger *zap.Logger, sendRaw bool) producer.ProducerInterface {
+func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consumer.Logs, logger *zap.Logger, sendRaw bool, fields []FieldConfig) producer.ProducerInterface {
 	return &otelLogsProducerWrapper{
 		wrapped:     wrapped,
 		logConsumer: logConsumer,
 		logger:      logger,
 		sendRaw:     sendRaw,
+		fields:      fields,
 	}
 }
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
 	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"github.com/netsampler/goflow2/v2/utils"
 	"go.opentelemetry.io/collector/component"
@@ -30,15 +32,17 @@ func (d dropHandler) Dropped(msg utils.Message) {
 }
 
 type netflowReceiver struct {
-	config      Config
-	logger      *zap.Logger
-	udpReceiver *utils.UDPReceiver
-	logConsumer consumer.Logs
-	cancel      context.CancelFunc
-	wg          sync.WaitGroup
+	config         Config
+	logger         *zap.Logger
+	udpReceiver    *utils.UDPReceiver
+	logConsumer    consumer.Logs
+	metricConsumer consumer.Metrics
+	aggregator     *flowAggregator
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
 }
 
-func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
+func newNetflowReceiver(params receiver.Settings, cfg Config) (*netflowReceiver, error) {
 	// UDP receiver configuration
 	udpCfg := &utils.UDPReceiverConfig{
 		Sockets:   cfg.Sockets,
@@ -57,7 +61,6 @@ func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consu
 	nr := &netflowReceiver{
 		logger:      params.Logger,
 		config:      cfg,
-		logConsumer: consumer,
 		udpReceiver: udpReceiver,
 	}
 
@@ -74,6 +77,12 @@ func (nr *netflowReceiver) Start(ctx context.Context, _ component.Host) error {
 	ctx, cancel := context.WithCancel(ctx)
 	nr.cancel = cancel
 
+	if nr.metricConsumer != nil {
+		nr.wg.Go(func() {
+			nr.flushMetrics(ctx)
+		})
+	}
+
 	nr.logger.Info("Starting UDP listener", zap.String("scheme", nr.config.Scheme), zap.Int("port", nr.config.Port))
 	if err := nr.udpReceiver.Start(nr.config.Hostname, nr.config.Port, decodeFunc); err != nil {
 		cancel()
@@ -99,38 +108,77 @@ func (nr *netflowReceiver) Shutdown(context.Context) error {
 		nr.logger.Warn("Error stopping UDP receiver", zap.Error(err))
 	}
 	nr.wg.Wait()
+
+	// Flows aggregated since the last interval are not lost
+	if nr.aggregator != nil {
+		if md, ok := nr.aggregator.flush(time.Now()); ok {
+			if err := nr.metricConsumer.ConsumeMetrics(context.Background(), md); err != nil {
+				nr.logger.Warn("Error sending flow metrics", zap.Error(err))
+			}
+		}
+	}
 	return nil
 }
 
+// flushMetrics sends the aggregated flow metrics every aggregation interval
+func (nr *netflowReceiver) flushMetrics(ctx context.Context) {
+	ticker := time.NewTicker(nr.config.Aggregation.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			md, ok := nr.aggregator.flush(now)
+			if !ok {
+				continue
+			}
+			if err := nr.metricConsumer.ConsumeMetrics(ctx, md); err != nil {
+				nr.logger.Error("Error sending flow metrics", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // buildDecodeFunc creates a decode function based on the scheme
 // This is the fuction that will be invoked for every netflow packet received
 // The function depends on the type of schema (netflow, sflow, flow)
 func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
-	// Eventually this can be used to configure mappings
-	cfgProducer := &protoproducer.ProducerConfig{}
+	// Decode the configured fields from the templates
+	cfgProducer := newProducerConfig(nr.config.Fields)
 	cfgm, err := cfgProducer.Compile() // converts configuration into a format that can be used by a protobuf producer
 	if err != nil {
 		return nil, err
 	}
 	// We use a goflow2 proto producer to produce messages using protobuf format
-	protoProducer, err := protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
+	var flowProducer producer.ProducerInterface
+	flowProducer, err = protoproducer.CreateProtoProducer(cfgm, protoproducer.CreateSamplingSystem)
 	if err != nil {
 		return nil, err
 	}
 
+	// the otel metrics producer aggregates those messages into OpenTelemetry metrics
+	if nr.metricConsumer != nil {
+		nr.aggregator = newFlowAggregator(nr.config.Aggregation, nr.config.Fields, time.Now())
+		flowProducer = newOtelMetricsProducer(flowProducer, nr.aggregator, nr.logger)
+	}
+
 	// the otel log producer converts those messages into OpenTelemetry logs
 	// it is a wrapper around the protobuf producer
-	otelLogsProducer := newOtelLogsProducer(protoProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	if nr.logConsumer != nil {
+		flowProducer = newOtelLogsProducer(flowProducer, nr.logConsumer, nr.logger, nr.config.SendRaw, nr.config.Fields)
+	}
 
 	cfgPipe := &utils.PipeConfig{
-		Producer: otelLogsProducer,
+		Producer: flowProducer,
 	}
 
 	var p utils.FlowPipe
 	switch nr.config.Scheme {
 	case "sflow":
 		p = utils.NewSFlowPipe(cfgPipe)
-	case "netflow":
+	case "netflow", "ipfix":
+		// The NetFlow pipe decodes NetFlow v5, v9 and IPFIX
 		p = utils.NewNetFlowPipe(cfgPipe)
 	default:
 		return nil, fmt.Errorf("scheme does not exist: %s", nr.config.Scheme)
@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,5 +21,5 @@ func TestCreateValidDefaultReceiver(t *testing.T) {
 	receiver, err := factory.CreateLogs(t.Context(), set, cfg, consumertest.NewNop())
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
-	assert.NotNil(t, receiver.(*netflowReceiver).udpReceiver)
+	assert.NotNil(t, receiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver).udpReceiver)
 }
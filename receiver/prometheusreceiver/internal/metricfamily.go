@@ -4,7 +4,6 @@
 package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
 
 import (
-	"encoding/hex"
 	"fmt"
 	"math"
 	"sort"
@@ -44,17 +43,18 @@ type metricGroup struct {
 	hasCount bool
 	sum      float64
 	hasSum   bool
-	// This corresponds to the `_created` sample found from the metric parsing.
+	// This corresponds to the `_created` sample found from the metric parsing, or to the created
+	// timestamp of the sample.
 	// - https://github.com/prometheus/OpenMetrics/blob/main/specification/OpenMetrics.md#timestamps
 	// - https://github.com/prometheus/OpenMetrics/blob/main/specification/OpenMetrics.md#counter-1
-	createdSeconds float64
-	value          float64
-	hasValue       bool
-	hValue         *histogram.Histogram
-	fhValue        *histogram.FloatHistogram
-	complexValue   []*dataPoint
-	exemplars      pmetric.ExemplarSlice
-	isNHCB         bool // true if this is a Native Histogram Custom Buckets (schema -53)
+	created      pcommon.Timestamp
+	value        float64
+	hasValue     bool
+	hValue       *histogram.Histogram
+	fhValue      *histogram.FloatHistogram
+	complexValue []*dataPoint
+	exemplars    pmetric.ExemplarSlice
+	isNHCB       bool // true if this is a Native Histogram Custom Buckets (schema -53)
 }
 
 func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, logger *zap.Logger, isNativeHistogram, isNHCB bool) *metricFamily {
@@ -105,66 +105,71 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	}
 
 	pointIsStale := value.IsStaleNaN(mg.sum) || value.IsStaleNaN(mg.count)
-	// NHCB conversion loses whether _count was absent. Reject the inconsistent
-	// form that identifies a sum-only classic histogram.
-	if mg.isNHCB && !pointIsStale && mg.count == 0 && mg.sum != 0 {
-		return
+	var point pmetric.HistogramDataPoint
+	if mg.isNHCB {
+		// NHCB conversion loses whether _count was absent. Reject the inconsistent
+		// form that identifies a sum-only classic histogram.
+		if !pointIsStale && mg.count == 0 && mg.sum != 0 {
+			return
+		}
+		h := mg.nativeHistogram()
+		if h == nil || h.Validate() != nil {
+			return
+		}
+		point = dest.AppendEmpty()
+		prometheus.NativeHistogramToHistogramDataPoint(h, point)
+	} else {
+		point = dest.AppendEmpty()
+		mg.setClassicHistogramBuckets(point, pointIsStale)
 	}
 
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	if mg.created != 0 {
+		point.SetStartTimestamp(mg.created)
+	}
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
+func (mg *metricGroup) setClassicHistogramBuckets(point pmetric.HistogramDataPoint, pointIsStale bool) {
 	mg.sortPoints()
 
-	var bounds []float64
-	var bucketCounts []uint64
+	bucketCount := len(mg.complexValue) + 1
+	// if the final bucket is +Inf, we ignore it
+	if bucketCount > 1 && mg.complexValue[bucketCount-2].boundary == math.Inf(1) {
+		bucketCount--
+	}
 
-	if mg.isNHCB {
-		switch {
-		case mg.hValue != nil:
-			bounds = make([]float64, len(mg.hValue.CustomValues))
-			copy(bounds, mg.hValue.CustomValues)
-			bucketCounts = convertNHCBBDeltBuckets(mg.hValue)
-		case mg.fhValue != nil:
-			bounds = make([]float64, len(mg.fhValue.CustomValues))
-			copy(bounds, mg.fhValue.CustomValues)
-			bucketCounts = convertNHCBAbsoluteBuckets(mg.fhValue)
-		}
-	} else {
-		bucketCount := len(mg.complexValue) + 1
-		// if the final bucket is +Inf, we ignore it
-		if bucketCount > 1 && mg.complexValue[bucketCount-2].boundary == math.Inf(1) {
-			bucketCount--
-		}
+	// for OTLP the bounds won't include +inf
+	bounds := make([]float64, bucketCount-1)
+	bucketCounts := make([]uint64, bucketCount)
+	var adjustedCount float64
 
-		// for OTLP the bounds won't include +inf
-		bounds = make([]float64, bucketCount-1)
-		bucketCounts = make([]uint64, bucketCount)
-		var adjustedCount float64
-
-		for i := 0; i < bucketCount-1; i++ {
-			bounds[i] = mg.complexValue[i].boundary
-			adjustedCount = mg.complexValue[i].value
-
-			// Buckets still need to be sent to know to set them as stale,
-			// but a staleness NaN converted to uint64 would be an extremely large number.
-			// Setting to 0 instead.
-			if pointIsStale {
-				adjustedCount = 0
-			} else if i != 0 {
-				adjustedCount -= mg.complexValue[i-1].value
-			}
-			bucketCounts[i] = uint64(adjustedCount)
-		}
+	for i := 0; i < bucketCount-1; i++ {
+		bounds[i] = mg.complexValue[i].boundary
+		adjustedCount = mg.complexValue[i].value
 
-		// Add the final bucket based on the total count
-		adjustedCount = mg.count
+		// Buckets still need to be sent to know to set them as stale,
+		// but a staleness NaN converted to uint64 would be an extremely large number.
+		// Setting to 0 instead.
 		if pointIsStale {
 			adjustedCount = 0
-		} else if bucketCount > 1 {
-			adjustedCount -= mg.complexValue[bucketCount-2].value
+		} else if i != 0 {
+			adjustedCount -= mg.complexValue[i-1].value
 		}
-		bucketCounts[bucketCount-1] = uint64(adjustedCount)
+		bucketCounts[i] = uint64(adjustedCount)
 	}
 
-	point := dest.AppendEmpty()
+	// Add the final bucket based on the total count
+	adjustedCount = mg.count
+	if pointIsStale {
+		adjustedCount = 0
+	} else if bucketCount > 1 {
+		adjustedCount -= mg.complexValue[bucketCount-2].value
+	}
+	bucketCounts[bucketCount-1] = uint64(adjustedCount)
 
 	if pointIsStale {
 		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
@@ -177,15 +182,6 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 
 	point.ExplicitBounds().FromRaw(bounds)
 	point.BucketCounts().FromRaw(bucketCounts)
-
-	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
-	tsNanos := timestampFromMs(mg.ts)
-	if mg.createdSeconds != 0 {
-		point.SetStartTimestamp(timestampFromFloat64(mg.createdSeconds))
-	}
-	point.SetTimestamp(tsNanos)
-	populateAttributes(pmetric.MetricTypeHistogram, mg.ls, point.Attributes())
-	mg.setExemplars(point.Exemplars())
 }
 
 // toExponentialHistogramDataPoints is based on
@@ -194,154 +190,73 @@ func (mg *metricGroup) toExponentialHistogramDataPoints(dest pmetric.Exponential
 	if !mg.hasCount {
 		return
 	}
-	point := dest.AppendEmpty()
-	point.SetTimestamp(timestampFromMs(mg.ts))
-
-	// We do not set Min or Max as native histograms don't have that information.
-	switch {
-	case mg.fhValue != nil:
-		fh := mg.fhValue
-
-		if value.IsStaleNaN(fh.Sum) {
-			point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
-			// The count and sum are initialized to 0, so we don't need to set them.
-		} else {
-			point.SetScale(fh.Schema)
-			// Input is a float native histogram. This conversion will lose
-			// precision,but we don't actually expect float histograms in scrape,
-			// since these are typically the result of operations on integer
-			// native histograms in the database.
-			point.SetCount(uint64(fh.Count))
-			point.SetSum(fh.Sum)
-			point.SetZeroThreshold(fh.ZeroThreshold)
-			point.SetZeroCount(uint64(fh.ZeroCount))
-
-			if len(fh.PositiveSpans) > 0 {
-				point.Positive().SetOffset(fh.PositiveSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-				convertAbsoluteBuckets(fh.PositiveSpans, fh.PositiveBuckets, point.Positive().BucketCounts())
-			}
-			if len(fh.NegativeSpans) > 0 {
-				point.Negative().SetOffset(fh.NegativeSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-				convertAbsoluteBuckets(fh.NegativeSpans, fh.NegativeBuckets, point.Negative().BucketCounts())
-			}
-		}
-
-	case mg.hValue != nil:
-		h := mg.hValue
-
-		if value.IsStaleNaN(h.Sum) {
-			point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
-			// The count and sum are initialized to 0, so we don't need to set them.
-		} else {
-			point.SetScale(h.Schema)
-			point.SetCount(h.Count)
-			point.SetSum(h.Sum)
-			point.SetZeroThreshold(h.ZeroThreshold)
-			point.SetZeroCount(h.ZeroCount)
-
-			if len(h.PositiveSpans) > 0 {
-				point.Positive().SetOffset(h.PositiveSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-				convertDeltaBuckets(h.PositiveSpans, h.PositiveBuckets, point.Positive().BucketCounts())
-			}
-			if len(h.NegativeSpans) > 0 {
-				point.Negative().SetOffset(h.NegativeSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-				convertDeltaBuckets(h.NegativeSpans, h.NegativeBuckets, point.Negative().BucketCounts())
-			}
-		}
-
-	default:
-		// This should never happen.
+	h := mg.nativeHistogram()
+	if h == nil || h.Validate() != nil {
 		return
 	}
+	point := dest.AppendEmpty()
+	prometheus.NativeHistogramToExponentialHistogramDataPoint(h, point)
 
 	tsNanos := timestampFromMs(mg.ts)
-	if mg.createdSeconds != 0 {
-		point.SetStartTimestamp(timestampFromFloat64(mg.createdSeconds))
+	if mg.created != 0 {
+		point.SetStartTimestamp(mg.created)
 	}
 	point.SetTimestamp(tsNanos)
 	populateAttributes(pmetric.MetricTypeHistogram, mg.ls, point.Attributes())
 	mg.setExemplars(point.Exemplars())
 }
 
-func convertDeltaBuckets(spans []histogram.Span, deltas []int64, buckets pcommon.UInt64Slice) {
-	buckets.EnsureCapacity(len(deltas))
-	bucketIdx := 0
-	bucketCount := int64(0)
-	for spanIdx, span := range spans {
-		if spanIdx > 0 {
-			for i := int32(0); i < span.Offset; i++ {
-				buckets.Append(uint64(0))
-			}
-		}
-		for i := uint32(0); i < span.Length; i++ {
-			bucketCount += deltas[bucketIdx]
-			bucketIdx++
-			buckets.Append(uint64(bucketCount))
-		}
-	}
-}
-
-func convertAbsoluteBuckets(spans []histogram.Span, counts []float64, buckets pcommon.UInt64Slice) {
-	buckets.EnsureCapacity(len(counts))
-	bucketIdx := 0
-	for spanIdx, span := range spans {
-		if spanIdx > 0 {
-			for i := int32(0); i < span.Offset; i++ {
-				buckets.Append(uint64(0))
-			}
-		}
-		for i := uint32(0); i < span.Length; i++ {
-			buckets.Append(uint64(counts[bucketIdx]))
-			bucketIdx++
+// nativeHistogram returns the native histogram of the group, or nil when the group has no native histogram.
+func (mg *metricGroup) nativeHistogram() *prometheus.NativeHistogram {
+	switch {
+	case mg.fhValue != nil:
+		// Input is a float native histogram. This conversion will lose
+		// precision,but we don't actually expect float histograms in scrape,
+		// since these are typically the result of operations on integer
+		// native histograms in the database.
+		fh := mg.fhValue
+		return &prometheus.NativeHistogram{
+			Schema:         fh.Schema,
+			ZeroThreshold:  fh.ZeroThreshold,
+			Sum:            fh.Sum,
+			IsFloat:        true,
+			FloatCount:     fh.Count,
+			FloatZeroCount: fh.ZeroCount,
+			PositiveSpans:  convertSpans(fh.PositiveSpans),
+			NegativeSpans:  convertSpans(fh.NegativeSpans),
+			PositiveCounts: fh.PositiveBuckets,
+			NegativeCounts: fh.NegativeBuckets,
+			CustomValues:   fh.CustomValues,
 		}
-	}
-}
-
-// convertNHCBBDeltBuckets converts NHCB delta buckets to otel bucket counts.
-func convertNHCBBDeltBuckets(histogram *histogram.Histogram) []uint64 {
-	bucketCounts := make([]uint64, len(histogram.CustomValues)+1)
-	if len(histogram.PositiveSpans) == 0 {
-		return bucketCounts
-	}
-	bucketIdx := 0
-	bucketCount := int64(0)
-	deltaIdx := 0
-
-	for _, span := range histogram.PositiveSpans {
-		bucketIdx += int(span.Offset)
-
-		for i := uint32(0); i < span.Length && bucketIdx < len(bucketCounts) && deltaIdx < len(histogram.PositiveBuckets); i++ {
-			bucketCount += histogram.PositiveBuckets[deltaIdx]
-			deltaIdx++
-
-			if bucketIdx >= 0 && bucketIdx < len(bucketCounts) {
-				bucketCounts[bucketIdx] = uint64(bucketCount)
-			}
-			bucketIdx++
+	case mg.hValue != nil:
+		h := mg.hValue
+		return &prometheus.NativeHistogram{
+			Schema:         h.Schema,
+			ZeroThreshold:  h.ZeroThreshold,
+			Sum:            h.Sum,
+			Count:          h.Count,
+			ZeroCount:      h.ZeroCount,
+			PositiveSpans:  convertSpans(h.PositiveSpans),
+			NegativeSpans:  convertSpans(h.NegativeSpans),
+			PositiveDeltas: h.PositiveBuckets,
+			NegativeDeltas: h.NegativeBuckets,
+			CustomValues:   h.CustomValues,
 		}
+	default:
+		// This should never happen.
+		return nil
 	}
-	return bucketCounts
 }
 
-// convertNHCBAbsoluteBuckets converts NHCB absolute buckets to otel bucket counts.
-func convertNHCBAbsoluteBuckets(histogram *histogram.FloatHistogram) []uint64 {
-	bucketCounts := make([]uint64, len(histogram.CustomValues)+1)
-	if len(histogram.PositiveSpans) == 0 {
-		return bucketCounts
+func convertSpans(spans []histogram.Span) []prometheus.BucketSpan {
+	if len(spans) == 0 {
+		return nil
 	}
-	bucketIdx := 0
-	for _, span := range histogram.PositiveSpans {
-		bucketIdx += int(span.Offset)
-
-		for i := uint32(0); i < span.Length && bucketIdx < len(bucketCounts) && i < uint32(len(histogram.PositiveBuckets)); i++ {
-			if bucketIdx >= 0 && bucketIdx < len(bucketCounts) {
-				// This intentionally truncates the float value to an integer (e.g. 5.7 becomes 5).
-				bucketCounts[bucketIdx] = uint64(histogram.PositiveBuckets[i])
-			}
-			bucketIdx++
-		}
+	converted := make([]prometheus.BucketSpan, len(spans))
+	for i, span := range spans {
+		converted[i] = prometheus.BucketSpan{Offset: span.Offset, Length: span.Length}
 	}
-	return bucketCounts
+	return converted
 }
 
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
@@ -393,8 +308,8 @@ func (mg *metricGroup) toSummaryPoint(dest pmetric.SummaryDataPointSlice) {
 	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
 	tsNanos := timestampFromMs(mg.ts)
 	point.SetTimestamp(tsNanos)
-	if mg.createdSeconds != 0 {
-		point.SetStartTimestamp(timestampFromFloat64(mg.createdSeconds))
+	if mg.created != 0 {
+		point.SetStartTimestamp(mg.created)
 	}
 	populateAttributes(pmetric.MetricTypeSummary, mg.ls, point.Attributes())
 }
@@ -407,8 +322,8 @@ func (mg *metricGroup) toNumberDataPoint(dest pmetric.NumberDataPointSlice) {
 	point := dest.AppendEmpty()
 	// gauge/undefined types have no start time.
 	if mg.mtype == pmetric.MetricTypeSum {
-		if mg.createdSeconds != 0 {
-			point.SetStartTimestamp(timestampFromFloat64(mg.createdSeconds))
+		if mg.created != 0 {
+			point.SetStartTimestamp(mg.created)
 		}
 	}
 	point.SetTimestamp(tsNanos)
@@ -476,7 +391,7 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 			mg.count = v
 			mg.hasCount = true
 		case metricName == mf.metadata.MetricFamily+metricSuffixCreated:
-			mg.createdSeconds = v
+			mg.created = timestampFromFloat64(v)
 		default:
 			boundary, err := getBoundary(mf.mtype, ls)
 			if err != nil {
@@ -486,11 +401,11 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 		}
 	case pmetric.MetricTypeExponentialHistogram:
 		if metricName == mf.metadata.MetricFamily+metricSuffixCreated {
-			mg.createdSeconds = v
+			mg.created = timestampFromFloat64(v)
 		}
 	case pmetric.MetricTypeSum:
 		if metricName == mf.metadata.MetricFamily+metricSuffixCreated {
-			mg.createdSeconds = v
+			mg.created = timestampFromFloat64(v)
 		} else {
 			mg.value = v
 			mg.hasValue = true
@@ -504,11 +419,12 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 }
 
 // addCreationTimestamp updates the metric group cache with the created timestamp for the group.
-// The parser gets the created time in ms however and we must convert it to seconds here.
+// The parser gets the created time in ms, which is converted without going through seconds so that
+// it matches the start time of the samples received through remote write.
 // - https://github.com/prometheus/prometheus/blob/2bf6f4c9dcbb1ad2e8fef70c6a48d8fc44a7f57c/model/textparse/interface.go#L77-L80
 func (mf *metricFamily) addCreationTimestamp(seriesRef uint64, ls labels.Labels, atMs, ctMs int64) {
 	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, atMs)
-	mg.createdSeconds = float64(ctMs) / 1000.0
+	mg.created = timestampFromMs(ctMs)
 }
 
 func (mf *metricFamily) addExponentialHistogramSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, h *histogram.Histogram, fh *histogram.FloatHistogram) error {
@@ -654,43 +570,6 @@ func convertExemplar(pe exemplar.Exemplar, e pmetric.Exemplar) {
 	e.SetDoubleValue(pe.Value)
 	e.FilteredAttributes().EnsureCapacity(pe.Labels.Len())
 	pe.Labels.Range(func(lb labels.Label) {
-		switch strings.ToLower(lb.Name) {
-		case prometheus.ExemplarTraceIDKey:
-			var tid [16]byte
-			err := decodeAndCopyToLowerBytes(tid[:], []byte(lb.Value))
-			if err == nil {
-				e.SetTraceID(tid)
-			} else {
-				e.FilteredAttributes().PutStr(lb.Name, lb.Value)
-			}
-		case prometheus.ExemplarSpanIDKey:
-			var sid [8]byte
-			err := decodeAndCopyToLowerBytes(sid[:], []byte(lb.Value))
-			if err == nil {
-				e.SetSpanID(sid)
-			} else {
-				e.FilteredAttributes().PutStr(lb.Name, lb.Value)
-			}
-		default:
-			e.FilteredAttributes().PutStr(lb.Name, lb.Value)
-		}
+		prometheus.AddExemplarLabel(e, lb.Name, lb.Value)
 	})
 }
-
-/*
-	decodeAndCopyToLowerBytes copies src to dst on lower bytes instead of higher
-
-1. If len(src) > len(dst) -> copy first len(dst) bytes as it is. Example -> src = []byte{0xab,0xcd,0xef,0xgh,0xij}, dst = [2]byte, result dst = [2]byte{0xab, 0xcd}
-2. If len(src) = len(dst) -> copy src to dst as it is
-3. If len(src) < len(dst) -> prepend required 0s and then add src to dst. Example -> src = []byte{0xab, 0xcd}, dst = [8]byte, result dst = [8]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xab, 0xcd}
-*/
-func decodeAndCopyToLowerBytes(dst, src []byte) error {
-	var err error
-	decodedLen := hex.DecodedLen(len(src))
-	if decodedLen >= len(dst) {
-		_, err = hex.Decode(dst, src[:hex.EncodedLen(len(dst))])
-	} else {
-		_, err = hex.Decode(dst[len(dst)-decodedLen:], src)
-	}
-	return err
-}
@@ -4,7 +4,10 @@
 package internal
 
 import (
+	"encoding/json"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +20,9 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 type testMetadataStore map[string]scrape.MetricMetadata
@@ -1184,3 +1190,96 @@ func TestMetricGroupData_toNumberDataUnitTest(t *testing.T) {
 		})
 	}
 }
+
+// The native histograms golden files are shared with pkg/translator/prometheus and the remote write receiver,
+// which must convert the same histograms to byte-identical data points.
+func TestMetricGroupData_nativeHistogramGolden(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "pkg", "translator", "prometheus", "testdata", "native_histograms")
+	cases, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, cases)
+
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, c.Name(), "histogram.json"))
+			require.NoError(t, err)
+			var nh prometheus.NativeHistogram
+			require.NoError(t, json.Unmarshal(data, &nh))
+
+			var h *histogram.Histogram
+			var fh *histogram.FloatHistogram
+			if nh.IsFloat {
+				fh = &histogram.FloatHistogram{
+					Schema:          nh.Schema,
+					ZeroThreshold:   nh.ZeroThreshold,
+					Sum:             nh.Sum,
+					Count:           nh.FloatCount,
+					ZeroCount:       nh.FloatZeroCount,
+					PositiveSpans:   toPromSpans(nh.PositiveSpans),
+					NegativeSpans:   toPromSpans(nh.NegativeSpans),
+					PositiveBuckets: nh.PositiveCounts,
+					NegativeBuckets: nh.NegativeCounts,
+					CustomValues:    nh.CustomValues,
+				}
+			} else {
+				h = &histogram.Histogram{
+					Schema:          nh.Schema,
+					ZeroThreshold:   nh.ZeroThreshold,
+					Sum:             nh.Sum,
+					Count:           nh.Count,
+					ZeroCount:       nh.ZeroCount,
+					PositiveSpans:   toPromSpans(nh.PositiveSpans),
+					NegativeSpans:   toPromSpans(nh.NegativeSpans),
+					PositiveBuckets: nh.PositiveDeltas,
+					NegativeBuckets: nh.NegativeDeltas,
+					CustomValues:    nh.CustomValues,
+				}
+			}
+
+			ls := labels.FromStrings("a", "A")
+			actual := pmetric.NewMetrics()
+			metric := actual.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetName(c.Name())
+			sl := pmetric.NewMetricSlice()
+			if nh.IsCustomBuckets() {
+				mp := newMetricFamily("histogram", mc, zap.NewNop(), false, false)
+				sRef, _ := getSeriesRefWithoutScopeLabels(nil, ls, mp.mtype)
+				require.NoError(t, mp.addNHCBSeries(sRef, "histogram", ls, 10, h, fh))
+				mp.appendMetric(sl, false)
+				require.Equal(t, 1, sl.Len())
+				dp := metric.SetEmptyHistogram().DataPoints().AppendEmpty()
+				sl.At(0).Histogram().DataPoints().At(0).CopyTo(dp)
+				dp.SetTimestamp(0)
+				dp.Attributes().Clear()
+			} else {
+				mp := newMetricFamily("histogram", mc, zap.NewNop(), true, false)
+				mp.mtype = pmetric.MetricTypeExponentialHistogram
+				sRef, _ := getSeriesRefWithoutScopeLabels(nil, ls, mp.mtype)
+				require.NoError(t, mp.addExponentialHistogramSeries(sRef, "histogram", ls, 10, h, fh))
+				mp.appendMetric(sl, false)
+				require.Equal(t, 1, sl.Len())
+				dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+				sl.At(0).ExponentialHistogram().DataPoints().At(0).CopyTo(dp)
+				dp.SetTimestamp(0)
+				dp.Attributes().Clear()
+			}
+
+			expected, err := golden.ReadMetrics(filepath.Join(dir, c.Name(), "expected.yaml"))
+			require.NoError(t, err)
+			marshaler := &pmetric.ProtoMarshaler{}
+			expectedBytes, err := marshaler.MarshalMetrics(expected)
+			require.NoError(t, err)
+			actualBytes, err := marshaler.MarshalMetrics(actual)
+			require.NoError(t, err)
+			require.Equal(t, expectedBytes, actualBytes)
+		})
+	}
+}
+
+func toPromSpans(spans []prometheus.BucketSpan) []histogram.Span {
+	converted := make([]histogram.Span, len(spans))
+	for i, span := range spans {
+		converted[i] = histogram.Span{Offset: span.Offset, Length: span.Length}
+	}
+	return converted
+}
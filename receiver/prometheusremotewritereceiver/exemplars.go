@@ -4,7 +4,6 @@
 package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
 
 import (
-	"encoding/hex"
 	"time"
 
 	"github.com/prometheus/prometheus/model/labels"
@@ -87,8 +86,9 @@ func collectExemplars(
 			exemplar.SetTimestamp(pcommon.Timestamp(ex.Timestamp * int64(time.Millisecond)))
 			exemplar.SetDoubleValue(ex.Value)
 
-			setTraceAndSpan(exemplar, promExemplar.Labels)
-			copyExemplarAttributes(exemplar.FilteredAttributes(), promExemplar.Labels)
+			promExemplar.Labels.Range(func(l labels.Label) {
+				prometheus.AddExemplarLabel(exemplar, l.Name, l.Value)
+			})
 			stats.Exemplars++
 		}
 
@@ -111,41 +111,6 @@ func extractScopeFromLabels(settings receiver.Settings, ls labels.Labels) (strin
 	return name, version
 }
 
-// setTraceAndSpan extracts trace ID and span ID from exemplar labels
-// and sets them on the provided Exemplar.
-//
-// The function expects hexadecimal-encoded IDs using Prometheus
-// exemplar label keys and silently ignores invalid values.
-func setTraceAndSpan(exemplar pmetric.Exemplar, labels labels.Labels) {
-	if tid := labels.Get(prometheus.ExemplarTraceIDKey); tid != "" {
-		var t [16]byte
-		if b, err := hex.DecodeString(tid); err == nil {
-			copy(t[:], b)
-			exemplar.SetTraceID(pcommon.TraceID(t))
-		}
-	}
-	if sid := labels.Get(prometheus.ExemplarSpanIDKey); sid != "" {
-		var s [8]byte
-		if b, err := hex.DecodeString(sid); err == nil {
-			copy(s[:], b)
-			exemplar.SetSpanID(pcommon.SpanID(s))
-		}
-	}
-}
-
-// copyExemplarAttributes copies all labels into the destination attribute map
-// except for trace ID and span ID labels, which are handled separately.
-//
-// The destination map is typically the exemplar's filtered attributes.
-func copyExemplarAttributes(dest pcommon.Map, labels labels.Labels) {
-	for k, v := range labels.Map() {
-		if k == prometheus.ExemplarTraceIDKey || k == prometheus.ExemplarSpanIDKey {
-			continue
-		}
-		dest.PutStr(k, v)
-	}
-}
-
 type exemplarKey struct {
 	ScopeName    string
 	ScopeVersion string
@@ -7,7 +7,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"strings"
 	"sync"
@@ -18,7 +17,6 @@ import (
 	remoteapi "github.com/prometheus/client_golang/exp/api/remote"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
-	"github.com/prometheus/prometheus/model/value"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/prometheus/prometheus/schema"
 	promremote "github.com/prometheus/prometheus/storage/remote"
@@ -621,7 +619,7 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 		exemplarSlice := pmetric.NewExemplarSlice()
 		// Process the individual histogram
 		if histogramType == "nhcb" {
-			prw.addNHCBDatapoint(histMetric.Histogram().DataPoints(), histogram, attrs, stats)
+			prw.addNHCBDatapoint(histMetric.Histogram().DataPoints(), histogram, attrs, ls, stats)
 			if histMetric.Histogram().DataPoints().Len() > 0 {
 				exemplarSlice = histMetric.Histogram().DataPoints().At(0).Exemplars()
 			}
@@ -690,203 +688,60 @@ func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labe
 }
 
 func (prw *prometheusRemoteWriteReceiver) addExponentialHistogramDatapoint(datapoints pmetric.ExponentialHistogramDataPointSlice, histogram *writev2.Histogram, attrs pcommon.Map, ls labels.Labels, stats *promremote.WriteResponseStats) {
+	h := nativeHistogram(histogram)
 	// Drop Native Histogram with negative counts
-	if hasNegativeCounts(histogram) {
-		prw.settings.Logger.Info("Dropping Native Histogram series with negative counts",
-			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")})
+	if err := h.Validate(); err != nil {
+		prw.settings.Logger.Info("Dropping Native Histogram series",
+			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")},
+			zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
 		return
 	}
 
 	dp := datapoints.AppendEmpty()
 	dp.SetStartTimestamp(pcommon.Timestamp(histogram.StartTimestamp * int64(time.Millisecond)))
 	dp.SetTimestamp(pcommon.Timestamp(histogram.Timestamp * int64(time.Millisecond)))
-	dp.SetScale(histogram.Schema)
-	dp.SetZeroThreshold(histogram.ZeroThreshold)
-
-	// Set count and sum using common helper
-	if value.IsStaleNaN(histogram.Sum) {
-		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
-	} else {
-		setCountAndSum(histogram, dp)
-	}
-
-	// The maximum bucket index is derived from the formula (2^(2^-n))^i <= MaxFloat64.
-	// MaxFloat64 is approx 2^1024. So (2^-n) * i <= 1024 => i <= 1024 * 2^n.
-	// The bucket containing MaxFloat64 has index i_max = 1024 * 2^n.
-	// The next bucket (i_max + 1) is the +Inf overflow bucket, which is also allowed.
-	// Buckets with an index strictly greater than i_max + 1 must be dropped.
-	// See https://prometheus.io/docs/specs/native_histograms/#schema for more information.
-	overflowLimit := int32(math.Ldexp(1024, int(histogram.Schema))) + 1
-	var droppedCount uint64
-
-	// The difference between float and integer histograms is that float histograms are stored as absolute counts
-	// while integer histograms are stored as deltas.
-	if histogram.IsFloatHistogram() {
-		// Float histograms
-		zeroCountFloat := histogram.GetZeroCountFloat()
-		dp.SetZeroCount(uint64(zeroCountFloat))
-
-		if len(histogram.PositiveSpans) > 0 {
-			dp.Positive().SetOffset(histogram.PositiveSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-			droppedCount += convertAbsoluteBuckets(histogram.PositiveSpans, histogram.PositiveCounts, dp.Positive().BucketCounts(), overflowLimit)
-		}
-		if len(histogram.NegativeSpans) > 0 {
-			dp.Negative().SetOffset(histogram.NegativeSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-			droppedCount += convertAbsoluteBuckets(histogram.NegativeSpans, histogram.NegativeCounts, dp.Negative().BucketCounts(), overflowLimit)
-		}
-	} else {
-		// Integer histograms
-		zeroCountInt := histogram.GetZeroCountInt()
-		dp.SetZeroCount(zeroCountInt)
-
-		if len(histogram.PositiveSpans) > 0 {
-			dp.Positive().SetOffset(histogram.PositiveSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-			droppedCount += convertDeltaBuckets(histogram.PositiveSpans, histogram.PositiveDeltas, dp.Positive().BucketCounts(), overflowLimit)
-		}
-		if len(histogram.NegativeSpans) > 0 {
-			dp.Negative().SetOffset(histogram.NegativeSpans[0].Offset - 1) // -1 because OTEL offset are for the lower bound, not the upper bound
-			droppedCount += convertDeltaBuckets(histogram.NegativeSpans, histogram.NegativeDeltas, dp.Negative().BucketCounts(), overflowLimit)
-		}
-	}
-
-	if droppedCount > 0 && !value.IsStaleNaN(histogram.Sum) {
-		count := dp.Count()
-		if droppedCount > count {
-			prw.settings.Logger.Info("Clamping Native Histogram count to zero due to inconsistent dropped overflow bucket count",
-				zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")})
-			dp.SetCount(0)
-		} else {
-			dp.SetCount(count - droppedCount)
-		}
-	}
+	prometheus.NativeHistogramToExponentialHistogramDataPoint(h, dp)
 
 	attrs.CopyTo(dp.Attributes())
 	stats.Histograms++
 }
 
-// hasNegativeCounts checks if a histogram has any negative counts
-func hasNegativeCounts(histogram *writev2.Histogram) bool {
+// nativeHistogram returns the remote write histogram in the representation shared with the Prometheus receiver.
+func nativeHistogram(histogram *writev2.Histogram) *prometheus.NativeHistogram {
+	h := &prometheus.NativeHistogram{
+		Schema:        histogram.Schema,
+		ZeroThreshold: histogram.ZeroThreshold,
+		Sum:           histogram.Sum,
+		PositiveSpans: convertSpans(histogram.PositiveSpans),
+		NegativeSpans: convertSpans(histogram.NegativeSpans),
+		CustomValues:  histogram.CustomValues,
+	}
+	// The difference between float and integer histograms is that float histograms are stored as absolute counts
+	// while integer histograms are stored as deltas.
 	if histogram.IsFloatHistogram() {
-		// Check overall count
-		if histogram.GetCountFloat() < 0 {
-			return true
-		}
-
-		// Check zero count
-		if histogram.GetZeroCountFloat() < 0 {
-			return true
-		}
-
-		// Check positive bucket counts
-		for _, count := range histogram.PositiveCounts {
-			if count < 0 {
-				return true
-			}
-		}
-
-		// Check negative bucket counts
-		for _, count := range histogram.NegativeCounts {
-			if count < 0 {
-				return true
-			}
-		}
+		h.IsFloat = true
+		h.FloatCount = histogram.GetCountFloat()
+		h.FloatZeroCount = histogram.GetZeroCountFloat()
+		h.PositiveCounts = histogram.PositiveCounts
+		h.NegativeCounts = histogram.NegativeCounts
 	} else {
-		// Integer histograms
-		var absolute int64
-		for _, delta := range histogram.NegativeDeltas {
-			absolute += delta
-			if absolute < 0 {
-				return true
-			}
-		}
-
-		absolute = 0
-		for _, delta := range histogram.PositiveDeltas {
-			absolute += delta
-			if absolute < 0 {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
-// convertDeltaBuckets converts Prometheus native histogram spans and deltas to OpenTelemetry bucket counts
-// For integer buckets, the values are deltas between the buckets. i.e a bucket list of 1,2,-2 would correspond to a bucket count of 1,3,1
-func convertDeltaBuckets(spans []writev2.BucketSpan, deltas []int64, buckets pcommon.UInt64Slice, overflowLimit int32) uint64 {
-	// The total capacity is the sum of the deltas and the offsets of the spans.
-	totalCapacity := len(deltas)
-	for _, span := range spans {
-		totalCapacity += int(span.Offset)
-	}
-	buckets.EnsureCapacity(totalCapacity)
-
-	bucketIdx := 0
-	bucketCount := int64(0)
-	var droppedCount uint64
-	initialOffset := spans[0].Offset
-	k := initialOffset
-
-	for spanIdx, span := range spans {
-		if spanIdx > 0 {
-			for i := int32(0); i < span.Offset; i++ {
-				if k <= overflowLimit {
-					buckets.Append(uint64(0))
-				}
-				k++
-			}
-		}
-		for i := uint32(0); i < span.Length; i++ {
-			bucketCount += deltas[bucketIdx]
-			bucketIdx++
-
-			if k <= overflowLimit {
-				buckets.Append(uint64(bucketCount))
-			} else {
-				droppedCount += uint64(bucketCount)
-			}
-			k++
-		}
+		h.Count = histogram.GetCountInt()
+		h.ZeroCount = histogram.GetZeroCountInt()
+		h.PositiveDeltas = histogram.PositiveDeltas
+		h.NegativeDeltas = histogram.NegativeDeltas
 	}
-	return droppedCount
+	return h
 }
 
-// convertAbsoluteBuckets converts Prometheus native histogram spans and absolute counts to OpenTelemetry bucket counts
-// For float buckets, the values are absolute counts, and must be 0 or positive.
-func convertAbsoluteBuckets(spans []writev2.BucketSpan, counts []float64, buckets pcommon.UInt64Slice, overflowLimit int32) uint64 {
-	// The total capacity is the sum of the counts and the offsets of the spans.
-	totalCapacity := len(counts)
-	for _, span := range spans {
-		totalCapacity += int(span.Offset)
+func convertSpans(spans []writev2.BucketSpan) []prometheus.BucketSpan {
+	if len(spans) == 0 {
+		return nil
 	}
-	buckets.EnsureCapacity(totalCapacity)
-
-	bucketIdx := 0
-	var droppedCount uint64
-	initialOffset := spans[0].Offset
-	k := initialOffset
-
-	for spanIdx, span := range spans {
-		if spanIdx > 0 {
-			for i := int32(0); i < span.Offset; i++ {
-				if k <= overflowLimit {
-					buckets.Append(uint64(0))
-				}
-				k++
-			}
-		}
-		for i := uint32(0); i < span.Length; i++ {
-			if k <= overflowLimit {
-				buckets.Append(uint64(counts[bucketIdx]))
-			} else {
-				droppedCount += uint64(counts[bucketIdx])
-			}
-			bucketIdx++
-			k++
-		}
+	converted := make([]prometheus.BucketSpan, len(spans))
+	for i, span := range spans {
+		converted[i] = prometheus.BucketSpan{Offset: span.Offset, Length: span.Length}
 	}
-	return droppedCount
+	return converted
 }
 
 // extractAttributes returns metric data point attributes, excluding job, instance, metric name, and all otel_scope_* labels.
@@ -944,94 +799,23 @@ func applyScopeInfo(sm pmetric.ScopeMetrics, si scopeInfo) {
 }
 
 // addNHCBDatapoint converts a single Native Histogram Custom Buckets (NHCB) to OpenTelemetry histogram datapoints
-func (*prometheusRemoteWriteReceiver) addNHCBDatapoint(datapoints pmetric.HistogramDataPointSlice, histogram *writev2.Histogram, attrs pcommon.Map, stats *promremote.WriteResponseStats) {
+func (prw *prometheusRemoteWriteReceiver) addNHCBDatapoint(datapoints pmetric.HistogramDataPointSlice, histogram *writev2.Histogram, attrs pcommon.Map, ls labels.Labels, stats *promremote.WriteResponseStats) {
 	if len(histogram.CustomValues) == 0 {
 		return
 	}
+	h := nativeHistogram(histogram)
+	if err := h.Validate(); err != nil {
+		prw.settings.Logger.Info("Dropping Native Histogram Custom Buckets series",
+			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")},
+			zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+		return
+	}
 
 	dp := datapoints.AppendEmpty()
 	dp.SetStartTimestamp(pcommon.Timestamp(histogram.StartTimestamp * int64(time.Millisecond)))
 	dp.SetTimestamp(pcommon.Timestamp(histogram.Timestamp * int64(time.Millisecond)))
-
-	if value.IsStaleNaN(histogram.Sum) {
-		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
-	} else {
-		setCountAndSum(histogram, dp)
-	}
-
-	dp.ExplicitBounds().FromRaw(histogram.CustomValues)
-	bucketCounts := convertNHCBBuckets(histogram)
-	dp.BucketCounts().FromRaw(bucketCounts)
+	prometheus.NativeHistogramToHistogramDataPoint(h, dp)
 
 	attrs.CopyTo(dp.Attributes())
 	stats.Histograms++
 }
-
-// convertNHCBBuckets converts NHCB bucket data to OpenTelemetry bucket counts
-func convertNHCBBuckets(histogram *writev2.Histogram) []uint64 {
-	// For NHCB, we need numExplicitBounds + 1 buckets (including the final +inf bucket)
-	bucketCounts := make([]uint64, len(histogram.CustomValues)+1)
-
-	// NHCB uses the positive bucket list and spans for all buckets
-	if len(histogram.PositiveSpans) == 0 {
-		return bucketCounts
-	}
-
-	if histogram.IsFloatHistogram() {
-		// Float histograms: values are absolute counts
-		bucketIdx := 0
-		for _, span := range histogram.PositiveSpans {
-			// Skip empty buckets based on offset
-			bucketIdx += int(span.Offset)
-
-			// Fill buckets for this span
-			for i := uint32(0); i < span.Length && bucketIdx < len(bucketCounts) && i < uint32(len(histogram.PositiveCounts)); i++ {
-				if bucketIdx >= 0 && bucketIdx < len(bucketCounts) {
-					bucketCounts[bucketIdx] = uint64(histogram.PositiveCounts[i])
-				}
-				bucketIdx++
-			}
-		}
-	} else {
-		// Integer histograms: values are deltas between buckets
-		bucketIdx := 0
-		bucketCount := int64(0)
-		deltaIdx := 0
-
-		for _, span := range histogram.PositiveSpans {
-			// Skip empty buckets based on offset
-			bucketIdx += int(span.Offset)
-
-			// Fill buckets for this span
-			for i := uint32(0); i < span.Length && bucketIdx < len(bucketCounts) && deltaIdx < len(histogram.PositiveDeltas); i++ {
-				bucketCount += histogram.PositiveDeltas[deltaIdx]
-				deltaIdx++
-
-				if bucketIdx >= 0 && bucketIdx < len(bucketCounts) {
-					bucketCounts[bucketIdx] = uint64(bucketCount)
-				}
-				bucketIdx++
-			}
-		}
-	}
-
-	return bucketCounts
-}
-
-// setCountAndSum sets count and sum for histogram datapoints (common interface)
-type countSumSetter interface {
-	SetSum(float64)
-	SetCount(uint64)
-}
-
-func setCountAndSum(histogram *writev2.Histogram, dp countSumSetter) {
-	dp.SetSum(histogram.Sum)
-
-	if histogram.IsFloatHistogram() {
-		countFloat := histogram.GetCountFloat()
-		dp.SetCount(uint64(countFloat))
-	} else {
-		countInt := histogram.GetCountInt()
-		dp.SetCount(countInt)
-	}
-}
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"testing"
@@ -21,6 +24,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	remoteapi "github.com/prometheus/client_golang/exp/api/remote"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/prometheus/prometheus/storage/remote"
@@ -43,6 +47,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver/internal/metadata"
@@ -3776,3 +3781,70 @@ func TestInvalidSchemaLogging(t *testing.T) {
 	assert.Equal(t, "test_instance", logs[0].ContextMap()["instance"])
 	assert.Equal(t, int64(123456789), logs[0].ContextMap()["timestamp"])
 }
+
+// The native histograms golden files are shared with pkg/translator/prometheus and the Prometheus receiver,
+// which must convert the same histograms to byte-identical data points.
+func TestNativeHistogramGolden(t *testing.T) {
+	dir := filepath.Join("..", "..", "pkg", "translator", "prometheus", "testdata", "native_histograms")
+	cases, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, cases)
+
+	prw := setupMetricsReceiver(t)
+	for _, c := range cases {
+		t.Run(c.Name(), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, c.Name(), "histogram.json"))
+			require.NoError(t, err)
+			var nh prometheus.NativeHistogram
+			require.NoError(t, json.Unmarshal(data, &nh))
+
+			h := &writev2.Histogram{
+				Schema:         nh.Schema,
+				ZeroThreshold:  nh.ZeroThreshold,
+				Sum:            nh.Sum,
+				PositiveSpans:  toWriteV2Spans(nh.PositiveSpans),
+				NegativeSpans:  toWriteV2Spans(nh.NegativeSpans),
+				PositiveDeltas: nh.PositiveDeltas,
+				NegativeDeltas: nh.NegativeDeltas,
+				PositiveCounts: nh.PositiveCounts,
+				NegativeCounts: nh.NegativeCounts,
+				CustomValues:   nh.CustomValues,
+			}
+			if nh.IsFloat {
+				h.Count = &writev2.Histogram_CountFloat{CountFloat: nh.FloatCount}
+				h.ZeroCount = &writev2.Histogram_ZeroCountFloat{ZeroCountFloat: nh.FloatZeroCount}
+			} else {
+				h.Count = &writev2.Histogram_CountInt{CountInt: nh.Count}
+				h.ZeroCount = &writev2.Histogram_ZeroCountInt{ZeroCountInt: nh.ZeroCount}
+			}
+
+			actual := pmetric.NewMetrics()
+			metric := actual.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetName(c.Name())
+			stats := remote.WriteResponseStats{}
+			if nh.IsCustomBuckets() {
+				prw.addNHCBDatapoint(metric.SetEmptyHistogram().DataPoints(), h, pcommon.NewMap(), labels.EmptyLabels(), &stats)
+			} else {
+				prw.addExponentialHistogramDatapoint(metric.SetEmptyExponentialHistogram().DataPoints(), h, pcommon.NewMap(), labels.EmptyLabels(), &stats)
+			}
+			require.Equal(t, 1, stats.Histograms)
+
+			expected, err := golden.ReadMetrics(filepath.Join(dir, c.Name(), "expected.yaml"))
+			require.NoError(t, err)
+			marshaler := &pmetric.ProtoMarshaler{}
+			expectedBytes, err := marshaler.MarshalMetrics(expected)
+			require.NoError(t, err)
+			actualBytes, err := marshaler.MarshalMetrics(actual)
+			require.NoError(t, err)
+			assert.Equal(t, expectedBytes, actualBytes)
+		})
+	}
+}
+
+func toWriteV2Spans(spans []prometheus.BucketSpan) []writev2.BucketSpan {
+	converted := make([]writev2.BucketSpan, len(spans))
+	for i, span := range spans {
+		converted[i] = writev2.BucketSpan{Offset: span.Offset, Length: span.Length}
+	}
+	return converted
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+)
+
+var (
+	errStreamRequired           = errors.New("stream is required")
+	errGroupRequired            = errors.New("consumer::group is required")
+	errNonPositiveCount         = errors.New("consumer::count must be positive")
+	errNonPositiveBlock         = errors.New("consumer::block must be positive")
+	errNonPositiveClaimMinIdle  = errors.New("consumer::claim_min_idle must be positive")
+	errNonPositiveClaimInterval = errors.New("consumer::claim_interval must be positive")
+	errNegativeMaxDeliveries    = errors.New("consumer::max_deliveries must not be negative")
+)
+
+// Config defines configuration for the Redis Streams receiver.
+type Config struct {
+	ClientConfig redisstreams.ClientConfig `mapstructure:",squash"`
+
+	// Consumer holds the settings of the consumer groups, shared by the signal types.
+	Consumer ConsumerConfig `mapstructure:"consumer"`
+
+	// Logs holds configuration about how logs should be consumed.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be consumed.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be consumed.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds signal-specific configuration for the Redis Streams receiver.
+type SignalConfig struct {
+	// Stream is the key of the stream the entries are read from. The stream and the
+	// consumer group are created if they don't exist.
+	//
+	// The default depends on the signal type:
+	//  - "otlp:traces" for traces
+	//  - "otlp:metrics" for metrics
+	//  - "otlp:logs" for logs
+	Stream string `mapstructure:"stream"`
+
+	// Encoding holds the encoding of the entries for the signal type, either "otlp_proto",
+	// "otlp_json" or the ID of an encoding extension.
+	//
+	// Defaults to "otlp_proto".
+	Encoding string `mapstructure:"encoding"`
+}
+
+// ConsumerConfig holds the settings of the consumer groups.
+type ConsumerConfig struct {
+	// Group is the name of the consumer group reading the streams (default "otelcol").
+	Group string `mapstructure:"group"`
+
+	// Name is the name of the consumer in the group. It must be unique among the
+	// collectors sharing the group. Defaults to the hostname.
+	Name string `mapstructure:"name"`
+
+	// Count is the maximum number of entries read or claimed at once (default 100).
+	Count int64 `mapstructure:"count"`
+
+	// Block is the time waited for new entries when the stream has none. It also bounds
+	// the time needed to stop the receiver (default 1s).
+	Block time.Duration `mapstructure:"block"`
+
+	// ClaimMinIdle is the time after which the entries delivered to a consumer of the
+	// group and not acknowledged are claimed (default 1m).
+	ClaimMinIdle time.Duration `mapstructure:"claim_min_idle"`
+
+	// ClaimInterval is the interval at which the idle entries are claimed (default 30s).
+	ClaimInterval time.Duration `mapstructure:"claim_interval"`
+
+	// MaxDeliveries is the number of times an entry is delivered to the consumers of the
+	// group before being acknowledged and dropped, when its data keeps failing to be
+	// consumed. Entries are delivered again until they are consumed when it is 0 (default 10).
+	MaxDeliveries int64 `mapstructure:"max_deliveries"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (c *Config) Validate() error {
+	var errs []error
+	if err := c.Consumer.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.Logs.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("logs::%w", err))
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::%w", err))
+	}
+	if err := c.Traces.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("traces::%w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (c ConsumerConfig) Validate() error {
+	var errs []error
+	if c.Group == "" {
+		errs = append(errs, errGroupRequired)
+	}
+	if c.Count <= 0 {
+		errs = append(errs, errNonPositiveCount)
+	}
+	if c.Block <= 0 {
+		errs = append(errs, errNonPositiveBlock)
+	}
+	if c.ClaimMinIdle <= 0 {
+		errs = append(errs, errNonPositiveClaimMinIdle)
+	}
+	if c.ClaimInterval <= 0 {
+		errs = append(errs, errNonPositiveClaimInterval)
+	}
+	if c.MaxDeliveries < 0 {
+		errs = append(errs, errNegativeMaxDeliveries)
+	}
+	return errors.Join(errs...)
+}
+
+func (c SignalConfig) Validate() error {
+	if c.Stream == "" {
+		return errStreamRequired
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    func(*Config)
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func(*Config) {},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all"),
+			expected: func(cfg *Config) {
+				cfg.ClientConfig.Endpoint = "redis:6379"
+				cfg.ClientConfig.Username = "collector"
+				cfg.ClientConfig.Password = "pass"
+				cfg.ClientConfig.DB = 1
+				cfg.Consumer = ConsumerConfig{
+					Group:         "gateways",
+					Name:          "gateway-0",
+					Count:         10,
+					Block:         5 * time.Second,
+					ClaimMinIdle:  5 * time.Minute,
+					ClaimInterval: time.Minute,
+					MaxDeliveries: 3,
+				}
+				cfg.Logs = SignalConfig{
+					Stream:   "agents:logs",
+					Encoding: "otlp_json",
+				}
+				cfg.Traces.Encoding = "jaeger_encoding"
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_stream"),
+			expectedErr: "metrics::stream is required",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_consumer"),
+			expectedErr: "consumer::group is required\n" +
+				"consumer::count must be positive\n" +
+				"consumer::block must be positive\n" +
+				"consumer::claim_min_idle must be positive\n" +
+				"consumer::claim_interval must be positive\n" +
+				"consumer::max_deliveries must not be negative",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			expectedErr: "endpoint is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createDefaultConfig().(*Config)
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
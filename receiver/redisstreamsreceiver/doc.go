@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate make mdatagen
+
+// Package redisstreamsreceiver receives telemetry from Redis Streams.
+package redisstreamsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver"
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver/internal/metadata"
+)
+
+const (
+	defaultEncoding      = "otlp_proto"
+	defaultLogsStream    = "otlp:logs"
+	defaultMetricsStream = "otlp:metrics"
+	defaultTracesStream  = "otlp:traces"
+	defaultGroup         = "otelcol"
+	defaultCount         = 100
+	defaultBlock         = time.Second
+	defaultClaimMinIdle  = time.Minute
+	defaultClaimInterval = 30 * time.Second
+	defaultMaxDeliveries = 10
+)
+
+// NewFactory creates the Redis Streams receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: redisstreams.NewDefaultClientConfig(),
+		Consumer: ConsumerConfig{
+			Group:         defaultGroup,
+			Count:         defaultCount,
+			Block:         defaultBlock,
+			ClaimMinIdle:  defaultClaimMinIdle,
+			ClaimInterval: defaultClaimInterval,
+			MaxDeliveries: defaultMaxDeliveries,
+		},
+		Logs: SignalConfig{
+			Stream:   defaultLogsStream,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Stream:   defaultMetricsStream,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Stream:   defaultTracesStream,
+			Encoding: defaultEncoding,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	oCfg := cfg.(*Config)
+	return newRedisReceiver(oCfg, set, oCfg.Traces, messaging.NewTracesHandler(nextConsumer))
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	oCfg := cfg.(*Config)
+	return newRedisReceiver(oCfg, set, oCfg.Metrics, messaging.NewMetricsHandler(nextConsumer))
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	oCfg := cfg.(*Config)
+	return newRedisReceiver(oCfg, set, oCfg.Logs, messaging.NewLogsHandler(nextConsumer))
+}
@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+)
+
+const (
+	transport = "redis"
+
+	// claimStart is the ID from which the pending entries of the group are claimed.
+	claimStart = "0-0"
+)
+
+// redisReceiver reads the entries of a signal type through a consumer group, and
+// acknowledges them once their data has been consumed.
+type redisReceiver struct {
+	cfg     *Config
+	signal  SignalConfig
+	logger  *zap.Logger
+	obsrecv *receiverhelper.ObsReport
+	handler messaging.SignalHandler
+
+	consumer string
+	client   *redis.Client
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func newRedisReceiver(cfg *Config, set receiver.Settings, signal SignalConfig, handler messaging.SignalHandler) (*redisReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &redisReceiver{
+		cfg:     cfg,
+		signal:  signal,
+		logger:  set.Logger,
+		obsrecv: obsrecv,
+		handler: handler,
+	}, nil
+}
+
+func (r *redisReceiver) Start(ctx context.Context, host component.Host) error {
+	if err := r.handler.Start(host, r.signal.Encoding); err != nil {
+		return err
+	}
+	r.consumer = r.cfg.Consumer.Name
+	if r.consumer == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("failed to get the hostname for the consumer name: %w", err)
+		}
+		r.consumer = hostname
+	}
+
+	c, err := redisstreams.Connect(ctx, r.cfg.ClientConfig)
+	if err != nil {
+		return err
+	}
+	r.client = c
+
+	// The group reads the entries already in the stream when it is created.
+	err = c.XGroupCreateMkStream(ctx, r.signal.Stream, r.cfg.Consumer.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %q on stream %q: %w", r.cfg.Consumer.Group, r.signal.Stream, err)
+	}
+
+	var consumeCtx context.Context
+	consumeCtx, r.cancel = context.WithCancel(context.Background())
+	r.done = make(chan struct{})
+	go r.consume(consumeCtx)
+	return nil
+}
+
+func (r *redisReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		// The entries not acknowledged yet are claimed by the other consumers of the group,
+		// or by this consumer once restarted.
+		r.cancel()
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if r.client != nil {
+		return r.client.Close()
+	}
+	return nil
+}
+
+// consume reads the new entries of the stream until the context is canceled, and
+// periodically claims the entries left pending by the consumers of the group.
+func (r *redisReceiver) consume(ctx context.Context) {
+	defer close(r.done)
+	var lastClaim time.Time
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= r.cfg.Consumer.ClaimInterval {
+			r.claim(ctx)
+			lastClaim = time.Now()
+		}
+		r.read(ctx)
+	}
+}
+
+func (r *redisReceiver) read(ctx context.Context) {
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    r.cfg.Consumer.Group,
+		Consumer: r.consumer,
+		Streams:  []string{r.signal.Stream, ">"},
+		Count:    r.cfg.Consumer.Count,
+		Block:    r.cfg.Consumer.Block,
+	}).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return
+	case err != nil:
+		if ctx.Err() == nil {
+			r.logger.Warn("Failed to read entries", zap.String("stream", r.signal.Stream), zap.Error(err))
+			r.wait(ctx)
+		}
+		return
+	}
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			r.handleEntry(ctx, msg)
+		}
+	}
+}
+
+// claim takes over the entries delivered to the consumers of the group, and not
+// acknowledged within the minimum idle time, and consumes them again. Entries already
+// delivered the maximum number of times are acknowledged and dropped instead.
+func (r *redisReceiver) claim(ctx context.Context) {
+	start := claimStart
+	for {
+		msgs, next, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   r.signal.Stream,
+			Group:    r.cfg.Consumer.Group,
+			Consumer: r.consumer,
+			MinIdle:  r.cfg.Consumer.ClaimMinIdle,
+			Start:    start,
+			Count:    r.cfg.Consumer.Count,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("Failed to claim pending entries", zap.String("stream", r.signal.Stream), zap.Error(err))
+			}
+			return
+		}
+		deliveries := r.deliveryCounts(ctx, msgs)
+		for _, msg := range msgs {
+			if count := deliveries[msg.ID]; r.cfg.Consumer.MaxDeliveries > 0 && count > r.cfg.Consumer.MaxDeliveries {
+				r.logger.Error("Dropping entry delivered too many times", zap.String("stream", r.signal.Stream), zap.String("id", msg.ID), zap.Int64("deliveries", count))
+				r.ack(ctx, msg.ID)
+				continue
+			}
+			r.handleEntry(ctx, msg)
+		}
+		if next == claimStart || ctx.Err() != nil {
+			return
+		}
+		start = next
+	}
+}
+
+// deliveryCounts returns the number of times the claimed entries have been delivered,
+// including the claim.
+func (r *redisReceiver) deliveryCounts(ctx context.Context, msgs []redis.XMessage) map[string]int64 {
+	if r.cfg.Consumer.MaxDeliveries == 0 || len(msgs) == 0 {
+		return nil
+	}
+	cmds := make([]*redis.XPendingExtCmd, 0, len(msgs))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, msg := range msgs {
+			cmds = append(cmds, pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+				Stream:   r.signal.Stream,
+				Group:    r.cfg.Consumer.Group,
+				Start:    msg.ID,
+				End:      msg.ID,
+				Count:    1,
+				Consumer: r.consumer,
+			}))
+		}
+		return nil
+	})
+	if err != nil {
+		// The entries are consumed again, and dropped on a later claim.
+		r.logger.Warn("Failed to get the delivery counts of the claimed entries", zap.String("stream", r.signal.Stream), zap.Error(err))
+		return nil
+	}
+	counts := make(map[string]int64, len(msgs))
+	for _, cmd := range cmds {
+		for _, pending := range cmd.Val() {
+			counts[pending.ID] = pending.RetryCount
+		}
+	}
+	return counts
+}
+
+// wait waits for the blocking time of the reads before reading again, after an error.
+func (r *redisReceiver) wait(ctx context.Context) {
+	timer := time.NewTimer(r.cfg.Consumer.Block)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// handleEntry acknowledges the entry once its data has been consumed. Entries whose data
+// failed to be consumed are left pending, to be claimed again up to the maximum number of
+// deliveries, unless the error is permanent.
+func (r *redisReceiver) handleEntry(ctx context.Context, msg redis.XMessage) {
+	var err error
+	if data, ok := msg.Values[redisstreams.DataField].(string); ok {
+		err = r.handler.Handle(contextWithMetadata(ctx, r.signal.Stream, msg.ID), r.obsrecv, []byte(data), nil)
+	} else {
+		err = consumererror.NewPermanent(fmt.Errorf("entry has no %q field", redisstreams.DataField))
+	}
+	switch {
+	case err == nil:
+	case consumererror.IsPermanent(err):
+		r.logger.Error("Dropping entry", zap.String("stream", r.signal.Stream), zap.String("id", msg.ID), zap.Error(err))
+	default:
+		r.logger.Warn("Failed to consume entry, it will be claimed again", zap.String("stream", r.signal.Stream), zap.String("id", msg.ID), zap.Error(err))
+		return
+	}
+	r.ack(ctx, msg.ID)
+}
+
+// ack acknowledges the entry, even if the receiver is stopping, as its data has been
+// consumed or dropped.
+func (r *redisReceiver) ack(ctx context.Context, id string) {
+	if err := r.client.XAck(context.WithoutCancel(ctx), r.signal.Stream, r.cfg.Consumer.Group, id).Err(); err != nil {
+		r.logger.Warn("Failed to acknowledge entry", zap.String("stream", r.signal.Stream), zap.String("id", id), zap.Error(err))
+	}
+}
+
+// contextWithMetadata propagates the stream and the ID of the entry as client metadata.
+func contextWithMetadata(ctx context.Context, stream, id string) context.Context {
+	return client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(map[string][]string{
+		"redis.stream": {stream},
+		"redis.id":     {id},
+	})})
+}
@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstreamsreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/redisstreams"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisstreamsreceiver/internal/metadata"
+)
+
+// runServer starts an in-memory Redis server, and returns a client of the server.
+func runServer(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	srv := miniredis.RunT(t)
+	c := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = c.Close() })
+	return srv, c
+}
+
+func newTestConfig(srv *miniredis.Miniredis) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = srv.Addr()
+	cfg.Consumer.Name = "collector"
+	cfg.Consumer.Block = 20 * time.Millisecond
+	cfg.Consumer.ClaimInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func startReceiver(t *testing.T, rcvr component.Component) {
+	require.NoError(t, rcvr.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+}
+
+func add(t *testing.T, c *redis.Client, stream string, values ...any) string {
+	id, err := c.XAdd(t.Context(), &redis.XAddArgs{Stream: stream, Values: values}).Result()
+	require.NoError(t, err)
+	return id
+}
+
+// requireAcknowledged waits for the entries delivered to the consumer group to be
+// acknowledged.
+func requireAcknowledged(t *testing.T, c *redis.Client, stream string) {
+	require.Eventually(t, func() bool {
+		pending, err := c.XPending(t.Context(), stream, defaultGroup).Result()
+		return err == nil && pending.Count == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func testLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "test")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message")
+	return logs
+}
+
+func testLogsData(t *testing.T) []byte {
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(testLogs())
+	require.NoError(t, err)
+	return data
+}
+
+func TestReadEntries(t *testing.T) {
+	srv, c := runServer(t)
+	// The entries added before the consumer group is created are read.
+	id := add(t, c, defaultLogsStream, redisstreams.DataField, testLogsData(t))
+
+	var mu sync.Mutex
+	var infos []client.Info
+	sink := &consumertest.LogsSink{}
+	next, err := consumer.NewLogs(func(ctx context.Context, logs plog.Logs) error {
+		mu.Lock()
+		infos = append(infos, client.FromContext(ctx))
+		mu.Unlock()
+		return sink.ConsumeLogs(ctx, logs)
+	})
+	require.NoError(t, err)
+
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), next)
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, testLogs(), sink.AllLogs()[0])
+	requireAcknowledged(t, c, defaultLogsStream)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, infos, 1)
+	assert.Equal(t, []string{defaultLogsStream}, infos[0].Metadata.Get("redis.stream"))
+	assert.Equal(t, []string{id}, infos[0].Metadata.Get("redis.id"))
+}
+
+// failingConsumer fails to consume the logs with a transient error, the given number
+// of times, and counts its calls.
+type failingConsumer struct {
+	mu       sync.Mutex
+	calls    int
+	failures int
+}
+
+func (c *failingConsumer) consumer(t *testing.T) consumer.Logs {
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.calls++
+		if c.failures < 0 || c.calls <= c.failures {
+			return errors.New("pipeline unavailable")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return next
+}
+
+func (c *failingConsumer) getCalls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestClaimAfterMinIdle(t *testing.T) {
+	srv, c := runServer(t)
+
+	next := &failingConsumer{failures: 1}
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), next.consumer(t))
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	add(t, c, defaultLogsStream, redisstreams.DataField, testLogsData(t))
+	require.Eventually(t, func() bool { return next.getCalls() == 1 }, 10*time.Second, 10*time.Millisecond)
+
+	// The entry is left pending, and not claimed until it has been idle for claim_min_idle.
+	assert.Never(t, func() bool { return next.getCalls() > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+	pending, err := c.XPending(t.Context(), defaultLogsStream, defaultGroup).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pending.Count)
+
+	srv.SetTime(time.Now().Add(defaultClaimMinIdle))
+	requireAcknowledged(t, c, defaultLogsStream)
+	assert.Equal(t, 2, next.getCalls())
+}
+
+func TestMaxDeliveries(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxDeliveries int64
+		expectedCalls int
+		acknowledged  bool
+	}{
+		{
+			name:          "limited",
+			maxDeliveries: 2,
+			expectedCalls: 2,
+			acknowledged:  true,
+		},
+		{
+			name:          "unlimited",
+			maxDeliveries: 0,
+			expectedCalls: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, c := runServer(t)
+
+			next := &failingConsumer{failures: -1}
+			cfg := newTestConfig(srv)
+			cfg.Consumer.MaxDeliveries = tt.maxDeliveries
+			rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, next.consumer(t))
+			require.NoError(t, err)
+			startReceiver(t, rcvr)
+
+			add(t, c, defaultLogsStream, redisstreams.DataField, testLogsData(t))
+			require.Eventually(t, func() bool { return next.getCalls() == 1 }, 10*time.Second, 10*time.Millisecond)
+
+			// Each claim delivers the entry again.
+			now := time.Now()
+			for i := 1; i <= 3; i++ {
+				srv.SetTime(now.Add(time.Duration(i) * defaultClaimMinIdle))
+				require.Eventually(t, func() bool {
+					pending, err := c.XPendingExt(t.Context(), &redis.XPendingExtArgs{
+						Stream: defaultLogsStream,
+						Group:  defaultGroup,
+						Start:  "-",
+						End:    "+",
+						Count:  1,
+					}).Result()
+					require.NoError(t, err)
+					return len(pending) == 0 || pending[0].RetryCount == int64(i+1)
+				}, 10*time.Second, 10*time.Millisecond)
+			}
+
+			if tt.acknowledged {
+				requireAcknowledged(t, c, defaultLogsStream)
+			} else {
+				require.Eventually(t, func() bool { return next.getCalls() == tt.expectedCalls }, 10*time.Second, 10*time.Millisecond)
+			}
+			assert.Never(t, func() bool { return next.getCalls() > tt.expectedCalls }, 100*time.Millisecond, 10*time.Millisecond)
+			assert.Equal(t, tt.expectedCalls, next.getCalls())
+		})
+	}
+}
+
+func TestClaimFromOtherConsumer(t *testing.T) {
+	srv, c := runServer(t)
+
+	// An entry is delivered to a consumer that stops before acknowledging it.
+	require.NoError(t, c.XGroupCreateMkStream(t.Context(), defaultLogsStream, defaultGroup, "0").Err())
+	add(t, c, defaultLogsStream, redisstreams.DataField, testLogsData(t))
+	require.NoError(t, c.XReadGroup(t.Context(), &redis.XReadGroupArgs{
+		Group:    defaultGroup,
+		Consumer: "stopped",
+		Streams:  []string{defaultLogsStream, ">"},
+	}).Err())
+	srv.SetTime(time.Now().Add(defaultClaimMinIdle))
+
+	sink := &consumertest.LogsSink{}
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), sink)
+	require.NoError(t, err)
+	startReceiver(t, rcvr)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+	requireAcknowledged(t, c, defaultLogsStream)
+}
+
+func TestAcknowledgeOnPermanentError(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []any
+		next   consumer.Logs
+	}{
+		{
+			name:   "missing data field",
+			values: []any{"payload", "logs"},
+			next:   consumertest.NewNop(),
+		},
+		{
+			name:   "unmarshal error",
+			values: []any{redisstreams.DataField, []byte{0x0a, 0xff}},
+			next:   consumertest.NewNop(),
+		},
+		{
+			name:   "permanent consumer error",
+			values: []any{redisstreams.DataField, testLogsData(t)},
+			next:   consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid logs"))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, c := runServer(t)
+
+			rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), tt.next)
+			require.NoError(t, err)
+			startReceiver(t, rcvr)
+
+			id := add(t, c, defaultLogsStream, tt.values...)
+
+			// Dropped entries are acknowledged, and never claimed again.
+			require.Eventually(t, func() bool {
+				groups, err := c.XInfoGroups(t.Context(), defaultLogsStream).Result()
+				return err == nil && len(groups) == 1 && groups[0].LastDeliveredID == id && groups[0].Pending == 0
+			}, 10*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	srv, c := runServer(t)
+
+	require.NoError(t, c.Set(t.Context(), defaultLogsStream, "value", 0).Err())
+	rcvr, err := createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), newTestConfig(srv), consumertest.NewNop())
+	require.NoError(t, err)
+	err = rcvr.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `failed to create consumer group "otelcol" on stream "otlp:logs"`)
+	assert.NoError(t, rcvr.Shutdown(t.Context()))
+
+	cfg := newTestConfig(srv)
+	srv.Close()
+	rcvr, err = createLogsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	err = rcvr.Start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to connect to")
+	assert.NoError(t, rcvr.Shutdown(t.Context()))
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Config Defaults
+const (
+	defaultEndpoint = "udp://localhost:162"
+)
+
+var (
+	// Config error messages
+	errMsgInvalidEndpointWError = `invalid endpoint '%s': must be in '[scheme]://[host]:[port]' format: %w`
+	errMsgInvalidEndpoint       = `invalid endpoint '%s': must be in '[scheme]://[host]:[port]' format`
+	errMsgDuplicateUser         = `user '%s' is configured more than once`
+	errMsgUser                  = `user '%s': %w`
+
+	// Config errors
+	errEmptyEndpoint        = errors.New("endpoint must be specified")
+	errEndpointBadScheme    = errors.New("endpoint scheme must be udp")
+	errBadEngineID          = errors.New("engine_id must be a hex encoded string of 5 to 32 bytes")
+	errEmptyUser            = errors.New("user must be specified")
+	errEmptySecurityLevel   = errors.New("security_level must be specified")
+	errBadSecurityLevel     = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
+	errEmptyAuthType        = errors.New("auth_type must be specified when security_level is auth_no_priv or auth_priv")
+	errBadAuthType          = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
+	errEmptyAuthPassword    = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
+	errEmptyPrivacyType     = errors.New("privacy_type must be specified when security_level is auth_priv")
+	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
+	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
+)
+
+// Config defines the configuration for the SNMP trap receiver.
+type Config struct {
+	// Endpoint is the address to listen for traps on. Must be formatted as udp://{host}:{port}.
+	// Default: udp://localhost:162
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Communities restricts v1 and v2c traps to the listed community strings.
+	// When empty, traps are accepted regardless of their community.
+	Communities []configopaque.String `mapstructure:"communities"`
+
+	// EngineID is the hex encoded authoritative engine ID of the receiver. It is the engine ID
+	// senders of v3 informs discover and localize their keys with. v3 traps are authenticated
+	// with the engine ID of their sender and do not need it.
+	EngineID string `mapstructure:"engine_id"`
+
+	// Users are the SNMP v3 users that traps and informs are accepted from.
+	// v3 messages from any other user are dropped.
+	Users []UserConfig `mapstructure:"users"`
+
+	// MIBPaths are MIB files, or directories of MIB files, used to resolve OIDs to names.
+	// The base SMI and SNMPv2-MIB notification objects are always known.
+	MIBPaths []string `mapstructure:"mib_paths"`
+}
+
+// UserConfig holds the SNMP v3 credentials of a single user.
+type UserConfig struct {
+	// User is the SNMP v3 user name.
+	User string `mapstructure:"user"`
+
+	// SecurityLevel is the security level messages from this user must use.
+	// Valid options: “no_auth_no_priv”, “auth_no_priv”, “auth_priv”
+	SecurityLevel string `mapstructure:"security_level"`
+
+	// AuthType is the type of authentication protocol used by this user.
+	// Only valid if “no_auth_no_priv” is not selected for SecurityLevel
+	// Valid options: “md5”, “sha”, “sha224”, “sha256”, “sha384”, “sha512”
+	AuthType string `mapstructure:"auth_type"`
+
+	// AuthPassword is the authentication password of this user.
+	// Only valid if "no_auth_no_priv" is not selected for SecurityLevel
+	AuthPassword configopaque.String `mapstructure:"auth_password"`
+
+	// PrivacyType is the type of privacy protocol used by this user.
+	// Only valid if "auth_priv" is selected for SecurityLevel
+	// Valid options: “des”, “aes”, “aes192”, “aes256”, “aes192c”, “aes256c”
+	PrivacyType string `mapstructure:"privacy_type"`
+
+	// PrivacyPassword is the privacy password of this user.
+	// Only valid if “auth_priv” is selected for SecurityLevel
+	PrivacyPassword configopaque.String `mapstructure:"privacy_password"`
+}
+
+// Validate validates the given config, returning an error specifying any issues with the config.
+func (cfg *Config) Validate() error {
+	var combinedErr error
+
+	combinedErr = errors.Join(combinedErr, validateEndpoint(cfg))
+	combinedErr = errors.Join(combinedErr, validateEngineID(cfg))
+
+	seen := map[string]bool{}
+	for _, user := range cfg.Users {
+		if err := user.validate(); err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgUser, user.User, err))
+		}
+		if seen[user.User] {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgDuplicateUser, user.User))
+		}
+		seen[user.User] = true
+	}
+
+	return combinedErr
+}
+
+// validateEndpoint validates the Endpoint
+func validateEndpoint(cfg *Config) error {
+	if cfg.Endpoint == "" {
+		return errEmptyEndpoint
+	}
+
+	// Ensure valid endpoint
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf(errMsgInvalidEndpointWError, cfg.Endpoint, err)
+	}
+	if u.Host == "" || u.Port() == "" {
+		return fmt.Errorf(errMsgInvalidEndpoint, cfg.Endpoint)
+	}
+
+	// Ensure valid scheme
+	switch strings.ToUpper(u.Scheme) {
+	case "UDP": // ok
+	default:
+		return errEndpointBadScheme
+	}
+
+	return nil
+}
+
+// validateEngineID validates the EngineID
+func validateEngineID(cfg *Config) error {
+	if cfg.EngineID == "" {
+		return nil
+	}
+
+	// RFC 3411 limits snmpEngineID to 5..32 octets
+	b, err := hex.DecodeString(strings.TrimPrefix(cfg.EngineID, "0x"))
+	if err != nil || len(b) < 5 || len(b) > 32 {
+		return errBadEngineID
+	}
+
+	return nil
+}
+
+// validate validates the credentials of a v3 user
+func (u *UserConfig) validate() error {
+	var combinedErr error
+
+	// Ensure valid user
+	if u.User == "" {
+		combinedErr = errors.Join(combinedErr, errEmptyUser)
+	}
+
+	if u.SecurityLevel == "" {
+		return errors.Join(combinedErr, errEmptySecurityLevel)
+	}
+
+	// Ensure valid security level
+	switch strings.ToUpper(u.SecurityLevel) {
+	case "NO_AUTH_NO_PRIV":
+		return combinedErr
+	case "AUTH_NO_PRIV":
+		// Ensure valid auth configs
+		return errors.Join(combinedErr, u.validateAuth())
+	case "AUTH_PRIV":
+		// Ensure valid auth and privacy configs
+		combinedErr = errors.Join(combinedErr, u.validateAuth())
+		return errors.Join(combinedErr, u.validatePrivacy())
+	default:
+		return errors.Join(combinedErr, errBadSecurityLevel)
+	}
+}
+
+// validateAuth validates the AuthType and AuthPassword
+func (u *UserConfig) validateAuth() error {
+	var combinedErr error
+
+	// Ensure valid auth password
+	if u.AuthPassword == "" {
+		combinedErr = errors.Join(combinedErr, errEmptyAuthPassword)
+	}
+
+	// Ensure valid auth type
+	if u.AuthType == "" {
+		return errors.Join(combinedErr, errEmptyAuthType)
+	}
+
+	switch strings.ToUpper(u.AuthType) {
+	case "MD5", "SHA", "SHA224", "SHA256", "SHA384", "SHA512": // ok
+	default:
+		combinedErr = errors.Join(combinedErr, errBadAuthType)
+	}
+
+	return combinedErr
+}
+
+// validatePrivacy validates the PrivacyType and PrivacyPassword
+func (u *UserConfig) validatePrivacy() error {
+	var combinedErr error
+
+	// Ensure valid privacy password
+	if u.PrivacyPassword == "" {
+		combinedErr = errors.Join(combinedErr, errEmptyPrivacyPassword)
+	}
+
+	// Ensure valid privacy type
+	if u.PrivacyType == "" {
+		return errors.Join(combinedErr, errEmptyPrivacyType)
+	}
+
+	switch strings.ToUpper(u.PrivacyType) {
+	case "DES", "AES", "AES192", "AES192C", "AES256", "AES256C": // ok
+	default:
+		combinedErr = errors.Join(combinedErr, errBadPrivacyType)
+	}
+
+	return combinedErr
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr error
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_settings"),
+			expected: &Config{
+				Endpoint:    "udp://0.0.0.0:1162",
+				Communities: []configopaque.String{"public", "ops"},
+				EngineID:    "8000000001020304",
+				Users: []UserConfig{
+					{
+						User:            "monitor",
+						SecurityLevel:   "auth_priv",
+						AuthType:        "SHA256",
+						AuthPassword:    "authpassword",
+						PrivacyType:     "AES",
+						PrivacyPassword: "privpassword",
+					},
+					{
+						User:          "readonly",
+						SecurityLevel: "no_auth_no_priv",
+					},
+				},
+				MIBPaths: []string{"/usr/share/snmp/mibs"},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "bad_endpoint"),
+			expectedErr: errEndpointBadScheme,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "bad_engine_id"),
+			expectedErr: errBadEngineID,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_users"),
+			expectedErr: errors.Join(
+				fmt.Errorf(errMsgUser, "", errors.Join(errEmptyUser, errBadAuthType, errEmptyPrivacyPassword, errEmptyPrivacyType)),
+				fmt.Errorf(errMsgUser, "monitor", errEmptyAuthPassword),
+				fmt.Errorf(errMsgUser, "monitor", errors.Join(errBadSecurityLevel)),
+				fmt.Errorf(errMsgDuplicateUser, "monitor"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = confmap.Validate(cfg)
+			if tt.expectedErr != nil {
+				assert.ErrorContains(t, err, tt.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+
+// baseMIB is the subset of the standard modules every trap receiver needs: the SMI roots, the
+// objects carried in the header of a v2c/v3 notification and the generic notifications that
+// SMIv1 generic traps are translated to.
+const baseMIB = `
+SNMPv2-SMI DEFINITIONS ::= BEGIN
+org            OBJECT IDENTIFIER ::= { iso 3 }
+dod            OBJECT IDENTIFIER ::= { org 6 }
+internet       OBJECT IDENTIFIER ::= { dod 1 }
+directory      OBJECT IDENTIFIER ::= { internet 1 }
+mgmt           OBJECT IDENTIFIER ::= { internet 2 }
+mib-2          OBJECT IDENTIFIER ::= { mgmt 1 }
+transmission   OBJECT IDENTIFIER ::= { mib-2 10 }
+experimental   OBJECT IDENTIFIER ::= { internet 3 }
+private        OBJECT IDENTIFIER ::= { internet 4 }
+enterprises    OBJECT IDENTIFIER ::= { private 1 }
+security       OBJECT IDENTIFIER ::= { internet 5 }
+snmpV2         OBJECT IDENTIFIER ::= { internet 6 }
+snmpDomains    OBJECT IDENTIFIER ::= { snmpV2 1 }
+snmpProxys     OBJECT IDENTIFIER ::= { snmpV2 2 }
+snmpModules    OBJECT IDENTIFIER ::= { snmpV2 3 }
+END
+
+SNMPv2-MIB DEFINITIONS ::= BEGIN
+system             OBJECT IDENTIFIER ::= { mib-2 1 }
+sysDescr           OBJECT-TYPE SYNTAX DisplayString ::= { system 1 }
+sysObjectID        OBJECT-TYPE SYNTAX OBJECT IDENTIFIER ::= { system 2 }
+sysUpTime          OBJECT-TYPE SYNTAX TimeTicks ::= { system 3 }
+sysContact         OBJECT-TYPE SYNTAX DisplayString ::= { system 4 }
+sysName            OBJECT-TYPE SYNTAX DisplayString ::= { system 5 }
+sysLocation        OBJECT-TYPE SYNTAX DisplayString ::= { system 6 }
+snmp               OBJECT IDENTIFIER ::= { mib-2 11 }
+snmpMIB            MODULE-IDENTITY ::= { snmpModules 1 }
+snmpMIBObjects     OBJECT IDENTIFIER ::= { snmpMIB 1 }
+snmpTrap           OBJECT IDENTIFIER ::= { snmpMIBObjects 4 }
+snmpTrapOID        OBJECT-TYPE SYNTAX OBJECT IDENTIFIER ::= { snmpTrap 1 }
+snmpTrapEnterprise OBJECT-TYPE SYNTAX OBJECT IDENTIFIER ::= { snmpTrap 3 }
+snmpTraps          OBJECT IDENTIFIER ::= { snmpMIBObjects 5 }
+coldStart          NOTIFICATION-TYPE ::= { snmpTraps 1 }
+warmStart          NOTIFICATION-TYPE ::= { snmpTraps 2 }
+authenticationFailure NOTIFICATION-TYPE ::= { snmpTraps 5 }
+END
+
+IF-MIB DEFINITIONS ::= BEGIN
+interfaces     OBJECT IDENTIFIER ::= { mib-2 2 }
+ifTable        OBJECT-TYPE SYNTAX SEQUENCE OF IfEntry ::= { interfaces 2 }
+ifEntry        OBJECT-TYPE SYNTAX IfEntry ::= { ifTable 1 }
+ifIndex        OBJECT-TYPE SYNTAX InterfaceIndex ::= { ifEntry 1 }
+ifDescr        OBJECT-TYPE SYNTAX DisplayString ::= { ifEntry 2 }
+ifAdminStatus  OBJECT-TYPE SYNTAX INTEGER { up(1), down(2), testing(3) } ::= { ifEntry 7 }
+ifOperStatus   OBJECT-TYPE
+    SYNTAX INTEGER { up(1), down(2), testing(3), unknown(4), dormant(5), notPresent(6), lowerLayerDown(7) }
+    ::= { ifEntry 8 }
+linkDown       NOTIFICATION-TYPE ::= { snmpTraps 3 }
+linkUp         NOTIFICATION-TYPE ::= { snmpTraps 4 }
+END
+
+SNMP-COMMUNITY-MIB DEFINITIONS ::= BEGIN
+snmpCommunityMIB        MODULE-IDENTITY ::= { snmpModules 18 }
+snmpCommunityMIBObjects OBJECT IDENTIFIER ::= { snmpCommunityMIB 1 }
+snmpTrapAddress         OBJECT-TYPE SYNTAX IpAddress ::= { snmpCommunityMIBObjects 3 }
+snmpTrapCommunity       OBJECT-TYPE SYNTAX OCTET STRING ::= { snmpCommunityMIBObjects 4 }
+END
+`
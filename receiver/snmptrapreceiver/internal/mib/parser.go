@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// macroKeywords are the SMIv1/SMIv2 macros whose invocations assign an OID to a name.
+var macroKeywords = map[string]bool{
+	"OBJECT-TYPE":        true,
+	"OBJECT-IDENTITY":    true,
+	"MODULE-IDENTITY":    true,
+	"NOTIFICATION-TYPE":  true,
+	"OBJECT-GROUP":       true,
+	"NOTIFICATION-GROUP": true,
+	"MODULE-COMPLIANCE":  true,
+	"AGENT-CAPABILITIES": true,
+	"TRAP-TYPE":          true,
+}
+
+// component is a single sub-identifier of an OID value, optionally named as in `iso(1)`.
+type component struct {
+	name   string
+	number uint64
+}
+
+// definition is an OID assignment found in a MIB module that still has to be attached to the tree.
+type definition struct {
+	name   string
+	module string
+	// parent is the symbolic name the OID value is relative to. It is empty for absolute values.
+	parent string
+	path   []component
+	syntax string
+	enums  map[int64]string
+}
+
+// module holds everything parsed from a single MIB module.
+type module struct {
+	definitions []definition
+	// types maps textual conventions and type assignments with enumerated values to their labels.
+	types map[string]map[int64]string
+}
+
+// parse extracts OID assignments and enumerations from MIB source. The parser is deliberately
+// lenient: anything it does not understand is skipped rather than reported.
+func parse(src string) module {
+	toks := tokenize(src)
+	m := module{types: map[string]map[int64]string{}}
+	moduleName := ""
+	at := func(i int) string {
+		if i < len(toks) {
+			return toks[i]
+		}
+		return ""
+	}
+
+	for i := 0; i < len(toks); i++ {
+		tok := toks[i]
+		switch {
+		case at(i+1) == "DEFINITIONS":
+			moduleName = tok
+			i++
+		case at(i+1) == "MACRO":
+			for i < len(toks) && toks[i] != "END" {
+				i++
+			}
+		case isValueName(tok) && at(i+1) == "OBJECT" && at(i+2) == "IDENTIFIER" && at(i+3) == "::=":
+			def, next, ok := parseValue(toks, i+4)
+			if ok {
+				def.name, def.module = tok, moduleName
+				m.definitions = append(m.definitions, def)
+			}
+			i = next - 1
+		case isValueName(tok) && macroKeywords[at(i+1)]:
+			def, next, ok := parseMacro(toks, i+1)
+			if ok {
+				def.name, def.module = tok, moduleName
+				m.definitions = append(m.definitions, def)
+			}
+			i = next - 1
+		case isTypeName(tok) && at(i+1) == "::=":
+			j := i + 2
+			if at(j) == "TEXTUAL-CONVENTION" {
+				for j < len(toks) && toks[j] != "SYNTAX" && toks[j] != "::=" {
+					j++
+				}
+				if at(j) != "SYNTAX" {
+					continue
+				}
+				j++
+			}
+			if _, enums, next := parseSyntax(toks, j); len(enums) > 0 {
+				m.types[tok] = enums
+				i = next - 1
+			}
+		}
+	}
+	return m
+}
+
+// parseMacro parses a macro invocation starting at its keyword and returns the assignment it makes.
+func parseMacro(toks []string, i int) (definition, int, bool) {
+	keyword := toks[i]
+	var def definition
+	enterprise := ""
+	for i++; i < len(toks) && toks[i] != "::="; i++ {
+		switch toks[i] {
+		case "SYNTAX":
+			if keyword == "OBJECT-TYPE" && def.syntax == "" && def.enums == nil {
+				var next int
+				def.syntax, def.enums, next = parseSyntax(toks, i+1)
+				i = next - 1
+			}
+		case "ENTERPRISE":
+			if i+1 < len(toks) {
+				enterprise = toks[i+1]
+			}
+		}
+	}
+	if i+1 >= len(toks) {
+		return def, len(toks), false
+	}
+
+	if keyword != "TRAP-TYPE" {
+		value, next, ok := parseValue(toks, i+1)
+		value.syntax, value.enums = def.syntax, def.enums
+		return value, next, ok
+	}
+
+	// SMIv1 traps are assigned a specific trap number relative to their enterprise. RFC 3584 maps
+	// them into the SMIv2 OID space as enterprise.0.specific.
+	specific, err := strconv.ParseUint(toks[i+1], 10, 32)
+	if err != nil || enterprise == "" {
+		return def, i + 2, false
+	}
+	def.parent = enterprise
+	def.path = []component{{number: 0}, {number: specific}}
+	return def, i + 2, true
+}
+
+// parseSyntax parses the type following a SYNTAX keyword or a type assignment. It returns the
+// referenced type name, when the syntax is a plain reference, and any enumerated values.
+func parseSyntax(toks []string, i int) (string, map[int64]string, int) {
+	if i >= len(toks) {
+		return "", nil, i
+	}
+	if toks[i] != "INTEGER" && toks[i] != "Integer32" {
+		if isTypeName(toks[i]) {
+			return toks[i], nil, i + 1
+		}
+		return "", nil, i
+	}
+	i++
+	if i >= len(toks) || toks[i] != "{" {
+		return "", nil, i
+	}
+
+	enums := map[int64]string{}
+	for i++; i+3 < len(toks) && toks[i] != "}"; i++ {
+		if toks[i] == "," {
+			continue
+		}
+		if toks[i+1] != "(" || toks[i+3] != ")" {
+			continue
+		}
+		if v, err := strconv.ParseInt(toks[i+2], 10, 64); err == nil {
+			enums[v] = toks[i]
+		}
+		i += 3
+	}
+	return "", enums, i + 1
+}
+
+// parseValue parses an OID value such as `{ mib-2 1 }` or `{ iso(1) org(3) 6 }`.
+func parseValue(toks []string, i int) (definition, int, bool) {
+	var def definition
+	if i >= len(toks) || toks[i] != "{" {
+		return def, i, false
+	}
+	i++
+	first := true
+	for ; i < len(toks) && toks[i] != "}"; i++ {
+		tok := toks[i]
+		if n, err := strconv.ParseUint(tok, 10, 32); err == nil {
+			def.path = append(def.path, component{number: n})
+			first = false
+			continue
+		}
+		if i+3 < len(toks) && toks[i+1] == "(" && toks[i+3] == ")" {
+			if n, err := strconv.ParseUint(toks[i+2], 10, 32); err == nil {
+				def.path = append(def.path, component{name: tok, number: n})
+			}
+			i += 3
+			first = false
+			continue
+		}
+		if first {
+			def.parent = tok
+		}
+		first = false
+	}
+	if i >= len(toks) {
+		return def, i, false
+	}
+	return def, i + 1, len(def.path) > 0
+}
+
+// tokenize splits MIB source into ASN.1 tokens. Comments are dropped and quoted strings are
+// replaced by a single placeholder token so that their content cannot be mistaken for syntax.
+func tokenize(src string) []string {
+	var toks []string
+	r := []rune(src)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '-' && i+1 < len(r) && r[i+1] == '-':
+			// A comment runs to the end of the line or to the next "--".
+			i += 2
+			for i < len(r) && r[i] != '\n' {
+				if r[i] == '-' && i+1 < len(r) && r[i+1] == '-' {
+					i += 2
+					break
+				}
+				i++
+			}
+		case c == '"':
+			i++
+			for i < len(r) && r[i] != '"' {
+				i++
+			}
+			i++
+			toks = append(toks, `""`)
+		case c == '\'':
+			// Binary or hexadecimal strings such as '00'H.
+			i++
+			for i < len(r) && r[i] != '\'' {
+				i++
+			}
+			i++
+			if i < len(r) && (r[i] == 'H' || r[i] == 'h' || r[i] == 'B' || r[i] == 'b') {
+				i++
+			}
+			toks = append(toks, `''`)
+		case c == ':' && strings.HasPrefix(string(r[i:min(i+3, len(r))]), "::="):
+			toks = append(toks, "::=")
+			i += 3
+		case c == '.' && i+1 < len(r) && r[i+1] == '.':
+			toks = append(toks, "..")
+			i += 2
+		case isIdentRune(c):
+			start := i
+			for i < len(r) && isIdentRune(r[i]) {
+				if r[i] == '-' && i+1 < len(r) && r[i+1] == '-' {
+					break
+				}
+				i++
+			}
+			toks = append(toks, string(r[start:i]))
+		default:
+			toks = append(toks, string(c))
+			i++
+		}
+	}
+	return toks
+}
+
+func isIdentRune(c rune) bool {
+	return c == '-' || c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// isValueName reports whether tok is a valid ASN.1 value reference, which starts with a lowercase letter.
+func isValueName(tok string) bool {
+	return tok != "" && unicode.IsLower(rune(tok[0]))
+}
+
+// isTypeName reports whether tok is a valid ASN.1 type reference, which starts with an uppercase letter.
+func isTypeName(tok string) bool {
+	return tok != "" && unicode.IsUpper(rune(tok[0]))
+}
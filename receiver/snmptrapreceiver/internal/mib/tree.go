@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Node is a named object in the OID tree.
+type Node struct {
+	Name   string
+	Module string
+	// OID is the numeric OID of the node in dotted form, without a leading dot.
+	OID    string
+	syntax string
+	enums  map[int64]string
+}
+
+// Tree resolves numeric OIDs to the names defined by the loaded MIB modules.
+type Tree struct {
+	nodes   map[string]*Node
+	names   map[string]string
+	types   map[string]map[int64]string
+	pending []definition
+}
+
+// NewTree returns a tree that knows the base SMI nodes and the standard SNMPv2 notifications.
+func NewTree() *Tree {
+	t := &Tree{
+		nodes: map[string]*Node{},
+		names: map[string]string{},
+		types: map[string]map[int64]string{},
+	}
+	for name, oid := range map[string]string{"ccitt": "0", "iso": "1", "joint-iso-ccitt": "2"} {
+		t.names[name] = oid
+		t.nodes[oid] = &Node{Name: name, Module: "SNMPv2-SMI", OID: oid}
+	}
+	t.add(parse(baseMIB))
+	return t
+}
+
+// LoadFiles parses every MIB file in paths. Directories are read non-recursively and hidden files
+// are skipped. Modules may be given in any order; references between them are resolved once all
+// of them have been parsed.
+func (t *Tree) LoadFiles(paths []string) error {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to load MIB path %q: %w", path, err)
+		}
+		files := []string{path}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return fmt.Errorf("failed to read MIB directory %q: %w", path, err)
+			}
+			files = files[:0]
+			for _, entry := range entries {
+				if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+					continue
+				}
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		for _, file := range files {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read MIB file %q: %w", file, err)
+			}
+			t.add(parse(string(src)))
+		}
+	}
+	return nil
+}
+
+// Unresolved returns the names of definitions whose parent was never defined by a loaded module.
+func (t *Tree) Unresolved() []string {
+	names := make([]string, 0, len(t.pending))
+	for _, def := range t.pending {
+		names = append(names, def.module+"::"+def.name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// add attaches the definitions of m to the tree, retrying definitions whose parent is defined
+// later in the same or a previously pending module.
+func (t *Tree) add(m module) {
+	for name, enums := range m.types {
+		t.types[name] = enums
+	}
+	pending := append(t.pending, m.definitions...)
+	for {
+		var unresolved []definition
+		for _, def := range pending {
+			if !t.attach(def) {
+				unresolved = append(unresolved, def)
+			}
+		}
+		if len(unresolved) == len(pending) {
+			t.pending = unresolved
+			return
+		}
+		pending = unresolved
+	}
+}
+
+func (t *Tree) attach(def definition) bool {
+	oid := ""
+	if def.parent != "" {
+		var ok bool
+		if oid, ok = t.names[def.parent]; !ok {
+			return false
+		}
+	}
+	for i, c := range def.path {
+		if oid == "" {
+			oid = strconv.FormatUint(c.number, 10)
+		} else {
+			oid += "." + strconv.FormatUint(c.number, 10)
+		}
+		if i == len(def.path)-1 {
+			break
+		}
+		if c.name != "" {
+			if _, ok := t.nodes[oid]; !ok {
+				t.names[c.name] = oid
+				t.nodes[oid] = &Node{Name: c.name, Module: def.module, OID: oid}
+			}
+		}
+	}
+	t.names[def.name] = oid
+	t.nodes[oid] = &Node{
+		Name:   def.name,
+		Module: def.module,
+		OID:    oid,
+		syntax: def.syntax,
+		enums:  def.enums,
+	}
+	return true
+}
+
+// Lookup returns the node with the longest OID that is a prefix of oid, along with the remaining
+// sub-identifiers (the instance index) in dotted form.
+func (t *Tree) Lookup(oid string) (*Node, string, bool) {
+	oid = strings.TrimPrefix(oid, ".")
+	for prefix := oid; prefix != ""; {
+		if node, ok := t.nodes[prefix]; ok {
+			return node, strings.TrimPrefix(oid[len(prefix):], "."), true
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return nil, "", false
+}
+
+// Name formats oid as MODULE::name.index, falling back to the numeric OID when no prefix of it is known.
+func (t *Tree) Name(oid string) string {
+	node, index, ok := t.Lookup(oid)
+	if !ok {
+		return strings.TrimPrefix(oid, ".")
+	}
+	name := node.Module + "::" + node.Name
+	if index != "" {
+		name += "." + index
+	}
+	return name
+}
+
+// EnumLabel returns the label of an enumerated value of node, following textual conventions.
+func (t *Tree) EnumLabel(node *Node, value int64) (string, bool) {
+	enums := node.enums
+	if enums == nil && node.syntax != "" {
+		enums = t.types[node.syntax]
+	}
+	label, ok := enums[value]
+	return label, ok
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseTree(t *testing.T) {
+	tree := NewTree()
+
+	tests := []struct {
+		oid      string
+		expected string
+	}{
+		{oid: ".1.3.6.1.6.3.1.1.5.3", expected: "IF-MIB::linkDown"},
+		{oid: "1.3.6.1.6.3.1.1.5.1", expected: "SNMPv2-MIB::coldStart"},
+		{oid: ".1.3.6.1.2.1.1.3.0", expected: "SNMPv2-MIB::sysUpTime.0"},
+		{oid: ".1.3.6.1.2.1.2.2.1.8.12", expected: "IF-MIB::ifOperStatus.12"},
+		{oid: ".1.3.6.1.4.1.8072.1", expected: "SNMPv2-SMI::enterprises.8072.1"},
+		{oid: ".1.3.6.1.6.3.18.1.3.0", expected: "SNMP-COMMUNITY-MIB::snmpTrapAddress.0"},
+		{oid: ".1", expected: "SNMPv2-SMI::iso"},
+		{oid: ".3.1", expected: "3.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.oid, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tree.Name(tt.oid))
+		})
+	}
+
+	node, index, ok := tree.Lookup(".1.3.6.1.2.1.2.2.1.8.12")
+	require.True(t, ok)
+	assert.Equal(t, "12", index)
+	assert.Equal(t, "1.3.6.1.2.1.2.2.1.8", node.OID)
+	label, ok := tree.EnumLabel(node, 7)
+	assert.True(t, ok)
+	assert.Equal(t, "lowerLayerDown", label)
+	_, ok = tree.EnumLabel(node, 42)
+	assert.False(t, ok)
+	assert.Empty(t, tree.Unresolved())
+}
+
+func TestLoadFiles(t *testing.T) {
+	tree := NewTree()
+	require.NoError(t, tree.LoadFiles([]string{filepath.Join("testdata")}))
+
+	tests := []struct {
+		oid      string
+		expected string
+	}{
+		{oid: ".1.3.6.1.4.1.99999", expected: "ACME-MIB::acme"},
+		{oid: ".1.3.6.1.4.1.99999.1.1.1.2.5", expected: "ACME-MIB::acmeAlarmSeverity.5"},
+		{oid: ".1.3.6.1.4.1.99999.1.1.1.4.5", expected: "ACME-MIB::acmeAlarmText.5"},
+		{oid: ".1.3.6.1.4.1.99999.2.1", expected: "ACME-MIB::acmeAlarmRaised"},
+		{oid: ".1.3.6.1.4.1.99999.0.7", expected: "ACME-MIB::acmeLegacyAlarm"},
+		{oid: ".1.3.6.1.4.1.99999.1.9", expected: "ACME-EXT-MIB::acmeExt"},
+		{oid: ".1.3.6.1.3.4242", expected: "ACME-MIB::acmeLabs"},
+		{oid: ".1.3.6.1.3.4242.1", expected: "ACME-MIB::acmeLab"},
+		{oid: ".1.3.6.1.3", expected: "SNMPv2-SMI::experimental"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.oid, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tree.Name(tt.oid))
+		})
+	}
+
+	severity, _, ok := tree.Lookup(".1.3.6.1.4.1.99999.1.1.1.2.5")
+	require.True(t, ok)
+	label, ok := tree.EnumLabel(severity, 3)
+	assert.True(t, ok)
+	assert.Equal(t, "critical", label)
+
+	state, _, ok := tree.Lookup(".1.3.6.1.4.1.99999.1.1.1.3.5")
+	require.True(t, ok)
+	label, ok = tree.EnumLabel(state, 0)
+	assert.True(t, ok)
+	assert.Equal(t, "cleared", label)
+
+	assert.Equal(t, []string{"ACME-EXT-MIB::acmeWidget"}, tree.Unresolved())
+}
+
+func TestLoadFilesMissing(t *testing.T) {
+	err := NewTree().LoadFiles([]string{filepath.Join("testdata", "MISSING-MIB.txt")})
+	assert.ErrorContains(t, err, "failed to load MIB path")
+}
+
+func TestTokenize(t *testing.T) {
+	toks := tokenize(`a OBJECT IDENTIFIER ::= { b 1 } -- trailing comment
+x-y "quoted -- text" '0F'H -- inline -- z`)
+	assert.Equal(t, []string{"a", "OBJECT", "IDENTIFIER", "::=", "{", "b", "1", "}", "x-y", `""`, `''`, "z"}, toks)
+}
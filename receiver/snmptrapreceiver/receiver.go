@@ -0,0 +1,394 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+)
+
+const (
+	attributeVersion      = "snmp.version"
+	attributePDUType      = "snmp.pdu_type"
+	attributeTrapOID      = "snmp.trap.oid"
+	attributeTrapName     = "snmp.trap.name"
+	attributeUser         = "snmp.user"
+	attributeAgentAddress = "snmp.agent.address"
+	attributePeerAddress  = "network.peer.address"
+	attributePeerPort     = "network.peer.port"
+
+	// OIDs of the objects RFC 3584 uses to carry the SNMPv1 trap header in a v2c/v3 notification.
+	oidSysUpTime          = ".1.3.6.1.2.1.1.3.0"
+	oidSnmpTrapOID        = ".1.3.6.1.6.3.1.1.4.1.0"
+	oidSnmpTrapEnterprise = ".1.3.6.1.6.3.1.1.4.3.0"
+	oidSnmpTraps          = ".1.3.6.1.6.3.1.1.5"
+)
+
+var errMissingTrapOID = errors.New("notification does not contain snmpTrapOID.0")
+
+type trapReceiver struct {
+	settings receiver.Settings
+	cfg      *Config
+	consumer consumer.Logs
+	obsrecv  *receiverhelper.ObsReport
+	mibs     *mib.Tree
+	listener *gosnmp.TrapListener
+}
+
+func newTrapReceiver(params receiver.Settings, cfg *Config, consumer consumer.Logs) (*trapReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              "udp",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &trapReceiver{
+		settings: params,
+		cfg:      cfg,
+		consumer: consumer,
+		obsrecv:  obsrecv,
+	}, nil
+}
+
+// Start loads the configured MIBs and starts listening for traps.
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	mibs := mib.NewTree()
+	if err := mibs.LoadFiles(r.cfg.MIBPaths); err != nil {
+		return err
+	}
+	if unresolved := mibs.Unresolved(); len(unresolved) > 0 {
+		r.settings.Logger.Warn("Some MIB definitions reference objects that are not defined by any loaded MIB and were ignored",
+			zap.Strings("definitions", unresolved))
+	}
+	r.mibs = mibs
+
+	params, err := r.newParams()
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(r.cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = params
+	listener.OnNewTrap = r.handleTrap
+	r.listener = listener
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- listener.Listen("udp://" + u.Host)
+	}()
+	select {
+	case <-listener.Listening():
+		return nil
+	case err := <-listenErr:
+		return fmt.Errorf("failed to listen on %s: %w", r.cfg.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps.
+func (r *trapReceiver) Shutdown(context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	return nil
+}
+
+// newParams builds the gosnmp parameters used to decode traps. v3 messages are always decoded
+// with the user security model so that messages from unknown users are rejected rather than
+// decoded without credentials.
+func (r *trapReceiver) newParams() (*gosnmp.GoSNMP, error) {
+	engineID, err := hex.DecodeString(strings.TrimPrefix(r.cfg.EngineID, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	params := &gosnmp.GoSNMP{
+		Version:       gosnmp.Version3,
+		SecurityModel: gosnmp.UserSecurityModel,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			AuthoritativeEngineID: string(engineID),
+		},
+	}
+	params.TrapSecurityParametersTable = gosnmp.NewSnmpV3SecurityParametersTable(params.Logger)
+	if r.cfg.EngineID != "" {
+		// Senders of informs discover the engine ID with an unauthenticated request from an
+		// empty user. It has to be decoded for the listener to answer it with a report.
+		if err := params.TrapSecurityParametersTable.Add("", newSecurityParameters(UserConfig{})); err != nil {
+			return nil, err
+		}
+	}
+	for _, user := range r.cfg.Users {
+		if err := params.TrapSecurityParametersTable.Add(user.User, newSecurityParameters(user)); err != nil {
+			return nil, fmt.Errorf("failed to add user %q: %w", user.User, err)
+		}
+	}
+	return params, nil
+}
+
+// newSecurityParameters sets gosnmp USM parameters based on the config of a v3 user
+func newSecurityParameters(user UserConfig) *gosnmp.UsmSecurityParameters {
+	securityParams := &gosnmp.UsmSecurityParameters{
+		UserName: user.User,
+	}
+	switch strings.ToUpper(user.SecurityLevel) {
+	case "AUTH_NO_PRIV":
+		securityParams.AuthenticationProtocol = getAuthProtocol(user.AuthType)
+		securityParams.AuthenticationPassphrase = string(user.AuthPassword)
+	case "AUTH_PRIV":
+		securityParams.AuthenticationProtocol = getAuthProtocol(user.AuthType)
+		securityParams.AuthenticationPassphrase = string(user.AuthPassword)
+		securityParams.PrivacyProtocol = getPrivacyProtocol(user.PrivacyType)
+		securityParams.PrivacyPassphrase = string(user.PrivacyPassword)
+	default:
+		securityParams.AuthenticationProtocol = gosnmp.NoAuth
+		securityParams.PrivacyProtocol = gosnmp.NoPriv
+	}
+	return securityParams
+}
+
+// getAuthProtocol gets gosnmp auth protocol based on config auth type
+func getAuthProtocol(authType string) gosnmp.SnmpV3AuthProtocol {
+	switch strings.ToUpper(authType) {
+	case "SHA":
+		return gosnmp.SHA
+	case "SHA224":
+		return gosnmp.SHA224
+	case "SHA256":
+		return gosnmp.SHA256
+	case "SHA384":
+		return gosnmp.SHA384
+	case "SHA512":
+		return gosnmp.SHA512
+	default:
+		return gosnmp.MD5
+	}
+}
+
+// getPrivacyProtocol gets gosnmp privacy protocol based on config privacy type
+func getPrivacyProtocol(privacyType string) gosnmp.SnmpV3PrivProtocol {
+	switch strings.ToUpper(privacyType) {
+	case "AES":
+		return gosnmp.AES
+	case "AES192":
+		return gosnmp.AES192
+	case "AES192C":
+		return gosnmp.AES192C
+	case "AES256":
+		return gosnmp.AES256
+	case "AES256C":
+		return gosnmp.AES256C
+	default:
+		return gosnmp.DES
+	}
+}
+
+// handleTrap is called by the listener for every trap or inform that could be decoded.
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if packet.Version != gosnmp.Version3 && len(r.cfg.Communities) > 0 &&
+		!slices.Contains(r.cfg.Communities, configopaque.String(packet.Community)) {
+		r.settings.Logger.Debug("Dropping trap with unknown community", zap.Stringer("peer", addr))
+		return
+	}
+	if packet.Version == gosnmp.Version3 && !r.isUser(packet) {
+		r.settings.Logger.Debug("Dropping trap from unknown user", zap.Stringer("peer", addr))
+		return
+	}
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	logs, err := r.convert(packet, addr)
+	if err != nil {
+		r.settings.Logger.Debug("Dropping malformed trap", zap.Stringer("peer", addr), zap.Error(err))
+		r.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
+		return
+	}
+	err = r.consumer.ConsumeLogs(ctx, logs)
+	if err != nil {
+		r.settings.Logger.Error("Failed to consume trap", zap.Error(err))
+	}
+	r.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 1, err)
+}
+
+// isUser reports whether a v3 message was sent by one of the configured users at the security
+// level configured for that user.
+func (r *trapReceiver) isUser(packet *gosnmp.SnmpPacket) bool {
+	usm, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok {
+		return false
+	}
+	return slices.ContainsFunc(r.cfg.Users, func(user UserConfig) bool {
+		return user.User == usm.UserName && packet.MsgFlags&gosnmp.AuthPriv == getMsgFlags(user.SecurityLevel)
+	})
+}
+
+// getMsgFlags gets the gosnmp message flags based on config security level
+func getMsgFlags(securityLevel string) gosnmp.SnmpV3MsgFlags {
+	switch strings.ToUpper(securityLevel) {
+	case "AUTH_NO_PRIV":
+		return gosnmp.AuthNoPriv
+	case "AUTH_PRIV":
+		return gosnmp.AuthPriv
+	default:
+		return gosnmp.NoAuthNoPriv
+	}
+}
+
+// convert turns a trap into a single log record. SNMPv1 traps are first translated to the
+// SNMPv2 notification format as described in RFC 3584 section 3.1, so that both produce the
+// same attributes.
+func (r *trapReceiver) convert(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(metadata.ScopeName)
+	sl.Scope().SetVersion(r.settings.BuildInfo.Version)
+	lr := sl.LogRecords().AppendEmpty()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	lr.SetTimestamp(now)
+	lr.SetObservedTimestamp(now)
+
+	attrs := lr.Attributes()
+	if addr != nil {
+		attrs.PutStr(attributePeerAddress, addr.IP.String())
+		attrs.PutInt(attributePeerPort, int64(addr.Port))
+	}
+
+	variables := packet.Variables
+	pduType := "trap"
+	if packet.PDUType == gosnmp.InformRequest {
+		pduType = "inform"
+	}
+	switch packet.Version {
+	case gosnmp.Version1:
+		attrs.PutStr(attributeVersion, "v1")
+		attrs.PutStr(attributeAgentAddress, packet.AgentAddress)
+		variables = append(v1Header(packet), variables...)
+	case gosnmp.Version2c:
+		attrs.PutStr(attributeVersion, "v2c")
+	default:
+		attrs.PutStr(attributeVersion, "v3")
+		if usm, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok {
+			attrs.PutStr(attributeUser, usm.UserName)
+		}
+	}
+	attrs.PutStr(attributePDUType, pduType)
+
+	trapOID := ""
+	for _, v := range variables {
+		if v.Name == oidSnmpTrapOID {
+			trapOID, _ = v.Value.(string)
+			continue
+		}
+		r.putVarbind(attrs, v)
+	}
+	if trapOID == "" {
+		return logs, errMissingTrapOID
+	}
+	trapName := r.mibs.Name(trapOID)
+	attrs.PutStr(attributeTrapOID, trapOID)
+	attrs.PutStr(attributeTrapName, trapName)
+	lr.Body().SetStr(trapName)
+
+	return logs, nil
+}
+
+// v1Header returns the sysUpTime.0, snmpTrapOID.0 and snmpTrapEnterprise.0 varbinds that
+// correspond to the header of a SNMPv1 trap.
+func v1Header(packet *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	enterprise := "." + strings.TrimPrefix(packet.Enterprise, ".")
+	trapOID := enterprise + ".0." + strconv.Itoa(packet.SpecificTrap)
+	if packet.GenericTrap < 6 {
+		trapOID = oidSnmpTraps + "." + strconv.Itoa(packet.GenericTrap+1)
+	}
+	return []gosnmp.SnmpPDU{
+		{Name: oidSysUpTime, Type: gosnmp.TimeTicks, Value: uint32(packet.Timestamp)}, //nolint:gosec
+		{Name: oidSnmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: trapOID},
+		{Name: oidSnmpTrapEnterprise, Type: gosnmp.ObjectIdentifier, Value: enterprise},
+	}
+}
+
+// putVarbind adds a varbind as an attribute keyed by the resolved name of its OID.
+func (r *trapReceiver) putVarbind(attrs pcommon.Map, v gosnmp.SnmpPDU) {
+	key := r.mibs.Name(v.Name)
+	switch v.Type {
+	case gosnmp.Integer:
+		value := gosnmp.ToBigInt(v.Value).Int64()
+		if node, _, ok := r.mibs.Lookup(v.Name); ok {
+			if label, ok := r.mibs.EnumLabel(node, value); ok {
+				attrs.PutStr(key, label)
+				return
+			}
+		}
+		attrs.PutInt(key, value)
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		value := gosnmp.ToBigInt(v.Value)
+		if value.IsInt64() {
+			attrs.PutInt(key, value.Int64())
+		} else {
+			attrs.PutStr(key, value.String())
+		}
+	case gosnmp.OctetString:
+		b, _ := v.Value.([]byte)
+		if isPrintable(b) {
+			attrs.PutStr(key, string(b))
+		} else {
+			attrs.PutStr(key, hex.EncodeToString(b))
+		}
+	case gosnmp.ObjectIdentifier:
+		oid, _ := v.Value.(string)
+		attrs.PutStr(key, r.mibs.Name(oid))
+	case gosnmp.IPAddress:
+		ip, _ := v.Value.(string)
+		attrs.PutStr(key, ip)
+	case gosnmp.OpaqueFloat:
+		f, _ := v.Value.(float32)
+		attrs.PutDouble(key, float64(f))
+	case gosnmp.OpaqueDouble:
+		f, _ := v.Value.(float64)
+		attrs.PutDouble(key, f)
+	case gosnmp.Opaque, gosnmp.BitString:
+		b, _ := v.Value.([]byte)
+		attrs.PutStr(key, hex.EncodeToString(b))
+	default:
+		attrs.PutEmpty(key)
+	}
+}
+
+// isPrintable reports whether b is valid UTF-8 text without control characters other than whitespace.
+func isPrintable(b []byte) bool {
+	s := string(b)
+	if !utf8.ValidString(s) {
+		return false
+	}
+	return strings.IndexFunc(s, func(c rune) bool {
+		return !unicode.IsPrint(c) && !unicode.IsSpace(c)
+	}) < 0
+}
@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmptrapreceiver
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver/internal/mib"
+)
+
+const testEngineID = "8000000001020304"
+
+func startReceiver(t *testing.T, cfg *Config) (*consumertest.LogsSink, *net.UDPAddr) {
+	// Reserve a free port for the listener
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	require.NoError(t, conn.Close())
+	cfg.Endpoint = "udp://" + addr.String()
+
+	sink := new(consumertest.LogsSink)
+	rcvr, err := NewFactory().CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+	return sink, addr
+}
+
+func newClient(t *testing.T, addr *net.UDPAddr, version gosnmp.SnmpVersion, configure ...func(*gosnmp.GoSNMP)) *gosnmp.GoSNMP {
+	client := &gosnmp.GoSNMP{
+		Target:    addr.IP.String(),
+		Port:      uint16(addr.Port), //nolint:gosec
+		Version:   version,
+		Community: "public",
+		Timeout:   2 * time.Second,
+		Retries:   1,
+	}
+	for _, fn := range configure {
+		fn(client)
+	}
+	require.NoError(t, client.Connect())
+	t.Cleanup(func() {
+		_ = client.Conn.Close()
+	})
+	return client
+}
+
+func attributes(t *testing.T, sink *consumertest.LogsSink, count int) []map[string]any {
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == count
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var result []map[string]any
+	for _, logs := range sink.AllLogs() {
+		lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		attrs := lr.Attributes().AsRaw()
+		assert.Equal(t, attrs[attributeTrapName], lr.Body().Str())
+		assert.NotZero(t, lr.Timestamp())
+		delete(attrs, attributePeerPort)
+		result = append(result, attrs)
+	}
+	return result
+}
+
+func TestReceiveV2cTrap(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MIBPaths = []string{filepath.Join("internal", "mib", "testdata")}
+	sink, addr := startReceiver(t, cfg)
+
+	client := newClient(t, addr, gosnmp.Version2c)
+	_, err := client.SendTrap(gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1234)},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.99999.2.1"},
+			{Name: ".1.3.6.1.4.1.99999.1.1.1.2.5", Type: gosnmp.Integer, Value: 3},
+			{Name: ".1.3.6.1.4.1.99999.1.1.1.3.5", Type: gosnmp.Integer, Value: 9},
+			{Name: ".1.3.6.1.4.1.99999.1.1.1.4.5", Type: gosnmp.OctetString, Value: "fan failure"},
+			{Name: ".1.3.6.1.4.1.424242.1", Type: gosnmp.OctetString, Value: []byte{0x00, 0xff}},
+			{Name: ".1.3.6.1.4.1.424242.2", Type: gosnmp.IPAddress, Value: "192.0.2.1"},
+			{Name: ".1.3.6.1.4.1.424242.3", Type: gosnmp.Counter64, Value: uint64(1) << 63},
+			{Name: ".1.3.6.1.4.1.424242.4", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.1"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{{
+		attributePeerAddress:               "127.0.0.1",
+		attributeVersion:                   "v2c",
+		attributePDUType:                   "trap",
+		attributeTrapOID:                   ".1.3.6.1.4.1.99999.2.1",
+		attributeTrapName:                  "ACME-MIB::acmeAlarmRaised",
+		"SNMPv2-MIB::sysUpTime.0":          int64(1234),
+		"ACME-MIB::acmeAlarmSeverity.5":    "critical",
+		"ACME-MIB::acmeAlarmState.5":       int64(9),
+		"ACME-MIB::acmeAlarmText.5":        "fan failure",
+		"SNMPv2-SMI::enterprises.424242.1": "00ff",
+		"SNMPv2-SMI::enterprises.424242.2": "192.0.2.1",
+		"SNMPv2-SMI::enterprises.424242.3": "9223372036854775808",
+		"SNMPv2-SMI::enterprises.424242.4": "SNMPv2-MIB::coldStart",
+	}}, attributes(t, sink, 1))
+}
+
+func TestReceiveV1Trap(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MIBPaths = []string{filepath.Join("internal", "mib", "testdata")}
+	sink, addr := startReceiver(t, cfg)
+
+	client := newClient(t, addr, gosnmp.Version1)
+	_, err := client.SendTrap(gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.99999",
+		AgentAddress: "192.0.2.10",
+		GenericTrap:  6,
+		SpecificTrap: 7,
+		Timestamp:    300,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.4.1.99999.1.1.1.4.1", Type: gosnmp.OctetString, Value: "disk full"},
+		},
+	})
+	require.NoError(t, err)
+	_, err = client.SendTrap(gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.99999",
+		AgentAddress: "192.0.2.10",
+		GenericTrap:  2,
+		Timestamp:    301,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.2.2.1.1.4", Type: gosnmp.Integer, Value: 4},
+			{Name: ".1.3.6.1.2.1.2.2.1.8.4", Type: gosnmp.Integer, Value: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []map[string]any{
+		{
+			attributePeerAddress:               "127.0.0.1",
+			attributeVersion:                   "v1",
+			attributePDUType:                   "trap",
+			attributeAgentAddress:              "192.0.2.10",
+			attributeTrapOID:                   ".1.3.6.1.4.1.99999.0.7",
+			attributeTrapName:                  "ACME-MIB::acmeLegacyAlarm",
+			"SNMPv2-MIB::sysUpTime.0":          int64(300),
+			"SNMPv2-MIB::snmpTrapEnterprise.0": "ACME-MIB::acme",
+			"ACME-MIB::acmeAlarmText.1":        "disk full",
+		},
+		{
+			attributePeerAddress:               "127.0.0.1",
+			attributeVersion:                   "v1",
+			attributePDUType:                   "trap",
+			attributeAgentAddress:              "192.0.2.10",
+			attributeTrapOID:                   ".1.3.6.1.6.3.1.1.5.3",
+			attributeTrapName:                  "IF-MIB::linkDown",
+			"SNMPv2-MIB::sysUpTime.0":          int64(301),
+			"SNMPv2-MIB::snmpTrapEnterprise.0": "ACME-MIB::acme",
+			"IF-MIB::ifIndex.4":                int64(4),
+			"IF-MIB::ifOperStatus.4":           "down",
+		},
+	}, attributes(t, sink, 2))
+}
+
+func TestReceiveCommunityFilter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Communities = []configopaque.String{"ops"}
+	sink, addr := startReceiver(t, cfg)
+
+	trap := gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.1"},
+		},
+	}
+	client := newClient(t, addr, gosnmp.Version2c)
+	_, err := client.SendTrap(trap)
+	require.NoError(t, err)
+	client.Community = "ops"
+	_, err = client.SendTrap(trap)
+	require.NoError(t, err)
+
+	attrs := attributes(t, sink, 1)
+	assert.Equal(t, "SNMPv2-MIB::coldStart", attrs[0][attributeTrapName])
+	// Give the listener time to deliver the first trap in case it was not dropped
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestReceiveV3Inform(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.EngineID = testEngineID
+	cfg.Users = []UserConfig{
+		{
+			User:            "monitor",
+			SecurityLevel:   "auth_priv",
+			AuthType:        "SHA256",
+			AuthPassword:    "authpassword",
+			PrivacyType:     "AES",
+			PrivacyPassword: "privpassword",
+		},
+		{
+			User:          "readonly",
+			SecurityLevel: "no_auth_no_priv",
+		},
+	}
+	sink, addr := startReceiver(t, cfg)
+
+	client := newClient(t, addr, gosnmp.Version3, func(client *gosnmp.GoSNMP) {
+		client.SecurityModel = gosnmp.UserSecurityModel
+		client.MsgFlags = gosnmp.AuthPriv
+		client.SecurityParameters = &gosnmp.UsmSecurityParameters{
+			UserName:                 "monitor",
+			AuthenticationProtocol:   gosnmp.SHA256,
+			AuthenticationPassphrase: "authpassword",
+			PrivacyProtocol:          gosnmp.AES,
+			PrivacyPassphrase:        "privpassword",
+		}
+	})
+	response, err := client.SendTrap(gosnmp.SnmpTrap{
+		IsInform: true,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(42)},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.4"},
+			{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.GetResponse, response.PDUType)
+
+	assert.Equal(t, []map[string]any{{
+		attributePeerAddress:      "127.0.0.1",
+		attributeVersion:          "v3",
+		attributePDUType:          "inform",
+		attributeUser:             "monitor",
+		attributeTrapOID:          ".1.3.6.1.6.3.1.1.5.4",
+		attributeTrapName:         "IF-MIB::linkUp",
+		"SNMPv2-MIB::sysUpTime.0": int64(42),
+		"IF-MIB::ifIndex.2":       int64(2),
+	}}, attributes(t, sink, 1))
+}
+
+func TestReceiveV3Rejected(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Users = []UserConfig{{
+		User:          "monitor",
+		SecurityLevel: "auth_no_priv",
+		AuthType:      "MD5",
+		AuthPassword:  "authpassword",
+	}}
+	sink, addr := startReceiver(t, cfg)
+
+	trap := gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.1"},
+		},
+	}
+	send := func(msgFlags gosnmp.SnmpV3MsgFlags, params *gosnmp.UsmSecurityParameters) {
+		params.AuthoritativeEngineID = string([]byte{0x80, 0x00, 0x00, 0x00, 0x09})
+		client := newClient(t, addr, gosnmp.Version3, func(client *gosnmp.GoSNMP) {
+			client.SecurityModel = gosnmp.UserSecurityModel
+			client.MsgFlags = msgFlags
+			client.SecurityParameters = params
+		})
+		_, err := client.SendTrap(trap)
+		require.NoError(t, err)
+	}
+
+	// Unknown user
+	send(gosnmp.AuthNoPriv, &gosnmp.UsmSecurityParameters{
+		UserName:                 "intruder",
+		AuthenticationProtocol:   gosnmp.MD5,
+		AuthenticationPassphrase: "authpassword",
+	})
+	// Known user below its configured security level
+	send(gosnmp.NoAuthNoPriv, &gosnmp.UsmSecurityParameters{
+		UserName: "monitor",
+	})
+	// Known user with a wrong password
+	send(gosnmp.AuthNoPriv, &gosnmp.UsmSecurityParameters{
+		UserName:                 "monitor",
+		AuthenticationProtocol:   gosnmp.MD5,
+		AuthenticationPassphrase: "wrongpassword",
+	})
+	send(gosnmp.AuthNoPriv, &gosnmp.UsmSecurityParameters{
+		UserName:                 "monitor",
+		AuthenticationProtocol:   gosnmp.MD5,
+		AuthenticationPassphrase: "authpassword",
+	})
+
+	attrs := attributes(t, sink, 1)
+	assert.Equal(t, "monitor", attrs[0][attributeUser])
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestConvertMissingTrapOID(t *testing.T) {
+	rcvr, err := newTrapReceiver(receivertest.NewNopSettings(metadata.Type), createDefaultConfig().(*Config), consumertest.NewNop())
+	require.NoError(t, err)
+	rcvr.mibs = mib.NewTree()
+
+	_, err = rcvr.convert(&gosnmp.SnmpPacket{
+		Version: gosnmp.Version2c,
+		PDUType: gosnmp.SNMPv2Trap,
+		SnmpTrap: gosnmp.SnmpTrap{
+			Variables: []gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1)}},
+		},
+	}, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 162})
+	assert.ErrorIs(t, err, errMissingTrapOID)
+}
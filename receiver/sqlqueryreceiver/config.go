@@ -16,6 +16,9 @@ type Config struct {
 	Config sqlquery.Config `mapstructure:",squash"`
 	// The maximumn number of open connections to the sql server. <= 0 means unlimited
 	MaxOpenConn int `mapstructure:"max_open_conn"`
+	// K8sLeaderElector is the ID of a k8s_leader_elector extension. When set, the queries only run while the
+	// collector holds the leadership, and the logs queries resume from the tracking value persisted in storage.
+	K8sLeaderElector *component.ID `mapstructure:"k8s_leader_elector"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
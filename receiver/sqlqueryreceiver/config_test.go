@@ -273,6 +273,40 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			fname: "config-logs-leader-election.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				Config: sqlquery.Config{
+					ControllerConfig: scraperhelper.ControllerConfig{
+						CollectionInterval: 10 * time.Second,
+						InitialDelay:       time.Second,
+					},
+					Driver:     "postgres",
+					DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+					Queries: []sqlquery.Query{
+						{
+							SQL:                "select * from test_logs where log_id > ?",
+							TrackingColumn:     "log_id",
+							TrackingStartValue: "10",
+							Logs: []sqlquery.LogsCfg{
+								{
+									BodyColumn: "log_body",
+								},
+							},
+						},
+					},
+					StorageID: func() *component.ID {
+						id := component.MustNewID("db_storage")
+						return &id
+					}(),
+				},
+				K8sLeaderElector: func() *component.ID {
+					id := component.MustNewID("k8s_leader_elector")
+					return &id
+				}(),
+			},
+		},
 		{
 			fname: "config-logs-datasource-config.yaml",
 			id:    component.NewIDWithName(metadata.Type, ""),
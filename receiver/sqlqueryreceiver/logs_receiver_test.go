@@ -5,11 +5,14 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -19,6 +22,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sleaderelectortest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
 )
@@ -387,3 +392,103 @@ func TestLogsReceiver_Timeout(t *testing.T) {
 		}
 	}, time.Second, 5*time.Millisecond, "expected a recoverable error status caused by the query timeout")
 }
+
+// trackingDBClient returns the rows of a table with a tracking column greater than the tracking value.
+type trackingDBClient struct {
+	mu   sync.Mutex
+	rows []sqlquery.StringMap
+}
+
+func (c *trackingDBClient) QueryRows(_ context.Context, args ...any) ([]sqlquery.StringMap, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	trackingValue, _ := strconv.Atoi(args[0].(string))
+	var rows []sqlquery.StringMap
+	for _, row := range c.rows {
+		if id, _ := strconv.Atoi(row["id"]); id > trackingValue {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (c *trackingDBClient) insert(row sqlquery.StringMap) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rows = append(c.rows, row)
+}
+
+func TestLogsReceiver_LeaderElectionFailover(t *testing.T) {
+	dbClient := &trackingDBClient{rows: []sqlquery.StringMap{{"id": "1"}, {"id": "2"}}}
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, func(sqlquery.Db, string, *zap.Logger, sqlquery.TelemetryConfig) sqlquery.DbClient {
+		return dbClient
+	})
+
+	// Both instances of the HA pair share the storage holding the tracking value.
+	storageExtension := storagetest.NewFileBackedStorageExtension("shared", t.TempDir())
+	electorID := component.MustNewID("k8s_leader_elector")
+	cfg := &Config{
+		Config: sqlquery.Config{
+			ControllerConfig: scraperhelper.ControllerConfig{
+				CollectionInterval: 10 * time.Millisecond,
+			},
+			Driver:     "postgres",
+			DataSource: "my-datasource",
+			Queries: []sqlquery.Query{{
+				SQL:                "select * from foo where id > $1 order by id",
+				TrackingColumn:     "id",
+				TrackingStartValue: "0",
+				Logs: []sqlquery.LogsCfg{{
+					BodyColumn: "id",
+				}},
+			}},
+			StorageID: &storageExtension.ID,
+		},
+		K8sLeaderElector: &electorID,
+	}
+	// The instances of the pair run the same configuration, hence use the same storage keys.
+	settings := receivertest.NewNopSettings(metadata.Type)
+
+	newInstance := func() (*k8sleaderelectortest.FakeLeaderElection, *consumertest.LogsSink) {
+		elector := &k8sleaderelectortest.FakeLeaderElection{}
+		host := &k8sleaderelectortest.FakeHost{
+			FakeLeaderElection: elector,
+			Extensions:         map[component.ID]component.Component{storageExtension.ID: storageExtension},
+		}
+		sink := &consumertest.LogsSink{}
+		rcv, err := createReceiver(t.Context(), settings, cfg, sink)
+		require.NoError(t, err)
+		require.NoError(t, rcv.Start(t.Context(), host))
+		t.Cleanup(func() {
+			require.NoError(t, rcv.Shutdown(context.Background()))
+		})
+		return elector, sink
+	}
+	bodies := func(sink *consumertest.LogsSink) []string {
+		var bodies []string
+		for _, logs := range sink.AllLogs() {
+			records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			for i := 0; i < records.Len(); i++ {
+				bodies = append(bodies, records.At(i).Body().Str())
+			}
+		}
+		return bodies
+	}
+
+	electorA, sinkA := newInstance()
+	electorB, sinkB := newInstance()
+
+	electorA.InvokeOnLeading()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, []string{"1", "2"}, bodies(sinkA))
+	}, time.Second, 5*time.Millisecond)
+	assert.Empty(t, bodies(sinkB), "the passive instance must not run the queries")
+
+	electorA.InvokeOnStopping()
+	dbClient.insert(sqlquery.StringMap{"id": "3"})
+	electorB.InvokeOnLeading()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, []string{"3"}, bodies(sinkB))
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"1", "2"}, bodies(sinkA), "the instance that lost the leadership must stop")
+}
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8sleaderelector"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
@@ -29,7 +30,14 @@ func createLogsReceiverFunc(sqlOpenerFunc sqlquery.SQLOpenerFunc, clientProvider
 		consumer consumer.Logs,
 	) (receiver.Logs, error) {
 		sqlQueryConfig := cfg.(*Config)
-		return newLogsReceiver(sqlQueryConfig, settings, sqlOpenerFunc, clientProviderFunc, consumer)
+		rcv, err := newLogsReceiver(sqlQueryConfig, settings, sqlOpenerFunc, clientProviderFunc, consumer)
+		if err != nil || sqlQueryConfig.K8sLeaderElector == nil {
+			return rcv, err
+		}
+		// The logs receiver can't be restarted, a new one is created every time the leadership is acquired.
+		return k8sleaderelector.NewLeaderGatedComponent(*sqlQueryConfig.K8sLeaderElector, settings.Logger, func() (component.Component, error) {
+			return newLogsReceiver(sqlQueryConfig, settings, sqlOpenerFunc, clientProviderFunc, consumer)
+		}), nil
 	}
 }
 
@@ -41,44 +49,61 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlquery.SQLOpenerFunc, clientProvi
 		consumer consumer.Metrics,
 	) (receiver.Metrics, error) {
 		sqlCfg := cfg.(*Config)
-		var dataSource string
-		var err error
-
-		if sqlCfg.Config.DataSource != "" {
-			dataSource = sqlCfg.Config.DataSource
-		} else {
-			dataSource, err = sqlquery.BuildDataSourceString(sqlCfg.Config)
-			if err != nil {
-				return nil, err
-			}
+		rcv, err := newMetricsReceiver(sqlCfg, settings, sqlOpenerFunc, clientProviderFunc, consumer)
+		if err != nil || sqlCfg.K8sLeaderElector == nil {
+			return rcv, err
 		}
-		var opts []scraperhelper.ControllerOption
-		pool := internal.NewPool(sqlOpenerFunc, sqlCfg.Config.Driver, dataSource, sqlCfg.MaxOpenConn)
-
-		for i, query := range sqlCfg.Config.Queries {
-			if len(query.Metrics) == 0 {
-				continue
-			}
-			id := component.MustNewIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
-
-			scope := pcommon.NewInstrumentationScope()
-			scope.SetName(metadata.ScopeName)
-			mp := sqlquery.NewScraper(id, query, sqlCfg.Config.ControllerConfig, settings.Logger, sqlCfg.Config.Telemetry, pool.DB, clientProviderFunc, scope)
-
-			wrapped := &statusReportingScraper{
-				delegate: mp,
-			}
-			opt := scraperhelper.AddMetricsScraper(metadata.Type, wrapped)
-			opts = append(opts, opt)
+		// The scraper controller can't be restarted, a new one is created every time the leadership is acquired.
+		return k8sleaderelector.NewLeaderGatedComponent(*sqlCfg.K8sLeaderElector, settings.Logger, func() (component.Component, error) {
+			return newMetricsReceiver(sqlCfg, settings, sqlOpenerFunc, clientProviderFunc, consumer)
+		}), nil
+	}
+}
+
+func newMetricsReceiver(
+	sqlCfg *Config,
+	settings receiver.Settings,
+	sqlOpenerFunc sqlquery.SQLOpenerFunc,
+	clientProviderFunc sqlquery.ClientProviderFunc,
+	consumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	var dataSource string
+	var err error
+
+	if sqlCfg.Config.DataSource != "" {
+		dataSource = sqlCfg.Config.DataSource
+	} else {
+		dataSource, err = sqlquery.BuildDataSourceString(sqlCfg.Config)
+		if err != nil {
+			return nil, err
 		}
+	}
+	var opts []scraperhelper.ControllerOption
+	pool := internal.NewPool(sqlOpenerFunc, sqlCfg.Config.Driver, dataSource, sqlCfg.MaxOpenConn)
 
-		return scraperhelper.NewMetricsController(
-			&sqlCfg.Config.ControllerConfig,
-			settings,
-			consumer,
-			opts...,
-		)
+	for i, query := range sqlCfg.Config.Queries {
+		if len(query.Metrics) == 0 {
+			continue
+		}
+		id := component.MustNewIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
+
+		scope := pcommon.NewInstrumentationScope()
+		scope.SetName(metadata.ScopeName)
+		mp := sqlquery.NewScraper(id, query, sqlCfg.Config.ControllerConfig, settings.Logger, sqlCfg.Config.Telemetry, pool.DB, clientProviderFunc, scope)
+
+		wrapped := &statusReportingScraper{
+			delegate: mp,
+		}
+		opt := scraperhelper.AddMetricsScraper(metadata.Type, wrapped)
+		opts = append(opts, opt)
 	}
+
+	return scraperhelper.NewMetricsController(
+		&sqlCfg.Config.ControllerConfig,
+		settings,
+		consumer,
+		opts...,
+	)
 }
 
 type statusReportingScraper struct {
@@ -4,6 +4,7 @@
 package sqlqueryreceiver
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sleaderelectortest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
 )
@@ -231,6 +233,69 @@ func TestStatusReportingMetrics(t *testing.T) {
 	require.NoError(t, receiver.Shutdown(ctx))
 }
 
+func TestCreateMetricsWithLeaderElection(t *testing.T) {
+	createReceiver := createMetricsReceiverFunc(fakeDBConnect, func(sqlquery.Db, string, *zap.Logger, sqlquery.TelemetryConfig) sqlquery.DbClient {
+		return constantDBClient{{"foo": "111"}}
+	})
+	ctx := t.Context()
+	electorID := component.MustNewID("k8s_leader_elector")
+	elector := &k8sleaderelectortest.FakeLeaderElection{}
+	sink := &consumertest.MetricsSink{}
+
+	receiver, err := createReceiver(
+		ctx,
+		receivertest.NewNopSettings(metadata.Type),
+		&Config{
+			Config: sqlquery.Config{
+				ControllerConfig: scraperhelper.ControllerConfig{
+					CollectionInterval: 10 * time.Millisecond,
+				},
+				Driver:     "postgres",
+				DataSource: "my-datasource",
+				Queries: []sqlquery.Query{{
+					SQL: "select * from foo",
+					Metrics: []sqlquery.MetricCfg{{
+						MetricName:  "my-metric",
+						ValueColumn: "foo",
+					}},
+				}},
+			},
+			K8sLeaderElector: &electorID,
+		},
+		sink,
+	)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(ctx, &k8sleaderelectortest.FakeHost{FakeLeaderElection: elector}))
+
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, sink.DataPointCount(), "no queries run before the leadership is acquired")
+
+	elector.InvokeOnLeading()
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() > 0
+	}, time.Second, 5*time.Millisecond)
+
+	elector.InvokeOnStopping()
+	count := sink.DataPointCount()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, count, sink.DataPointCount(), "no queries run after the leadership is lost")
+
+	// The scraper controller is recreated when the leadership is regained
+	elector.InvokeOnLeading()
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() > count
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, receiver.Shutdown(ctx))
+}
+
+// constantDBClient returns the same rows for every query.
+type constantDBClient []sqlquery.StringMap
+
+func (c constantDBClient) QueryRows(context.Context, ...any) ([]sqlquery.StringMap, error) {
+	return c, nil
+}
+
 type statusReporterHost struct {
 	component.Host
 	report func(*componentstatus.Event)
@@ -45,7 +45,7 @@ func TestLoadConfig(t *testing.T) {
 				},
 				AggregationInterval:   60 * time.Second,
 				CounterType:           protocol.CounterTypeFloat,
-				TimerHistogramMapping: defaultTimerHistogramMapping,
+				TimerHistogramMapping: defaultTimerHistogramMapping(),
 				SocketPermissions:     0o622,
 			},
 		},
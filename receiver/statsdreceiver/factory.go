@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
@@ -24,7 +25,15 @@ const (
 	defaultSocketPermissions   = os.FileMode(0o622)
 )
 
-var defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "gauge"}}
+// defaultTimerHistogramMapping converts distributions to gauges, or to histograms if the
+// receiver.statsd.distributionHistogramDefault feature gate is enabled.
+func defaultTimerHistogramMapping() []protocol.TimerHistogramMapping {
+	distributionObserverType := protocol.GaugeObserver
+	if metadata.ReceiverStatsdDistributionHistogramDefaultFeatureGate.IsEnabled() {
+		distributionObserverType = protocol.HistogramObserver
+	}
+	return []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: distributionObserverType}}
+}
 
 // NewFactory creates a factory for the StatsD receiver.
 func NewFactory() receiver.Factory {
@@ -32,6 +41,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -45,7 +55,7 @@ func createDefaultConfig() component.Config {
 		EnableMetricType:      defaultEnableMetricType,
 		IsMonotonicCounter:    metadata.ReceiverStatsdMonotonicCounterDefaultFeatureGate.IsEnabled(),
 		CounterType:           protocol.DefaultCounterType,
-		TimerHistogramMapping: defaultTimerHistogramMapping,
+		TimerHistogramMapping: defaultTimerHistogramMapping(),
 		SocketPermissions:     defaultSocketPermissions,
 	}
 }
@@ -56,6 +66,42 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
+	var err error
 	c := cfg.(*Config)
-	return newReceiver(params, *c, consumer)
+	r := receivers.GetOrAdd(c, func() component.Component {
+		var rcv receiver.Metrics
+		rcv, err = newReceiver(params, *c, nil)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+	return r, nil
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	var err error
+	c := cfg.(*Config)
+	r := receivers.GetOrAdd(c, func() component.Component {
+		var rcv receiver.Metrics
+		rcv, err = newReceiver(params, *c, nil)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.Unwrap().(*statsdReceiver).nextLogsConsumer = consumer
+	return r, nil
+}
+
+// receivers is the map of StatsD receivers shared by the metrics and logs pipelines,
+// as both listen on the same endpoint.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
@@ -39,6 +40,22 @@ func TestCreateDefaultConfig_MonotonicCounterDefaultFeatureGate(t *testing.T) {
 	assert.True(t, cfg.IsMonotonicCounter)
 }
 
+func TestCreateDefaultConfig_DistributionHistogramDefaultFeatureGate(t *testing.T) {
+	gate := metadata.ReceiverStatsdDistributionHistogramDefaultFeatureGate
+	originalValue := gate.IsEnabled()
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(gate.ID(), originalValue))
+	})
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(gate.ID(), false))
+	cfg := createDefaultConfig().(*Config)
+	assert.Contains(t, cfg.TimerHistogramMapping, protocol.TimerHistogramMapping{StatsdType: "distribution", ObserverType: protocol.GaugeObserver})
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(gate.ID(), true))
+	cfg = createDefaultConfig().(*Config)
+	assert.Contains(t, cfg.TimerHistogramMapping, protocol.TimerHistogramMapping{StatsdType: "distribution", ObserverType: protocol.HistogramObserver})
+}
+
 func TestCreateReceiver(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.NetAddr.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
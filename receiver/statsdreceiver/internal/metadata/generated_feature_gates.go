@@ -6,6 +6,14 @@ import (
 	"go.opentelemetry.io/collector/featuregate"
 )
 
+var ReceiverStatsdDistributionHistogramDefaultFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"receiver.statsd.distributionHistogramDefault",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, changes the default timer_histogram_mapping of DogStatsD distributions from gauge to histogram, so that distributions are converted to exponential histograms by default. A mapping of the distribution statsd_type in the receiver config always takes precedence over this feature gate."),
+	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/24768"),
+	featuregate.WithRegisterFromVersion("v0.159.0"),
+)
+
 var ReceiverStatsdMonotonicCounterDefaultFeatureGate = featuregate.GlobalRegistry().MustRegister(
 	"receiver.statsd.monotonicCounterDefault",
 	featuregate.StageAlpha,
@@ -13,3 +21,11 @@ var ReceiverStatsdMonotonicCounterDefaultFeatureGate = featuregate.GlobalRegistr
 	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/14956"),
 	featuregate.WithRegisterFromVersion("v0.159.0"),
 )
+
+var ReceiverStatsdOriginResourceAttributesFeatureGate = featuregate.GlobalRegistry().MustRegister(
+	"receiver.statsd.originResourceAttributes",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the DogStatsD origin detection fields of metrics (container ID, container name and pod UID) are set as resource attributes, and metrics of different origins are emitted under different resources. When disabled, the container ID field is set as is in the container.id data point attribute."),
+	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/31295"),
+	featuregate.WithRegisterFromVersion("v0.159.0"),
+)
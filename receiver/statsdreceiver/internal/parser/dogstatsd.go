@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/parser"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	eventName        = "dogstatsd.event"
+	serviceCheckName = "dogstatsd.service_check"
+
+	attrEventTitle          = "dogstatsd.event.title"
+	attrEventPriority       = "dogstatsd.event.priority"
+	attrEventAlertType      = "dogstatsd.event.alert_type"
+	attrEventAggregationKey = "dogstatsd.event.aggregation_key"
+	attrEventSourceType     = "dogstatsd.event.source_type_name"
+	attrServiceCheckName    = "dogstatsd.service_check.name"
+	attrServiceCheckStatus  = "dogstatsd.service_check.status"
+)
+
+var (
+	errEmptyEventTitle       = errors.New("empty event title")
+	errEmptyServiceCheckName = errors.New("empty service check name")
+
+	// eventAlertTypes maps the alert types of an event to the severity of its log record.
+	eventAlertTypes = map[string]plog.SeverityNumber{
+		"error":   plog.SeverityNumberError,
+		"warning": plog.SeverityNumberWarn,
+		"info":    plog.SeverityNumberInfo,
+		"success": plog.SeverityNumberInfo,
+	}
+
+	// serviceCheckStatuses are the names and log record severities of the service check status codes.
+	serviceCheckStatuses = []struct {
+		name     string
+		severity plog.SeverityNumber
+	}{
+		{name: "ok", severity: plog.SeverityNumberInfo},
+		{name: "warning", severity: plog.SeverityNumberWarn},
+		{name: "critical", severity: plog.SeverityNumberError},
+		{name: "unknown", severity: plog.SeverityNumberUnspecified},
+	}
+)
+
+// origin identifies where a DogStatsD message comes from, using the origin detection fields
+// of the DogStatsD protocol and the hostname of events and service checks.
+type origin struct {
+	host          string
+	containerID   string
+	podUID        string
+	containerName string
+}
+
+// parseField sets the origin detection field held by part and reports whether part is one.
+func (o *origin) parseField(part string) bool {
+	switch {
+	case strings.HasPrefix(part, "c:"):
+		// As per DogStatsD protocol v1.2, the container ID is either sent as is or, by newer
+		// clients, as "ci-<container ID>" or "in-<cgroup inode>". Resolving a cgroup inode
+		// needs access to the host cgroup filesystem, so inodes are ignored.
+		// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
+		for _, id := range strings.Split(strings.TrimPrefix(part, "c:"), ",") {
+			switch {
+			case strings.HasPrefix(id, "ci-"):
+				o.containerID = strings.TrimPrefix(id, "ci-")
+			case strings.HasPrefix(id, "in-"):
+			case id != "":
+				o.containerID = id
+			}
+		}
+		return true
+	case strings.HasPrefix(part, "e:"):
+		// As per DogStatsD protocol v1.4, the external data is injected in the environment of a
+		// Kubernetes pod by the Datadog admission controller.
+		for _, item := range strings.Split(strings.TrimPrefix(part, "e:"), ",") {
+			switch {
+			case strings.HasPrefix(item, "cn-"):
+				o.containerName = strings.TrimPrefix(item, "cn-")
+			case strings.HasPrefix(item, "pu-"):
+				o.podUID = strings.TrimPrefix(item, "pu-")
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// putResourceAttributes sets the resource attributes describing the origin.
+func (o origin) putResourceAttributes(attrs pcommon.Map) {
+	if o.host != "" {
+		attrs.PutStr(string(conventions.HostNameKey), o.host)
+	}
+	if o.containerID != "" {
+		attrs.PutStr(string(conventions.ContainerIDKey), o.containerID)
+	}
+	if o.podUID != "" {
+		attrs.PutStr(string(conventions.K8SPodUIDKey), o.podUID)
+	}
+	if o.containerName != "" {
+		attrs.PutStr(string(conventions.K8SContainerNameKey), o.containerName)
+	}
+}
+
+// logBatch holds the events and service checks received from an address and origin.
+type logBatch struct {
+	addr    net.Addr
+	logs    plog.Logs
+	records plog.LogRecordSlice
+}
+
+type logParseFunc func(line string, enableSimpleTags bool) (plog.LogRecord, origin, error)
+
+func (p *StatsDParser) aggregateLog(parse logParseFunc, line string, addr net.Addr) error {
+	lr, o, err := parse(line, p.enableSimpleTags)
+	if err != nil {
+		return err
+	}
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+
+	addrKey := p.addressKey(addr)
+	addrKey.Origin = o
+
+	batch, ok := p.logsByAddress[addrKey]
+	if !ok {
+		batch = &logBatch{
+			addr: addr,
+			logs: plog.NewLogs(),
+		}
+		rl := batch.logs.ResourceLogs().AppendEmpty()
+		o.putResourceAttributes(rl.Resource().Attributes())
+		sl := rl.ScopeLogs().AppendEmpty()
+		p.setVersionAndNameScope(sl.Scope())
+		batch.records = sl.LogRecords()
+		p.logsByAddress[addrKey] = batch
+	}
+	lr.MoveTo(batch.records.AppendEmpty())
+	return nil
+}
+
+// GetLogs gets the events and service checks preparing for flushing and reset them.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.logsByAddress))
+	for _, batch := range p.logsByAddress {
+		batchLogs = append(batchLogs, BatchLogs{
+			Info: client.Info{
+				Addr: batch.addr,
+			},
+			Logs: batch.logs,
+		})
+	}
+	p.logsByAddress = make(map[netAddr]*logBatch)
+	return batchLogs
+}
+
+// parseEventMessage parses a DogStatsD event:
+// _e{<TITLE_UTF8_LENGTH>,<TEXT_UTF8_LENGTH>}:<TITLE>|<TEXT>|d:<TIMESTAMP>|h:<HOSTNAME>|p:<PRIORITY>|t:<ALERT_TYPE>|#<TAGS>
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=events
+func parseEventMessage(line string, enableSimpleTags bool) (plog.LogRecord, origin, error) {
+	lr := plog.NewLogRecord()
+	var o origin
+
+	header, rest, found := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !found {
+		return lr, o, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, found := strings.Cut(header, ",")
+	if !found {
+		return lr, o, fmt.Errorf("invalid event lengths: %s", header)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen < 0 {
+		return lr, o, fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return lr, o, fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	if titleLen+1+textLen > len(rest) || rest[titleLen] != '|' {
+		return lr, o, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	if titleLen == 0 {
+		return lr, o, errEmptyEventTitle
+	}
+
+	title := rest[:titleLen]
+	text := rest[titleLen+1 : titleLen+1+textLen]
+	fields := rest[titleLen+1+textLen:]
+	if fields != "" && fields[0] != '|' {
+		return lr, o, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	lr.SetEventName(eventName)
+	lr.Body().SetStr(strings.ReplaceAll(text, `\n`, "\n"))
+	attrs := lr.Attributes()
+	attrs.PutStr(attrEventTitle, strings.ReplaceAll(title, `\n`, "\n"))
+
+	priority := "normal"
+	alertType := "info"
+	for _, part := range strings.Split(strings.TrimPrefix(fields, "|"), "|") {
+		switch {
+		case part == "":
+		case strings.HasPrefix(part, "d:"):
+			timestampStr := strings.TrimPrefix(part, "d:")
+			timestampSeconds, err := strconv.ParseInt(timestampStr, 10, 64)
+			if err != nil {
+				return lr, o, fmt.Errorf("invalid timestamp: %s", timestampStr)
+			}
+			lr.SetTimestamp(pcommon.Timestamp(timestampSeconds * 1e9))
+		case strings.HasPrefix(part, "h:"):
+			o.host = strings.TrimPrefix(part, "h:")
+		case strings.HasPrefix(part, "p:"):
+			priority = strings.TrimPrefix(part, "p:")
+			if priority != "normal" && priority != "low" {
+				return lr, o, fmt.Errorf("invalid event priority: %s", priority)
+			}
+		case strings.HasPrefix(part, "t:"):
+			alertType = strings.TrimPrefix(part, "t:")
+			if _, ok := eventAlertTypes[alertType]; !ok {
+				return lr, o, fmt.Errorf("invalid event alert type: %s", alertType)
+			}
+		case strings.HasPrefix(part, "k:"):
+			attrs.PutStr(attrEventAggregationKey, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "s:"):
+			attrs.PutStr(attrEventSourceType, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return lr, o, err
+			}
+			for _, tag := range tags {
+				attrs.PutStr(string(tag.Key), tag.Value.AsString())
+			}
+		case o.parseField(part):
+		default:
+			return lr, o, fmt.Errorf("unrecognized message part: %s", part)
+		}
+	}
+	attrs.PutStr(attrEventPriority, priority)
+	attrs.PutStr(attrEventAlertType, alertType)
+	lr.SetSeverityNumber(eventAlertTypes[alertType])
+	lr.SetSeverityText(alertType)
+
+	return lr, o, nil
+}
+
+// parseServiceCheckMessage parses a DogStatsD service check:
+// _sc|<NAME>|<STATUS>|d:<TIMESTAMP>|h:<HOSTNAME>|#<TAGS>|m:<SERVICE_CHECK_MESSAGE>
+// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=servicechecks
+func parseServiceCheckMessage(line string, enableSimpleTags bool) (plog.LogRecord, origin, error) {
+	lr := plog.NewLogRecord()
+	var o origin
+
+	name, rest, _ := strings.Cut(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if name == "" {
+		return lr, o, errEmptyServiceCheckName
+	}
+	statusStr, rest, _ := strings.Cut(rest, "|")
+	status, err := strconv.Atoi(statusStr)
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return lr, o, fmt.Errorf("invalid service check status: %s", statusStr)
+	}
+
+	lr.SetEventName(serviceCheckName)
+	attrs := lr.Attributes()
+	attrs.PutStr(attrServiceCheckName, name)
+	attrs.PutStr(attrServiceCheckStatus, serviceCheckStatuses[status].name)
+	lr.SetSeverityNumber(serviceCheckStatuses[status].severity)
+	lr.SetSeverityText(serviceCheckStatuses[status].name)
+
+	var part string
+	for rest != "" {
+		// The message is the last field and may contain pipes.
+		if strings.HasPrefix(rest, "m:") {
+			message := strings.ReplaceAll(strings.TrimPrefix(rest, "m:"), `\n`, "\n")
+			lr.Body().SetStr(strings.ReplaceAll(message, `m\:`, "m:"))
+			break
+		}
+		part, rest, _ = strings.Cut(rest, "|")
+		switch {
+		case part == "":
+		case strings.HasPrefix(part, "d:"):
+			timestampStr := strings.TrimPrefix(part, "d:")
+			timestampSeconds, err := strconv.ParseInt(timestampStr, 10, 64)
+			if err != nil {
+				return lr, o, fmt.Errorf("invalid timestamp: %s", timestampStr)
+			}
+			lr.SetTimestamp(pcommon.Timestamp(timestampSeconds * 1e9))
+		case strings.HasPrefix(part, "h:"):
+			o.host = strings.TrimPrefix(part, "h:")
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return lr, o, err
+			}
+			for _, tag := range tags {
+				attrs.PutStr(string(tag.Key), tag.Value.AsString())
+			}
+		case o.parseField(part):
+		default:
+			return lr, o, fmt.Errorf("unrecognized message part: %s", part)
+		}
+	}
+
+	return lr, o, nil
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parser
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+func Test_ParseEventMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantAttrs map[string]any
+		wantBody  string
+		wantSev   plog.SeverityNumber
+		wantTime  pcommon.Timestamp
+		wantOrig  origin
+		err       error
+	}{
+		{
+			name:     "minimal event",
+			input:    "_e{5,4}:title|text",
+			wantBody: "text",
+			wantAttrs: map[string]any{
+				attrEventTitle:     "title",
+				attrEventPriority:  "normal",
+				attrEventAlertType: "info",
+			},
+			wantSev: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "event with all fields",
+			input:    `_e{9,12}:Deploy|up|line1\nline2|d:1656581400|h:web-1|p:low|t:error|k:deploys|s:jenkins|#env:prod,team:core|c:ci-abc123|e:cn-app,pu-1234`,
+			wantBody: "line1\nline2",
+			wantAttrs: map[string]any{
+				attrEventTitle:          "Deploy|up",
+				attrEventPriority:       "low",
+				attrEventAlertType:      "error",
+				attrEventAggregationKey: "deploys",
+				attrEventSourceType:     "jenkins",
+				"env":                   "prod",
+				"team":                  "core",
+			},
+			wantSev:  plog.SeverityNumberError,
+			wantTime: pcommon.Timestamp(1656581400 * 1e9),
+			wantOrig: origin{host: "web-1", containerID: "abc123", containerName: "app", podUID: "1234"},
+		},
+		{
+			name:     "event with empty text",
+			input:    "_e{5,0}:title||t:warning",
+			wantBody: "",
+			wantAttrs: map[string]any{
+				attrEventTitle:     "title",
+				attrEventPriority:  "normal",
+				attrEventAlertType: "warning",
+			},
+			wantSev: plog.SeverityNumberWarn,
+		},
+		{
+			name:  "missing lengths",
+			input: "_e{5}:title|text",
+			err:   errors.New("invalid event lengths: 5"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("invalid event title length: a"),
+		},
+		{
+			name:  "lengths do not match",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "text longer than its length",
+			input: "_e{5,2}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,2}:title|text"),
+		},
+		{
+			name:  "empty title",
+			input: "_e{0,4}:|text",
+			err:   errors.New("empty event title"),
+		},
+		{
+			name:  "invalid priority",
+			input: "_e{5,4}:title|text|p:high",
+			err:   errors.New("invalid event priority: high"),
+		},
+		{
+			name:  "invalid alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("invalid event alert type: fatal"),
+		},
+		{
+			name:  "unrecognized message part",
+			input: "_e{5,4}:title|text|$extra",
+			err:   errors.New("unrecognized message part: $extra"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, o, err := parseEventMessage(tt.input, false)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, eventName, lr.EventName())
+			assert.Equal(t, tt.wantBody, lr.Body().Str())
+			assert.Equal(t, tt.wantAttrs, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.wantSev, lr.SeverityNumber())
+			assert.Equal(t, tt.wantTime, lr.Timestamp())
+			assert.Equal(t, tt.wantOrig, o)
+		})
+	}
+}
+
+func Test_ParseServiceCheckMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantAttrs map[string]any
+		wantBody  string
+		wantSev   plog.SeverityNumber
+		wantTime  pcommon.Timestamp
+		wantOrig  origin
+		err       error
+	}{
+		{
+			name:  "minimal service check",
+			input: "_sc|app.is_up|0",
+			wantAttrs: map[string]any{
+				attrServiceCheckName:   "app.is_up",
+				attrServiceCheckStatus: "ok",
+			},
+			wantSev: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "service check with all fields",
+			input:    `_sc|app.is_up|2|d:1656581400|h:web-1|#env:prod|c:abc123|m:down|since m\:10\ntry again`,
+			wantBody: "down|since m:10\ntry again",
+			wantAttrs: map[string]any{
+				attrServiceCheckName:   "app.is_up",
+				attrServiceCheckStatus: "critical",
+				"env":                  "prod",
+			},
+			wantSev:  plog.SeverityNumberError,
+			wantTime: pcommon.Timestamp(1656581400 * 1e9),
+			wantOrig: origin{host: "web-1", containerID: "abc123"},
+		},
+		{
+			name:  "unknown status",
+			input: "_sc|app.is_up|3",
+			wantAttrs: map[string]any{
+				attrServiceCheckName:   "app.is_up",
+				attrServiceCheckStatus: "unknown",
+			},
+			wantSev: plog.SeverityNumberUnspecified,
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty service check name"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|app.is_up|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_sc|app.is_up|1|d:now",
+			err:   errors.New("invalid timestamp: now"),
+		},
+		{
+			name:  "invalid tag format",
+			input: "_sc|app.is_up|1|#env",
+			err:   errors.New("invalid tag format: \"env\""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr, o, err := parseServiceCheckMessage(tt.input, false)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, serviceCheckName, lr.EventName())
+			if tt.wantBody == "" {
+				assert.Equal(t, pcommon.ValueTypeEmpty, lr.Body().Type())
+			} else {
+				assert.Equal(t, tt.wantBody, lr.Body().Str())
+			}
+			assert.Equal(t, tt.wantAttrs, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.wantSev, lr.SeverityNumber())
+			assert.Equal(t, tt.wantTime, lr.Timestamp())
+			assert.Equal(t, tt.wantOrig, o)
+		})
+	}
+}
+
+func TestStatsDParser_GetLogs(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	defer func() { timeNowFunc = time.Now }()
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, false, false, nil, protocol.CounterTypeInt))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text|h:web-1", addr))
+	require.NoError(t, p.Aggregate("_sc|app.is_up|1|h:web-1", addr))
+	require.NoError(t, p.Aggregate("_sc|app.is_up|0|c:abc123", addr))
+	require.NoError(t, p.Aggregate("test.metric:1|c", addr))
+	require.Error(t, p.Aggregate("_sc|app.is_up|9", addr))
+
+	// Metrics and logs are flushed independently.
+	require.Len(t, p.GetMetrics(), 1)
+
+	batches := p.GetLogs()
+	require.Len(t, batches, 2)
+	records := map[string]int{}
+	for _, batch := range batches {
+		assert.Equal(t, addr, batch.Info.Addr)
+		rl := batch.Logs.ResourceLogs().At(0)
+		assert.Equal(t, receiverName, rl.ScopeLogs().At(0).Scope().Name())
+		lrs := rl.ScopeLogs().At(0).LogRecords()
+		for i := 0; i < lrs.Len(); i++ {
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), lrs.At(i).ObservedTimestamp())
+		}
+		if v, ok := rl.Resource().Attributes().Get("host.name"); ok {
+			records[v.Str()] = lrs.Len()
+		} else if v, ok := rl.Resource().Attributes().Get("container.id"); ok {
+			records[v.Str()] = lrs.Len()
+		}
+	}
+	assert.Equal(t, map[string]int{"web-1": 2, "abc123": 1}, records)
+
+	assert.Empty(t, p.GetLogs())
+}
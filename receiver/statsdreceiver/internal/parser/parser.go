@@ -7,15 +7,18 @@ import (
 	"net"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
-// Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Parser is something that can map input StatsD strings to OTLP Metric representations,
+// and DogStatsD events and service checks to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType, enableSimpleTags, isMonotonicCounter, enableIPOnlyAggregation, ignoreHost bool, sendTimerHistogram []protocol.TimerHistogramMapping, counterType protocol.CounterType) error
 	GetMetrics() []BatchMetrics
+	GetLogs() []BatchLogs
 	Aggregate(line string, addr net.Addr) error
 }
 
@@ -23,3 +26,8 @@ type BatchMetrics struct {
 	Info    client.Info
 	Metrics pmetric.Metrics
 }
+
+type BatchLogs struct {
+	Info client.Info
+	Logs plog.Logs
+}
@@ -65,12 +65,18 @@ type StatsDParser struct {
 	counterType             protocol.CounterType
 	timerEvents             ObserverCategory
 	histogramEvents         ObserverCategory
+	distributionEvents      ObserverCategory
+	logsByAddress           map[netAddr]*logBatch
 	lastIntervalTime        time.Time
 	BuildInfo               component.BuildInfo
+	// OriginResourceAttributes sets the DogStatsD origin of metrics as resource attributes
+	// instead of data point attributes.
+	OriginResourceAttributes bool
 }
 
 type instruments struct {
 	addr                   net.Addr
+	origin                 origin
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
@@ -170,10 +176,17 @@ type histogramMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
-	addition    bool
-	unit        string
-	sampleRate  float64
-	timestamp   uint64
+	// packedValues holds the values following the first one in a DogStatsD packed message
+	// such as "name:1:2:3|d" (protocol v1.1).
+	packedValues []float64
+	addition     bool
+	unit         string
+	sampleRate   float64
+	timestamp    uint64
+	origin       origin
+	// containerField is the container ID field as sent, recorded on the data points
+	// when the origin isn't set as resource attributes.
+	containerField string
 }
 
 type statsDMetricDescription struct {
@@ -205,6 +218,7 @@ func (p *StatsDParser) resetState(when time.Time) {
 
 func (p *StatsDParser) Initialize(enableMetricType, enableSimpleTags, isMonotonicCounter, enableIPOnlyAggregation, ignoreHost bool, sendTimerHistogram []protocol.TimerHistogramMapping, counterType protocol.CounterType) error {
 	p.resetState(timeNowFunc())
+	p.logsByAddress = make(map[netAddr]*logBatch)
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
@@ -216,28 +230,38 @@ func (p *StatsDParser) Initialize(enableMetricType, enableSimpleTags, isMonotoni
 	p.counterType = counterType
 
 	// Note: validation occurs in ("../".Config).validate()
+	distributionMapped := false
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
-		case protocol.HistogramTypeName, protocol.DistributionTypeName:
-			p.histogramEvents.method = eachMap.ObserverType
-			if eachMap.Histogram.ExplicitBuckets != nil {
-				p.histogramEvents.explicitBucketConfigs = explicitBucketInitializeRegex(eachMap.Histogram)
-			}
-			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
-			p.histogramEvents.summaryPercentiles = eachMap.Summary.Percentiles
+		case protocol.HistogramTypeName:
+			p.histogramEvents = observerCategory(eachMap)
+		case protocol.DistributionTypeName:
+			p.distributionEvents = observerCategory(eachMap)
+			distributionMapped = true
 		case protocol.TimingTypeName, protocol.TimingAltTypeName:
-			p.timerEvents.method = eachMap.ObserverType
-			if eachMap.Histogram.ExplicitBuckets != nil {
-				p.timerEvents.explicitBucketConfigs = explicitBucketInitializeRegex(eachMap.Histogram)
-			}
-			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
-			p.timerEvents.summaryPercentiles = eachMap.Summary.Percentiles
+			p.timerEvents = observerCategory(eachMap)
 		case protocol.CounterTypeName, protocol.GaugeTypeName:
 		}
 	}
+	// Distributions used to share the mapping of histograms, keep doing so unless they have their own.
+	if !distributionMapped {
+		p.distributionEvents = p.histogramEvents
+	}
 	return nil
 }
 
+func observerCategory(mapping protocol.TimerHistogramMapping) ObserverCategory {
+	category := ObserverCategory{
+		method:             mapping.ObserverType,
+		histogramConfig:    expoHistogramConfig(mapping.Histogram),
+		summaryPercentiles: mapping.Summary.Percentiles,
+	}
+	if mapping.Histogram.ExplicitBuckets != nil {
+		category.explicitBucketConfigs = explicitBucketInitializeRegex(mapping.Histogram)
+	}
+	return category
+}
+
 func explicitBucketInitializeRegex(opts protocol.HistogramConfig) []explicitBucketConfig {
 	ebc := make([]explicitBucketConfig, len(opts.ExplicitBuckets))
 	for i := range opts.ExplicitBuckets {
@@ -269,6 +293,7 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			Metrics: pmetric.NewMetrics(),
 		}
 		rm := batch.Metrics.ResourceMetrics().AppendEmpty()
+		instrument.origin.putResourceAttributes(rm.Resource().Attributes())
 		for _, metric := range instrument.gauges {
 			p.copyMetricAndScope(rm, metric)
 		}
@@ -333,8 +358,10 @@ var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	switch t {
-	case HistogramType, DistributionType:
+	case HistogramType:
 		return p.histogramEvents
+	case DistributionType:
+		return p.distributionEvents
 	case TimingType:
 		return p.timerEvents
 	case CounterType, GaugeType:
@@ -342,35 +369,69 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	return defaultObserverCategory
 }
 
-// Aggregate for each metric line.
+// Aggregate for each metric, event or service check line.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.aggregateLog(parseEventMessage, line, addr)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.aggregateLog(parseServiceCheckMessage, line, addr)
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType, p.enableSimpleTags)
 	if err != nil {
 		return err
 	}
 
 	// Discard NaN and infinite values for all metric types
-	if math.IsNaN(parsedMetric.asFloat) || math.IsInf(parsedMetric.asFloat, 0) {
-		reason := "NaN"
-		if math.IsInf(parsedMetric.asFloat, 0) {
-			reason = "infinite"
+	values := append([]float64{parsedMetric.asFloat}, parsedMetric.packedValues...)
+	for _, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			reason := "NaN"
+			if math.IsInf(value, 0) {
+				reason = "infinite"
+			}
+			return fmt.Errorf("discarding metric %q: invalid %s value", parsedMetric.description.name, reason)
 		}
-		return fmt.Errorf("discarding metric %q: invalid %s value", parsedMetric.description.name, reason)
 	}
 
-	addrKey := newNetAddr(addr)
-	if p.ignoreHost {
-		addrKey = netAddr{}
-	} else if p.enableIPOnlyAggregation {
-		addrKey = newIPOnlyNetAddr(addr)
+	if !p.OriginResourceAttributes {
+		if parsedMetric.containerField != "" {
+			attrs := append(parsedMetric.description.attrs.ToSlice(), attribute.String(string(conventions.ContainerIDKey), parsedMetric.containerField))
+			parsedMetric.description.attrs = attribute.NewSet(attrs...)
+		}
+		parsedMetric.origin = origin{}
 	}
 
+	addrKey := p.addressKey(addr)
+	addrKey.Origin = parsedMetric.origin
+
 	instrument, ok := p.instrumentsByAddress[addrKey]
 	if !ok {
 		instrument = newInstruments(addr)
+		instrument.origin = parsedMetric.origin
 		p.instrumentsByAddress[addrKey] = instrument
 	}
 
+	for _, value := range values {
+		parsedMetric.asFloat = value
+		p.aggregateMetric(instrument, parsedMetric)
+	}
+	return nil
+}
+
+// addressKey returns the key metrics, events and service checks received from addr are aggregated under.
+func (p *StatsDParser) addressKey(addr net.Addr) netAddr {
+	if p.ignoreHost {
+		return netAddr{}
+	}
+	if p.enableIPOnlyAggregation {
+		return newIPOnlyNetAddr(addr)
+	}
+	return newNetAddr(addr)
+}
+
+func (p *StatsDParser) aggregateMetric(instrument *instruments, parsedMetric statsDMetric) {
 	switch parsedMetric.description.metricType {
 	case GaugeType:
 		_, ok := instrument.gauges[parsedMetric.description]
@@ -462,8 +523,6 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 			// No action.
 		}
 	}
-
-	return nil
 }
 
 func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool) (statsDMetric, error) {
@@ -489,6 +548,9 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 	if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
 		result.addition = true
 	}
+	// As per DogStatsD protocol v1.1, several values can be packed in a single message:
+	// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v11
+	valueStr, packedStr, packed := strings.Cut(valueStr, ":")
 
 	metricType, additionalParts, _ := strings.Cut(rest, "|")
 	inType := MetricType(metricType)
@@ -499,6 +561,21 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
 	}
 
+	if packed {
+		switch inType {
+		case TimingType, HistogramType, DistributionType:
+		default:
+			return result, errors.New("only TIMING, HISTOGRAM and DISTRIBUTION metrics support packed values")
+		}
+		for _, packedValue := range strings.Split(packedStr, ":") {
+			f, err := strconv.ParseFloat(packedValue, 64)
+			if err != nil {
+				return result, fmt.Errorf("parse metric value string: %s", packedValue)
+			}
+			result.packedValues = append(result.packedValues, f)
+		}
+	}
+
 	var kvs []attribute.KeyValue
 
 	var part string
@@ -515,41 +592,18 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if tagsStr == "" {
-				continue
-			}
-
-			var tagSet string
-			tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
-			for ; tagSet != "" || tagsStr != ""; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
-				if tagSet == "" {
-					continue
-				}
-				k, v, _ := strings.Cut(tagSet, ":")
-				if k == "" {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				// support both simple tags (w/o value) and dimension tags (w/ value).
-				// dogstatsd notably allows simple tags.
-				if v == "" && !enableSimpleTags {
-					return result, fmt.Errorf("invalid tag format: %q", tagSet)
-				}
-
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"), enableSimpleTags)
+			if err != nil {
+				return result, err
 			}
-		case strings.HasPrefix(part, "c:"):
-			// As per DogStatD protocol v1.2:
-			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v12
-			containerID := strings.TrimPrefix(part, "c:")
-
-			if containerID != "" {
-				kvs = append(kvs, attribute.String(string(conventions.ContainerIDKey), containerID))
+			kvs = append(kvs, tags...)
+		case result.origin.parseField(part):
+			if strings.HasPrefix(part, "c:") {
+				result.containerField = strings.TrimPrefix(part, "c:")
 			}
+		case strings.HasPrefix(part, "card:"):
+			// As per DogStatsD protocol v1.5, the tag cardinality is a hint for the Datadog Agent
+			// tagger, which has no equivalent here.
 		case strings.HasPrefix(part, "T"):
 			// As per DogStatD protocol v1.3:
 			// https://docs.datadoghq.com/developers/dogstatsd/datagram_shell/?tab=metrics#dogstatsd-protocol-v13
@@ -591,17 +645,45 @@ func parseMessageToMetric(line string, enableMetricType, enableSimpleTags bool)
 type netAddr struct {
 	Network string
 	String  string
+	Origin  origin
+}
+
+// parseTags parses a comma separated DogStatsD tag set, handling an empty tag set
+// where the tags part was still sent (some clients do this).
+func parseTags(tagsStr string, enableSimpleTags bool) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+
+	var tagSet string
+	tagSet, tagsStr, _ = strings.Cut(tagsStr, ",")
+	for ; tagSet != "" || tagsStr != ""; tagSet, tagsStr, _ = strings.Cut(tagsStr, ",") {
+		if tagSet == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(tagSet, ":")
+		if k == "" {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		// support both simple tags (w/o value) and dimension tags (w/ value).
+		// dogstatsd notably allows simple tags.
+		if v == "" && !enableSimpleTags {
+			return nil, fmt.Errorf("invalid tag format: %q", tagSet)
+		}
+
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return kvs, nil
 }
 
 func newNetAddr(addr net.Addr) netAddr {
-	return netAddr{addr.Network(), addr.String()}
+	return netAddr{Network: addr.Network(), String: addr.String()}
 }
 
 func newIPOnlyNetAddr(addr net.Addr) netAddr {
 	host, _, err := net.SplitHostPort(addr.String())
 	if err != nil {
 		// if there is an error, use the original address
-		return netAddr{addr.Network(), addr.String()}
+		return netAddr{Network: addr.Network(), String: addr.String()}
 	}
-	return netAddr{addr.Network(), host}
+	return netAddr{Network: addr.Network(), String: host}
 }
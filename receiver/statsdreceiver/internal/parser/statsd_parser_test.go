@@ -133,16 +133,64 @@ func Test_ParseMessageToMetric(t *testing.T) {
 		{
 			name:  "counter metric with container ID",
 			input: "test.metric:42|c|#key:value|c:abc123",
-			wantMetric: testStatsDMetric(
+			wantMetric: withOrigin(testStatsDMetric(
 				"test.metric",
 				42,
 				false,
 				"c",
 				0,
-				[]string{"key", "container.id"},
-				[]string{"value", "abc123"},
+				[]string{"key"},
+				[]string{"value"},
 				0,
-			),
+			), origin{containerID: "abc123"}, "abc123"),
+		},
+		{
+			name:  "counter metric with prefixed container ID and external data",
+			input: "test.metric:42|c|#key:value|c:ci-abc123|e:it-false,cn-nginx,pu-75a2b6d5-3949-4afb-ad0d-92ff0674e759|card:orchestrator",
+			wantMetric: withOrigin(testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"c",
+				0,
+				[]string{"key"},
+				[]string{"value"},
+				0,
+			), origin{containerID: "abc123", containerName: "nginx", podUID: "75a2b6d5-3949-4afb-ad0d-92ff0674e759"}, "ci-abc123"),
+		},
+		{
+			name:  "counter metric with cgroup inode",
+			input: "test.metric:42|c|c:in-4026531835",
+			wantMetric: withOrigin(testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"c", 0, nil, nil, 0,
+			), origin{}, "in-4026531835"),
+		},
+		{
+			name:  "distribution with packed values",
+			input: "test.metric:42:0.5:7|d|#key:value",
+			wantMetric: withPackedValues(testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"d",
+				0,
+				[]string{"key"},
+				[]string{"value"},
+				0,
+			), 0.5, 7),
+		},
+		{
+			name:  "invalid packed value",
+			input: "test.metric:42:abc|h",
+			err:   errors.New("parse metric value string: abc"),
+		},
+		{
+			name:  "counter with packed values",
+			input: "test.metric:42:43|c",
+			err:   errors.New("only TIMING, HISTOGRAM and DISTRIBUTION metrics support packed values"),
 		},
 		{
 			name:  "counter metric with timestamp",
@@ -632,6 +680,17 @@ func Test_ParseMessageToMetricWithSimpleTags(t *testing.T) {
 	}
 }
 
+func withOrigin(m statsDMetric, o origin, containerField string) statsDMetric {
+	m.origin = o
+	m.containerField = containerField
+	return m
+}
+
+func withPackedValues(m statsDMetric, values ...float64) statsDMetric {
+	m.packedValues = values
+	return m
+}
+
 func testStatsDMetric(
 	name string, asFloat float64,
 	addition bool, metricType MetricType,
@@ -1747,6 +1806,129 @@ func TestStatsDParser_Mappings(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_DistributionMappings(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		mapping []protocol.TimerHistogramMapping
+		expect  map[string]string
+	}{
+		{
+			name: "distribution-follows-histogram",
+			mapping: []protocol.TimerHistogramMapping{
+				{StatsdType: "histogram", ObserverType: "summary"},
+			},
+			expect: map[string]string{
+				"H": "Summary",
+				"D": "Summary",
+			},
+		},
+		{
+			name: "distribution-own-mapping",
+			mapping: []protocol.TimerHistogramMapping{
+				{StatsdType: "histogram", ObserverType: "summary"},
+				{StatsdType: "distribution", ObserverType: "histogram"},
+			},
+			expect: map[string]string{
+				"H": "Summary",
+				"D": "ExponentialHistogram",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &StatsDParser{}
+
+			require.NoError(t, p.Initialize(false, false, false, false, false, tc.mapping, protocol.CounterTypeInt))
+
+			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+			require.NoError(t, p.Aggregate("H:10|h", addr))
+			require.NoError(t, p.Aggregate("D:10|d", addr))
+
+			typeNames := map[string]string{}
+			ilm := p.GetMetrics()[0].Metrics.ResourceMetrics().At(0).ScopeMetrics()
+			for i := 0; i < ilm.Len(); i++ {
+				m := ilm.At(i).Metrics().At(0)
+				typeNames[m.Name()] = m.Type().String()
+			}
+
+			assert.Equal(t, tc.expect, typeNames)
+		})
+	}
+}
+
+func TestStatsDParser_PackedValues(t *testing.T) {
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, false, false,
+		[]protocol.TimerHistogramMapping{{StatsdType: "distribution", ObserverType: "histogram"}},
+		protocol.CounterTypeInt,
+	))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("test.distribution:1:2:3|d|@0.5", addr))
+	require.NoError(t, p.Aggregate("test.distribution:4|d|@0.5", addr))
+
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 1)
+	dp := metrics[0].Metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(8), dp.Count())
+	assert.Equal(t, 20.0, dp.Sum())
+	assert.Equal(t, 1.0, dp.Min())
+	assert.Equal(t, 4.0, dp.Max())
+}
+
+func TestStatsDParser_Origin(t *testing.T) {
+	p := &StatsDParser{OriginResourceAttributes: true}
+	require.NoError(t, p.Initialize(false, false, false, false, false, nil, protocol.CounterTypeInt))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("test.metric:1|c", addr))
+	require.NoError(t, p.Aggregate("test.metric:2|c|c:ci-abc123|e:it-false,cn-nginx,pu-1234", addr))
+	require.NoError(t, p.Aggregate("test.metric:3|c|c:abc123|e:it-false,cn-nginx,pu-1234", addr))
+
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 2)
+	values := map[string]int64{}
+	for _, batch := range metrics {
+		rm := batch.Metrics.ResourceMetrics().At(0)
+		assert.Equal(t, addr, batch.Info.Addr)
+		containerID := ""
+		if v, ok := rm.Resource().Attributes().Get("container.id"); ok {
+			containerID = v.Str()
+			assert.Equal(t, map[string]any{
+				"container.id":       "abc123",
+				"k8s.container.name": "nginx",
+				"k8s.pod.uid":        "1234",
+			}, rm.Resource().Attributes().AsRaw())
+		}
+		values[containerID] = rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue()
+	}
+	assert.Equal(t, map[string]int64{"": 1, "abc123": 5}, values)
+}
+
+func TestStatsDParser_OriginAttributes(t *testing.T) {
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, false, false, false, nil, protocol.CounterTypeInt))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("test.metric:1|c", addr))
+	require.NoError(t, p.Aggregate("test.metric:2|c|c:abc123", addr))
+	require.NoError(t, p.Aggregate("test.metric:4|c|c:ci-abc123|e:it-false,cn-nginx,pu-1234", addr))
+
+	metrics := p.GetMetrics()
+	require.Len(t, metrics, 1)
+	rm := metrics[0].Metrics.ResourceMetrics().At(0)
+	assert.Equal(t, 0, rm.Resource().Attributes().Len())
+	// Without the feature gate, the container ID field is recorded on the data points as sent,
+	// and the external data is ignored.
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics().All() {
+		dp := sm.Metrics().At(0).Sum().DataPoints().At(0)
+		containerID := ""
+		if v, ok := dp.Attributes().Get("container.id"); ok {
+			containerID = v.Str()
+			assert.Equal(t, 1, dp.Attributes().Len())
+		}
+		values[containerID] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"": 1, "abc123": 2, "ci-abc123": 4}, values)
+}
+
 func TestStatsDParser_ScopeIsIncluded(t *testing.T) {
 	const devVersion = "dev-0.0.1"
 
@@ -2362,6 +2544,7 @@ func TestStatsDParser_DiscardInvalidValues(t *testing.T) {
 		{"test.gauge:+Inf|g", "discarding metric \"test.gauge\": invalid infinite value"},
 		{"test.timer:-Inf|ms", "discarding metric \"test.timer\": invalid infinite value"},
 		{"test.histogram:NaN|h", "discarding metric \"test.histogram\": invalid NaN value"},
+		{"test.distribution:1:NaN|d", "discarding metric \"test.distribution\": invalid NaN value"},
 	}
 
 	for _, tc := range invalidTestCases {
@@ -25,19 +25,24 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics and receiver.Logs for StatsD protocol.
+// DogStatsD events and service checks are emitted as logs.
 type statsdReceiver struct {
 	settings receiver.Settings
 	config   *Config
 
-	server       transport.Server
-	reporter     *reporter
-	obsrecv      *receiverhelper.ObsReport
-	parser       parser.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server           transport.Server
+	reporter         *reporter
+	obsrecv          *receiverhelper.ObsReport
+	parser           parser.Parser
+	nextConsumer     consumer.Metrics
+	nextLogsConsumer consumer.Logs
+	cancel           context.CancelFunc
 }
 
 // newReceiver creates the StatsD receiver with the given parameters.
@@ -78,7 +83,8 @@ func newReceiver(
 		obsrecv:      obsrecv,
 		reporter:     rep,
 		parser: &parser.StatsDParser{
-			BuildInfo: set.BuildInfo,
+			BuildInfo:                set.BuildInfo,
+			OriginResourceAttributes: metadata.ReceiverStatsdOriginResourceAttributesFeatureGate.IsEnabled(),
 		},
 	}
 	return r, nil
@@ -120,8 +126,15 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	// The transport requires a metrics consumer, which logs only pipelines do not have.
+	nextConsumer := r.nextConsumer
+	if nextConsumer == nil {
+		if nextConsumer, err = consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil }); err != nil {
+			return err
+		}
+	}
 	go func() {
-		if err := r.server.ListenAndServe(r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(nextConsumer, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
 			}
@@ -133,6 +146,13 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			select {
 			case <-ticker.C:
 				batchMetrics := r.parser.GetMetrics()
+				batchLogs := r.parser.GetLogs()
+				if r.nextConsumer == nil {
+					batchMetrics = nil
+				}
+				if r.nextLogsConsumer == nil {
+					batchLogs = nil
+				}
 				for _, batch := range batchMetrics {
 					batchCtx := client.NewContext(ctx, batch.Info)
 					numPoints := batch.Metrics.DataPointCount()
@@ -143,6 +163,16 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 					}
 					r.obsrecv.EndMetricsOp(flushCtx, metadata.Type.String(), numPoints, err)
 				}
+				for _, batch := range batchLogs {
+					batchCtx := client.NewContext(ctx, batch.Info)
+					numRecords := batch.Logs.LogRecordCount()
+					flushCtx := r.obsrecv.StartLogsOp(batchCtx)
+					err := r.nextLogsConsumer.ConsumeLogs(flushCtx, batch.Logs)
+					if err != nil {
+						r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+					}
+					r.obsrecv.EndLogsOp(flushCtx, metadata.Type.String(), numRecords, err)
+				}
 			case metric := <-transferChan:
 				err := r.parser.Aggregate(metric.Raw, metric.Addr)
 				if err != nil {
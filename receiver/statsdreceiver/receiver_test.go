@@ -5,6 +5,8 @@ package statsdreceiver
 
 import (
 	"errors"
+	"net"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
@@ -183,3 +186,121 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func Test_statsdreceiver_DogStatsDEndToEnd(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport confignet.TransportType
+		addr      string
+	}{
+		{
+			name:      "UDP",
+			transport: confignet.TransportTypeUDP,
+			addr:      testutil.GetAvailableLocalNetworkAddress(t, "udp"),
+		},
+		{
+			name:      "UDS",
+			transport: confignet.TransportTypeUnixgram,
+			addr:      filepath.Join(t.TempDir(), "dsd.sock"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tt.transport == confignet.TransportTypeUnixgram {
+				t.Skip("skipping UDS test on windows")
+			}
+			for _, gate := range []*featuregate.Gate{
+				metadata.ReceiverStatsdDistributionHistogramDefaultFeatureGate,
+				metadata.ReceiverStatsdOriginResourceAttributesFeatureGate,
+			} {
+				originalValue := gate.IsEnabled()
+				require.NoError(t, featuregate.GlobalRegistry().Set(gate.ID(), true))
+				t.Cleanup(func() {
+					require.NoError(t, featuregate.GlobalRegistry().Set(gate.ID(), originalValue))
+				})
+			}
+			cfg := createDefaultConfig().(*Config)
+			cfg.NetAddr = confignet.AddrConfig{
+				Endpoint:  tt.addr,
+				Transport: tt.transport,
+			}
+			cfg.AggregationInterval = time.Second
+			metricsSink := new(consumertest.MetricsSink)
+			logsSink := new(consumertest.LogsSink)
+			rcv, err := newReceiver(receivertest.NewNopSettings(metadata.Type), *cfg, metricsSink)
+			require.NoError(t, err)
+			r := rcv.(*statsdReceiver)
+			r.nextLogsConsumer = logsSink
+
+			require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+			defer func() {
+				assert.NoError(t, r.Shutdown(t.Context()))
+			}()
+
+			conn, err := net.Dial(string(tt.transport), tt.addr)
+			require.NoError(t, err)
+			defer conn.Close()
+			_, err = conn.Write([]byte("request.latency:1:2:3|d|#env:prod|c:ci-abc123|e:cn-app,pu-1234\n" +
+				"_e{6,4}:Deploy|done|t:success|#env:prod\n" +
+				"_sc|app.is_up|2|#env:prod|c:ci-abc123|m:down"))
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				return metricsSink.DataPointCount() == 1 && logsSink.LogRecordCount() == 2
+			}, 10*time.Second, 100*time.Millisecond)
+
+			rm := metricsSink.AllMetrics()[0].ResourceMetrics().At(0)
+			assert.Equal(t, map[string]any{
+				"container.id":       "abc123",
+				"k8s.container.name": "app",
+				"k8s.pod.uid":        "1234",
+			}, rm.Resource().Attributes().AsRaw())
+			metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "request.latency", metric.Name())
+			require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+			assert.Equal(t, uint64(3), metric.ExponentialHistogram().DataPoints().At(0).Count())
+
+			eventNames := map[string]string{}
+			for _, logs := range logsSink.AllLogs() {
+				for i := 0; i < logs.ResourceLogs().Len(); i++ {
+					rl := logs.ResourceLogs().At(i)
+					for j := 0; j < rl.ScopeLogs().At(0).LogRecords().Len(); j++ {
+						lr := rl.ScopeLogs().At(0).LogRecords().At(j)
+						eventNames[lr.EventName()] = ""
+						if containerID, ok := rl.Resource().Attributes().Get("container.id"); ok {
+							eventNames[lr.EventName()] = containerID.Str()
+						}
+					}
+				}
+			}
+			assert.Equal(t, map[string]string{
+				"dogstatsd.event":         "",
+				"dogstatsd.service_check": "abc123",
+			}, eventNames)
+		})
+	}
+}
+
+func Test_statsdreceiver_LogsOnly(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	cfg.AggregationInterval = time.Second
+	logsSink := new(consumertest.LogsSink)
+	rcv, err := NewFactory().CreateLogs(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, logsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcv.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, rcv.Shutdown(t.Context()))
+	}()
+
+	conn, err := net.Dial("udp", cfg.NetAddr.Endpoint)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("test.metric:42|c\n_sc|app.is_up|0"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 1
+	}, 10*time.Second, 100*time.Millisecond)
+}
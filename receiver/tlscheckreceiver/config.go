@@ -6,6 +6,7 @@ package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -52,6 +53,25 @@ type Config struct {
 	ControllerConfig     scraperhelper.ControllerConfig `mapstructure:",squash"`
 	MetricsBuilderConfig metadata.MetricsBuilderConfig  `mapstructure:",squash"`
 	Targets              []*CertificateTarget           `mapstructure:"targets"`
+	Verification         VerificationConfig             `mapstructure:"verification"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// VerificationConfig configures the verification of the certificate chains and of the revocation
+// status of the leaf certificates. Checks are only performed when the metrics reporting them are enabled.
+type VerificationConfig struct {
+	// CAFile is the PEM trust bundle chains are verified against. The system trust store is used when empty.
+	CAFile string `mapstructure:"ca_file"`
+	// OCSP enables checking the revocation status with the stapled OCSP response, or the OCSP responder of the certificate.
+	OCSP bool `mapstructure:"ocsp"`
+	// CRL enables checking the revocation status with the CRL distribution point of the certificate.
+	CRL bool `mapstructure:"crl"`
+	// Timeout is the timeout of the requests to OCSP responders and CRL distribution points.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// CacheTTL is how long OCSP responses and CRLs are cached, at most until their next update.
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
@@ -96,5 +116,12 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, validateTarget(target))
 	}
 
+	if (cfg.Verification.OCSP || cfg.Verification.CRL) && cfg.Verification.Timeout <= 0 {
+		err = multierr.Append(err, errors.New(`"verification::timeout" must be greater than 0 when revocation checks are enabled`))
+	}
+	if cfg.Verification.CacheTTL < 0 {
+		err = multierr.Append(err, errors.New(`"verification::cache_ttl" cannot be negative`))
+	}
+
 	return err
 }
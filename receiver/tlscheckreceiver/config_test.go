@@ -199,6 +199,32 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errors.New(`"password" cannot be set when "file_path" is empty (endpoint-based target)`),
 		},
+		{
+			desc: "revocation checks without timeout",
+			cfg: &Config{
+				Targets: []*CertificateTarget{
+					{
+						TCPAddrConfig: confignet.TCPAddrConfig{Endpoint: "example.com:443"},
+					},
+				},
+				Verification:     VerificationConfig{OCSP: true},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: errors.New(`"verification::timeout" must be greater than 0 when revocation checks are enabled`),
+		},
+		{
+			desc: "negative cache ttl",
+			cfg: &Config{
+				Targets: []*CertificateTarget{
+					{
+						TCPAddrConfig: confignet.TCPAddrConfig{Endpoint: "example.com:443"},
+					},
+				},
+				Verification:     VerificationConfig{CRL: true, Timeout: time.Second, CacheTTL: -time.Minute},
+				ControllerConfig: scraperhelper.NewDefaultControllerConfig(),
+			},
+			expectedErr: errors.New(`"verification::cache_ttl" cannot be negative`),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -6,6 +6,7 @@ package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -19,6 +20,11 @@ import (
 
 var errConfigNotTLSCheck = errors.New(`invalid config`)
 
+const (
+	defaultVerificationTimeout  = 10 * time.Second
+	defaultVerificationCacheTTL = time.Hour
+)
+
 func NewFactory() receiver.Factory {
 	return xreceiver.NewFactory(
 		metadata.Type,
@@ -35,6 +41,10 @@ func newDefaultConfig() component.Config {
 		ControllerConfig:     cfg,
 		MetricsBuilderConfig: metadata.NewDefaultMetricsBuilderConfig(),
 		Targets:              []*CertificateTarget{},
+		Verification: VerificationConfig{
+			Timeout:  defaultVerificationTimeout,
+			CacheTTL: defaultVerificationCacheTTL,
+		},
 	}
 }
 
@@ -50,7 +60,7 @@ func newReceiver(
 	}
 
 	mp := newScraper(tlsCheckConfig, settings, getConnectionState)
-	s, err := collectorscraper.NewMetrics(mp.scrape)
+	s, err := collectorscraper.NewMetrics(mp.scrape, collectorscraper.WithStart(mp.start))
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +40,10 @@ func TestNewFactory(t *testing.T) {
 					},
 					MetricsBuilderConfig: metadata.NewDefaultMetricsBuilderConfig(),
 					Targets:              []*CertificateTarget{},
+					Verification: VerificationConfig{
+						Timeout:  10 * time.Second,
+						CacheTTL: time.Hour,
+					},
 				}
 
 				require.Equal(t, expectedCfg, factory.CreateDefaultConfig())
@@ -9,6 +9,171 @@ import (
 	"go.opentelemetry.io/collector/filter"
 )
 
+// TlscheckChainValidMetricAttributeKey specifies the key of an attribute for the tlscheck.chain.valid metric.
+type TlscheckChainValidMetricAttributeKey string
+
+const (
+	TlscheckChainValidMetricAttributeKeyTlscheckChainFailureReason TlscheckChainValidMetricAttributeKey = "tlscheck.chain.failure_reason"
+)
+
+// TlscheckChainValidMetricConfig provides config for the tlscheck.chain.valid metric.
+type TlscheckChainValidMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+
+	AggregationStrategy string                                 `mapstructure:"aggregation_strategy"`
+	EnabledAttributes   []TlscheckChainValidMetricAttributeKey `mapstructure:"attributes"`
+}
+
+func (ms *TlscheckChainValidMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+func (ms *TlscheckChainValidMetricConfig) Validate() error {
+	for _, val := range ms.EnabledAttributes {
+		switch val {
+		case TlscheckChainValidMetricAttributeKeyTlscheckChainFailureReason:
+		default:
+			return fmt.Errorf("metric tlscheck.chain.valid doesn't have an attribute %v, valid attributes: [tlscheck.chain.failure_reason]", val)
+		}
+	}
+
+	switch ms.AggregationStrategy {
+	case AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax:
+	default:
+		return fmt.Errorf("invalid aggregation strategy %q, valid strategies: [%s, %s, %s, %s]", ms.AggregationStrategy, AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax)
+	}
+
+	return nil
+}
+
+// TlscheckKeySizeMetricAttributeKey specifies the key of an attribute for the tlscheck.key.size metric.
+type TlscheckKeySizeMetricAttributeKey string
+
+const (
+	TlscheckKeySizeMetricAttributeKeyTlscheckX509KeyType            TlscheckKeySizeMetricAttributeKey = "tlscheck.x509.key_type"
+	TlscheckKeySizeMetricAttributeKeyTlscheckX509SignatureAlgorithm TlscheckKeySizeMetricAttributeKey = "tlscheck.x509.signature_algorithm"
+)
+
+// TlscheckKeySizeMetricConfig provides config for the tlscheck.key.size metric.
+type TlscheckKeySizeMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+
+	AggregationStrategy string                              `mapstructure:"aggregation_strategy"`
+	EnabledAttributes   []TlscheckKeySizeMetricAttributeKey `mapstructure:"attributes"`
+}
+
+func (ms *TlscheckKeySizeMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+func (ms *TlscheckKeySizeMetricConfig) Validate() error {
+	for _, val := range ms.EnabledAttributes {
+		switch val {
+		case TlscheckKeySizeMetricAttributeKeyTlscheckX509KeyType, TlscheckKeySizeMetricAttributeKeyTlscheckX509SignatureAlgorithm:
+		default:
+			return fmt.Errorf("metric tlscheck.key.size doesn't have an attribute %v, valid attributes: [tlscheck.x509.key_type, tlscheck.x509.signature_algorithm]", val)
+		}
+	}
+
+	switch ms.AggregationStrategy {
+	case AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax:
+	default:
+		return fmt.Errorf("invalid aggregation strategy %q, valid strategies: [%s, %s, %s, %s]", ms.AggregationStrategy, AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax)
+	}
+
+	return nil
+}
+
+// TlscheckOcspStapledMetricConfig provides config for the tlscheck.ocsp.stapled metric.
+type TlscheckOcspStapledMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+}
+
+func (ms *TlscheckOcspStapledMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// TlscheckRevokedMetricAttributeKey specifies the key of an attribute for the tlscheck.revoked metric.
+type TlscheckRevokedMetricAttributeKey string
+
+const (
+	TlscheckRevokedMetricAttributeKeyTlscheckRevocationMethod TlscheckRevokedMetricAttributeKey = "tlscheck.revocation.method"
+)
+
+// TlscheckRevokedMetricConfig provides config for the tlscheck.revoked metric.
+type TlscheckRevokedMetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+
+	AggregationStrategy string                              `mapstructure:"aggregation_strategy"`
+	EnabledAttributes   []TlscheckRevokedMetricAttributeKey `mapstructure:"attributes"`
+}
+
+func (ms *TlscheckRevokedMetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+func (ms *TlscheckRevokedMetricConfig) Validate() error {
+	for _, val := range ms.EnabledAttributes {
+		switch val {
+		case TlscheckRevokedMetricAttributeKeyTlscheckRevocationMethod:
+		default:
+			return fmt.Errorf("metric tlscheck.revoked doesn't have an attribute %v, valid attributes: [tlscheck.revocation.method]", val)
+		}
+	}
+
+	switch ms.AggregationStrategy {
+	case AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax:
+	default:
+		return fmt.Errorf("invalid aggregation strategy %q, valid strategies: [%s, %s, %s, %s]", ms.AggregationStrategy, AggregationStrategySum, AggregationStrategyAvg, AggregationStrategyMin, AggregationStrategyMax)
+	}
+
+	return nil
+}
+
 // TlscheckTimeLeftMetricAttributeKey specifies the key of an attribute for the tlscheck.time_left metric.
 type TlscheckTimeLeftMetricAttributeKey string
 
@@ -61,11 +226,33 @@ func (ms *TlscheckTimeLeftMetricConfig) Validate() error {
 
 // MetricsConfig provides config for tls_check metrics.
 type MetricsConfig struct {
-	TlscheckTimeLeft TlscheckTimeLeftMetricConfig `mapstructure:"tlscheck.time_left"`
+	TlscheckChainValid  TlscheckChainValidMetricConfig  `mapstructure:"tlscheck.chain.valid"`
+	TlscheckKeySize     TlscheckKeySizeMetricConfig     `mapstructure:"tlscheck.key.size"`
+	TlscheckOcspStapled TlscheckOcspStapledMetricConfig `mapstructure:"tlscheck.ocsp.stapled"`
+	TlscheckRevoked     TlscheckRevokedMetricConfig     `mapstructure:"tlscheck.revoked"`
+	TlscheckTimeLeft    TlscheckTimeLeftMetricConfig    `mapstructure:"tlscheck.time_left"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
 	return MetricsConfig{
+		TlscheckChainValid: TlscheckChainValidMetricConfig{
+			Enabled:             false,
+			AggregationStrategy: AggregationStrategyAvg,
+			EnabledAttributes:   []TlscheckChainValidMetricAttributeKey{TlscheckChainValidMetricAttributeKeyTlscheckChainFailureReason},
+		},
+		TlscheckKeySize: TlscheckKeySizeMetricConfig{
+			Enabled:             false,
+			AggregationStrategy: AggregationStrategyAvg,
+			EnabledAttributes:   []TlscheckKeySizeMetricAttributeKey{TlscheckKeySizeMetricAttributeKeyTlscheckX509KeyType, TlscheckKeySizeMetricAttributeKeyTlscheckX509SignatureAlgorithm},
+		},
+		TlscheckOcspStapled: TlscheckOcspStapledMetricConfig{
+			Enabled: false,
+		},
+		TlscheckRevoked: TlscheckRevokedMetricConfig{
+			Enabled:             false,
+			AggregationStrategy: AggregationStrategyAvg,
+			EnabledAttributes:   []TlscheckRevokedMetricAttributeKey{TlscheckRevokedMetricAttributeKeyTlscheckRevocationMethod},
+		},
 		TlscheckTimeLeft: TlscheckTimeLeftMetricConfig{
 			Enabled:             true,
 			AggregationStrategy: AggregationStrategyAvg,
@@ -26,6 +26,24 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "all_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
+					TlscheckChainValid: TlscheckChainValidMetricConfig{
+						Enabled:             true,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []TlscheckChainValidMetricAttributeKey{TlscheckChainValidMetricAttributeKeyTlscheckChainFailureReason},
+					},
+					TlscheckKeySize: TlscheckKeySizeMetricConfig{
+						Enabled:             true,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []TlscheckKeySizeMetricAttributeKey{TlscheckKeySizeMetricAttributeKeyTlscheckX509KeyType, TlscheckKeySizeMetricAttributeKeyTlscheckX509SignatureAlgorithm},
+					},
+					TlscheckOcspStapled: TlscheckOcspStapledMetricConfig{
+						Enabled: true,
+					},
+					TlscheckRevoked: TlscheckRevokedMetricConfig{
+						Enabled:             true,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []TlscheckRevokedMetricAttributeKey{TlscheckRevokedMetricAttributeKeyTlscheckRevocationMethod},
+					},
 					TlscheckTimeLeft: TlscheckTimeLeftMetricConfig{
 						Enabled:             true,
 						AggregationStrategy: AggregationStrategyAvg,
@@ -41,6 +59,24 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "none_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
+					TlscheckChainValid: TlscheckChainValidMetricConfig{
+						Enabled:             false,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []TlscheckChainValidMetricAttributeKey{TlscheckChainValidMetricAttributeKeyTlscheckChainFailureReason},
+					},
+					TlscheckKeySize: TlscheckKeySizeMetricConfig{
+						Enabled:             false,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []TlscheckKeySizeMetricAttributeKey{TlscheckKeySizeMetricAttributeKeyTlscheckX509KeyType, TlscheckKeySizeMetricAttributeKeyTlscheckX509SignatureAlgorithm},
+					},
+					TlscheckOcspStapled: TlscheckOcspStapledMetricConfig{
+						Enabled: false,
+					},
+					TlscheckRevoked: TlscheckRevokedMetricConfig{
+						Enabled:             false,
+						AggregationStrategy: AggregationStrategyAvg,
+						EnabledAttributes:   []TlscheckRevokedMetricAttributeKey{TlscheckRevokedMetricAttributeKeyTlscheckRevocationMethod},
+					},
 					TlscheckTimeLeft: TlscheckTimeLeftMetricConfig{
 						Enabled:             false,
 						AggregationStrategy: AggregationStrategyAvg,
@@ -56,11 +92,47 @@ func TestMetricsBuilderConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := loadMetricsBuilderConfig(t, tt.name)
-			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(TlscheckTimeLeftMetricConfig{}, ResourceAttributeConfig{}))
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(TlscheckChainValidMetricConfig{}, TlscheckKeySizeMetricConfig{}, TlscheckOcspStapledMetricConfig{}, TlscheckRevokedMetricConfig{}, TlscheckTimeLeftMetricConfig{}, ResourceAttributeConfig{}))
 			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
 		})
 	}
 }
+func TestTlscheckChainValidMetricsConfig_Validate(t *testing.T) {
+	cfg := DefaultMetricsConfig().TlscheckChainValid
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnabledAttributes = []TlscheckChainValidMetricAttributeKey{"invalid"}
+	require.ErrorContains(t, cfg.Validate(), "metric tlscheck.chain.valid doesn't have an attribute invalid, valid attributes: [tlscheck.chain.failure_reason]")
+
+	cfg = DefaultMetricsConfig().TlscheckChainValid
+	cfg.AggregationStrategy = "invalid"
+	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
+}
+
+func TestTlscheckKeySizeMetricsConfig_Validate(t *testing.T) {
+	cfg := DefaultMetricsConfig().TlscheckKeySize
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnabledAttributes = []TlscheckKeySizeMetricAttributeKey{"invalid"}
+	require.ErrorContains(t, cfg.Validate(), "metric tlscheck.key.size doesn't have an attribute invalid, valid attributes: [tlscheck.x509.key_type, tlscheck.x509.signature_algorithm]")
+
+	cfg = DefaultMetricsConfig().TlscheckKeySize
+	cfg.AggregationStrategy = "invalid"
+	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
+}
+
+func TestTlscheckRevokedMetricsConfig_Validate(t *testing.T) {
+	cfg := DefaultMetricsConfig().TlscheckRevoked
+	require.NoError(t, cfg.Validate())
+
+	cfg.EnabledAttributes = []TlscheckRevokedMetricAttributeKey{"invalid"}
+	require.ErrorContains(t, cfg.Validate(), "metric tlscheck.revoked doesn't have an attribute invalid, valid attributes: [tlscheck.revocation.method]")
+
+	cfg = DefaultMetricsConfig().TlscheckRevoked
+	cfg.AggregationStrategy = "invalid"
+	require.ErrorContains(t, cfg.Validate(), "invalid aggregation strategy")
+}
+
 func TestTlscheckTimeLeftMetricsConfig_Validate(t *testing.T) {
 	cfg := DefaultMetricsConfig().TlscheckTimeLeft
 	require.NoError(t, cfg.Validate())
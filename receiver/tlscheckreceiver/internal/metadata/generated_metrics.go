@@ -3,14 +3,13 @@
 package metadata
 
 import (
-	"slices"
-	"time"
-
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/filter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"slices"
+	"time"
 )
 
 const (
@@ -20,7 +19,128 @@ const (
 	AggregationStrategyMax = "max"
 )
 
+// AttributeTlscheckChainFailureReason specifies the value tlscheck.chain.failure_reason attribute.
+type AttributeTlscheckChainFailureReason int
+
+const (
+	_ AttributeTlscheckChainFailureReason = iota
+	AttributeTlscheckChainFailureReasonNone
+	AttributeTlscheckChainFailureReasonUnknownAuthority
+	AttributeTlscheckChainFailureReasonExpired
+	AttributeTlscheckChainFailureReasonNotYetValid
+	AttributeTlscheckChainFailureReasonHostnameMismatch
+	AttributeTlscheckChainFailureReasonIncompatibleUsage
+	AttributeTlscheckChainFailureReasonInvalid
+)
+
+// String returns the string representation of the AttributeTlscheckChainFailureReason.
+func (av AttributeTlscheckChainFailureReason) String() string {
+	switch av {
+	case AttributeTlscheckChainFailureReasonNone:
+		return "none"
+	case AttributeTlscheckChainFailureReasonUnknownAuthority:
+		return "unknown_authority"
+	case AttributeTlscheckChainFailureReasonExpired:
+		return "expired"
+	case AttributeTlscheckChainFailureReasonNotYetValid:
+		return "not_yet_valid"
+	case AttributeTlscheckChainFailureReasonHostnameMismatch:
+		return "hostname_mismatch"
+	case AttributeTlscheckChainFailureReasonIncompatibleUsage:
+		return "incompatible_usage"
+	case AttributeTlscheckChainFailureReasonInvalid:
+		return "invalid"
+	}
+	return ""
+}
+
+// MapAttributeTlscheckChainFailureReason is a helper map of string to AttributeTlscheckChainFailureReason attribute value.
+var MapAttributeTlscheckChainFailureReason = map[string]AttributeTlscheckChainFailureReason{
+	"none":               AttributeTlscheckChainFailureReasonNone,
+	"unknown_authority":  AttributeTlscheckChainFailureReasonUnknownAuthority,
+	"expired":            AttributeTlscheckChainFailureReasonExpired,
+	"not_yet_valid":      AttributeTlscheckChainFailureReasonNotYetValid,
+	"hostname_mismatch":  AttributeTlscheckChainFailureReasonHostnameMismatch,
+	"incompatible_usage": AttributeTlscheckChainFailureReasonIncompatibleUsage,
+	"invalid":            AttributeTlscheckChainFailureReasonInvalid,
+}
+
+// AttributeTlscheckRevocationMethod specifies the value tlscheck.revocation.method attribute.
+type AttributeTlscheckRevocationMethod int
+
+const (
+	_ AttributeTlscheckRevocationMethod = iota
+	AttributeTlscheckRevocationMethodOcsp
+	AttributeTlscheckRevocationMethodCrl
+)
+
+// String returns the string representation of the AttributeTlscheckRevocationMethod.
+func (av AttributeTlscheckRevocationMethod) String() string {
+	switch av {
+	case AttributeTlscheckRevocationMethodOcsp:
+		return "ocsp"
+	case AttributeTlscheckRevocationMethodCrl:
+		return "crl"
+	}
+	return ""
+}
+
+// MapAttributeTlscheckRevocationMethod is a helper map of string to AttributeTlscheckRevocationMethod attribute value.
+var MapAttributeTlscheckRevocationMethod = map[string]AttributeTlscheckRevocationMethod{
+	"ocsp": AttributeTlscheckRevocationMethodOcsp,
+	"crl":  AttributeTlscheckRevocationMethodCrl,
+}
+
+// AttributeTlscheckX509KeyType specifies the value tlscheck.x509.key_type attribute.
+type AttributeTlscheckX509KeyType int
+
+const (
+	_ AttributeTlscheckX509KeyType = iota
+	AttributeTlscheckX509KeyTypeRsa
+	AttributeTlscheckX509KeyTypeEcdsa
+	AttributeTlscheckX509KeyTypeEd25519
+	AttributeTlscheckX509KeyTypeUnknown
+)
+
+// String returns the string representation of the AttributeTlscheckX509KeyType.
+func (av AttributeTlscheckX509KeyType) String() string {
+	switch av {
+	case AttributeTlscheckX509KeyTypeRsa:
+		return "rsa"
+	case AttributeTlscheckX509KeyTypeEcdsa:
+		return "ecdsa"
+	case AttributeTlscheckX509KeyTypeEd25519:
+		return "ed25519"
+	case AttributeTlscheckX509KeyTypeUnknown:
+		return "unknown"
+	}
+	return ""
+}
+
+// MapAttributeTlscheckX509KeyType is a helper map of string to AttributeTlscheckX509KeyType attribute value.
+var MapAttributeTlscheckX509KeyType = map[string]AttributeTlscheckX509KeyType{
+	"rsa":     AttributeTlscheckX509KeyTypeRsa,
+	"ecdsa":   AttributeTlscheckX509KeyTypeEcdsa,
+	"ed25519": AttributeTlscheckX509KeyTypeEd25519,
+	"unknown": AttributeTlscheckX509KeyTypeUnknown,
+}
+
 var MetricsInfo = metricsInfo{
+	TlscheckChainValid: metricInfo{
+		Name:       "tlscheck.chain.valid",
+		Attributes: []string{"tlscheck.chain.failure_reason"},
+	},
+	TlscheckKeySize: metricInfo{
+		Name:       "tlscheck.key.size",
+		Attributes: []string{"tlscheck.x509.key_type", "tlscheck.x509.signature_algorithm"},
+	},
+	TlscheckOcspStapled: metricInfo{
+		Name: "tlscheck.ocsp.stapled",
+	},
+	TlscheckRevoked: metricInfo{
+		Name:       "tlscheck.revoked",
+		Attributes: []string{"tlscheck.revocation.method"},
+	},
 	TlscheckTimeLeft: metricInfo{
 		Name:       "tlscheck.time_left",
 		Attributes: []string{"tlscheck.x509.issuer", "tlscheck.x509.cn", "tlscheck.x509.san"},
@@ -28,7 +148,11 @@ var MetricsInfo = metricsInfo{
 }
 
 type metricsInfo struct {
-	TlscheckTimeLeft metricInfo
+	TlscheckChainValid  metricInfo
+	TlscheckKeySize     metricInfo
+	TlscheckOcspStapled metricInfo
+	TlscheckRevoked     metricInfo
+	TlscheckTimeLeft    metricInfo
 }
 
 type metricInfo struct {
@@ -36,6 +160,326 @@ type metricInfo struct {
 	Attributes []string
 }
 
+type metricTlscheckChainValid struct {
+	data          pmetric.Metric                 // data buffer for generated metric.
+	config        TlscheckChainValidMetricConfig // metric config provided by user.
+	capacity      int                            // max observed number of data points added to the metric.
+	aggDataPoints []int64                        // slice containing number of aggregated datapoints at each index
+}
+
+// init fills tlscheck.chain.valid metric with initial data.
+func (m *metricTlscheckChainValid) init() {
+	m.data.SetName("tlscheck.chain.valid")
+	m.data.SetDescription("1 if the certificate chain validates against the trust bundle, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+	m.aggDataPoints = m.aggDataPoints[:0]
+}
+
+func (m *metricTlscheckChainValid) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlscheckChainFailureReasonAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	if slices.Contains(m.config.EnabledAttributes, TlscheckChainValidMetricAttributeKeyTlscheckChainFailureReason) {
+		dp.Attributes().PutStr("tlscheck.chain.failure_reason", tlscheckChainFailureReasonAttributeValue)
+	}
+
+	var s string
+	dps := m.data.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dpi := dps.At(i)
+		if dp.Attributes().Equal(dpi.Attributes()) && dp.StartTimestamp() == dpi.StartTimestamp() && dp.Timestamp() == dpi.Timestamp() {
+			switch s = m.config.AggregationStrategy; s {
+			case AggregationStrategySum, AggregationStrategyAvg:
+				dpi.SetIntValue(dpi.IntValue() + val)
+				m.aggDataPoints[i] += 1
+				return
+			case AggregationStrategyMin:
+				if dpi.IntValue() > val {
+					dpi.SetIntValue(val)
+				}
+				return
+			case AggregationStrategyMax:
+				if dpi.IntValue() < val {
+					dpi.SetIntValue(val)
+				}
+				return
+			}
+		}
+	}
+
+	dp.SetIntValue(val)
+	m.aggDataPoints = append(m.aggDataPoints, 1)
+	dp.MoveTo(dps.AppendEmpty())
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckChainValid) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckChainValid) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		if m.config.AggregationStrategy == AggregationStrategyAvg {
+			for i, aggCount := range m.aggDataPoints {
+				m.data.Gauge().DataPoints().At(i).SetIntValue(m.data.Gauge().DataPoints().At(i).IntValue() / aggCount)
+			}
+		}
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckChainValid(cfg TlscheckChainValidMetricConfig) metricTlscheckChainValid {
+	m := metricTlscheckChainValid{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckKeySize struct {
+	data          pmetric.Metric              // data buffer for generated metric.
+	config        TlscheckKeySizeMetricConfig // metric config provided by user.
+	capacity      int                         // max observed number of data points added to the metric.
+	aggDataPoints []int64                     // slice containing number of aggregated datapoints at each index
+}
+
+// init fills tlscheck.key.size metric with initial data.
+func (m *metricTlscheckKeySize) init() {
+	m.data.SetName("tlscheck.key.size")
+	m.data.SetDescription("Size in bits of the public key of the certificate.")
+	m.data.SetUnit("bit")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+	m.aggDataPoints = m.aggDataPoints[:0]
+}
+
+func (m *metricTlscheckKeySize) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlscheckX509KeyTypeAttributeValue string, tlscheckX509SignatureAlgorithmAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	if slices.Contains(m.config.EnabledAttributes, TlscheckKeySizeMetricAttributeKeyTlscheckX509KeyType) {
+		dp.Attributes().PutStr("tlscheck.x509.key_type", tlscheckX509KeyTypeAttributeValue)
+	}
+	if slices.Contains(m.config.EnabledAttributes, TlscheckKeySizeMetricAttributeKeyTlscheckX509SignatureAlgorithm) {
+		dp.Attributes().PutStr("tlscheck.x509.signature_algorithm", tlscheckX509SignatureAlgorithmAttributeValue)
+	}
+
+	var s string
+	dps := m.data.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dpi := dps.At(i)
+		if dp.Attributes().Equal(dpi.Attributes()) && dp.StartTimestamp() == dpi.StartTimestamp() && dp.Timestamp() == dpi.Timestamp() {
+			switch s = m.config.AggregationStrategy; s {
+			case AggregationStrategySum, AggregationStrategyAvg:
+				dpi.SetIntValue(dpi.IntValue() + val)
+				m.aggDataPoints[i] += 1
+				return
+			case AggregationStrategyMin:
+				if dpi.IntValue() > val {
+					dpi.SetIntValue(val)
+				}
+				return
+			case AggregationStrategyMax:
+				if dpi.IntValue() < val {
+					dpi.SetIntValue(val)
+				}
+				return
+			}
+		}
+	}
+
+	dp.SetIntValue(val)
+	m.aggDataPoints = append(m.aggDataPoints, 1)
+	dp.MoveTo(dps.AppendEmpty())
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckKeySize) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckKeySize) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		if m.config.AggregationStrategy == AggregationStrategyAvg {
+			for i, aggCount := range m.aggDataPoints {
+				m.data.Gauge().DataPoints().At(i).SetIntValue(m.data.Gauge().DataPoints().At(i).IntValue() / aggCount)
+			}
+		}
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckKeySize(cfg TlscheckKeySizeMetricConfig) metricTlscheckKeySize {
+	m := metricTlscheckKeySize{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckOcspStapled struct {
+	data     pmetric.Metric                  // data buffer for generated metric.
+	config   TlscheckOcspStapledMetricConfig // metric config provided by user.
+	capacity int                             // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.ocsp.stapled metric with initial data.
+func (m *metricTlscheckOcspStapled) init() {
+	m.data.SetName("tlscheck.ocsp.stapled")
+	m.data.SetDescription("1 if the endpoint staples an OCSP response to the TLS handshake, otherwise 0. Only reported for endpoints.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricTlscheckOcspStapled) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckOcspStapled) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckOcspStapled) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckOcspStapled(cfg TlscheckOcspStapledMetricConfig) metricTlscheckOcspStapled {
+	m := metricTlscheckOcspStapled{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckRevoked struct {
+	data          pmetric.Metric              // data buffer for generated metric.
+	config        TlscheckRevokedMetricConfig // metric config provided by user.
+	capacity      int                         // max observed number of data points added to the metric.
+	aggDataPoints []int64                     // slice containing number of aggregated datapoints at each index
+}
+
+// init fills tlscheck.revoked metric with initial data.
+func (m *metricTlscheckRevoked) init() {
+	m.data.SetName("tlscheck.revoked")
+	m.data.SetDescription("1 if the certificate is revoked, otherwise 0. Not reported when the revocation status cannot be determined.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+	m.aggDataPoints = m.aggDataPoints[:0]
+}
+
+func (m *metricTlscheckRevoked) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlscheckRevocationMethodAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	if slices.Contains(m.config.EnabledAttributes, TlscheckRevokedMetricAttributeKeyTlscheckRevocationMethod) {
+		dp.Attributes().PutStr("tlscheck.revocation.method", tlscheckRevocationMethodAttributeValue)
+	}
+
+	var s string
+	dps := m.data.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dpi := dps.At(i)
+		if dp.Attributes().Equal(dpi.Attributes()) && dp.StartTimestamp() == dpi.StartTimestamp() && dp.Timestamp() == dpi.Timestamp() {
+			switch s = m.config.AggregationStrategy; s {
+			case AggregationStrategySum, AggregationStrategyAvg:
+				dpi.SetIntValue(dpi.IntValue() + val)
+				m.aggDataPoints[i] += 1
+				return
+			case AggregationStrategyMin:
+				if dpi.IntValue() > val {
+					dpi.SetIntValue(val)
+				}
+				return
+			case AggregationStrategyMax:
+				if dpi.IntValue() < val {
+					dpi.SetIntValue(val)
+				}
+				return
+			}
+		}
+	}
+
+	dp.SetIntValue(val)
+	m.aggDataPoints = append(m.aggDataPoints, 1)
+	dp.MoveTo(dps.AppendEmpty())
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckRevoked) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckRevoked) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		if m.config.AggregationStrategy == AggregationStrategyAvg {
+			for i, aggCount := range m.aggDataPoints {
+				m.data.Gauge().DataPoints().At(i).SetIntValue(m.data.Gauge().DataPoints().At(i).IntValue() / aggCount)
+			}
+		}
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckRevoked(cfg TlscheckRevokedMetricConfig) metricTlscheckRevoked {
+	m := metricTlscheckRevoked{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricTlscheckTimeLeft struct {
 	data          pmetric.Metric               // data buffer for generated metric.
 	config        TlscheckTimeLeftMetricConfig // metric config provided by user.
@@ -141,6 +585,10 @@ type MetricsBuilder struct {
 	buildInfo                      component.BuildInfo  // contains version information.
 	resourceAttributeIncludeFilter map[string]filter.Filter
 	resourceAttributeExcludeFilter map[string]filter.Filter
+	metricTlscheckChainValid       metricTlscheckChainValid
+	metricTlscheckKeySize          metricTlscheckKeySize
+	metricTlscheckOcspStapled      metricTlscheckOcspStapled
+	metricTlscheckRevoked          metricTlscheckRevoked
 	metricTlscheckTimeLeft         metricTlscheckTimeLeft
 }
 
@@ -167,6 +615,10 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.Settings, opt
 		startTime:                      pcommon.NewTimestampFromTime(time.Now()),
 		metricsBuffer:                  pmetric.NewMetrics(),
 		buildInfo:                      settings.BuildInfo,
+		metricTlscheckChainValid:       newMetricTlscheckChainValid(mbc.Metrics.TlscheckChainValid),
+		metricTlscheckKeySize:          newMetricTlscheckKeySize(mbc.Metrics.TlscheckKeySize),
+		metricTlscheckOcspStapled:      newMetricTlscheckOcspStapled(mbc.Metrics.TlscheckOcspStapled),
+		metricTlscheckRevoked:          newMetricTlscheckRevoked(mbc.Metrics.TlscheckRevoked),
 		metricTlscheckTimeLeft:         newMetricTlscheckTimeLeft(mbc.Metrics.TlscheckTimeLeft),
 		resourceAttributeIncludeFilter: make(map[string]filter.Filter),
 		resourceAttributeExcludeFilter: make(map[string]filter.Filter),
@@ -246,6 +698,10 @@ func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
 	ils.Scope().SetName(ScopeName)
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricTlscheckChainValid.emit(ils.Metrics())
+	mb.metricTlscheckKeySize.emit(ils.Metrics())
+	mb.metricTlscheckOcspStapled.emit(ils.Metrics())
+	mb.metricTlscheckRevoked.emit(ils.Metrics())
 	mb.metricTlscheckTimeLeft.emit(ils.Metrics())
 
 	for _, op := range options {
@@ -278,6 +734,26 @@ func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics
 	return metrics
 }
 
+// RecordTlscheckChainValidDataPoint adds a data point to tlscheck.chain.valid metric.
+func (mb *MetricsBuilder) RecordTlscheckChainValidDataPoint(ts pcommon.Timestamp, val int64, tlscheckChainFailureReasonAttributeValue AttributeTlscheckChainFailureReason) {
+	mb.metricTlscheckChainValid.recordDataPoint(mb.startTime, ts, val, tlscheckChainFailureReasonAttributeValue.String())
+}
+
+// RecordTlscheckKeySizeDataPoint adds a data point to tlscheck.key.size metric.
+func (mb *MetricsBuilder) RecordTlscheckKeySizeDataPoint(ts pcommon.Timestamp, val int64, tlscheckX509KeyTypeAttributeValue AttributeTlscheckX509KeyType, tlscheckX509SignatureAlgorithmAttributeValue string) {
+	mb.metricTlscheckKeySize.recordDataPoint(mb.startTime, ts, val, tlscheckX509KeyTypeAttributeValue.String(), tlscheckX509SignatureAlgorithmAttributeValue)
+}
+
+// RecordTlscheckOcspStapledDataPoint adds a data point to tlscheck.ocsp.stapled metric.
+func (mb *MetricsBuilder) RecordTlscheckOcspStapledDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricTlscheckOcspStapled.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordTlscheckRevokedDataPoint adds a data point to tlscheck.revoked metric.
+func (mb *MetricsBuilder) RecordTlscheckRevokedDataPoint(ts pcommon.Timestamp, val int64, tlscheckRevocationMethodAttributeValue AttributeTlscheckRevocationMethod) {
+	mb.metricTlscheckRevoked.recordDataPoint(mb.startTime, ts, val, tlscheckRevocationMethodAttributeValue.String())
+}
+
 // RecordTlscheckTimeLeftDataPoint adds a data point to tlscheck.time_left metric.
 func (mb *MetricsBuilder) RecordTlscheckTimeLeftDataPoint(ts pcommon.Timestamp, val int64, tlscheckX509IssuerAttributeValue string, tlscheckX509CnAttributeValue string, tlscheckX509SanAttributeValue []any) {
 	mb.metricTlscheckTimeLeft.recordDataPoint(mb.startTime, ts, val, tlscheckX509IssuerAttributeValue, tlscheckX509CnAttributeValue, tlscheckX509SanAttributeValue)
@@ -67,6 +67,9 @@ func TestMetricsBuilder(t *testing.T) {
 			settings.Logger = zap.New(observedZapCore)
 			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, tt.name), settings, WithStartTime(start))
 			aggMap := make(map[string]string) // contains the aggregation strategies for each metric name
+			aggMap["tlscheck.chain.valid"] = mb.metricTlscheckChainValid.config.AggregationStrategy
+			aggMap["tlscheck.key.size"] = mb.metricTlscheckKeySize.config.AggregationStrategy
+			aggMap["tlscheck.revoked"] = mb.metricTlscheckRevoked.config.AggregationStrategy
 			aggMap["tlscheck.time_left"] = mb.metricTlscheckTimeLeft.config.AggregationStrategy
 
 			expectedWarnings := 0
@@ -76,6 +79,27 @@ func TestMetricsBuilder(t *testing.T) {
 
 			defaultMetricsCount := 0
 			allMetricsCount := 0
+
+			allMetricsCount++
+			mb.RecordTlscheckChainValidDataPoint(ts, 1, AttributeTlscheckChainFailureReasonNone)
+			if tt.name == "reaggregate_set" {
+				mb.RecordTlscheckChainValidDataPoint(ts, 3, AttributeTlscheckChainFailureReasonUnknownAuthority)
+			}
+
+			allMetricsCount++
+			mb.RecordTlscheckKeySizeDataPoint(ts, 1, AttributeTlscheckX509KeyTypeRsa, "tlscheck.x509.signature_algorithm-val")
+			if tt.name == "reaggregate_set" {
+				mb.RecordTlscheckKeySizeDataPoint(ts, 3, AttributeTlscheckX509KeyTypeEcdsa, "tlscheck.x509.signature_algorithm-val-2")
+			}
+
+			allMetricsCount++
+			mb.RecordTlscheckOcspStapledDataPoint(ts, 1)
+
+			allMetricsCount++
+			mb.RecordTlscheckRevokedDataPoint(ts, 1, AttributeTlscheckRevocationMethodOcsp)
+			if tt.name == "reaggregate_set" {
+				mb.RecordTlscheckRevokedDataPoint(ts, 3, AttributeTlscheckRevocationMethodCrl)
+			}
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordTlscheckTimeLeftDataPoint(ts, 1, "tlscheck.x509.issuer-val", "tlscheck.x509.cn-val", []any{"tlscheck.x509.san-item1", "tlscheck.x509.san-item2"})
@@ -88,6 +112,9 @@ func TestMetricsBuilder(t *testing.T) {
 			res := rb.Emit()
 			metrics := mb.Emit(WithResource(res))
 			if tt.name == "reaggregate_set" {
+				assert.Empty(t, mb.metricTlscheckChainValid.aggDataPoints)
+				assert.Empty(t, mb.metricTlscheckKeySize.aggDataPoints)
+				assert.Empty(t, mb.metricTlscheckRevoked.aggDataPoints)
 				assert.Empty(t, mb.metricTlscheckTimeLeft.aggDataPoints)
 			}
 
@@ -116,6 +143,143 @@ func TestMetricsBuilder(t *testing.T) {
 			validatedMetrics := make(map[string]bool)
 			for _, mi := range allMetricsList {
 				switch mi.Name() {
+				case "tlscheck.chain.valid":
+					if tt.name != "reaggregate_set" {
+						assert.False(t, validatedMetrics["tlscheck.chain.valid"], "Found a duplicate in the metrics slice: tlscheck.chain.valid")
+						validatedMetrics["tlscheck.chain.valid"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "1 if the certificate chain validates against the trust bundle, otherwise 0.", mi.Description())
+						assert.Equal(t, "1", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						assert.Equal(t, int64(1), dp.IntValue())
+						tlscheckChainFailureReasonAttrVal, ok := dp.Attributes().Get("tlscheck.chain.failure_reason")
+						assert.True(t, ok)
+						assert.Equal(t, "none", tlscheckChainFailureReasonAttrVal.Str())
+					} else {
+						assert.False(t, validatedMetrics["tlscheck.chain.valid"], "Found a duplicate in the metrics slice: tlscheck.chain.valid")
+						validatedMetrics["tlscheck.chain.valid"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "1 if the certificate chain validates against the trust bundle, otherwise 0.", mi.Description())
+						assert.Equal(t, "1", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						switch aggMap["tlscheck.chain.valid"] {
+						case "sum":
+							assert.Equal(t, int64(4), dp.IntValue())
+						case "avg":
+							assert.Equal(t, int64(2), dp.IntValue())
+						case "min":
+							assert.Equal(t, int64(1), dp.IntValue())
+						case "max":
+							assert.Equal(t, int64(3), dp.IntValue())
+						}
+						_, ok := dp.Attributes().Get("tlscheck.chain.failure_reason")
+						assert.False(t, ok)
+					}
+				case "tlscheck.key.size":
+					if tt.name != "reaggregate_set" {
+						assert.False(t, validatedMetrics["tlscheck.key.size"], "Found a duplicate in the metrics slice: tlscheck.key.size")
+						validatedMetrics["tlscheck.key.size"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "Size in bits of the public key of the certificate.", mi.Description())
+						assert.Equal(t, "bit", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						assert.Equal(t, int64(1), dp.IntValue())
+						tlscheckX509KeyTypeAttrVal, ok := dp.Attributes().Get("tlscheck.x509.key_type")
+						assert.True(t, ok)
+						assert.Equal(t, "rsa", tlscheckX509KeyTypeAttrVal.Str())
+						tlscheckX509SignatureAlgorithmAttrVal, ok := dp.Attributes().Get("tlscheck.x509.signature_algorithm")
+						assert.True(t, ok)
+						assert.Equal(t, "tlscheck.x509.signature_algorithm-val", tlscheckX509SignatureAlgorithmAttrVal.Str())
+					} else {
+						assert.False(t, validatedMetrics["tlscheck.key.size"], "Found a duplicate in the metrics slice: tlscheck.key.size")
+						validatedMetrics["tlscheck.key.size"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "Size in bits of the public key of the certificate.", mi.Description())
+						assert.Equal(t, "bit", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						switch aggMap["tlscheck.key.size"] {
+						case "sum":
+							assert.Equal(t, int64(4), dp.IntValue())
+						case "avg":
+							assert.Equal(t, int64(2), dp.IntValue())
+						case "min":
+							assert.Equal(t, int64(1), dp.IntValue())
+						case "max":
+							assert.Equal(t, int64(3), dp.IntValue())
+						}
+						_, ok := dp.Attributes().Get("tlscheck.x509.key_type")
+						assert.False(t, ok)
+						_, ok = dp.Attributes().Get("tlscheck.x509.signature_algorithm")
+						assert.False(t, ok)
+					}
+				case "tlscheck.ocsp.stapled":
+					assert.False(t, validatedMetrics["tlscheck.ocsp.stapled"], "Found a duplicate in the metrics slice: tlscheck.ocsp.stapled")
+					validatedMetrics["tlscheck.ocsp.stapled"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+					assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if the endpoint staples an OCSP response to the TLS handshake, otherwise 0. Only reported for endpoints.", mi.Description())
+					assert.Equal(t, "1", mi.Unit())
+					dp := mi.Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "tlscheck.revoked":
+					if tt.name != "reaggregate_set" {
+						assert.False(t, validatedMetrics["tlscheck.revoked"], "Found a duplicate in the metrics slice: tlscheck.revoked")
+						validatedMetrics["tlscheck.revoked"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "1 if the certificate is revoked, otherwise 0. Not reported when the revocation status cannot be determined.", mi.Description())
+						assert.Equal(t, "1", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						assert.Equal(t, int64(1), dp.IntValue())
+						tlscheckRevocationMethodAttrVal, ok := dp.Attributes().Get("tlscheck.revocation.method")
+						assert.True(t, ok)
+						assert.Equal(t, "ocsp", tlscheckRevocationMethodAttrVal.Str())
+					} else {
+						assert.False(t, validatedMetrics["tlscheck.revoked"], "Found a duplicate in the metrics slice: tlscheck.revoked")
+						validatedMetrics["tlscheck.revoked"] = true
+						assert.Equal(t, pmetric.MetricTypeGauge, mi.Type())
+						assert.Equal(t, 1, mi.Gauge().DataPoints().Len())
+						assert.Equal(t, "1 if the certificate is revoked, otherwise 0. Not reported when the revocation status cannot be determined.", mi.Description())
+						assert.Equal(t, "1", mi.Unit())
+						dp := mi.Gauge().DataPoints().At(0)
+						assert.Equal(t, start, dp.StartTimestamp())
+						assert.Equal(t, ts, dp.Timestamp())
+						assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+						switch aggMap["tlscheck.revoked"] {
+						case "sum":
+							assert.Equal(t, int64(4), dp.IntValue())
+						case "avg":
+							assert.Equal(t, int64(2), dp.IntValue())
+						case "min":
+							assert.Equal(t, int64(1), dp.IntValue())
+						case "max":
+							assert.Equal(t, int64(3), dp.IntValue())
+						}
+						_, ok := dp.Attributes().Get("tlscheck.revocation.method")
+						assert.False(t, ok)
+					}
 				case "tlscheck.time_left":
 					if tt.name != "reaggregate_set" {
 						assert.False(t, validatedMetrics["tlscheck.time_left"], "Found a duplicate in the metrics slice: tlscheck.time_left")
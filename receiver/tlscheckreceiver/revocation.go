@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// maxRevocationResponseSize bounds the size of the OCSP responses and CRLs that are downloaded
+const maxRevocationResponseSize = 20 << 20
+
+var errNoIssuer = errors.New("the issuer of the certificate is required to check its revocation status")
+
+// revocationChecker checks the revocation status of certificates with OCSP and CRLs.
+// OCSP statuses are cached per certificate and CRLs per distribution point.
+type revocationChecker struct {
+	client   *http.Client
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu           sync.Mutex
+	ocspStatuses map[string]cachedOCSPStatus
+	crls         map[string]cachedCRL
+}
+
+type cachedOCSPStatus struct {
+	revoked bool
+	expires time.Time
+}
+
+type cachedCRL struct {
+	crl     *x509.RevocationList
+	expires time.Time
+}
+
+func newRevocationChecker(cfg VerificationConfig) *revocationChecker {
+	return &revocationChecker{
+		client:       &http.Client{Timeout: cfg.Timeout},
+		cacheTTL:     cfg.CacheTTL,
+		now:          time.Now,
+		ocspStatuses: map[string]cachedOCSPStatus{},
+		crls:         map[string]cachedCRL{},
+	}
+}
+
+// cacheExpiry returns when a result fetched now expires, given the time of its next update.
+func (c *revocationChecker) cacheExpiry(nextUpdate time.Time) time.Time {
+	expires := c.now().Add(c.cacheTTL)
+	if !nextUpdate.IsZero() && nextUpdate.Before(expires) {
+		return nextUpdate
+	}
+	return expires
+}
+
+// checkOCSP returns whether cert is revoked according to the stapled OCSP response, when it is valid, or to
+// the OCSP responder of the certificate.
+func (c *revocationChecker) checkOCSP(ctx context.Context, cert, issuer *x509.Certificate, staple []byte) (bool, error) {
+	if issuer == nil {
+		return false, errNoIssuer
+	}
+	key := string(cert.RawIssuer) + cert.SerialNumber.String()
+
+	c.mu.Lock()
+	cached, ok := c.ocspStatuses[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expires) {
+		return cached.revoked, nil
+	}
+
+	var resp *ocsp.Response
+	if len(staple) > 0 {
+		// An invalid staple falls back to the responder.
+		resp, _ = ocsp.ParseResponseForCert(staple, cert, issuer)
+	}
+	if resp == nil {
+		var err error
+		if resp, err = c.queryOCSPResponder(ctx, cert, issuer); err != nil {
+			return false, err
+		}
+	}
+
+	var revoked bool
+	switch resp.Status {
+	case ocsp.Good:
+	case ocsp.Revoked:
+		revoked = true
+	default:
+		return false, errors.New("the OCSP responder does not know the certificate")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, v := range c.ocspStatuses {
+		if !now.Before(v.expires) {
+			delete(c.ocspStatuses, k)
+		}
+	}
+	c.ocspStatuses[key] = cachedOCSPStatus{revoked: revoked, expires: c.cacheExpiry(resp.NextUpdate)}
+	return revoked, nil
+}
+
+func (c *revocationChecker) queryOCSPResponder(ctx context.Context, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.New("the certificate has no OCSP responder")
+	}
+	ocspRequest, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OCSP request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cert.OCSPServer[0], bytes.NewReader(ocspRequest))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	body, err := c.fetch(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OCSP responder %s: %w", cert.OCSPServer[0], err)
+	}
+	resp, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response from OCSP responder %s: %w", cert.OCSPServer[0], err)
+	}
+	return resp, nil
+}
+
+// checkCRL returns whether cert is listed by the CRL of its first HTTP distribution point.
+func (c *revocationChecker) checkCRL(ctx context.Context, cert, issuer *x509.Certificate) (bool, error) {
+	if issuer == nil {
+		return false, errNoIssuer
+	}
+	var url string
+	for _, dp := range cert.CRLDistributionPoints {
+		if strings.HasPrefix(dp, "http://") || strings.HasPrefix(dp, "https://") {
+			url = dp
+			break
+		}
+	}
+	if url == "" {
+		return false, errors.New("the certificate has no HTTP CRL distribution point")
+	}
+
+	crl, err := c.getCRL(ctx, url, issuer)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *revocationChecker) getCRL(ctx context.Context, url string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	c.mu.Lock()
+	cached, ok := c.crls[url]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expires) {
+		return cached.crl, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.fetch(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download CRL %s: %w", url, err)
+	}
+	crl, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CRL %s: %w", url, err)
+	}
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("CRL %s is not signed by the issuer of the certificate: %w", url, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.crls[url] = cachedCRL{crl: crl, expires: c.cacheExpiry(crl.NextUpdate)}
+	return crl, nil
+}
+
+func (c *revocationChecker) fetch(req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
+}
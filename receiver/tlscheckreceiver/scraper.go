@@ -36,6 +36,9 @@ type scraper struct {
 	cfg                *Config
 	settings           receiver.Settings
 	getConnectionState func(endpoint string) (tls.ConnectionState, error)
+	// roots is the trust bundle chains are verified against, nil for the system trust store
+	roots      *x509.CertPool
+	revocation *revocationChecker
 }
 
 // listens to the error channel and combines errors sent from different go routines,
@@ -128,9 +131,10 @@ func getConnectionState(endpoint string) (tls.ConnectionState, error) {
 	return conn.ConnectionState(), nil
 }
 
-// extractCertMetrics records the tlscheck.time_left metric for a single leaf certificate.
+// extractCertMetrics records the metrics of a single leaf certificate and its chain.
 // target is the resource label (endpoint string or file path).
-func (s *scraper) extractCertMetrics(target string, cert *x509.Certificate, metrics *pmetric.Metrics, mux *sync.Mutex) {
+func (s *scraper) extractCertMetrics(ctx context.Context, target string, chain certificateChain, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
+	cert := chain.certs[0]
 	issuer := cert.Issuer.String()
 	commonName := cert.Subject.CommonName
 
@@ -158,6 +162,7 @@ func (s *scraper) extractCertMetrics(target string, cert *x509.Certificate, metr
 	rb := mb.NewResourceBuilder()
 	rb.SetTlscheckTarget(target)
 	mb.RecordTlscheckTimeLeftDataPoint(now, timeLeftInt, issuer, commonName, sans)
+	s.recordVerificationMetrics(ctx, mb, now, chain, errs)
 	resourceMetrics := mb.Emit(metadata.WithResource(rb.Emit()))
 
 	// Only guard the mutation of the shared metrics object with the mutex.
@@ -166,7 +171,7 @@ func (s *scraper) extractCertMetrics(target string, cert *x509.Certificate, metr
 	mux.Unlock()
 }
 
-func (s *scraper) scrapeEndpoint(endpoint string, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapeEndpoint(ctx context.Context, endpoint string, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
 	defer wg.Done()
 	if err := validateEndpoint(endpoint); err != nil {
 		s.settings.Logger.Error("Failed to validate endpoint", zap.String("endpoint", endpoint), zap.Error(err))
@@ -189,10 +194,16 @@ func (s *scraper) scrapeEndpoint(endpoint string, metrics *pmetric.Metrics, wg *
 		return
 	}
 
-	s.extractCertMetrics(endpoint, state.PeerCertificates[0], metrics, mux)
+	hostname, _, _ := net.SplitHostPort(endpoint)
+	s.extractCertMetrics(ctx, endpoint, certificateChain{
+		certs:      state.PeerCertificates,
+		hostname:   hostname,
+		ocspStaple: state.OCSPResponse,
+		endpoint:   true,
+	}, metrics, mux, errs)
 }
 
-func (s *scraper) scrapeFile(target *CertificateTarget, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapeFile(ctx context.Context, target *CertificateTarget, metrics *pmetric.Metrics, wg *sync.WaitGroup, mux *sync.Mutex, errs chan error) {
 	defer wg.Done()
 	filePath := target.FilePath
 
@@ -206,15 +217,15 @@ func (s *scraper) scrapeFile(target *CertificateTarget, metrics *pmetric.Metrics
 
 	switch format {
 	case FileFormatJKS:
-		s.scrapeJKS(target, metrics, mux, errs)
+		s.scrapeJKS(ctx, target, metrics, mux, errs)
 	case FileFormatPKCS12:
-		s.scrapePKCS12(target, metrics, mux, errs)
+		s.scrapePKCS12(ctx, target, metrics, mux, errs)
 	default:
-		s.scrapePEM(filePath, metrics, mux, errs)
+		s.scrapePEM(ctx, filePath, metrics, mux, errs)
 	}
 }
 
-func (s *scraper) scrapePEM(filePath string, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapePEM(ctx context.Context, filePath string, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		s.settings.Logger.Error("Failed to open certificate file", zap.String("file_path", filePath), zap.Error(err))
@@ -261,11 +272,11 @@ func (s *scraper) scrapePEM(filePath string, metrics *pmetric.Metrics, mux *sync
 
 	s.settings.Logger.Debug("Found certificates in chain", zap.String("file_path", filePath), zap.Int("count", len(certs)))
 
-	// Use the leaf certificate (first in file)
-	s.extractCertMetrics(filePath, certs[0], metrics, mux)
+	// Use the leaf certificate (first in file), the others are its chain
+	s.extractCertMetrics(ctx, filePath, certificateChain{certs: certs}, metrics, mux, errs)
 }
 
-func (s *scraper) scrapeJKS(target *CertificateTarget, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapeJKS(ctx context.Context, target *CertificateTarget, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
 	filePath := target.FilePath
 	password := []byte(string(target.Password))
 
@@ -304,7 +315,7 @@ func (s *scraper) scrapeJKS(target *CertificateTarget, metrics *pmetric.Metrics,
 					zap.Error(parseErr))
 				continue
 			}
-			s.extractCertMetrics(filePath, cert, metrics, mux)
+			s.extractCertMetrics(ctx, filePath, certificateChain{certs: []*x509.Certificate{cert}}, metrics, mux, errs)
 			foundAny = true
 		} else if entry, err := ks.GetPrivateKeyEntry(alias, password); err == nil {
 			if len(entry.CertificateChain) == 0 {
@@ -319,7 +330,13 @@ func (s *scraper) scrapeJKS(target *CertificateTarget, metrics *pmetric.Metrics,
 					zap.Error(parseErr))
 				continue
 			}
-			s.extractCertMetrics(filePath, cert, metrics, mux)
+			certs := []*x509.Certificate{cert}
+			for _, chainCert := range entry.CertificateChain[1:] {
+				if parsed, chainErr := x509.ParseCertificate(chainCert.Content); chainErr == nil {
+					certs = append(certs, parsed)
+				}
+			}
+			s.extractCertMetrics(ctx, filePath, certificateChain{certs: certs}, metrics, mux, errs)
 			foundAny = true
 		} else {
 			lastAliasErr = err
@@ -340,7 +357,7 @@ func (s *scraper) scrapeJKS(target *CertificateTarget, metrics *pmetric.Metrics,
 	}
 }
 
-func (s *scraper) scrapePKCS12(target *CertificateTarget, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
+func (s *scraper) scrapePKCS12(ctx context.Context, target *CertificateTarget, metrics *pmetric.Metrics, mux *sync.Mutex, errs chan error) {
 	filePath := target.FilePath
 	password := string(target.Password)
 
@@ -351,7 +368,7 @@ func (s *scraper) scrapePKCS12(target *CertificateTarget, metrics *pmetric.Metri
 		return
 	}
 
-	_, cert, _, err := pkcs12.DecodeChain(data, password)
+	_, cert, caCerts, err := pkcs12.DecodeChain(data, password)
 	if err != nil {
 		s.settings.Logger.Error("Failed to decode PKCS#12 keystore", zap.String("file_path", filePath), zap.Error(err))
 		errs <- err
@@ -365,7 +382,7 @@ func (s *scraper) scrapePKCS12(target *CertificateTarget, metrics *pmetric.Metri
 		return
 	}
 
-	s.extractCertMetrics(filePath, cert, metrics, mux)
+	s.extractCertMetrics(ctx, filePath, certificateChain{certs: append([]*x509.Certificate{cert}, caCerts...)}, metrics, mux, errs)
 }
 
 func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
@@ -389,9 +406,9 @@ func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 
 	for _, target := range s.cfg.Targets {
 		if target.FilePath != "" {
-			go s.scrapeFile(target, &metrics, &wg, &mux, errChan)
+			go s.scrapeFile(ctx, target, &metrics, &wg, &mux, errChan)
 		} else {
-			go s.scrapeEndpoint(target.TCPAddrConfig.Endpoint, &metrics, &wg, &mux, errChan)
+			go s.scrapeEndpoint(ctx, target.TCPAddrConfig.Endpoint, &metrics, &wg, &mux, errChan)
 		}
 	}
 
@@ -406,5 +423,6 @@ func newScraper(cfg *Config, settings receiver.Settings, getConnectionState func
 		cfg:                cfg,
 		settings:           settings,
 		getConnectionState: getConnectionState,
+		revocation:         newRevocationChecker(cfg.Verification),
 	}
 }
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+// certificateChain is a leaf certificate along with the certificates presented with it
+type certificateChain struct {
+	// certs starts with the leaf certificate
+	certs []*x509.Certificate
+	// hostname is the name the leaf certificate is verified for, it is only set for endpoints
+	hostname string
+	// ocspStaple is the OCSP response stapled by an endpoint
+	ocspStaple []byte
+	endpoint   bool
+}
+
+// start loads the trust bundle chains are verified against
+func (s *scraper) start(_ context.Context, _ component.Host) error {
+	if s.cfg.Verification.CAFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.cfg.Verification.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to read trust bundle: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return fmt.Errorf("no certificates found in trust bundle %s", s.cfg.Verification.CAFile)
+	}
+	s.roots = roots
+	return nil
+}
+
+// recordVerificationMetrics records the chain, revocation and key metrics of the chain that are enabled
+func (s *scraper) recordVerificationMetrics(ctx context.Context, mb *metadata.MetricsBuilder, now pcommon.Timestamp, chain certificateChain, errs chan error) {
+	metrics := s.cfg.MetricsBuilderConfig.Metrics
+	leaf := chain.certs[0]
+
+	if metrics.TlscheckKeySize.Enabled {
+		keyType, size := publicKeyInfo(leaf)
+		mb.RecordTlscheckKeySizeDataPoint(now, size, keyType, leaf.SignatureAlgorithm.String())
+	}
+
+	if metrics.TlscheckOcspStapled.Enabled && chain.endpoint {
+		stapled := int64(0)
+		if len(chain.ocspStaple) > 0 {
+			stapled = 1
+		}
+		mb.RecordTlscheckOcspStapledDataPoint(now, stapled)
+	}
+
+	checkOCSP := metrics.TlscheckRevoked.Enabled && s.cfg.Verification.OCSP
+	checkCRL := metrics.TlscheckRevoked.Enabled && s.cfg.Verification.CRL
+	if !metrics.TlscheckChainValid.Enabled && !checkOCSP && !checkCRL {
+		return
+	}
+
+	verifiedChains, reason := s.verifyChain(chain)
+	if metrics.TlscheckChainValid.Enabled {
+		valid := int64(0)
+		if reason == metadata.AttributeTlscheckChainFailureReasonNone {
+			valid = 1
+		}
+		mb.RecordTlscheckChainValidDataPoint(now, valid, reason)
+	}
+
+	issuer := findIssuer(leaf, verifiedChains, chain.certs)
+	if checkOCSP {
+		if revoked, err := s.revocation.checkOCSP(ctx, leaf, issuer, chain.ocspStaple); err != nil {
+			s.settings.Logger.Warn("Failed to check the OCSP revocation status", zap.String("subject", leaf.Subject.String()), zap.Error(err))
+			errs <- err
+		} else {
+			mb.RecordTlscheckRevokedDataPoint(now, boolToInt(revoked), metadata.AttributeTlscheckRevocationMethodOcsp)
+		}
+	}
+	if checkCRL {
+		if revoked, err := s.revocation.checkCRL(ctx, leaf, issuer); err != nil {
+			s.settings.Logger.Warn("Failed to check the CRL revocation status", zap.String("subject", leaf.Subject.String()), zap.Error(err))
+			errs <- err
+		} else {
+			mb.RecordTlscheckRevokedDataPoint(now, boolToInt(revoked), metadata.AttributeTlscheckRevocationMethodCrl)
+		}
+	}
+}
+
+// verifyChain verifies the chain against the trust bundle, and returns the verified chains or the reason it failed
+func (s *scraper) verifyChain(chain certificateChain) ([][]*x509.Certificate, metadata.AttributeTlscheckChainFailureReason) {
+	leaf := chain.certs[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain.certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         s.roots,
+		Intermediates: intermediates,
+		DNSName:       chain.hostname,
+	}
+	if !chain.endpoint {
+		// Certificate files are not necessarily server certificates.
+		opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+
+	verifiedChains, err := leaf.Verify(opts)
+	if err != nil {
+		s.settings.Logger.Debug("Certificate chain verification failed", zap.String("subject", leaf.Subject.String()), zap.Error(err))
+		return nil, chainFailureReason(err, leaf, time.Now())
+	}
+	return verifiedChains, metadata.AttributeTlscheckChainFailureReasonNone
+}
+
+func chainFailureReason(err error, leaf *x509.Certificate, now time.Time) metadata.AttributeTlscheckChainFailureReason {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	switch {
+	case errors.As(err, &unknownAuthorityErr):
+		return metadata.AttributeTlscheckChainFailureReasonUnknownAuthority
+	case errors.As(err, &hostnameErr):
+		return metadata.AttributeTlscheckChainFailureReasonHostnameMismatch
+	case errors.As(err, &invalidErr):
+		switch invalidErr.Reason {
+		case x509.Expired:
+			// The reason is the same for certificates that are not valid yet.
+			cert := leaf
+			if invalidErr.Cert != nil {
+				cert = invalidErr.Cert
+			}
+			if now.Before(cert.NotBefore) {
+				return metadata.AttributeTlscheckChainFailureReasonNotYetValid
+			}
+			return metadata.AttributeTlscheckChainFailureReasonExpired
+		case x509.IncompatibleUsage:
+			return metadata.AttributeTlscheckChainFailureReasonIncompatibleUsage
+		}
+	}
+	return metadata.AttributeTlscheckChainFailureReasonInvalid
+}
+
+// findIssuer returns the issuer of cert from the verified chains, or else from the presented certificates
+func findIssuer(cert *x509.Certificate, verifiedChains [][]*x509.Certificate, certs []*x509.Certificate) *x509.Certificate {
+	for _, verifiedChain := range verifiedChains {
+		if len(verifiedChain) > 1 {
+			return verifiedChain[1]
+		}
+	}
+	for _, candidate := range certs[1:] {
+		if bytes.Equal(candidate.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(candidate) == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// publicKeyInfo returns the type and size in bits of the public key of cert
+func publicKeyInfo(cert *x509.Certificate) (metadata.AttributeTlscheckX509KeyType, int64) {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return metadata.AttributeTlscheckX509KeyTypeRsa, int64(key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return metadata.AttributeTlscheckX509KeyTypeEcdsa, int64(key.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return metadata.AttributeTlscheckX509KeyTypeEd25519, int64(len(key) * 8)
+	default:
+		return metadata.AttributeTlscheckX509KeyTypeUnknown, 0
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,447 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+// testPKI is a root and an intermediate CA, with a server acting as the OCSP responder and CRL
+// distribution point of the intermediate.
+type testPKI struct {
+	root, intermediate       *x509.Certificate
+	rootKey, intermediateKey crypto.Signer
+	server                   *httptest.Server
+	ocspRequests             atomic.Int32
+	crlRequests              atomic.Int32
+
+	mu      sync.Mutex
+	revoked map[string]bool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	p := &testPKI{revoked: map[string]bool{}}
+	p.rootKey = newTestKey(t)
+	p.root = createTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, p.rootKey, p.rootKey)
+	p.intermediateKey = newTestKey(t)
+	p.intermediate = createTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, p.root, p.intermediateKey, p.rootKey)
+
+	p.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ocsp":
+			p.ocspRequests.Add(1)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			req, err := ocsp.ParseRequest(body)
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, err = w.Write(p.ocspResponse(t, req.SerialNumber))
+			assert.NoError(t, err)
+		case "/crl":
+			p.crlRequests.Add(1)
+			_, err := w.Write(p.crl(t))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func newTestKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func createTestCertificate(t *testing.T, template, parent *x509.Certificate, key, parentKey crypto.Signer) *x509.Certificate {
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// issue returns a server certificate for dnsName issued by the intermediate CA
+func (p *testPKI) issue(t *testing.T, serial int64, dnsName string, notBefore, notAfter time.Time) *x509.Certificate {
+	return createTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: dnsName},
+		DNSNames:              []string{dnsName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		OCSPServer:            []string{p.server.URL + "/ocsp"},
+		CRLDistributionPoints: []string{p.server.URL + "/crl"},
+	}, p.intermediate, newTestKey(t), p.intermediateKey)
+}
+
+func (p *testPKI) revoke(serial int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.revoked[big.NewInt(serial).String()] = true
+}
+
+func (p *testPKI) ocspResponse(t *testing.T, serial *big.Int) []byte {
+	p.mu.Lock()
+	status := ocsp.Good
+	if p.revoked[serial.String()] {
+		status = ocsp.Revoked
+	}
+	p.mu.Unlock()
+	resp, err := ocsp.CreateResponse(p.intermediate, p.intermediate, ocsp.Response{
+		Status:       status,
+		SerialNumber: serial,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   time.Now().Add(time.Hour),
+		RevokedAt:    time.Now().Add(-time.Minute),
+	}, p.intermediateKey)
+	require.NoError(t, err)
+	return resp
+}
+
+func (p *testPKI) crl(t *testing.T) []byte {
+	p.mu.Lock()
+	var entries []x509.RevocationListEntry
+	for serial := range p.revoked {
+		n, _ := new(big.Int).SetString(serial, 10)
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: n, RevocationTime: time.Now().Add(-time.Minute)})
+	}
+	p.mu.Unlock()
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now().Add(-time.Minute),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}, p.intermediate, p.intermediateKey)
+	require.NoError(t, err)
+	return crl
+}
+
+// writeRoot writes the root CA to a trust bundle file and returns its path
+func (p *testPKI) writeRoot(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.root.Raw}), 0o600))
+	return path
+}
+
+func TestRevocationChecker(t *testing.T) {
+	pki := newTestPKI(t)
+	good := pki.issue(t, 10, "good.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	revoked := pki.issue(t, 11, "revoked.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	pki.revoke(11)
+
+	checker := newRevocationChecker(VerificationConfig{Timeout: 5 * time.Second, CacheTTL: time.Hour})
+	for i := 0; i < 2; i++ {
+		isRevoked, err := checker.checkOCSP(t.Context(), good, pki.intermediate, nil)
+		require.NoError(t, err)
+		assert.False(t, isRevoked)
+		isRevoked, err = checker.checkOCSP(t.Context(), revoked, pki.intermediate, nil)
+		require.NoError(t, err)
+		assert.True(t, isRevoked)
+
+		isRevoked, err = checker.checkCRL(t.Context(), good, pki.intermediate)
+		require.NoError(t, err)
+		assert.False(t, isRevoked)
+		isRevoked, err = checker.checkCRL(t.Context(), revoked, pki.intermediate)
+		require.NoError(t, err)
+		assert.True(t, isRevoked)
+	}
+	// The second round is answered from the cache
+	assert.Equal(t, int32(2), pki.ocspRequests.Load())
+	assert.Equal(t, int32(1), pki.crlRequests.Load())
+
+	// Cached results expire with the cache TTL
+	checker.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err := checker.checkOCSP(t.Context(), good, pki.intermediate, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), pki.ocspRequests.Load())
+}
+
+func TestRevocationCheckerStaple(t *testing.T) {
+	pki := newTestPKI(t)
+	cert := pki.issue(t, 10, "good.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	pki.revoke(10)
+	staple := pki.ocspResponse(t, cert.SerialNumber)
+
+	checker := newRevocationChecker(VerificationConfig{Timeout: 5 * time.Second})
+	isRevoked, err := checker.checkOCSP(t.Context(), cert, pki.intermediate, staple)
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+	assert.Zero(t, pki.ocspRequests.Load())
+
+	// An invalid staple falls back to the responder
+	isRevoked, err = checker.checkOCSP(t.Context(), cert, pki.intermediate, []byte("invalid"))
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+	assert.Equal(t, int32(1), pki.ocspRequests.Load())
+}
+
+func TestRevocationCheckerErrors(t *testing.T) {
+	pki := newTestPKI(t)
+	cert := pki.issue(t, 10, "good.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	checker := newRevocationChecker(VerificationConfig{Timeout: 5 * time.Second})
+
+	_, err := checker.checkOCSP(t.Context(), cert, nil, nil)
+	require.ErrorIs(t, err, errNoIssuer)
+	_, err = checker.checkCRL(t.Context(), cert, nil)
+	require.ErrorIs(t, err, errNoIssuer)
+
+	// The CRL is not signed by the root
+	_, err = checker.checkCRL(t.Context(), cert, pki.root)
+	require.ErrorContains(t, err, "is not signed by the issuer of the certificate")
+
+	noResponder := pki.issue(t, 11, "good.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	noResponder.OCSPServer = nil
+	noResponder.CRLDistributionPoints = []string{"ldap://example.com/crl"}
+	_, err = checker.checkOCSP(t.Context(), noResponder, pki.intermediate, nil)
+	require.EqualError(t, err, "the certificate has no OCSP responder")
+	_, err = checker.checkCRL(t.Context(), noResponder, pki.intermediate)
+	require.EqualError(t, err, "the certificate has no HTTP CRL distribution point")
+}
+
+func scrapeVerification(t *testing.T, cfg *Config, state tls.ConnectionState) map[string]pmetric.Metric {
+	cfg.MetricsBuilderConfig = metadata.NewDefaultMetricsBuilderConfig()
+	cfg.MetricsBuilderConfig.Metrics.TlscheckChainValid.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.TlscheckKeySize.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.TlscheckOcspStapled.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.TlscheckRevoked.Enabled = true
+	if cfg.Verification.Timeout == 0 {
+		cfg.Verification.Timeout = 5 * time.Second
+	}
+
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), func(string) (tls.ConnectionState, error) {
+		return state, nil
+	})
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	metrics, err := s.scrape(t.Context())
+	require.NoError(t, err)
+
+	found := map[string]pmetric.Metric{}
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		found[ms.At(i).Name()] = ms.At(i)
+	}
+	return found
+}
+
+func endpointTarget(endpoint string) []*CertificateTarget {
+	return []*CertificateTarget{{TCPAddrConfig: confignet.TCPAddrConfig{Endpoint: endpoint}}}
+}
+
+func TestScrape_ChainVerification(t *testing.T) {
+	pki := newTestPKI(t)
+	caFile := pki.writeRoot(t)
+	valid := pki.issue(t, 10, "valid.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	expired := pki.issue(t, 11, "valid.example.com", time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	notYetValid := pki.issue(t, 12, "valid.example.com", time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+
+	testCases := []struct {
+		desc           string
+		endpoint       string
+		caFile         string
+		certs          []*x509.Certificate
+		expectedValid  int64
+		expectedReason string
+	}{
+		{
+			desc:           "valid chain",
+			endpoint:       "valid.example.com:443",
+			caFile:         caFile,
+			certs:          []*x509.Certificate{valid, pki.intermediate},
+			expectedValid:  1,
+			expectedReason: "none",
+		},
+		{
+			desc:           "root missing from the trust store",
+			endpoint:       "valid.example.com:443",
+			certs:          []*x509.Certificate{valid, pki.intermediate},
+			expectedReason: "unknown_authority",
+		},
+		{
+			desc:           "intermediate not presented",
+			endpoint:       "valid.example.com:443",
+			caFile:         caFile,
+			certs:          []*x509.Certificate{valid},
+			expectedReason: "unknown_authority",
+		},
+		{
+			desc:           "hostname mismatch",
+			endpoint:       "other.example.com:443",
+			caFile:         caFile,
+			certs:          []*x509.Certificate{valid, pki.intermediate},
+			expectedReason: "hostname_mismatch",
+		},
+		{
+			desc:           "expired",
+			endpoint:       "valid.example.com:443",
+			caFile:         caFile,
+			certs:          []*x509.Certificate{expired, pki.intermediate},
+			expectedReason: "expired",
+		},
+		{
+			desc:           "not yet valid",
+			endpoint:       "valid.example.com:443",
+			caFile:         caFile,
+			certs:          []*x509.Certificate{notYetValid, pki.intermediate},
+			expectedReason: "not_yet_valid",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &Config{
+				Targets:      endpointTarget(tc.endpoint),
+				Verification: VerificationConfig{CAFile: tc.caFile},
+			}
+			found := scrapeVerification(t, cfg, tls.ConnectionState{PeerCertificates: tc.certs})
+
+			require.Contains(t, found, "tlscheck.chain.valid")
+			dp := found["tlscheck.chain.valid"].Gauge().DataPoints().At(0)
+			assert.Equal(t, tc.expectedValid, dp.IntValue())
+			reason, _ := dp.Attributes().Get("tlscheck.chain.failure_reason")
+			assert.Equal(t, tc.expectedReason, reason.Str())
+
+			// Revocation checks are disabled
+			assert.NotContains(t, found, "tlscheck.revoked")
+		})
+	}
+}
+
+func TestScrape_KeyAndStapling(t *testing.T) {
+	pki := newTestPKI(t)
+	cert := pki.issue(t, 10, "valid.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	for stapled, staple := range [][]byte{nil, pki.ocspResponse(t, cert.SerialNumber)} {
+		found := scrapeVerification(t, &Config{Targets: endpointTarget("valid.example.com:443")}, tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert, pki.intermediate},
+			OCSPResponse:     staple,
+		})
+
+		require.Contains(t, found, "tlscheck.ocsp.stapled")
+		assert.Equal(t, int64(stapled), found["tlscheck.ocsp.stapled"].Gauge().DataPoints().At(0).IntValue())
+
+		require.Contains(t, found, "tlscheck.key.size")
+		dp := found["tlscheck.key.size"].Gauge().DataPoints().At(0)
+		assert.Equal(t, int64(256), dp.IntValue())
+		assert.Equal(t, map[string]any{
+			"tlscheck.x509.key_type":            "ecdsa",
+			"tlscheck.x509.signature_algorithm": "ECDSA-SHA256",
+		}, dp.Attributes().AsRaw())
+	}
+}
+
+func TestScrape_Revocation(t *testing.T) {
+	pki := newTestPKI(t)
+	cert := pki.issue(t, 10, "valid.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	pki.revoke(10)
+
+	cfg := &Config{
+		Targets:      endpointTarget("valid.example.com:443"),
+		Verification: VerificationConfig{CAFile: pki.writeRoot(t), OCSP: true, CRL: true},
+	}
+	found := scrapeVerification(t, cfg, tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert, pki.intermediate}})
+
+	require.Contains(t, found, "tlscheck.revoked")
+	dps := found["tlscheck.revoked"].Gauge().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	methods := map[string]int64{}
+	for i := 0; i < dps.Len(); i++ {
+		method, _ := dps.At(i).Attributes().Get("tlscheck.revocation.method")
+		methods[method.Str()] = dps.At(i).IntValue()
+	}
+	assert.Equal(t, map[string]int64{"ocsp": 1, "crl": 1}, methods)
+}
+
+func TestScrape_RevocationFileWithoutIssuer(t *testing.T) {
+	pki := newTestPKI(t)
+	cert := pki.issue(t, 10, "valid.example.com", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0o600))
+
+	cfg := &Config{
+		Targets:              []*CertificateTarget{{FilePath: path}},
+		MetricsBuilderConfig: metadata.NewDefaultMetricsBuilderConfig(),
+		Verification:         VerificationConfig{OCSP: true, Timeout: 5 * time.Second},
+	}
+	cfg.MetricsBuilderConfig.Metrics.TlscheckRevoked.Enabled = true
+	s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), mockGetConnectionStateValid)
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+
+	metrics, err := s.scrape(t.Context())
+	require.ErrorContains(t, err, errNoIssuer.Error())
+	// The time left is still reported
+	assert.Equal(t, 1, metrics.DataPointCount())
+}
+
+func TestStart_InvalidTrustBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+	for _, caFile := range []string{path, filepath.Join(t.TempDir(), "missing.pem")} {
+		cfg := &Config{Verification: VerificationConfig{CAFile: caFile}}
+		s := newScraper(cfg, receivertest.NewNopSettings(metadata.Type), mockGetConnectionStateValid)
+		require.Error(t, s.start(t.Context(), componenttest.NewNopHost()))
+	}
+}
+
+func TestChainFailureReason(t *testing.T) {
+	leaf := &x509.Certificate{NotBefore: time.Now().Add(time.Hour)}
+	assert.Equal(t, metadata.AttributeTlscheckChainFailureReasonNotYetValid,
+		chainFailureReason(x509.CertificateInvalidError{Reason: x509.Expired}, leaf, time.Now()))
+	assert.Equal(t, metadata.AttributeTlscheckChainFailureReasonIncompatibleUsage,
+		chainFailureReason(x509.CertificateInvalidError{Cert: leaf, Reason: x509.IncompatibleUsage}, leaf, time.Now()))
+	assert.Equal(t, metadata.AttributeTlscheckChainFailureReasonInvalid,
+		chainFailureReason(errors.New("x509: unhandled critical extension"), leaf, time.Now()))
+}
@@ -5,6 +5,7 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"time"
 
@@ -22,6 +23,7 @@ var (
 	errHMACMissingSecret           = errors.New("hmac_signature.secret is required when hmac_signature is configured")
 	errHMACMissingHeader           = errors.New("hmac_signature.header is required when hmac_signature is configured")
 	errHMACMissingPrefix           = errors.New("hmac_signature.prefix is required when hmac_signature is configured")
+	errHMACAndSignature            = errors.New("hmac_signature and signature cannot be configured at the same time")
 )
 
 // Config defines configuration for the Generic Webhook receiver.
@@ -37,6 +39,8 @@ type Config struct {
 	ConvertHeadersToAttributes bool                    `mapstructure:"convert_headers_to_attributes"` // optional to convert all headers to attributes
 	HeaderAttributeRegex       string                  `mapstructure:"header_attribute_regex"`        // optional to convert headers matching a regex to log attributes
 	HMACSignature              HMACSignature           `mapstructure:"hmac_signature"`                // optional HMAC hex digest signature verification
+	Signature                  SignatureConfig         `mapstructure:"signature"`                     // optional signature verification of the requests received on path
+	Routes                     []RouteConfig           `mapstructure:"routes"`                        // optional paths whose requests are mapped into metrics and spans
 }
 
 type RequiredHeader struct {
@@ -115,6 +119,27 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.Signature.configured() {
+		if cfg.HMACSignature.Secret != "" || cfg.HMACSignature.Header != "" || cfg.HMACSignature.Prefix != "" {
+			errs = multierr.Append(errs, errHMACAndSignature)
+		}
+		for _, err := range multierr.Errors(cfg.Signature.Validate()) {
+			errs = multierr.Append(errs, fmt.Errorf("signature: %w", err))
+		}
+	}
+
+	paths := map[string]bool{cfg.Path: true, cfg.HealthPath: true}
+	for i := range cfg.Routes {
+		route := &cfg.Routes[i]
+		if route.Path != "" && paths[route.Path] {
+			errs = multierr.Append(errs, fmt.Errorf("routes[%d]: path %q is already in use", i, route.Path))
+		}
+		paths[route.Path] = true
+		for _, err := range multierr.Errors(route.Validate()) {
+			errs = multierr.Append(errs, fmt.Errorf("routes[%d]: %w", i, err))
+		}
+	}
+
 	if cfg.HeaderAttributeRegex != "" {
 		_, err := regexp.Compile(cfg.HeaderAttributeRegex)
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -392,3 +393,144 @@ func TestLoadConfig(t *testing.T) {
 
 	require.Equal(t, expect, conf)
 }
+
+func TestLoadRoutesConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub("webhookevent/routes")
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, confmap.Validate(cfg))
+
+	require.Equal(t, SignatureConfig{Scheme: "stripe", Secret: "stripe-secret"}, cfg.Signature)
+	require.Len(t, cfg.Routes, 1)
+	route := cfg.Routes[0]
+	require.Equal(t, "/ci", route.Path)
+	require.Equal(t, "hmac", route.Signature.Scheme)
+	require.Equal(t, []string{"set(log.cache, ParseJSON(log.body))"}, route.Statements)
+	require.Len(t, route.Metrics, 1)
+	require.Equal(t, "ci.build.duration", route.Metrics[0].Name)
+	require.Equal(t, `log.cache["duration"]`, route.Metrics[0].Value)
+	require.Equal(t, []AttributeConfig{{Key: "ci.pipeline", Value: `log.cache["pipeline"]`}}, route.Metrics[0].Attributes)
+	require.Len(t, route.Spans, 1)
+	require.Equal(t, `log.cache["finished_at"]`, route.Spans[0].EndTime)
+	require.Equal(t, []string{`log.cache["status"] == "failed"`}, route.Spans[0].ErrorConditions)
+}
+
+func TestValidateRoutesConfig(t *testing.T) {
+	t.Parallel()
+
+	validRoute := func() RouteConfig {
+		return RouteConfig{
+			Path:    "/ci",
+			Metrics: []MetricConfig{{Name: "ci.builds", Type: "sum", Monotonic: true, Value: "1"}},
+		}
+	}
+
+	tests := []struct {
+		desc      string
+		configure func(cfg *Config)
+		errs      []string
+	}{
+		{
+			desc: "valid route",
+			configure: func(cfg *Config) {
+				cfg.Routes = []RouteConfig{validRoute()}
+			},
+		},
+		{
+			desc: "signature and hmac_signature",
+			configure: func(cfg *Config) {
+				cfg.HMACSignature = HMACSignature{Secret: "secret", Header: "X-Signature", Prefix: "sha256="}
+				cfg.Signature = SignatureConfig{Scheme: "slack", Secret: "secret"}
+			},
+			errs: []string{errHMACAndSignature.Error()},
+		},
+		{
+			desc: "invalid signature",
+			configure: func(cfg *Config) {
+				cfg.Signature = SignatureConfig{Scheme: "hmac", Tolerance: -time.Second}
+			},
+			errs: []string{
+				"signature: secret is required",
+				"signature: header is required by the hmac scheme",
+				"signature: prefix is required by the hmac scheme",
+				"signature: tolerance must not be negative",
+			},
+		},
+		{
+			desc: "unsupported signature scheme",
+			configure: func(cfg *Config) {
+				cfg.Signature = SignatureConfig{Scheme: "md5", Secret: "secret"}
+			},
+			errs: []string{`signature: unsupported scheme "md5"`},
+		},
+		{
+			desc: "path already in use",
+			configure: func(cfg *Config) {
+				route := validRoute()
+				route.Path = defaultPath
+				cfg.Routes = []RouteConfig{route, validRoute(), validRoute()}
+			},
+			errs: []string{
+				`routes[0]: path "/events" is already in use`,
+				`routes[2]: path "/ci" is already in use`,
+			},
+		},
+		{
+			desc: "missing fields",
+			configure: func(cfg *Config) {
+				cfg.Routes = []RouteConfig{{}, {Path: "/ci", Metrics: []MetricConfig{{Type: "histogram", Monotonic: true}}, Spans: []SpanConfig{{Kind: "remote"}}}}
+			},
+			errs: []string{
+				"routes[0]: path is required",
+				"routes[0]: at least one metric or span is required",
+				"routes[1]: metrics[0]: name is required",
+				"routes[1]: metrics[0]: value is required",
+				`routes[1]: metrics[0]: unsupported type "histogram"`,
+				"routes[1]: metrics[0]: only sums can be monotonic",
+				"routes[1]: spans[0]: name is required",
+				`routes[1]: spans[0]: unsupported kind "remote"`,
+			},
+		},
+		{
+			desc: "invalid OTTL",
+			configure: func(cfg *Config) {
+				route := validRoute()
+				route.Statements = []string{"set(log.cache, ParseJSON(log.body)"}
+				route.Metrics[0].Value = "NoSuchFunc(log.body)"
+				route.Metrics[0].Attributes = []AttributeConfig{{Key: "status"}}
+				route.Spans = []SpanConfig{{Name: "log.body", Conditions: []string{"log.body =="}}}
+				cfg.Routes = []RouteConfig{route}
+			},
+			errs: []string{
+				"routes[0]: statements: ",
+				"routes[0]: metrics[0].value: ",
+				"routes[0]: metrics[0].attributes[0]: key and value are required",
+				"routes[0]: spans[0].conditions: ",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.ServerConfig.NetAddr.Endpoint = "localhost:0"
+			test.configure(cfg)
+			err := cfg.Validate()
+			if len(test.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			errs := multierr.Errors(err)
+			require.Len(t, errs, len(test.errs), "%v", err)
+			for i, expected := range test.errs {
+				require.ErrorContains(t, errs[i], expected)
+			}
+		})
+	}
+}
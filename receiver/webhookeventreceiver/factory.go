@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/xreceiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
 )
 
@@ -36,6 +37,8 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		xreceiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		xreceiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		xreceiver.WithTraces(createTracesReceiver, metadata.TracesStability),
 		xreceiver.WithDeprecatedTypeAlias(metadata.DeprecatedType),
 	)
 }
@@ -71,6 +74,65 @@ func createLogsReceiver(
 	cfg component.Config,
 	consumer consumer.Logs,
 ) (receiver.Logs, error) {
-	conf := cfg.(*Config)
-	return newLogsReceiver(params, *conf, consumer)
+	if consumer == nil {
+		return nil, errNilLogsConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*eventReceiver).logConsumer = consumer
+	return r, nil
+}
+
+// createMetricsReceiver creates a metrics receiver based on provided config.
+func createMetricsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	if consumer == nil {
+		return nil, errNilMetricsConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*eventReceiver).metricsConsumer = consumer
+	return r, nil
+}
+
+// createTracesReceiver creates a traces receiver based on provided config.
+func createTracesReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	cfg component.Config,
+	consumer consumer.Traces,
+) (receiver.Traces, error) {
+	if consumer == nil {
+		return nil, errNilTracesConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*eventReceiver).tracesConsumer = consumer
+	return r, nil
 }
+
+// getOrAddReceiver returns the receiver of cfg, which serves the logs, metrics and traces pipelines it is used in.
+func getOrAddReceiver(params receiver.Settings, cfg component.Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var er *eventReceiver
+		er, err = newEventReceiver(params, *cfg.(*Config))
+		return er
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+var receivers = sharedcomponent.NewSharedComponents()
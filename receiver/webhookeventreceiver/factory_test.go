@@ -52,3 +52,16 @@ func TestCreateLogs(t *testing.T) {
 		t.Run(test.desc, test.run)
 	}
 }
+
+func TestCreateWithNilConsumer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig.NetAddr.Endpoint = "localhost:8080"
+	set := receivertest.NewNopSettings(metadata.Type)
+
+	_, err := createLogsReceiver(t.Context(), set, cfg, nil)
+	require.ErrorIs(t, err, errNilLogsConsumer)
+	_, err = createMetricsReceiver(t.Context(), set, cfg, nil)
+	require.ErrorIs(t, err, errNilMetricsConsumer)
+	_, err = createTracesReceiver(t.Context(), set, cfg, nil)
+	require.ErrorIs(t, err, errNilTracesConsumer)
+}
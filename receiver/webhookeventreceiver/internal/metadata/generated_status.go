@@ -15,5 +15,7 @@ var (
 )
 
 const (
-	LogsStability = component.StabilityLevelBeta
+	MetricsStability = component.StabilityLevelDevelopment
+	TracesStability  = component.StabilityLevelDevelopment
+	LogsStability    = component.StabilityLevelBeta
 )
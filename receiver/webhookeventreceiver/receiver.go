@@ -8,15 +8,11 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
-	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +30,8 @@ import (
 
 var (
 	errNilLogsConsumer          = errors.New("missing a logs consumer")
+	errNilMetricsConsumer       = errors.New("missing a metrics consumer")
+	errNilTracesConsumer        = errors.New("missing a traces consumer")
 	errInvalidRequestMethod     = errors.New("invalid method. Valid method is POST")
 	errInvalidEncodingType      = errors.New("invalid encoding type")
 	errEmptyResponseBody        = errors.New("request body content length is zero")
@@ -50,12 +48,16 @@ type eventReceiver struct {
 	settings            receiver.Settings
 	cfg                 *Config
 	logConsumer         consumer.Logs
+	metricsConsumer     consumer.Metrics
+	tracesConsumer      consumer.Traces
 	server              *http.Server
 	shutdownWG          sync.WaitGroup
 	obsrecv             *receiverhelper.ObsReport
 	gzipPool            *sync.Pool
 	includeHeadersRegex *regexp.Regexp
 	maxRequestBodySize  int // Computed max token size for scanner (minimum 64KB)
+	verifier            signatureVerifier
+	routes              []*route
 }
 
 func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -63,6 +65,16 @@ func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Log
 		return nil, errNilLogsConsumer
 	}
 
+	er, err := newEventReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	er.logConsumer = consumer
+	return er, nil
+}
+
+// newEventReceiver creates the receiver shared by the logs, metrics and traces pipelines.
+func newEventReceiver(params receiver.Settings, cfg Config) (*eventReceiver, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
@@ -87,15 +99,43 @@ func newLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Log
 		return nil, err
 	}
 
+	signature := cfg.Signature
+	if cfg.HMACSignature.Secret != "" {
+		signature = SignatureConfig{
+			Scheme: signatureSchemeHMAC,
+			Secret: cfg.HMACSignature.Secret,
+			Header: cfg.HMACSignature.Header,
+			Prefix: cfg.HMACSignature.Prefix,
+		}
+	}
+
+	verifier := newSignatureVerifier(signature)
+
+	routes := make([]*route, 0, len(cfg.Routes))
+	for _, routeCfg := range cfg.Routes {
+		// Validate() call above has already ensured the OTTL of the routes parses
+		r, err := newRoute(routeCfg, params.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+		// Routes without their own signature are verified like the default path, so that
+		// configuring a route never opens an unsigned endpoint on a receiver requiring signatures.
+		if r.verifier == nil {
+			r.verifier = verifier
+		}
+		routes = append(routes, r)
+	}
+
 	// create eventReceiver instance
 	er := &eventReceiver{
 		settings:            params,
 		cfg:                 &cfg,
-		logConsumer:         consumer,
 		obsrecv:             obsrecv,
 		gzipPool:            &sync.Pool{New: func() any { return new(gzip.Reader) }},
 		includeHeadersRegex: includeHeaderRegex,
 		maxRequestBodySize:  int(cfg.ServerConfig.MaxRequestBodySize),
+		verifier:            verifier,
+		routes:              routes,
 	}
 
 	return er, nil
@@ -117,7 +157,13 @@ func (er *eventReceiver) Start(ctx context.Context, host component.Host) error {
 	// set up router.
 	router := httprouter.New()
 
-	router.POST(er.cfg.Path, er.handleReq)
+	// the default path turns requests into logs, it is only served in logs pipelines.
+	if er.logConsumer != nil {
+		router.POST(er.cfg.Path, er.handleReq)
+	}
+	for _, r := range er.routes {
+		router.POST(r.path, er.handleRouteReq(r))
+	}
 	router.GET(er.cfg.HealthPath, er.handleHealthCheck)
 
 	// webhook server standup and configuration
@@ -167,58 +213,125 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 	ctx := r.Context()
 	ctx = er.obsrecv.StartLogsOp(ctx)
 
+	numLogs := 0
+	status, err := er.processReq(r, er.verifier, func(sc *bufio.Scanner) (int, error) {
+		ld, n, err := er.reqToLog(sc, r.Header, r.URL.Query())
+		numLogs = n
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if err = er.logConsumer.ConsumeLogs(ctx, ld); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		return http.StatusOK, nil
+	})
+
+	if err != nil {
+		er.failBadReq(ctx, w, status, err)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), numLogs, err)
+}
+
+// handleRouteReq returns the handler of the requests received on a route, which are turned into
+// metrics and spans. On success returns a 200 response code to the webhook
+func (er *eventReceiver) handleRouteReq(rt *route) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := r.Context()
+		withMetrics := er.metricsConsumer != nil && len(rt.metrics) > 0
+		withTraces := er.tracesConsumer != nil && len(rt.spans) > 0
+		var metricsCtx, tracesCtx context.Context
+		if withMetrics {
+			metricsCtx = er.obsrecv.StartMetricsOp(ctx)
+		}
+		if withTraces {
+			tracesCtx = er.obsrecv.StartTracesOp(ctx)
+		}
+
+		numDataPoints, numSpans := 0, 0
+		var metricsErr, tracesErr error
+		status, err := er.processReq(r, rt.verifier, func(sc *bufio.Scanner) (int, error) {
+			ld, _, err := er.reqToLog(sc, r.Header, r.URL.Query())
+			if err != nil {
+				return http.StatusBadRequest, err
+			}
+			md, td, err := rt.evaluate(ctx, ld, withMetrics, withTraces)
+			if err != nil {
+				return http.StatusBadRequest, err
+			}
+
+			numDataPoints, numSpans = md.DataPointCount(), td.SpanCount()
+			if numDataPoints > 0 {
+				metricsErr = er.metricsConsumer.ConsumeMetrics(metricsCtx, md)
+			}
+			if numSpans > 0 {
+				tracesErr = er.tracesConsumer.ConsumeTraces(tracesCtx, td)
+			}
+			if err = errors.Join(metricsErr, tracesErr); err != nil {
+				return http.StatusInternalServerError, err
+			}
+			return http.StatusOK, nil
+		})
+
+		if err != nil {
+			er.failBadReq(ctx, w, status, err)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		if status != http.StatusInternalServerError {
+			// the request was rejected before reaching the consumers
+			metricsErr, tracesErr = err, err
+		}
+		if withMetrics {
+			er.obsrecv.EndMetricsOp(metricsCtx, metadata.Type.String(), numDataPoints, metricsErr)
+		}
+		if withTraces {
+			er.obsrecv.EndTracesOp(tracesCtx, metadata.Type.String(), numSpans, tracesErr)
+		}
+	}
+}
+
+// processReq checks the request and passes a scanner over its decoded body to consume. It returns the HTTP status code
+// of the response, along with the error the request failed with.
+func (er *eventReceiver) processReq(r *http.Request, verifier signatureVerifier, consume func(sc *bufio.Scanner) (int, error)) (int, error) {
 	if r.Method != http.MethodPost {
-		er.failBadReq(ctx, w, http.StatusBadRequest, errInvalidRequestMethod)
-		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errInvalidRequestMethod)
-		return
+		return http.StatusBadRequest, errInvalidRequestMethod
 	}
 
 	if er.cfg.RequiredHeader.Key != "" {
 		requiredHeaderValue := r.Header.Get(er.cfg.RequiredHeader.Key)
 		if requiredHeaderValue != er.cfg.RequiredHeader.Value {
-			er.failBadReq(ctx, w, http.StatusUnauthorized, errMissingRequiredHeader)
-			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errMissingRequiredHeader)
-			return
+			return http.StatusUnauthorized, errMissingRequiredHeader
 		}
 	}
 
 	encoding := r.Header.Get("Content-Encoding")
 	// only support gzip if encoding header is set.
 	if encoding != "" && encoding != "gzip" {
-		er.failBadReq(ctx, w, http.StatusUnsupportedMediaType, errInvalidEncodingType)
-		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errInvalidEncodingType)
-		return
+		return http.StatusUnsupportedMediaType, errInvalidEncodingType
 	}
 
 	if r.ContentLength == 0 {
-		er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
-		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, errEmptyResponseBody)
-		return
+		return http.StatusBadRequest, errEmptyResponseBody
 	}
 
-	// If HMAC signature verification is configured, read the raw body for verification
+	// If signature verification is configured, read the raw body for verification
 	// before any decompression, since webhook providers sign the raw payload.
-	if er.cfg.HMACSignature.Secret != "" {
+	if verifier != nil {
 		limitedBody := io.LimitReader(r.Body, int64(er.maxRequestBodySize)+1)
 		rawBody, readErr := io.ReadAll(limitedBody)
 		_ = r.Body.Close()
 		if readErr != nil {
-			er.failBadReq(ctx, w, http.StatusBadRequest, readErr)
-			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, readErr)
-			return
+			return http.StatusBadRequest, readErr
 		}
 
 		if len(rawBody) > er.maxRequestBodySize {
-			tooLargeErr := fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, er.maxRequestBodySize)
-			er.failBadReq(ctx, w, http.StatusBadRequest, tooLargeErr)
-			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, tooLargeErr)
-			return
+			return http.StatusBadRequest, fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, er.maxRequestBodySize)
 		}
 
-		if verifyErr := er.verifyHMACSignature(rawBody, r.Header.Get(er.cfg.HMACSignature.Header)); verifyErr != nil {
-			er.failBadReq(ctx, w, http.StatusUnauthorized, verifyErr)
-			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, verifyErr)
-			return
+		if verifyErr := verifier.verify(r.Header, rawBody); verifyErr != nil {
+			return http.StatusUnauthorized, verifyErr
 		}
 
 		// Replace the body with a reader over the raw bytes for subsequent processing
@@ -231,35 +344,18 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 		reader := er.gzipPool.Get().(*gzip.Reader)
 		err := reader.Reset(bodyReader)
 		if err != nil {
-			er.failBadReq(ctx, w, http.StatusBadRequest, err)
-			er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), 0, err)
 			_, _ = io.ReadAll(r.Body)
 			_ = r.Body.Close()
-			return
+			return http.StatusBadRequest, err
 		}
 		bodyReader = reader
 		defer er.gzipPool.Put(reader)
 	}
 
-	// send body into a scanner and then convert the request body into a log
-	sc := bufio.NewScanner(bodyReader)
-	ld, numLogs, err := er.reqToLog(sc, r.Header, r.URL.Query())
-
+	// send body into a scanner and then convert the request body
+	status, err := consume(bufio.NewScanner(bodyReader))
 	_ = bodyReader.Close()
-
-	if err != nil {
-		er.failBadReq(ctx, w, http.StatusBadRequest, err)
-		er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), numLogs, err)
-		return
-	}
-
-	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
-	if consumerErr != nil {
-		er.failBadReq(ctx, w, http.StatusInternalServerError, consumerErr)
-	} else {
-		w.WriteHeader(http.StatusOK)
-	}
-	er.obsrecv.EndLogsOp(ctx, metadata.Type.String(), numLogs, consumerErr)
+	return status, err
 }
 
 // Simple healthcheck endpoint.
@@ -270,36 +366,6 @@ func (*eventReceiver) handleHealthCheck(w http.ResponseWriter, _ *http.Request,
 	_, _ = w.Write([]byte(healthyResponse))
 }
 
-// verifyHMACSignature verifies the HMAC-SHA256 hex digest signature of the request body.
-// The signature header value is expected to be in the format "<prefix><hex-digest>",
-// e.g. "sha256=abc123..." (GitHub) or "v1=abc123..." (Fingerprint).
-func (er *eventReceiver) verifyHMACSignature(payload []byte, signatureHeader string) error {
-	if signatureHeader == "" {
-		return errMissingSignatureHeader
-	}
-
-	prefix := er.cfg.HMACSignature.Prefix
-	if !strings.HasPrefix(signatureHeader, prefix) {
-		return fmt.Errorf("%w: expected prefix %q", errInvalidSignaturePrefix, prefix)
-	}
-
-	hexDigest := strings.TrimPrefix(signatureHeader, prefix)
-	sigBytes, err := hex.DecodeString(hexDigest)
-	if err != nil {
-		return fmt.Errorf("%w: %w", errInvalidSignatureEncoding, err)
-	}
-
-	mac := hmac.New(sha256.New, []byte(er.cfg.HMACSignature.Secret))
-	mac.Write(payload)
-	expectedMAC := mac.Sum(nil)
-
-	if !hmac.Equal(sigBytes, expectedMAC) {
-		return errSignatureMismatch
-	}
-
-	return nil
-}
-
 // write response on a failed/bad request. Generates a small json body based on the thrown by
 // the handle func and the appropriate http status code. many webhooks will either log these responses or
 // notify webhook users should a none 2xx code be detected.
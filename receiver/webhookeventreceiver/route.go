@@ -0,0 +1,637 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const (
+	metricTypeGauge = "gauge"
+	metricTypeSum   = "sum"
+)
+
+var spanKinds = map[string]ptrace.SpanKind{
+	"":         ptrace.SpanKindInternal,
+	"internal": ptrace.SpanKindInternal,
+	"server":   ptrace.SpanKindServer,
+	"client":   ptrace.SpanKindClient,
+	"producer": ptrace.SpanKindProducer,
+	"consumer": ptrace.SpanKindConsumer,
+}
+
+// RouteConfig maps the requests received on a path into metrics and spans. The body of the requests is
+// turned into log records like on the default path, then each log record is evaluated with OTTL.
+type RouteConfig struct {
+	// Path is the path the route accepts requests on.
+	Path string `mapstructure:"path"`
+	// Signature defines how the signature of the requests received on the route is verified.
+	Signature SignatureConfig `mapstructure:"signature"`
+	// Statements are OTTL statements executed on each log record before the metrics and spans are built,
+	// typically to parse the body into the cache, e.g. `set(log.cache, ParseJSON(log.body))`.
+	Statements []string `mapstructure:"statements"`
+	// Metrics are the metrics a data point is added to for each log record.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+	// Spans are the spans built from each log record.
+	Spans []SpanConfig `mapstructure:"spans"`
+	// ErrorMode determines how errors returned from OTTL evaluation are handled. Default propagate,
+	// which rejects the request.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// MetricConfig defines a metric built from the log records of a route.
+type MetricConfig struct {
+	// Name is the name of the metric.
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+	// Type is the type of the metric: "gauge" or "sum". Sums have a delta temporality. Default gauge.
+	Type string `mapstructure:"type"`
+	// Monotonic sets whether a sum is monotonic.
+	Monotonic bool `mapstructure:"monotonic"`
+	// Conditions are OTTL conditions. When set, a data point is only added for the log records matching any of them.
+	Conditions []string `mapstructure:"conditions"`
+	// Value is an OTTL value expression resolving to the int or double value of the data point. Log records
+	// for which it resolves to nil are skipped.
+	Value string `mapstructure:"value"`
+	// Timestamp is an optional OTTL value expression resolving to the time of the data point.
+	Timestamp string `mapstructure:"timestamp"`
+	// Attributes are the attributes of the data point.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// SpanConfig defines a span built from the log records of a route.
+type SpanConfig struct {
+	// Name is an OTTL value expression resolving to the name of the span.
+	Name string `mapstructure:"name"`
+	// Kind is the kind of the span: "internal", "server", "client", "producer" or "consumer". Default internal.
+	Kind string `mapstructure:"kind"`
+	// Conditions are OTTL conditions. When set, a span is only built for the log records matching any of them.
+	Conditions []string `mapstructure:"conditions"`
+	// StartTime and EndTime are optional OTTL value expressions resolving to the start and end times of the span.
+	StartTime string `mapstructure:"start_time"`
+	EndTime   string `mapstructure:"end_time"`
+	// TraceID, SpanID and ParentSpanID are optional OTTL value expressions resolving to the IDs of the span,
+	// either as bytes or hex strings. Random IDs are generated when the trace and span IDs are not set.
+	TraceID      string `mapstructure:"trace_id"`
+	SpanID       string `mapstructure:"span_id"`
+	ParentSpanID string `mapstructure:"parent_span_id"`
+	// Attributes are the attributes of the span.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+	// ErrorConditions are OTTL conditions. The span status is set to Error if any of them match, and to Ok otherwise.
+	ErrorConditions []string `mapstructure:"error_conditions"`
+	// StatusMessage is an optional OTTL value expression evaluated for failed spans.
+	StatusMessage string `mapstructure:"status_message"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AttributeConfig sets an attribute from an OTTL value expression.
+type AttributeConfig struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *RouteConfig) Validate() error {
+	var errs error
+	if cfg.Path == "" {
+		errs = multierr.Append(errs, errors.New("path is required"))
+	}
+	if len(cfg.Metrics) == 0 && len(cfg.Spans) == 0 {
+		errs = multierr.Append(errs, errors.New("at least one metric or span is required"))
+	}
+	for _, err := range multierr.Errors(cfg.Signature.Validate()) {
+		errs = multierr.Append(errs, fmt.Errorf("signature: %w", err))
+	}
+	for i, metric := range cfg.Metrics {
+		if metric.Name == "" {
+			errs = multierr.Append(errs, fmt.Errorf("metrics[%d]: name is required", i))
+		}
+		if metric.Value == "" {
+			errs = multierr.Append(errs, fmt.Errorf("metrics[%d]: value is required", i))
+		}
+		if metric.Type != "" && metric.Type != metricTypeGauge && metric.Type != metricTypeSum {
+			errs = multierr.Append(errs, fmt.Errorf("metrics[%d]: unsupported type %q", i, metric.Type))
+		}
+		if metric.Monotonic && metric.Type != metricTypeSum {
+			errs = multierr.Append(errs, fmt.Errorf("metrics[%d]: only sums can be monotonic", i))
+		}
+	}
+	for i, span := range cfg.Spans {
+		if span.Name == "" {
+			errs = multierr.Append(errs, fmt.Errorf("spans[%d]: name is required", i))
+		}
+		if _, ok := spanKinds[span.Kind]; !ok {
+			errs = multierr.Append(errs, fmt.Errorf("spans[%d]: unsupported kind %q", i, span.Kind))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	_, err := newRoute(*cfg, component.TelemetrySettings{Logger: zap.NewNop()})
+	return err
+}
+
+type valueExpression = *ottl.ValueExpression[*ottllog.TransformContext]
+
+type conditionSequence = *ottl.ConditionSequence[*ottllog.TransformContext]
+
+type attributeExpression struct {
+	key   string
+	value valueExpression
+}
+
+type metricBuilder struct {
+	cfg        MetricConfig
+	conditions conditionSequence
+	value      valueExpression
+	timestamp  valueExpression
+	attributes []attributeExpression
+}
+
+type spanBuilder struct {
+	kind            ptrace.SpanKind
+	conditions      conditionSequence
+	name            valueExpression
+	startTime       valueExpression
+	endTime         valueExpression
+	traceID         valueExpression
+	spanID          valueExpression
+	parentSpanID    valueExpression
+	attributes      []attributeExpression
+	errorConditions conditionSequence
+	statusMessage   valueExpression
+}
+
+// route holds the compiled OTTL of a RouteConfig.
+type route struct {
+	path       string
+	verifier   signatureVerifier
+	errorMode  ottl.ErrorMode
+	logger     *zap.Logger
+	statements ottl.StatementSequence[*ottllog.TransformContext]
+	metrics    []metricBuilder
+	spans      []spanBuilder
+}
+
+// routeParser parses the OTTL of a route, collecting the errors of all the fields.
+type routeParser struct {
+	parser    ottl.Parser[*ottllog.TransformContext]
+	set       component.TelemetrySettings
+	errorMode ottl.ErrorMode
+	errs      error
+}
+
+func (p *routeParser) valueExpression(field, expr string) valueExpression {
+	if expr == "" {
+		return nil
+	}
+	parsed, err := p.parser.ParseValueExpression(expr)
+	if err != nil {
+		p.errs = multierr.Append(p.errs, fmt.Errorf("%s: %w", field, err))
+	}
+	return parsed
+}
+
+func (p *routeParser) conditions(field string, conditions []string) conditionSequence {
+	if len(conditions) == 0 {
+		return nil
+	}
+	parsed, err := p.parser.ParseConditions(conditions)
+	if err != nil {
+		p.errs = multierr.Append(p.errs, fmt.Errorf("%s: %w", field, err))
+		return nil
+	}
+	seq := ottllog.NewConditionSequence(parsed, p.set, ottllog.WithConditionSequenceErrorMode(p.errorMode))
+	return &seq
+}
+
+func (p *routeParser) attributes(field string, attributes []AttributeConfig) []attributeExpression {
+	exprs := make([]attributeExpression, 0, len(attributes))
+	for i, attr := range attributes {
+		if attr.Key == "" || attr.Value == "" {
+			p.errs = multierr.Append(p.errs, fmt.Errorf("%s[%d]: key and value are required", field, i))
+			continue
+		}
+		exprs = append(exprs, attributeExpression{key: attr.Key, value: p.valueExpression(fmt.Sprintf("%s[%d]", field, i), attr.Value)})
+	}
+	return exprs
+}
+
+func newRoute(cfg RouteConfig, set component.TelemetrySettings) (*route, error) {
+	errorMode := cfg.ErrorMode
+	if errorMode == "" {
+		errorMode = ottl.PropagateError
+	}
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[*ottllog.TransformContext](), set, ottllog.EnablePathContextNames())
+	if err != nil {
+		return nil, err
+	}
+	p := &routeParser{parser: parser, set: set, errorMode: errorMode}
+
+	r := &route{
+		path:      cfg.Path,
+		verifier:  newSignatureVerifier(cfg.Signature),
+		errorMode: errorMode,
+		logger:    set.Logger,
+	}
+	statements, err := parser.ParseStatements(cfg.Statements)
+	if err != nil {
+		p.errs = multierr.Append(p.errs, fmt.Errorf("statements: %w", err))
+	}
+	r.statements = ottllog.NewStatementSequence(statements, set, ottllog.WithStatementSequenceErrorMode(errorMode))
+
+	for i, metric := range cfg.Metrics {
+		field := fmt.Sprintf("metrics[%d]", i)
+		r.metrics = append(r.metrics, metricBuilder{
+			cfg:        metric,
+			conditions: p.conditions(field+".conditions", metric.Conditions),
+			value:      p.valueExpression(field+".value", metric.Value),
+			timestamp:  p.valueExpression(field+".timestamp", metric.Timestamp),
+			attributes: p.attributes(field+".attributes", metric.Attributes),
+		})
+	}
+	for i, span := range cfg.Spans {
+		field := fmt.Sprintf("spans[%d]", i)
+		r.spans = append(r.spans, spanBuilder{
+			kind:            spanKinds[span.Kind],
+			conditions:      p.conditions(field+".conditions", span.Conditions),
+			name:            p.valueExpression(field+".name", span.Name),
+			startTime:       p.valueExpression(field+".start_time", span.StartTime),
+			endTime:         p.valueExpression(field+".end_time", span.EndTime),
+			traceID:         p.valueExpression(field+".trace_id", span.TraceID),
+			spanID:          p.valueExpression(field+".span_id", span.SpanID),
+			parentSpanID:    p.valueExpression(field+".parent_span_id", span.ParentSpanID),
+			attributes:      p.attributes(field+".attributes", span.Attributes),
+			errorConditions: p.conditions(field+".error_conditions", span.ErrorConditions),
+			statusMessage:   p.valueExpression(field+".status_message", span.StatusMessage),
+		})
+	}
+	if p.errs != nil {
+		return nil, p.errs
+	}
+	return r, nil
+}
+
+// evaluate builds the metrics and spans of the route from the log records of a request, which share a
+// single resource and scope. The metrics or spans are only built when withMetrics or withTraces is set.
+func (r *route) evaluate(ctx context.Context, ld plog.Logs, withMetrics, withTraces bool) (pmetric.Metrics, ptrace.Traces, error) {
+	md := pmetric.NewMetrics()
+	td := ptrace.NewTraces()
+	resourceLogs := ld.ResourceLogs().At(0)
+	scopeLogs := resourceLogs.ScopeLogs().At(0)
+	metrics := make([]pmetric.Metric, len(r.metrics))
+	var spans ptrace.SpanSlice
+	for i := 0; i < scopeLogs.LogRecords().Len(); i++ {
+		tCtx := ottllog.NewTransformContextPtr(resourceLogs, scopeLogs, scopeLogs.LogRecords().At(i))
+		err := r.statements.Execute(ctx, tCtx)
+		if err == nil && withMetrics {
+			err = r.addDataPoints(ctx, tCtx, md, metrics)
+		}
+		if err == nil && withTraces {
+			err = r.addSpans(ctx, tCtx, td, &spans)
+		}
+		tCtx.Close()
+		if err != nil {
+			return md, td, err
+		}
+	}
+	return md, td, nil
+}
+
+// addDataPoints adds the data points of the log record to the metrics, which are created in md on their first data point.
+func (r *route) addDataPoints(ctx context.Context, tCtx *ottllog.TransformContext, md pmetric.Metrics, metrics []pmetric.Metric) error {
+	for i := range r.metrics {
+		mb := &r.metrics[i]
+		ok, err := r.matches(ctx, mb.conditions, tCtx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		value, err := r.eval(ctx, mb.value, tCtx)
+		if err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int64, float64:
+		case bool:
+			value = boolToInt(v)
+		default:
+			if err = r.handleError(fmt.Errorf("metric %q: value must be an int or a double, got %T", mb.cfg.Name, value)); err != nil {
+				return err
+			}
+			continue
+		}
+		timestamp, err := r.evalTimestamp(ctx, mb.timestamp, tCtx)
+		if err != nil {
+			return err
+		}
+
+		if metrics[i] == (pmetric.Metric{}) {
+			metrics[i] = newMetric(md, tCtx, mb.cfg)
+		}
+		var dp pmetric.NumberDataPoint
+		if metrics[i].Type() == pmetric.MetricTypeSum {
+			dp = metrics[i].Sum().DataPoints().AppendEmpty()
+		} else {
+			dp = metrics[i].Gauge().DataPoints().AppendEmpty()
+		}
+		if v, isInt := value.(int64); isInt {
+			dp.SetIntValue(v)
+		} else {
+			dp.SetDoubleValue(value.(float64))
+		}
+		dp.SetTimestamp(timestamp)
+		if err = r.setAttributes(ctx, tCtx, mb.attributes, dp.Attributes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newMetric creates a metric in md, in a resource and scope copied from those of the log record.
+func newMetric(md pmetric.Metrics, tCtx *ottllog.TransformContext, cfg MetricConfig) pmetric.Metric {
+	if md.ResourceMetrics().Len() == 0 {
+		rm := md.ResourceMetrics().AppendEmpty()
+		tCtx.GetResource().CopyTo(rm.Resource())
+		tCtx.GetInstrumentationScope().CopyTo(rm.ScopeMetrics().AppendEmpty().Scope())
+	}
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+	metric.SetName(cfg.Name)
+	metric.SetDescription(cfg.Description)
+	metric.SetUnit(cfg.Unit)
+	if cfg.Type == metricTypeSum {
+		metric.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		metric.Sum().SetIsMonotonic(cfg.Monotonic)
+	} else {
+		metric.SetEmptyGauge()
+	}
+	return metric
+}
+
+// addSpans adds the spans of the log record to spans, which is created in td on the first span.
+func (r *route) addSpans(ctx context.Context, tCtx *ottllog.TransformContext, td ptrace.Traces, spans *ptrace.SpanSlice) error {
+	for i := range r.spans {
+		sb := &r.spans[i]
+		ok, err := r.matches(ctx, sb.conditions, tCtx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		name, err := r.eval(ctx, sb.name, tCtx)
+		if err != nil {
+			return err
+		}
+		if name == nil {
+			continue
+		}
+
+		if *spans == (ptrace.SpanSlice{}) {
+			rs := td.ResourceSpans().AppendEmpty()
+			tCtx.GetResource().CopyTo(rs.Resource())
+			ss := rs.ScopeSpans().AppendEmpty()
+			tCtx.GetInstrumentationScope().CopyTo(ss.Scope())
+			*spans = ss.Spans()
+		}
+		span := spans.AppendEmpty()
+		span.SetName(valueToString(name))
+		span.SetKind(sb.kind)
+
+		startTime, err := r.evalTimestamp(ctx, sb.startTime, tCtx)
+		if err != nil {
+			return err
+		}
+		span.SetStartTimestamp(startTime)
+		endTime := startTime
+		if sb.endTime != nil {
+			if endTime, err = r.evalTimestamp(ctx, sb.endTime, tCtx); err != nil {
+				return err
+			}
+		}
+		span.SetEndTimestamp(endTime)
+
+		if err = r.setIDs(ctx, sb, tCtx, span); err != nil {
+			return err
+		}
+		if err = r.setAttributes(ctx, tCtx, sb.attributes, span.Attributes()); err != nil {
+			return err
+		}
+
+		isError := false
+		if sb.errorConditions != nil {
+			if isError, err = sb.errorConditions.Eval(ctx, tCtx); err != nil {
+				return err
+			}
+		}
+		if !isError {
+			span.Status().SetCode(ptrace.StatusCodeOk)
+			continue
+		}
+		span.Status().SetCode(ptrace.StatusCodeError)
+		msg, err := r.eval(ctx, sb.statusMessage, tCtx)
+		if err != nil {
+			return err
+		}
+		if msg != nil {
+			span.Status().SetMessage(valueToString(msg))
+		}
+	}
+	return nil
+}
+
+func (r *route) setIDs(ctx context.Context, sb *spanBuilder, tCtx *ottllog.TransformContext, span ptrace.Span) error {
+	var traceID pcommon.TraceID
+	if err := r.evalID(ctx, sb.traceID, tCtx, traceID[:]); err != nil {
+		return err
+	}
+	if traceID.IsEmpty() {
+		_, _ = rand.Read(traceID[:])
+	}
+	span.SetTraceID(traceID)
+
+	var spanID pcommon.SpanID
+	if err := r.evalID(ctx, sb.spanID, tCtx, spanID[:]); err != nil {
+		return err
+	}
+	if spanID.IsEmpty() {
+		_, _ = rand.Read(spanID[:])
+	}
+	span.SetSpanID(spanID)
+
+	var parentSpanID pcommon.SpanID
+	if err := r.evalID(ctx, sb.parentSpanID, tCtx, parentSpanID[:]); err != nil {
+		return err
+	}
+	span.SetParentSpanID(parentSpanID)
+	return nil
+}
+
+// evalID evaluates expr into id, which is left empty when expr is not set or resolves to nil.
+func (r *route) evalID(ctx context.Context, expr valueExpression, tCtx *ottllog.TransformContext, id []byte) error {
+	val, err := r.eval(ctx, expr, tCtx)
+	if err != nil || val == nil {
+		return err
+	}
+	var b []byte
+	switch v := val.(type) {
+	case pcommon.TraceID:
+		b = v[:]
+	case pcommon.SpanID:
+		b = v[:]
+	case []byte:
+		b = v
+	case string:
+		if b, err = hex.DecodeString(v); err != nil {
+			return r.handleError(fmt.Errorf("invalid hex ID %q: %w", v, err))
+		}
+	default:
+		return r.handleError(fmt.Errorf("ID must be bytes or a hex string, got %T", val))
+	}
+	if len(b) != len(id) {
+		return r.handleError(fmt.Errorf("ID must be %d bytes long, got %d", len(id), len(b)))
+	}
+	copy(id, b)
+	return nil
+}
+
+// evalTimestamp evaluates expr into a timestamp. It defaults to the time of the log record when expr is not set,
+// or to its observed time when the time is not set either.
+func (r *route) evalTimestamp(ctx context.Context, expr valueExpression, tCtx *ottllog.TransformContext) (pcommon.Timestamp, error) {
+	defaultTimestamp := tCtx.GetLogRecord().Timestamp()
+	if defaultTimestamp == 0 {
+		defaultTimestamp = tCtx.GetLogRecord().ObservedTimestamp()
+	}
+	val, err := r.eval(ctx, expr, tCtx)
+	if err != nil || val == nil {
+		return defaultTimestamp, err
+	}
+	switch v := val.(type) {
+	case time.Time:
+		return pcommon.NewTimestampFromTime(v), nil
+	case int64:
+		// Unix time in nanoseconds, as returned by the UnixNano converter
+		return pcommon.Timestamp(v), nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return defaultTimestamp, r.handleError(fmt.Errorf("invalid timestamp %q: %w", v, err))
+		}
+		return pcommon.NewTimestampFromTime(t), nil
+	default:
+		return defaultTimestamp, r.handleError(fmt.Errorf("timestamp must be a time, unix nanoseconds or an RFC 3339 string, got %T", val))
+	}
+}
+
+func (r *route) setAttributes(ctx context.Context, tCtx *ottllog.TransformContext, attributes []attributeExpression, attrs pcommon.Map) error {
+	for _, attr := range attributes {
+		val, err := r.eval(ctx, attr.value, tCtx)
+		if err != nil {
+			return err
+		}
+		if val == nil {
+			continue
+		}
+		if err = putValue(attrs, attr.key, val); err != nil {
+			return r.handleError(fmt.Errorf("failed to set attribute %q: %w", attr.key, err))
+		}
+	}
+	return nil
+}
+
+// matches reports whether the log record matches the conditions, which always match when they are not set.
+func (*route) matches(ctx context.Context, conditions conditionSequence, tCtx *ottllog.TransformContext) (bool, error) {
+	if conditions == nil {
+		return true, nil
+	}
+	return conditions.Eval(ctx, tCtx)
+}
+
+// eval evaluates expr, which resolves to nil when it is not set.
+func (r *route) eval(ctx context.Context, expr valueExpression, tCtx *ottllog.TransformContext) (any, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	val, err := expr.Eval(ctx, tCtx)
+	if err != nil {
+		return nil, r.handleError(fmt.Errorf("failed to evaluate %q: %w", expr, err))
+	}
+	return val, nil
+}
+
+// handleError returns err when errors are propagated. Otherwise it is logged, and the value
+// being built is left unset.
+func (r *route) handleError(err error) error {
+	switch r.errorMode {
+	case ottl.PropagateError:
+		return err
+	case ottl.IgnoreError:
+		r.logger.Warn("failed to evaluate webhook route", zap.String("path", r.path), zap.Error(err))
+	}
+	return nil
+}
+
+func putValue(m pcommon.Map, key string, val any) error {
+	switch typed := val.(type) {
+	case pcommon.Value:
+		typed.CopyTo(m.PutEmpty(key))
+	case pcommon.Map:
+		typed.CopyTo(m.PutEmptyMap(key))
+	case pcommon.Slice:
+		typed.CopyTo(m.PutEmptySlice(key))
+	case pcommon.ByteSlice:
+		typed.CopyTo(m.PutEmptyBytes(key))
+	default:
+		return m.PutEmpty(key).FromRaw(typed)
+	}
+	return nil
+}
+
+func valueToString(val any) string {
+	if str, ok := val.(string); ok {
+		return str
+	}
+	m := pcommon.NewMap()
+	if err := putValue(m, "", val); err != nil {
+		return fmt.Sprint(val)
+	}
+	v, _ := m.Get("")
+	return v.AsString()
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
+)
+
+const ciBuilds = `{"pipeline": "deploy", "status": "success", "duration": 12.5, "started_at": "2026-01-02T10:00:00Z", "finished_at": "2026-01-02T10:00:12.5Z", "trace_id": "0102030405060708090a0b0c0d0e0f10"}
+{"pipeline": "test", "status": "failed", "duration": 3, "error": "exit code 1", "started_at": "2026-01-02T10:01:00Z", "finished_at": "2026-01-02T10:01:03Z"}`
+
+func ciRoute() RouteConfig {
+	return RouteConfig{
+		Path:       "/ci",
+		Statements: []string{`set(log.cache, ParseJSON(log.body))`},
+		Metrics: []MetricConfig{
+			{
+				Name:      "ci.build.duration",
+				Unit:      "s",
+				Value:     `log.cache["duration"]`,
+				Timestamp: `log.cache["finished_at"]`,
+				Attributes: []AttributeConfig{
+					{Key: "ci.pipeline", Value: `log.cache["pipeline"]`},
+				},
+			},
+			{
+				Name:       "ci.build.failures",
+				Type:       "sum",
+				Monotonic:  true,
+				Conditions: []string{`log.cache["status"] == "failed"`},
+				Value:      "1",
+			},
+		},
+		Spans: []SpanConfig{
+			{
+				Name:            `log.cache["pipeline"]`,
+				Kind:            "server",
+				StartTime:       `log.cache["started_at"]`,
+				EndTime:         `log.cache["finished_at"]`,
+				TraceID:         `log.cache["trace_id"]`,
+				Attributes:      []AttributeConfig{{Key: "ci.status", Value: `log.cache["status"]`}},
+				ErrorConditions: []string{`log.cache["status"] == "failed"`},
+				StatusMessage:   `log.cache["error"]`,
+			},
+		},
+	}
+}
+
+// startRouteReceiver creates the receiver of cfg in metrics and traces pipelines
+func startRouteReceiver(t *testing.T, cfg *Config) (*eventReceiver, *consumertest.MetricsSink, *consumertest.TracesSink) {
+	cfg.ServerConfig.NetAddr.Endpoint = "localhost:0"
+	cfg.SplitLogsAtNewLine = true
+	metricsSink := new(consumertest.MetricsSink)
+	tracesSink := new(consumertest.TracesSink)
+
+	mr, err := createMetricsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, metricsSink)
+	require.NoError(t, err)
+	tr, err := createTracesReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, tracesSink)
+	require.NoError(t, err)
+	require.Same(t, mr, tr)
+
+	require.NoError(t, mr.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, mr.Shutdown(t.Context()))
+	})
+	return mr.(*sharedcomponent.SharedComponent).Unwrap().(*eventReceiver), metricsSink, tracesSink
+}
+
+func postRoute(er *eventReceiver, body string, headers map[string]string) *http.Response {
+	req := httptest.NewRequest(http.MethodPost, "http://localhost/ci?ci.system=buildkite", strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	er.handleRouteReq(er.routes[0])(w, req, nil)
+	return w.Result()
+}
+
+func TestRouteMetricsAndSpans(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Routes = []RouteConfig{ciRoute()}
+	er, metricsSink, tracesSink := startRouteReceiver(t, cfg)
+
+	require.Equal(t, http.StatusOK, postRoute(er, ciBuilds, nil).StatusCode)
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	md := metricsSink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"ci.system": "buildkite"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeLogName, rm.ScopeMetrics().At(0).Scope().Name())
+
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	duration := metrics.At(0)
+	assert.Equal(t, "ci.build.duration", duration.Name())
+	assert.Equal(t, "s", duration.Unit())
+	require.Equal(t, pmetric.MetricTypeGauge, duration.Type())
+	require.Equal(t, 2, duration.Gauge().DataPoints().Len())
+	dp := duration.Gauge().DataPoints().At(0)
+	assert.Equal(t, 12.5, dp.DoubleValue())
+	assert.Equal(t, time.Date(2026, 1, 2, 10, 0, 12, 5e8, time.UTC), dp.Timestamp().AsTime())
+	assert.Equal(t, map[string]any{"ci.pipeline": "deploy"}, dp.Attributes().AsRaw())
+	// JSON numbers are parsed as doubles
+	assert.Equal(t, 3.0, duration.Gauge().DataPoints().At(1).DoubleValue())
+
+	failures := metrics.At(1)
+	assert.Equal(t, "ci.build.failures", failures.Name())
+	require.Equal(t, pmetric.MetricTypeSum, failures.Type())
+	assert.True(t, failures.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, failures.Sum().AggregationTemporality())
+	require.Equal(t, 1, failures.Sum().DataPoints().Len())
+	assert.Equal(t, int64(1), failures.Sum().DataPoints().At(0).IntValue())
+	// Data points default to the time the request was received
+	assert.NotZero(t, failures.Sum().DataPoints().At(0).Timestamp())
+
+	require.Len(t, tracesSink.AllTraces(), 1)
+	spans := tracesSink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	deploy := spans.At(0)
+	assert.Equal(t, "deploy", deploy.Name())
+	assert.Equal(t, ptrace.SpanKindServer, deploy.Kind())
+	assert.Equal(t, pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, deploy.TraceID())
+	assert.False(t, deploy.SpanID().IsEmpty())
+	assert.True(t, deploy.ParentSpanID().IsEmpty())
+	assert.Equal(t, 12500*time.Millisecond, deploy.EndTimestamp().AsTime().Sub(deploy.StartTimestamp().AsTime()))
+	assert.Equal(t, ptrace.StatusCodeOk, deploy.Status().Code())
+	assert.Equal(t, map[string]any{"ci.status": "success"}, deploy.Attributes().AsRaw())
+
+	test := spans.At(1)
+	assert.Equal(t, "test", test.Name())
+	assert.False(t, test.TraceID().IsEmpty())
+	assert.NotEqual(t, deploy.TraceID(), test.TraceID())
+	assert.Equal(t, ptrace.StatusCodeError, test.Status().Code())
+	assert.Equal(t, "exit code 1", test.Status().Message())
+}
+
+func TestRouteWithoutTracesPipeline(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig.NetAddr.Endpoint = "localhost:0"
+	cfg.Routes = []RouteConfig{ciRoute()}
+	sink := new(consumertest.MetricsSink)
+	r, err := createMetricsReceiver(t.Context(), receivertest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(t.Context()))
+	}()
+
+	er := r.(*sharedcomponent.SharedComponent).Unwrap().(*eventReceiver)
+	require.Equal(t, http.StatusOK, postRoute(er, strings.Split(ciBuilds, "\n")[0], nil).StatusCode)
+	require.Equal(t, 1, sink.DataPointCount())
+}
+
+func TestRouteErrorMode(t *testing.T) {
+	tests := []struct {
+		desc       string
+		errorMode  ottl.ErrorMode
+		status     int
+		dataPoints int
+	}{
+		{
+			desc:   "propagate",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:      "ignore",
+			errorMode: ottl.IgnoreError,
+			status:    http.StatusOK,
+			// the duration of the first build and the failure of the second one
+			dataPoints: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			route := ciRoute()
+			route.ErrorMode = test.errorMode
+			route.Spans = nil
+			cfg := createDefaultConfig().(*Config)
+			cfg.Routes = []RouteConfig{route}
+			er, metricsSink, _ := startRouteReceiver(t, cfg)
+
+			// The duration of the second build is not a number, and its finish time is invalid
+			body := `{"pipeline": "deploy", "duration": 1}` + "\n" + `{"pipeline": "test", "duration": "long", "finished_at": "yesterday", "status": "failed"}`
+			require.Equal(t, test.status, postRoute(er, body, nil).StatusCode)
+			require.Equal(t, test.dataPoints, metricsSink.DataPointCount())
+		})
+	}
+}
+
+func TestRouteSignature(t *testing.T) {
+	route := ciRoute()
+	route.Signature = SignatureConfig{Scheme: "stripe", Secret: "stripe-secret"}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Routes = []RouteConfig{route}
+	er, metricsSink, _ := startRouteReceiver(t, cfg)
+
+	body := strings.Split(ciBuilds, "\n")[0]
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := "t=" + ts + ",v1=" + computeHMACSHA256("stripe-secret", ts+"."+body)
+
+	require.Equal(t, http.StatusUnauthorized, postRoute(er, body, nil).StatusCode)
+	require.Equal(t, http.StatusOK, postRoute(er, body, map[string]string{"Stripe-Signature": signature}).StatusCode)
+	// The same delivery is rejected when it is replayed
+	require.Equal(t, http.StatusUnauthorized, postRoute(er, body, map[string]string{"Stripe-Signature": signature}).StatusCode)
+	require.Equal(t, 1, metricsSink.DataPointCount())
+}
+
+func TestRouteInheritsSignature(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Signature = SignatureConfig{Scheme: "stripe", Secret: "stripe-secret"}
+	cfg.Routes = []RouteConfig{ciRoute()}
+	er, metricsSink, _ := startRouteReceiver(t, cfg)
+
+	body := strings.Split(ciBuilds, "\n")[0]
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := "t=" + ts + ",v1=" + computeHMACSHA256("stripe-secret", ts+"."+body)
+
+	require.Equal(t, http.StatusUnauthorized, postRoute(er, body, nil).StatusCode)
+	require.Equal(t, http.StatusOK, postRoute(er, body, map[string]string{"Stripe-Signature": signature}).StatusCode)
+	require.Equal(t, 1, metricsSink.DataPointCount())
+}
+
+func TestRouteInheritsHMACSignature(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HMACSignature = HMACSignature{Secret: "github-secret", Header: "X-Hub-Signature-256", Prefix: "sha256="}
+	cfg.Routes = []RouteConfig{ciRoute()}
+	er, metricsSink, _ := startRouteReceiver(t, cfg)
+
+	body := strings.Split(ciBuilds, "\n")[0]
+
+	require.Equal(t, http.StatusUnauthorized, postRoute(er, body, nil).StatusCode)
+	require.Equal(t, http.StatusOK, postRoute(er, body, map[string]string{"X-Hub-Signature-256": "sha256=" + computeHMACSHA256("github-secret", body)}).StatusCode)
+	require.Equal(t, 1, metricsSink.DataPointCount())
+}
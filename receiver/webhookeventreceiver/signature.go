@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.uber.org/multierr"
+)
+
+const (
+	// signatureSchemeHMAC verifies a "<prefix><hex digest>" header computed over the body,
+	// as sent by GitHub or Fingerprint.
+	signatureSchemeHMAC = "hmac"
+	// signatureSchemeStripe verifies a "t=<timestamp>,v1=<hex digest>" header computed over
+	// "<timestamp>.<body>", as sent by Stripe.
+	signatureSchemeStripe = "stripe"
+	// signatureSchemeSlack verifies a "v0=<hex digest>" header computed over "v0:<timestamp>:<body>",
+	// with the timestamp sent in a separate header, as sent by Slack.
+	signatureSchemeSlack = "slack"
+
+	defaultSignatureTolerance = 5 * time.Minute
+)
+
+var (
+	errMissingSignatureTimestamp = errors.New("missing signature timestamp")
+	errInvalidSignatureTimestamp = errors.New("signature timestamp is invalid")
+	errSignatureOutsideTolerance = errors.New("signature timestamp is outside of the tolerance window")
+	errSignatureReplayed         = errors.New("signature was already received")
+)
+
+// signatureSchemes holds the constructors of the verifiers of the supported signature schemes.
+var signatureSchemes = map[string]func(SignatureConfig) signatureVerifier{
+	signatureSchemeHMAC: func(cfg SignatureConfig) signatureVerifier {
+		return &hmacVerifier{secret: []byte(cfg.Secret), header: cfg.Header, prefix: cfg.Prefix}
+	},
+	signatureSchemeStripe: func(cfg SignatureConfig) signatureVerifier {
+		return &timestampedHMACVerifier{
+			secret:        []byte(cfg.Secret),
+			parse:         parseStripeSignature(cfg.headerOrDefault("Stripe-Signature")),
+			signedPayload: func(timestamp string, body []byte) []byte { return slices.Concat([]byte(timestamp+"."), body) },
+			replay:        newReplayGuard(cfg.toleranceOrDefault()),
+		}
+	},
+	signatureSchemeSlack: func(cfg SignatureConfig) signatureVerifier {
+		return &timestampedHMACVerifier{
+			secret:        []byte(cfg.Secret),
+			parse:         parseSlackSignature(cfg.headerOrDefault("X-Slack-Signature"), cfg.timestampHeaderOrDefault("X-Slack-Request-Timestamp")),
+			signedPayload: func(timestamp string, body []byte) []byte { return slices.Concat([]byte("v0:"+timestamp+":"), body) },
+			replay:        newReplayGuard(cfg.toleranceOrDefault()),
+		}
+	},
+}
+
+// SignatureConfig defines how the signature of the requests is verified.
+type SignatureConfig struct {
+	// Scheme is the signature scheme used by the webhook provider: "hmac", "stripe" or "slack".
+	Scheme string `mapstructure:"scheme"`
+	// Secret is the shared secret used to compute the HMAC-SHA256 digest.
+	Secret configopaque.String `mapstructure:"secret"`
+	// Header is the HTTP header containing the signature. It is required by the hmac scheme, the other
+	// schemes default to the header used by their provider.
+	Header string `mapstructure:"header"`
+	// Prefix is the prefix before the hex digest in the header value of the hmac scheme (e.g. "sha256=").
+	Prefix string `mapstructure:"prefix"`
+	// TimestampHeader is the HTTP header containing the timestamp of the slack scheme. Default "X-Slack-Request-Timestamp".
+	TimestampHeader string `mapstructure:"timestamp_header"`
+	// Tolerance is the maximum difference between the signature timestamp and the current time for the
+	// timestamped schemes. Signatures are also rejected when they are received twice within this window. Default 5m.
+	Tolerance time.Duration `mapstructure:"tolerance"`
+}
+
+func (cfg *SignatureConfig) configured() bool {
+	return *cfg != SignatureConfig{}
+}
+
+func (cfg *SignatureConfig) Validate() error {
+	if !cfg.configured() {
+		return nil
+	}
+
+	var errs error
+	if _, ok := signatureSchemes[cfg.Scheme]; !ok {
+		errs = multierr.Append(errs, fmt.Errorf("unsupported scheme %q", cfg.Scheme))
+	}
+	if cfg.Secret == "" {
+		errs = multierr.Append(errs, errors.New("secret is required"))
+	}
+	if cfg.Scheme == signatureSchemeHMAC {
+		if cfg.Header == "" {
+			errs = multierr.Append(errs, errors.New("header is required by the hmac scheme"))
+		}
+		if cfg.Prefix == "" {
+			errs = multierr.Append(errs, errors.New("prefix is required by the hmac scheme"))
+		}
+	}
+	if cfg.Tolerance < 0 {
+		errs = multierr.Append(errs, errors.New("tolerance must not be negative"))
+	}
+	return errs
+}
+
+func (cfg *SignatureConfig) headerOrDefault(header string) string {
+	if cfg.Header != "" {
+		return cfg.Header
+	}
+	return header
+}
+
+func (cfg *SignatureConfig) timestampHeaderOrDefault(header string) string {
+	if cfg.TimestampHeader != "" {
+		return cfg.TimestampHeader
+	}
+	return header
+}
+
+func (cfg *SignatureConfig) toleranceOrDefault() time.Duration {
+	if cfg.Tolerance != 0 {
+		return cfg.Tolerance
+	}
+	return defaultSignatureTolerance
+}
+
+// signatureVerifier verifies the signature of the raw body of a request.
+type signatureVerifier interface {
+	verify(header http.Header, body []byte) error
+}
+
+// newSignatureVerifier returns the verifier of the configured scheme, or nil when no signature is configured.
+func newSignatureVerifier(cfg SignatureConfig) signatureVerifier {
+	if !cfg.configured() {
+		return nil
+	}
+	return signatureSchemes[cfg.Scheme](cfg)
+}
+
+// hmacVerifier verifies an HMAC-SHA256 hex digest of the body.
+// The signature header value is expected to be in the format "<prefix><hex-digest>",
+// e.g. "sha256=abc123..." (GitHub) or "v1=abc123..." (Fingerprint).
+type hmacVerifier struct {
+	secret []byte
+	header string
+	prefix string
+}
+
+func (v *hmacVerifier) verify(header http.Header, body []byte) error {
+	signatureHeader := header.Get(v.header)
+	if signatureHeader == "" {
+		return errMissingSignatureHeader
+	}
+
+	if !strings.HasPrefix(signatureHeader, v.prefix) {
+		return fmt.Errorf("%w: expected prefix %q", errInvalidSignaturePrefix, v.prefix)
+	}
+
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(signatureHeader, v.prefix))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignatureEncoding, err)
+	}
+
+	if !hmac.Equal(sigBytes, computeHMAC(v.secret, body)) {
+		return errSignatureMismatch
+	}
+	return nil
+}
+
+// timestampedHMACVerifier verifies HMAC-SHA256 hex digests computed over a payload that includes
+// a timestamp, and rejects the signatures that are too old or that were already received.
+type timestampedHMACVerifier struct {
+	secret []byte
+	// parse returns the timestamp and the hex digests of the request. A request matches when any of the
+	// digests is valid, which lets providers sign with several secrets while they are rotated.
+	parse         func(header http.Header) (string, []string, error)
+	signedPayload func(timestamp string, body []byte) []byte
+	replay        *replayGuard
+}
+
+func (v *timestampedHMACVerifier) verify(header http.Header, body []byte) error {
+	timestamp, signatures, err := v.parse(header)
+	if err != nil {
+		return err
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", errInvalidSignatureTimestamp, timestamp)
+	}
+
+	expectedMAC := computeHMAC(v.secret, v.signedPayload(timestamp, body))
+	for _, signature := range signatures {
+		sigBytes, err := hex.DecodeString(signature)
+		if err != nil || !hmac.Equal(sigBytes, expectedMAC) {
+			continue
+		}
+		// The decoded digest identifies the signature, hex digits differing only by case
+		// must not pass as a new signature.
+		return v.replay.check(time.Unix(seconds, 0), string(sigBytes))
+	}
+	return errSignatureMismatch
+}
+
+func parseStripeSignature(signatureHeader string) func(http.Header) (string, []string, error) {
+	return func(header http.Header) (string, []string, error) {
+		value := header.Get(signatureHeader)
+		if value == "" {
+			return "", nil, errMissingSignatureHeader
+		}
+		var timestamp string
+		var signatures []string
+		for item := range strings.SplitSeq(value, ",") {
+			key, val, _ := strings.Cut(strings.TrimSpace(item), "=")
+			switch key {
+			case "t":
+				timestamp = val
+			case "v1":
+				signatures = append(signatures, val)
+			}
+		}
+		if timestamp == "" {
+			return "", nil, errMissingSignatureTimestamp
+		}
+		if len(signatures) == 0 {
+			return "", nil, fmt.Errorf("%w: expected a v1 signature", errInvalidSignaturePrefix)
+		}
+		return timestamp, signatures, nil
+	}
+}
+
+func parseSlackSignature(signatureHeader, timestampHeader string) func(http.Header) (string, []string, error) {
+	return func(header http.Header) (string, []string, error) {
+		value := header.Get(signatureHeader)
+		if value == "" {
+			return "", nil, errMissingSignatureHeader
+		}
+		signature, ok := strings.CutPrefix(value, "v0=")
+		if !ok {
+			return "", nil, fmt.Errorf("%w: expected prefix %q", errInvalidSignaturePrefix, "v0=")
+		}
+		timestamp := header.Get(timestampHeader)
+		if timestamp == "" {
+			return "", nil, errMissingSignatureTimestamp
+		}
+		return timestamp, []string{signature}, nil
+	}
+}
+
+func computeHMAC(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// replayGuard rejects signatures whose timestamp is outside of the tolerance window, and signatures
+// that were already received. Signatures are remembered until their timestamp leaves the window.
+type replayGuard struct {
+	tolerance time.Duration
+	now       func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newReplayGuard(tolerance time.Duration) *replayGuard {
+	return &replayGuard{
+		tolerance: tolerance,
+		now:       time.Now,
+		seen:      map[string]time.Time{},
+	}
+}
+
+func (g *replayGuard) check(timestamp time.Time, signature string) error {
+	now := g.now()
+	if timestamp.Before(now.Add(-g.tolerance)) || timestamp.After(now.Add(g.tolerance)) {
+		return fmt.Errorf("%w of %s", errSignatureOutsideTolerance, g.tolerance)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for sig, expires := range g.seen {
+		if now.After(expires) {
+			delete(g.seen, sig)
+		}
+	}
+	if _, ok := g.seen[signature]; ok {
+		return errSignatureReplayed
+	}
+	g.seen[signature] = timestamp.Add(g.tolerance)
+	return nil
+}
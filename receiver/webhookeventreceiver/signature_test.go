@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripeSignature(t *testing.T) {
+	body := `{"type": "charge.succeeded"}`
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	validSig := computeHMACSHA256("stripe-secret", ts+"."+body)
+
+	tests := []struct {
+		desc   string
+		header string
+		now    time.Time
+		err    error
+	}{
+		{
+			desc:   "valid signature",
+			header: "t=" + ts + ",v1=" + validSig,
+			now:    now,
+		},
+		{
+			desc:   "valid signature among rotated secrets",
+			header: "t=" + ts + ",v1=" + computeHMACSHA256("old-secret", ts+"."+body) + ",v1=" + validSig + ",v0=abc",
+			now:    now.Add(time.Minute),
+		},
+		{
+			desc: "missing header",
+			now:  now,
+			err:  errMissingSignatureHeader,
+		},
+		{
+			desc:   "missing timestamp",
+			header: "v1=" + validSig,
+			now:    now,
+			err:    errMissingSignatureTimestamp,
+		},
+		{
+			desc:   "missing v1 signature",
+			header: "t=" + ts + ",v0=" + validSig,
+			now:    now,
+			err:    errInvalidSignaturePrefix,
+		},
+		{
+			desc:   "invalid timestamp",
+			header: "t=yesterday,v1=" + validSig,
+			now:    now,
+			err:    errInvalidSignatureTimestamp,
+		},
+		{
+			desc:   "signature of another timestamp",
+			header: "t=" + strconv.FormatInt(now.Unix()+1, 10) + ",v1=" + validSig,
+			now:    now,
+			err:    errSignatureMismatch,
+		},
+		{
+			desc:   "too old",
+			header: "t=" + ts + ",v1=" + validSig,
+			now:    now.Add(6 * time.Minute),
+			err:    errSignatureOutsideTolerance,
+		},
+		{
+			desc:   "too far in the future",
+			header: "t=" + ts + ",v1=" + validSig,
+			now:    now.Add(-6 * time.Minute),
+			err:    errSignatureOutsideTolerance,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := newSignatureVerifier(SignatureConfig{Scheme: "stripe", Secret: "stripe-secret"}).(*timestampedHMACVerifier)
+			v.replay.now = func() time.Time { return test.now }
+
+			header := http.Header{}
+			if test.header != "" {
+				header.Set("Stripe-Signature", test.header)
+			}
+			err := v.verify(header, []byte(body))
+			if test.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestSlackSignature(t *testing.T) {
+	body := "token=abc&team_id=T1"
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	validSig := "v0=" + computeHMACSHA256("slack-secret", "v0:"+ts+":"+body)
+
+	tests := []struct {
+		desc      string
+		signature string
+		timestamp string
+		err       error
+	}{
+		{
+			desc:      "valid signature",
+			signature: validSig,
+			timestamp: ts,
+		},
+		{
+			desc:      "missing signature",
+			timestamp: ts,
+			err:       errMissingSignatureHeader,
+		},
+		{
+			desc:      "missing timestamp",
+			signature: validSig,
+			err:       errMissingSignatureTimestamp,
+		},
+		{
+			desc:      "invalid prefix",
+			signature: "v1=" + validSig[3:],
+			timestamp: ts,
+			err:       errInvalidSignaturePrefix,
+		},
+		{
+			desc:      "tampered timestamp",
+			signature: validSig,
+			timestamp: strconv.FormatInt(now.Unix()-10, 10),
+			err:       errSignatureMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := newSignatureVerifier(SignatureConfig{Scheme: "slack", Secret: "slack-secret", TimestampHeader: "X-Timestamp"}).(*timestampedHMACVerifier)
+			v.replay.now = func() time.Time { return now }
+
+			header := http.Header{}
+			if test.signature != "" {
+				header.Set("X-Slack-Signature", test.signature)
+			}
+			if test.timestamp != "" {
+				header.Set("X-Timestamp", test.timestamp)
+			}
+			err := v.verify(header, []byte(body))
+			if test.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestSignatureReplayed(t *testing.T) {
+	body := `{"type": "charge.succeeded"}`
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		desc      string
+		cfg       SignatureConfig
+		signature string
+		header    func(signature string) http.Header
+	}{
+		{
+			desc:      "stripe",
+			cfg:       SignatureConfig{Scheme: "stripe", Secret: "stripe-secret"},
+			signature: computeHMACSHA256("stripe-secret", ts+"."+body),
+			header: func(signature string) http.Header {
+				return http.Header{"Stripe-Signature": {"t=" + ts + ",v1=" + signature}}
+			},
+		},
+		{
+			desc:      "slack",
+			cfg:       SignatureConfig{Scheme: "slack", Secret: "slack-secret"},
+			signature: computeHMACSHA256("slack-secret", "v0:"+ts+":"+body),
+			header: func(signature string) http.Header {
+				return http.Header{
+					"X-Slack-Signature":         {"v0=" + signature},
+					"X-Slack-Request-Timestamp": {ts},
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			v := newSignatureVerifier(test.cfg).(*timestampedHMACVerifier)
+			v.replay.now = func() time.Time { return now }
+
+			require.NoError(t, v.verify(test.header(test.signature), []byte(body)))
+			require.ErrorIs(t, v.verify(test.header(test.signature), []byte(body)), errSignatureReplayed)
+			// Hex decoding ignores the case of the digits, a re-cased signature is the same signature.
+			require.ErrorIs(t, v.verify(test.header(strings.ToUpper(test.signature)), []byte(body)), errSignatureReplayed)
+		})
+	}
+}
+
+func TestReplayGuard(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := newReplayGuard(time.Minute)
+	g.now = func() time.Time { return now }
+
+	require.NoError(t, g.check(now, "sig1"))
+	require.NoError(t, g.check(now.Add(-30*time.Second), "sig2"))
+	require.ErrorIs(t, g.check(now, "sig1"), errSignatureReplayed)
+	require.ErrorIs(t, g.check(now.Add(-2*time.Minute), "sig3"), errSignatureOutsideTolerance)
+
+	// Signatures are forgotten once their timestamp leaves the window
+	now = now.Add(45 * time.Second)
+	require.NoError(t, g.check(now, "sig3"))
+	require.Len(t, g.seen, 2)
+	require.Contains(t, g.seen, "sig1")
+	require.Contains(t, g.seen, "sig3")
+}